@@ -32,7 +32,9 @@ func main() {
 
 	// Initialize app with JWT configuration
 	application := app.New(pool, queries, cfg.JWTSecret, cfg.AccessTokenExpiry, cfg.RefreshTokenExpiry)
+	application.StartEventStreams(ctx)
 
+	// Event streams clear WriteTimeout for their own connection
 	server := &http.Server{
 		Addr:         ":8080",
 		Handler:      application.Router,
@@ -32,14 +32,37 @@ func main() {
 	expenseRepo := repository.NewExpenseRepository(pool, queries)
 	expenseCategoryRepo := repository.NewExpenseCategoryRepository(pool)
 	groupActivityRepo := repository.NewGroupActivityRepository(pool)
+	budgetRepo := repository.NewBudgetRepository(queries)
 
 	// Initialize dependencies for auth cleanup
 	userRepo := repository.NewUserRepository(queries)
-	sessionRepo := repository.NewSessionRepository(queries)
+	sessionRepo := repository.NewSessionRepository(pool, queries)
 	pendingUserRepo := repository.NewPendingUserRepository(pool, queries)
+	userTokenRepo := repository.NewUserTokenRepository(queries)
+	emailOutboxRepo := repository.NewEmailOutboxRepository(queries)
+	rateLimitRepo := repository.NewRateLimitRepository(queries)
+	twoFactorRepo := repository.NewTwoFactorRepository(pool, queries)
 
-	groupActivityService := service.NewGroupActivityService(groupActivityRepo)
-	expenseService := service.NewExpenseService(expenseRepo, expenseCategoryRepo, groupActivityService, userRepo, pendingUserRepo)
+	// Initialize dependencies for webhooks and notifications
+	groupRepo := repository.NewGroupRepository(pool, queries)
+	webhookRepo := repository.NewWebhookRepository(queries)
+	notificationRepo := repository.NewNotificationRepository(queries)
+
+	// Initialize dependencies for debt reminders
+	balanceRepo := repository.NewBalanceRepository(queries)
+	debtReminderRepo := repository.NewDebtReminderRepository(pool, queries)
+
+	rateProvider, err := service.ParseStaticRates(os.Getenv("EXCHANGE_RATES"))
+	if err != nil {
+		log.Fatalf("invalid EXCHANGE_RATES: %v", err)
+	}
+
+	webhookClient := service.NewWebhookHTTPClient(os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") == "true")
+	webhookService := service.NewWebhookService(webhookRepo, groupRepo, webhookClient)
+	notificationService := service.NewNotificationService(notificationRepo)
+	groupActivityService := service.NewGroupActivityService(groupActivityRepo, webhookService, notificationService)
+	budgetService := service.NewBudgetService(budgetRepo, groupRepo, expenseCategoryRepo, groupActivityService)
+	expenseService := service.NewExpenseService(expenseRepo, expenseCategoryRepo, groupActivityService, userRepo, pendingUserRepo, rateProvider, budgetService)
 	recurringExpenseService := service.NewRecurringExpenseService(recurringExpenseRepo, expenseService)
 	userService := service.NewUserService(userRepo)
 
@@ -50,18 +73,41 @@ func main() {
 	}
 
 	jwtService := service.NewJWTService(jwtSecret, 168*3600*1e9, 720*3600*1e9) // 7 days, 30 days
-	authService := service.NewAuthService(userService, sessionRepo, jwtService, 168*3600*1e9, 720*3600*1e9)
+	twoFactorService := service.NewTwoFactorService(twoFactorRepo, userRepo)
+	authService := service.NewAuthService(userService, twoFactorService, sessionRepo, jwtService, 168*3600*1e9, 720*3600*1e9)
+
+	smtpConfig, err := service.SMTPConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid mail config: %v", err)
+	}
+	mailer := service.NewMailer(smtpConfig)
+	emailService := service.NewEmailService(emailOutboxRepo, mailer)
+	accountService := service.NewAccountService(userRepo, userTokenRepo, authService, emailService, mailer, os.Getenv("APP_BASE_URL"))
+	balanceService := service.NewBalanceService(balanceRepo)
+	debtReminderService := service.NewDebtReminderService(debtReminderRepo, groupRepo, balanceService, emailService, os.Getenv("APP_BASE_URL"))
 
 	// Initialize and start workers
 	recurringExpenseGen := job.NewRecurringExpenseGenerator(recurringExpenseService)
 	recurringExpenseGen.Start(ctx)
 
-	authCleanup := job.NewAuthCleanup(authService)
+	authCleanup := job.NewAuthCleanup(authService, accountService, rateLimitRepo)
 	authCleanup.Start(ctx)
 
+	emailDispatcher := job.NewEmailDispatcher(emailService)
+	emailDispatcher.Start(ctx)
+
+	webhookDispatcher := job.NewWebhookDispatcher(webhookService)
+	webhookDispatcher.Start(ctx)
+
+	debtReminderJob := job.NewDebtReminderJob(debtReminderService)
+	debtReminderJob.Start(ctx)
+
 	log.Println("Workers started:")
 	log.Println("  - Recurring expense generator (daily at 2 AM)")
 	log.Println("  - Auth cleanup (hourly)")
+	log.Println("  - Email dispatcher (every 15s)")
+	log.Println("  - Webhook dispatcher (every 10s)")
+	log.Println("  - Debt reminders (hourly)")
 
 	// Graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -71,5 +117,8 @@ func main() {
 	log.Println("Shutting down workers...")
 	recurringExpenseGen.Stop()
 	authCleanup.Stop()
+	emailDispatcher.Stop()
+	webhookDispatcher.Stop()
+	debtReminderJob.Stop()
 	log.Println("Workers stopped")
 }
@@ -1,14 +1,18 @@
 package app
 
 import (
+	"context"
+	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/router"
 	"github.com/dhruvsaxena1998/splitplus/internal/repository"
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
@@ -18,38 +22,65 @@ type App struct {
 	Router http.Handler
 
 	// repositories
-	userRepository             repository.UserRepository
-	sessionRepository          repository.SessionRepository
-	friendRepository           repository.FriendRepository
-	groupRepository            repository.GroupRepository
-	expenseRepository          repository.ExpenseRepository
-	expenseCategoryRepository  repository.ExpenseCategoryRepository
-	expenseCommentRepository   repository.ExpenseCommentRepository
-	groupActivityRepository    repository.GroupActivityRepository
-	balanceRepository          repository.BalanceRepository
-	settlementRepository       repository.SettlementRepository
-	recurringExpenseRepository repository.RecurringExpenseRepository
-	pendingUserRepository      repository.PendingUserRepository
-	groupInvitationRepository  repository.GroupInvitationRepository
-	themeRepository            repository.ThemeRepository
+	userRepository                repository.UserRepository
+	sessionRepository             repository.SessionRepository
+	friendRepository              repository.FriendRepository
+	groupRepository               repository.GroupRepository
+	expenseRepository             repository.ExpenseRepository
+	expenseCategoryRepository     repository.ExpenseCategoryRepository
+	expenseCommentRepository      repository.ExpenseCommentRepository
+	groupActivityRepository       repository.GroupActivityRepository
+	balanceRepository             repository.BalanceRepository
+	settlementRepository          repository.SettlementRepository
+	recurringExpenseRepository    repository.RecurringExpenseRepository
+	pendingUserRepository         repository.PendingUserRepository
+	groupInvitationRepository     repository.GroupInvitationRepository
+	themeRepository               repository.ThemeRepository
+	expenseAttachmentRepository   repository.ExpenseAttachmentRepository
+	userTokenRepository           repository.UserTokenRepository
+	emailOutboxRepository         repository.EmailOutboxRepository
+	paymentMethodRepository       repository.PaymentMethodRepository
+	rateLimitRepository           repository.RateLimitRepository
+	identityRepository            repository.IdentityRepository
+	twoFactorRepository           repository.TwoFactorRepository
+	personalAccessTokenRepository repository.PersonalAccessTokenRepository
+	webhookRepository             repository.WebhookRepository
+	activityStreamRepository      repository.ActivityStreamRepository
+	notificationRepository        repository.NotificationRepository
+	debtReminderRepository        repository.DebtReminderRepository
+	budgetRepository              repository.BudgetRepository
 
 	// services
-	userService             service.UserService
-	jwtService              service.JWTService
-	authService             service.AuthService
-	friendService           service.FriendService
-	friendExpenseService    service.FriendExpenseService
-	friendSettlementService service.FriendSettlementService
-	groupService            service.GroupService
-	expenseService          service.ExpenseService
-	expenseCategoryService  service.ExpenseCategoryService
-	expenseCommentService   service.ExpenseCommentService
-	groupActivityService    service.GroupActivityService
-	balanceService          service.BalanceService
-	settlementService       service.SettlementService
-	recurringExpenseService service.RecurringExpenseService
-	groupInvitationService  service.GroupInvitationService
-	themeService            service.ThemeService
+	userService                service.UserService
+	jwtService                 service.JWTService
+	authService                service.AuthService
+	twoFactorService           service.TwoFactorService
+	personalAccessTokenService service.PersonalAccessTokenService
+	accountService             service.AccountService
+	oidcService                service.OIDCService
+	emailService               service.EmailService
+	friendService              service.FriendService
+	friendExpenseService       service.FriendExpenseService
+	friendSettlementService    service.FriendSettlementService
+	groupService               service.GroupService
+	expenseService             service.ExpenseService
+	expenseCategoryService     service.ExpenseCategoryService
+	expenseCommentService      service.ExpenseCommentService
+	groupActivityService       service.GroupActivityService
+	balanceService             service.BalanceService
+	settlementService          service.SettlementService
+	recurringExpenseService    service.RecurringExpenseService
+	groupInvitationService     service.GroupInvitationService
+	themeService               service.ThemeService
+	exportService              service.ExportService
+	expenseAttachmentService   service.ExpenseAttachmentService
+	exchangeRateProvider       service.ExchangeRateProvider
+	paymentMethodService       service.PaymentMethodService
+	webhookService             service.WebhookService
+	notificationService        service.NotificationService
+	eventStreamService         service.EventStreamService
+	debtReminderService        service.DebtReminderService
+	budgetService              service.BudgetService
 }
 
 func New(pool *pgxpool.Pool, queries *sqlc.Queries, jwtSecret string, accessTokenExpiry, refreshTokenExpiry time.Duration) *App {
@@ -57,7 +88,7 @@ func New(pool *pgxpool.Pool, queries *sqlc.Queries, jwtSecret string, accessToke
 
 	// initialize repositories
 	app.userRepository = repository.NewUserRepository(queries)
-	app.sessionRepository = repository.NewSessionRepository(queries)
+	app.sessionRepository = repository.NewSessionRepository(pool, queries)
 	app.friendRepository = repository.NewFriendRepository(queries)
 	app.groupRepository = repository.NewGroupRepository(pool, queries)
 	app.expenseRepository = repository.NewExpenseRepository(pool, queries)
@@ -70,40 +101,129 @@ func New(pool *pgxpool.Pool, queries *sqlc.Queries, jwtSecret string, accessToke
 	app.pendingUserRepository = repository.NewPendingUserRepository(pool, queries)
 	app.groupInvitationRepository = repository.NewGroupInvitationRepository(pool, queries)
 	app.themeRepository = repository.NewThemeRepository(queries)
+	app.expenseAttachmentRepository = repository.NewExpenseAttachmentRepository(queries)
+	app.userTokenRepository = repository.NewUserTokenRepository(queries)
+	app.emailOutboxRepository = repository.NewEmailOutboxRepository(queries)
+	app.paymentMethodRepository = repository.NewPaymentMethodRepository(queries)
+	app.rateLimitRepository = repository.NewRateLimitRepository(queries)
+	app.identityRepository = repository.NewIdentityRepository(queries)
+	app.twoFactorRepository = repository.NewTwoFactorRepository(pool, queries)
+	app.personalAccessTokenRepository = repository.NewPersonalAccessTokenRepository(queries)
+	app.webhookRepository = repository.NewWebhookRepository(queries)
+	app.activityStreamRepository = repository.NewActivityStreamRepository(pool)
+	app.notificationRepository = repository.NewNotificationRepository(queries)
+	app.debtReminderRepository = repository.NewDebtReminderRepository(pool, queries)
+	app.budgetRepository = repository.NewBudgetRepository(queries)
+
+	// Exchange rates for foreign-currency expenses, e.g. EXCHANGE_RATES="EUR/USD=1.08,GBP/USD=1.27"
+	rateProvider, err := service.ParseStaticRates(os.Getenv("EXCHANGE_RATES"))
+	if err != nil {
+		log.Fatalf("invalid EXCHANGE_RATES: %v", err)
+	}
+	app.exchangeRateProvider = rateProvider
+
+	// Expense attachments live on local disk under ATTACHMENT_STORAGE_DIR
+	attachmentDir := os.Getenv("ATTACHMENT_STORAGE_DIR")
+	if attachmentDir == "" {
+		attachmentDir = "./data/attachments"
+	}
+	attachmentStorage, err := service.NewLocalAttachmentStorage(attachmentDir)
+	if err != nil {
+		log.Fatalf("invalid ATTACHMENT_STORAGE_DIR: %v", err)
+	}
+	var attachmentMaxBytes int64
+	if v := os.Getenv("ATTACHMENT_MAX_BYTES"); v != "" {
+		attachmentMaxBytes, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			log.Fatalf("invalid ATTACHMENT_MAX_BYTES: %v", err)
+		}
+	}
+
+	// Email goes through SMTP_HOST; without it messages are only logged
+	smtpConfig, err := service.SMTPConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid mail config: %v", err)
+	}
+	mailer := service.NewMailer(smtpConfig)
+	appBaseURL := os.Getenv("APP_BASE_URL")
+	if appBaseURL == "" {
+		appBaseURL = "http://localhost:3000"
+	}
+
+	// OpenID Connect providers for social sign-in, e.g. OIDC_PROVIDERS=google
+	oidcProviders, err := service.OIDCProvidersFromEnv()
+	if err != nil {
+		log.Fatalf("invalid OIDC config: %v", err)
+	}
+
+	// Rate limit counters live in memory unless RATE_LIMIT_STORE=postgres, which
+	// shares them between replicas
+	var rateLimitStore middleware.RateLimitStore
+	switch os.Getenv("RATE_LIMIT_STORE") {
+	case "", "memory":
+		rateLimitStore = middleware.NewMemoryRateLimitStore()
+	case "postgres":
+		rateLimitStore = middleware.NewPostgresRateLimitStore(app.rateLimitRepository)
+	default:
+		log.Fatalf("invalid RATE_LIMIT_STORE: %q", os.Getenv("RATE_LIMIT_STORE"))
+	}
+
+	// Webhook receivers on private networks are refused unless
+	// WEBHOOK_ALLOW_PRIVATE_NETWORKS=true, which local development needs
+	webhookClient := service.NewWebhookHTTPClient(os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") == "true")
 
 	// initialize services
-	app.groupActivityService = service.NewGroupActivityService(app.groupActivityRepository) // Initialize early for dependencies
+	app.webhookService = service.NewWebhookService(app.webhookRepository, app.groupRepository, webhookClient)
+	app.notificationService = service.NewNotificationService(app.notificationRepository)
+	app.groupActivityService = service.NewGroupActivityService(app.groupActivityRepository, app.webhookService, app.notificationService) // Initialize early for dependencies
 
 	app.userService = service.NewUserService(app.userRepository)
 	app.jwtService = service.NewJWTService(jwtSecret, accessTokenExpiry, refreshTokenExpiry)
-	app.authService = service.NewAuthService(app.userService, app.sessionRepository, app.jwtService, accessTokenExpiry, refreshTokenExpiry)
+	app.twoFactorService = service.NewTwoFactorService(app.twoFactorRepository, app.userRepository)
+	app.personalAccessTokenService = service.NewPersonalAccessTokenService(app.personalAccessTokenRepository)
+	app.authService = service.NewAuthService(app.userService, app.twoFactorService, app.sessionRepository, app.jwtService, accessTokenExpiry, refreshTokenExpiry)
+	app.emailService = service.NewEmailService(app.emailOutboxRepository, mailer)
+	app.accountService = service.NewAccountService(app.userRepository, app.userTokenRepository, app.authService, app.emailService, mailer, appBaseURL)
+	app.oidcService = service.NewOIDCService(oidcProviders, app.identityRepository, app.userRepository, app.userService, app.authService)
 	app.friendService = service.NewFriendService(app.friendRepository)
 	app.friendExpenseService = service.NewFriendExpenseService(app.expenseRepository, app.friendRepository)
 	app.friendSettlementService = service.NewFriendSettlementService(app.settlementRepository, app.friendRepository)
 	app.groupService = service.NewGroupService(app.groupRepository, app.groupInvitationRepository, app.groupActivityService)
 	app.expenseCategoryService = service.NewExpenseCategoryService(app.expenseCategoryRepository)
-	app.expenseService = service.NewExpenseService(app.expenseRepository, app.expenseCategoryRepository, app.groupActivityService, app.userRepository, app.pendingUserRepository)
+	app.budgetService = service.NewBudgetService(app.budgetRepository, app.groupRepository, app.expenseCategoryRepository, app.groupActivityService)
+	app.expenseService = service.NewExpenseService(app.expenseRepository, app.expenseCategoryRepository, app.groupActivityService, app.userRepository, app.pendingUserRepository, app.exchangeRateProvider, app.budgetService)
 	app.expenseCommentService = service.NewExpenseCommentService(app.expenseCommentRepository, app.expenseService, app.groupActivityService)
 	app.balanceService = service.NewBalanceService(app.balanceRepository)
 	app.settlementService = service.NewSettlementService(app.settlementRepository, app.groupActivityService)
+	app.paymentMethodService = service.NewPaymentMethodService(app.paymentMethodRepository, app.balanceService, app.settlementService)
+	app.debtReminderService = service.NewDebtReminderService(app.debtReminderRepository, app.groupRepository, app.balanceService, app.emailService, appBaseURL)
 	app.recurringExpenseService = service.NewRecurringExpenseService(app.recurringExpenseRepository, app.expenseService)
-	app.groupInvitationService = service.NewGroupInvitationService(app.groupInvitationRepository, app.pendingUserRepository, app.groupRepository, app.userRepository, app.userService)
+	app.groupInvitationService = service.NewGroupInvitationService(app.groupInvitationRepository, app.pendingUserRepository, app.groupRepository, app.userRepository, app.userService, app.groupActivityService, app.emailService, appBaseURL)
 	app.themeService = service.NewThemeService(app.themeRepository)
+	app.exportService = service.NewExportService(app.expenseRepository, app.settlementRepository, app.expenseCategoryRepository, app.expenseCommentRepository)
+	app.eventStreamService = service.NewEventStreamService(app.activityStreamRepository, app.groupRepository)
+	app.expenseAttachmentService = service.NewExpenseAttachmentService(app.expenseAttachmentRepository, app.expenseService, app.groupActivityService, attachmentStorage, attachmentMaxBytes)
 
 	// initialize router
 	app.Router = router.New(
-		router.WithAuthRoutes(app.authService, app.jwtService, app.sessionRepository),
-		router.WithUserRoutes(app.userService, app.themeService, app.jwtService, app.sessionRepository),
+		router.WithAccessTokenAuth(app.personalAccessTokenService),
+		router.WithAuthRoutes(app.authService, app.accountService, app.oidcService, app.jwtService, app.sessionRepository, rateLimitStore),
+		router.WithUserRoutes(app.userService, app.accountService, app.authService, app.twoFactorService, app.personalAccessTokenService, app.themeService, app.paymentMethodService, app.notificationService, app.jwtService, app.sessionRepository, rateLimitStore),
 		router.WithThemeRoutes(app.themeService, app.jwtService, app.sessionRepository),
-		router.WithFriendRoutes(app.friendService, app.friendExpenseService, app.friendSettlementService, app.jwtService, app.sessionRepository),
-		router.WithGroupRoutes(app.groupService, app.groupInvitationService, app.jwtService, app.sessionRepository),
+		router.WithFriendRoutes(app.friendService, app.friendExpenseService, app.friendSettlementService, app.balanceService, app.jwtService, app.sessionRepository),
+		router.WithGroupRoutes(app.groupService, app.groupInvitationService, app.jwtService, app.sessionRepository, rateLimitStore),
 		router.WithExpenseRoutes(app.expenseService, app.jwtService, app.sessionRepository),
 		router.WithExpenseCategoryRoutes(app.expenseCategoryService, app.jwtService, app.sessionRepository),
 		router.WithExpenseCommentRoutes(app.expenseCommentService, app.jwtService, app.sessionRepository),
 		router.WithGroupActivityRoutes(app.groupActivityService, app.jwtService, app.sessionRepository),
-		router.WithBalanceRoutes(app.balanceService, app.jwtService, app.sessionRepository),
+		router.WithBalanceRoutes(app.balanceService, app.paymentMethodService, app.debtReminderService, app.jwtService, app.sessionRepository, rateLimitStore),
 		router.WithSettlementRoutes(app.settlementService, app.jwtService, app.sessionRepository),
 		router.WithRecurringExpenseRoutes(app.recurringExpenseService, app.jwtService, app.sessionRepository),
+		router.WithExportRoutes(app.exportService, app.jwtService, app.sessionRepository),
+		router.WithExpenseAttachmentRoutes(app.expenseAttachmentService, app.jwtService, app.sessionRepository),
+		router.WithWebhookRoutes(app.webhookService, app.jwtService, app.sessionRepository),
+		router.WithBudgetRoutes(app.budgetService, app.jwtService, app.sessionRepository),
+		router.WithEventStreamRoutes(app.eventStreamService, app.jwtService, app.sessionRepository, app.personalAccessTokenRepository),
 	)
 
 	// debug - dev only
@@ -113,3 +233,9 @@ func New(pool *pgxpool.Pool, queries *sqlc.Queries, jwtSecret string, accessToke
 
 	return app
 }
+
+// StartEventStreams listens for new group activities and pushes them to
+// connected event stream clients until ctx ends.
+func (app *App) StartEventStreams(ctx context.Context) {
+	go app.eventStreamService.Run(ctx)
+}
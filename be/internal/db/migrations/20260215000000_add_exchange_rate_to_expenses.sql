@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Snapshot of the rate used to convert an expense into its group's currency
+ALTER TABLE expenses
+ADD COLUMN exchange_rate DECIMAL(18, 8) NOT NULL DEFAULT 1 CHECK (exchange_rate > 0);
+
+COMMENT ON COLUMN expenses.exchange_rate IS
+  'Rate used to convert amount from currency_code into the group currency when the expense was recorded. 1 when both currencies match.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE expenses
+DROP COLUMN IF EXISTS exchange_rate;
+-- +goose StatementEnd
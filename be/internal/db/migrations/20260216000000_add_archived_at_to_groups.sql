@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Archived groups stay readable but are treated as finished (e.g. a past trip)
+ALTER TABLE groups
+ADD COLUMN archived_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN groups.archived_at IS
+  'When the group was archived. NULL for active groups.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE groups
+DROP COLUMN IF EXISTS archived_at;
+-- +goose StatementEnd
@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Line items for itemized (receipt) expenses. Tax, tip and discount rows are
+-- stored alongside the items and spread proportionally across consumers.
+CREATE TABLE expense_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    expense_id UUID NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL DEFAULT 'item' CHECK (kind IN ('item', 'tax', 'tip', 'discount')),
+    name TEXT NOT NULL DEFAULT '',
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount >= 0),
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+COMMENT ON COLUMN expense_items.kind IS
+  'item rows carry consumers; tax, tip and discount rows are distributed in proportion to each consumer''s item subtotal.';
+
+CREATE INDEX idx_expense_items_expense_id ON expense_items(expense_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE expense_item_consumers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    expense_item_id UUID NOT NULL REFERENCES expense_items(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id),
+    pending_user_id UUID REFERENCES pending_users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (num_nonnulls(user_id, pending_user_id) = 1)
+);
+
+CREATE INDEX idx_expense_item_consumers_item_id ON expense_item_consumers(expense_item_id);
+
+-- Splits derived from line items are recorded with their own type
+ALTER TABLE expense_split
+DROP CONSTRAINT IF EXISTS expense_split_split_type_check;
+
+ALTER TABLE expense_split
+ADD CONSTRAINT expense_split_split_type_check
+CHECK (split_type IN ('equal', 'percentage', 'shares', 'fixed', 'custom', 'itemized'));
+
+COMMENT ON COLUMN expense_split.share_value IS
+  'For percentage splits: the percentage value (0-100). For shares splits: the share count. For itemized splits: the item subtotal before tax, tip and discount. NULL for equal/fixed/custom.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE expense_split
+DROP CONSTRAINT IF EXISTS expense_split_split_type_check;
+
+ALTER TABLE expense_split
+ADD CONSTRAINT expense_split_split_type_check
+CHECK (split_type IN ('equal', 'percentage', 'shares', 'fixed', 'custom'));
+
+COMMENT ON COLUMN expense_split.share_value IS
+  'For percentage splits: the percentage value (0-100). For shares splits: the share count. NULL for equal/fixed/custom.';
+
+DROP TABLE IF EXISTS expense_item_consumers;
+DROP TABLE IF EXISTS expense_items;
+-- +goose StatementEnd
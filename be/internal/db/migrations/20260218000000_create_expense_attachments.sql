@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Receipts and other files attached to an expense. The bytes live in the
+-- configured storage backend; this table only keeps the metadata.
+CREATE TABLE expense_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    expense_id UUID NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+    uploaded_by UUID NOT NULL REFERENCES users(id),
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    checksum_sha256 TEXT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN expense_attachments.content_type IS
+  'Sniffed from the uploaded bytes, never taken from the client.';
+COMMENT ON COLUMN expense_attachments.storage_key IS
+  'Backend-relative key of the stored file.';
+
+CREATE INDEX idx_expense_attachments_expense_id ON expense_attachments(expense_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS expense_attachments;
+-- +goose StatementEnd
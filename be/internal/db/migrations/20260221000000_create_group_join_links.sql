@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reusable links that let anyone holding them join a group as a member,
+-- unlike group_invitations which are tied to one email address.
+CREATE TABLE group_join_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    max_uses INT CHECK (max_uses > 0),
+    use_count INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN group_join_links.max_uses IS
+  'NULL means the link can be used any number of times until it expires.';
+
+CREATE INDEX idx_group_join_links_group_id ON group_join_links(group_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS group_join_links;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Settlements move pending -> completed once the payee confirms the payment,
+-- or pending -> disputed when the payee says it never arrived.
+ALTER TABLE settlements
+DROP CONSTRAINT IF EXISTS settlements_status_check;
+
+ALTER TABLE settlements
+ADD CONSTRAINT settlements_status_check CHECK (status IN ('pending', 'completed', 'disputed', 'cancelled'));
+
+ALTER TABLE settlements
+ADD COLUMN confirmed_at TIMESTAMPTZ,
+ADD COLUMN disputed_at TIMESTAMPTZ,
+ADD COLUMN cancelled_at TIMESTAMPTZ,
+ADD COLUMN dispute_reason TEXT;
+
+-- Existing completed settlements count as confirmed
+UPDATE settlements
+SET confirmed_at = COALESCE(paid_at, updated_at)
+WHERE status = 'completed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE settlements
+SET status = 'pending'
+WHERE status = 'disputed';
+
+ALTER TABLE settlements
+DROP COLUMN IF EXISTS dispute_reason,
+DROP COLUMN IF EXISTS cancelled_at,
+DROP COLUMN IF EXISTS disputed_at,
+DROP COLUMN IF EXISTS confirmed_at;
+
+ALTER TABLE settlements
+DROP CONSTRAINT IF EXISTS settlements_status_check;
+
+ALTER TABLE settlements
+ADD CONSTRAINT settlements_status_check CHECK (status IN ('pending', 'completed', 'cancelled'));
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Payment handles a user can receive money on. At most one handle per provider;
+-- lower priority values are preferred when generating payment links.
+CREATE TABLE user_payment_methods (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL CHECK (provider IN ('upi', 'paypal', 'venmo', 'iban')),
+    handle TEXT NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_user_payment_methods_user_provider ON user_payment_methods(user_id, provider);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_payment_methods;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Refresh tokens are now stored as SHA-256 hex digests instead of in the clear.
+UPDATE sessions
+SET refresh_token_hash = encode(sha256(convert_to(refresh_token_hash, 'UTF8')), 'hex');
+
+-- Every refresh token a session has rotated away from. Presenting one of these
+-- again means the token was copied, so the whole session is revoked.
+CREATE TABLE refresh_token_history (
+    token_hash TEXT PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rotated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_refresh_token_history_session_id ON refresh_token_history(session_id);
+
+-- Audit trail for account security signals. session_id is kept without a
+-- foreign key so the event outlives the session it revoked.
+CREATE TABLE security_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id UUID,
+    event_type TEXT NOT NULL CHECK (event_type IN ('refresh_token_reuse')),
+    ip_address TEXT,
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_security_events_user_id ON security_events(user_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS security_events;
+DROP TABLE IF EXISTS refresh_token_history;
+-- Hashed tokens can't be turned back into the raw ones, so sign everyone out.
+DELETE FROM sessions;
+-- +goose StatementEnd
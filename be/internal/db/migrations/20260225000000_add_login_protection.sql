@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Failed sign-in attempts per account. The row is removed on a successful
+-- sign-in; locked_until is set once enough failures pile up.
+CREATE TABLE user_login_attempts (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    failed_attempts INT NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMPTZ
+);
+
+-- Fixed-window request counters shared by every API replica. key combines the
+-- rate limit policy with the client it applies to (IP or user).
+CREATE TABLE rate_limit_counters (
+    key TEXT PRIMARY KEY,
+    count INT NOT NULL DEFAULT 0,
+    window_ends_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_rate_limit_counters_window_ends_at ON rate_limit_counters(window_ends_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rate_limit_counters;
+DROP TABLE IF EXISTS user_login_attempts;
+-- +goose StatementEnd
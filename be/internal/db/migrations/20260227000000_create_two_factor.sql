@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- TOTP (RFC 6238) two-factor authentication. A row without enabled_at is an
+-- enrollment waiting for its first code. last_used_step is the last 30 second
+-- time step a code was accepted for, so a code can't be used twice.
+-- failed_attempts counts wrong codes given to change the settings while signed
+-- in; locked_until is set once enough of them pile up.
+CREATE TABLE user_two_factor (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    enabled_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ
+);
+
+-- Single use recovery codes for when the authenticator is lost. Only the
+-- SHA-256 hash of each code is stored.
+CREATE TABLE user_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_user_recovery_codes_user_hash ON user_recovery_codes(user_id, code_hash);
+
+-- Sign-ins that passed the password (or provider) step and wait for a second
+-- factor. Only the SHA-256 hash of the challenge token is stored.
+CREATE TABLE two_factor_challenges (
+    token_hash TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_two_factor_challenges_expires_at ON two_factor_challenges(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS two_factor_challenges;
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_two_factor;
+-- +goose StatementEnd
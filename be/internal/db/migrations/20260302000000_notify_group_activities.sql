@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Announces every new activity on the group_activities channel so each API
+-- replica can push it to its event stream subscribers. The payload only names
+-- the activity; listeners load the row themselves, which keeps it well under
+-- the NOTIFY size limit.
+CREATE OR REPLACE FUNCTION notify_group_activity() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('group_activities', json_build_object('id', NEW.id, 'group_id', NEW.group_id)::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER group_activities_notify
+AFTER INSERT ON group_activities
+FOR EACH ROW EXECUTE FUNCTION notify_group_activity();
+
+-- Resuming a stream reads activities in (created_at, id) order
+CREATE INDEX idx_group_activities_group_stream ON group_activities(group_id, created_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_group_activities_group_stream;
+DROP TRIGGER IF EXISTS group_activities_notify ON group_activities;
+DROP FUNCTION IF EXISTS notify_group_activity();
+-- +goose StatementEnd
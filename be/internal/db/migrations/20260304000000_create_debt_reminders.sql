@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- One row per member who owes or owed money in a group: since when they have
+-- owed it, NULL once settled up, and when they were last reminded.
+CREATE TABLE debt_reminders (
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    owing_since TIMESTAMPTZ,
+    last_reminded_at TIMESTAMPTZ,
+    PRIMARY KEY (group_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS debt_reminders;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Spending limits for a group, or for one of its categories. Weekly budgets
+-- run Monday to Sunday and monthly ones by calendar month; custom budgets cover
+-- start_date to end_date once. Thresholds are percentages of amount at which
+-- members are alerted.
+CREATE TABLE group_budgets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    category_id UUID REFERENCES expense_categories(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    period TEXT NOT NULL CHECK (period IN ('weekly', 'monthly', 'custom')),
+    start_date DATE,
+    end_date DATE,
+    thresholds INT[] NOT NULL DEFAULT '{80,100}',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (period <> 'custom' OR (start_date IS NOT NULL AND end_date IS NOT NULL AND end_date >= start_date))
+);
+
+COMMENT ON COLUMN group_budgets.category_id IS
+  'The category the budget is limited to. NULL counts every expense in the group.';
+
+CREATE INDEX idx_group_budgets_group_id ON group_budgets(group_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS group_budgets;
+-- +goose StatementEnd
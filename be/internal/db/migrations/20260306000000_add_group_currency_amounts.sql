@@ -0,0 +1,70 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Converted amounts, so balances add up the same figures on both sides of an expense
+ALTER TABLE expense_payments
+ADD COLUMN amount_in_group_currency DECIMAL(18, 2);
+
+ALTER TABLE expense_split
+ADD COLUMN amount_in_group_currency DECIMAL(18, 2);
+
+COMMENT ON COLUMN expense_payments.amount_in_group_currency IS
+  'amount converted into the group currency at the expense exchange_rate. The payments of an expense sum to its converted amount rounded to cents; the largest payment absorbs the rounding remainder.';
+
+COMMENT ON COLUMN expense_split.amount_in_group_currency IS
+  'amount_owned converted into the group currency at the expense exchange_rate. The splits of an expense sum to its converted amount rounded to cents; the largest split absorbs the rounding remainder.';
+
+-- Backfill: convert every line, then move each live expense's rounding
+-- remainder onto its largest line
+UPDATE expense_payments ep
+SET amount_in_group_currency = ROUND(ep.amount * e.exchange_rate, 2)
+FROM expenses e
+WHERE e.id = ep.expense_id;
+
+UPDATE expense_payments ep
+SET amount_in_group_currency = ep.amount_in_group_currency + r.remainder
+FROM (
+    SELECT
+        ep.id,
+        ROW_NUMBER() OVER (PARTITION BY ep.expense_id ORDER BY ep.amount DESC, ep.created_at, ep.id) AS rn,
+        ROUND(e.amount * e.exchange_rate, 2)
+            - SUM(ep.amount_in_group_currency) OVER (PARTITION BY ep.expense_id) AS remainder
+    FROM expense_payments ep
+    JOIN expenses e ON e.id = ep.expense_id
+    WHERE ep.deleted_at IS NULL
+) r
+WHERE r.id = ep.id AND r.rn = 1;
+
+UPDATE expense_split es
+SET amount_in_group_currency = ROUND(es.amount_owned * e.exchange_rate, 2)
+FROM expenses e
+WHERE e.id = es.expense_id;
+
+UPDATE expense_split es
+SET amount_in_group_currency = es.amount_in_group_currency + r.remainder
+FROM (
+    SELECT
+        es.id,
+        ROW_NUMBER() OVER (PARTITION BY es.expense_id ORDER BY es.amount_owned DESC, es.created_at, es.id) AS rn,
+        ROUND(e.amount * e.exchange_rate, 2)
+            - SUM(es.amount_in_group_currency) OVER (PARTITION BY es.expense_id) AS remainder
+    FROM expense_split es
+    JOIN expenses e ON e.id = es.expense_id
+    WHERE es.deleted_at IS NULL
+) r
+WHERE r.id = es.id AND r.rn = 1;
+
+ALTER TABLE expense_payments
+ALTER COLUMN amount_in_group_currency SET NOT NULL;
+
+ALTER TABLE expense_split
+ALTER COLUMN amount_in_group_currency SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE expense_split
+DROP COLUMN IF EXISTS amount_in_group_currency;
+
+ALTER TABLE expense_payments
+DROP COLUMN IF EXISTS amount_in_group_currency;
+-- +goose StatementEnd
@@ -2,13 +2,15 @@
 -- Calculate balance for each user in a group
 -- Balance = total_paid - total_owed
 -- Positive balance means user is owed money, negative means user owes money
+-- Expense amounts are in the group currency (amount_in_group_currency)
+-- With confirmed_only, settlements still waiting for the payee to confirm are ignored
 WITH payments AS (
     SELECT
         ep.user_id,
-        SUM(ep.amount) AS total_paid
+        SUM(ep.amount_in_group_currency) AS total_paid
     FROM expense_payments ep
     JOIN expenses e ON e.id = ep.expense_id
-    WHERE e.group_id = $1
+    WHERE e.group_id = sqlc.arg('group_id')
       AND e.deleted_at IS NULL
       AND ep.deleted_at IS NULL
     GROUP BY ep.user_id
@@ -18,9 +20,9 @@ settlement_payments AS (
         s.payer_id AS user_id,
         SUM(s.amount) AS total_paid
     FROM settlements s
-    WHERE s.group_id = $1
+    WHERE s.group_id = sqlc.arg('group_id')
       AND s.type = 'group'
-      AND s.status IN ('pending', 'completed')
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
       AND s.deleted_at IS NULL
       AND s.payer_id IS NOT NULL
     GROUP BY s.payer_id
@@ -28,10 +30,10 @@ settlement_payments AS (
 splits AS (
     SELECT
         es.user_id,
-        SUM(es.amount_owned) AS total_owed
+        SUM(es.amount_in_group_currency) AS total_owed
     FROM expense_split es
     JOIN expenses e ON e.id = es.expense_id
-    WHERE e.group_id = $1
+    WHERE e.group_id = sqlc.arg('group_id')
       AND e.deleted_at IS NULL
       AND es.deleted_at IS NULL
     GROUP BY es.user_id
@@ -41,9 +43,9 @@ settlement_splits AS (
         s.payee_id AS user_id,
         SUM(s.amount) AS total_owed
     FROM settlements s
-    WHERE s.group_id = $1
+    WHERE s.group_id = sqlc.arg('group_id')
       AND s.type = 'group'
-      AND s.status IN ('pending', 'completed')
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
       AND s.deleted_at IS NULL
       AND s.payee_id IS NOT NULL
     GROUP BY s.payee_id
@@ -61,8 +63,8 @@ LEFT JOIN payments p ON p.user_id = u.id
 LEFT JOIN settlement_payments sp ON sp.user_id = u.id
 LEFT JOIN splits s ON s.user_id = u.id
 LEFT JOIN settlement_splits ss ON ss.user_id = u.id
-WHERE gm.group_id = $1 
-    AND gm.status = 'active' 
+WHERE gm.group_id = sqlc.arg('group_id') 
+    AND gm.status IN ('active', 'inactive') 
     AND gm.deleted_at IS NULL
     AND u.deleted_at IS NULL
 ORDER BY u.email;
@@ -71,14 +73,16 @@ ORDER BY u.email;
 -- Calculate balance for each user or pending user in a group
 -- Balance = total_paid - total_owed
 -- Positive balance means entity is owed money, negative means entity owes money
+-- Expense amounts are in the group currency (amount_in_group_currency)
+-- With confirmed_only, settlements still waiting for the payee to confirm are ignored
 WITH payments AS (
     SELECT
         COALESCE(ep.user_id, ep.pending_user_id) AS entity_id,
         CASE WHEN ep.user_id IS NOT NULL THEN 'user' ELSE 'pending' END AS entity_type,
-        SUM(ep.amount) AS total_paid
+        SUM(ep.amount_in_group_currency) AS total_paid
     FROM expense_payments ep
     JOIN expenses e ON e.id = ep.expense_id
-    WHERE e.group_id = $1
+    WHERE e.group_id = sqlc.arg('group_id')
       AND e.deleted_at IS NULL
       AND ep.deleted_at IS NULL
     GROUP BY COALESCE(ep.user_id, ep.pending_user_id),
@@ -90,9 +94,9 @@ settlement_payments AS (
         CASE WHEN s.payer_id IS NOT NULL THEN 'user' ELSE 'pending' END AS entity_type,
         SUM(s.amount) AS total_paid
     FROM settlements s
-    WHERE s.group_id = $1
+    WHERE s.group_id = sqlc.arg('group_id')
       AND s.type = 'group'
-      AND s.status IN ('pending', 'completed')
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
       AND s.deleted_at IS NULL
     GROUP BY COALESCE(s.payer_id, s.payer_pending_user_id),
              CASE WHEN s.payer_id IS NOT NULL THEN 'user' ELSE 'pending' END
@@ -101,10 +105,10 @@ splits AS (
     SELECT
         COALESCE(es.user_id, es.pending_user_id) AS entity_id,
         CASE WHEN es.user_id IS NOT NULL THEN 'user' ELSE 'pending' END AS entity_type,
-        SUM(es.amount_owned) AS total_owed
+        SUM(es.amount_in_group_currency) AS total_owed
     FROM expense_split es
     JOIN expenses e ON e.id = es.expense_id
-    WHERE e.group_id = $1
+    WHERE e.group_id = sqlc.arg('group_id')
       AND e.deleted_at IS NULL
       AND es.deleted_at IS NULL
     GROUP BY COALESCE(es.user_id, es.pending_user_id),
@@ -116,9 +120,9 @@ settlement_splits AS (
         CASE WHEN s.payee_id IS NOT NULL THEN 'user' ELSE 'pending' END AS entity_type,
         SUM(s.amount) AS total_owed
     FROM settlements s
-    WHERE s.group_id = $1
+    WHERE s.group_id = sqlc.arg('group_id')
       AND s.type = 'group'
-      AND s.status IN ('pending', 'completed')
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
       AND s.deleted_at IS NULL
     GROUP BY COALESCE(s.payee_id, s.payee_pending_user_id),
              CASE WHEN s.payee_id IS NOT NULL THEN 'user' ELSE 'pending' END
@@ -126,36 +130,36 @@ settlement_splits AS (
 entities AS (
     SELECT gm.user_id AS user_id, NULL::uuid AS pending_user_id, 'user' AS entity_type
     FROM group_members gm
-    WHERE gm.group_id = $1
-      AND gm.status = 'active'
+    WHERE gm.group_id = sqlc.arg('group_id')
+      AND gm.status IN ('active', 'inactive')
       AND gm.deleted_at IS NULL
     UNION
     SELECT NULL::uuid AS user_id, pu.id AS pending_user_id, 'pending' AS entity_type
     FROM pending_users pu
     JOIN expense_split es ON es.pending_user_id = pu.id AND es.deleted_at IS NULL
     JOIN expenses e ON e.id = es.expense_id AND e.deleted_at IS NULL
-    WHERE e.group_id = $1
+    WHERE e.group_id = sqlc.arg('group_id')
     UNION
     SELECT NULL::uuid AS user_id, pu.id AS pending_user_id, 'pending' AS entity_type
     FROM pending_users pu
     JOIN expense_payments ep ON ep.pending_user_id = pu.id AND ep.deleted_at IS NULL
     JOIN expenses e ON e.id = ep.expense_id AND e.deleted_at IS NULL
-    WHERE e.group_id = $1
+    WHERE e.group_id = sqlc.arg('group_id')
     UNION
     SELECT NULL::uuid AS user_id, pu.id AS pending_user_id, 'pending' AS entity_type
     FROM pending_users pu
     JOIN settlements s ON s.payer_pending_user_id = pu.id
-    WHERE s.group_id = $1
+    WHERE s.group_id = sqlc.arg('group_id')
       AND s.type = 'group'
-      AND s.status IN ('pending', 'completed')
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
       AND s.deleted_at IS NULL
     UNION
     SELECT NULL::uuid AS user_id, pu.id AS pending_user_id, 'pending' AS entity_type
     FROM pending_users pu
     JOIN settlements s ON s.payee_pending_user_id = pu.id
-    WHERE s.group_id = $1
+    WHERE s.group_id = sqlc.arg('group_id')
       AND s.type = 'group'
-      AND s.status IN ('pending', 'completed')
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
       AND s.deleted_at IS NULL
 )
 SELECT
@@ -186,13 +190,14 @@ ORDER BY COALESCE(u.email, pu.email);
 
 -- name: GetUserBalanceInGroup :one
 -- Get balance for a specific user in a group
+-- With confirmed_only, settlements still waiting for the payee to confirm are ignored
 WITH payments AS (
     SELECT
         ep.user_id,
-        SUM(ep.amount) AS total_paid
+        SUM(ep.amount_in_group_currency) AS total_paid
     FROM expense_payments ep
     JOIN expenses e ON e.id = ep.expense_id
-    WHERE e.group_id = $1
+    WHERE e.group_id = sqlc.arg('group_id')
       AND e.deleted_at IS NULL
       AND ep.deleted_at IS NULL
     GROUP BY ep.user_id
@@ -202,9 +207,9 @@ settlement_payments AS (
         s.payer_id AS user_id,
         SUM(s.amount) AS total_paid
     FROM settlements s
-    WHERE s.group_id = $1
+    WHERE s.group_id = sqlc.arg('group_id')
       AND s.type = 'group'
-      AND s.status IN ('pending', 'completed')
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
       AND s.deleted_at IS NULL
       AND s.payer_id IS NOT NULL
     GROUP BY s.payer_id
@@ -212,10 +217,10 @@ settlement_payments AS (
 splits AS (
     SELECT
         es.user_id,
-        SUM(es.amount_owned) AS total_owed
+        SUM(es.amount_in_group_currency) AS total_owed
     FROM expense_split es
     JOIN expenses e ON e.id = es.expense_id
-    WHERE e.group_id = $1
+    WHERE e.group_id = sqlc.arg('group_id')
       AND e.deleted_at IS NULL
       AND es.deleted_at IS NULL
     GROUP BY es.user_id
@@ -225,9 +230,9 @@ settlement_splits AS (
         s.payee_id AS user_id,
         SUM(s.amount) AS total_owed
     FROM settlements s
-    WHERE s.group_id = $1
+    WHERE s.group_id = sqlc.arg('group_id')
       AND s.type = 'group'
-      AND s.status IN ('pending', 'completed')
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
       AND s.deleted_at IS NULL
       AND s.payee_id IS NOT NULL
     GROUP BY s.payee_id
@@ -249,19 +254,20 @@ LEFT JOIN payments p ON p.user_id = u.id
 LEFT JOIN settlement_payments sp ON sp.user_id = u.id
 LEFT JOIN splits s ON s.user_id = u.id
 LEFT JOIN settlement_splits ss ON ss.user_id = u.id
-WHERE gm.group_id = $1 
-    AND gm.user_id = $2
-    AND gm.status = 'active' 
+WHERE gm.group_id = sqlc.arg('group_id') 
+    AND gm.user_id = sqlc.arg('user_id')
+    AND gm.status IN ('active', 'inactive') 
     AND gm.deleted_at IS NULL
     AND u.deleted_at IS NULL;
 
 -- name: GetOverallUserBalance :many
 -- Get user's balance across all groups
+-- With confirmed_only, settlements still waiting for the payee to confirm are ignored
 WITH payments AS (
     SELECT
         e.group_id,
         ep.user_id,
-        SUM(ep.amount) AS total_paid
+        SUM(ep.amount_in_group_currency) AS total_paid
     FROM expense_payments ep
     JOIN expenses e ON e.id = ep.expense_id
     WHERE e.deleted_at IS NULL
@@ -275,7 +281,7 @@ settlement_payments AS (
         SUM(s.amount) AS total_paid
     FROM settlements s
     WHERE s.type = 'group'
-      AND s.status IN ('pending', 'completed')
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
       AND s.deleted_at IS NULL
       AND s.group_id IS NOT NULL
       AND s.payer_id IS NOT NULL
@@ -285,7 +291,7 @@ splits AS (
     SELECT
         e.group_id,
         es.user_id,
-        SUM(es.amount_owned) AS total_owed
+        SUM(es.amount_in_group_currency) AS total_owed
     FROM expense_split es
     JOIN expenses e ON e.id = es.expense_id
     WHERE e.deleted_at IS NULL
@@ -299,7 +305,7 @@ settlement_splits AS (
         SUM(s.amount) AS total_owed
     FROM settlements s
     WHERE s.type = 'group'
-      AND s.status IN ('pending', 'completed')
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
       AND s.deleted_at IS NULL
       AND s.group_id IS NOT NULL
       AND s.payee_id IS NOT NULL
@@ -317,9 +323,166 @@ LEFT JOIN payments p ON p.group_id = gm.group_id AND p.user_id = gm.user_id
 LEFT JOIN settlement_payments sp ON sp.group_id = gm.group_id AND sp.user_id = gm.user_id
 LEFT JOIN splits s ON s.group_id = gm.group_id AND s.user_id = gm.user_id
 LEFT JOIN settlement_splits ss ON ss.group_id = gm.group_id AND ss.user_id = gm.user_id
-WHERE gm.user_id = $1
-    AND gm.status = 'active'
+WHERE gm.user_id = sqlc.arg('user_id')
+    AND gm.status IN ('active', 'inactive')
     AND gm.deleted_at IS NULL
     AND g.deleted_at IS NULL
 GROUP BY g.id, g.name, g.currency_code, p.total_paid, sp.total_paid, s.total_owed, ss.total_owed
 ORDER BY g.name;
+
+-- name: ListDirectFriendBalances :many
+-- Net position of a user towards each counterpart from direct friend expenses
+-- and settlements (type = 'friend', no group), per currency
+-- Only expenses shared by exactly the user and one other registered user count
+-- Positive balance means the counterpart owes the user
+-- With confirmed_only, settlements still waiting for the payee to confirm are ignored
+WITH participants AS (
+    SELECT ep.expense_id, ep.user_id
+    FROM expense_payments ep
+    WHERE ep.deleted_at IS NULL
+    UNION
+    SELECT es.expense_id, es.user_id
+    FROM expense_split es
+    WHERE es.deleted_at IS NULL
+),
+pair_expenses AS (
+    SELECT
+        e.id AS expense_id,
+        e.currency_code,
+        other.user_id AS friend_id
+    FROM expenses e
+    JOIN participants me ON me.expense_id = e.id AND me.user_id = sqlc.arg('user_id')
+    JOIN participants other ON other.expense_id = e.id AND other.user_id <> sqlc.arg('user_id')
+    WHERE e.type = 'friend'
+      AND e.group_id IS NULL
+      AND e.deleted_at IS NULL
+      AND NOT EXISTS (
+          SELECT 1
+          FROM participants p
+          WHERE p.expense_id = e.id
+            AND (p.user_id IS NULL OR p.user_id NOT IN (sqlc.arg('user_id'), other.user_id))
+      )
+),
+entries AS (
+    SELECT pe.friend_id, pe.currency_code, ep.amount
+    FROM pair_expenses pe
+    JOIN expense_payments ep ON ep.expense_id = pe.expense_id AND ep.deleted_at IS NULL
+    WHERE ep.user_id = sqlc.arg('user_id')
+    UNION ALL
+    SELECT pe.friend_id, pe.currency_code, -es.amount_owned
+    FROM pair_expenses pe
+    JOIN expense_split es ON es.expense_id = pe.expense_id AND es.deleted_at IS NULL
+    WHERE es.user_id = sqlc.arg('user_id')
+    UNION ALL
+    SELECT s.payee_id, s.currency_code, s.amount
+    FROM settlements s
+    WHERE s.type = 'friend'
+      AND s.group_id IS NULL
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
+      AND s.deleted_at IS NULL
+      AND s.payer_id = sqlc.arg('user_id')
+      AND s.payee_id IS NOT NULL
+    UNION ALL
+    SELECT s.payer_id, s.currency_code, -s.amount
+    FROM settlements s
+    WHERE s.type = 'friend'
+      AND s.group_id IS NULL
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
+      AND s.deleted_at IS NULL
+      AND s.payee_id = sqlc.arg('user_id')
+      AND s.payer_id IS NOT NULL
+)
+SELECT
+    friend_id,
+    currency_code,
+    SUM(amount)::TEXT AS balance
+FROM entries
+GROUP BY friend_id, currency_code
+HAVING SUM(amount) <> 0
+ORDER BY friend_id, currency_code;
+
+-- name: ListGroupFriendBalances :many
+-- Net position of a user towards each other registered user in every group the
+-- user belongs to, in the group currency, each expense's share rounded to cents
+-- Each expense is attributed pairwise: what one of them paid covers the other's
+-- share in proportion to the expense amount, all converted into the group currency
+-- Only group settlements between the two of them count
+-- Positive balance means the counterpart owes the user
+-- With confirmed_only, settlements still waiting for the payee to confirm are ignored
+WITH totals AS (
+    SELECT ep.expense_id, SUM(ep.amount_in_group_currency) AS amount
+    FROM expense_payments ep
+    WHERE ep.deleted_at IS NULL
+    GROUP BY ep.expense_id
+),
+paid AS (
+    SELECT ep.expense_id, ep.user_id, SUM(ep.amount_in_group_currency) AS amount
+    FROM expense_payments ep
+    WHERE ep.deleted_at IS NULL
+      AND ep.user_id IS NOT NULL
+    GROUP BY ep.expense_id, ep.user_id
+),
+owed AS (
+    SELECT es.expense_id, es.user_id, SUM(es.amount_in_group_currency) AS amount
+    FROM expense_split es
+    WHERE es.deleted_at IS NULL
+      AND es.user_id IS NOT NULL
+    GROUP BY es.expense_id, es.user_id
+),
+participants AS (
+    SELECT expense_id, user_id FROM paid
+    UNION
+    SELECT expense_id, user_id FROM owed
+),
+entries AS (
+    SELECT
+        e.group_id,
+        other.user_id AS friend_id,
+        ROUND((COALESCE(other_owed.amount, 0::DECIMAL) * COALESCE(my_paid.amount, 0::DECIMAL)
+            - COALESCE(my_owed.amount, 0::DECIMAL) * COALESCE(other_paid.amount, 0::DECIMAL))
+            / t.amount, 2) AS amount
+    FROM expenses e
+    JOIN totals t ON t.expense_id = e.id
+    JOIN participants me ON me.expense_id = e.id AND me.user_id = sqlc.arg('user_id')
+    JOIN participants other ON other.expense_id = e.id AND other.user_id <> sqlc.arg('user_id')
+    LEFT JOIN paid my_paid ON my_paid.expense_id = e.id AND my_paid.user_id = me.user_id
+    LEFT JOIN owed my_owed ON my_owed.expense_id = e.id AND my_owed.user_id = me.user_id
+    LEFT JOIN paid other_paid ON other_paid.expense_id = e.id AND other_paid.user_id = other.user_id
+    LEFT JOIN owed other_owed ON other_owed.expense_id = e.id AND other_owed.user_id = other.user_id
+    WHERE e.group_id IS NOT NULL
+      AND e.deleted_at IS NULL
+      AND t.amount <> 0
+    UNION ALL
+    SELECT s.group_id, s.payee_id, s.amount
+    FROM settlements s
+    WHERE s.type = 'group'
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
+      AND s.deleted_at IS NULL
+      AND s.group_id IS NOT NULL
+      AND s.payer_id = sqlc.arg('user_id')
+      AND s.payee_id IS NOT NULL
+    UNION ALL
+    SELECT s.group_id, s.payer_id, -s.amount
+    FROM settlements s
+    WHERE s.type = 'group'
+      AND (s.status = 'completed' OR (s.status = 'pending' AND NOT sqlc.arg('confirmed_only')::BOOLEAN))
+      AND s.deleted_at IS NULL
+      AND s.group_id IS NOT NULL
+      AND s.payee_id = sqlc.arg('user_id')
+      AND s.payer_id IS NOT NULL
+)
+SELECT
+    g.id AS group_id,
+    g.name AS group_name,
+    g.currency_code AS currency_code,
+    en.friend_id,
+    SUM(en.amount)::TEXT AS balance
+FROM entries en
+JOIN groups g ON g.id = en.group_id
+JOIN group_members gm ON gm.group_id = g.id AND gm.user_id = sqlc.arg('user_id')
+WHERE gm.status IN ('active', 'inactive')
+    AND gm.deleted_at IS NULL
+    AND g.deleted_at IS NULL
+GROUP BY g.id, g.name, g.currency_code, en.friend_id
+HAVING SUM(en.amount) <> 0
+ORDER BY g.name, en.friend_id;
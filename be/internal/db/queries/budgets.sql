@@ -0,0 +1,47 @@
+-- name: CreateGroupBudget :one
+INSERT INTO group_budgets (group_id, category_id, name, amount, period, start_date, end_date, thresholds, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetGroupBudget :one
+SELECT * FROM group_budgets
+WHERE id = $1 AND group_id = $2;
+
+-- name: ListGroupBudgets :many
+SELECT * FROM group_budgets
+WHERE group_id = $1
+ORDER BY created_at;
+
+-- name: ListBudgetsForCategory :many
+-- Budgets an expense in the category counts towards: the group-wide ones and
+-- the category's own. A NULL category only matches group-wide budgets.
+SELECT * FROM group_budgets
+WHERE group_id = sqlc.arg('group_id')
+  AND (category_id IS NULL OR category_id = sqlc.narg('category_id'))
+ORDER BY created_at;
+
+-- name: CountGroupBudgets :one
+SELECT COUNT(*) FROM group_budgets
+WHERE group_id = $1;
+
+-- name: UpdateGroupBudget :one
+UPDATE group_budgets
+SET category_id = $3, name = $4, amount = $5, period = $6, start_date = $7, end_date = $8, thresholds = $9, updated_at = NOW()
+WHERE id = $1 AND group_id = $2
+RETURNING *;
+
+-- name: DeleteGroupBudget :exec
+DELETE FROM group_budgets
+WHERE id = $1 AND group_id = $2;
+
+-- name: GetBudgetSpent :one
+-- Total of the group's expenses dated within the period, in the group currency.
+-- A NULL category counts every expense.
+SELECT COALESCE(SUM(amount * exchange_rate), 0)::numeric AS spent
+FROM expenses
+WHERE group_id = sqlc.arg('group_id')
+  AND type = 'group'
+  AND deleted_at IS NULL
+  AND date >= sqlc.arg('start_date')::date
+  AND date <= sqlc.arg('end_date')::date
+  AND (sqlc.narg('category_id')::uuid IS NULL OR category_id = sqlc.narg('category_id')::uuid);
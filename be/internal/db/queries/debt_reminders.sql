@@ -0,0 +1,51 @@
+-- name: ListReminderGroups :many
+-- Active groups with automatic reminders turned on, with their owner.
+SELECT g.id, g.name, g.currency_code, g.settings, m.user_id AS owner_id
+FROM groups g
+JOIN group_members m ON m.group_id = g.id AND m.role = 'owner' AND m.status = 'active'
+WHERE g.deleted_at IS NULL
+  AND g.archived_at IS NULL
+  AND g.settings @> '{"reminders": {"enabled": true}}'
+ORDER BY g.id;
+
+-- name: TrackDebtors :exec
+-- Starts the debt age of members who weren't owing yet.
+INSERT INTO debt_reminders (group_id, user_id, owing_since)
+SELECT sqlc.arg('group_id')::uuid, debtor, sqlc.arg('now')::timestamptz
+FROM unnest(sqlc.arg('user_ids')::uuid[]) AS debtor
+ON CONFLICT (group_id, user_id) DO UPDATE
+SET owing_since = EXCLUDED.owing_since
+WHERE debt_reminders.owing_since IS NULL;
+
+-- name: ClearSettledDebtors :exec
+-- Marks members no longer in debt as settled, so their next debt starts a new
+-- age. The last reminder is kept, so settling up doesn't reset the interval.
+UPDATE debt_reminders
+SET owing_since = NULL
+WHERE group_id = sqlc.arg('group_id')
+  AND owing_since IS NOT NULL
+  AND user_id <> ALL(sqlc.arg('user_ids')::uuid[]);
+
+-- name: ListDebtReminderStates :many
+SELECT
+    r.user_id,
+    r.owing_since,
+    r.last_reminded_at,
+    u.email,
+    u.name,
+    u.language,
+    u.timezone
+FROM debt_reminders r
+JOIN users u ON u.id = r.user_id
+WHERE r.group_id = $1 AND r.owing_since IS NOT NULL AND u.deleted_at IS NULL;
+
+-- name: ClaimDebtReminder :execrows
+-- Records a reminder unless the member got one after reminded_before; no rows
+-- affected means the reminder must not be sent.
+INSERT INTO debt_reminders (group_id, user_id, owing_since, last_reminded_at)
+VALUES (sqlc.arg('group_id'), sqlc.arg('user_id'), sqlc.arg('now'), sqlc.arg('now'))
+ON CONFLICT (group_id, user_id) DO UPDATE
+SET owing_since = COALESCE(debt_reminders.owing_since, EXCLUDED.owing_since),
+    last_reminded_at = EXCLUDED.last_reminded_at
+WHERE debt_reminders.last_reminded_at IS NULL
+   OR debt_reminders.last_reminded_at <= sqlc.arg('reminded_before');
@@ -0,0 +1,32 @@
+-- name: EnqueueEmail :one
+-- A NULL send_after sends the email right away.
+INSERT INTO email_outbox (template, to_address, subject, text_body, html_body, max_attempts, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5, $6, COALESCE(sqlc.narg('send_after')::timestamptz, NOW())) RETURNING *;
+
+-- name: ClaimDueEmails :many
+UPDATE email_outbox
+SET status = 'sending', locked_at = NOW(), attempts = attempts + 1
+WHERE id IN (
+    SELECT id FROM email_outbox
+    WHERE (status = 'pending' AND next_attempt_at <= NOW())
+       OR (status = 'sending' AND locked_at < NOW() - INTERVAL '10 minutes')
+    ORDER BY next_attempt_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkEmailSent :exec
+UPDATE email_outbox
+SET status = 'sent', sent_at = NOW(), locked_at = NULL, last_error = NULL
+WHERE id = $1;
+
+-- name: MarkEmailRetry :exec
+UPDATE email_outbox
+SET status = 'pending', next_attempt_at = $2, last_error = $3, locked_at = NULL
+WHERE id = $1;
+
+-- name: MarkEmailFailed :exec
+UPDATE email_outbox
+SET status = 'failed', last_error = $2, locked_at = NULL
+WHERE id = $1;
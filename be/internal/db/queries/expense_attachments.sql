@@ -0,0 +1,16 @@
+-- name: CreateExpenseAttachment :one
+INSERT INTO expense_attachments (expense_id, uploaded_by, file_name, content_type, size_bytes, checksum_sha256, storage_key)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: GetExpenseAttachmentByID :one
+SELECT * FROM expense_attachments
+WHERE id = $1;
+
+-- name: ListExpenseAttachments :many
+SELECT * FROM expense_attachments
+WHERE expense_id = $1
+ORDER BY created_at ASC;
+
+-- name: DeleteExpenseAttachment :exec
+DELETE FROM expense_attachments
+WHERE id = $1;
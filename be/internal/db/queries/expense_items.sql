@@ -0,0 +1,23 @@
+-- name: CreateExpenseItem :one
+INSERT INTO expense_items (expense_id, kind, name, amount, position)
+VALUES ($1, $2, $3, $4, $5) RETURNING *;
+
+-- name: CreateExpenseItemConsumer :one
+INSERT INTO expense_item_consumers (expense_item_id, user_id, pending_user_id)
+VALUES ($1, $2, $3) RETURNING *;
+
+-- name: ListExpenseItems :many
+SELECT * FROM expense_items
+WHERE expense_id = $1 AND deleted_at IS NULL
+ORDER BY position ASC, created_at ASC;
+
+-- name: ListExpenseItemConsumers :many
+SELECT c.* FROM expense_item_consumers c
+JOIN expense_items i ON c.expense_item_id = i.id
+WHERE i.expense_id = $1 AND i.deleted_at IS NULL
+ORDER BY i.position ASC, c.created_at ASC;
+
+-- name: DeleteExpenseItems :exec
+UPDATE expense_items
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE expense_id = $1 AND deleted_at IS NULL;
@@ -1,6 +1,6 @@
 -- name: CreateExpense :one
-INSERT INTO expenses (group_id, type, title, notes, amount, currency_code, date, category_id, tags, created_by, updated_by)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10) RETURNING *;
+INSERT INTO expenses (group_id, type, title, notes, amount, currency_code, exchange_rate, date, category_id, tags, created_by, updated_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11) RETURNING *;
 
 -- name: GetExpenseByID :one
 SELECT * FROM expenses
@@ -17,10 +17,11 @@ SET title = $2,
     notes = $3,
     amount = $4,
     currency_code = $5,
-    date = $6,
-    category_id = $7,
-    tags = $8,
-    updated_by = $9,
+    exchange_rate = $6,
+    date = $7,
+    category_id = $8,
+    tags = $9,
+    updated_by = $10,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
@@ -31,8 +32,8 @@ SET deleted_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: CreateExpensePayment :one
-INSERT INTO expense_payments (expense_id, user_id, pending_user_id, amount, payment_method)
-VALUES ($1, $2, $3, $4, $5) RETURNING *;
+INSERT INTO expense_payments (expense_id, user_id, pending_user_id, amount, payment_method, amount_in_group_currency)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
 
 -- name: ListExpensePayments :many
 SELECT
@@ -61,8 +62,8 @@ SET deleted_at = CURRENT_TIMESTAMP
 WHERE expense_id = $1 AND deleted_at IS NULL;
 
 -- name: CreateExpenseSplit :one
-INSERT INTO expense_split (expense_id, user_id, pending_user_id, amount_owned, split_type, share_value)
-VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
+INSERT INTO expense_split (expense_id, user_id, pending_user_id, amount_owned, split_type, share_value, amount_in_group_currency)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
 
 -- name: ListExpenseSplits :many
 SELECT
@@ -41,11 +41,18 @@ SELECT
     g.description,
     g.currency_code,
     g.created_at,
+    g.archived_at,
     gm.id as membership_id,
     gm.role as member_role,
     gm.status as member_status,
     gm.joined_at as member_joined_at
 FROM group_members AS gm
 INNER JOIN groups AS g ON gm.group_id = g.id
-WHERE gm.user_id = $1 AND gm.deleted_at IS NULL AND g.deleted_at IS NULL
-ORDER BY g.created_at DESC;
\ No newline at end of file
+WHERE gm.user_id = $1 AND gm.status != 'inactive' AND gm.deleted_at IS NULL AND g.deleted_at IS NULL
+ORDER BY g.created_at DESC;
+
+-- name: UpdateGroupMemberRole :one
+UPDATE group_members
+SET role = $3, updated_at = CURRENT_TIMESTAMP
+WHERE group_id = $1 AND user_id = $2 AND deleted_at IS NULL
+RETURNING *;
@@ -47,3 +47,39 @@ JOIN users u ON ga.user_id = u.id
 WHERE ga.entity_type = 'expense' 
   AND ga.entity_id = $1
 ORDER BY ga.created_at DESC;
+
+-- name: GetGroupActivity :one
+SELECT * FROM group_activities
+WHERE id = $1;
+
+-- name: ListGroupActivitiesAfter :many
+-- Activities of the group logged after after_id, oldest first. Event streams
+-- use it to replay what a client missed; an unknown after_id or a deleted group
+-- replays nothing.
+SELECT ga.* FROM group_activities ga
+JOIN groups g ON g.id = ga.group_id
+WHERE ga.group_id = sqlc.arg('group_id')
+  AND g.deleted_at IS NULL
+  AND (ga.created_at, ga.id) > (
+    SELECT a.created_at, a.id FROM group_activities a WHERE a.id = sqlc.arg('after_id')
+  )
+ORDER BY ga.created_at, ga.id
+LIMIT sqlc.arg('limit');
+
+-- name: ListUserActivitiesAfter :many
+-- Like ListGroupActivitiesAfter, across every group the user is an active member of.
+SELECT ga.* FROM group_activities ga
+JOIN group_members gm ON gm.group_id = ga.group_id
+JOIN groups g ON g.id = ga.group_id
+WHERE gm.user_id = sqlc.arg('user_id')
+  AND gm.status = 'active'
+  AND g.deleted_at IS NULL
+  AND (ga.created_at, ga.id) > (
+    SELECT a.created_at, a.id FROM group_activities a WHERE a.id = sqlc.arg('after_id')
+  )
+ORDER BY ga.created_at, ga.id
+LIMIT sqlc.arg('limit');
+
+-- name: ListActiveGroupMemberIDs :many
+SELECT user_id FROM group_members
+WHERE group_id = $1 AND status = 'active';
@@ -29,3 +29,9 @@ FROM group_invitations gi
 JOIN groups g ON gi.group_id = g.id
 JOIN users u ON gi.invited_by = u.id
 WHERE gi.email = $1 AND gi.status = 'pending' AND gi.expires_at > NOW();
+
+-- name: RenewInvitation :one
+UPDATE group_invitations
+SET token = $2, expires_at = $3, updated_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
@@ -0,0 +1,45 @@
+-- name: CreateJoinLink :one
+INSERT INTO group_join_links (group_id, token, max_uses, expires_at, created_by)
+VALUES ($1, $2, $3, $4, $5) RETURNING *;
+
+-- name: GetJoinLinkByID :one
+SELECT * FROM group_join_links
+WHERE id = $1;
+
+-- name: GetJoinLinkByToken :one
+-- Links of deleted or archived groups can't be used, like revoked ones.
+SELECT gjl.*, g.name AS group_name
+FROM group_join_links gjl
+JOIN groups g ON gjl.group_id = g.id
+WHERE gjl.token = $1
+    AND g.deleted_at IS NULL
+    AND g.archived_at IS NULL
+    AND gjl.revoked_at IS NULL
+    AND gjl.expires_at > NOW()
+    AND (gjl.max_uses IS NULL OR gjl.use_count < gjl.max_uses);
+
+-- name: ListJoinLinksByGroup :many
+SELECT * FROM group_join_links
+WHERE group_id = $1
+ORDER BY created_at DESC;
+
+-- name: ConsumeJoinLink :one
+-- Checks the group again, so a link can't be used once its group is deleted or
+-- archived after it was looked up.
+UPDATE group_join_links gjl
+SET use_count = gjl.use_count + 1
+FROM groups g
+WHERE gjl.id = $1
+    AND g.id = gjl.group_id
+    AND g.deleted_at IS NULL
+    AND g.archived_at IS NULL
+    AND gjl.revoked_at IS NULL
+    AND gjl.expires_at > NOW()
+    AND (gjl.max_uses IS NULL OR gjl.use_count < gjl.max_uses)
+RETURNING gjl.*;
+
+-- name: RevokeJoinLink :one
+UPDATE group_join_links
+SET revoked_at = NOW()
+WHERE id = $1 AND revoked_at IS NULL
+RETURNING *;
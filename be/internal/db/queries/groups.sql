@@ -4,4 +4,43 @@ VALUES ($1, $2, $3, $4, $4) RETURNING *;
 
 -- name: GetGroupByID :one
 SELECT * FROM groups
-WHERE id = $1 AND deleted_at IS NULL;
\ No newline at end of file
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: UpdateGroup :one
+UPDATE groups
+SET name = $2,
+    description = $3,
+    currency_code = $4,
+    default_split_method = $5,
+    settings = $6,
+    updated_by = $7,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: ArchiveGroup :one
+UPDATE groups
+SET archived_at = CURRENT_TIMESTAMP,
+    updated_by = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND archived_at IS NULL
+RETURNING *;
+
+-- name: RestoreGroup :one
+UPDATE groups
+SET archived_at = NULL,
+    updated_by = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND archived_at IS NOT NULL
+RETURNING *;
+
+-- name: DeleteGroup :exec
+UPDATE groups
+SET deleted_at = CURRENT_TIMESTAMP,
+    updated_by = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: CountGroupExpenses :one
+SELECT COUNT(*) FROM expenses
+WHERE group_id = $1 AND deleted_at IS NULL;
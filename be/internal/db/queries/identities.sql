@@ -0,0 +1,27 @@
+-- name: GetUserIdentity :one
+SELECT * FROM user_identities
+WHERE provider = $1 AND subject = $2;
+
+-- name: CreateUserIdentity :one
+INSERT INTO user_identities (user_id, provider, subject, email)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: UpdateUserIdentityLogin :exec
+UPDATE user_identities
+SET email = $2, last_login_at = NOW()
+WHERE id = $1;
+
+-- name: CreateOIDCLoginState :exec
+INSERT INTO oidc_login_states (state, provider, code_verifier, nonce, expires_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ConsumeOIDCLoginState :one
+-- Deletes the state as it is read, so a callback can't be replayed.
+DELETE FROM oidc_login_states
+WHERE state = $1 AND expires_at > NOW()
+RETURNING *;
+
+-- name: DeleteExpiredOIDCLoginStates :exec
+DELETE FROM oidc_login_states
+WHERE expires_at <= NOW();
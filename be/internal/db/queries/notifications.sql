@@ -0,0 +1,76 @@
+-- name: CreateNotifications :exec
+-- Fans the activity out to the given users, skipping those who muted its category.
+INSERT INTO notifications (user_id, activity_id, group_id, actor_id, category, action, entity_type, entity_id, metadata)
+SELECT r.user_id, sqlc.arg('activity_id')::uuid, sqlc.arg('group_id')::uuid, sqlc.arg('actor_id')::uuid, sqlc.arg('category')::text,
+       sqlc.arg('action')::text, sqlc.arg('entity_type')::text, sqlc.arg('entity_id')::uuid, sqlc.arg('metadata')::jsonb
+FROM unnest(sqlc.arg('user_ids')::uuid[]) AS r(user_id)
+WHERE NOT EXISTS (
+    SELECT 1 FROM notification_mutes m
+    WHERE m.user_id = r.user_id AND m.category = sqlc.arg('category')::text
+)
+ON CONFLICT (user_id, activity_id) DO NOTHING;
+
+-- name: ListUserNotifications :many
+SELECT
+    n.id,
+    n.activity_id,
+    n.group_id,
+    n.actor_id,
+    n.category,
+    n.action,
+    n.entity_type,
+    n.entity_id,
+    n.metadata,
+    n.read_at,
+    n.created_at,
+    g.name AS group_name,
+    u.name AS actor_name,
+    u.avatar_url AS actor_avatar_url
+FROM notifications n
+JOIN groups g ON g.id = n.group_id
+JOIN users u ON u.id = n.actor_id
+WHERE n.user_id = sqlc.arg('user_id')
+  AND (NOT sqlc.arg('unread_only')::bool OR n.read_at IS NULL)
+ORDER BY n.created_at DESC, n.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: MarkNotificationRead :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications
+SET read_at = NOW()
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: ListNotificationMutes :many
+SELECT category FROM notification_mutes
+WHERE user_id = $1
+ORDER BY category;
+
+-- name: MuteNotificationCategory :exec
+INSERT INTO notification_mutes (user_id, category)
+VALUES ($1, $2)
+ON CONFLICT (user_id, category) DO NOTHING;
+
+-- name: UnmuteNotificationCategory :exec
+DELETE FROM notification_mutes
+WHERE user_id = $1 AND category = $2;
+
+-- name: ListExpenseParticipantIDs :many
+-- Registered users who paid for or share in the expense.
+SELECT user_id FROM expense_payments
+WHERE expense_id = $1 AND deleted_at IS NULL AND user_id IS NOT NULL
+UNION
+SELECT user_id FROM expense_split
+WHERE expense_id = $1 AND deleted_at IS NULL AND user_id IS NOT NULL;
+
+-- name: GetSettlementParticipants :one
+SELECT payer_id, payee_id FROM settlements
+WHERE id = $1;
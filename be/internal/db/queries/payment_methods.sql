@@ -0,0 +1,26 @@
+-- name: ListUserPaymentMethods :many
+SELECT * FROM user_payment_methods
+WHERE user_id = $1
+ORDER BY priority, created_at;
+
+-- name: ListPaymentMethodsByUsers :many
+SELECT * FROM user_payment_methods
+WHERE user_id = ANY(sqlc.arg('user_ids')::UUID[])
+ORDER BY user_id, priority, created_at;
+
+-- name: GetUserPaymentMethod :one
+SELECT * FROM user_payment_methods
+WHERE user_id = $1 AND provider = $2;
+
+-- name: UpsertUserPaymentMethod :one
+INSERT INTO user_payment_methods (user_id, provider, handle, priority)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, provider) DO UPDATE
+SET handle = EXCLUDED.handle,
+    priority = EXCLUDED.priority,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteUserPaymentMethod :exec
+DELETE FROM user_payment_methods
+WHERE user_id = $1 AND provider = $2;
@@ -9,6 +9,17 @@ RETURNING *;
 -- name: GetPendingUserByEmail :one
 SELECT * FROM pending_users WHERE email = $1;
 
+-- name: GetGroupPendingUserByEmail :one
+-- Returns the pending user with the given email if they have an open invitation to the group
+SELECT pu.*
+FROM pending_users pu
+JOIN group_invitations gi ON gi.email = pu.email
+WHERE gi.group_id = $1
+  AND pu.email = $2
+  AND gi.status = 'pending'
+  AND gi.expires_at > NOW()
+LIMIT 1;
+
 -- name: GetPendingUserByID :one
 SELECT * FROM pending_users WHERE id = $1;
 
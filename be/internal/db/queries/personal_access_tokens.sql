@@ -0,0 +1,28 @@
+-- name: CreatePersonalAccessToken :one
+INSERT INTO personal_access_tokens (user_id, name, token_hash, token_prefix, scopes, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetPersonalAccessTokenByHash :one
+SELECT * FROM personal_access_tokens
+WHERE token_hash = $1;
+
+-- name: ListUserPersonalAccessTokens :many
+SELECT * FROM personal_access_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: CountUserPersonalAccessTokens :one
+SELECT COUNT(*) FROM personal_access_tokens
+WHERE user_id = $1;
+
+-- name: DeleteUserPersonalAccessToken :one
+DELETE FROM personal_access_tokens
+WHERE id = $1 AND user_id = $2
+RETURNING id;
+
+-- name: TouchPersonalAccessToken :exec
+-- Records use at most once a minute so busy scripts don't write on every request.
+UPDATE personal_access_tokens
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
@@ -0,0 +1,19 @@
+-- name: IncrementRateLimitCounter :one
+-- Counts one request against key, starting a new window when the previous one
+-- has ended.
+INSERT INTO rate_limit_counters (key, count, window_ends_at)
+VALUES (sqlc.arg('key'), 1, sqlc.arg('window_ends_at'))
+ON CONFLICT (key) DO UPDATE
+SET count = CASE
+        WHEN rate_limit_counters.window_ends_at <= NOW() THEN 1
+        ELSE rate_limit_counters.count + 1
+    END,
+    window_ends_at = CASE
+        WHEN rate_limit_counters.window_ends_at <= NOW() THEN EXCLUDED.window_ends_at
+        ELSE rate_limit_counters.window_ends_at
+    END
+RETURNING count, window_ends_at;
+
+-- name: DeleteExpiredRateLimitCounters :exec
+DELETE FROM rate_limit_counters
+WHERE window_ends_at <= NOW();
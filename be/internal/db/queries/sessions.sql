@@ -42,3 +42,39 @@ WHERE expires_at <= NOW();
 SELECT * FROM sessions
 WHERE user_id = $1 AND expires_at > NOW()
 ORDER BY created_at DESC;
+
+-- name: GetUserSession :one
+SELECT * FROM sessions
+WHERE id = $1 AND user_id = $2 AND expires_at > NOW();
+
+-- name: DeleteSessionByID :exec
+DELETE FROM sessions
+WHERE id = $1;
+
+-- name: IsSessionActive :one
+SELECT EXISTS(
+    SELECT 1 FROM sessions
+    WHERE id = $1 AND expires_at > NOW()
+) AS is_active;
+
+-- name: RotateSessionRefreshToken :one
+-- Swaps the refresh token only if the presented one is still current, and
+-- archives the old hash in the same statement so reuse can be detected.
+WITH rotated AS (
+    UPDATE sessions
+    SET refresh_token_hash = sqlc.arg('new_refresh_token_hash'), last_used_at = NOW()
+    WHERE refresh_token_hash = sqlc.arg('refresh_token_hash') AND expires_at > NOW()
+    RETURNING id, user_id, refresh_token_hash, user_agent, ip_address, expires_at, created_at, last_used_at
+), archived AS (
+    INSERT INTO refresh_token_history (token_hash, session_id, user_id)
+    SELECT sqlc.arg('refresh_token_hash'), id, user_id FROM rotated
+)
+SELECT id, user_id, refresh_token_hash, user_agent, ip_address, expires_at, created_at, last_used_at FROM rotated;
+
+-- name: GetRefreshTokenHistory :one
+SELECT * FROM refresh_token_history
+WHERE token_hash = $1;
+
+-- name: CreateSecurityEvent :exec
+INSERT INTO security_events (user_id, session_id, event_type, ip_address, user_agent)
+VALUES ($1, $2, $3, $4, $5);
@@ -4,9 +4,13 @@ INSERT INTO settlements (
     payer_id, payer_pending_user_id,
     payee_id, payee_pending_user_id,
     amount, currency_code, status,
-    payment_method, transaction_reference, notes, created_by, updated_by
+    payment_method, transaction_reference, notes, created_by, updated_by,
+    confirmed_at
+)
+VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13,
+    CASE WHEN $9 = 'completed' THEN CURRENT_TIMESTAMP END
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13)
 RETURNING *;
 
 -- name: GetSettlementByID :one
@@ -32,6 +36,10 @@ SELECT
     s.created_by,
     s.updated_at,
     s.updated_by,
+    s.confirmed_at,
+    s.disputed_at,
+    s.cancelled_at,
+    s.dispute_reason,
     COALESCE(payer_user.email, payer_pending.email) AS payer_email,
     COALESCE(payer_user.name, payer_pending.name) AS payer_name,
     payer_user.avatar_url AS payer_avatar_url,
@@ -67,6 +75,10 @@ SELECT
     s.created_by,
     s.updated_at,
     s.updated_by,
+    s.confirmed_at,
+    s.disputed_at,
+    s.cancelled_at,
+    s.dispute_reason,
     g.name AS group_name,
     COALESCE(payer_user.email, payer_pending.email) AS payer_email,
     COALESCE(payer_user.name, payer_pending.name) AS payer_name,
@@ -102,12 +114,19 @@ SELECT EXISTS (
 );
 
 -- name: UpdateSettlementStatus :one
+-- Moves a settlement to a new status, only if it is still in current_status
 UPDATE settlements
-SET status = $2,
-    paid_at = CASE WHEN $2 = 'completed' AND paid_at IS NULL THEN CURRENT_TIMESTAMP ELSE paid_at END,
+SET status = sqlc.arg('status'),
+    paid_at = CASE WHEN sqlc.arg('status') = 'completed' AND paid_at IS NULL THEN CURRENT_TIMESTAMP ELSE paid_at END,
+    confirmed_at = CASE WHEN sqlc.arg('status') = 'completed' THEN CURRENT_TIMESTAMP ELSE confirmed_at END,
+    disputed_at = CASE WHEN sqlc.arg('status') = 'disputed' THEN CURRENT_TIMESTAMP ELSE disputed_at END,
+    cancelled_at = CASE WHEN sqlc.arg('status') = 'cancelled' THEN CURRENT_TIMESTAMP ELSE cancelled_at END,
+    dispute_reason = CASE WHEN sqlc.arg('status') = 'disputed' THEN sqlc.narg('dispute_reason') ELSE dispute_reason END,
     updated_at = CURRENT_TIMESTAMP,
-    updated_by = $3
-WHERE id = $1 AND deleted_at IS NULL
+    updated_by = sqlc.arg('updated_by')
+WHERE id = sqlc.arg('id')
+  AND status = sqlc.arg('current_status')
+  AND deleted_at IS NULL
 RETURNING *;
 
 -- name: UpdateSettlement :one
@@ -119,6 +138,9 @@ SET amount = $2,
     transaction_reference = $6,
     notes = $7,
     paid_at = CASE WHEN $4 = 'completed' AND paid_at IS NULL THEN CURRENT_TIMESTAMP ELSE paid_at END,
+    confirmed_at = CASE WHEN $4 = 'completed' AND status <> 'completed' THEN CURRENT_TIMESTAMP ELSE confirmed_at END,
+    disputed_at = CASE WHEN $4 = 'disputed' AND status <> 'disputed' THEN CURRENT_TIMESTAMP ELSE disputed_at END,
+    cancelled_at = CASE WHEN $4 = 'cancelled' AND status <> 'cancelled' THEN CURRENT_TIMESTAMP ELSE cancelled_at END,
     updated_at = CURRENT_TIMESTAMP,
     updated_by = $8
 WHERE id = $1 AND deleted_at IS NULL
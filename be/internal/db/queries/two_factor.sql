@@ -0,0 +1,86 @@
+-- name: GetUserTwoFactor :one
+SELECT * FROM user_two_factor
+WHERE user_id = $1;
+
+-- name: UpsertUserTwoFactorSecret :one
+-- Starts or restarts an enrollment; an enabled secret is never replaced.
+INSERT INTO user_two_factor (user_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+WHERE user_two_factor.enabled_at IS NULL
+RETURNING *;
+
+-- name: EnableUserTwoFactor :exec
+UPDATE user_two_factor
+SET enabled_at = NOW()
+WHERE user_id = $1;
+
+-- name: RecordTwoFactorStep :one
+-- Only succeeds for a time step later than the last one used, so a code can't be replayed.
+UPDATE user_two_factor
+SET last_used_step = $2
+WHERE user_id = $1 AND last_used_step < $2
+RETURNING *;
+
+-- name: RecordTwoFactorFailure :one
+-- Counts a wrong code given to change two-factor settings.
+UPDATE user_two_factor
+SET failed_attempts = failed_attempts + 1
+WHERE user_id = $1
+RETURNING failed_attempts;
+
+-- name: LockUserTwoFactor :exec
+-- Locks two-factor settings changes until locked_until; failures count from zero again after it.
+UPDATE user_two_factor
+SET failed_attempts = 0, locked_until = $2
+WHERE user_id = $1;
+
+-- name: ResetTwoFactorFailures :exec
+UPDATE user_two_factor
+SET failed_attempts = 0
+WHERE user_id = $1;
+
+-- name: DeleteUserTwoFactor :exec
+DELETE FROM user_two_factor
+WHERE user_id = $1;
+
+-- name: CreateRecoveryCodes :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+SELECT sqlc.arg('user_id')::uuid, unnest(sqlc.arg('code_hashes')::text[]);
+
+-- name: UseRecoveryCode :one
+UPDATE user_recovery_codes
+SET used_at = NOW()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+RETURNING *;
+
+-- name: CountUnusedRecoveryCodes :one
+SELECT COUNT(*) FROM user_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = $1;
+
+-- name: CreateTwoFactorChallenge :exec
+INSERT INTO two_factor_challenges (token_hash, user_id, expires_at)
+VALUES ($1, $2, $3);
+
+-- name: GetTwoFactorChallenge :one
+SELECT * FROM two_factor_challenges
+WHERE token_hash = $1 AND expires_at > NOW();
+
+-- name: RecordTwoFactorChallengeFailure :one
+UPDATE two_factor_challenges
+SET failed_attempts = failed_attempts + 1
+WHERE token_hash = $1
+RETURNING failed_attempts;
+
+-- name: DeleteTwoFactorChallenge :exec
+DELETE FROM two_factor_challenges
+WHERE token_hash = $1;
+
+-- name: DeleteExpiredTwoFactorChallenges :exec
+DELETE FROM two_factor_challenges
+WHERE expires_at <= NOW();
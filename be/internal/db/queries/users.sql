@@ -8,4 +8,40 @@ WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: GetUserByEmail :one
 SELECT * FROM users
-WHERE email = $1 AND deleted_at IS NULL;
\ No newline at end of file
+WHERE email = $1 AND deleted_at IS NULL;
+
+-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET email_verified_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND email_verified_at IS NULL AND deleted_at IS NULL;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password_hash = $2, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetUserLoginAttempts :one
+SELECT * FROM user_login_attempts
+WHERE user_id = $1;
+
+-- name: RecordFailedLogin :one
+-- Counts a failed sign-in. Failures older than window_start no longer count,
+-- so the streak starts over.
+INSERT INTO user_login_attempts (user_id, failed_attempts, last_failed_at)
+VALUES (sqlc.arg('user_id'), 1, NOW())
+ON CONFLICT (user_id) DO UPDATE
+SET failed_attempts = CASE
+        WHEN user_login_attempts.last_failed_at < sqlc.arg('window_start')::TIMESTAMPTZ THEN 1
+        ELSE user_login_attempts.failed_attempts + 1
+    END,
+    last_failed_at = NOW()
+RETURNING *;
+
+-- name: LockUserLogin :exec
+UPDATE user_login_attempts
+SET locked_until = $2
+WHERE user_id = $1;
+
+-- name: ResetFailedLogins :exec
+DELETE FROM user_login_attempts
+WHERE user_id = $1;
@@ -0,0 +1,81 @@
+-- name: CreateGroupWebhook :one
+INSERT INTO group_webhooks (group_id, url, secret, events, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetGroupWebhook :one
+SELECT * FROM group_webhooks
+WHERE id = $1 AND group_id = $2;
+
+-- name: ListGroupWebhooks :many
+SELECT * FROM group_webhooks
+WHERE group_id = $1
+ORDER BY created_at;
+
+-- name: CountGroupWebhooks :one
+SELECT COUNT(*) FROM group_webhooks
+WHERE group_id = $1;
+
+-- name: UpdateGroupWebhook :one
+UPDATE group_webhooks
+SET url = $3, events = $4, active = $5, updated_at = NOW()
+WHERE id = $1 AND group_id = $2
+RETURNING *;
+
+-- name: DeleteGroupWebhook :exec
+DELETE FROM group_webhooks
+WHERE id = $1 AND group_id = $2;
+
+-- name: EnqueueWebhookDeliveries :exec
+-- Queues the event for every active webhook of the group that subscribed to it.
+INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, max_attempts)
+SELECT id, sqlc.arg('event_id')::uuid, sqlc.arg('event_type')::text, sqlc.arg('payload')::jsonb, sqlc.arg('max_attempts')::int
+FROM group_webhooks
+WHERE group_id = sqlc.arg('group_id')
+  AND active
+  AND (cardinality(events) = 0 OR sqlc.arg('event_type')::text = ANY(events));
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, max_attempts)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 AND webhook_id = $2;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries d
+SET status = 'sending', locked_at = NOW(), attempts = d.attempts + 1
+FROM group_webhooks w
+WHERE w.id = d.webhook_id
+  AND d.id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE (status = 'pending' AND next_attempt_at <= NOW())
+       OR (status = 'sending' AND locked_at < NOW() - INTERVAL '10 minutes')
+    ORDER BY next_attempt_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, d.max_attempts, w.url, w.secret;
+
+-- name: MarkWebhookDeliveryDelivered :exec
+UPDATE webhook_deliveries
+SET status = 'delivered', delivered_at = NOW(), locked_at = NULL, response_status = $2, response_body = $3, last_error = NULL
+WHERE id = $1;
+
+-- name: MarkWebhookDeliveryRetry :exec
+UPDATE webhook_deliveries
+SET status = 'pending', next_attempt_at = $2, response_status = $3, response_body = $4, last_error = $5, locked_at = NULL
+WHERE id = $1;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET status = 'failed', response_status = $2, response_body = $3, last_error = $4, locked_at = NULL
+WHERE id = $1;
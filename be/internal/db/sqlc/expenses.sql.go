@@ -12,8 +12,8 @@ import (
 )
 
 const createExpense = `-- name: CreateExpense :one
-INSERT INTO expenses (group_id, type, title, notes, amount, currency_code, date, category_id, tags, created_by, updated_by)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10) RETURNING id, group_id, title, notes, amount, currency_code, date, created_at, created_by, updated_at, updated_by, deleted_at, type, category_id, tags
+INSERT INTO expenses (group_id, type, title, notes, amount, currency_code, exchange_rate, date, category_id, tags, created_by, updated_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11) RETURNING id, group_id, title, notes, amount, currency_code, date, created_at, created_by, updated_at, updated_by, deleted_at, type, category_id, tags, exchange_rate
 `
 
 type CreateExpenseParams struct {
@@ -23,6 +23,7 @@ type CreateExpenseParams struct {
 	Notes        pgtype.Text    `json:"notes"`
 	Amount       pgtype.Numeric `json:"amount"`
 	CurrencyCode string         `json:"currency_code"`
+	ExchangeRate pgtype.Numeric `json:"exchange_rate"`
 	Date         pgtype.Date    `json:"date"`
 	CategoryID   pgtype.UUID    `json:"category_id"`
 	Tags         []string       `json:"tags"`
@@ -37,6 +38,7 @@ func (q *Queries) CreateExpense(ctx context.Context, arg CreateExpenseParams) (E
 		arg.Notes,
 		arg.Amount,
 		arg.CurrencyCode,
+		arg.ExchangeRate,
 		arg.Date,
 		arg.CategoryID,
 		arg.Tags,
@@ -59,21 +61,23 @@ func (q *Queries) CreateExpense(ctx context.Context, arg CreateExpenseParams) (E
 		&i.Type,
 		&i.CategoryID,
 		&i.Tags,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
 
 const createExpensePayment = `-- name: CreateExpensePayment :one
-INSERT INTO expense_payments (expense_id, user_id, pending_user_id, amount, payment_method)
-VALUES ($1, $2, $3, $4, $5) RETURNING id, expense_id, user_id, amount, payment_method, created_at, updated_at, deleted_at, pending_user_id
+INSERT INTO expense_payments (expense_id, user_id, pending_user_id, amount, payment_method, amount_in_group_currency)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, expense_id, user_id, amount, payment_method, created_at, updated_at, deleted_at, pending_user_id, amount_in_group_currency
 `
 
 type CreateExpensePaymentParams struct {
-	ExpenseID     pgtype.UUID    `json:"expense_id"`
-	UserID        pgtype.UUID    `json:"user_id"`
-	PendingUserID pgtype.UUID    `json:"pending_user_id"`
-	Amount        pgtype.Numeric `json:"amount"`
-	PaymentMethod pgtype.Text    `json:"payment_method"`
+	ExpenseID             pgtype.UUID    `json:"expense_id"`
+	UserID                pgtype.UUID    `json:"user_id"`
+	PendingUserID         pgtype.UUID    `json:"pending_user_id"`
+	Amount                pgtype.Numeric `json:"amount"`
+	PaymentMethod         pgtype.Text    `json:"payment_method"`
+	AmountInGroupCurrency pgtype.Numeric `json:"amount_in_group_currency"`
 }
 
 func (q *Queries) CreateExpensePayment(ctx context.Context, arg CreateExpensePaymentParams) (ExpensePayment, error) {
@@ -83,6 +87,7 @@ func (q *Queries) CreateExpensePayment(ctx context.Context, arg CreateExpensePay
 		arg.PendingUserID,
 		arg.Amount,
 		arg.PaymentMethod,
+		arg.AmountInGroupCurrency,
 	)
 	var i ExpensePayment
 	err := row.Scan(
@@ -95,22 +100,24 @@ func (q *Queries) CreateExpensePayment(ctx context.Context, arg CreateExpensePay
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.PendingUserID,
+		&i.AmountInGroupCurrency,
 	)
 	return i, err
 }
 
 const createExpenseSplit = `-- name: CreateExpenseSplit :one
-INSERT INTO expense_split (expense_id, user_id, pending_user_id, amount_owned, split_type, share_value)
-VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, expense_id, user_id, amount_owned, split_type, created_at, updated_at, deleted_at, pending_user_id, share_value
+INSERT INTO expense_split (expense_id, user_id, pending_user_id, amount_owned, split_type, share_value, amount_in_group_currency)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, expense_id, user_id, amount_owned, split_type, created_at, updated_at, deleted_at, pending_user_id, share_value, amount_in_group_currency
 `
 
 type CreateExpenseSplitParams struct {
-	ExpenseID     pgtype.UUID    `json:"expense_id"`
-	UserID        pgtype.UUID    `json:"user_id"`
-	PendingUserID pgtype.UUID    `json:"pending_user_id"`
-	AmountOwned   pgtype.Numeric `json:"amount_owned"`
-	SplitType     string         `json:"split_type"`
-	ShareValue    pgtype.Numeric `json:"share_value"`
+	ExpenseID             pgtype.UUID    `json:"expense_id"`
+	UserID                pgtype.UUID    `json:"user_id"`
+	PendingUserID         pgtype.UUID    `json:"pending_user_id"`
+	AmountOwned           pgtype.Numeric `json:"amount_owned"`
+	SplitType             string         `json:"split_type"`
+	ShareValue            pgtype.Numeric `json:"share_value"`
+	AmountInGroupCurrency pgtype.Numeric `json:"amount_in_group_currency"`
 }
 
 func (q *Queries) CreateExpenseSplit(ctx context.Context, arg CreateExpenseSplitParams) (ExpenseSplit, error) {
@@ -121,6 +128,7 @@ func (q *Queries) CreateExpenseSplit(ctx context.Context, arg CreateExpenseSplit
 		arg.AmountOwned,
 		arg.SplitType,
 		arg.ShareValue,
+		arg.AmountInGroupCurrency,
 	)
 	var i ExpenseSplit
 	err := row.Scan(
@@ -134,6 +142,7 @@ func (q *Queries) CreateExpenseSplit(ctx context.Context, arg CreateExpenseSplit
 		&i.DeletedAt,
 		&i.PendingUserID,
 		&i.ShareValue,
+		&i.AmountInGroupCurrency,
 	)
 	return i, err
 }
@@ -172,7 +181,7 @@ func (q *Queries) DeleteExpenseSplits(ctx context.Context, expenseID pgtype.UUID
 }
 
 const getExpenseByID = `-- name: GetExpenseByID :one
-SELECT id, group_id, title, notes, amount, currency_code, date, created_at, created_by, updated_at, updated_by, deleted_at, type, category_id, tags FROM expenses
+SELECT id, group_id, title, notes, amount, currency_code, date, created_at, created_by, updated_at, updated_by, deleted_at, type, category_id, tags, exchange_rate FROM expenses
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -195,6 +204,7 @@ func (q *Queries) GetExpenseByID(ctx context.Context, id pgtype.UUID) (Expense,
 		&i.Type,
 		&i.CategoryID,
 		&i.Tags,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
@@ -347,7 +357,7 @@ func (q *Queries) ListExpenseSplits(ctx context.Context, expenseID pgtype.UUID)
 }
 
 const listExpensesByGroup = `-- name: ListExpensesByGroup :many
-SELECT id, group_id, title, notes, amount, currency_code, date, created_at, created_by, updated_at, updated_by, deleted_at, type, category_id, tags FROM expenses
+SELECT id, group_id, title, notes, amount, currency_code, date, created_at, created_by, updated_at, updated_by, deleted_at, type, category_id, tags, exchange_rate FROM expenses
 WHERE group_id = $1 AND deleted_at IS NULL
 ORDER BY date DESC, created_at DESC
 `
@@ -377,6 +387,7 @@ func (q *Queries) ListExpensesByGroup(ctx context.Context, groupID pgtype.UUID)
 			&i.Type,
 			&i.CategoryID,
 			&i.Tags,
+			&i.ExchangeRate,
 		); err != nil {
 			return nil, err
 		}
@@ -389,7 +400,7 @@ func (q *Queries) ListExpensesByGroup(ctx context.Context, groupID pgtype.UUID)
 }
 
 const listFriendExpenses = `-- name: ListFriendExpenses :many
-SELECT e.id, e.group_id, e.title, e.notes, e.amount, e.currency_code, e.date, e.created_at, e.created_by, e.updated_at, e.updated_by, e.deleted_at, e.type, e.category_id, e.tags
+SELECT e.id, e.group_id, e.title, e.notes, e.amount, e.currency_code, e.date, e.created_at, e.created_by, e.updated_at, e.updated_by, e.deleted_at, e.type, e.category_id, e.tags, e.exchange_rate
 FROM expenses e
 WHERE e.type = 'friend'
   AND e.group_id IS NULL
@@ -451,6 +462,7 @@ func (q *Queries) ListFriendExpenses(ctx context.Context, arg ListFriendExpenses
 			&i.Type,
 			&i.CategoryID,
 			&i.Tags,
+			&i.ExchangeRate,
 		); err != nil {
 			return nil, err
 		}
@@ -463,7 +475,7 @@ func (q *Queries) ListFriendExpenses(ctx context.Context, arg ListFriendExpenses
 }
 
 const searchExpenses = `-- name: SearchExpenses :many
-SELECT e.id, e.group_id, e.title, e.notes, e.amount, e.currency_code, e.date, e.created_at, e.created_by, e.updated_at, e.updated_by, e.deleted_at, e.type, e.category_id, e.tags FROM expenses e
+SELECT e.id, e.group_id, e.title, e.notes, e.amount, e.currency_code, e.date, e.created_at, e.created_by, e.updated_at, e.updated_by, e.deleted_at, e.type, e.category_id, e.tags, e.exchange_rate FROM expenses e
 WHERE e.group_id = $1
   AND e.deleted_at IS NULL
   AND (
@@ -541,6 +553,7 @@ func (q *Queries) SearchExpenses(ctx context.Context, arg SearchExpensesParams)
 			&i.Type,
 			&i.CategoryID,
 			&i.Tags,
+			&i.ExchangeRate,
 		); err != nil {
 			return nil, err
 		}
@@ -558,13 +571,14 @@ SET title = $2,
     notes = $3,
     amount = $4,
     currency_code = $5,
-    date = $6,
-    category_id = $7,
-    tags = $8,
-    updated_by = $9,
+    exchange_rate = $6,
+    date = $7,
+    category_id = $8,
+    tags = $9,
+    updated_by = $10,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, group_id, title, notes, amount, currency_code, date, created_at, created_by, updated_at, updated_by, deleted_at, type, category_id, tags
+RETURNING id, group_id, title, notes, amount, currency_code, date, created_at, created_by, updated_at, updated_by, deleted_at, type, category_id, tags, exchange_rate
 `
 
 type UpdateExpenseParams struct {
@@ -573,6 +587,7 @@ type UpdateExpenseParams struct {
 	Notes        pgtype.Text    `json:"notes"`
 	Amount       pgtype.Numeric `json:"amount"`
 	CurrencyCode string         `json:"currency_code"`
+	ExchangeRate pgtype.Numeric `json:"exchange_rate"`
 	Date         pgtype.Date    `json:"date"`
 	CategoryID   pgtype.UUID    `json:"category_id"`
 	Tags         []string       `json:"tags"`
@@ -586,6 +601,7 @@ func (q *Queries) UpdateExpense(ctx context.Context, arg UpdateExpenseParams) (E
 		arg.Notes,
 		arg.Amount,
 		arg.CurrencyCode,
+		arg.ExchangeRate,
 		arg.Date,
 		arg.CategoryID,
 		arg.Tags,
@@ -608,6 +624,7 @@ func (q *Queries) UpdateExpense(ctx context.Context, arg UpdateExpenseParams) (E
 		&i.Type,
 		&i.CategoryID,
 		&i.Tags,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
@@ -618,7 +635,7 @@ SET amount_owned = $3,
     split_type = $4,
     updated_at = CURRENT_TIMESTAMP
 WHERE expense_id = $1 AND user_id = $2 AND deleted_at IS NULL
-RETURNING id, expense_id, user_id, amount_owned, split_type, created_at, updated_at, deleted_at, pending_user_id, share_value
+RETURNING id, expense_id, user_id, amount_owned, split_type, created_at, updated_at, deleted_at, pending_user_id, share_value, amount_in_group_currency
 `
 
 type UpdateExpenseSplitParams struct {
@@ -647,6 +664,7 @@ func (q *Queries) UpdateExpenseSplit(ctx context.Context, arg UpdateExpenseSplit
 		&i.DeletedAt,
 		&i.PendingUserID,
 		&i.ShareValue,
+		&i.AmountInGroupCurrency,
 	)
 	return i, err
 }
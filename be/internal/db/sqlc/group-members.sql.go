@@ -89,13 +89,14 @@ SELECT
     g.description,
     g.currency_code,
     g.created_at,
+    g.archived_at,
     gm.id as membership_id,
     gm.role as member_role,
     gm.status as member_status,
     gm.joined_at as member_joined_at
 FROM group_members AS gm
 INNER JOIN groups AS g ON gm.group_id = g.id
-WHERE gm.user_id = $1 AND gm.deleted_at IS NULL AND g.deleted_at IS NULL
+WHERE gm.user_id = $1 AND gm.status != 'inactive' AND gm.deleted_at IS NULL AND g.deleted_at IS NULL
 ORDER BY g.created_at DESC
 `
 
@@ -105,6 +106,7 @@ type GetGroupsByUserIDRow struct {
 	Description    pgtype.Text        `json:"description"`
 	CurrencyCode   string             `json:"currency_code"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ArchivedAt     pgtype.Timestamptz `json:"archived_at"`
 	MembershipID   pgtype.UUID        `json:"membership_id"`
 	MemberRole     string             `json:"member_role"`
 	MemberStatus   string             `json:"member_status"`
@@ -126,6 +128,7 @@ func (q *Queries) GetGroupsByUserID(ctx context.Context, userID pgtype.UUID) ([]
 			&i.Description,
 			&i.CurrencyCode,
 			&i.CreatedAt,
+			&i.ArchivedAt,
 			&i.MembershipID,
 			&i.MemberRole,
 			&i.MemberStatus,
@@ -214,6 +217,38 @@ func (q *Queries) ListGroupMembers(ctx context.Context, groupID pgtype.UUID) ([]
 	return items, nil
 }
 
+const updateGroupMemberRole = `-- name: UpdateGroupMemberRole :one
+UPDATE group_members
+SET role = $3, updated_at = CURRENT_TIMESTAMP
+WHERE group_id = $1 AND user_id = $2 AND deleted_at IS NULL
+RETURNING id, group_id, user_id, role, status, invited_by, invited_at, joined_at, created_at, updated_at, deleted_at
+`
+
+type UpdateGroupMemberRoleParams struct {
+	GroupID pgtype.UUID `json:"group_id"`
+	UserID  pgtype.UUID `json:"user_id"`
+	Role    string      `json:"role"`
+}
+
+func (q *Queries) UpdateGroupMemberRole(ctx context.Context, arg UpdateGroupMemberRoleParams) (GroupMember, error) {
+	row := q.db.QueryRow(ctx, updateGroupMemberRole, arg.GroupID, arg.UserID, arg.Role)
+	var i GroupMember
+	err := row.Scan(
+		&i.ID,
+		&i.GroupID,
+		&i.UserID,
+		&i.Role,
+		&i.Status,
+		&i.InvitedBy,
+		&i.InvitedAt,
+		&i.JoinedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
 const updateGroupMemberStatus = `-- name: UpdateGroupMemberStatus :one
 UPDATE group_members
 SET status = $3, joined_at = $4, updated_at = CURRENT_TIMESTAMP
@@ -122,6 +122,52 @@ func (q *Queries) GetExpenseHistory(ctx context.Context, entityID pgtype.UUID) (
 	return items, nil
 }
 
+const getGroupActivity = `-- name: GetGroupActivity :one
+SELECT id, group_id, user_id, action, entity_type, entity_id, metadata, created_at FROM group_activities
+WHERE id = $1
+`
+
+func (q *Queries) GetGroupActivity(ctx context.Context, id pgtype.UUID) (GroupActivity, error) {
+	row := q.db.QueryRow(ctx, getGroupActivity, id)
+	var i GroupActivity
+	err := row.Scan(
+		&i.ID,
+		&i.GroupID,
+		&i.UserID,
+		&i.Action,
+		&i.EntityType,
+		&i.EntityID,
+		&i.Metadata,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listActiveGroupMemberIDs = `-- name: ListActiveGroupMemberIDs :many
+SELECT user_id FROM group_members
+WHERE group_id = $1 AND status = 'active'
+`
+
+func (q *Queries) ListActiveGroupMemberIDs(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listActiveGroupMemberIDs, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var user_id pgtype.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listGroupActivities = `-- name: ListGroupActivities :many
 SELECT 
     ga.id,
@@ -193,3 +239,103 @@ func (q *Queries) ListGroupActivities(ctx context.Context, arg ListGroupActiviti
 	}
 	return items, nil
 }
+
+const listGroupActivitiesAfter = `-- name: ListGroupActivitiesAfter :many
+SELECT ga.id, ga.group_id, ga.user_id, ga.action, ga.entity_type, ga.entity_id, ga.metadata, ga.created_at FROM group_activities ga
+JOIN groups g ON g.id = ga.group_id
+WHERE ga.group_id = $1
+  AND g.deleted_at IS NULL
+  AND (ga.created_at, ga.id) > (
+    SELECT a.created_at, a.id FROM group_activities a WHERE a.id = $2
+  )
+ORDER BY ga.created_at, ga.id
+LIMIT $3
+`
+
+type ListGroupActivitiesAfterParams struct {
+	GroupID pgtype.UUID `json:"group_id"`
+	AfterID pgtype.UUID `json:"after_id"`
+	Limit   int32       `json:"limit"`
+}
+
+// Activities of the group logged after after_id, oldest first. Event streams
+// use it to replay what a client missed; an unknown after_id or a deleted group
+// replays nothing.
+func (q *Queries) ListGroupActivitiesAfter(ctx context.Context, arg ListGroupActivitiesAfterParams) ([]GroupActivity, error) {
+	rows, err := q.db.Query(ctx, listGroupActivitiesAfter, arg.GroupID, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GroupActivity{}
+	for rows.Next() {
+		var i GroupActivity
+		if err := rows.Scan(
+			&i.ID,
+			&i.GroupID,
+			&i.UserID,
+			&i.Action,
+			&i.EntityType,
+			&i.EntityID,
+			&i.Metadata,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserActivitiesAfter = `-- name: ListUserActivitiesAfter :many
+SELECT ga.id, ga.group_id, ga.user_id, ga.action, ga.entity_type, ga.entity_id, ga.metadata, ga.created_at FROM group_activities ga
+JOIN group_members gm ON gm.group_id = ga.group_id
+JOIN groups g ON g.id = ga.group_id
+WHERE gm.user_id = $1
+  AND gm.status = 'active'
+  AND g.deleted_at IS NULL
+  AND (ga.created_at, ga.id) > (
+    SELECT a.created_at, a.id FROM group_activities a WHERE a.id = $2
+  )
+ORDER BY ga.created_at, ga.id
+LIMIT $3
+`
+
+type ListUserActivitiesAfterParams struct {
+	UserID  pgtype.UUID `json:"user_id"`
+	AfterID pgtype.UUID `json:"after_id"`
+	Limit   int32       `json:"limit"`
+}
+
+// Like ListGroupActivitiesAfter, across every group the user is an active member of.
+func (q *Queries) ListUserActivitiesAfter(ctx context.Context, arg ListUserActivitiesAfterParams) ([]GroupActivity, error) {
+	rows, err := q.db.Query(ctx, listUserActivitiesAfter, arg.UserID, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GroupActivity{}
+	for rows.Next() {
+		var i GroupActivity
+		if err := rows.Scan(
+			&i.ID,
+			&i.GroupID,
+			&i.UserID,
+			&i.Action,
+			&i.EntityType,
+			&i.EntityID,
+			&i.Metadata,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -226,6 +226,37 @@ func (q *Queries) ListInvitationsByGroup(ctx context.Context, groupID pgtype.UUI
 	return items, nil
 }
 
+const renewInvitation = `-- name: RenewInvitation :one
+UPDATE group_invitations
+SET token = $2, expires_at = $3, updated_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING id, group_id, email, token, role, status, invited_by, expires_at, created_at, updated_at
+`
+
+type RenewInvitationParams struct {
+	ID        pgtype.UUID        `json:"id"`
+	Token     string             `json:"token"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) RenewInvitation(ctx context.Context, arg RenewInvitationParams) (GroupInvitation, error) {
+	row := q.db.QueryRow(ctx, renewInvitation, arg.ID, arg.Token, arg.ExpiresAt)
+	var i GroupInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.GroupID,
+		&i.Email,
+		&i.Token,
+		&i.Role,
+		&i.Status,
+		&i.InvitedBy,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateInvitationStatus = `-- name: UpdateInvitationStatus :one
 UPDATE group_invitations
 SET status = $2, updated_at = NOW()
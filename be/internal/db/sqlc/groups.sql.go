@@ -11,9 +11,55 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveGroup = `-- name: ArchiveGroup :one
+UPDATE groups
+SET archived_at = CURRENT_TIMESTAMP,
+    updated_by = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND archived_at IS NULL
+RETURNING id, name, description, currency_code, default_split_method, settings, created_at, created_by, updated_at, updated_by, deleted_at, archived_at
+`
+
+type ArchiveGroupParams struct {
+	ID        pgtype.UUID `json:"id"`
+	UpdatedBy pgtype.UUID `json:"updated_by"`
+}
+
+func (q *Queries) ArchiveGroup(ctx context.Context, arg ArchiveGroupParams) (Group, error) {
+	row := q.db.QueryRow(ctx, archiveGroup, arg.ID, arg.UpdatedBy)
+	var i Group
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CurrencyCode,
+		&i.DefaultSplitMethod,
+		&i.Settings,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdatedAt,
+		&i.UpdatedBy,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+	)
+	return i, err
+}
+
+const countGroupExpenses = `-- name: CountGroupExpenses :one
+SELECT COUNT(*) FROM expenses
+WHERE group_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountGroupExpenses(ctx context.Context, groupID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countGroupExpenses, groupID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createGroup = `-- name: CreateGroup :one
 INSERT INTO groups (name, description, currency_code, created_by, updated_by)
-VALUES ($1, $2, $3, $4, $4) RETURNING id, name, description, currency_code, default_split_method, settings, created_at, created_by, updated_at, updated_by, deleted_at
+VALUES ($1, $2, $3, $4, $4) RETURNING id, name, description, currency_code, default_split_method, settings, created_at, created_by, updated_at, updated_by, deleted_at, archived_at
 `
 
 type CreateGroupParams struct {
@@ -43,12 +89,31 @@ func (q *Queries) CreateGroup(ctx context.Context, arg CreateGroupParams) (Group
 		&i.UpdatedAt,
 		&i.UpdatedBy,
 		&i.DeletedAt,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
 
+const deleteGroup = `-- name: DeleteGroup :exec
+UPDATE groups
+SET deleted_at = CURRENT_TIMESTAMP,
+    updated_by = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type DeleteGroupParams struct {
+	ID        pgtype.UUID `json:"id"`
+	UpdatedBy pgtype.UUID `json:"updated_by"`
+}
+
+func (q *Queries) DeleteGroup(ctx context.Context, arg DeleteGroupParams) error {
+	_, err := q.db.Exec(ctx, deleteGroup, arg.ID, arg.UpdatedBy)
+	return err
+}
+
 const getGroupByID = `-- name: GetGroupByID :one
-SELECT id, name, description, currency_code, default_split_method, settings, created_at, created_by, updated_at, updated_by, deleted_at FROM groups
+SELECT id, name, description, currency_code, default_split_method, settings, created_at, created_by, updated_at, updated_by, deleted_at, archived_at FROM groups
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -67,6 +132,92 @@ func (q *Queries) GetGroupByID(ctx context.Context, id pgtype.UUID) (Group, erro
 		&i.UpdatedAt,
 		&i.UpdatedBy,
 		&i.DeletedAt,
+		&i.ArchivedAt,
+	)
+	return i, err
+}
+
+const restoreGroup = `-- name: RestoreGroup :one
+UPDATE groups
+SET archived_at = NULL,
+    updated_by = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND archived_at IS NOT NULL
+RETURNING id, name, description, currency_code, default_split_method, settings, created_at, created_by, updated_at, updated_by, deleted_at, archived_at
+`
+
+type RestoreGroupParams struct {
+	ID        pgtype.UUID `json:"id"`
+	UpdatedBy pgtype.UUID `json:"updated_by"`
+}
+
+func (q *Queries) RestoreGroup(ctx context.Context, arg RestoreGroupParams) (Group, error) {
+	row := q.db.QueryRow(ctx, restoreGroup, arg.ID, arg.UpdatedBy)
+	var i Group
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CurrencyCode,
+		&i.DefaultSplitMethod,
+		&i.Settings,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdatedAt,
+		&i.UpdatedBy,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+	)
+	return i, err
+}
+
+const updateGroup = `-- name: UpdateGroup :one
+UPDATE groups
+SET name = $2,
+    description = $3,
+    currency_code = $4,
+    default_split_method = $5,
+    settings = $6,
+    updated_by = $7,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, description, currency_code, default_split_method, settings, created_at, created_by, updated_at, updated_by, deleted_at, archived_at
+`
+
+type UpdateGroupParams struct {
+	ID                 pgtype.UUID `json:"id"`
+	Name               string      `json:"name"`
+	Description        pgtype.Text `json:"description"`
+	CurrencyCode       string      `json:"currency_code"`
+	DefaultSplitMethod string      `json:"default_split_method"`
+	Settings           []byte      `json:"settings"`
+	UpdatedBy          pgtype.UUID `json:"updated_by"`
+}
+
+func (q *Queries) UpdateGroup(ctx context.Context, arg UpdateGroupParams) (Group, error) {
+	row := q.db.QueryRow(ctx, updateGroup,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.CurrencyCode,
+		arg.DefaultSplitMethod,
+		arg.Settings,
+		arg.UpdatedBy,
+	)
+	var i Group
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CurrencyCode,
+		&i.DefaultSplitMethod,
+		&i.Settings,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdatedAt,
+		&i.UpdatedBy,
+		&i.DeletedAt,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
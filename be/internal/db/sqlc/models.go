@@ -8,6 +8,31 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type DebtReminder struct {
+	GroupID        pgtype.UUID        `json:"group_id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	OwingSince     pgtype.Timestamptz `json:"owing_since"`
+	LastRemindedAt pgtype.Timestamptz `json:"last_reminded_at"`
+}
+
+type EmailOutbox struct {
+	ID            pgtype.UUID        `json:"id"`
+	Template      string             `json:"template"`
+	ToAddress     string             `json:"to_address"`
+	Subject       string             `json:"subject"`
+	TextBody      string             `json:"text_body"`
+	HtmlBody      string             `json:"html_body"`
+	Status        string             `json:"status"`
+	Attempts      int32              `json:"attempts"`
+	MaxAttempts   int32              `json:"max_attempts"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	// Set while a worker is sending; rows stuck in sending are retried once it is stale.
+	LockedAt  pgtype.Timestamptz `json:"locked_at"`
+	LastError pgtype.Text        `json:"last_error"`
+	SentAt    pgtype.Timestamptz `json:"sent_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Expense struct {
 	ID           pgtype.UUID        `json:"id"`
 	GroupID      pgtype.UUID        `json:"group_id"`
@@ -24,6 +49,22 @@ type Expense struct {
 	Type         string             `json:"type"`
 	CategoryID   pgtype.UUID        `json:"category_id"`
 	Tags         []string           `json:"tags"`
+	// Rate used to convert amount from currency_code into the group currency when the expense was recorded. 1 when both currencies match.
+	ExchangeRate pgtype.Numeric `json:"exchange_rate"`
+}
+
+type ExpenseAttachment struct {
+	ID         pgtype.UUID `json:"id"`
+	ExpenseID  pgtype.UUID `json:"expense_id"`
+	UploadedBy pgtype.UUID `json:"uploaded_by"`
+	FileName   string      `json:"file_name"`
+	// Sniffed from the uploaded bytes, never taken from the client.
+	ContentType    string `json:"content_type"`
+	SizeBytes      int64  `json:"size_bytes"`
+	ChecksumSha256 string `json:"checksum_sha256"`
+	// Backend-relative key of the stored file.
+	StorageKey string             `json:"storage_key"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
 type ExpenseCategory struct {
@@ -50,6 +91,27 @@ type ExpenseComment struct {
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type ExpenseItem struct {
+	ID        pgtype.UUID `json:"id"`
+	ExpenseID pgtype.UUID `json:"expense_id"`
+	// item rows carry consumers; tax, tip and discount rows are distributed in proportion to each consumer's item subtotal.
+	Kind      string             `json:"kind"`
+	Name      string             `json:"name"`
+	Amount    pgtype.Numeric     `json:"amount"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type ExpenseItemConsumer struct {
+	ID            pgtype.UUID        `json:"id"`
+	ExpenseItemID pgtype.UUID        `json:"expense_item_id"`
+	UserID        pgtype.UUID        `json:"user_id"`
+	PendingUserID pgtype.UUID        `json:"pending_user_id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
 type ExpensePayment struct {
 	ID            pgtype.UUID        `json:"id"`
 	ExpenseID     pgtype.UUID        `json:"expense_id"`
@@ -60,6 +122,8 @@ type ExpensePayment struct {
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
 	PendingUserID pgtype.UUID        `json:"pending_user_id"`
+	// amount converted into the group currency at the expense exchange_rate. The payments of an expense sum to its converted amount rounded to cents; the largest payment absorbs the rounding remainder.
+	AmountInGroupCurrency pgtype.Numeric `json:"amount_in_group_currency"`
 }
 
 type ExpenseSplit struct {
@@ -72,8 +136,10 @@ type ExpenseSplit struct {
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
 	PendingUserID pgtype.UUID        `json:"pending_user_id"`
-	// For percentage splits: the percentage value (0-100). For shares splits: the share count. NULL for equal/fixed/custom.
+	// For percentage splits: the percentage value (0-100). For shares splits: the share count. For itemized splits: the item subtotal before tax, tip and discount. NULL for equal/fixed/custom.
 	ShareValue pgtype.Numeric `json:"share_value"`
+	// amount_owned converted into the group currency at the expense exchange_rate. The splits of an expense sum to its converted amount rounded to cents; the largest split absorbs the rounding remainder.
+	AmountInGroupCurrency pgtype.Numeric `json:"amount_in_group_currency"`
 }
 
 type Friendship struct {
@@ -98,6 +164,8 @@ type Group struct {
 	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
 	UpdatedBy          pgtype.UUID        `json:"updated_by"`
 	DeletedAt          pgtype.Timestamptz `json:"deleted_at"`
+	// When the group was archived. NULL for active groups.
+	ArchivedAt pgtype.Timestamptz `json:"archived_at"`
 }
 
 type GroupActivity struct {
@@ -111,6 +179,22 @@ type GroupActivity struct {
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
+type GroupBudget struct {
+	ID      pgtype.UUID `json:"id"`
+	GroupID pgtype.UUID `json:"group_id"`
+	// The category the budget is limited to. NULL counts every expense in the group.
+	CategoryID pgtype.UUID        `json:"category_id"`
+	Name       string             `json:"name"`
+	Amount     pgtype.Numeric     `json:"amount"`
+	Period     string             `json:"period"`
+	StartDate  pgtype.Date        `json:"start_date"`
+	EndDate    pgtype.Date        `json:"end_date"`
+	Thresholds []int32            `json:"thresholds"`
+	CreatedBy  pgtype.UUID        `json:"created_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
 type GroupInvitation struct {
 	ID        pgtype.UUID        `json:"id"`
 	GroupID   pgtype.UUID        `json:"group_id"`
@@ -124,6 +208,19 @@ type GroupInvitation struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+type GroupJoinLink struct {
+	ID      pgtype.UUID `json:"id"`
+	GroupID pgtype.UUID `json:"group_id"`
+	Token   string      `json:"token"`
+	// NULL means the link can be used any number of times until it expires.
+	MaxUses   pgtype.Int4        `json:"max_uses"`
+	UseCount  int32              `json:"use_count"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type GroupMember struct {
 	ID        pgtype.UUID        `json:"id"`
 	GroupID   pgtype.UUID        `json:"group_id"`
@@ -138,6 +235,48 @@ type GroupMember struct {
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type GroupWebhook struct {
+	ID        pgtype.UUID        `json:"id"`
+	GroupID   pgtype.UUID        `json:"group_id"`
+	Url       string             `json:"url"`
+	Secret    string             `json:"secret"`
+	Events    []string           `json:"events"`
+	Active    bool               `json:"active"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Notification struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	ActivityID pgtype.UUID        `json:"activity_id"`
+	GroupID    pgtype.UUID        `json:"group_id"`
+	ActorID    pgtype.UUID        `json:"actor_id"`
+	Category   string             `json:"category"`
+	Action     string             `json:"action"`
+	EntityType string             `json:"entity_type"`
+	EntityID   pgtype.UUID        `json:"entity_id"`
+	Metadata   []byte             `json:"metadata"`
+	ReadAt     pgtype.Timestamptz `json:"read_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type NotificationMute struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Category  string             `json:"category"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type OidcLoginState struct {
+	State        string             `json:"state"`
+	Provider     string             `json:"provider"`
+	CodeVerifier string             `json:"code_verifier"`
+	Nonce        string             `json:"nonce"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
 type PendingUser struct {
 	ID        pgtype.UUID        `json:"id"`
 	Email     string             `json:"email"`
@@ -146,6 +285,24 @@ type PendingUser struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+type PersonalAccessToken struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Name        string             `json:"name"`
+	TokenHash   string             `json:"token_hash"`
+	TokenPrefix string             `json:"token_prefix"`
+	Scopes      []string           `json:"scopes"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type RateLimitCounter struct {
+	Key          string             `json:"key"`
+	Count        int32              `json:"count"`
+	WindowEndsAt pgtype.Timestamptz `json:"window_ends_at"`
+}
+
 type RecurringExpense struct {
 	ID                 pgtype.UUID        `json:"id"`
 	GroupID            pgtype.UUID        `json:"group_id"`
@@ -189,6 +346,23 @@ type RecurringExpenseSplit struct {
 	DeletedAt          pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type RefreshTokenHistory struct {
+	TokenHash string             `json:"token_hash"`
+	SessionID pgtype.UUID        `json:"session_id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	RotatedAt pgtype.Timestamptz `json:"rotated_at"`
+}
+
+type SecurityEvent struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	SessionID pgtype.UUID        `json:"session_id"`
+	EventType string             `json:"event_type"`
+	IpAddress pgtype.Text        `json:"ip_address"`
+	UserAgent pgtype.Text        `json:"user_agent"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Session struct {
 	ID               pgtype.UUID        `json:"id"`
 	UserID           pgtype.UUID        `json:"user_id"`
@@ -220,6 +394,10 @@ type Settlement struct {
 	Type                 string             `json:"type"`
 	PayerPendingUserID   pgtype.UUID        `json:"payer_pending_user_id"`
 	PayeePendingUserID   pgtype.UUID        `json:"payee_pending_user_id"`
+	ConfirmedAt          pgtype.Timestamptz `json:"confirmed_at"`
+	DisputedAt           pgtype.Timestamptz `json:"disputed_at"`
+	CancelledAt          pgtype.Timestamptz `json:"cancelled_at"`
+	DisputeReason        pgtype.Text        `json:"dispute_reason"`
 }
 
 type ThemePreset struct {
@@ -241,6 +419,14 @@ type TokenBlacklist struct {
 	Reason        pgtype.Text        `json:"reason"`
 }
 
+type TwoFactorChallenge struct {
+	TokenHash      string             `json:"token_hash"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	FailedAttempts int32              `json:"failed_attempts"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
 type User struct {
 	ID              pgtype.UUID        `json:"id"`
 	Email           string             `json:"email"`
@@ -255,6 +441,41 @@ type User struct {
 	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type UserIdentity struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Provider    string             `json:"provider"`
+	Subject     string             `json:"subject"`
+	Email       pgtype.Text        `json:"email"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	LastLoginAt pgtype.Timestamptz `json:"last_login_at"`
+}
+
+type UserLoginAttempt struct {
+	UserID         pgtype.UUID        `json:"user_id"`
+	FailedAttempts int32              `json:"failed_attempts"`
+	LastFailedAt   pgtype.Timestamptz `json:"last_failed_at"`
+	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
+}
+
+type UserPaymentMethod struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Provider  string             `json:"provider"`
+	Handle    string             `json:"handle"`
+	Priority  int32              `json:"priority"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UserRecoveryCode struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CodeHash  string             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type UserTheme struct {
 	ID             pgtype.UUID        `json:"id"`
 	UserID         pgtype.UUID        `json:"user_id"`
@@ -268,6 +489,26 @@ type UserTheme struct {
 	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type UserToken struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Purpose   string             `json:"purpose"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserTwoFactor struct {
+	UserID         pgtype.UUID        `json:"user_id"`
+	Secret         string             `json:"secret"`
+	EnabledAt      pgtype.Timestamptz `json:"enabled_at"`
+	LastUsedStep   int64              `json:"last_used_step"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	FailedAttempts int32              `json:"failed_attempts"`
+	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
+}
+
 type UserThemePreference struct {
 	UserID            pgtype.UUID        `json:"user_id"`
 	ActiveType        string             `json:"active_type"`
@@ -277,3 +518,21 @@ type UserThemePreference struct {
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 }
+
+type WebhookDelivery struct {
+	ID             pgtype.UUID        `json:"id"`
+	WebhookID      pgtype.UUID        `json:"webhook_id"`
+	EventID        pgtype.UUID        `json:"event_id"`
+	EventType      string             `json:"event_type"`
+	Payload        []byte             `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	MaxAttempts    int32              `json:"max_attempts"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	LockedAt       pgtype.Timestamptz `json:"locked_at"`
+	ResponseStatus pgtype.Int4        `json:"response_status"`
+	ResponseBody   pgtype.Text        `json:"response_body"`
+	LastError      pgtype.Text        `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
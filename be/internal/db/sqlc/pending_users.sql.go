@@ -47,6 +47,36 @@ func (q *Queries) DeletePendingUserByID(ctx context.Context, id pgtype.UUID) err
 	return err
 }
 
+const getGroupPendingUserByEmail = `-- name: GetGroupPendingUserByEmail :one
+SELECT pu.id, pu.email, pu.name, pu.created_at, pu.updated_at
+FROM pending_users pu
+JOIN group_invitations gi ON gi.email = pu.email
+WHERE gi.group_id = $1
+  AND pu.email = $2
+  AND gi.status = 'pending'
+  AND gi.expires_at > NOW()
+LIMIT 1
+`
+
+type GetGroupPendingUserByEmailParams struct {
+	GroupID pgtype.UUID `json:"group_id"`
+	Email   string      `json:"email"`
+}
+
+// Returns the pending user with the given email if they have an open invitation to the group
+func (q *Queries) GetGroupPendingUserByEmail(ctx context.Context, arg GetGroupPendingUserByEmailParams) (PendingUser, error) {
+	row := q.db.QueryRow(ctx, getGroupPendingUserByEmail, arg.GroupID, arg.Email)
+	var i PendingUser
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getPendingUserByEmail = `-- name: GetPendingUserByEmail :one
 SELECT id, email, name, created_at, updated_at FROM pending_users WHERE email = $1
 `
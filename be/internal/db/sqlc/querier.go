@@ -11,81 +11,187 @@ import (
 )
 
 type Querier interface {
+	ArchiveGroup(ctx context.Context, arg ArchiveGroupParams) (Group, error)
 	BlacklistToken(ctx context.Context, arg BlacklistTokenParams) error
+	// Records a reminder unless the member got one after reminded_before; no rows
+	// affected means the reminder must not be sent.
+	ClaimDebtReminder(ctx context.Context, arg ClaimDebtReminderParams) (int64, error)
+	ClaimDueEmails(ctx context.Context, limit int32) ([]EmailOutbox, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, limit int32) ([]ClaimDueWebhookDeliveriesRow, error)
+	// Marks members no longer in debt as settled, so their next debt starts a new
+	// age. The last reminder is kept, so settling up doesn't reset the interval.
+	ClearSettledDebtors(ctx context.Context, arg ClearSettledDebtorsParams) error
+	// Checks the group again, so a link can't be used once its group is deleted or
+	// archived after it was looked up.
+	ConsumeJoinLink(ctx context.Context, id pgtype.UUID) (GroupJoinLink, error)
+	// Deletes the state as it is read, so a callback can't be replayed.
+	ConsumeOIDCLoginState(ctx context.Context, state string) (OidcLoginState, error)
 	CountExpenseComments(ctx context.Context, expenseID pgtype.UUID) (int64, error)
+	CountGroupBudgets(ctx context.Context, groupID pgtype.UUID) (int64, error)
+	CountGroupExpenses(ctx context.Context, groupID pgtype.UUID) (int64, error)
+	CountGroupWebhooks(ctx context.Context, groupID pgtype.UUID) (int64, error)
+	CountUnreadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUserPersonalAccessTokens(ctx context.Context, userID pgtype.UUID) (int64, error)
 	CreateExpense(ctx context.Context, arg CreateExpenseParams) (Expense, error)
+	CreateExpenseAttachment(ctx context.Context, arg CreateExpenseAttachmentParams) (ExpenseAttachment, error)
 	CreateExpenseComment(ctx context.Context, arg CreateExpenseCommentParams) (ExpenseComment, error)
+	CreateExpenseItem(ctx context.Context, arg CreateExpenseItemParams) (ExpenseItem, error)
+	CreateExpenseItemConsumer(ctx context.Context, arg CreateExpenseItemConsumerParams) (ExpenseItemConsumer, error)
 	CreateExpensePayment(ctx context.Context, arg CreateExpensePaymentParams) (ExpensePayment, error)
 	CreateExpenseSplit(ctx context.Context, arg CreateExpenseSplitParams) (ExpenseSplit, error)
 	CreateFriendship(ctx context.Context, arg CreateFriendshipParams) (Friendship, error)
 	CreateGroup(ctx context.Context, arg CreateGroupParams) (Group, error)
 	CreateGroupActivity(ctx context.Context, arg CreateGroupActivityParams) (GroupActivity, error)
+	CreateGroupBudget(ctx context.Context, arg CreateGroupBudgetParams) (GroupBudget, error)
 	CreateGroupCategory(ctx context.Context, arg CreateGroupCategoryParams) (ExpenseCategory, error)
 	CreateGroupMember(ctx context.Context, arg CreateGroupMemberParams) (GroupMember, error)
+	CreateGroupWebhook(ctx context.Context, arg CreateGroupWebhookParams) (GroupWebhook, error)
 	CreateInvitation(ctx context.Context, arg CreateInvitationParams) (GroupInvitation, error)
+	CreateJoinLink(ctx context.Context, arg CreateJoinLinkParams) (GroupJoinLink, error)
+	// Fans the activity out to the given users, skipping those who muted its category.
+	CreateNotifications(ctx context.Context, arg CreateNotificationsParams) error
+	CreateOIDCLoginState(ctx context.Context, arg CreateOIDCLoginStateParams) error
 	CreatePendingUser(ctx context.Context, arg CreatePendingUserParams) (PendingUser, error)
+	CreatePersonalAccessToken(ctx context.Context, arg CreatePersonalAccessTokenParams) (PersonalAccessToken, error)
+	CreateRecoveryCodes(ctx context.Context, arg CreateRecoveryCodesParams) error
 	CreateRecurringExpense(ctx context.Context, arg CreateRecurringExpenseParams) (RecurringExpense, error)
 	CreateRecurringExpensePayment(ctx context.Context, arg CreateRecurringExpensePaymentParams) (RecurringExpensePayment, error)
 	CreateRecurringExpenseSplit(ctx context.Context, arg CreateRecurringExpenseSplitParams) (RecurringExpenseSplit, error)
+	CreateSecurityEvent(ctx context.Context, arg CreateSecurityEventParams) error
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateSettlement(ctx context.Context, arg CreateSettlementParams) (Settlement, error)
+	CreateTwoFactorChallenge(ctx context.Context, arg CreateTwoFactorChallengeParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error)
 	CreateUserTheme(ctx context.Context, arg CreateUserThemeParams) (UserTheme, error)
+	CreateUserToken(ctx context.Context, arg CreateUserTokenParams) (UserToken, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
 	DeleteAllUserSessions(ctx context.Context, userID pgtype.UUID) error
 	DeleteExpense(ctx context.Context, id pgtype.UUID) error
+	DeleteExpenseAttachment(ctx context.Context, id pgtype.UUID) error
 	DeleteExpenseComment(ctx context.Context, id pgtype.UUID) error
+	DeleteExpenseItems(ctx context.Context, expenseID pgtype.UUID) error
 	DeleteExpensePayments(ctx context.Context, expenseID pgtype.UUID) error
 	DeleteExpenseSplits(ctx context.Context, expenseID pgtype.UUID) error
 	DeleteExpiredBlacklistedTokens(ctx context.Context) error
+	DeleteExpiredOIDCLoginStates(ctx context.Context) error
+	DeleteExpiredRateLimitCounters(ctx context.Context) error
 	DeleteExpiredSessions(ctx context.Context) error
+	DeleteExpiredTwoFactorChallenges(ctx context.Context) error
+	DeleteExpiredUserTokens(ctx context.Context) error
 	DeleteFriendship(ctx context.Context, id pgtype.UUID) error
+	DeleteGroup(ctx context.Context, arg DeleteGroupParams) error
+	DeleteGroupBudget(ctx context.Context, arg DeleteGroupBudgetParams) error
 	DeleteGroupCategory(ctx context.Context, arg DeleteGroupCategoryParams) error
+	DeleteGroupWebhook(ctx context.Context, arg DeleteGroupWebhookParams) error
 	DeletePendingUserByID(ctx context.Context, id pgtype.UUID) error
 	DeleteRecurringExpense(ctx context.Context, id pgtype.UUID) error
 	DeleteSession(ctx context.Context, refreshTokenHash string) error
+	DeleteSessionByID(ctx context.Context, id pgtype.UUID) error
 	DeleteSettlement(ctx context.Context, id pgtype.UUID) error
+	DeleteTwoFactorChallenge(ctx context.Context, tokenHash string) error
+	DeleteUserPaymentMethod(ctx context.Context, arg DeleteUserPaymentMethodParams) error
+	DeleteUserPersonalAccessToken(ctx context.Context, arg DeleteUserPersonalAccessTokenParams) (pgtype.UUID, error)
+	DeleteUserRecoveryCodes(ctx context.Context, userID pgtype.UUID) error
 	DeleteUserTheme(ctx context.Context, id pgtype.UUID) error
+	DeleteUserTwoFactor(ctx context.Context, userID pgtype.UUID) error
+	EnableUserTwoFactor(ctx context.Context, userID pgtype.UUID) error
+	// A NULL send_after sends the email right away.
+	EnqueueEmail(ctx context.Context, arg EnqueueEmailParams) (EmailOutbox, error)
+	// Queues the event for every active webhook of the group that subscribed to it.
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) error
 	GetActiveSessionsByUserID(ctx context.Context, userID pgtype.UUID) ([]Session, error)
+	// Total of the group's expenses dated within the period, in the group currency.
+	// A NULL category counts every expense.
+	GetBudgetSpent(ctx context.Context, arg GetBudgetSpentParams) (pgtype.Numeric, error)
 	GetCategoryByID(ctx context.Context, id pgtype.UUID) (ExpenseCategory, error)
 	GetCategoryBySlug(ctx context.Context, arg GetCategoryBySlugParams) (ExpenseCategory, error)
+	GetExpenseAttachmentByID(ctx context.Context, id pgtype.UUID) (ExpenseAttachment, error)
 	GetExpenseByID(ctx context.Context, id pgtype.UUID) (Expense, error)
 	GetExpenseCommentByID(ctx context.Context, id pgtype.UUID) (GetExpenseCommentByIDRow, error)
 	GetExpenseHistory(ctx context.Context, entityID pgtype.UUID) ([]GetExpenseHistoryRow, error)
 	GetFriendship(ctx context.Context, arg GetFriendshipParams) (Friendship, error)
 	GetFriendshipByID(ctx context.Context, id pgtype.UUID) (Friendship, error)
+	GetGroupActivity(ctx context.Context, id pgtype.UUID) (GroupActivity, error)
 	// Calculate balance for each user in a group
 	// Balance = total_paid - total_owed
 	// Positive balance means user is owed money, negative means user owes money
-	GetGroupBalances(ctx context.Context, groupID pgtype.UUID) ([]GetGroupBalancesRow, error)
+	// Expense amounts are in the group currency (amount_in_group_currency)
+	// With confirmed_only, settlements still waiting for the payee to confirm are ignored
+	GetGroupBalances(ctx context.Context, arg GetGroupBalancesParams) ([]GetGroupBalancesRow, error)
 	// Calculate balance for each user or pending user in a group
 	// Balance = total_paid - total_owed
 	// Positive balance means entity is owed money, negative means entity owes money
-	GetGroupBalancesWithPending(ctx context.Context, groupID pgtype.UUID) ([]GetGroupBalancesWithPendingRow, error)
+	// Expense amounts are in the group currency (amount_in_group_currency)
+	// With confirmed_only, settlements still waiting for the payee to confirm are ignored
+	GetGroupBalancesWithPending(ctx context.Context, arg GetGroupBalancesWithPendingParams) ([]GetGroupBalancesWithPendingRow, error)
+	GetGroupBudget(ctx context.Context, arg GetGroupBudgetParams) (GroupBudget, error)
 	GetGroupByID(ctx context.Context, id pgtype.UUID) (Group, error)
 	GetGroupMember(ctx context.Context, arg GetGroupMemberParams) (GroupMember, error)
+	// Returns the pending user with the given email if they have an open invitation to the group
+	GetGroupPendingUserByEmail(ctx context.Context, arg GetGroupPendingUserByEmailParams) (PendingUser, error)
+	GetGroupWebhook(ctx context.Context, arg GetGroupWebhookParams) (GroupWebhook, error)
 	GetGroupsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetGroupsByUserIDRow, error)
 	GetInvitationByID(ctx context.Context, id pgtype.UUID) (GroupInvitation, error)
 	GetInvitationByToken(ctx context.Context, token string) (GetInvitationByTokenRow, error)
+	GetJoinLinkByID(ctx context.Context, id pgtype.UUID) (GroupJoinLink, error)
+	// Links of deleted or archived groups can't be used, like revoked ones.
+	GetJoinLinkByToken(ctx context.Context, token string) (GetJoinLinkByTokenRow, error)
 	// Get user's balance across all groups
-	GetOverallUserBalance(ctx context.Context, userID pgtype.UUID) ([]GetOverallUserBalanceRow, error)
+	// With confirmed_only, settlements still waiting for the payee to confirm are ignored
+	GetOverallUserBalance(ctx context.Context, arg GetOverallUserBalanceParams) ([]GetOverallUserBalanceRow, error)
 	GetPendingInvitationsByEmail(ctx context.Context, email string) ([]GetPendingInvitationsByEmailRow, error)
 	GetPendingUserByEmail(ctx context.Context, email string) (PendingUser, error)
 	GetPendingUserByID(ctx context.Context, id pgtype.UUID) (PendingUser, error)
+	GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (PersonalAccessToken, error)
 	GetRecurringExpenseByID(ctx context.Context, id pgtype.UUID) (RecurringExpense, error)
 	GetRecurringExpensesDue(ctx context.Context) ([]RecurringExpense, error)
+	GetRefreshTokenHistory(ctx context.Context, tokenHash string) (RefreshTokenHistory, error)
 	GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (Session, error)
 	GetSettlementByID(ctx context.Context, id pgtype.UUID) (Settlement, error)
+	GetSettlementParticipants(ctx context.Context, id pgtype.UUID) (GetSettlementParticipantsRow, error)
 	GetThemePresetBySlug(ctx context.Context, slug string) (ThemePreset, error)
+	GetTwoFactorChallenge(ctx context.Context, tokenHash string) (TwoFactorChallenge, error)
 	// Get balance for a specific user in a group
+	// With confirmed_only, settlements still waiting for the payee to confirm are ignored
 	GetUserBalanceInGroup(ctx context.Context, arg GetUserBalanceInGroupParams) (GetUserBalanceInGroupRow, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error)
+	GetUserLoginAttempts(ctx context.Context, userID pgtype.UUID) (UserLoginAttempt, error)
+	GetUserPaymentMethod(ctx context.Context, arg GetUserPaymentMethodParams) (UserPaymentMethod, error)
+	GetUserSession(ctx context.Context, arg GetUserSessionParams) (Session, error)
 	GetUserThemeByID(ctx context.Context, id pgtype.UUID) (UserTheme, error)
 	GetUserThemePreferences(ctx context.Context, userID pgtype.UUID) (UserThemePreference, error)
+	GetUserTokenByHash(ctx context.Context, tokenHash string) (UserToken, error)
+	GetUserTwoFactor(ctx context.Context, userID pgtype.UUID) (UserTwoFactor, error)
+	GetWebhookDelivery(ctx context.Context, arg GetWebhookDeliveryParams) (WebhookDelivery, error)
 	HasPendingMemberInvitation(ctx context.Context, arg HasPendingMemberInvitationParams) (bool, error)
+	// Counts one request against key, starting a new window when the previous one
+	// has ended.
+	IncrementRateLimitCounter(ctx context.Context, arg IncrementRateLimitCounterParams) (IncrementRateLimitCounterRow, error)
+	InvalidateUserTokens(ctx context.Context, arg InvalidateUserTokensParams) error
+	IsSessionActive(ctx context.Context, id pgtype.UUID) (bool, error)
 	IsTokenBlacklisted(ctx context.Context, tokenJti string) (bool, error)
+	ListActiveGroupMemberIDs(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error)
+	// Budgets an expense in the category counts towards: the group-wide ones and
+	// the category's own. A NULL category only matches group-wide budgets.
+	ListBudgetsForCategory(ctx context.Context, arg ListBudgetsForCategoryParams) ([]GroupBudget, error)
 	ListCategoriesForGroup(ctx context.Context, groupID pgtype.UUID) ([]ExpenseCategory, error)
+	ListDebtReminderStates(ctx context.Context, groupID pgtype.UUID) ([]ListDebtReminderStatesRow, error)
+	// Net position of a user towards each counterpart from direct friend expenses
+	// and settlements (type = 'friend', no group), per currency
+	// Only expenses shared by exactly the user and one other registered user count
+	// Positive balance means the counterpart owes the user
+	// With confirmed_only, settlements still waiting for the payee to confirm are ignored
+	ListDirectFriendBalances(ctx context.Context, arg ListDirectFriendBalancesParams) ([]ListDirectFriendBalancesRow, error)
+	ListExpenseAttachments(ctx context.Context, expenseID pgtype.UUID) ([]ExpenseAttachment, error)
 	ListExpenseComments(ctx context.Context, expenseID pgtype.UUID) ([]ListExpenseCommentsRow, error)
+	ListExpenseItemConsumers(ctx context.Context, expenseID pgtype.UUID) ([]ExpenseItemConsumer, error)
+	ListExpenseItems(ctx context.Context, expenseID pgtype.UUID) ([]ExpenseItem, error)
+	// Registered users who paid for or share in the expense.
+	ListExpenseParticipantIDs(ctx context.Context, expenseID pgtype.UUID) ([]pgtype.UUID, error)
 	ListExpensePayments(ctx context.Context, expenseID pgtype.UUID) ([]ListExpensePaymentsRow, error)
 	ListExpenseSplits(ctx context.Context, expenseID pgtype.UUID) ([]ListExpenseSplitsRow, error)
 	ListExpensesByGroup(ctx context.Context, groupID pgtype.UUID) ([]Expense, error)
@@ -93,24 +199,88 @@ type Querier interface {
 	ListFriendSettlements(ctx context.Context, arg ListFriendSettlementsParams) ([]ListFriendSettlementsRow, error)
 	ListFriends(ctx context.Context, userID pgtype.UUID) ([]ListFriendsRow, error)
 	ListGroupActivities(ctx context.Context, arg ListGroupActivitiesParams) ([]ListGroupActivitiesRow, error)
+	// Activities of the group logged after after_id, oldest first. Event streams
+	// use it to replay what a client missed; an unknown after_id or a deleted group
+	// replays nothing.
+	ListGroupActivitiesAfter(ctx context.Context, arg ListGroupActivitiesAfterParams) ([]GroupActivity, error)
+	ListGroupBudgets(ctx context.Context, groupID pgtype.UUID) ([]GroupBudget, error)
+	// Net position of a user towards each other registered user in every group the
+	// user belongs to, in the group currency, each expense's share rounded to cents
+	// Each expense is attributed pairwise: what one of them paid covers the other's
+	// share in proportion to the expense amount, all converted into the group currency
+	// Only group settlements between the two of them count
+	// Positive balance means the counterpart owes the user
+	// With confirmed_only, settlements still waiting for the payee to confirm are ignored
+	ListGroupFriendBalances(ctx context.Context, arg ListGroupFriendBalancesParams) ([]ListGroupFriendBalancesRow, error)
 	ListGroupMembers(ctx context.Context, groupID pgtype.UUID) ([]ListGroupMembersRow, error)
+	ListGroupWebhooks(ctx context.Context, groupID pgtype.UUID) ([]GroupWebhook, error)
 	ListIncomingFriendRequests(ctx context.Context, friendUserID pgtype.UUID) ([]Friendship, error)
 	ListInvitationsByGroup(ctx context.Context, groupID pgtype.UUID) ([]ListInvitationsByGroupRow, error)
+	ListJoinLinksByGroup(ctx context.Context, groupID pgtype.UUID) ([]GroupJoinLink, error)
+	ListNotificationMutes(ctx context.Context, userID pgtype.UUID) ([]string, error)
 	ListOutgoingFriendRequests(ctx context.Context, userID pgtype.UUID) ([]Friendship, error)
+	ListPaymentMethodsByUsers(ctx context.Context, userIds []pgtype.UUID) ([]UserPaymentMethod, error)
 	ListRecurringExpensePayments(ctx context.Context, recurringExpenseID pgtype.UUID) ([]ListRecurringExpensePaymentsRow, error)
 	ListRecurringExpenseSplits(ctx context.Context, recurringExpenseID pgtype.UUID) ([]ListRecurringExpenseSplitsRow, error)
 	ListRecurringExpensesByGroup(ctx context.Context, groupID pgtype.UUID) ([]RecurringExpense, error)
+	// Active groups with automatic reminders turned on, with their owner.
+	ListReminderGroups(ctx context.Context) ([]ListReminderGroupsRow, error)
 	ListSettlementsByGroup(ctx context.Context, groupID pgtype.UUID) ([]ListSettlementsByGroupRow, error)
 	ListSettlementsByUser(ctx context.Context, payerID pgtype.UUID) ([]ListSettlementsByUserRow, error)
 	ListThemePresets(ctx context.Context) ([]ThemePreset, error)
+	// Like ListGroupActivitiesAfter, across every group the user is an active member of.
+	ListUserActivitiesAfter(ctx context.Context, arg ListUserActivitiesAfterParams) ([]GroupActivity, error)
+	ListUserNotifications(ctx context.Context, arg ListUserNotificationsParams) ([]ListUserNotificationsRow, error)
+	ListUserPaymentMethods(ctx context.Context, userID pgtype.UUID) ([]UserPaymentMethod, error)
+	ListUserPersonalAccessTokens(ctx context.Context, userID pgtype.UUID) ([]PersonalAccessToken, error)
 	ListUserThemes(ctx context.Context, userID pgtype.UUID) ([]UserTheme, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	LockUserLogin(ctx context.Context, arg LockUserLoginParams) error
+	// Locks two-factor settings changes until locked_until; failures count from zero again after it.
+	LockUserTwoFactor(ctx context.Context, arg LockUserTwoFactorParams) error
+	MarkAllNotificationsRead(ctx context.Context, userID pgtype.UUID) (int64, error)
+	MarkEmailFailed(ctx context.Context, arg MarkEmailFailedParams) error
+	MarkEmailRetry(ctx context.Context, arg MarkEmailRetryParams) error
+	MarkEmailSent(ctx context.Context, id pgtype.UUID) error
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (Notification, error)
+	MarkUserEmailVerified(ctx context.Context, id pgtype.UUID) error
+	MarkUserTokenUsed(ctx context.Context, id pgtype.UUID) (UserToken, error)
+	MarkWebhookDeliveryDelivered(ctx context.Context, arg MarkWebhookDeliveryDeliveredParams) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error
+	MarkWebhookDeliveryRetry(ctx context.Context, arg MarkWebhookDeliveryRetryParams) error
+	MuteNotificationCategory(ctx context.Context, arg MuteNotificationCategoryParams) error
+	// Counts a failed sign-in. Failures older than window_start no longer count,
+	// so the streak starts over.
+	RecordFailedLogin(ctx context.Context, arg RecordFailedLoginParams) (UserLoginAttempt, error)
+	RecordTwoFactorChallengeFailure(ctx context.Context, tokenHash string) (int32, error)
+	// Counts a wrong code given to change two-factor settings.
+	RecordTwoFactorFailure(ctx context.Context, userID pgtype.UUID) (int32, error)
+	// Only succeeds for a time step later than the last one used, so a code can't be replayed.
+	RecordTwoFactorStep(ctx context.Context, arg RecordTwoFactorStepParams) (UserTwoFactor, error)
+	RenewInvitation(ctx context.Context, arg RenewInvitationParams) (GroupInvitation, error)
+	ResetFailedLogins(ctx context.Context, userID pgtype.UUID) error
+	ResetTwoFactorFailures(ctx context.Context, userID pgtype.UUID) error
+	RestoreGroup(ctx context.Context, arg RestoreGroupParams) (Group, error)
+	RevokeJoinLink(ctx context.Context, id pgtype.UUID) (GroupJoinLink, error)
+	// Swaps the refresh token only if the presented one is still current, and
+	// archives the old hash in the same statement so reuse can be detected.
+	RotateSessionRefreshToken(ctx context.Context, arg RotateSessionRefreshTokenParams) (Session, error)
 	SearchExpenses(ctx context.Context, arg SearchExpensesParams) ([]Expense, error)
+	// Records use at most once a minute so busy scripts don't write on every request.
+	TouchPersonalAccessToken(ctx context.Context, id pgtype.UUID) error
+	// Starts the debt age of members who weren't owing yet.
+	TrackDebtors(ctx context.Context, arg TrackDebtorsParams) error
+	UnmuteNotificationCategory(ctx context.Context, arg UnmuteNotificationCategoryParams) error
 	UpdateExpense(ctx context.Context, arg UpdateExpenseParams) (Expense, error)
 	UpdateExpenseComment(ctx context.Context, arg UpdateExpenseCommentParams) (ExpenseComment, error)
 	UpdateExpenseSplit(ctx context.Context, arg UpdateExpenseSplitParams) (ExpenseSplit, error)
 	UpdateFriendshipStatus(ctx context.Context, arg UpdateFriendshipStatusParams) (Friendship, error)
+	UpdateGroup(ctx context.Context, arg UpdateGroupParams) (Group, error)
+	UpdateGroupBudget(ctx context.Context, arg UpdateGroupBudgetParams) (GroupBudget, error)
 	UpdateGroupCategory(ctx context.Context, arg UpdateGroupCategoryParams) (ExpenseCategory, error)
+	UpdateGroupMemberRole(ctx context.Context, arg UpdateGroupMemberRoleParams) (GroupMember, error)
 	UpdateGroupMemberStatus(ctx context.Context, arg UpdateGroupMemberStatusParams) (GroupMember, error)
+	UpdateGroupWebhook(ctx context.Context, arg UpdateGroupWebhookParams) (GroupWebhook, error)
 	UpdateInvitationStatus(ctx context.Context, arg UpdateInvitationStatusParams) (GroupInvitation, error)
 	UpdateNextOccurrenceDate(ctx context.Context, arg UpdateNextOccurrenceDateParams) (RecurringExpense, error)
 	UpdatePendingPaymentUserID(ctx context.Context, arg UpdatePendingPaymentUserIDParams) error
@@ -121,9 +291,16 @@ type Querier interface {
 	UpdateRecurringExpenseActiveStatus(ctx context.Context, arg UpdateRecurringExpenseActiveStatusParams) (RecurringExpense, error)
 	UpdateSessionLastUsed(ctx context.Context, id pgtype.UUID) error
 	UpdateSettlement(ctx context.Context, arg UpdateSettlementParams) (Settlement, error)
+	// Moves a settlement to a new status, only if it is still in current_status
 	UpdateSettlementStatus(ctx context.Context, arg UpdateSettlementStatusParams) (Settlement, error)
+	UpdateUserIdentityLogin(ctx context.Context, arg UpdateUserIdentityLoginParams) error
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
 	UpdateUserTheme(ctx context.Context, arg UpdateUserThemeParams) (UserTheme, error)
+	UpsertUserPaymentMethod(ctx context.Context, arg UpsertUserPaymentMethodParams) (UserPaymentMethod, error)
 	UpsertUserThemePreferences(ctx context.Context, arg UpsertUserThemePreferencesParams) (UserThemePreference, error)
+	// Starts or restarts an enrollment; an enabled secret is never replaced.
+	UpsertUserTwoFactorSecret(ctx context.Context, arg UpsertUserTwoFactorSecretParams) (UserTwoFactor, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (UserRecoveryCode, error)
 }
 
 var _ Querier = (*Queries)(nil)
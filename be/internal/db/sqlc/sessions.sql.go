@@ -33,6 +33,30 @@ func (q *Queries) BlacklistToken(ctx context.Context, arg BlacklistTokenParams)
 	return err
 }
 
+const createSecurityEvent = `-- name: CreateSecurityEvent :exec
+INSERT INTO security_events (user_id, session_id, event_type, ip_address, user_agent)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateSecurityEventParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	SessionID pgtype.UUID `json:"session_id"`
+	EventType string      `json:"event_type"`
+	IpAddress pgtype.Text `json:"ip_address"`
+	UserAgent pgtype.Text `json:"user_agent"`
+}
+
+func (q *Queries) CreateSecurityEvent(ctx context.Context, arg CreateSecurityEventParams) error {
+	_, err := q.db.Exec(ctx, createSecurityEvent,
+		arg.UserID,
+		arg.SessionID,
+		arg.EventType,
+		arg.IpAddress,
+		arg.UserAgent,
+	)
+	return err
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (user_id, refresh_token_hash, user_agent, ip_address, expires_at)
 VALUES ($1, $2, $3, $4, $5)
@@ -109,6 +133,16 @@ func (q *Queries) DeleteSession(ctx context.Context, refreshTokenHash string) er
 	return err
 }
 
+const deleteSessionByID = `-- name: DeleteSessionByID :exec
+DELETE FROM sessions
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSessionByID(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteSessionByID, id)
+	return err
+}
+
 const getActiveSessionsByUserID = `-- name: GetActiveSessionsByUserID :many
 SELECT id, user_id, refresh_token_hash, user_agent, ip_address, expires_at, created_at, last_used_at FROM sessions
 WHERE user_id = $1 AND expires_at > NOW()
@@ -144,6 +178,23 @@ func (q *Queries) GetActiveSessionsByUserID(ctx context.Context, userID pgtype.U
 	return items, nil
 }
 
+const getRefreshTokenHistory = `-- name: GetRefreshTokenHistory :one
+SELECT token_hash, session_id, user_id, rotated_at FROM refresh_token_history
+WHERE token_hash = $1
+`
+
+func (q *Queries) GetRefreshTokenHistory(ctx context.Context, tokenHash string) (RefreshTokenHistory, error) {
+	row := q.db.QueryRow(ctx, getRefreshTokenHistory, tokenHash)
+	var i RefreshTokenHistory
+	err := row.Scan(
+		&i.TokenHash,
+		&i.SessionID,
+		&i.UserID,
+		&i.RotatedAt,
+	)
+	return i, err
+}
+
 const getSessionByRefreshTokenHash = `-- name: GetSessionByRefreshTokenHash :one
 SELECT id, user_id, refresh_token_hash, user_agent, ip_address, expires_at, created_at, last_used_at FROM sessions
 WHERE refresh_token_hash = $1 AND expires_at > NOW()
@@ -165,6 +216,46 @@ func (q *Queries) GetSessionByRefreshTokenHash(ctx context.Context, refreshToken
 	return i, err
 }
 
+const getUserSession = `-- name: GetUserSession :one
+SELECT id, user_id, refresh_token_hash, user_agent, ip_address, expires_at, created_at, last_used_at FROM sessions
+WHERE id = $1 AND user_id = $2 AND expires_at > NOW()
+`
+
+type GetUserSessionParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetUserSession(ctx context.Context, arg GetUserSessionParams) (Session, error) {
+	row := q.db.QueryRow(ctx, getUserSession, arg.ID, arg.UserID)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RefreshTokenHash,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+	)
+	return i, err
+}
+
+const isSessionActive = `-- name: IsSessionActive :one
+SELECT EXISTS(
+    SELECT 1 FROM sessions
+    WHERE id = $1 AND expires_at > NOW()
+) AS is_active
+`
+
+func (q *Queries) IsSessionActive(ctx context.Context, id pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isSessionActive, id)
+	var is_active bool
+	err := row.Scan(&is_active)
+	return is_active, err
+}
+
 const isTokenBlacklisted = `-- name: IsTokenBlacklisted :one
 SELECT EXISTS(
     SELECT 1 FROM token_blacklist
@@ -179,6 +270,42 @@ func (q *Queries) IsTokenBlacklisted(ctx context.Context, tokenJti string) (bool
 	return is_blacklisted, err
 }
 
+const rotateSessionRefreshToken = `-- name: RotateSessionRefreshToken :one
+WITH rotated AS (
+    UPDATE sessions
+    SET refresh_token_hash = $1, last_used_at = NOW()
+    WHERE refresh_token_hash = $2 AND expires_at > NOW()
+    RETURNING id, user_id, refresh_token_hash, user_agent, ip_address, expires_at, created_at, last_used_at
+), archived AS (
+    INSERT INTO refresh_token_history (token_hash, session_id, user_id)
+    SELECT $2, id, user_id FROM rotated
+)
+SELECT id, user_id, refresh_token_hash, user_agent, ip_address, expires_at, created_at, last_used_at FROM rotated
+`
+
+type RotateSessionRefreshTokenParams struct {
+	NewRefreshTokenHash string `json:"new_refresh_token_hash"`
+	RefreshTokenHash    string `json:"refresh_token_hash"`
+}
+
+// Swaps the refresh token only if the presented one is still current, and
+// archives the old hash in the same statement so reuse can be detected.
+func (q *Queries) RotateSessionRefreshToken(ctx context.Context, arg RotateSessionRefreshTokenParams) (Session, error) {
+	row := q.db.QueryRow(ctx, rotateSessionRefreshToken, arg.NewRefreshTokenHash, arg.RefreshTokenHash)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RefreshTokenHash,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+	)
+	return i, err
+}
+
 const updateSessionLastUsed = `-- name: UpdateSessionLastUsed :exec
 UPDATE sessions
 SET last_used_at = NOW()
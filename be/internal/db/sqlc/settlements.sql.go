@@ -17,10 +17,14 @@ INSERT INTO settlements (
     payer_id, payer_pending_user_id,
     payee_id, payee_pending_user_id,
     amount, currency_code, status,
-    payment_method, transaction_reference, notes, created_by, updated_by
+    payment_method, transaction_reference, notes, created_by, updated_by,
+    confirmed_at
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13)
-RETURNING id, group_id, payer_id, payee_id, amount, currency_code, status, payment_method, transaction_reference, paid_at, notes, created_at, created_by, updated_at, updated_by, deleted_at, type, payer_pending_user_id, payee_pending_user_id
+VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13,
+    CASE WHEN $9 = 'completed' THEN CURRENT_TIMESTAMP END
+)
+RETURNING id, group_id, payer_id, payee_id, amount, currency_code, status, payment_method, transaction_reference, paid_at, notes, created_at, created_by, updated_at, updated_by, deleted_at, type, payer_pending_user_id, payee_pending_user_id, confirmed_at, disputed_at, cancelled_at, dispute_reason
 `
 
 type CreateSettlementParams struct {
@@ -76,6 +80,10 @@ func (q *Queries) CreateSettlement(ctx context.Context, arg CreateSettlementPara
 		&i.Type,
 		&i.PayerPendingUserID,
 		&i.PayeePendingUserID,
+		&i.ConfirmedAt,
+		&i.DisputedAt,
+		&i.CancelledAt,
+		&i.DisputeReason,
 	)
 	return i, err
 }
@@ -92,7 +100,7 @@ func (q *Queries) DeleteSettlement(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getSettlementByID = `-- name: GetSettlementByID :one
-SELECT id, group_id, payer_id, payee_id, amount, currency_code, status, payment_method, transaction_reference, paid_at, notes, created_at, created_by, updated_at, updated_by, deleted_at, type, payer_pending_user_id, payee_pending_user_id FROM settlements
+SELECT id, group_id, payer_id, payee_id, amount, currency_code, status, payment_method, transaction_reference, paid_at, notes, created_at, created_by, updated_at, updated_by, deleted_at, type, payer_pending_user_id, payee_pending_user_id, confirmed_at, disputed_at, cancelled_at, dispute_reason FROM settlements
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -119,6 +127,10 @@ func (q *Queries) GetSettlementByID(ctx context.Context, id pgtype.UUID) (Settle
 		&i.Type,
 		&i.PayerPendingUserID,
 		&i.PayeePendingUserID,
+		&i.ConfirmedAt,
+		&i.DisputedAt,
+		&i.CancelledAt,
+		&i.DisputeReason,
 	)
 	return i, err
 }
@@ -270,6 +282,10 @@ SELECT
     s.created_by,
     s.updated_at,
     s.updated_by,
+    s.confirmed_at,
+    s.disputed_at,
+    s.cancelled_at,
+    s.dispute_reason,
     COALESCE(payer_user.email, payer_pending.email) AS payer_email,
     COALESCE(payer_user.name, payer_pending.name) AS payer_name,
     payer_user.avatar_url AS payer_avatar_url,
@@ -305,6 +321,10 @@ type ListSettlementsByGroupRow struct {
 	CreatedBy            pgtype.UUID        `json:"created_by"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
 	UpdatedBy            pgtype.UUID        `json:"updated_by"`
+	ConfirmedAt          pgtype.Timestamptz `json:"confirmed_at"`
+	DisputedAt           pgtype.Timestamptz `json:"disputed_at"`
+	CancelledAt          pgtype.Timestamptz `json:"cancelled_at"`
+	DisputeReason        pgtype.Text        `json:"dispute_reason"`
 	PayerEmail           string             `json:"payer_email"`
 	PayerName            pgtype.Text        `json:"payer_name"`
 	PayerAvatarUrl       pgtype.Text        `json:"payer_avatar_url"`
@@ -342,6 +362,10 @@ func (q *Queries) ListSettlementsByGroup(ctx context.Context, groupID pgtype.UUI
 			&i.CreatedBy,
 			&i.UpdatedAt,
 			&i.UpdatedBy,
+			&i.ConfirmedAt,
+			&i.DisputedAt,
+			&i.CancelledAt,
+			&i.DisputeReason,
 			&i.PayerEmail,
 			&i.PayerName,
 			&i.PayerAvatarUrl,
@@ -380,6 +404,10 @@ SELECT
     s.created_by,
     s.updated_at,
     s.updated_by,
+    s.confirmed_at,
+    s.disputed_at,
+    s.cancelled_at,
+    s.dispute_reason,
     g.name AS group_name,
     COALESCE(payer_user.email, payer_pending.email) AS payer_email,
     COALESCE(payer_user.name, payer_pending.name) AS payer_name,
@@ -422,6 +450,10 @@ type ListSettlementsByUserRow struct {
 	CreatedBy            pgtype.UUID        `json:"created_by"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
 	UpdatedBy            pgtype.UUID        `json:"updated_by"`
+	ConfirmedAt          pgtype.Timestamptz `json:"confirmed_at"`
+	DisputedAt           pgtype.Timestamptz `json:"disputed_at"`
+	CancelledAt          pgtype.Timestamptz `json:"cancelled_at"`
+	DisputeReason        pgtype.Text        `json:"dispute_reason"`
 	GroupName            string             `json:"group_name"`
 	PayerEmail           string             `json:"payer_email"`
 	PayerName            pgtype.Text        `json:"payer_name"`
@@ -460,6 +492,10 @@ func (q *Queries) ListSettlementsByUser(ctx context.Context, payerID pgtype.UUID
 			&i.CreatedBy,
 			&i.UpdatedAt,
 			&i.UpdatedBy,
+			&i.ConfirmedAt,
+			&i.DisputedAt,
+			&i.CancelledAt,
+			&i.DisputeReason,
 			&i.GroupName,
 			&i.PayerEmail,
 			&i.PayerName,
@@ -489,10 +525,13 @@ SET amount = $2,
     transaction_reference = $6,
     notes = $7,
     paid_at = CASE WHEN $4 = 'completed' AND paid_at IS NULL THEN CURRENT_TIMESTAMP ELSE paid_at END,
+    confirmed_at = CASE WHEN $4 = 'completed' AND status <> 'completed' THEN CURRENT_TIMESTAMP ELSE confirmed_at END,
+    disputed_at = CASE WHEN $4 = 'disputed' AND status <> 'disputed' THEN CURRENT_TIMESTAMP ELSE disputed_at END,
+    cancelled_at = CASE WHEN $4 = 'cancelled' AND status <> 'cancelled' THEN CURRENT_TIMESTAMP ELSE cancelled_at END,
     updated_at = CURRENT_TIMESTAMP,
     updated_by = $8
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, group_id, payer_id, payee_id, amount, currency_code, status, payment_method, transaction_reference, paid_at, notes, created_at, created_by, updated_at, updated_by, deleted_at, type, payer_pending_user_id, payee_pending_user_id
+RETURNING id, group_id, payer_id, payee_id, amount, currency_code, status, payment_method, transaction_reference, paid_at, notes, created_at, created_by, updated_at, updated_by, deleted_at, type, payer_pending_user_id, payee_pending_user_id, confirmed_at, disputed_at, cancelled_at, dispute_reason
 `
 
 type UpdateSettlementParams struct {
@@ -538,28 +577,47 @@ func (q *Queries) UpdateSettlement(ctx context.Context, arg UpdateSettlementPara
 		&i.Type,
 		&i.PayerPendingUserID,
 		&i.PayeePendingUserID,
+		&i.ConfirmedAt,
+		&i.DisputedAt,
+		&i.CancelledAt,
+		&i.DisputeReason,
 	)
 	return i, err
 }
 
 const updateSettlementStatus = `-- name: UpdateSettlementStatus :one
 UPDATE settlements
-SET status = $2,
-    paid_at = CASE WHEN $2 = 'completed' AND paid_at IS NULL THEN CURRENT_TIMESTAMP ELSE paid_at END,
+SET status = $1,
+    paid_at = CASE WHEN $1 = 'completed' AND paid_at IS NULL THEN CURRENT_TIMESTAMP ELSE paid_at END,
+    confirmed_at = CASE WHEN $1 = 'completed' THEN CURRENT_TIMESTAMP ELSE confirmed_at END,
+    disputed_at = CASE WHEN $1 = 'disputed' THEN CURRENT_TIMESTAMP ELSE disputed_at END,
+    cancelled_at = CASE WHEN $1 = 'cancelled' THEN CURRENT_TIMESTAMP ELSE cancelled_at END,
+    dispute_reason = CASE WHEN $1 = 'disputed' THEN $2 ELSE dispute_reason END,
     updated_at = CURRENT_TIMESTAMP,
     updated_by = $3
-WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, group_id, payer_id, payee_id, amount, currency_code, status, payment_method, transaction_reference, paid_at, notes, created_at, created_by, updated_at, updated_by, deleted_at, type, payer_pending_user_id, payee_pending_user_id
+WHERE id = $4
+  AND status = $5
+  AND deleted_at IS NULL
+RETURNING id, group_id, payer_id, payee_id, amount, currency_code, status, payment_method, transaction_reference, paid_at, notes, created_at, created_by, updated_at, updated_by, deleted_at, type, payer_pending_user_id, payee_pending_user_id, confirmed_at, disputed_at, cancelled_at, dispute_reason
 `
 
 type UpdateSettlementStatusParams struct {
-	ID        pgtype.UUID `json:"id"`
-	Status    string      `json:"status"`
-	UpdatedBy pgtype.UUID `json:"updated_by"`
+	Status        string      `json:"status"`
+	DisputeReason pgtype.Text `json:"dispute_reason"`
+	UpdatedBy     pgtype.UUID `json:"updated_by"`
+	ID            pgtype.UUID `json:"id"`
+	CurrentStatus string      `json:"current_status"`
 }
 
+// Moves a settlement to a new status, only if it is still in current_status
 func (q *Queries) UpdateSettlementStatus(ctx context.Context, arg UpdateSettlementStatusParams) (Settlement, error) {
-	row := q.db.QueryRow(ctx, updateSettlementStatus, arg.ID, arg.Status, arg.UpdatedBy)
+	row := q.db.QueryRow(ctx, updateSettlementStatus,
+		arg.Status,
+		arg.DisputeReason,
+		arg.UpdatedBy,
+		arg.ID,
+		arg.CurrentStatus,
+	)
 	var i Settlement
 	err := row.Scan(
 		&i.ID,
@@ -581,6 +639,10 @@ func (q *Queries) UpdateSettlementStatus(ctx context.Context, arg UpdateSettleme
 		&i.Type,
 		&i.PayerPendingUserID,
 		&i.PayeePendingUserID,
+		&i.ConfirmedAt,
+		&i.DisputedAt,
+		&i.CancelledAt,
+		&i.DisputeReason,
 	)
 	return i, err
 }
@@ -88,3 +88,104 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 	)
 	return i, err
 }
+
+const getUserLoginAttempts = `-- name: GetUserLoginAttempts :one
+SELECT user_id, failed_attempts, last_failed_at, locked_until FROM user_login_attempts
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserLoginAttempts(ctx context.Context, userID pgtype.UUID) (UserLoginAttempt, error) {
+	row := q.db.QueryRow(ctx, getUserLoginAttempts, userID)
+	var i UserLoginAttempt
+	err := row.Scan(
+		&i.UserID,
+		&i.FailedAttempts,
+		&i.LastFailedAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const lockUserLogin = `-- name: LockUserLogin :exec
+UPDATE user_login_attempts
+SET locked_until = $2
+WHERE user_id = $1
+`
+
+type LockUserLoginParams struct {
+	UserID      pgtype.UUID        `json:"user_id"`
+	LockedUntil pgtype.Timestamptz `json:"locked_until"`
+}
+
+func (q *Queries) LockUserLogin(ctx context.Context, arg LockUserLoginParams) error {
+	_, err := q.db.Exec(ctx, lockUserLogin, arg.UserID, arg.LockedUntil)
+	return err
+}
+
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET email_verified_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND email_verified_at IS NULL AND deleted_at IS NULL
+`
+
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markUserEmailVerified, id)
+	return err
+}
+
+const recordFailedLogin = `-- name: RecordFailedLogin :one
+INSERT INTO user_login_attempts (user_id, failed_attempts, last_failed_at)
+VALUES ($1, 1, NOW())
+ON CONFLICT (user_id) DO UPDATE
+SET failed_attempts = CASE
+        WHEN user_login_attempts.last_failed_at < $2::TIMESTAMPTZ THEN 1
+        ELSE user_login_attempts.failed_attempts + 1
+    END,
+    last_failed_at = NOW()
+RETURNING user_id, failed_attempts, last_failed_at, locked_until
+`
+
+type RecordFailedLoginParams struct {
+	UserID      pgtype.UUID        `json:"user_id"`
+	WindowStart pgtype.Timestamptz `json:"window_start"`
+}
+
+// Counts a failed sign-in. Failures older than window_start no longer count,
+// so the streak starts over.
+func (q *Queries) RecordFailedLogin(ctx context.Context, arg RecordFailedLoginParams) (UserLoginAttempt, error) {
+	row := q.db.QueryRow(ctx, recordFailedLogin, arg.UserID, arg.WindowStart)
+	var i UserLoginAttempt
+	err := row.Scan(
+		&i.UserID,
+		&i.FailedAttempts,
+		&i.LastFailedAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const resetFailedLogins = `-- name: ResetFailedLogins :exec
+DELETE FROM user_login_attempts
+WHERE user_id = $1
+`
+
+func (q *Queries) ResetFailedLogins(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, resetFailedLogins, userID)
+	return err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET password_hash = $2, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type UpdateUserPasswordParams struct {
+	ID           pgtype.UUID `json:"id"`
+	PasswordHash string      `json:"password_hash"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.PasswordHash)
+	return err
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ForgotPasswordHandler always answers 202 so the response doesn't reveal
+// whether an account exists for the email.
+func ForgotPasswordHandler(accountService service.AccountService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := middleware.GetBody[ForgotPasswordRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		if err := accountService.RequestPasswordReset(r.Context(), req.Email); err != nil {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.auth.password_reset_request_failed", "Unable to send a password reset email right now.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusAccepted, map[string]string{
+			"message": "if an account exists for this email, a reset link has been sent",
+		})
+	}
+}
+
+func ResetPasswordHandler(accountService service.AccountService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := middleware.GetBody[ResetPasswordRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		if err := accountService.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+			if err == service.ErrInvalidAccountToken {
+				response.SendErrorWithCode(w, http.StatusBadRequest, "auth.password_reset.token_invalid", "This reset link is invalid or has expired.")
+				return
+			}
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.auth.password_reset_failed", "Unable to reset password right now.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func VerifyEmailHandler(accountService service.AccountService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := middleware.GetBody[VerifyEmailRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		if err := accountService.VerifyEmail(r.Context(), req.Token); err != nil {
+			if err == service.ErrInvalidAccountToken {
+				response.SendErrorWithCode(w, http.StatusBadRequest, "auth.email_verification.token_invalid", "This verification link is invalid or has expired.")
+				return
+			}
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.auth.email_verification_failed", "Unable to verify email right now.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, map[string]string{
+			"message": "email verified successfully",
+		})
+	}
+}
+
+func RequestEmailVerificationHandler(accountService service.AccountService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		if err := accountService.RequestEmailVerification(r.Context(), userID); err != nil {
+			var statusCode int
+			var code string
+			var message string
+			switch err {
+			case service.ErrEmailAlreadyVerified:
+				statusCode = http.StatusConflict
+				code = "conflict.user.email_already_verified"
+				message = "Your email address is already verified."
+			case service.ErrUserNotFound:
+				statusCode = http.StatusNotFound
+				code = "resource.user.not_found"
+				message = "User not found."
+			default:
+				statusCode = http.StatusInternalServerError
+				code = "system.user.email_verification_request_failed"
+				message = "Unable to send a verification email right now."
+			}
+			response.SendErrorWithCode(w, statusCode, code, message)
+			return
+		}
+
+		response.SendSuccess(w, http.StatusAccepted, map[string]string{
+			"message": "verification email sent",
+		})
+	}
+}
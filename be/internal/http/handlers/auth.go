@@ -19,13 +19,27 @@ type LoginResponse struct {
 	ExpiresIn    int64  `json:"expires_in"`
 }
 
+// TwoFactorChallengeResponse is returned by a sign-in instead of LoginResponse
+// when the user has two-factor authentication enabled.
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int64  `json:"expires_in"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 type RefreshTokenResponse struct {
-	AccessToken string `json:"access_token"`
-	ExpiresIn   int64  `json:"expires_in"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
 
 type LogoutRequest struct {
@@ -44,7 +58,7 @@ func LoginHandler(authService service.AuthService) http.HandlerFunc {
 		userAgent := r.UserAgent()
 		ipAddress := r.RemoteAddr
 
-		accessToken, refreshToken, expiresIn, err := authService.Login(
+		result, err := authService.Login(
 			r.Context(),
 			req.Email,
 			req.Password,
@@ -60,6 +74,10 @@ func LoginHandler(authService service.AuthService) http.HandlerFunc {
 				statusCode = http.StatusUnauthorized
 				code = "auth.credentials.invalid"
 				message = "Invalid email or password."
+			case service.ErrAccountLocked:
+				statusCode = http.StatusTooManyRequests
+				code = "auth.account.locked"
+				message = "Too many failed sign-in attempts. Please try again later."
 			default:
 				statusCode = http.StatusInternalServerError
 				code = "system.auth.login_failed"
@@ -69,14 +87,72 @@ func LoginHandler(authService service.AuthService) http.HandlerFunc {
 			return
 		}
 
-		resp := LoginResponse{
+		sendLoginResult(w, result)
+	}
+}
+
+func TwoFactorLoginHandler(authService service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := middleware.GetBody[TwoFactorLoginRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		accessToken, refreshToken, expiresIn, err := authService.CompleteTwoFactorLogin(
+			r.Context(),
+			req.ChallengeToken,
+			req.Code,
+			r.UserAgent(),
+			r.RemoteAddr,
+		)
+		if err != nil {
+			var statusCode int
+			var code string
+			var message string
+			switch err {
+			case service.ErrInvalidTwoFactorCode:
+				statusCode = http.StatusUnauthorized
+				code = "auth.two_factor.code_invalid"
+				message = "Invalid authentication code."
+			case service.ErrTwoFactorChallengeInvalid, service.ErrUserNotFound:
+				statusCode = http.StatusUnauthorized
+				code = "auth.two_factor.challenge_invalid"
+				message = "Your sign-in expired. Please sign in again."
+			default:
+				statusCode = http.StatusInternalServerError
+				code = "system.auth.login_failed"
+				message = "Unable to sign in right now."
+			}
+			response.SendErrorWithCode(w, statusCode, code, message)
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, LoginResponse{
 			AccessToken:  accessToken,
 			RefreshToken: refreshToken,
 			ExpiresIn:    expiresIn,
-		}
+		})
+	}
+}
 
-		response.SendSuccess(w, http.StatusOK, resp)
+// sendLoginResult responds with the session tokens, or with the challenge when
+// the user still has to give a second factor.
+func sendLoginResult(w http.ResponseWriter, result service.LoginResult) {
+	if result.ChallengeToken != "" {
+		response.SendSuccess(w, http.StatusOK, TwoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    result.ChallengeToken,
+			ExpiresIn:         int64(service.TwoFactorChallengeTTL.Seconds()),
+		})
+		return
 	}
+
+	response.SendSuccess(w, http.StatusOK, LoginResponse{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		ExpiresIn:    result.ExpiresIn,
+	})
 }
 
 func RefreshTokenHandler(authService service.AuthService) http.HandlerFunc {
@@ -90,7 +166,7 @@ func RefreshTokenHandler(authService service.AuthService) http.HandlerFunc {
 		userAgent := r.UserAgent()
 		ipAddress := r.RemoteAddr
 
-		accessToken, expiresIn, err := authService.RefreshToken(
+		accessToken, refreshToken, expiresIn, err := authService.RefreshToken(
 			r.Context(),
 			req.RefreshToken,
 			userAgent,
@@ -105,6 +181,10 @@ func RefreshTokenHandler(authService service.AuthService) http.HandlerFunc {
 				statusCode = http.StatusUnauthorized
 				code = "auth.refresh_token.invalid"
 				message = "Your session has expired. Please sign in again."
+			case service.ErrRefreshTokenReused:
+				statusCode = http.StatusUnauthorized
+				code = "auth.refresh_token.reused"
+				message = "This session was signed out for your security. Please sign in again."
 			default:
 				statusCode = http.StatusInternalServerError
 				code = "system.auth.refresh_failed"
@@ -115,8 +195,9 @@ func RefreshTokenHandler(authService service.AuthService) http.HandlerFunc {
 		}
 
 		resp := RefreshTokenResponse{
-			AccessToken: accessToken,
-			ExpiresIn:   expiresIn,
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			ExpiresIn:    expiresIn,
 		}
 
 		response.SendSuccess(w, http.StatusOK, resp)
@@ -146,8 +146,9 @@ func TestAuthenticationFlow(t *testing.T) {
 		var refreshResponse struct {
 			Status bool `json:"status"`
 			Data   *struct {
-				AccessToken string `json:"access_token"`
-				ExpiresIn   int    `json:"expires_in"`
+				AccessToken  string `json:"access_token"`
+				RefreshToken string `json:"refresh_token"`
+				ExpiresIn    int    `json:"expires_in"`
 			} `json:"data"`
 		}
 		err := json.Unmarshal(rec.Body.Bytes(), &refreshResponse)
@@ -157,6 +158,7 @@ func TestAuthenticationFlow(t *testing.T) {
 		require.NotNil(t, refreshResponse.Data)
 		assert.NotEmpty(t, refreshResponse.Data.AccessToken)
 		assert.NotZero(t, refreshResponse.Data.ExpiresIn)
+		assert.NotEqual(t, refreshToken, refreshResponse.Data.RefreshToken, "Refresh token should be rotated")
 	})
 
 	// Cleanup
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -11,6 +12,19 @@ import (
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
 )
 
+// parseBalanceOptions reads the optional ?confirmed_only=true flag
+func parseBalanceOptions(r *http.Request) (service.BalanceOptions, error) {
+	var opts service.BalanceOptions
+	if v := r.URL.Query().Get("confirmed_only"); v != "" {
+		confirmedOnly, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.ConfirmedOnly = confirmedOnly
+	}
+	return opts, nil
+}
+
 // Handlers
 
 func ListGroupBalancesHandler(balanceService service.BalanceService) http.HandlerFunc {
@@ -28,7 +42,13 @@ func ListGroupBalancesHandler(balanceService service.BalanceService) http.Handle
 			return
 		}
 
-		balances, err := balanceService.GetGroupBalances(r.Context(), groupID, requesterID)
+		opts, err := parseBalanceOptions(r)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid confirmed_only")
+			return
+		}
+
+		balances, err := balanceService.GetGroupBalances(r.Context(), groupID, requesterID, opts)
 		if err != nil {
 			var statusCode int
 			switch err {
@@ -69,7 +89,13 @@ func GetUserBalanceInGroupHandler(balanceService service.BalanceService) http.Ha
 			return
 		}
 
-		balance, err := balanceService.GetUserBalanceInGroup(r.Context(), groupID, userID, requesterID)
+		opts, err := parseBalanceOptions(r)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid confirmed_only")
+			return
+		}
+
+		balance, err := balanceService.GetUserBalanceInGroup(r.Context(), groupID, userID, requesterID, opts)
 		if err != nil {
 			var statusCode int
 			switch err {
@@ -96,7 +122,13 @@ func GetOverallUserBalanceHandler(balanceService service.BalanceService) http.Ha
 			return
 		}
 
-		balances, err := balanceService.GetOverallUserBalance(r.Context(), userID)
+		opts, err := parseBalanceOptions(r)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid confirmed_only")
+			return
+		}
+
+		balances, err := balanceService.GetOverallUserBalance(r.Context(), userID, opts)
 		if err != nil {
 			response.SendError(w, http.StatusInternalServerError, err.Error())
 			return
@@ -121,7 +153,13 @@ func GetSimplifiedDebtsHandler(balanceService service.BalanceService) http.Handl
 			return
 		}
 
-		debts, err := balanceService.GetSimplifiedDebts(r.Context(), groupID, requesterID)
+		opts, err := parseBalanceOptions(r)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid confirmed_only")
+			return
+		}
+
+		debts, err := balanceService.GetSimplifiedDebts(r.Context(), groupID, requesterID, opts)
 		if err != nil {
 			var statusCode int
 			switch err {
@@ -139,3 +177,119 @@ func GetSimplifiedDebtsHandler(balanceService service.BalanceService) http.Handl
 		response.SendSuccess(w, http.StatusOK, debts)
 	}
 }
+
+type PayDebtRequest struct {
+	CreditorID           string `json:"creditor_id" validate:"required,uuid"`
+	Provider             string `json:"provider" validate:"required,oneof=upi paypal venmo iban"`
+	Amount               string `json:"amount"`
+	TransactionReference string `json:"transaction_reference" validate:"max=255"`
+}
+
+// PayDebtHandler records that the requester used a payment link by creating a
+// pending settlement for the creditor to confirm.
+func PayDebtHandler(paymentMethodService service.PaymentMethodService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requesterID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		req, ok := middleware.GetBody[PayDebtRequest](r)
+		if !ok {
+			response.SendError(w, http.StatusInternalServerError, "invalid request context")
+			return
+		}
+
+		groupIDStr := chi.URLParam(r, "group_id")
+		var groupID pgtype.UUID
+		if err := groupID.Scan(groupIDStr); err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid group id")
+			return
+		}
+
+		var creditorID pgtype.UUID
+		if err := creditorID.Scan(req.CreditorID); err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid creditor id")
+			return
+		}
+
+		settlement, err := paymentMethodService.PayDebt(r.Context(), service.PayDebtInput{
+			GroupID:              groupID,
+			PayerID:              requesterID,
+			CreditorID:           creditorID,
+			Provider:             req.Provider,
+			Amount:               req.Amount,
+			TransactionReference: req.TransactionReference,
+		})
+		if err != nil {
+			var statusCode int
+			switch err {
+			case service.ErrGroupNotFound, service.ErrDebtNotFound, service.ErrPaymentMethodNotFound:
+				statusCode = http.StatusNotFound
+			case service.ErrNotGroupMember:
+				statusCode = http.StatusForbidden
+			case service.ErrPaymentProviderInvalid, service.ErrPaymentAmountInvalid:
+				statusCode = http.StatusBadRequest
+			default:
+				statusCode = http.StatusInternalServerError
+			}
+			response.SendError(w, statusCode, err.Error())
+			return
+		}
+
+		response.SendSuccess(w, http.StatusCreated, settlementToResponse(settlement))
+	}
+}
+
+type RemindDebtorRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+}
+
+// RemindDebtorHandler emails a member a reminder of what they owe the requester.
+func RemindDebtorHandler(reminderService service.DebtReminderService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requesterID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		req, ok := middleware.GetBody[RemindDebtorRequest](r)
+		if !ok {
+			response.SendError(w, http.StatusInternalServerError, "invalid request context")
+			return
+		}
+
+		groupIDStr := chi.URLParam(r, "group_id")
+		var groupID pgtype.UUID
+		if err := groupID.Scan(groupIDStr); err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid group id")
+			return
+		}
+
+		var debtorID pgtype.UUID
+		if err := debtorID.Scan(req.UserID); err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid user id")
+			return
+		}
+
+		if err := reminderService.RemindDebtor(r.Context(), groupID, requesterID, debtorID); err != nil {
+			var statusCode int
+			switch err {
+			case service.ErrGroupNotFound, service.ErrDebtNotFound:
+				statusCode = http.StatusNotFound
+			case service.ErrNotGroupMember:
+				statusCode = http.StatusForbidden
+			case service.ErrReminderTooSoon:
+				statusCode = http.StatusTooManyRequests
+			default:
+				statusCode = http.StatusInternalServerError
+			}
+			response.SendError(w, statusCode, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+type CreateBudgetRequest struct {
+	Name       string  `json:"name" validate:"required,max=100"`
+	CategoryID string  `json:"category_id,omitempty" validate:"omitempty,uuid"`
+	Amount     string  `json:"amount" validate:"required"`
+	Period     string  `json:"period" validate:"required,oneof=weekly monthly custom"`
+	StartDate  string  `json:"start_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	EndDate    string  `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Thresholds []int32 `json:"thresholds,omitempty" validate:"omitempty,max=5,dive,min=1,max=1000"`
+}
+
+// UpdateBudgetRequest changes the fields that are set. An empty category_id
+// makes the budget count every expense in the group.
+type UpdateBudgetRequest struct {
+	Name       *string  `json:"name,omitempty" validate:"omitempty,max=100"`
+	CategoryID *string  `json:"category_id,omitempty" validate:"omitempty,uuid|eq="`
+	Amount     *string  `json:"amount,omitempty"`
+	Period     *string  `json:"period,omitempty" validate:"omitempty,oneof=weekly monthly custom"`
+	StartDate  *string  `json:"start_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	EndDate    *string  `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Thresholds *[]int32 `json:"thresholds,omitempty" validate:"omitempty,max=5,dive,min=1,max=1000"`
+}
+
+type BudgetResponse struct {
+	ID         string  `json:"id"`
+	GroupID    string  `json:"group_id"`
+	CategoryID string  `json:"category_id,omitempty"`
+	Name       string  `json:"name"`
+	Amount     string  `json:"amount"`
+	Period     string  `json:"period"`
+	StartDate  string  `json:"start_date,omitempty"`
+	EndDate    string  `json:"end_date,omitempty"`
+	Thresholds []int32 `json:"thresholds"`
+	CreatedBy  string  `json:"created_by"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  string  `json:"updated_at"`
+}
+
+type BudgetStatusResponse struct {
+	Budget            BudgetResponse `json:"budget"`
+	PeriodStart       string         `json:"period_start"`
+	PeriodEnd         string         `json:"period_end"`
+	Spent             string         `json:"spent"`
+	Remaining         string         `json:"remaining"`
+	OverBudget        bool           `json:"over_budget"`
+	ThresholdsReached []int32        `json:"thresholds_reached"`
+}
+
+func budgetToResponse(budget sqlc.GroupBudget) BudgetResponse {
+	amount, _ := numericToString(budget.Amount)
+	resp := BudgetResponse{
+		ID:         formatUUID(budget.ID.Bytes),
+		GroupID:    formatUUID(budget.GroupID.Bytes),
+		Name:       budget.Name,
+		Amount:     amount,
+		Period:     budget.Period,
+		Thresholds: budget.Thresholds,
+		CreatedBy:  formatUUID(budget.CreatedBy.Bytes),
+		CreatedAt:  formatRFC3339(budget.CreatedAt),
+		UpdatedAt:  formatRFC3339(budget.UpdatedAt),
+	}
+	if budget.CategoryID.Valid {
+		resp.CategoryID = formatUUID(budget.CategoryID.Bytes)
+	}
+	if budget.StartDate.Valid {
+		resp.StartDate = budget.StartDate.Time.Format("2006-01-02")
+	}
+	if budget.EndDate.Valid {
+		resp.EndDate = budget.EndDate.Time.Format("2006-01-02")
+	}
+	if resp.Thresholds == nil {
+		resp.Thresholds = []int32{}
+	}
+	return resp
+}
+
+func budgetStatusToResponse(status service.BudgetStatus) BudgetStatusResponse {
+	return BudgetStatusResponse{
+		Budget:            budgetToResponse(status.Budget),
+		PeriodStart:       status.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:         status.PeriodEnd.Format("2006-01-02"),
+		Spent:             status.Spent.StringFixed(2),
+		Remaining:         status.Remaining.StringFixed(2),
+		OverBudget:        status.Remaining.IsNegative(),
+		ThresholdsReached: status.ThresholdsReached,
+	}
+}
+
+// sendBudgetError maps budget service errors to API error codes.
+func sendBudgetError(w http.ResponseWriter, err error, fallbackCode, fallbackMessage string) {
+	switch {
+	case errors.Is(err, service.ErrGroupNotFound):
+		response.SendErrorWithCode(w, http.StatusNotFound, "resource.group.not_found", "Group not found.")
+	case errors.Is(err, service.ErrNotGroupMember):
+		response.SendErrorWithCode(w, http.StatusForbidden, "permission.group.member_required", "You are not a member of this group.")
+	case errors.Is(err, service.ErrInsufficientPermissions):
+		response.SendErrorWithCode(w, http.StatusForbidden, "permission.group.role_required", "Only group owners and admins can manage budgets.")
+	case errors.Is(err, service.ErrBudgetNotFound):
+		response.SendErrorWithCode(w, http.StatusNotFound, "resource.budget.not_found", "Budget not found.")
+	case errors.Is(err, service.ErrCategoryNotFound), errors.Is(err, service.ErrCategoryNotInGroup):
+		response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.budget.category_invalid", "Category not found in this group.")
+	case errors.Is(err, service.ErrInvalidBudgetName):
+		response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.budget.name_invalid", "Budget name is required.")
+	case errors.Is(err, service.ErrInvalidBudgetAmount):
+		response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.budget.amount_invalid", "Budget amount must be a positive amount with at most 2 decimals.")
+	case errors.Is(err, service.ErrInvalidBudgetPeriod):
+		response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.budget.period_invalid", "Budget period must be weekly, monthly or custom.")
+	case errors.Is(err, service.ErrInvalidBudgetDates):
+		response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.budget.dates_invalid", "Custom budgets need a start date on or before their end date.")
+	case errors.Is(err, service.ErrInvalidBudgetThresholds):
+		response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.budget.thresholds_invalid", "Use up to 5 alert thresholds between 1 and 1000 percent.")
+	case errors.Is(err, service.ErrTooManyBudgets):
+		response.SendErrorWithCode(w, http.StatusConflict, "conflict.budget.limit_reached", "Delete a budget before adding another.")
+	default:
+		response.SendErrorWithCode(w, http.StatusInternalServerError, fallbackCode, fallbackMessage)
+	}
+}
+
+// budgetRequestIDs reads the requester and the group, and the budget when
+// the route has one. It writes the error response itself.
+func budgetRequestIDs(w http.ResponseWriter, r *http.Request, withBudget bool) (userID, groupID, budgetID pgtype.UUID, ok bool) {
+	userID, ok = middleware.GetUserID(r)
+	if !ok {
+		response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+		return userID, groupID, budgetID, false
+	}
+
+	groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+	if err != nil {
+		response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+		return userID, groupID, budgetID, false
+	}
+
+	if withBudget {
+		budgetID, err = parseUUID(chi.URLParam(r, "budget_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.budget.id_invalid", "Invalid budget ID.")
+			return userID, groupID, budgetID, false
+		}
+	}
+	return userID, groupID, budgetID, true
+}
+
+// parseBudgetDate parses a validated YYYY-MM-DD date; empty is no date.
+func parseBudgetDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil
+	}
+	return &date
+}
+
+func ListBudgetsHandler(budgetService service.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, _, ok := budgetRequestIDs(w, r, false)
+		if !ok {
+			return
+		}
+
+		budgets, err := budgetService.ListBudgets(r.Context(), groupID, userID)
+		if err != nil {
+			sendBudgetError(w, err, "system.budget.list_failed", "Unable to load budgets.")
+			return
+		}
+
+		resp := make([]BudgetResponse, 0, len(budgets))
+		for _, budget := range budgets {
+			resp = append(resp, budgetToResponse(budget))
+		}
+
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+func CreateBudgetHandler(budgetService service.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, _, ok := budgetRequestIDs(w, r, false)
+		if !ok {
+			return
+		}
+
+		req, ok := middleware.GetBody[CreateBudgetRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		var categoryID pgtype.UUID
+		if req.CategoryID != "" {
+			categoryID, _ = parseUUID(req.CategoryID)
+		}
+
+		budget, err := budgetService.CreateBudget(r.Context(), service.CreateBudgetInput{
+			GroupID:     groupID,
+			RequesterID: userID,
+			CategoryID:  categoryID,
+			Name:        req.Name,
+			Amount:      req.Amount,
+			Period:      req.Period,
+			StartDate:   parseBudgetDate(req.StartDate),
+			EndDate:     parseBudgetDate(req.EndDate),
+			Thresholds:  req.Thresholds,
+		})
+		if err != nil {
+			sendBudgetError(w, err, "system.budget.create_failed", "Unable to create budget.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusCreated, budgetToResponse(budget))
+	}
+}
+
+func UpdateBudgetHandler(budgetService service.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, budgetID, ok := budgetRequestIDs(w, r, true)
+		if !ok {
+			return
+		}
+
+		req, ok := middleware.GetBody[UpdateBudgetRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		input := service.UpdateBudgetInput{
+			GroupID:     groupID,
+			BudgetID:    budgetID,
+			RequesterID: userID,
+			Name:        req.Name,
+			Amount:      req.Amount,
+			Period:      req.Period,
+			Thresholds:  req.Thresholds,
+		}
+		if req.CategoryID != nil {
+			var categoryID pgtype.UUID
+			if *req.CategoryID != "" {
+				categoryID, _ = parseUUID(*req.CategoryID)
+			}
+			input.CategoryID = &categoryID
+		}
+		if req.StartDate != nil {
+			input.StartDate = parseBudgetDate(*req.StartDate)
+		}
+		if req.EndDate != nil {
+			input.EndDate = parseBudgetDate(*req.EndDate)
+		}
+
+		budget, err := budgetService.UpdateBudget(r.Context(), input)
+		if err != nil {
+			sendBudgetError(w, err, "system.budget.update_failed", "Unable to update budget.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, budgetToResponse(budget))
+	}
+}
+
+func DeleteBudgetHandler(budgetService service.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, budgetID, ok := budgetRequestIDs(w, r, true)
+		if !ok {
+			return
+		}
+
+		if err := budgetService.DeleteBudget(r.Context(), groupID, budgetID, userID); err != nil {
+			sendBudgetError(w, err, "system.budget.delete_failed", "Unable to delete budget.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GetBudgetStatusHandler reports spending against every budget for the period
+// containing ?date=YYYY-MM-DD, today by default.
+func GetBudgetStatusHandler(budgetService service.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, _, ok := budgetRequestIDs(w, r, false)
+		if !ok {
+			return
+		}
+
+		date := time.Now().UTC()
+		if value := r.URL.Query().Get("date"); value != "" {
+			parsed, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				response.SendErrorWithCode(w, http.StatusBadRequest, "validation.budget.date_invalid", "Date must be formatted as YYYY-MM-DD.")
+				return
+			}
+			date = parsed
+		}
+
+		statuses, err := budgetService.GetStatus(r.Context(), groupID, userID, date)
+		if err != nil {
+			sendBudgetError(w, err, "system.budget.status_failed", "Unable to load budget status.")
+			return
+		}
+
+		resp := make([]BudgetStatusResponse, 0, len(statuses))
+		for _, status := range statuses {
+			resp = append(resp, budgetStatusToResponse(status))
+		}
+
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockBudgetService for testing
+type MockBudgetService struct {
+	service.BudgetService
+	GetStatusFunc func(ctx context.Context, groupID, requesterID pgtype.UUID, date time.Time) ([]service.BudgetStatus, error)
+}
+
+func (m *MockBudgetService) GetStatus(ctx context.Context, groupID, requesterID pgtype.UUID, date time.Time) ([]service.BudgetStatus, error) {
+	if m.GetStatusFunc != nil {
+		return m.GetStatusFunc(ctx, groupID, requesterID, date)
+	}
+	return []service.BudgetStatus{}, nil
+}
+
+func TestGetBudgetStatusHandler(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	amount := pgtype.Numeric{}
+	_ = amount.Scan("100.00")
+
+	tests := []struct {
+		name     string
+		query    string
+		err      error
+		wantCode int
+		wantDate time.Time
+	}{
+		{name: "for a date", query: "?date=2026-03-18", wantCode: http.StatusOK, wantDate: time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "invalid date", query: "?date=18/03/2026", wantCode: http.StatusBadRequest},
+		{name: "not a member", err: service.ErrNotGroupMember, wantCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotDate time.Time
+			mock := &MockBudgetService{
+				GetStatusFunc: func(ctx context.Context, groupID, requesterID pgtype.UUID, date time.Time) ([]service.BudgetStatus, error) {
+					gotDate = date
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return []service.BudgetStatus{{
+						Budget:            sqlc.GroupBudget{ID: testutil.CreateTestUUID(5), GroupID: groupID, Name: "Food", Amount: amount, Period: service.BudgetPeriodMonthly, Thresholds: []int32{80, 100}},
+						PeriodStart:       time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+						PeriodEnd:         time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+						Spent:             decimal.RequireFromString("120.5"),
+						Remaining:         decimal.RequireFromString("-20.5"),
+						ThresholdsReached: []int32{80, 100},
+					}}, nil
+				},
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/groups/"+groupID.String()+"/budgets/status"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("group_id", groupID.String())
+			ctx := context.WithValue(middleware.SetUserID(req.Context(), testutil.CreateTestUUID(2)), chi.RouteCtxKey, rctx)
+			rr := httptest.NewRecorder()
+			GetBudgetStatusHandler(mock).ServeHTTP(rr, req.WithContext(ctx))
+
+			if rr.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rr.Code, rr.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if !gotDate.Equal(tt.wantDate) {
+				t.Errorf("expected date %v, got %v", tt.wantDate, gotDate)
+			}
+
+			var resp response.StandardResponse[[]BudgetStatusResponse, response.ErrorDetail]
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if resp.Data == nil || len(*resp.Data) != 1 {
+				t.Fatalf("expected 1 status, got %+v", resp.Data)
+			}
+			status := (*resp.Data)[0]
+			if status.Spent != "120.50" || status.Remaining != "-20.50" || !status.OverBudget {
+				t.Errorf("unexpected amounts %+v", status)
+			}
+			if status.PeriodStart != "2026-03-01" || status.PeriodEnd != "2026-03-31" || status.Budget.Amount != "100.00" {
+				t.Errorf("unexpected status %+v", status)
+			}
+		})
+	}
+}
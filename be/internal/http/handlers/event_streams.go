@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+// streamHeartbeatInterval keeps idle streams from being cut by proxies.
+const streamHeartbeatInterval = 25 * time.Second
+
+func GroupEventStreamHandler(eventStreamService service.EventStreamService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		lastEventID, ok := parseLastEventID(w, r)
+		if !ok {
+			return
+		}
+
+		sub, err := eventStreamService.SubscribeGroup(r.Context(), groupID, userID, lastEventID)
+		if err != nil {
+			if errors.Is(err, service.ErrGroupNotFound) {
+				response.SendErrorWithCode(w, http.StatusNotFound, "resource.group.not_found", "Group not found.")
+				return
+			}
+			if errors.Is(err, service.ErrNotGroupMember) {
+				response.SendErrorWithCode(w, http.StatusForbidden, "permission.group.member_required", "You are not a member of this group.")
+				return
+			}
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.stream.subscribe_failed", "Unable to open event stream.")
+			return
+		}
+		defer sub.Close()
+
+		writeEventStream(w, r, sub)
+	}
+}
+
+func UserEventStreamHandler(eventStreamService service.EventStreamService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		lastEventID, ok := parseLastEventID(w, r)
+		if !ok {
+			return
+		}
+
+		sub, err := eventStreamService.SubscribeUser(r.Context(), userID, lastEventID)
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.stream.subscribe_failed", "Unable to open event stream.")
+			return
+		}
+		defer sub.Close()
+
+		writeEventStream(w, r, sub)
+	}
+}
+
+// parseLastEventID reads the event to resume after from the Last-Event-ID
+// header browsers send when reconnecting, or the last_event_id query parameter
+// for the first connection. It writes the error response itself.
+func parseLastEventID(w http.ResponseWriter, r *http.Request) (pgtype.UUID, bool) {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
+	}
+	if raw == "" {
+		return pgtype.UUID{}, true
+	}
+
+	id, err := parseUUID(raw)
+	if err != nil {
+		response.SendErrorWithCode(w, http.StatusBadRequest, "validation.stream.last_event_id_invalid", "Invalid Last-Event-ID.")
+		return pgtype.UUID{}, false
+	}
+	return id, true
+}
+
+// writeEventStream sends events as Server-Sent Events until the client goes
+// away or the subscription ends.
+func writeEventStream(w http.ResponseWriter, r *http.Request, sub *service.StreamSubscription) {
+	rc := http.NewResponseController(w)
+	// Streams outlive the server's write timeout
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Ask browsers to wait a few seconds before reconnecting
+	fmt.Fprint(w, "retry: 3000\n\n")
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				continue
+			}
+			if event.ID != "" {
+				fmt.Fprintf(w, "id: %s\n", event.ID)
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case <-r.Context().Done():
+			return
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockEventStreamService replays a fixed list of events and ends the stream.
+type MockEventStreamService struct {
+	Events        []service.StreamEvent
+	Err           error
+	GotLastEvent  pgtype.UUID
+	GotGroupID    pgtype.UUID
+	GotSubscriber pgtype.UUID
+}
+
+func (m *MockEventStreamService) subscription() *service.StreamSubscription {
+	events := make(chan service.StreamEvent, len(m.Events))
+	for _, event := range m.Events {
+		events <- event
+	}
+	close(events)
+	return &service.StreamSubscription{Events: events, Close: func() {}}
+}
+
+func (m *MockEventStreamService) SubscribeGroup(ctx context.Context, groupID, userID, lastEventID pgtype.UUID) (*service.StreamSubscription, error) {
+	m.GotGroupID, m.GotSubscriber, m.GotLastEvent = groupID, userID, lastEventID
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.subscription(), nil
+}
+
+func (m *MockEventStreamService) SubscribeUser(ctx context.Context, userID, lastEventID pgtype.UUID) (*service.StreamSubscription, error) {
+	m.GotSubscriber, m.GotLastEvent = userID, lastEventID
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.subscription(), nil
+}
+
+func (m *MockEventStreamService) Run(ctx context.Context) {}
+
+func TestGroupEventStreamHandler(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	groupID := testutil.CreateTestUUID(2)
+	lastEventID := testutil.CreateTestUUID(3)
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		err         error
+		wantCode    int
+		wantBody    []string
+	}{
+		{
+			name:        "streams events",
+			lastEventID: formatUUID(lastEventID.Bytes),
+			wantCode:    http.StatusOK,
+			wantBody: []string{
+				"retry: 3000\n\n",
+				"id: a1\nevent: activity\ndata: {\"action\":\"expense_created\"}\n\n",
+				"id: a1\nevent: balance_changed\ndata: {\"group_id\":\"g1\"}\n\n",
+				"event: reset\ndata: null\n\n",
+			},
+		},
+		{name: "invalid last event id", lastEventID: "nope", wantCode: http.StatusBadRequest, wantBody: []string{"validation.stream.last_event_id_invalid"}},
+		{name: "not a member", err: service.ErrNotGroupMember, wantCode: http.StatusForbidden, wantBody: []string{"permission.group.member_required"}},
+		{name: "deleted group", err: service.ErrGroupNotFound, wantCode: http.StatusNotFound, wantBody: []string{"resource.group.not_found"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &MockEventStreamService{
+				Err: tt.err,
+				Events: []service.StreamEvent{
+					{ID: "a1", Type: service.StreamEventActivity, Data: map[string]string{"action": "expense_created"}},
+					{ID: "a1", Type: service.StreamEventBalanceChanged, Data: map[string]string{"group_id": "g1"}},
+					{Type: service.StreamEventReset},
+				},
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/groups/"+formatUUID(groupID.Bytes)+"/events", nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("group_id", formatUUID(groupID.Bytes))
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			req = req.WithContext(middleware.SetUserID(ctx, userID))
+			rr := httptest.NewRecorder()
+
+			GroupEventStreamHandler(svc).ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rr.Code, rr.Body.String())
+			}
+			body := rr.Body.String()
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("expected %q in %q", want, body)
+				}
+			}
+			if tt.wantCode == http.StatusOK {
+				if ct := rr.Header().Get("Content-Type"); ct != "text/event-stream" {
+					t.Errorf("expected text/event-stream, got %q", ct)
+				}
+				if svc.GotLastEvent != lastEventID || svc.GotGroupID != groupID || svc.GotSubscriber != userID {
+					t.Errorf("unexpected subscription %+v", svc)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+// maxAttachmentRequestBytes caps the raw request body. The configured per-file
+// limit is enforced by the service while streaming.
+const maxAttachmentRequestBytes = 64 << 20
+
+// Response structs
+
+type AttachmentResponse struct {
+	ID          pgtype.UUID `json:"id"`
+	ExpenseID   pgtype.UUID `json:"expense_id"`
+	UploadedBy  pgtype.UUID `json:"uploaded_by"`
+	FileName    string      `json:"file_name"`
+	ContentType string      `json:"content_type"`
+	SizeBytes   int64       `json:"size_bytes"`
+	Checksum    string      `json:"checksum_sha256"`
+	CreatedAt   string      `json:"created_at"`
+}
+
+// Handlers
+
+// UploadAttachmentHandler stores the "file" part of a multipart form. The part is
+// streamed straight to storage rather than buffered by ParseMultipartForm.
+func UploadAttachmentHandler(attachmentService service.ExpenseAttachmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, expenseID, userID, ok := parseAttachmentRequest(w, r)
+		if !ok {
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentRequestBytes)
+		reader, err := r.MultipartReader()
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "expected a multipart/form-data body")
+			return
+		}
+
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				response.SendError(w, http.StatusBadRequest, "file is required")
+				return
+			}
+			if err != nil {
+				sendAttachmentError(w, err)
+				return
+			}
+			if part.FormName() != "file" {
+				part.Close()
+				continue
+			}
+
+			attachment, err := attachmentService.UploadAttachment(r.Context(), service.UploadAttachmentInput{
+				GroupID:    groupID,
+				ExpenseID:  expenseID,
+				UploadedBy: userID,
+				FileName:   part.FileName(),
+				Content:    part,
+			})
+			part.Close()
+			if err != nil {
+				sendAttachmentError(w, err)
+				return
+			}
+
+			response.SendSuccess(w, http.StatusCreated, attachmentToResponse(attachment))
+			return
+		}
+	}
+}
+
+func ListAttachmentsHandler(attachmentService service.ExpenseAttachmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, expenseID, userID, ok := parseAttachmentRequest(w, r)
+		if !ok {
+			return
+		}
+
+		attachments, err := attachmentService.ListAttachments(r.Context(), groupID, expenseID, userID)
+		if err != nil {
+			sendAttachmentError(w, err)
+			return
+		}
+
+		resp := make([]AttachmentResponse, len(attachments))
+		for i, a := range attachments {
+			resp[i] = attachmentToResponse(a)
+		}
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+// DownloadAttachmentHandler streams the stored file back with the sniffed type.
+func DownloadAttachmentHandler(attachmentService service.ExpenseAttachmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, expenseID, userID, ok := parseAttachmentRequest(w, r)
+		if !ok {
+			return
+		}
+
+		attachmentID, err := parseUUID(chi.URLParam(r, "attachment_id"))
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid attachment_id")
+			return
+		}
+
+		attachment, content, err := attachmentService.OpenAttachment(r.Context(), groupID, expenseID, attachmentID, userID)
+		if err != nil {
+			sendAttachmentError(w, err)
+			return
+		}
+		defer content.Close()
+
+		w.Header().Set("Content-Type", attachment.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(attachment.SizeBytes, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": attachment.FileName}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Security-Policy", "sandbox")
+		w.Header().Set("Cache-Control", "private, max-age=3600")
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := io.Copy(w, content); err != nil {
+			log.Printf("attachment %s download interrupted: %v", uuidToString(attachment.ID), err)
+		}
+	}
+}
+
+func DeleteAttachmentHandler(attachmentService service.ExpenseAttachmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, expenseID, userID, ok := parseAttachmentRequest(w, r)
+		if !ok {
+			return
+		}
+
+		attachmentID, err := parseUUID(chi.URLParam(r, "attachment_id"))
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid attachment_id")
+			return
+		}
+
+		if err := attachmentService.DeleteAttachment(r.Context(), groupID, expenseID, attachmentID, userID); err != nil {
+			sendAttachmentError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Helper functions
+
+func parseAttachmentRequest(w http.ResponseWriter, r *http.Request) (groupID, expenseID, userID pgtype.UUID, ok bool) {
+	groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "invalid group_id")
+		return groupID, expenseID, userID, false
+	}
+
+	expenseID, err = parseUUID(chi.URLParam(r, "expense_id"))
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "invalid expense_id")
+		return groupID, expenseID, userID, false
+	}
+
+	userID, ok = middleware.GetUserID(r)
+	if !ok {
+		response.SendError(w, http.StatusUnauthorized, "unauthorized")
+		return groupID, expenseID, userID, false
+	}
+
+	return groupID, expenseID, userID, true
+}
+
+func sendAttachmentError(w http.ResponseWriter, err error) {
+	statusCode := http.StatusInternalServerError
+	message := err.Error()
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr), errors.Is(err, service.ErrAttachmentTooLarge):
+		statusCode = http.StatusRequestEntityTooLarge
+		message = service.ErrAttachmentTooLarge.Error()
+	case errors.Is(err, service.ErrExpenseNotFound), errors.Is(err, service.ErrAttachmentNotFound):
+		statusCode = http.StatusNotFound
+	case errors.Is(err, service.ErrNotGroupMember), errors.Is(err, service.ErrAttachmentPermissionDenied):
+		statusCode = http.StatusForbidden
+	case errors.Is(err, service.ErrAttachmentTypeNotAllowed):
+		statusCode = http.StatusUnsupportedMediaType
+	case errors.Is(err, service.ErrAttachmentEmpty):
+		statusCode = http.StatusBadRequest
+	}
+
+	response.SendError(w, statusCode, message)
+}
+
+func attachmentToResponse(a sqlc.ExpenseAttachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          a.ID,
+		ExpenseID:   a.ExpenseID,
+		UploadedBy:  a.UploadedBy,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		SizeBytes:   a.SizeBytes,
+		Checksum:    a.ChecksumSha256,
+		CreatedAt:   formatTimestamp(a.CreatedAt),
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
@@ -25,6 +26,26 @@ type CommentResponse struct {
 	User      *UserInfo   `json:"user,omitempty"`
 }
 
+func commentRowsToResponse(comments []sqlc.ListExpenseCommentsRow) []CommentResponse {
+	resp := make([]CommentResponse, len(comments))
+	for i, c := range comments {
+		resp[i] = CommentResponse{
+			ID:        c.ID,
+			ExpenseID: c.ExpenseID,
+			UserID:    c.UserID,
+			Comment:   c.Comment,
+			CreatedAt: formatTimestamp(c.CreatedAt),
+			UpdatedAt: formatTimestamp(c.UpdatedAt),
+			User: &UserInfo{
+				Email:     c.UserEmail,
+				Name:      c.UserName.String,
+				AvatarURL: c.UserAvatarUrl.String,
+			},
+		}
+	}
+	return resp
+}
+
 func CreateCommentHandler(commentService service.ExpenseCommentService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		req, ok := middleware.GetBody[CommentRequest](r)
@@ -90,24 +111,7 @@ func ListCommentsHandler(commentService service.ExpenseCommentService) http.Hand
 			return
 		}
 
-		resp := make([]CommentResponse, len(comments))
-		for i, c := range comments {
-			resp[i] = CommentResponse{
-				ID:        c.ID,
-				ExpenseID: c.ExpenseID,
-				UserID:    c.UserID,
-				Comment:   c.Comment,
-				CreatedAt: formatTimestamp(c.CreatedAt),
-				UpdatedAt: formatTimestamp(c.UpdatedAt),
-				User: &UserInfo{
-					Email:     c.UserEmail,
-					Name:      c.UserName.String,
-					AvatarURL: c.UserAvatarUrl.String,
-				},
-			}
-		}
-
-		response.SendSuccess(w, http.StatusOK, resp)
+		response.SendSuccess(w, http.StatusOK, commentRowsToResponse(comments))
 	}
 }
 
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+// maxImportUploadBytes bounds the CSV upload; MaxImportRows is enforced separately.
+const maxImportUploadBytes = 5 << 20
+
+// Request structs
+
+// ImportColumnMappingRequest maps expense fields to CSV headers. Fields left out
+// fall back to a header with the field's own name.
+type ImportColumnMappingRequest struct {
+	Date         *string `json:"date"`
+	Title        *string `json:"title"`
+	Amount       *string `json:"amount"`
+	Currency     *string `json:"currency"`
+	Notes        *string `json:"notes"`
+	Category     *string `json:"category"`
+	Tags         *string `json:"tags"`
+	Payer        *string `json:"payer"`
+	Participants *string `json:"participants"`
+	SplitType    *string `json:"split_type"`
+	SplitValues  *string `json:"split_values"`
+}
+
+// Response structs
+
+type ImportRowErrorResponse struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportExpensesResponse struct {
+	DryRun    bool                     `json:"dry_run"`
+	Valid     bool                     `json:"valid"`
+	TotalRows int                      `json:"total_rows"`
+	Imported  int                      `json:"imported"`
+	Errors    []ImportRowErrorResponse `json:"errors"`
+	Expenses  []ExpenseResponse        `json:"expenses"`
+}
+
+// Handlers
+
+// ImportExpensesHandler imports expenses from a CSV file. The file is sent either
+// as the "file" field of a multipart form or as a raw text/csv body. Options are
+// read from form fields or query params: mapping (JSON object), date_format and
+// dry_run. Nothing is written unless every row is valid.
+func ImportExpensesHandler(expenseService service.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid group_id")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxImportUploadBytes)
+
+		var file io.Reader = r.Body
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			if err := r.ParseMultipartForm(maxImportUploadBytes); err != nil {
+				response.SendError(w, http.StatusBadRequest, "invalid multipart form")
+				return
+			}
+			upload, _, err := r.FormFile("file")
+			if err != nil {
+				response.SendError(w, http.StatusBadRequest, "file is required")
+				return
+			}
+			defer upload.Close()
+			file = upload
+		}
+
+		mapping := service.DefaultImportColumnMapping()
+		if raw := formOrQuery(r, "mapping"); raw != "" {
+			var req ImportColumnMappingRequest
+			if err := json.Unmarshal([]byte(raw), &req); err != nil {
+				response.SendError(w, http.StatusBadRequest, "invalid mapping, expected a JSON object")
+				return
+			}
+			mapping = req.apply(mapping)
+		}
+
+		dryRun := false
+		if raw := formOrQuery(r, "dry_run"); raw != "" {
+			dryRun, err = strconv.ParseBool(raw)
+			if err != nil {
+				response.SendError(w, http.StatusBadRequest, "invalid dry_run, expected true or false")
+				return
+			}
+		}
+
+		result, err := expenseService.ImportExpenses(r.Context(), service.ImportExpensesInput{
+			GroupID:     groupID,
+			RequesterID: userID,
+			CSV:         file,
+			Mapping:     mapping,
+			DateFormat:  formOrQuery(r, "date_format"),
+			DryRun:      dryRun,
+		})
+		if err != nil {
+			statusCode := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, service.ErrExpenseNotFound):
+				statusCode = http.StatusNotFound
+			case errors.Is(err, service.ErrNotGroupMember):
+				statusCode = http.StatusForbidden
+			case errors.Is(err, service.ErrGroupArchived):
+				statusCode = http.StatusConflict
+			case errors.Is(err, service.ErrImportInvalidCSV),
+				errors.Is(err, service.ErrImportEmpty),
+				errors.Is(err, service.ErrImportTooManyRows),
+				errors.Is(err, service.ErrImportMissingColumn),
+				errors.Is(err, service.ErrImportInvalidMapping),
+				errors.Is(err, service.ErrImportDateFormat):
+				statusCode = http.StatusBadRequest
+			}
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				statusCode = http.StatusRequestEntityTooLarge
+			}
+			response.SendError(w, statusCode, err.Error())
+			return
+		}
+
+		resp := importResultToResponse(result)
+		if !resp.Valid && !result.DryRun {
+			response.SendErrorWithCodeAndDetails(w, http.StatusUnprocessableEntity, "validation.import.invalid_rows", "Some rows are invalid; nothing was imported.", resp.Errors)
+			return
+		}
+
+		statusCode := http.StatusCreated
+		if result.DryRun {
+			statusCode = http.StatusOK
+		}
+		response.SendSuccess(w, statusCode, resp)
+	}
+}
+
+// Helper functions
+
+func formOrQuery(r *http.Request, key string) string {
+	if r.MultipartForm != nil {
+		if values := r.MultipartForm.Value[key]; len(values) > 0 {
+			return values[0]
+		}
+	}
+	return r.URL.Query().Get(key)
+}
+
+func (req ImportColumnMappingRequest) apply(mapping service.ImportColumnMapping) service.ImportColumnMapping {
+	set := func(dst *string, src *string) {
+		if src != nil {
+			*dst = strings.TrimSpace(*src)
+		}
+	}
+	set(&mapping.Date, req.Date)
+	set(&mapping.Title, req.Title)
+	set(&mapping.Amount, req.Amount)
+	set(&mapping.Currency, req.Currency)
+	set(&mapping.Notes, req.Notes)
+	set(&mapping.Category, req.Category)
+	set(&mapping.Tags, req.Tags)
+	set(&mapping.Payer, req.Payer)
+	set(&mapping.Participants, req.Participants)
+	set(&mapping.SplitType, req.SplitType)
+	set(&mapping.SplitValues, req.SplitValues)
+	return mapping
+}
+
+func importResultToResponse(result service.ImportExpensesResult) ImportExpensesResponse {
+	resp := ImportExpensesResponse{
+		DryRun:    result.DryRun,
+		Valid:     len(result.Errors) == 0,
+		TotalRows: result.TotalRows,
+		Imported:  result.Imported,
+		Errors:    make([]ImportRowErrorResponse, len(result.Errors)),
+		Expenses:  make([]ExpenseResponse, len(result.Expenses)),
+	}
+	for i, e := range result.Errors {
+		resp.Errors[i] = ImportRowErrorResponse{Row: e.Row, Column: e.Column, Message: e.Message}
+	}
+	for i, e := range result.Expenses {
+		resp.Expenses[i] = expenseToResponse(e)
+	}
+	return resp
+}
@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
@@ -32,16 +34,38 @@ type SplitRequest struct {
 	Amount        *string `json:"amount,omitempty"`
 }
 
+type ItemConsumerRequest struct {
+	UserID        string `json:"user_id,omitempty" validate:"omitempty,uuid"`
+	PendingUserID string `json:"pending_user_id,omitempty" validate:"omitempty,uuid"`
+}
+
+type ReceiptItemRequest struct {
+	Name      string                `json:"name" validate:"max=200"`
+	Amount    string                `json:"amount" validate:"required"`
+	Consumers []ItemConsumerRequest `json:"consumers" validate:"required,min=1,dive"`
+}
+
+// ReceiptRequest describes an itemized expense. When present, splits are
+// derived from the items and must be omitted.
+type ReceiptRequest struct {
+	Items    []ReceiptItemRequest `json:"items" validate:"required,min=1,dive"`
+	Tax      *string              `json:"tax,omitempty"`
+	Tip      *string              `json:"tip,omitempty"`
+	Discount *string              `json:"discount,omitempty"`
+}
+
 type CreateExpenseRequest struct {
 	Title        string           `json:"title" validate:"required,min=1,max=200"`
 	Notes        string           `json:"notes,omitempty" validate:"max=1000"`
 	Amount       string           `json:"amount" validate:"required"`
 	CurrencyCode string           `json:"currency_code,omitempty" validate:"omitempty,len=3"`
+	ExchangeRate *string          `json:"exchange_rate,omitempty"`
 	Date         string           `json:"date" validate:"required"`
 	CategoryID   string           `json:"category_id,omitempty" validate:"omitempty,uuid"`
 	Tags         []string         `json:"tags,omitempty"`
 	Payments     []PaymentRequest `json:"payments" validate:"required,min=1,dive"`
-	Splits       []SplitRequest   `json:"splits" validate:"required,min=1,dive"`
+	Splits       []SplitRequest   `json:"splits" validate:"required_without=Receipt,dive"`
+	Receipt      *ReceiptRequest  `json:"receipt,omitempty"`
 }
 
 type UpdateExpenseRequest struct {
@@ -49,29 +73,33 @@ type UpdateExpenseRequest struct {
 	Notes        string           `json:"notes,omitempty" validate:"max=1000"`
 	Amount       string           `json:"amount" validate:"required"`
 	CurrencyCode string           `json:"currency_code,omitempty" validate:"omitempty,len=3"`
+	ExchangeRate *string          `json:"exchange_rate,omitempty"`
 	Date         string           `json:"date" validate:"required"`
 	CategoryID   string           `json:"category_id,omitempty" validate:"omitempty,uuid"`
 	Tags         []string         `json:"tags,omitempty"`
 	Payments     []PaymentRequest `json:"payments" validate:"required,min=1,dive"`
-	Splits       []SplitRequest   `json:"splits" validate:"required,min=1,dive"`
+	Splits       []SplitRequest   `json:"splits" validate:"required_without=Receipt,dive"`
+	Receipt      *ReceiptRequest  `json:"receipt,omitempty"`
 }
 
 // Response structs
 
 type ExpenseResponse struct {
-	ID           pgtype.UUID  `json:"id"`
-	GroupID      pgtype.UUID  `json:"group_id"`
-	Title        string       `json:"title"`
-	Notes        string       `json:"notes,omitempty"`
-	Amount       string       `json:"amount"`
-	CurrencyCode string       `json:"currency_code"`
-	Date         string       `json:"date"`
-	CategoryID   *pgtype.UUID `json:"category_id,omitempty"`
-	Tags         []string     `json:"tags,omitempty"`
-	CreatedAt    string       `json:"created_at"`
-	CreatedBy    pgtype.UUID  `json:"created_by"`
-	UpdatedAt    string       `json:"updated_at"`
-	UpdatedBy    pgtype.UUID  `json:"updated_by"`
+	ID              pgtype.UUID  `json:"id"`
+	GroupID         pgtype.UUID  `json:"group_id"`
+	Title           string       `json:"title"`
+	Notes           string       `json:"notes,omitempty"`
+	Amount          string       `json:"amount"`
+	CurrencyCode    string       `json:"currency_code"`
+	ExchangeRate    string       `json:"exchange_rate"`
+	ConvertedAmount string       `json:"converted_amount"`
+	Date            string       `json:"date"`
+	CategoryID      *pgtype.UUID `json:"category_id,omitempty"`
+	Tags            []string     `json:"tags,omitempty"`
+	CreatedAt       string       `json:"created_at"`
+	CreatedBy       pgtype.UUID  `json:"created_by"`
+	UpdatedAt       string       `json:"updated_at"`
+	UpdatedBy       pgtype.UUID  `json:"updated_by"`
 }
 
 type PaymentResponse struct {
@@ -99,10 +127,30 @@ type SplitResponse struct {
 	PendingUser   *UserInfo    `json:"pending_user,omitempty"`
 }
 
+type ItemConsumerResponse struct {
+	UserID        *pgtype.UUID `json:"user_id,omitempty"`
+	PendingUserID *pgtype.UUID `json:"pending_user_id,omitempty"`
+}
+
+type ReceiptItemResponse struct {
+	ID        pgtype.UUID            `json:"id"`
+	Name      string                 `json:"name"`
+	Amount    string                 `json:"amount"`
+	Consumers []ItemConsumerResponse `json:"consumers"`
+}
+
+type ReceiptResponse struct {
+	Items    []ReceiptItemResponse `json:"items"`
+	Tax      string                `json:"tax,omitempty"`
+	Tip      string                `json:"tip,omitempty"`
+	Discount string                `json:"discount,omitempty"`
+}
+
 type CreateExpenseResponse struct {
 	Expense  ExpenseResponse   `json:"expense"`
 	Payments []PaymentResponse `json:"payments"`
 	Splits   []SplitResponse   `json:"splits"`
+	Receipt  *ReceiptResponse  `json:"receipt,omitempty"`
 }
 
 // List responses
@@ -205,6 +253,13 @@ func CreateExpenseHandler(expenseService service.ExpenseService) http.HandlerFun
 			}
 		}
 
+		// Convert receipt line items for itemized expenses
+		receipt, err := receiptRequestToInput(req.Receipt)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		// Parse category_id if provided
 		var categoryID *pgtype.UUID
 		if req.CategoryID != "" {
@@ -222,12 +277,14 @@ func CreateExpenseHandler(expenseService service.ExpenseService) http.HandlerFun
 			Notes:        req.Notes,
 			Amount:       req.Amount,
 			CurrencyCode: req.CurrencyCode,
+			ExchangeRate: req.ExchangeRate,
 			Date:         date,
 			CategoryID:   categoryID,
 			Tags:         req.Tags,
 			CreatedBy:    userID,
 			Payments:     payments,
 			Splits:       splits,
+			Receipt:      receipt,
 		})
 		if err != nil {
 			statusCode := http.StatusBadRequest
@@ -238,9 +295,14 @@ func CreateExpenseHandler(expenseService service.ExpenseService) http.HandlerFun
 				statusCode = http.StatusForbidden
 			case service.ErrCategoryNotFound, service.ErrCategoryNotInGroup:
 				statusCode = http.StatusBadRequest
+			case service.ErrGroupArchived:
+				statusCode = http.StatusConflict
 			case service.ErrInvalidAmount, service.ErrPaymentTotalMismatch, service.ErrSplitTotalMismatch,
 				service.ErrPercentageTotalMismatch, service.ErrAmountRequired, service.ErrPercentageRequired,
-				service.ErrSharesRequired, service.ErrMixedSplitTypes, service.ErrInvalidSplitType:
+				service.ErrSharesRequired, service.ErrMixedSplitTypes, service.ErrInvalidSplitType,
+				service.ErrInvalidCurrencyCode, service.ErrInvalidExchangeRate, service.ErrExchangeRateUnavailable,
+				service.ErrReceiptTotalMismatch, service.ErrItemConsumersRequired, service.ErrDuplicateItemConsumer,
+				service.ErrSplitsWithReceipt:
 				statusCode = http.StatusUnprocessableEntity
 			}
 			response.SendError(w, statusCode, err.Error())
@@ -278,6 +340,7 @@ func CreateExpenseHandler(expenseService service.ExpenseService) http.HandlerFun
 			Expense:  expenseToResponse(result.Expense),
 			Payments: paymentsWithUserToResponse(paymentRows),
 			Splits:   splitsWithUserToResponse(splitRows),
+			Receipt:  receiptToResponse(result.Items),
 		}
 
 		response.SendSuccess(w, http.StatusCreated, resp)
@@ -338,10 +401,24 @@ func GetExpenseHandler(expenseService service.ExpenseService) http.HandlerFunc {
 			return
 		}
 
+		items, err := expenseService.GetExpenseItems(r.Context(), expenseID, userID)
+		if err != nil {
+			statusCode := http.StatusBadRequest
+			switch err {
+			case service.ErrExpenseNotFound:
+				statusCode = http.StatusNotFound
+			case service.ErrNotGroupMember:
+				statusCode = http.StatusForbidden
+			}
+			response.SendError(w, statusCode, err.Error())
+			return
+		}
+
 		resp := CreateExpenseResponse{
 			Expense:  expenseToResponse(expense),
 			Payments: paymentsWithUserToResponse(payments),
 			Splits:   splitsWithUserToResponse(splits),
+			Receipt:  receiptToResponse(items),
 		}
 
 		response.SendSuccess(w, http.StatusOK, resp)
@@ -492,6 +569,13 @@ func UpdateExpenseHandler(expenseService service.ExpenseService) http.HandlerFun
 			}
 		}
 
+		// Convert receipt line items for itemized expenses
+		receipt, err := receiptRequestToInput(req.Receipt)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		// Parse category_id if provided
 		var categoryID *pgtype.UUID
 		if req.CategoryID != "" {
@@ -509,12 +593,14 @@ func UpdateExpenseHandler(expenseService service.ExpenseService) http.HandlerFun
 			Notes:        req.Notes,
 			Amount:       req.Amount,
 			CurrencyCode: req.CurrencyCode,
+			ExchangeRate: req.ExchangeRate,
 			Date:         date,
 			CategoryID:   categoryID,
 			Tags:         req.Tags,
 			UpdatedBy:    userID,
 			Payments:     payments,
 			Splits:       splits,
+			Receipt:      receipt,
 		})
 		if err != nil {
 			statusCode := http.StatusBadRequest
@@ -525,9 +611,14 @@ func UpdateExpenseHandler(expenseService service.ExpenseService) http.HandlerFun
 				statusCode = http.StatusForbidden
 			case service.ErrCategoryNotFound, service.ErrCategoryNotInGroup:
 				statusCode = http.StatusBadRequest
+			case service.ErrGroupArchived:
+				statusCode = http.StatusConflict
 			case service.ErrInvalidAmount, service.ErrPaymentTotalMismatch, service.ErrSplitTotalMismatch,
 				service.ErrPercentageTotalMismatch, service.ErrAmountRequired, service.ErrPercentageRequired,
-				service.ErrSharesRequired, service.ErrMixedSplitTypes, service.ErrInvalidSplitType:
+				service.ErrSharesRequired, service.ErrMixedSplitTypes, service.ErrInvalidSplitType,
+				service.ErrInvalidCurrencyCode, service.ErrInvalidExchangeRate, service.ErrExchangeRateUnavailable,
+				service.ErrReceiptTotalMismatch, service.ErrItemConsumersRequired, service.ErrDuplicateItemConsumer,
+				service.ErrSplitsWithReceipt:
 				statusCode = http.StatusUnprocessableEntity
 			}
 			response.SendError(w, statusCode, err.Error())
@@ -565,6 +656,7 @@ func UpdateExpenseHandler(expenseService service.ExpenseService) http.HandlerFun
 			Expense:  expenseToResponse(result.Expense),
 			Payments: paymentsWithUserToResponse(paymentRows),
 			Splits:   splitsWithUserToResponse(splitRows),
+			Receipt:  receiptToResponse(result.Items),
 		}
 
 		response.SendSuccess(w, http.StatusOK, resp)
@@ -660,7 +752,7 @@ func SearchExpensesHandler(expenseService service.ExpenseService) http.HandlerFu
 			}
 			owerID = &id
 		}
-		
+
 		limit := 20
 		if s := r.URL.Query().Get("limit"); s != "" {
 			if l, err := strconv.Atoi(s); err == nil && l > 0 {
@@ -674,7 +766,7 @@ func SearchExpensesHandler(expenseService service.ExpenseService) http.HandlerFu
 				offset = o
 			}
 		}
-		
+
 		input := service.SearchExpensesInput{
 			GroupID:    groupID,
 			Query:      queryPtr,
@@ -709,7 +801,7 @@ func SearchExpensesHandler(expenseService service.ExpenseService) http.HandlerFu
 			if err != nil {
 				continue
 			}
-			
+
 			resp[i] = GroupExpenseResponse{
 				Expense:  expenseToResponse(e),
 				Payments: paymentsWithUserToResponse(paymentRows),
@@ -755,6 +847,16 @@ func DeleteExpenseHandler(expenseService service.ExpenseService) http.HandlerFun
 
 func expenseToResponse(expense sqlc.Expense) ExpenseResponse {
 	amount, _ := numericToString(expense.Amount)
+	exchangeRate := "1"
+	if expense.ExchangeRate.Valid {
+		exchangeRate, _ = numericToString(expense.ExchangeRate)
+	}
+	convertedAmount := amount
+	if amountDec, err := decimal.NewFromString(amount); err == nil {
+		if rateDec, err := decimal.NewFromString(exchangeRate); err == nil {
+			convertedAmount = amountDec.Mul(rateDec).StringFixed(2)
+		}
+	}
 	dateStr := ""
 	if expense.Date.Valid {
 		dateStr = expense.Date.Time.Format("2006-01-02")
@@ -771,19 +873,21 @@ func expenseToResponse(expense sqlc.Expense) ExpenseResponse {
 	}
 
 	return ExpenseResponse{
-		ID:           expense.ID,
-		GroupID:      expense.GroupID,
-		Title:        expense.Title,
-		Notes:        expense.Notes.String,
-		Amount:       amount,
-		CurrencyCode: expense.CurrencyCode,
-		Date:         dateStr,
-		CategoryID:   categoryID,
-		Tags:         tags,
-		CreatedAt:    formatTimestamp(expense.CreatedAt),
-		CreatedBy:    expense.CreatedBy,
-		UpdatedAt:    formatTimestamp(expense.UpdatedAt),
-		UpdatedBy:    expense.UpdatedBy,
+		ID:              expense.ID,
+		GroupID:         expense.GroupID,
+		Title:           expense.Title,
+		Notes:           expense.Notes.String,
+		Amount:          amount,
+		CurrencyCode:    expense.CurrencyCode,
+		ExchangeRate:    exchangeRate,
+		ConvertedAmount: convertedAmount,
+		Date:            dateStr,
+		CategoryID:      categoryID,
+		Tags:            tags,
+		CreatedAt:       formatTimestamp(expense.CreatedAt),
+		CreatedBy:       expense.CreatedBy,
+		UpdatedAt:       formatTimestamp(expense.UpdatedAt),
+		UpdatedBy:       expense.UpdatedBy,
 	}
 }
 
@@ -913,3 +1017,90 @@ func numericToString(n pgtype.Numeric) (string, error) {
 	}
 	return "0", nil
 }
+
+func receiptRequestToInput(req *ReceiptRequest) (*service.ReceiptInput, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	items := make([]service.ReceiptItemInput, len(req.Items))
+	for i, item := range req.Items {
+		consumers := make([]service.ItemConsumerInput, len(item.Consumers))
+		for j, c := range item.Consumers {
+			var userID pgtype.UUID
+			if c.UserID != "" {
+				var err error
+				userID, err = parseUUID(c.UserID)
+				if err != nil {
+					return nil, errors.New("invalid item consumer user_id")
+				}
+			}
+
+			var pendingUserID *pgtype.UUID
+			if c.PendingUserID != "" {
+				id, err := parseUUID(c.PendingUserID)
+				if err != nil {
+					return nil, errors.New("invalid item consumer pending_user_id")
+				}
+				pendingUserID = &id
+			}
+
+			consumers[j] = service.ItemConsumerInput{
+				UserID:        userID,
+				PendingUserID: pendingUserID,
+			}
+		}
+
+		items[i] = service.ReceiptItemInput{
+			Name:      item.Name,
+			Amount:    item.Amount,
+			Consumers: consumers,
+		}
+	}
+
+	return &service.ReceiptInput{
+		Items:    items,
+		Tax:      req.Tax,
+		Tip:      req.Tip,
+		Discount: req.Discount,
+	}, nil
+}
+
+// receiptToResponse returns nil for expenses that were not itemized.
+func receiptToResponse(details []service.ExpenseItemDetail) *ReceiptResponse {
+	if len(details) == 0 {
+		return nil
+	}
+
+	resp := &ReceiptResponse{Items: []ReceiptItemResponse{}}
+	for _, d := range details {
+		amount, _ := numericToString(d.Item.Amount)
+		switch d.Item.Kind {
+		case "tax":
+			resp.Tax = amount
+		case "tip":
+			resp.Tip = amount
+		case "discount":
+			resp.Discount = amount
+		default:
+			consumers := make([]ItemConsumerResponse, len(d.Consumers))
+			for i, c := range d.Consumers {
+				if c.UserID.Valid {
+					userID := c.UserID
+					consumers[i].UserID = &userID
+				}
+				if c.PendingUserID.Valid {
+					pendingUserID := c.PendingUserID
+					consumers[i].PendingUserID = &pendingUserID
+				}
+			}
+			resp.Items = append(resp.Items, ReceiptItemResponse{
+				ID:        d.Item.ID,
+				Name:      d.Item.Name,
+				Amount:    amount,
+				Consumers: consumers,
+			})
+		}
+	}
+	return resp
+}
@@ -30,6 +30,8 @@ type MockExpenseService struct {
 	GetExpensePaymentsFunc  func(ctx context.Context, expenseID, requesterID pgtype.UUID) ([]sqlc.ListExpensePaymentsRow, error)
 	GetExpenseSplitsFunc    func(ctx context.Context, expenseID, requesterID pgtype.UUID) ([]sqlc.ListExpenseSplitsRow, error)
 	SearchExpensesFunc      func(ctx context.Context, input service.SearchExpensesInput, requesterID pgtype.UUID) ([]sqlc.Expense, error)
+	GetExpenseItemsFunc     func(ctx context.Context, expenseID, requesterID pgtype.UUID) ([]service.ExpenseItemDetail, error)
+	ImportExpensesFunc      func(ctx context.Context, input service.ImportExpensesInput) (service.ImportExpensesResult, error)
 }
 
 func (m *MockExpenseService) CreateExpense(ctx context.Context, input service.CreateExpenseInput) (service.CreateExpenseResult, error) {
@@ -88,6 +90,20 @@ func (m *MockExpenseService) SearchExpenses(ctx context.Context, input service.S
 	return []sqlc.Expense{}, nil
 }
 
+func (m *MockExpenseService) GetExpenseItems(ctx context.Context, expenseID, requesterID pgtype.UUID) ([]service.ExpenseItemDetail, error) {
+	if m.GetExpenseItemsFunc != nil {
+		return m.GetExpenseItemsFunc(ctx, expenseID, requesterID)
+	}
+	return []service.ExpenseItemDetail{}, nil
+}
+
+func (m *MockExpenseService) ImportExpenses(ctx context.Context, input service.ImportExpensesInput) (service.ImportExpensesResult, error) {
+	if m.ImportExpensesFunc != nil {
+		return m.ImportExpensesFunc(ctx, input)
+	}
+	return service.ImportExpensesResult{}, nil
+}
+
 var _ service.ExpenseService = (*MockExpenseService)(nil)
 
 func createExpenseRequest(method, path string, body interface{}, userID pgtype.UUID) *http.Request {
@@ -106,6 +122,7 @@ func createExpenseRequest(method, path string, body interface{}, userID pgtype.U
 func TestCreateExpenseHandler(t *testing.T) {
 	userID := testutil.CreateTestUUID(1)
 	groupID := testutil.CreateTestUUID(10)
+	receiptTax := "3.00"
 
 	tests := []struct {
 		name           string
@@ -216,6 +233,67 @@ func TestCreateExpenseHandler(t *testing.T) {
 			},
 			expectedStatus: http.StatusCreated,
 		},
+		{
+			name: "itemized receipt without splits",
+			requestBody: CreateExpenseRequest{
+				Title:    "Dinner",
+				Amount:   "33.00",
+				Date:     time.Now().Format("2006-01-02"),
+				Payments: []PaymentRequest{{UserID: userID.String(), Amount: "33.00"}},
+				Receipt: &ReceiptRequest{
+					Items: []ReceiptItemRequest{
+						{Name: "Pizza", Amount: "30.00", Consumers: []ItemConsumerRequest{{UserID: userID.String()}}},
+					},
+					Tax: &receiptTax,
+				},
+			},
+			userID:  userID,
+			groupID: groupID,
+			mockSetup: func(mock *MockExpenseService) {
+				mock.CreateExpenseFunc = func(ctx context.Context, input service.CreateExpenseInput) (service.CreateExpenseResult, error) {
+					if input.Receipt == nil || len(input.Receipt.Items) != 1 || len(input.Splits) != 0 {
+						t.Errorf("expected receipt with one item and no splits, got %+v", input)
+					}
+					return service.CreateExpenseResult{Expense: sqlc.Expense{ID: testutil.CreateTestUUID(100)}}, nil
+				}
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "receipt total mismatch",
+			requestBody: CreateExpenseRequest{
+				Title:    "Dinner",
+				Amount:   "50.00",
+				Date:     time.Now().Format("2006-01-02"),
+				Payments: []PaymentRequest{{UserID: userID.String(), Amount: "50.00"}},
+				Receipt: &ReceiptRequest{
+					Items: []ReceiptItemRequest{
+						{Name: "Pizza", Amount: "30.00", Consumers: []ItemConsumerRequest{{UserID: userID.String()}}},
+					},
+				},
+			},
+			userID:  userID,
+			groupID: groupID,
+			mockSetup: func(mock *MockExpenseService) {
+				mock.CreateExpenseFunc = func(ctx context.Context, input service.CreateExpenseInput) (service.CreateExpenseResult, error) {
+					return service.CreateExpenseResult{}, service.ErrReceiptTotalMismatch
+				}
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing splits and receipt",
+			requestBody: CreateExpenseRequest{
+				Title:    "Dinner",
+				Amount:   "50.00",
+				Date:     time.Now().Format("2006-01-02"),
+				Payments: []PaymentRequest{{UserID: userID.String(), Amount: "50.00"}},
+			},
+			userID:         userID,
+			groupID:        groupID,
+			mockSetup:      func(mock *MockExpenseService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
 	}
 
 	for _, tt := range tests {
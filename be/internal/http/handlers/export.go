@@ -0,0 +1,399 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+// Response structs
+
+type ExportCategoryResponse struct {
+	ID   pgtype.UUID `json:"id"`
+	Slug string      `json:"slug"`
+	Name string      `json:"name"`
+}
+
+type ExportExpenseResponse struct {
+	ExpenseResponse
+	Category *ExportCategoryResponse `json:"category,omitempty"`
+	Payments []PaymentResponse       `json:"payments"`
+	Splits   []SplitResponse         `json:"splits"`
+	Receipt  *ReceiptResponse        `json:"receipt,omitempty"`
+	Comments []CommentResponse       `json:"comments"`
+}
+
+// Handlers
+
+// ExportGroupHandler streams a group's ledger as CSV or JSON.
+// Query params: format (csv|json, default json), start_date, end_date, category_id.
+func ExportGroupHandler(exportService service.ExportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid group_id")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		filter := service.ExportFilter{GroupID: groupID, RequesterID: userID}
+
+		if s := r.URL.Query().Get("start_date"); s != "" {
+			t, err := time.Parse("2006-01-02", s)
+			if err != nil {
+				response.SendError(w, http.StatusBadRequest, "invalid start_date format")
+				return
+			}
+			filter.StartDate = &t
+		}
+
+		if s := r.URL.Query().Get("end_date"); s != "" {
+			t, err := time.Parse("2006-01-02", s)
+			if err != nil {
+				response.SendError(w, http.StatusBadRequest, "invalid end_date format")
+				return
+			}
+			filter.EndDate = &t
+		}
+
+		if s := r.URL.Query().Get("category_id"); s != "" {
+			id, err := parseUUID(s)
+			if err != nil {
+				response.SendError(w, http.StatusBadRequest, "invalid category_id")
+				return
+			}
+			filter.CategoryID = &id
+		}
+
+		var writer exportStream
+		switch format := strings.ToLower(r.URL.Query().Get("format")); format {
+		case "", "json":
+			writer = &jsonExportWriter{w: w}
+		case "csv":
+			writer = &csvExportWriter{w: w}
+		default:
+			response.SendError(w, http.StatusBadRequest, "invalid format, expected csv or json")
+			return
+		}
+
+		err = exportService.ExportGroup(r.Context(), filter, writer)
+		if err != nil {
+			if writer.started() {
+				// Headers are already on the wire; all we can do is cut the stream short.
+				log.Printf("export of group %s aborted: %v", uuidToString(groupID), err)
+				return
+			}
+
+			statusCode := http.StatusInternalServerError
+			switch err {
+			case service.ErrGroupNotFound:
+				statusCode = http.StatusNotFound
+			case service.ErrNotGroupMember:
+				statusCode = http.StatusForbidden
+			case service.ErrInvalidDateRange:
+				statusCode = http.StatusBadRequest
+			}
+			response.SendError(w, statusCode, err.Error())
+			return
+		}
+	}
+}
+
+// Export writers
+
+type exportStream interface {
+	service.ExportWriter
+	started() bool
+}
+
+func setExportHeaders(w http.ResponseWriter, group sqlc.Group, contentType, ext string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="group-%s-ledger.%s"`, uuidToString(group.ID), ext))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+}
+
+func flush(w io.Writer) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// jsonExportWriter writes a single JSON document:
+// {"group":{...},"categories":[...],"expenses":[...],"settlements":[...]}
+type jsonExportWriter struct {
+	w              http.ResponseWriter
+	begun          bool
+	expenseCount   int
+	settlementOpen bool
+	settlementSeen int
+}
+
+func (j *jsonExportWriter) started() bool { return j.begun }
+
+func (j *jsonExportWriter) Begin(group sqlc.Group, categories []sqlc.ExpenseCategory) error {
+	setExportHeaders(j.w, group, "application/json", "json")
+	j.begun = true
+
+	groupJSON, err := json.Marshal(groupToResponse(group))
+	if err != nil {
+		return err
+	}
+	categoriesJSON, err := json.Marshal(exportCategories(categories))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(j.w, `{"group":%s,"categories":%s,"expenses":[`, groupJSON, categoriesJSON)
+	return err
+}
+
+func (j *jsonExportWriter) WriteExpense(expense service.ExportedExpense) error {
+	data, err := json.Marshal(exportExpenseToResponse(expense))
+	if err != nil {
+		return err
+	}
+	if j.expenseCount > 0 {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.expenseCount++
+	if _, err := j.w.Write(data); err != nil {
+		return err
+	}
+	flush(j.w)
+	return nil
+}
+
+func (j *jsonExportWriter) openSettlements() error {
+	if j.settlementOpen {
+		return nil
+	}
+	j.settlementOpen = true
+	_, err := io.WriteString(j.w, `],"settlements":[`)
+	return err
+}
+
+func (j *jsonExportWriter) WriteSettlement(settlement sqlc.ListSettlementsByGroupRow) error {
+	if err := j.openSettlements(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(settlementRowToResponse(settlement))
+	if err != nil {
+		return err
+	}
+	if j.settlementSeen > 0 {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.settlementSeen++
+	if _, err := j.w.Write(data); err != nil {
+		return err
+	}
+	flush(j.w)
+	return nil
+}
+
+func (j *jsonExportWriter) End() error {
+	if err := j.openSettlements(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(j.w, "]}")
+	flush(j.w)
+	return err
+}
+
+// csvExportWriter flattens the ledger into one row per record. Payments, splits,
+// receipt items and comments follow the expense they belong to and share its
+// expense_id; settlements come last.
+type csvExportWriter struct {
+	w     http.ResponseWriter
+	csv   *csv.Writer
+	begun bool
+}
+
+var csvExportHeader = []string{
+	"record_type", "id", "expense_id", "date", "title", "category", "tags",
+	"amount", "currency_code", "exchange_rate", "user_id", "user_email", "user_name",
+	"counterparty_id", "counterparty_email", "split_type", "status", "method", "notes",
+}
+
+func (c *csvExportWriter) started() bool { return c.begun }
+
+func (c *csvExportWriter) Begin(group sqlc.Group, categories []sqlc.ExpenseCategory) error {
+	setExportHeaders(c.w, group, "text/csv; charset=utf-8", "csv")
+	c.begun = true
+	c.csv = csv.NewWriter(c.w)
+	return c.csv.Write(csvExportHeader)
+}
+
+func (c *csvExportWriter) WriteExpense(record service.ExportedExpense) error {
+	e := record.Expense
+	expenseID := uuidToString(e.ID)
+	date := ""
+	if e.Date.Valid {
+		date = e.Date.Time.Format("2006-01-02")
+	}
+	category := ""
+	if record.Category != nil {
+		category = record.Category.Slug
+	}
+	amount, _ := numericToString(e.Amount)
+	exchangeRate := "1"
+	if e.ExchangeRate.Valid {
+		exchangeRate, _ = numericToString(e.ExchangeRate)
+	}
+
+	rows := [][]string{{
+		"expense", expenseID, expenseID, date, csvSafe(e.Title), category, csvSafe(strings.Join(e.Tags, ";")),
+		amount, e.CurrencyCode, exchangeRate, uuidToString(e.CreatedBy), "", "",
+		"", "", "", "", "", csvSafe(e.Notes.String),
+	}}
+
+	for _, p := range record.Payments {
+		paymentAmount, _ := numericToString(p.Amount)
+		participantID, email, name := p.UserID, p.UserEmail.String, p.UserName.String
+		if p.PendingUserID.Valid {
+			participantID, email, name = p.PendingUserID, p.PendingUserEmail.String, p.PendingUserName.String
+		}
+		rows = append(rows, []string{
+			"payment", uuidToString(p.ID), expenseID, date, "", "", "",
+			paymentAmount, e.CurrencyCode, exchangeRate, uuidToString(participantID), csvSafe(email), csvSafe(name),
+			"", "", "", "", csvSafe(p.PaymentMethod.String), "",
+		})
+	}
+
+	for _, s := range record.Splits {
+		splitAmount, _ := numericToString(s.AmountOwned)
+		participantID, email, name := s.UserID, s.UserEmail.String, s.UserName.String
+		if s.PendingUserID.Valid {
+			participantID, email, name = s.PendingUserID, s.PendingUserEmail.String, s.PendingUserName.String
+		}
+		rows = append(rows, []string{
+			"split", uuidToString(s.ID), expenseID, date, "", "", "",
+			splitAmount, e.CurrencyCode, exchangeRate, uuidToString(participantID), csvSafe(email), csvSafe(name),
+			"", "", s.SplitType, "", "", "",
+		})
+	}
+
+	for _, item := range record.Items {
+		itemAmount, _ := numericToString(item.Item.Amount)
+		consumers := make([]string, len(item.Consumers))
+		for i, consumer := range item.Consumers {
+			if consumer.PendingUserID.Valid {
+				consumers[i] = uuidToString(consumer.PendingUserID)
+			} else {
+				consumers[i] = uuidToString(consumer.UserID)
+			}
+		}
+		rows = append(rows, []string{
+			"item", uuidToString(item.Item.ID), expenseID, date, csvSafe(item.Item.Name), "", "",
+			itemAmount, e.CurrencyCode, exchangeRate, strings.Join(consumers, ";"), "", "",
+			"", "", item.Item.Kind, "", "", "",
+		})
+	}
+
+	for _, comment := range record.Comments {
+		rows = append(rows, []string{
+			"comment", uuidToString(comment.ID), expenseID, comment.CreatedAt.Time.Format("2006-01-02"), "", "", "",
+			"", "", "", uuidToString(comment.UserID), csvSafe(comment.UserEmail), csvSafe(comment.UserName.String),
+			"", "", "", "", "", csvSafe(comment.Comment),
+		})
+	}
+
+	if err := c.csv.WriteAll(rows); err != nil {
+		return err
+	}
+	flush(c.w)
+	return nil
+}
+
+func (c *csvExportWriter) WriteSettlement(s sqlc.ListSettlementsByGroupRow) error {
+	settlement := settlementRowToResponse(s)
+	date := s.CreatedAt.Time.Format("2006-01-02")
+	if s.PaidAt.Valid {
+		date = s.PaidAt.Time.Format("2006-01-02")
+	}
+
+	payerID := s.PayerID
+	if s.PayerPendingUserID.Valid {
+		payerID = s.PayerPendingUserID
+	}
+	payeeID := s.PayeeID
+	if s.PayeePendingUserID.Valid {
+		payeeID = s.PayeePendingUserID
+	}
+
+	if err := c.csv.Write([]string{
+		"settlement", uuidToString(s.ID), "", date, "", "", "",
+		settlement.Amount, s.CurrencyCode, "", uuidToString(payerID), csvSafe(s.PayerEmail), csvSafe(s.PayerName.String),
+		uuidToString(payeeID), csvSafe(s.PayeeEmail), "", s.Status, csvSafe(settlement.PaymentMethod), csvSafe(settlement.Notes),
+	}); err != nil {
+		return err
+	}
+	c.csv.Flush()
+	flush(c.w)
+	return c.csv.Error()
+}
+
+func (c *csvExportWriter) End() error {
+	c.csv.Flush()
+	flush(c.w)
+	return c.csv.Error()
+}
+
+// Helper functions
+
+// csvSafe stops spreadsheet apps from evaluating user-entered text as a formula.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func exportCategories(categories []sqlc.ExpenseCategory) []ExportCategoryResponse {
+	resp := make([]ExportCategoryResponse, len(categories))
+	for i, c := range categories {
+		resp[i] = ExportCategoryResponse{ID: c.ID, Slug: c.Slug, Name: c.Name}
+	}
+	return resp
+}
+
+func exportExpenseToResponse(record service.ExportedExpense) ExportExpenseResponse {
+	resp := ExportExpenseResponse{
+		ExpenseResponse: expenseToResponse(record.Expense),
+		Payments:        paymentsWithUserToResponse(record.Payments),
+		Splits:          splitsWithUserToResponse(record.Splits),
+		Receipt:         receiptToResponse(record.Items),
+		Comments:        commentRowsToResponse(record.Comments),
+	}
+	if record.Category != nil {
+		resp.Category = &ExportCategoryResponse{
+			ID:   record.Category.ID,
+			Slug: record.Category.Slug,
+			Name: record.Category.Name,
+		}
+	}
+	return resp
+}
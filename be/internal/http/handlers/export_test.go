@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockExportService for testing
+type MockExportService struct {
+	ExportGroupFunc func(ctx context.Context, filter service.ExportFilter, w service.ExportWriter) error
+}
+
+func (m *MockExportService) ExportGroup(ctx context.Context, filter service.ExportFilter, w service.ExportWriter) error {
+	if m.ExportGroupFunc != nil {
+		return m.ExportGroupFunc(ctx, filter, w)
+	}
+	return nil
+}
+
+func TestExportGroupHandler(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	groupID := testutil.CreateTestUUID(10)
+	expenseID := testutil.CreateTestUUID(20)
+
+	writeLedger := func(ctx context.Context, filter service.ExportFilter, w service.ExportWriter) error {
+		if err := w.Begin(sqlc.Group{ID: groupID, Name: "Trip"}, nil); err != nil {
+			return err
+		}
+		err := w.WriteExpense(service.ExportedExpense{
+			Expense: sqlc.Expense{
+				ID:           expenseID,
+				GroupID:      groupID,
+				Title:        "=HYPERLINK(\"http://evil\")",
+				Amount:       pgtype.Numeric{Int: big.NewInt(4250), Exp: -2, Valid: true},
+				CurrencyCode: "USD",
+				Date:         pgtype.Date{Time: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Valid: true},
+				Tags:         []string{"food", "dinner"},
+				CreatedBy:    userID,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return w.End()
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		exportFunc     func(ctx context.Context, filter service.ExportFilter, w service.ExportWriter) error
+		expectedStatus int
+		contentType    string
+	}{
+		{
+			name:           "json by default",
+			exportFunc:     writeLedger,
+			expectedStatus: http.StatusOK,
+			contentType:    "application/json",
+		},
+		{
+			name:           "csv",
+			query:          "?format=csv",
+			exportFunc:     writeLedger,
+			expectedStatus: http.StatusOK,
+			contentType:    "text/csv; charset=utf-8",
+		},
+		{
+			name:           "unknown format",
+			query:          "?format=xml",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid start_date",
+			query:          "?start_date=01-05-2026",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "not a member",
+			query: "?format=csv",
+			exportFunc: func(ctx context.Context, filter service.ExportFilter, w service.ExportWriter) error {
+				return service.ErrNotGroupMember
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := ExportGroupHandler(&MockExportService{ExportGroupFunc: tt.exportFunc})
+
+			req := createAuthenticatedRequest(http.MethodGet, "/groups/"+uuidToString(groupID)+"/export"+tt.query, nil, userID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("group_id", uuidToString(groupID))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			if got := rr.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("expected content type %q, got %q", tt.contentType, got)
+			}
+			if !strings.HasPrefix(rr.Header().Get("Content-Disposition"), "attachment;") {
+				t.Errorf("expected attachment disposition, got %q", rr.Header().Get("Content-Disposition"))
+			}
+
+			if tt.contentType == "application/json" {
+				var doc struct {
+					Expenses    []ExportExpenseResponse       `json:"expenses"`
+					Settlements []SettlementWithUsersResponse `json:"settlements"`
+				}
+				if err := json.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+					t.Fatalf("invalid JSON export: %v", err)
+				}
+				if len(doc.Expenses) != 1 || len(doc.Settlements) != 0 {
+					t.Errorf("expected 1 expense and 0 settlements, got %d and %d", len(doc.Expenses), len(doc.Settlements))
+				}
+				return
+			}
+
+			records, err := csv.NewReader(rr.Body).ReadAll()
+			if err != nil {
+				t.Fatalf("invalid CSV export: %v", err)
+			}
+			if len(records) != 2 {
+				t.Fatalf("expected header and 1 row, got %d records", len(records))
+			}
+			row := records[1]
+			if row[0] != "expense" || row[7] != "42.50" || row[6] != "food;dinner" {
+				t.Errorf("unexpected expense row: %v", row)
+			}
+			if !strings.HasPrefix(row[4], "'=") {
+				t.Errorf("expected formula in title to be escaped, got %q", row[4])
+			}
+		})
+	}
+}
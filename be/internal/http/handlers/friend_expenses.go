@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
@@ -41,37 +43,9 @@ func CreateFriendExpenseHandler(friendExpenseService service.FriendExpenseServic
 			return
 		}
 
-		// Convert payments
-		payments := make([]service.PaymentInput, len(req.Payments))
-		for i, p := range req.Payments {
-			paymentUserID, err := parseUUID(p.UserID)
-			if err != nil {
-				response.SendError(w, http.StatusBadRequest, "invalid payment user_id")
-				return
-			}
-			payments[i] = service.PaymentInput{
-				UserID:        paymentUserID,
-				Amount:        p.Amount,
-				PaymentMethod: p.PaymentMethod,
-			}
-		}
-
-		// Convert splits
-		splits := make([]service.SplitInput, len(req.Splits))
-		for i, s := range req.Splits {
-			splitUserID, err := parseUUID(s.UserID)
-			if err != nil {
-				response.SendError(w, http.StatusBadRequest, "invalid split user_id")
-				return
-			}
-			splits[i] = service.SplitInput{
-				UserID:        splitUserID,
-				PendingUserID: nil,
-				Type:          s.Type,
-				Percentage:    s.Percentage,
-				Shares:        s.Shares,
-				Amount:        s.Amount,
-			}
+		payments, splits, ok := parseFriendExpenseLines(w, req.Payments, req.Splits)
+		if !ok {
+			return
 		}
 
 		result, err := friendExpenseService.CreateFriendExpense(r.Context(), userID, friendID, service.CreateExpenseInput{
@@ -85,37 +59,171 @@ func CreateFriendExpenseHandler(friendExpenseService service.FriendExpenseServic
 			Splits:       splits,
 		})
 		if err != nil {
-			statusCode := http.StatusBadRequest
-			switch err {
-			case service.ErrInvalidAmount, service.ErrPaymentTotalMismatch, service.ErrSplitTotalMismatch:
-				statusCode = http.StatusUnprocessableEntity
-			case service.ErrFriendNotFound, service.ErrInvalidFriendAction:
-				statusCode = http.StatusForbidden
-			}
-			response.SendError(w, statusCode, err.Error())
+			response.SendError(w, friendExpenseErrorStatus(err), err.Error())
+			return
+		}
+
+		sendFriendExpenseResult(w, r, friendExpenseService, result.Expense, userID, friendID, http.StatusCreated)
+	}
+}
+
+func UpdateFriendExpenseHandler(friendExpenseService service.FriendExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := middleware.GetBody[UpdateExpenseRequest](r)
+		if !ok {
+			response.SendError(w, http.StatusInternalServerError, "invalid request context")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		friendID, err := parseUUID(chi.URLParam(r, "friend_id"))
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid friend_id")
+			return
+		}
+
+		expenseID, err := parseUUID(chi.URLParam(r, "expense_id"))
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid expense_id")
+			return
+		}
+
+		// Parse date
+		date, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid date format, expected YYYY-MM-DD")
 			return
 		}
 
-		// Load payments and splits with user info
-		paymentRows, err := friendExpenseService.GetFriendExpensePayments(r.Context(), result.Expense.ID, userID, friendID)
+		payments, splits, ok := parseFriendExpenseLines(w, req.Payments, req.Splits)
+		if !ok {
+			return
+		}
+
+		result, err := friendExpenseService.UpdateFriendExpense(r.Context(), expenseID, userID, friendID, service.UpdateExpenseInput{
+			ExpenseID:    expenseID,
+			Title:        req.Title,
+			Notes:        req.Notes,
+			Amount:       req.Amount,
+			CurrencyCode: req.CurrencyCode,
+			Date:         date,
+			Tags:         req.Tags,
+			UpdatedBy:    userID,
+			Payments:     payments,
+			Splits:       splits,
+		})
 		if err != nil {
-			response.SendError(w, http.StatusBadRequest, err.Error())
+			response.SendError(w, friendExpenseErrorStatus(err), err.Error())
 			return
 		}
-		splitRows, err := friendExpenseService.GetFriendExpenseSplits(r.Context(), result.Expense.ID, userID, friendID)
+
+		sendFriendExpenseResult(w, r, friendExpenseService, result.Expense, userID, friendID, http.StatusOK)
+	}
+}
+
+func DeleteFriendExpenseHandler(friendExpenseService service.FriendExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		friendID, err := parseUUID(chi.URLParam(r, "friend_id"))
 		if err != nil {
-			response.SendError(w, http.StatusBadRequest, err.Error())
+			response.SendError(w, http.StatusBadRequest, "invalid friend_id")
 			return
 		}
 
-		resp := CreateExpenseResponse{
-			Expense:  expenseToResponse(result.Expense),
-			Payments: paymentsWithUserToResponse(paymentRows),
-			Splits:   splitsWithUserToResponse(splitRows),
+		expenseID, err := parseUUID(chi.URLParam(r, "expense_id"))
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid expense_id")
+			return
+		}
+
+		if err := friendExpenseService.DeleteFriendExpense(r.Context(), expenseID, userID, friendID); err != nil {
+			response.SendError(w, friendExpenseErrorStatus(err), err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// parseFriendExpenseLines converts request payments and splits. Friend expenses
+// only involve registered users, so pending user ids are not accepted.
+func parseFriendExpenseLines(w http.ResponseWriter, reqPayments []PaymentRequest, reqSplits []SplitRequest) ([]service.PaymentInput, []service.SplitInput, bool) {
+	payments := make([]service.PaymentInput, len(reqPayments))
+	for i, p := range reqPayments {
+		paymentUserID, err := parseUUID(p.UserID)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid payment user_id")
+			return nil, nil, false
+		}
+		payments[i] = service.PaymentInput{
+			UserID:        paymentUserID,
+			Amount:        p.Amount,
+			PaymentMethod: p.PaymentMethod,
+		}
+	}
+
+	splits := make([]service.SplitInput, len(reqSplits))
+	for i, s := range reqSplits {
+		splitUserID, err := parseUUID(s.UserID)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid split user_id")
+			return nil, nil, false
 		}
+		splits[i] = service.SplitInput{
+			UserID:        splitUserID,
+			PendingUserID: nil,
+			Type:          s.Type,
+			Percentage:    s.Percentage,
+			Shares:        s.Shares,
+			Amount:        s.Amount,
+		}
+	}
+
+	return payments, splits, true
+}
+
+// sendFriendExpenseResult responds with the expense and its payments and splits with user info.
+func sendFriendExpenseResult(w http.ResponseWriter, r *http.Request, friendExpenseService service.FriendExpenseService, expense sqlc.Expense, userID, friendID pgtype.UUID, statusCode int) {
+	paymentRows, err := friendExpenseService.GetFriendExpensePayments(r.Context(), expense.ID, userID, friendID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	splitRows, err := friendExpenseService.GetFriendExpenseSplits(r.Context(), expense.ID, userID, friendID)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.SendSuccess(w, statusCode, CreateExpenseResponse{
+		Expense:  expenseToResponse(expense),
+		Payments: paymentsWithUserToResponse(paymentRows),
+		Splits:   splitsWithUserToResponse(splitRows),
+	})
+}
 
-		response.SendSuccess(w, http.StatusCreated, resp)
+func friendExpenseErrorStatus(err error) int {
+	switch err {
+	case service.ErrExpenseNotFound:
+		return http.StatusNotFound
+	case service.ErrInvalidAmount, service.ErrPaymentTotalMismatch, service.ErrSplitTotalMismatch,
+		service.ErrPercentageTotalMismatch, service.ErrAmountRequired, service.ErrPercentageRequired,
+		service.ErrSharesRequired, service.ErrMixedSplitTypes, service.ErrInvalidSplitType:
+		return http.StatusUnprocessableEntity
+	case service.ErrFriendNotFound, service.ErrInvalidFriendAction:
+		return http.StatusForbidden
 	}
+	return http.StatusBadRequest
 }
 
 func ListFriendExpensesHandler(friendExpenseService service.FriendExpenseService) http.HandlerFunc {
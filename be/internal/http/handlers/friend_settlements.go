@@ -23,6 +23,16 @@ type CreateFriendSettlementRequest struct {
 	Notes                string `json:"notes" validate:"max=500"`
 }
 
+// UpdateFriendSettlementRequest mirrors UpdateSettlementRequest; an empty status keeps the current one.
+type UpdateFriendSettlementRequest struct {
+	Amount               string `json:"amount" validate:"required"`
+	CurrencyCode         string `json:"currency_code" validate:"omitempty,len=3"`
+	Status               string `json:"status" validate:"omitempty,oneof=pending completed cancelled"`
+	PaymentMethod        string `json:"payment_method" validate:"max=50"`
+	TransactionReference string `json:"transaction_reference" validate:"max=100"`
+	Notes                string `json:"notes" validate:"max=500"`
+}
+
 func CreateFriendSettlementHandler(friendSettlementService service.FriendSettlementService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		requesterID, ok := middleware.GetUserID(r)
@@ -72,7 +82,7 @@ func CreateFriendSettlementHandler(friendSettlementService service.FriendSettlem
 		if err != nil {
 			var statusCode int
 			switch err {
-			case service.ErrFriendNotFound:
+			case service.ErrFriendNotFound, service.ErrSettlementForbidden:
 				statusCode = http.StatusForbidden
 			case service.ErrInvalidAmount, service.ErrInvalidStatus:
 				statusCode = http.StatusBadRequest
@@ -88,6 +98,95 @@ func CreateFriendSettlementHandler(friendSettlementService service.FriendSettlem
 	}
 }
 
+func UpdateFriendSettlementHandler(friendSettlementService service.FriendSettlementService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requesterID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		req, ok := middleware.GetBody[UpdateFriendSettlementRequest](r)
+		if !ok {
+			response.SendError(w, http.StatusInternalServerError, "invalid request context")
+			return
+		}
+
+		friendID, settlementID, ok := parseFriendSettlementParams(w, r)
+		if !ok {
+			return
+		}
+
+		settlement, err := friendSettlementService.UpdateFriendSettlement(r.Context(), settlementID, requesterID, friendID, service.UpdateFriendSettlementInput{
+			Amount:               req.Amount,
+			CurrencyCode:         req.CurrencyCode,
+			Status:               req.Status,
+			PaymentMethod:        req.PaymentMethod,
+			TransactionReference: req.TransactionReference,
+			Notes:                req.Notes,
+		})
+		if err != nil {
+			response.SendError(w, friendSettlementErrorStatus(err), err.Error())
+			return
+		}
+
+		resp := settlementToResponse(settlement)
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+func DeleteFriendSettlementHandler(friendSettlementService service.FriendSettlementService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requesterID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		friendID, settlementID, ok := parseFriendSettlementParams(w, r)
+		if !ok {
+			return
+		}
+
+		if err := friendSettlementService.DeleteFriendSettlement(r.Context(), settlementID, requesterID, friendID); err != nil {
+			response.SendError(w, friendSettlementErrorStatus(err), err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func parseFriendSettlementParams(w http.ResponseWriter, r *http.Request) (pgtype.UUID, pgtype.UUID, bool) {
+	friendID, err := parseUUID(chi.URLParam(r, "friend_id"))
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, "invalid friend_id")
+		return pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	var settlementID pgtype.UUID
+	if err := settlementID.Scan(chi.URLParam(r, "settlement_id")); err != nil {
+		response.SendError(w, http.StatusBadRequest, "invalid settlement id")
+		return pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	return friendID, settlementID, true
+}
+
+func friendSettlementErrorStatus(err error) int {
+	switch err {
+	case service.ErrSettlementNotFound:
+		return http.StatusNotFound
+	case service.ErrFriendNotFound, service.ErrInvalidFriendAction, service.ErrSettlementForbidden:
+		return http.StatusForbidden
+	case service.ErrInvalidAmount, service.ErrInvalidStatus:
+		return http.StatusBadRequest
+	case service.ErrInvalidStatusTransition:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
 func ListFriendSettlementsHandler(friendSettlementService service.FriendSettlementService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		requesterID, ok := middleware.GetUserID(r)
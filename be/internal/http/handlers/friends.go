@@ -21,6 +21,9 @@ type FriendSummaryResponse struct {
 	FriendEmail string      `json:"friend_email"`
 	FriendName  string      `json:"friend_name,omitempty"`
 	AvatarURL   string      `json:"avatar_url,omitempty"`
+	// Balances is the net balance with the friend per currency; positive means
+	// the friend owes you
+	Balances []service.CurrencyAmount `json:"balances"`
 }
 
 type FriendRequestResponse struct {
@@ -74,7 +77,7 @@ func SendFriendRequestHandler(friendService service.FriendService) http.HandlerF
 	}
 }
 
-func ListFriendsHandler(friendService service.FriendService) http.HandlerFunc {
+func ListFriendsHandler(friendService service.FriendService, balanceService service.BalanceService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID, ok := middleware.GetUserID(r)
 		if !ok {
@@ -88,14 +91,35 @@ func ListFriendsHandler(friendService service.FriendService) http.HandlerFunc {
 			return
 		}
 
+		opts, err := parseBalanceOptions(r)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid confirmed_only")
+			return
+		}
+
+		balances, err := balanceService.ListFriendBalances(r.Context(), userID, opts)
+		if err != nil {
+			response.SendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		balancesByFriend := make(map[pgtype.UUID][]service.CurrencyAmount, len(balances))
+		for _, b := range balances {
+			balancesByFriend[b.FriendID] = b.Balances
+		}
+
 		resp := make([]FriendSummaryResponse, len(friends))
 		for i, f := range friends {
+			friendBalances, ok := balancesByFriend[f.FriendID]
+			if !ok {
+				friendBalances = []service.CurrencyAmount{}
+			}
 			resp[i] = FriendSummaryResponse{
 				ID:          f.ID,
 				FriendID:    f.FriendID,
 				FriendEmail: f.FriendEmail,
 				FriendName:  f.FriendName,
 				AvatarURL:   f.AvatarURL,
+				Balances:    friendBalances,
 			}
 		}
 
@@ -103,6 +127,40 @@ func ListFriendsHandler(friendService service.FriendService) http.HandlerFunc {
 	}
 }
 
+func GetFriendBalanceHandler(balanceService service.BalanceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		friendID, err := parseUUID(chi.URLParam(r, "friend_id"))
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid friend_id")
+			return
+		}
+
+		opts, err := parseBalanceOptions(r)
+		if err != nil {
+			response.SendError(w, http.StatusBadRequest, "invalid confirmed_only")
+			return
+		}
+
+		balance, err := balanceService.GetFriendBalance(r.Context(), userID, friendID, opts)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if err == service.ErrFriendNotFound {
+				status = http.StatusNotFound
+			}
+			response.SendError(w, status, err.Error())
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, balance)
+	}
+}
+
 func ListIncomingFriendRequestsHandler(friendService service.FriendService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID, ok := middleware.GetUserID(r)
@@ -79,6 +79,43 @@ func (m *MockFriendService) RemoveFriend(ctx context.Context, requesterID, frien
 
 var _ service.FriendService = (*MockFriendService)(nil)
 
+type MockBalanceService struct {
+	GetFriendBalanceFunc   func(ctx context.Context, userID, friendID pgtype.UUID, opts service.BalanceOptions) (service.FriendBalanceResponse, error)
+	ListFriendBalancesFunc func(ctx context.Context, userID pgtype.UUID, opts service.BalanceOptions) ([]service.FriendBalanceResponse, error)
+}
+
+func (m *MockBalanceService) GetGroupBalances(ctx context.Context, groupID, requesterID pgtype.UUID, opts service.BalanceOptions) ([]service.BalanceResponse, error) {
+	return nil, nil
+}
+
+func (m *MockBalanceService) GetUserBalanceInGroup(ctx context.Context, groupID, userID, requesterID pgtype.UUID, opts service.BalanceOptions) (service.BalanceResponse, error) {
+	return service.BalanceResponse{}, nil
+}
+
+func (m *MockBalanceService) GetOverallUserBalance(ctx context.Context, userID pgtype.UUID, opts service.BalanceOptions) ([]service.GroupBalanceResponse, error) {
+	return nil, nil
+}
+
+func (m *MockBalanceService) GetSimplifiedDebts(ctx context.Context, groupID, requesterID pgtype.UUID, opts service.BalanceOptions) ([]service.DebtResponse, error) {
+	return nil, nil
+}
+
+func (m *MockBalanceService) GetFriendBalance(ctx context.Context, userID, friendID pgtype.UUID, opts service.BalanceOptions) (service.FriendBalanceResponse, error) {
+	if m.GetFriendBalanceFunc != nil {
+		return m.GetFriendBalanceFunc(ctx, userID, friendID, opts)
+	}
+	return service.FriendBalanceResponse{FriendID: friendID}, nil
+}
+
+func (m *MockBalanceService) ListFriendBalances(ctx context.Context, userID pgtype.UUID, opts service.BalanceOptions) ([]service.FriendBalanceResponse, error) {
+	if m.ListFriendBalancesFunc != nil {
+		return m.ListFriendBalancesFunc(ctx, userID, opts)
+	}
+	return nil, nil
+}
+
+var _ service.BalanceService = (*MockBalanceService)(nil)
+
 func createFriendRequest(method, path string, body interface{}, userID pgtype.UUID) *http.Request {
 	var req *http.Request
 	if body != nil {
@@ -175,15 +212,88 @@ func TestListFriendsHandler_Success(t *testing.T) {
 		},
 	}
 
+	balances := &MockBalanceService{
+		ListFriendBalancesFunc: func(ctx context.Context, uid pgtype.UUID, opts service.BalanceOptions) ([]service.FriendBalanceResponse, error) {
+			return []service.FriendBalanceResponse{
+				{
+					FriendID: friendID,
+					Balances: []service.CurrencyAmount{{CurrencyCode: "EUR", Amount: "-4.5"}, {CurrencyCode: "USD", Amount: "12"}},
+				},
+				{
+					FriendID: testutil.CreateTestUUID(3),
+					Balances: []service.CurrencyAmount{{CurrencyCode: "USD", Amount: "7"}},
+				},
+			}, nil
+		},
+	}
+
 	req := createFriendRequest("GET", "/friends", nil, userID)
 	rr := httptest.NewRecorder()
 
-	handler := ListFriendsHandler(mock)
+	handler := ListFriendsHandler(mock, balances)
 	handler.ServeHTTP(rr, req)
 
 	if rr.Code != http.StatusOK {
 		t.Fatalf("expected status 200, got %d", rr.Code)
 	}
+
+	var body struct {
+		Data []FriendSummaryResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(body.Data) != 1 {
+		t.Fatalf("expected 1 friend, got %d", len(body.Data))
+	}
+	if got := body.Data[0].Balances; len(got) != 2 || got[0].Amount != "-4.5" || got[1].Amount != "12" {
+		t.Errorf("unexpected balances: %+v", got)
+	}
+}
+
+func TestGetFriendBalanceHandler(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	friendID := testutil.CreateTestUUID(2)
+
+	tests := []struct {
+		name           string
+		friendParam    string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success", friendParam: friendID.String(), expectedStatus: http.StatusOK},
+		{name: "invalid friend id", friendParam: "not-a-uuid", expectedStatus: http.StatusBadRequest},
+		{name: "not a friend", friendParam: friendID.String(), err: service.ErrFriendNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockBalanceService{
+				GetFriendBalanceFunc: func(ctx context.Context, uid, fid pgtype.UUID, opts service.BalanceOptions) (service.FriendBalanceResponse, error) {
+					if tt.err != nil {
+						return service.FriendBalanceResponse{}, tt.err
+					}
+					return service.FriendBalanceResponse{
+						FriendID: fid,
+						Balances: []service.CurrencyAmount{{CurrencyCode: "USD", Amount: "10"}},
+						Sources:  []service.FriendBalanceSource{{Type: service.FriendBalanceSourceDirect, CurrencyCode: "USD", Amount: "10"}},
+					}, nil
+				},
+			}
+
+			req := createFriendRequest("GET", "/friends/"+tt.friendParam+"/balance", nil, userID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("friend_id", tt.friendParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			GetFriendBalanceHandler(mock).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
 }
 
 func TestAcceptFriendRequestHandler_ErrorMapping(t *testing.T) {
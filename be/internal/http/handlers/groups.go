@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
@@ -19,6 +23,22 @@ type CreateGroupRequest struct {
 	CurrencyCode string `json:"currency_code" validate:"omitempty,len=3"`
 }
 
+type UpdateGroupRequest struct {
+	Name               *string         `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Description        *string         `json:"description,omitempty" validate:"omitempty,max=500"`
+	CurrencyCode       *string         `json:"currency_code,omitempty" validate:"omitempty,len=3"`
+	DefaultSplitMethod *string         `json:"default_split_method,omitempty" validate:"omitempty,oneof=equal percentage shares fixed"`
+	Settings           json.RawMessage `json:"settings,omitempty"`
+}
+
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin member"`
+}
+
+type TransferOwnershipRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+}
+
 // Response structs
 
 type CreateGroupResponse struct {
@@ -30,6 +50,18 @@ type CreateGroupResponse struct {
 	Role         string      `json:"role"`
 }
 
+type GroupResponse struct {
+	ID                 pgtype.UUID     `json:"id"`
+	Name               string          `json:"name"`
+	Description        string          `json:"description,omitempty"`
+	CurrencyCode       string          `json:"currency_code"`
+	DefaultSplitMethod string          `json:"default_split_method"`
+	Settings           json.RawMessage `json:"settings"`
+	ArchivedAt         string          `json:"archived_at,omitempty"`
+	CreatedAt          string          `json:"created_at"`
+	UpdatedAt          string          `json:"updated_at"`
+}
+
 type GroupMemberResponse struct {
 	ID        pgtype.UUID `json:"id"`
 	GroupID   pgtype.UUID `json:"group_id"`
@@ -64,6 +96,7 @@ type UserGroupResponse struct {
 	Description    string      `json:"description,omitempty"`
 	CurrencyCode   string      `json:"currency_code"`
 	CreatedAt      string      `json:"created_at"`
+	ArchivedAt     string      `json:"archived_at,omitempty"`
 	MembershipID   pgtype.UUID `json:"membership_id"`
 	MemberRole     string      `json:"member_role"`
 	MemberStatus   string      `json:"member_status"`
@@ -213,6 +246,7 @@ func ListUserGroupsHandler(groupService service.GroupService) http.HandlerFunc {
 				Description:    g.Description.String,
 				CurrencyCode:   g.CurrencyCode,
 				CreatedAt:      formatTimestamp(g.CreatedAt),
+				ArchivedAt:     formatTimestamp(g.ArchivedAt),
 				MembershipID:   g.MembershipID,
 				MemberRole:     g.MemberRole,
 				MemberStatus:   g.MemberStatus,
@@ -224,6 +258,383 @@ func ListUserGroupsHandler(groupService service.GroupService) http.HandlerFunc {
 	}
 }
 
+func UpdateGroupHandler(groupService service.GroupService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := middleware.GetBody[UpdateGroupRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		group, err := groupService.UpdateGroup(r.Context(), service.UpdateGroupInput{
+			GroupID:            groupID,
+			Name:               req.Name,
+			Description:        req.Description,
+			CurrencyCode:       req.CurrencyCode,
+			DefaultSplitMethod: req.DefaultSplitMethod,
+			Settings:           req.Settings,
+			UpdatedBy:          userID,
+		})
+		if err != nil {
+			sendGroupLifecycleError(w, err, "system.group.update_failed", "Unable to update group.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, groupToResponse(group))
+	}
+}
+
+func ArchiveGroupHandler(groupService service.GroupService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		group, err := groupService.ArchiveGroup(r.Context(), groupID, userID)
+		if err != nil {
+			sendGroupLifecycleError(w, err, "system.group.archive_failed", "Unable to archive group.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, groupToResponse(group))
+	}
+}
+
+func RestoreGroupHandler(groupService service.GroupService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		group, err := groupService.RestoreGroup(r.Context(), groupID, userID)
+		if err != nil {
+			sendGroupLifecycleError(w, err, "system.group.restore_failed", "Unable to restore group.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, groupToResponse(group))
+	}
+}
+
+func DeleteGroupHandler(groupService service.GroupService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		force, ok := parseForceParam(w, r)
+		if !ok {
+			return
+		}
+
+		if err := groupService.DeleteGroup(r.Context(), groupID, userID, force); err != nil {
+			sendGroupLifecycleError(w, err, "system.group.delete_failed", "Unable to delete group.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func UpdateMemberRoleHandler(groupService service.GroupService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := middleware.GetBody[UpdateMemberRoleRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		memberUserID, err := parseUUID(chi.URLParam(r, "user_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.member.user_id.invalid", "Invalid user id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		member, err := groupService.UpdateMemberRole(r.Context(), service.UpdateMemberRoleInput{
+			GroupID:     groupID,
+			UserID:      memberUserID,
+			Role:        req.Role,
+			RequesterID: userID,
+		})
+		if err != nil {
+			sendGroupLifecycleError(w, err, "system.member.role_update_failed", "Unable to update member role.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, GroupMemberResponse{
+			ID:        member.ID,
+			GroupID:   member.GroupID,
+			UserID:    member.UserID,
+			Role:      member.Role,
+			Status:    member.Status,
+			InvitedAt: formatTimestamp(member.InvitedAt),
+			JoinedAt:  formatTimestamp(member.JoinedAt),
+		})
+	}
+}
+
+func TransferOwnershipHandler(groupService service.GroupService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := middleware.GetBody[TransferOwnershipRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		newOwnerID, err := parseUUID(req.UserID)
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.member.user_id.invalid", "Invalid user id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		if err := groupService.TransferOwnership(r.Context(), groupID, newOwnerID, userID); err != nil {
+			sendGroupLifecycleError(w, err, "system.member.transfer_failed", "Unable to transfer ownership.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func RemoveMemberHandler(groupService service.GroupService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		memberUserID, err := parseUUID(chi.URLParam(r, "user_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.member.user_id.invalid", "Invalid user id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		force, ok := parseForceParam(w, r)
+		if !ok {
+			return
+		}
+
+		if err := groupService.RemoveMember(r.Context(), groupID, memberUserID, userID, force); err != nil {
+			sendGroupLifecycleError(w, err, "system.member.remove_failed", "Unable to remove member.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func LeaveGroupHandler(groupService service.GroupService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		force, ok := parseForceParam(w, r)
+		if !ok {
+			return
+		}
+
+		if err := groupService.LeaveGroup(r.Context(), groupID, userID, force); err != nil {
+			sendGroupLifecycleError(w, err, "system.member.leave_failed", "Unable to leave group.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// parseForceParam reads the optional ?force= flag used to override balance checks.
+func parseForceParam(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	raw := r.URL.Query().Get("force")
+	if raw == "" {
+		return false, true
+	}
+	force, err := strconv.ParseBool(raw)
+	if err != nil {
+		response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.force.invalid", "Invalid force flag.")
+		return false, false
+	}
+	return force, true
+}
+
+// sendGroupLifecycleError maps errors shared by the group lifecycle and member management handlers.
+func sendGroupLifecycleError(w http.ResponseWriter, err error, code, message string) {
+	var balanceErr *service.MemberBalanceError
+	if errors.As(err, &balanceErr) {
+		response.SendErrorWithCodeAndDetails(w, http.StatusConflict, "conflict.member.outstanding_balance",
+			"Member has an outstanding balance in this group. Settle up or retry with force=true.",
+			map[string]string{"balance": balanceErr.Balance})
+		return
+	}
+
+	statusCode := http.StatusBadRequest
+	switch err {
+	case service.ErrGroupNotFound:
+		statusCode = http.StatusNotFound
+		code = "resource.group.not_found"
+		message = "Group not found."
+	case service.ErrNotGroupMember:
+		statusCode = http.StatusForbidden
+		code = "permission.group.member_required"
+		message = "You are not a member of this group."
+	case service.ErrInsufficientPermissions:
+		statusCode = http.StatusForbidden
+		code = "permission.group.role_required"
+		message = "You do not have permission to manage this group."
+	case service.ErrGroupArchived:
+		statusCode = http.StatusConflict
+		code = "conflict.group.archived"
+		message = "Group is archived."
+	case service.ErrGroupNotArchived:
+		statusCode = http.StatusConflict
+		code = "conflict.group.not_archived"
+		message = "Group is not archived."
+	case service.ErrGroupHasBalances:
+		statusCode = http.StatusConflict
+		code = "conflict.group.outstanding_balances"
+		message = "Group has outstanding balances. Settle up or delete with force=true."
+	case service.ErrGroupCurrencyLocked:
+		statusCode = http.StatusConflict
+		code = "conflict.group.currency_locked"
+		message = "Group currency cannot be changed once expenses exist."
+	case service.ErrInvalidGroupName:
+		statusCode = http.StatusUnprocessableEntity
+		code = "validation.group.name.invalid"
+		message = "Group name is required."
+	case service.ErrInvalidCurrencyCode:
+		statusCode = http.StatusUnprocessableEntity
+		code = "validation.group.currency_code.invalid"
+		message = "Currency code must be a 3-letter ISO 4217 code."
+	case service.ErrInvalidSplitMethod:
+		statusCode = http.StatusUnprocessableEntity
+		code = "validation.group.default_split_method.invalid"
+		message = "Invalid default split method."
+	case service.ErrInvalidGroupSettings:
+		statusCode = http.StatusUnprocessableEntity
+		code = "validation.group.settings.invalid"
+		message = "Group settings must be a JSON object."
+	case service.ErrInvalidReminderSettings:
+		statusCode = http.StatusUnprocessableEntity
+		code = "validation.group.settings.reminders_invalid"
+		message = "Reminder settings are invalid."
+	case service.ErrMemberNotFound:
+		statusCode = http.StatusNotFound
+		code = "resource.member.not_found"
+		message = "Member not found in this group."
+	case service.ErrInvalidMemberRole:
+		statusCode = http.StatusUnprocessableEntity
+		code = "validation.member.role.invalid"
+		message = "Role must be admin or member."
+	case service.ErrCannotModifyOwner:
+		statusCode = http.StatusConflict
+		code = "conflict.member.owner"
+		message = "The group owner cannot be changed this way. Transfer ownership instead."
+	case service.ErrCannotModifySelf:
+		statusCode = http.StatusConflict
+		code = "conflict.member.self"
+		message = "You cannot change your own membership this way."
+	case service.ErrOwnerCannotLeave:
+		statusCode = http.StatusConflict
+		code = "conflict.member.owner_cannot_leave"
+		message = "Transfer ownership before leaving the group."
+	}
+	response.SendErrorWithCode(w, statusCode, code, message)
+}
+
+func groupToResponse(group sqlc.Group) GroupResponse {
+	settings := json.RawMessage(group.Settings)
+	if len(settings) == 0 {
+		settings = json.RawMessage("{}")
+	}
+
+	return GroupResponse{
+		ID:                 group.ID,
+		Name:               group.Name,
+		Description:        group.Description.String,
+		CurrencyCode:       group.CurrencyCode,
+		DefaultSplitMethod: group.DefaultSplitMethod,
+		Settings:           settings,
+		ArchivedAt:         formatTimestamp(group.ArchivedAt),
+		CreatedAt:          formatTimestamp(group.CreatedAt),
+		UpdatedAt:          formatTimestamp(group.UpdatedAt),
+	}
+}
+
 // Helper to convert UUID to string
 func uuidToString(uuid pgtype.UUID) string {
 	if !uuid.Valid {
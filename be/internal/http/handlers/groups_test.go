@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,10 +24,18 @@ import (
 
 // MockGroupService is a mock implementation of GroupService for testing
 type MockGroupService struct {
-	CreateGroupFunc      func(ctx context.Context, input service.CreateGroupInput) (service.CreateGroupResult, error)
-
-	ListGroupMembersFunc func(ctx context.Context, groupID, requesterID pgtype.UUID) ([]service.GroupMemberDetail, error)
-	ListUserGroupsFunc   func(ctx context.Context, userID pgtype.UUID) ([]sqlc.GetGroupsByUserIDRow, error)
+	CreateGroupFunc func(ctx context.Context, input service.CreateGroupInput) (service.CreateGroupResult, error)
+
+	ListGroupMembersFunc  func(ctx context.Context, groupID, requesterID pgtype.UUID) ([]service.GroupMemberDetail, error)
+	ListUserGroupsFunc    func(ctx context.Context, userID pgtype.UUID) ([]sqlc.GetGroupsByUserIDRow, error)
+	UpdateGroupFunc       func(ctx context.Context, input service.UpdateGroupInput) (sqlc.Group, error)
+	ArchiveGroupFunc      func(ctx context.Context, groupID, requesterID pgtype.UUID) (sqlc.Group, error)
+	RestoreGroupFunc      func(ctx context.Context, groupID, requesterID pgtype.UUID) (sqlc.Group, error)
+	DeleteGroupFunc       func(ctx context.Context, groupID, requesterID pgtype.UUID, force bool) error
+	UpdateMemberRoleFunc  func(ctx context.Context, input service.UpdateMemberRoleInput) (sqlc.GroupMember, error)
+	TransferOwnershipFunc func(ctx context.Context, groupID, newOwnerID, requesterID pgtype.UUID) error
+	RemoveMemberFunc      func(ctx context.Context, groupID, userID, requesterID pgtype.UUID, force bool) error
+	LeaveGroupFunc        func(ctx context.Context, groupID, userID pgtype.UUID, force bool) error
 }
 
 func (m *MockGroupService) CreateGroup(ctx context.Context, input service.CreateGroupInput) (service.CreateGroupResult, error) {
@@ -36,8 +45,6 @@ func (m *MockGroupService) CreateGroup(ctx context.Context, input service.Create
 	return service.CreateGroupResult{}, nil
 }
 
-
-
 func (m *MockGroupService) ListGroupMembers(ctx context.Context, groupID, requesterID pgtype.UUID) ([]service.GroupMemberDetail, error) {
 	if m.ListGroupMembersFunc != nil {
 		return m.ListGroupMembersFunc(ctx, groupID, requesterID)
@@ -52,6 +59,62 @@ func (m *MockGroupService) ListUserGroups(ctx context.Context, userID pgtype.UUI
 	return []sqlc.GetGroupsByUserIDRow{}, nil
 }
 
+func (m *MockGroupService) UpdateGroup(ctx context.Context, input service.UpdateGroupInput) (sqlc.Group, error) {
+	if m.UpdateGroupFunc != nil {
+		return m.UpdateGroupFunc(ctx, input)
+	}
+	return sqlc.Group{}, nil
+}
+
+func (m *MockGroupService) ArchiveGroup(ctx context.Context, groupID, requesterID pgtype.UUID) (sqlc.Group, error) {
+	if m.ArchiveGroupFunc != nil {
+		return m.ArchiveGroupFunc(ctx, groupID, requesterID)
+	}
+	return sqlc.Group{}, nil
+}
+
+func (m *MockGroupService) RestoreGroup(ctx context.Context, groupID, requesterID pgtype.UUID) (sqlc.Group, error) {
+	if m.RestoreGroupFunc != nil {
+		return m.RestoreGroupFunc(ctx, groupID, requesterID)
+	}
+	return sqlc.Group{}, nil
+}
+
+func (m *MockGroupService) DeleteGroup(ctx context.Context, groupID, requesterID pgtype.UUID, force bool) error {
+	if m.DeleteGroupFunc != nil {
+		return m.DeleteGroupFunc(ctx, groupID, requesterID, force)
+	}
+	return nil
+}
+
+func (m *MockGroupService) UpdateMemberRole(ctx context.Context, input service.UpdateMemberRoleInput) (sqlc.GroupMember, error) {
+	if m.UpdateMemberRoleFunc != nil {
+		return m.UpdateMemberRoleFunc(ctx, input)
+	}
+	return sqlc.GroupMember{}, nil
+}
+
+func (m *MockGroupService) TransferOwnership(ctx context.Context, groupID, newOwnerID, requesterID pgtype.UUID) error {
+	if m.TransferOwnershipFunc != nil {
+		return m.TransferOwnershipFunc(ctx, groupID, newOwnerID, requesterID)
+	}
+	return nil
+}
+
+func (m *MockGroupService) RemoveMember(ctx context.Context, groupID, userID, requesterID pgtype.UUID, force bool) error {
+	if m.RemoveMemberFunc != nil {
+		return m.RemoveMemberFunc(ctx, groupID, userID, requesterID, force)
+	}
+	return nil
+}
+
+func (m *MockGroupService) LeaveGroup(ctx context.Context, groupID, userID pgtype.UUID, force bool) error {
+	if m.LeaveGroupFunc != nil {
+		return m.LeaveGroupFunc(ctx, groupID, userID, force)
+	}
+	return nil
+}
+
 var _ service.GroupService = (*MockGroupService)(nil)
 
 // Helper to create request with auth context
@@ -171,8 +234,6 @@ func TestCreateGroupHandler(t *testing.T) {
 	}
 }
 
-
-
 func TestListGroupMembersHandler(t *testing.T) {
 	groupID := testutil.CreateTestUUID(10)
 	userID := testutil.CreateTestUUID(1)
@@ -421,8 +482,304 @@ func TestListUserGroupsHandler(t *testing.T) {
 
 // Helper to convert UUID to string
 
-
 func parseTestTime(timeStr string) time.Time {
 	t, _ := time.Parse(time.RFC3339, timeStr)
 	return t
 }
+
+func TestUpdateGroupHandler(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	groupID := testutil.CreateTestUUID(10)
+	newName := "Renamed Group"
+	newCurrency := "EUR"
+	badSplitMethod := "random"
+
+	tests := []struct {
+		name           string
+		requestBody    UpdateGroupRequest
+		mockSetup      func(*MockGroupService)
+		expectedStatus int
+	}{
+		{
+			name:        "successful update",
+			requestBody: UpdateGroupRequest{Name: &newName},
+			mockSetup: func(mock *MockGroupService) {
+				mock.UpdateGroupFunc = func(ctx context.Context, input service.UpdateGroupInput) (sqlc.Group, error) {
+					if input.Name == nil || *input.Name != newName {
+						t.Errorf("expected name to be forwarded")
+					}
+					return testutil.CreateTestGroup(groupID, *input.Name, userID), nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "insufficient permissions",
+			requestBody: UpdateGroupRequest{Name: &newName},
+			mockSetup: func(mock *MockGroupService) {
+				mock.UpdateGroupFunc = func(ctx context.Context, input service.UpdateGroupInput) (sqlc.Group, error) {
+					return sqlc.Group{}, service.ErrInsufficientPermissions
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:        "currency locked",
+			requestBody: UpdateGroupRequest{CurrencyCode: &newCurrency},
+			mockSetup: func(mock *MockGroupService) {
+				mock.UpdateGroupFunc = func(ctx context.Context, input service.UpdateGroupInput) (sqlc.Group, error) {
+					return sqlc.Group{}, service.ErrGroupCurrencyLocked
+				}
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "invalid split method",
+			requestBody:    UpdateGroupRequest{DefaultSplitMethod: &badSplitMethod},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockGroupService{}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockService)
+			}
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := createAuthenticatedRequest(http.MethodPatch, "/groups/"+uuidToString(groupID), body, userID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("group_id", uuidToString(groupID))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			handler := middleware.ValidateBody[UpdateGroupRequest](validator.New())(UpdateGroupHandler(mockService))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeleteGroupHandler(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	groupID := testutil.CreateTestUUID(10)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockGroupService)
+		expectedStatus int
+	}{
+		{
+			name: "successful delete",
+			mockSetup: func(mock *MockGroupService) {
+				mock.DeleteGroupFunc = func(ctx context.Context, gID, requesterID pgtype.UUID, force bool) error {
+					if force {
+						t.Errorf("expected force to be false")
+					}
+					return nil
+				}
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "outstanding balances",
+			mockSetup: func(mock *MockGroupService) {
+				mock.DeleteGroupFunc = func(ctx context.Context, gID, requesterID pgtype.UUID, force bool) error {
+					return service.ErrGroupHasBalances
+				}
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:  "forced delete",
+			query: "?force=true",
+			mockSetup: func(mock *MockGroupService) {
+				mock.DeleteGroupFunc = func(ctx context.Context, gID, requesterID pgtype.UUID, force bool) error {
+					if !force {
+						t.Errorf("expected force to be true")
+					}
+					return nil
+				}
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "invalid force flag",
+			query:          "?force=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockGroupService{}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockService)
+			}
+
+			req := createAuthenticatedRequest(http.MethodDelete, "/groups/"+uuidToString(groupID)+tt.query, nil, userID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("group_id", uuidToString(groupID))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			DeleteGroupHandler(mockService).ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRemoveMemberHandler(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	memberID := testutil.CreateTestUUID(2)
+	groupID := testutil.CreateTestUUID(10)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockGroupService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "successful removal",
+			mockSetup: func(mock *MockGroupService) {
+				mock.RemoveMemberFunc = func(ctx context.Context, gID, uID, requesterID pgtype.UUID, force bool) error {
+					if uID != memberID {
+						t.Errorf("expected member id to be passed through")
+					}
+					return nil
+				}
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "outstanding balance",
+			mockSetup: func(mock *MockGroupService) {
+				mock.RemoveMemberFunc = func(ctx context.Context, gID, uID, requesterID pgtype.UUID, force bool) error {
+					return &service.MemberBalanceError{Balance: "-12.50"}
+				}
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "conflict.member.outstanding_balance",
+		},
+		{
+			name: "cannot remove owner",
+			mockSetup: func(mock *MockGroupService) {
+				mock.RemoveMemberFunc = func(ctx context.Context, gID, uID, requesterID pgtype.UUID, force bool) error {
+					return service.ErrCannotModifyOwner
+				}
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "conflict.member.owner",
+		},
+		{
+			name: "member not found",
+			mockSetup: func(mock *MockGroupService) {
+				mock.RemoveMemberFunc = func(ctx context.Context, gID, uID, requesterID pgtype.UUID, force bool) error {
+					return service.ErrMemberNotFound
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockGroupService{}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockService)
+			}
+
+			path := "/groups/" + uuidToString(groupID) + "/members/" + uuidToString(memberID) + tt.query
+			req := createAuthenticatedRequest(http.MethodDelete, path, nil, userID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("group_id", uuidToString(groupID))
+			rctx.URLParams.Add("user_id", uuidToString(memberID))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			RemoveMemberHandler(mockService).ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedCode != "" && !strings.Contains(w.Body.String(), tt.expectedCode) {
+				t.Errorf("expected error code %q in body: %s", tt.expectedCode, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestLeaveGroupHandler(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	groupID := testutil.CreateTestUUID(10)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockGroupService)
+		expectedStatus int
+	}{
+		{
+			name: "successful leave",
+			mockSetup: func(mock *MockGroupService) {
+				mock.LeaveGroupFunc = func(ctx context.Context, gID, uID pgtype.UUID, force bool) error {
+					return nil
+				}
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:  "forced leave with balance",
+			query: "?force=true",
+			mockSetup: func(mock *MockGroupService) {
+				mock.LeaveGroupFunc = func(ctx context.Context, gID, uID pgtype.UUID, force bool) error {
+					if !force {
+						return &service.MemberBalanceError{Balance: "5.00"}
+					}
+					return nil
+				}
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "owner cannot leave",
+			mockSetup: func(mock *MockGroupService) {
+				mock.LeaveGroupFunc = func(ctx context.Context, gID, uID pgtype.UUID, force bool) error {
+					return service.ErrOwnerCannotLeave
+				}
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockGroupService{}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockService)
+			}
+
+			req := createAuthenticatedRequest(http.MethodPost, "/groups/"+uuidToString(groupID)+"/leave"+tt.query, nil, userID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("group_id", uuidToString(groupID))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			LeaveGroupHandler(mockService).ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
@@ -22,10 +24,16 @@ type AcceptInvitationRequest struct {
 }
 
 type JoinGroupRequest struct {
+	Email    string `json:"email,omitempty"` // Only used with join links
 	Password string `json:"password,omitempty"`
 	Name     string `json:"name,omitempty"`
 }
 
+type CreateJoinLinkRequest struct {
+	MaxUses        *int32 `json:"max_uses,omitempty" validate:"omitempty,min=1"`
+	ExpiresInHours int    `json:"expires_in_hours,omitempty" validate:"omitempty,min=1,max=720"`
+}
+
 type InvitationResponse struct {
 	ID           pgtype.UUID `json:"id"`
 	GroupID      pgtype.UUID `json:"group_id"`
@@ -37,6 +45,35 @@ type InvitationResponse struct {
 	InvitedBy    pgtype.UUID `json:"invited_by"`
 	InviterName  string      `json:"inviter_name,omitempty"`
 	InviterEmail string      `json:"inviter_email,omitempty"`
+	Type         string      `json:"type"` // "invitation" or "join_link"
+}
+
+// GroupInvitationResponse describes an invitation to group members. It never
+// includes the token, which only goes to the invitee by email.
+type GroupInvitationResponse struct {
+	ID        pgtype.UUID `json:"id"`
+	GroupID   pgtype.UUID `json:"group_id"`
+	Email     string      `json:"email"`
+	Role      string      `json:"role"`
+	Status    string      `json:"status"`
+	InvitedBy pgtype.UUID `json:"invited_by"`
+	ExpiresAt string      `json:"expires_at"`
+	CreatedAt string      `json:"created_at"`
+}
+
+// JoinLinkResponse describes a shareable join link. The token is shown to the
+// owners and admins who manage links so they can share /invite/{token}.
+type JoinLinkResponse struct {
+	ID        pgtype.UUID `json:"id"`
+	GroupID   pgtype.UUID `json:"group_id"`
+	Token     string      `json:"token"`
+	MaxUses   *int32      `json:"max_uses"`
+	UseCount  int32       `json:"use_count"`
+	Status    string      `json:"status"` // "active", "expired", "exhausted" or "revoked"
+	ExpiresAt string      `json:"expires_at"`
+	RevokedAt *string     `json:"revoked_at"`
+	CreatedBy pgtype.UUID `json:"created_by"`
+	CreatedAt string      `json:"created_at"`
 }
 
 func CreateInvitationHandler(invitationService service.GroupInvitationService) http.HandlerFunc {
@@ -59,7 +96,7 @@ func CreateInvitationHandler(invitationService service.GroupInvitationService) h
 			return
 		}
 
-		token, err := invitationService.CreateInvitation(r.Context(), service.CreateInvitationInput{
+		invitation, err := invitationService.CreateInvitation(r.Context(), service.CreateInvitationInput{
 			GroupID:   groupID,
 			InvitedBy: userID,
 			Email:     req.Email,
@@ -80,9 +117,15 @@ func CreateInvitationHandler(invitationService service.GroupInvitationService) h
 			return
 		}
 
-		response.SendSuccess(w, http.StatusCreated, map[string]string{
-			"message": "invitation sent (mock)",
-			"token":   token, // Returning token for testing purposes
+		response.SendSuccess(w, http.StatusCreated, GroupInvitationResponse{
+			ID:        invitation.ID,
+			GroupID:   invitation.GroupID,
+			Email:     invitation.Email,
+			Role:      invitation.Role,
+			Status:    invitation.Status,
+			InvitedBy: invitation.InvitedBy,
+			ExpiresAt: formatTimestamp(invitation.ExpiresAt),
+			CreatedAt: formatTimestamp(invitation.CreatedAt),
 		})
 	}
 }
@@ -120,6 +163,10 @@ func GetInvitationHandler(invitationService service.GroupInvitationService) http
 			InvitedBy:    inv.Invitation.InvitedBy,
 			InviterName:  inv.InviterName,
 			InviterEmail: inv.InviterEmail,
+			Type:         "invitation",
+		}
+		if inv.JoinLink {
+			resp.Type = "join_link"
 		}
 
 		response.SendSuccess(w, http.StatusOK, resp)
@@ -153,6 +200,10 @@ func AcceptInvitationHandler(invitationService service.GroupInvitationService) h
 				statusCode = http.StatusNotFound
 				code = "resource.invitation.not_found"
 				message = "Invitation not found."
+			case service.ErrGroupNotFound:
+				statusCode = http.StatusNotFound
+				code = "resource.group.not_found"
+				message = "Group not found."
 			case service.ErrInvitationExpired:
 				statusCode = http.StatusGone
 				code = "resource.invitation.expired"
@@ -189,6 +240,7 @@ func JoinGroupHandler(invitationService service.GroupInvitationService) http.Han
 
 		user, member, err := invitationService.JoinGroup(r.Context(), service.JoinGroupInput{
 			Token:               token,
+			Email:               req.Email,
 			Password:            req.Password,
 			Name:                req.Name,
 			AuthenticatedUserID: pgtype.UUID{Bytes: userID.Bytes, Valid: isAuth},
@@ -203,6 +255,10 @@ func JoinGroupHandler(invitationService service.GroupInvitationService) http.Han
 				statusCode = http.StatusNotFound
 				code = "resource.invitation.not_found"
 				message = "Invitation not found."
+			case service.ErrGroupNotFound:
+				statusCode = http.StatusNotFound
+				code = "resource.group.not_found"
+				message = "Group not found."
 			case service.ErrUserNotFound:
 				statusCode = http.StatusNotFound
 				code = "resource.user.not_found"
@@ -211,6 +267,10 @@ func JoinGroupHandler(invitationService service.GroupInvitationService) http.Han
 				statusCode = http.StatusUnauthorized
 				code = "auth.credentials.invalid"
 				message = "Invalid email or password."
+			case service.ErrAccountLocked:
+				statusCode = http.StatusTooManyRequests
+				code = "auth.account.locked"
+				message = "Too many failed sign-in attempts. Please try again later."
 			case service.ErrInvitationEmailMismatch:
 				statusCode = http.StatusForbidden
 				code = "permission.invitation.email_mismatch"
@@ -223,6 +283,10 @@ func JoinGroupHandler(invitationService service.GroupInvitationService) http.Han
 				statusCode = http.StatusUnprocessableEntity
 				code = "validation.invitation.password.required_new_account"
 				message = "Password is required to create account."
+			case service.ErrEmailRequiredForJoinLink:
+				statusCode = http.StatusUnprocessableEntity
+				code = "validation.invitation.email.required"
+				message = "Email is required to join with this link."
 			}
 			response.SendErrorWithCode(w, statusCode, code, message)
 			return
@@ -236,6 +300,268 @@ func JoinGroupHandler(invitationService service.GroupInvitationService) http.Han
 	}
 }
 
+func ListGroupInvitationsHandler(invitationService service.GroupInvitationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.invitation.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		invitations, err := invitationService.ListGroupInvitations(r.Context(), groupID, userID)
+		if err != nil {
+			sendInvitationManagementError(w, err, "system.invitation.list_failed", "Unable to list invitations.")
+			return
+		}
+
+		now := time.Now()
+		resp := make([]GroupInvitationResponse, 0, len(invitations))
+		for _, inv := range invitations {
+			status := inv.Status
+			if status == "pending" && !inv.ExpiresAt.Time.After(now) {
+				status = "expired"
+			}
+			resp = append(resp, GroupInvitationResponse{
+				ID:        inv.ID,
+				GroupID:   inv.GroupID,
+				Email:     inv.Email,
+				Role:      inv.Role,
+				Status:    status,
+				InvitedBy: inv.InvitedBy,
+				ExpiresAt: formatTimestamp(inv.ExpiresAt),
+				CreatedAt: formatTimestamp(inv.CreatedAt),
+			})
+		}
+
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+func ResendInvitationHandler(invitationService service.GroupInvitationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, invitationID, userID, ok := parseInvitationManagementParams(w, r, "invitation_id")
+		if !ok {
+			return
+		}
+
+		invitation, err := invitationService.ResendInvitation(r.Context(), groupID, invitationID, userID)
+		if err != nil {
+			sendInvitationManagementError(w, err, "system.invitation.resend_failed", "Unable to resend invitation.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, GroupInvitationResponse{
+			ID:        invitation.ID,
+			GroupID:   invitation.GroupID,
+			Email:     invitation.Email,
+			Role:      invitation.Role,
+			Status:    invitation.Status,
+			InvitedBy: invitation.InvitedBy,
+			ExpiresAt: formatTimestamp(invitation.ExpiresAt),
+			CreatedAt: formatTimestamp(invitation.CreatedAt),
+		})
+	}
+}
+
+func RevokeInvitationHandler(invitationService service.GroupInvitationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, invitationID, userID, ok := parseInvitationManagementParams(w, r, "invitation_id")
+		if !ok {
+			return
+		}
+
+		if err := invitationService.RevokeInvitation(r.Context(), groupID, invitationID, userID); err != nil {
+			sendInvitationManagementError(w, err, "system.invitation.revoke_failed", "Unable to revoke invitation.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func CreateJoinLinkHandler(invitationService service.GroupInvitationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := middleware.GetBody[CreateJoinLinkRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.invitation.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		link, err := invitationService.CreateJoinLink(r.Context(), service.CreateJoinLinkInput{
+			GroupID:   groupID,
+			CreatedBy: userID,
+			MaxUses:   req.MaxUses,
+			ExpiresIn: time.Duration(req.ExpiresInHours) * time.Hour,
+		})
+		if err != nil {
+			sendInvitationManagementError(w, err, "system.join_link.create_failed", "Unable to create join link.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusCreated, joinLinkToResponse(link, time.Now()))
+	}
+}
+
+func ListJoinLinksHandler(invitationService service.GroupInvitationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.invitation.group_id.invalid", "Invalid group id.")
+			return
+		}
+
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		links, err := invitationService.ListJoinLinks(r.Context(), groupID, userID)
+		if err != nil {
+			sendInvitationManagementError(w, err, "system.join_link.list_failed", "Unable to list join links.")
+			return
+		}
+
+		now := time.Now()
+		resp := make([]JoinLinkResponse, 0, len(links))
+		for _, link := range links {
+			resp = append(resp, joinLinkToResponse(link, now))
+		}
+
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+func RevokeJoinLinkHandler(invitationService service.GroupInvitationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, linkID, userID, ok := parseInvitationManagementParams(w, r, "link_id")
+		if !ok {
+			return
+		}
+
+		if err := invitationService.RevokeJoinLink(r.Context(), groupID, linkID, userID); err != nil {
+			sendInvitationManagementError(w, err, "system.join_link.revoke_failed", "Unable to revoke join link.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// parseInvitationManagementParams reads the group id, the named child id and the caller.
+func parseInvitationManagementParams(w http.ResponseWriter, r *http.Request, idParam string) (pgtype.UUID, pgtype.UUID, pgtype.UUID, bool) {
+	groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+	if err != nil {
+		response.SendErrorWithCode(w, http.StatusBadRequest, "validation.invitation.group_id.invalid", "Invalid group id.")
+		return pgtype.UUID{}, pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	id, err := parseUUID(chi.URLParam(r, idParam))
+	if err != nil {
+		response.SendErrorWithCode(w, http.StatusBadRequest, "validation.invitation."+idParam+".invalid", "Invalid id.")
+		return pgtype.UUID{}, pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+		return pgtype.UUID{}, pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	return groupID, id, userID, true
+}
+
+// sendInvitationManagementError maps errors from the owner/admin invitation and join link handlers.
+func sendInvitationManagementError(w http.ResponseWriter, err error, code, message string) {
+	statusCode := http.StatusBadRequest
+	switch err {
+	case service.ErrGroupNotFound:
+		statusCode = http.StatusNotFound
+		code = "resource.group.not_found"
+		message = "Group not found."
+	case service.ErrNotGroupMember:
+		statusCode = http.StatusForbidden
+		code = "permission.group.member_required"
+		message = "You are not a member of this group."
+	case service.ErrInsufficientPermissions:
+		statusCode = http.StatusForbidden
+		code = "permission.group.role_required"
+		message = "Only group owners and admins can manage invitations."
+	case service.ErrInvitationNotFound:
+		statusCode = http.StatusNotFound
+		code = "resource.invitation.not_found"
+		message = "Invitation not found."
+	case service.ErrInvitationNotPending:
+		statusCode = http.StatusConflict
+		code = "conflict.invitation.not_pending"
+		message = "Invitation has already been accepted or revoked."
+	case service.ErrJoinLinkNotFound:
+		statusCode = http.StatusNotFound
+		code = "resource.join_link.not_found"
+		message = "Join link not found."
+	case service.ErrJoinLinkRevoked:
+		statusCode = http.StatusConflict
+		code = "conflict.join_link.revoked"
+		message = "Join link has already been revoked."
+	case service.ErrInvalidJoinLinkMaxUses:
+		statusCode = http.StatusUnprocessableEntity
+		code = "validation.join_link.max_uses.invalid"
+		message = "Max uses must be a positive number."
+	case service.ErrInvalidJoinLinkExpiry:
+		statusCode = http.StatusUnprocessableEntity
+		code = "validation.join_link.expires_in_hours.invalid"
+		message = "Join links must expire between 1 hour and 30 days from now."
+	}
+	response.SendErrorWithCode(w, statusCode, code, message)
+}
+
+func joinLinkToResponse(link sqlc.GroupJoinLink, now time.Time) JoinLinkResponse {
+	resp := JoinLinkResponse{
+		ID:        link.ID,
+		GroupID:   link.GroupID,
+		Token:     link.Token,
+		UseCount:  link.UseCount,
+		Status:    "active",
+		ExpiresAt: formatTimestamp(link.ExpiresAt),
+		CreatedBy: link.CreatedBy,
+		CreatedAt: formatTimestamp(link.CreatedAt),
+	}
+	if link.MaxUses.Valid {
+		maxUses := link.MaxUses.Int32
+		resp.MaxUses = &maxUses
+	}
+
+	switch {
+	case link.RevokedAt.Valid:
+		revokedAt := formatTimestamp(link.RevokedAt)
+		resp.RevokedAt = &revokedAt
+		resp.Status = "revoked"
+	case !link.ExpiresAt.Time.After(now):
+		resp.Status = "expired"
+	case link.MaxUses.Valid && link.UseCount >= link.MaxUses.Int32:
+		resp.Status = "exhausted"
+	}
+	return resp
+}
+
 func ListPendingInvitationsHandler(invitationService service.GroupInvitationService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Get email from query or from user info?
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+type UpdateNotificationPreferenceRequest struct {
+	Category string `json:"category" validate:"required,oneof=expenses comments settlements members"`
+	Muted    *bool  `json:"muted" validate:"required"`
+}
+
+type NotificationActor struct {
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+type NotificationResponse struct {
+	ID         string            `json:"id"`
+	ActivityID string            `json:"activity_id"`
+	GroupID    string            `json:"group_id"`
+	GroupName  string            `json:"group_name"`
+	Category   string            `json:"category"`
+	Action     string            `json:"action"`
+	EntityType string            `json:"entity_type"`
+	EntityID   string            `json:"entity_id"`
+	Metadata   json.RawMessage   `json:"metadata,omitempty"`
+	Actor      NotificationActor `json:"actor"`
+	Read       bool              `json:"read"`
+	ReadAt     string            `json:"read_at,omitempty"`
+	CreatedAt  string            `json:"created_at"`
+}
+
+type UnreadNotificationsResponse struct {
+	Count int64 `json:"count"`
+}
+
+func notificationToResponse(n sqlc.ListUserNotificationsRow) NotificationResponse {
+	resp := NotificationResponse{
+		ID:         formatUUID(n.ID.Bytes),
+		ActivityID: formatUUID(n.ActivityID.Bytes),
+		GroupID:    formatUUID(n.GroupID.Bytes),
+		GroupName:  n.GroupName,
+		Category:   n.Category,
+		Action:     n.Action,
+		EntityType: n.EntityType,
+		EntityID:   formatUUID(n.EntityID.Bytes),
+		Actor: NotificationActor{
+			ID:        formatUUID(n.ActorID.Bytes),
+			Name:      n.ActorName.String,
+			AvatarURL: n.ActorAvatarUrl.String,
+		},
+		Read:      n.ReadAt.Valid,
+		ReadAt:    formatRFC3339(n.ReadAt),
+		CreatedAt: formatRFC3339(n.CreatedAt),
+	}
+	if len(n.Metadata) > 0 && string(n.Metadata) != "{}" {
+		resp.Metadata = n.Metadata
+	}
+	return resp
+}
+
+func ListNotificationsHandler(notificationService service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		query := r.URL.Query()
+		limit := int32(20)
+		if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 {
+			limit = int32(min(l, 100))
+		}
+		offset := int32(0)
+		if o, err := strconv.Atoi(query.Get("offset")); err == nil && o >= 0 {
+			offset = int32(o)
+		}
+		unreadOnly, _ := strconv.ParseBool(query.Get("unread"))
+
+		notifications, err := notificationService.ListNotifications(r.Context(), service.ListNotificationsInput{
+			UserID:     userID,
+			UnreadOnly: unreadOnly,
+			Limit:      limit,
+			Offset:     offset,
+		})
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.notification.list_failed", "Unable to load notifications.")
+			return
+		}
+
+		resp := make([]NotificationResponse, 0, len(notifications))
+		for _, n := range notifications {
+			resp = append(resp, notificationToResponse(n))
+		}
+
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+func CountUnreadNotificationsHandler(notificationService service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		count, err := notificationService.CountUnread(r.Context(), userID)
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.notification.count_failed", "Unable to count notifications.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, UnreadNotificationsResponse{Count: count})
+	}
+}
+
+func MarkNotificationReadHandler(notificationService service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		notificationID, err := parseUUID(chi.URLParam(r, "notification_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.notification.id_invalid", "Invalid notification ID.")
+			return
+		}
+
+		if _, err := notificationService.MarkRead(r.Context(), userID, notificationID); err != nil {
+			if errors.Is(err, service.ErrNotificationNotFound) {
+				response.SendErrorWithCode(w, http.StatusNotFound, "resource.notification.not_found", "Notification not found.")
+				return
+			}
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.notification.update_failed", "Unable to update notification.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func MarkAllNotificationsReadHandler(notificationService service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		updated, err := notificationService.MarkAllRead(r.Context(), userID)
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.notification.update_failed", "Unable to update notifications.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, map[string]int64{"updated": updated})
+	}
+}
+
+func GetNotificationPreferencesHandler(notificationService service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		preferences, err := notificationService.GetPreferences(r.Context(), userID)
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.notification.preferences_failed", "Unable to load notification preferences.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, preferences)
+	}
+}
+
+func UpdateNotificationPreferenceHandler(notificationService service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		req, ok := middleware.GetBody[UpdateNotificationPreferenceRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		preferences, err := notificationService.SetPreference(r.Context(), userID, req.Category, *req.Muted)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidNotificationCategory) {
+				response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.notification.category_invalid", "Unknown notification category.")
+				return
+			}
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.notification.preferences_failed", "Unable to update notification preferences.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, preferences)
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockNotificationService for testing
+type MockNotificationService struct {
+	service.NotificationService
+	ListNotificationsFunc func(ctx context.Context, input service.ListNotificationsInput) ([]sqlc.ListUserNotificationsRow, error)
+	MarkReadFunc          func(ctx context.Context, userID, notificationID pgtype.UUID) (sqlc.Notification, error)
+}
+
+func (m *MockNotificationService) ListNotifications(ctx context.Context, input service.ListNotificationsInput) ([]sqlc.ListUserNotificationsRow, error) {
+	if m.ListNotificationsFunc != nil {
+		return m.ListNotificationsFunc(ctx, input)
+	}
+	return []sqlc.ListUserNotificationsRow{}, nil
+}
+
+func (m *MockNotificationService) MarkRead(ctx context.Context, userID, notificationID pgtype.UUID) (sqlc.Notification, error) {
+	if m.MarkReadFunc != nil {
+		return m.MarkReadFunc(ctx, userID, notificationID)
+	}
+	return sqlc.Notification{}, nil
+}
+
+func TestListNotificationsHandler(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+
+	var got service.ListNotificationsInput
+	mock := &MockNotificationService{
+		ListNotificationsFunc: func(ctx context.Context, input service.ListNotificationsInput) ([]sqlc.ListUserNotificationsRow, error) {
+			got = input
+			return []sqlc.ListUserNotificationsRow{{
+				ID:        testutil.CreateTestUUID(10),
+				ActorID:   testutil.CreateTestUUID(2),
+				ActorName: pgtype.Text{String: "Asha", Valid: true},
+				GroupName: "Trip",
+				Category:  service.NotificationCategoryExpenses,
+				Action:    "expense_created",
+				Metadata:  []byte(`{"title":"Dinner"}`),
+			}}, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users/me/notifications?unread=true&limit=500", nil)
+	req = req.WithContext(middleware.SetUserID(req.Context(), userID))
+	rr := httptest.NewRecorder()
+	ListNotificationsHandler(mock).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got.UserID != userID || !got.UnreadOnly || got.Limit != 100 {
+		t.Errorf("unexpected input %+v", got)
+	}
+
+	var resp response.StandardResponse[[]NotificationResponse, response.ErrorDetail]
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Data == nil || len(*resp.Data) != 1 {
+		t.Fatalf("expected 1 notification, got %+v", resp.Data)
+	}
+	n := (*resp.Data)[0]
+	if n.Read || n.Actor.Name != "Asha" || n.GroupName != "Trip" || string(n.Metadata) != `{"title":"Dinner"}` {
+		t.Errorf("unexpected notification %+v", n)
+	}
+}
+
+func TestMarkNotificationReadHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		notificationID string
+		err            error
+		wantCode       int
+	}{
+		{"marks read", testutil.CreateTestUUID(10).String(), nil, http.StatusNoContent},
+		{"invalid id", "nope", nil, http.StatusBadRequest},
+		{"someone else's notification", testutil.CreateTestUUID(11).String(), service.ErrNotificationNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockNotificationService{
+				MarkReadFunc: func(ctx context.Context, userID, notificationID pgtype.UUID) (sqlc.Notification, error) {
+					return sqlc.Notification{}, tt.err
+				},
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/users/me/notifications/"+tt.notificationID+"/read", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("notification_id", tt.notificationID)
+			ctx := context.WithValue(middleware.SetUserID(req.Context(), testutil.CreateTestUUID(1)), chi.RouteCtxKey, rctx)
+			rr := httptest.NewRecorder()
+			MarkNotificationReadHandler(mock).ServeHTTP(rr, req.WithContext(ctx))
+
+			if rr.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d: %s", tt.wantCode, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+type OIDCProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// OIDCAuthorizeResponse carries the PKCE code verifier, which the client keeps
+// (e.g. in sessionStorage) and sends back with the callback.
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	CodeVerifier     string `json:"code_verifier"`
+}
+
+type OIDCCallbackRequest struct {
+	Code         string `json:"code" validate:"required"`
+	State        string `json:"state" validate:"required"`
+	CodeVerifier string `json:"code_verifier" validate:"required"`
+}
+
+func ListOIDCProvidersHandler(oidcService service.OIDCService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response.SendSuccess(w, http.StatusOK, OIDCProvidersResponse{Providers: oidcService.ListProviders()})
+	}
+}
+
+func StartOIDCLoginHandler(oidcService service.OIDCService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authorizationURL, codeVerifier, err := oidcService.StartLogin(r.Context(), chi.URLParam(r, "provider"))
+		if err != nil {
+			if errors.Is(err, service.ErrOIDCProviderNotFound) {
+				response.SendErrorWithCode(w, http.StatusNotFound, "resource.oidc_provider.not_found", "Sign-in provider not found.")
+				return
+			}
+			log.Printf("oidc start login: %v", err)
+			response.SendErrorWithCode(w, http.StatusBadGateway, "system.oidc.provider_unavailable", "The sign-in provider is unavailable right now.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, OIDCAuthorizeResponse{AuthorizationURL: authorizationURL, CodeVerifier: codeVerifier})
+	}
+}
+
+func OIDCCallbackHandler(oidcService service.OIDCService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := middleware.GetBody[OIDCCallbackRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		result, err := oidcService.CompleteLogin(
+			r.Context(),
+			chi.URLParam(r, "provider"),
+			req.Code,
+			req.State,
+			req.CodeVerifier,
+			r.UserAgent(),
+			r.RemoteAddr,
+		)
+		if err != nil {
+			var statusCode int
+			var code string
+			var message string
+			switch {
+			case errors.Is(err, service.ErrOIDCProviderNotFound):
+				statusCode = http.StatusNotFound
+				code = "resource.oidc_provider.not_found"
+				message = "Sign-in provider not found."
+			case errors.Is(err, service.ErrOIDCStateInvalid):
+				statusCode = http.StatusBadRequest
+				code = "auth.oidc.state_invalid"
+				message = "This sign-in link expired. Please try again."
+			case errors.Is(err, service.ErrOIDCCodeInvalid), errors.Is(err, service.ErrOIDCTokenInvalid):
+				statusCode = http.StatusUnauthorized
+				code = "auth.oidc.rejected"
+				message = "The sign-in provider could not confirm your identity."
+			case errors.Is(err, service.ErrOIDCEmailNotVerified):
+				statusCode = http.StatusForbidden
+				code = "auth.oidc.email_unverified"
+				message = "Your email address is not verified with this provider."
+			case errors.Is(err, service.ErrOIDCAccountNotVerified):
+				statusCode = http.StatusConflict
+				code = "conflict.oidc.account_unverified"
+				message = "An account with this email exists. Sign in with your password and verify your email first."
+			default:
+				log.Printf("oidc callback: %v", err)
+				statusCode = http.StatusInternalServerError
+				code = "system.auth.login_failed"
+				message = "Unable to sign in right now."
+			}
+			response.SendErrorWithCode(w, statusCode, code, message)
+			return
+		}
+
+		sendLoginResult(w, result)
+	}
+}
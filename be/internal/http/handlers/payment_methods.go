@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+type SetPaymentMethodRequest struct {
+	Handle   string `json:"handle" validate:"required,max=300"`
+	Priority int32  `json:"priority" validate:"min=0,max=100"`
+}
+
+type PaymentMethodResponse struct {
+	Provider  string `json:"provider"`
+	Handle    string `json:"handle"`
+	Priority  int32  `json:"priority"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func paymentMethodToResponse(m sqlc.UserPaymentMethod) PaymentMethodResponse {
+	return PaymentMethodResponse{
+		Provider:  m.Provider,
+		Handle:    m.Handle,
+		Priority:  m.Priority,
+		CreatedAt: formatRFC3339(m.CreatedAt),
+		UpdatedAt: formatRFC3339(m.UpdatedAt),
+	}
+}
+
+func ListPaymentMethodsHandler(paymentMethodService service.PaymentMethodService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		methods, err := paymentMethodService.ListPaymentMethods(r.Context(), userID)
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.payment_method.list_failed", "Unable to load payment methods.")
+			return
+		}
+
+		resp := make([]PaymentMethodResponse, 0, len(methods))
+		for _, m := range methods {
+			resp = append(resp, paymentMethodToResponse(m))
+		}
+
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+func SetPaymentMethodHandler(paymentMethodService service.PaymentMethodService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		req, ok := middleware.GetBody[SetPaymentMethodRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		method, err := paymentMethodService.SetPaymentMethod(r.Context(), service.SetPaymentMethodInput{
+			UserID:   userID,
+			Provider: chi.URLParam(r, "provider"),
+			Handle:   req.Handle,
+			Priority: req.Priority,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrPaymentProviderInvalid):
+				response.SendErrorWithCode(w, http.StatusNotFound, "resource.payment_method.provider_not_found", "Unsupported payment provider.")
+			case errors.Is(err, service.ErrPaymentHandleInvalid):
+				response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.payment_method.handle_invalid", "Invalid payment handle for this provider.")
+			default:
+				response.SendErrorWithCode(w, http.StatusInternalServerError, "system.payment_method.update_failed", "Unable to save payment method.")
+			}
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, paymentMethodToResponse(method))
+	}
+}
+
+func DeletePaymentMethodHandler(paymentMethodService service.PaymentMethodService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		if err := paymentMethodService.DeletePaymentMethod(r.Context(), userID, chi.URLParam(r, "provider")); err != nil {
+			if errors.Is(err, service.ErrPaymentMethodNotFound) {
+				response.SendErrorWithCode(w, http.StatusNotFound, "resource.payment_method.not_found", "Payment method not found.")
+				return
+			}
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.payment_method.delete_failed", "Unable to delete payment method.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+type CreatePersonalAccessTokenRequest struct {
+	Name          string   `json:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=read expenses:write settlements:write groups:write friends:write profile:write"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=365"`
+}
+
+type PersonalAccessTokenResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	TokenPrefix string   `json:"token_prefix"`
+	Scopes      []string `json:"scopes"`
+	ExpiresAt   string   `json:"expires_at,omitempty"`
+	LastUsedAt  string   `json:"last_used_at,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+type CreatePersonalAccessTokenResponse struct {
+	PersonalAccessTokenResponse
+	// Token is only ever returned here
+	Token string `json:"token"`
+}
+
+func personalAccessTokenToResponse(pat sqlc.PersonalAccessToken) PersonalAccessTokenResponse {
+	return PersonalAccessTokenResponse{
+		ID:          formatUUID(pat.ID.Bytes),
+		Name:        pat.Name,
+		TokenPrefix: pat.TokenPrefix,
+		Scopes:      pat.Scopes,
+		ExpiresAt:   formatRFC3339(pat.ExpiresAt),
+		LastUsedAt:  formatRFC3339(pat.LastUsedAt),
+		CreatedAt:   formatRFC3339(pat.CreatedAt),
+	}
+}
+
+func ListPersonalAccessTokensHandler(tokenService service.PersonalAccessTokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		tokens, err := tokenService.ListTokens(r.Context(), userID)
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.token.list_failed", "Unable to load access tokens.")
+			return
+		}
+
+		resp := make([]PersonalAccessTokenResponse, 0, len(tokens))
+		for _, pat := range tokens {
+			resp = append(resp, personalAccessTokenToResponse(pat))
+		}
+
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+func CreatePersonalAccessTokenHandler(tokenService service.PersonalAccessTokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		req, ok := middleware.GetBody[CreatePersonalAccessTokenRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		token, pat, err := tokenService.CreateToken(r.Context(), service.CreatePersonalAccessTokenInput{
+			UserID:    userID,
+			Name:      req.Name,
+			Scopes:    req.Scopes,
+			ExpiresIn: time.Duration(req.ExpiresInDays) * 24 * time.Hour,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidTokenScope):
+				response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.token.scopes_invalid", "Unknown token scope.")
+			case errors.Is(err, service.ErrInvalidTokenExpiry):
+				response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.token.expiry_invalid", "Tokens can last at most 365 days.")
+			case errors.Is(err, service.ErrTooManyAccessTokens):
+				response.SendErrorWithCode(w, http.StatusConflict, "conflict.token.limit_reached", "Revoke an access token before creating another.")
+			default:
+				response.SendErrorWithCode(w, http.StatusInternalServerError, "system.token.create_failed", "Unable to create access token.")
+			}
+			return
+		}
+
+		response.SendSuccess(w, http.StatusCreated, CreatePersonalAccessTokenResponse{
+			PersonalAccessTokenResponse: personalAccessTokenToResponse(pat),
+			Token:                       token,
+		})
+	}
+}
+
+func RevokePersonalAccessTokenHandler(tokenService service.PersonalAccessTokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		tokenID, err := parseUUID(chi.URLParam(r, "token_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.token.id_invalid", "Invalid token ID.")
+			return
+		}
+
+		if err := tokenService.RevokeToken(r.Context(), userID, tokenID); err != nil {
+			if errors.Is(err, service.ErrPersonalAccessTokenNotFound) {
+				response.SendErrorWithCode(w, http.StatusNotFound, "resource.token.not_found", "Access token not found.")
+				return
+			}
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.token.revoke_failed", "Unable to revoke access token.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+type SessionResponse struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	IPAddress  string `json:"ip_address,omitempty"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at"`
+	ExpiresAt  string `json:"expires_at"`
+	Current    bool   `json:"current"`
+}
+
+func ListSessionsHandler(authService service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		sessions, err := authService.ListSessions(r.Context(), userID)
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.session.list_failed", "Unable to load sessions.")
+			return
+		}
+
+		currentID, hasCurrent := middleware.GetSessionID(r)
+
+		resp := make([]SessionResponse, 0, len(sessions))
+		for _, session := range sessions {
+			resp = append(resp, SessionResponse{
+				ID:         formatUUID(session.ID.Bytes),
+				UserAgent:  session.UserAgent.String,
+				IPAddress:  session.IpAddress.String,
+				CreatedAt:  formatRFC3339(session.CreatedAt),
+				LastUsedAt: formatRFC3339(session.LastUsedAt),
+				ExpiresAt:  formatRFC3339(session.ExpiresAt),
+				Current:    hasCurrent && session.ID == currentID,
+			})
+		}
+
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+func RevokeSessionHandler(authService service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		sessionID, err := parseUUID(chi.URLParam(r, "session_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.session.id_invalid", "Invalid session ID.")
+			return
+		}
+
+		currentID, _ := middleware.GetSessionID(r)
+
+		if err := authService.RevokeSession(r.Context(), userID, sessionID, currentID); err != nil {
+			switch {
+			case errors.Is(err, service.ErrSessionNotFound):
+				response.SendErrorWithCode(w, http.StatusNotFound, "resource.session.not_found", "Session not found.")
+			case errors.Is(err, service.ErrCannotRevokeCurrentSession):
+				response.SendErrorWithCode(w, http.StatusConflict, "conflict.session.current", "Use logout to end the current session.")
+			default:
+				response.SendErrorWithCode(w, http.StatusInternalServerError, "system.session.revoke_failed", "Unable to revoke session.")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockAuthService for testing
+type MockAuthService struct {
+	service.AuthService
+	ListSessionsFunc  func(ctx context.Context, userID pgtype.UUID) ([]sqlc.Session, error)
+	RevokeSessionFunc func(ctx context.Context, userID, sessionID, currentSessionID pgtype.UUID) error
+}
+
+func (m *MockAuthService) ListSessions(ctx context.Context, userID pgtype.UUID) ([]sqlc.Session, error) {
+	if m.ListSessionsFunc != nil {
+		return m.ListSessionsFunc(ctx, userID)
+	}
+	return []sqlc.Session{}, nil
+}
+
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID, sessionID, currentSessionID pgtype.UUID) error {
+	if m.RevokeSessionFunc != nil {
+		return m.RevokeSessionFunc(ctx, userID, sessionID, currentSessionID)
+	}
+	return nil
+}
+
+func createSessionRequest(method, path string, userID, sessionID pgtype.UUID) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	ctx := middleware.SetUserID(req.Context(), userID)
+	ctx = middleware.SetSessionID(ctx, sessionID)
+	return req.WithContext(ctx)
+}
+
+func TestListSessionsHandler_FlagsCurrent(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	currentID := testutil.CreateTestUUID(10)
+	otherID := testutil.CreateTestUUID(11)
+
+	mock := &MockAuthService{
+		ListSessionsFunc: func(ctx context.Context, uid pgtype.UUID) ([]sqlc.Session, error) {
+			return []sqlc.Session{
+				{ID: otherID, UserID: uid, UserAgent: pgtype.Text{String: "Firefox", Valid: true}},
+				{ID: currentID, UserID: uid, UserAgent: pgtype.Text{String: "Safari", Valid: true}},
+			}, nil
+		},
+	}
+
+	rr := httptest.NewRecorder()
+	ListSessionsHandler(mock).ServeHTTP(rr, createSessionRequest("GET", "/users/me/sessions", userID, currentID))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp response.StandardResponse[[]SessionResponse, response.ErrorDetail]
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Data == nil || len(*resp.Data) != 2 {
+		t.Fatalf("expected 2 sessions, got %+v", resp.Data)
+	}
+	for _, session := range *resp.Data {
+		wantCurrent := session.ID == currentID.String()
+		if session.Current != wantCurrent {
+			t.Errorf("session %s: expected current=%v, got %v", session.ID, wantCurrent, session.Current)
+		}
+	}
+}
+
+func TestRevokeSessionHandler(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	currentID := testutil.CreateTestUUID(10)
+	otherID := testutil.CreateTestUUID(11)
+
+	tests := []struct {
+		name           string
+		sessionParam   string
+		err            error
+		expectedStatus int
+	}{
+		{name: "revoked", sessionParam: otherID.String(), expectedStatus: http.StatusNoContent},
+		{name: "invalid id", sessionParam: "nope", expectedStatus: http.StatusBadRequest},
+		{name: "not found", sessionParam: otherID.String(), err: service.ErrSessionNotFound, expectedStatus: http.StatusNotFound},
+		{name: "current session", sessionParam: currentID.String(), err: service.ErrCannotRevokeCurrentSession, expectedStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockAuthService{
+				RevokeSessionFunc: func(ctx context.Context, uid, sid, current pgtype.UUID) error {
+					if current != currentID {
+						t.Errorf("expected current session %v, got %v", currentID, current)
+					}
+					return tt.err
+				},
+			}
+
+			req := createSessionRequest("DELETE", "/users/me/sessions/"+tt.sessionParam, userID, currentID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("session_id", tt.sessionParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			RevokeSessionHandler(mock).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
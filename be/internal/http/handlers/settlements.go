@@ -22,7 +22,7 @@ type CreateSettlementRequest struct {
 	PayeePendingUserID   string `json:"payee_pending_user_id" validate:"omitempty,uuid"`
 	Amount               string `json:"amount" validate:"required"`
 	CurrencyCode         string `json:"currency_code" validate:"omitempty,len=3"`
-	Status               string `json:"status" validate:"omitempty,oneof=pending completed cancelled"`
+	Status               string `json:"status" validate:"omitempty,oneof=pending completed"`
 	PaymentMethod        string `json:"payment_method" validate:"max=50"`
 	TransactionReference string `json:"transaction_reference" validate:"max=100"`
 	Notes                string `json:"notes" validate:"max=500"`
@@ -31,14 +31,16 @@ type CreateSettlementRequest struct {
 type UpdateSettlementRequest struct {
 	Amount               string `json:"amount" validate:"required"`
 	CurrencyCode         string `json:"currency_code" validate:"omitempty,len=3"`
-	Status               string `json:"status" validate:"required,oneof=pending completed cancelled"`
+	Status               string `json:"status" validate:"required,oneof=pending completed disputed cancelled"`
 	PaymentMethod        string `json:"payment_method" validate:"max=50"`
 	TransactionReference string `json:"transaction_reference" validate:"max=100"`
 	Notes                string `json:"notes" validate:"max=500"`
 }
 
 type UpdateSettlementStatusRequest struct {
-	Status string `json:"status" validate:"required,oneof=pending completed cancelled"`
+	Status string `json:"status" validate:"required,oneof=pending completed disputed cancelled"`
+	// Reason is kept when disputing and recorded in the group activity
+	Reason string `json:"reason" validate:"max=500"`
 }
 
 // Response structs
@@ -56,6 +58,10 @@ type SettlementResponse struct {
 	PaymentMethod        string      `json:"payment_method,omitempty"`
 	TransactionReference string      `json:"transaction_reference,omitempty"`
 	PaidAt               string      `json:"paid_at,omitempty"`
+	ConfirmedAt          string      `json:"confirmed_at,omitempty"`
+	DisputedAt           string      `json:"disputed_at,omitempty"`
+	CancelledAt          string      `json:"cancelled_at,omitempty"`
+	DisputeReason        string      `json:"dispute_reason,omitempty"`
 	Notes                string      `json:"notes,omitempty"`
 	CreatedAt            string      `json:"created_at"`
 	CreatedBy            pgtype.UUID `json:"created_by"`
@@ -76,6 +82,10 @@ type SettlementWithUsersResponse struct {
 	PaymentMethod        string                    `json:"payment_method,omitempty"`
 	TransactionReference string                    `json:"transaction_reference,omitempty"`
 	PaidAt               string                    `json:"paid_at,omitempty"`
+	ConfirmedAt          string                    `json:"confirmed_at,omitempty"`
+	DisputedAt           string                    `json:"disputed_at,omitempty"`
+	CancelledAt          string                    `json:"cancelled_at,omitempty"`
+	DisputeReason        string                    `json:"dispute_reason,omitempty"`
 	Notes                string                    `json:"notes,omitempty"`
 	CreatedAt            string                    `json:"created_at"`
 	CreatedBy            pgtype.UUID               `json:"created_by"`
@@ -94,6 +104,14 @@ type SettlementParticipantInfo struct {
 	IsPending     bool        `json:"is_pending"`
 }
 
+// formatRFC3339 formats an optional timestamp, empty when unset.
+func formatRFC3339(ts pgtype.Timestamptz) string {
+	if !ts.Valid {
+		return ""
+	}
+	return ts.Time.Format(time.RFC3339)
+}
+
 // Helper to convert settlement to response
 func settlementToResponse(s sqlc.Settlement) SettlementResponse {
 	amount := "0"
@@ -138,6 +156,10 @@ func settlementToResponse(s sqlc.Settlement) SettlementResponse {
 		PaymentMethod:        paymentMethod,
 		TransactionReference: transactionReference,
 		PaidAt:               paidAt,
+		ConfirmedAt:          formatRFC3339(s.ConfirmedAt),
+		DisputedAt:           formatRFC3339(s.DisputedAt),
+		CancelledAt:          formatRFC3339(s.CancelledAt),
+		DisputeReason:        s.DisputeReason.String,
 		Notes:                notes,
 		CreatedAt:            s.CreatedAt.Time.Format(time.RFC3339),
 		CreatedBy:            s.CreatedBy,
@@ -146,6 +168,76 @@ func settlementToResponse(s sqlc.Settlement) SettlementResponse {
 	}
 }
 
+// settlementRowToResponse converts a settlement list row with payer/payee details.
+func settlementRowToResponse(row sqlc.ListSettlementsByGroupRow) SettlementWithUsersResponse {
+	amount := "0"
+	if val, err := row.Amount.Value(); err == nil {
+		if str, ok := val.(string); ok {
+			amount = str
+		}
+	}
+
+	paymentMethod := ""
+	if row.PaymentMethod.Valid {
+		paymentMethod = row.PaymentMethod.String
+	}
+
+	transactionReference := ""
+	if row.TransactionReference.Valid {
+		transactionReference = row.TransactionReference.String
+	}
+
+	notes := ""
+	if row.Notes.Valid {
+		notes = row.Notes.String
+	}
+
+	paidAt := ""
+	if row.PaidAt.Valid {
+		paidAt = row.PaidAt.Time.Format(time.RFC3339)
+	}
+
+	return SettlementWithUsersResponse{
+		ID:                   row.ID,
+		GroupID:              row.GroupID,
+		PayerID:              row.PayerID,
+		PayerPendingUserID:   row.PayerPendingUserID,
+		PayeeID:              row.PayeeID,
+		PayeePendingUserID:   row.PayeePendingUserID,
+		Amount:               amount,
+		CurrencyCode:         row.CurrencyCode,
+		Status:               row.Status,
+		PaymentMethod:        paymentMethod,
+		TransactionReference: transactionReference,
+		PaidAt:               paidAt,
+		ConfirmedAt:          formatRFC3339(row.ConfirmedAt),
+		DisputedAt:           formatRFC3339(row.DisputedAt),
+		CancelledAt:          formatRFC3339(row.CancelledAt),
+		DisputeReason:        row.DisputeReason.String,
+		Notes:                notes,
+		CreatedAt:            row.CreatedAt.Time.Format(time.RFC3339),
+		CreatedBy:            row.CreatedBy,
+		UpdatedAt:            row.UpdatedAt.Time.Format(time.RFC3339),
+		UpdatedBy:            row.UpdatedBy,
+		Payer: SettlementParticipantInfo{
+			UserID:        row.PayerID,
+			PendingUserID: row.PayerPendingUserID,
+			Email:         row.PayerEmail,
+			Name:          row.PayerName.String,
+			AvatarURL:     row.PayerAvatarUrl.String,
+			IsPending:     row.PayerPendingUserID.Valid,
+		},
+		Payee: SettlementParticipantInfo{
+			UserID:        row.PayeeID,
+			PendingUserID: row.PayeePendingUserID,
+			Email:         row.PayeeEmail,
+			Name:          row.PayeeName.String,
+			AvatarURL:     row.PayeeAvatarUrl.String,
+			IsPending:     row.PayeePendingUserID.Valid,
+		},
+	}
+}
+
 // Handlers
 
 func CreateSettlementHandler(settlementService service.SettlementService) http.HandlerFunc {
@@ -227,8 +319,13 @@ func CreateSettlementHandler(settlementService service.SettlementService) http.H
 				statusCode = http.StatusNotFound
 			case service.ErrNotGroupMember:
 				statusCode = http.StatusForbidden
-			case service.ErrInvalidAmount, service.ErrInvalidStatus, service.ErrInvalidSettlement:
+			case service.ErrInvalidAmount, service.ErrInvalidStatus, service.ErrInvalidSettlement,
+				service.ErrInvalidCurrencyCode, service.ErrSettlementCurrency:
 				statusCode = http.StatusBadRequest
+			case service.ErrSettlementForbidden:
+				statusCode = http.StatusForbidden
+			case service.ErrInvalidStatusTransition:
+				statusCode = http.StatusConflict
 			default:
 				statusCode = http.StatusInternalServerError
 			}
@@ -309,68 +406,7 @@ func ListSettlementsByGroupHandler(settlementService service.SettlementService)
 		// Convert rows to response with user info
 		settlements := make([]SettlementWithUsersResponse, 0, len(rows))
 		for _, row := range rows {
-			amount := "0"
-			if val, err := row.Amount.Value(); err == nil {
-				if str, ok := val.(string); ok {
-					amount = str
-				}
-			}
-
-			paymentMethod := ""
-			if row.PaymentMethod.Valid {
-				paymentMethod = row.PaymentMethod.String
-			}
-
-			transactionReference := ""
-			if row.TransactionReference.Valid {
-				transactionReference = row.TransactionReference.String
-			}
-
-			notes := ""
-			if row.Notes.Valid {
-				notes = row.Notes.String
-			}
-
-			paidAt := ""
-			if row.PaidAt.Valid {
-				paidAt = row.PaidAt.Time.Format(time.RFC3339)
-			}
-
-			settlements = append(settlements, SettlementWithUsersResponse{
-				ID:                   row.ID,
-				GroupID:              row.GroupID,
-				PayerID:              row.PayerID,
-				PayerPendingUserID:   row.PayerPendingUserID,
-				PayeeID:              row.PayeeID,
-				PayeePendingUserID:   row.PayeePendingUserID,
-				Amount:               amount,
-				CurrencyCode:         row.CurrencyCode,
-				Status:               row.Status,
-				PaymentMethod:        paymentMethod,
-				TransactionReference: transactionReference,
-				PaidAt:               paidAt,
-				Notes:                notes,
-				CreatedAt:            row.CreatedAt.Time.Format(time.RFC3339),
-				CreatedBy:            row.CreatedBy,
-				UpdatedAt:            row.UpdatedAt.Time.Format(time.RFC3339),
-				UpdatedBy:            row.UpdatedBy,
-				Payer: SettlementParticipantInfo{
-					UserID:        row.PayerID,
-					PendingUserID: row.PayerPendingUserID,
-					Email:         row.PayerEmail,
-					Name:          row.PayerName.String,
-					AvatarURL:     row.PayerAvatarUrl.String,
-					IsPending:     row.PayerPendingUserID.Valid,
-				},
-				Payee: SettlementParticipantInfo{
-					UserID:        row.PayeeID,
-					PendingUserID: row.PayeePendingUserID,
-					Email:         row.PayeeEmail,
-					Name:          row.PayeeName.String,
-					AvatarURL:     row.PayeeAvatarUrl.String,
-					IsPending:     row.PayeePendingUserID.Valid,
-				},
-			})
+			settlements = append(settlements, settlementRowToResponse(row))
 		}
 
 		response.SendSuccess(w, http.StatusOK, settlements)
@@ -413,10 +449,13 @@ func UpdateSettlementHandler(settlementService service.SettlementService) http.H
 			switch err {
 			case service.ErrSettlementNotFound:
 				statusCode = http.StatusNotFound
-			case service.ErrNotGroupMember:
+			case service.ErrNotGroupMember, service.ErrSettlementForbidden:
 				statusCode = http.StatusForbidden
-			case service.ErrInvalidAmount, service.ErrInvalidStatus:
+			case service.ErrInvalidAmount, service.ErrInvalidStatus,
+				service.ErrInvalidCurrencyCode, service.ErrSettlementCurrency:
 				statusCode = http.StatusBadRequest
+			case service.ErrInvalidStatusTransition, service.ErrSettlementFinalized:
+				statusCode = http.StatusConflict
 			default:
 				statusCode = http.StatusInternalServerError
 			}
@@ -453,6 +492,7 @@ func UpdateSettlementStatusHandler(settlementService service.SettlementService)
 		settlement, err := settlementService.UpdateSettlementStatus(r.Context(), service.UpdateSettlementStatusInput{
 			SettlementID: settlementID,
 			Status:       req.Status,
+			Reason:       req.Reason,
 			UpdatedBy:    requesterID,
 		})
 		if err != nil {
@@ -460,10 +500,12 @@ func UpdateSettlementStatusHandler(settlementService service.SettlementService)
 			switch err {
 			case service.ErrSettlementNotFound:
 				statusCode = http.StatusNotFound
-			case service.ErrNotGroupMember:
+			case service.ErrNotGroupMember, service.ErrSettlementForbidden:
 				statusCode = http.StatusForbidden
 			case service.ErrInvalidStatus:
 				statusCode = http.StatusBadRequest
+			case service.ErrInvalidStatusTransition:
+				statusCode = http.StatusConflict
 			default:
 				statusCode = http.StatusInternalServerError
 			}
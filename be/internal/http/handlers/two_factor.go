@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,max=64"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled                bool   `json:"enabled"`
+	EnabledAt              string `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int64  `json:"recovery_codes_remaining"`
+}
+
+type TwoFactorEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func GetTwoFactorStatusHandler(twoFactorService service.TwoFactorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		status, err := twoFactorService.GetStatus(r.Context(), userID)
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.two_factor.fetch_failed", "Unable to load two-factor settings.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, TwoFactorStatusResponse{
+			Enabled:                status.Enabled,
+			EnabledAt:              formatRFC3339(status.EnabledAt),
+			RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+		})
+	}
+}
+
+func EnrollTwoFactorHandler(twoFactorService service.TwoFactorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		enrollment, err := twoFactorService.Enroll(r.Context(), userID)
+		if err != nil {
+			sendTwoFactorError(w, err, "system.two_factor.enroll_failed", "Unable to set up two-factor authentication.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, TwoFactorEnrollmentResponse{
+			Secret:          enrollment.Secret,
+			ProvisioningURI: enrollment.ProvisioningURI,
+		})
+	}
+}
+
+func VerifyTwoFactorHandler(twoFactorService service.TwoFactorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		req, ok := middleware.GetBody[TwoFactorCodeRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		recoveryCodes, err := twoFactorService.ConfirmEnrollment(r.Context(), userID, req.Code)
+		if err != nil {
+			sendTwoFactorError(w, err, "system.two_factor.enroll_failed", "Unable to set up two-factor authentication.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+	}
+}
+
+func RegenerateRecoveryCodesHandler(twoFactorService service.TwoFactorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		req, ok := middleware.GetBody[TwoFactorCodeRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		recoveryCodes, err := twoFactorService.RegenerateRecoveryCodes(r.Context(), userID, req.Code)
+		if err != nil {
+			sendTwoFactorError(w, err, "system.two_factor.recovery_codes_failed", "Unable to create new recovery codes.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+	}
+}
+
+func DisableTwoFactorHandler(twoFactorService service.TwoFactorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+			return
+		}
+
+		req, ok := middleware.GetBody[TwoFactorCodeRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		if err := twoFactorService.Disable(r.Context(), userID, req.Code); err != nil {
+			sendTwoFactorError(w, err, "system.two_factor.disable_failed", "Unable to turn off two-factor authentication.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// sendTwoFactorError maps the errors shared by the two-factor settings endpoints.
+func sendTwoFactorError(w http.ResponseWriter, err error, fallbackCode, fallbackMessage string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidTwoFactorCode):
+		response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.two_factor.code_invalid", "Invalid authentication code.")
+	case errors.Is(err, service.ErrTwoFactorLocked):
+		response.SendErrorWithCode(w, http.StatusTooManyRequests, "auth.two_factor.locked", "Too many invalid codes. Please try again later.")
+	case errors.Is(err, service.ErrTwoFactorAlreadyEnabled):
+		response.SendErrorWithCode(w, http.StatusConflict, "conflict.two_factor.already_enabled", "Two-factor authentication is already turned on.")
+	case errors.Is(err, service.ErrTwoFactorNotEnrolled):
+		response.SendErrorWithCode(w, http.StatusConflict, "conflict.two_factor.not_enrolled", "Start two-factor setup first.")
+	case errors.Is(err, service.ErrTwoFactorNotEnabled):
+		response.SendErrorWithCode(w, http.StatusConflict, "conflict.two_factor.not_enabled", "Two-factor authentication is not turned on.")
+	case errors.Is(err, service.ErrUserNotFound):
+		response.SendErrorWithCode(w, http.StatusNotFound, "resource.user.not_found", "User not found.")
+	default:
+		response.SendErrorWithCode(w, http.StatusInternalServerError, fallbackCode, fallbackMessage)
+	}
+}
@@ -16,10 +16,11 @@ type CreateUserRequest struct {
 }
 
 type UserResponse struct {
-	ID        pgtype.UUID `json:"id"`
-	Name      string      `json:"name"`
-	Email     string      `json:"email"`
-	CreatedAt string      `json:"created_at"`
+	ID            pgtype.UUID `json:"id"`
+	Name          string      `json:"name"`
+	Email         string      `json:"email"`
+	EmailVerified bool        `json:"email_verified"`
+	CreatedAt     string      `json:"created_at"`
 }
 
 func CreateUserHandler(userService service.UserService) http.HandlerFunc {
@@ -58,10 +59,11 @@ func CreateUserHandler(userService service.UserService) http.HandlerFunc {
 		}
 
 		resp := UserResponse{
-			ID:        user.ID,
-			Name:      user.Name.String,
-			Email:     user.Email,
-			CreatedAt: user.CreatedAt.Time.String(),
+			ID:            user.ID,
+			Name:          user.Name.String,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerifiedAt.Valid,
+			CreatedAt:     user.CreatedAt.Time.String(),
 		}
 
 		response.SendSuccess(w, http.StatusCreated, resp)
@@ -87,10 +89,11 @@ func GetMeHandler(userService service.UserService) http.HandlerFunc {
 		}
 
 		resp := UserResponse{
-			ID:        user.ID,
-			Name:      user.Name.String,
-			Email:     user.Email,
-			CreatedAt: user.CreatedAt.Time.String(),
+			ID:            user.ID,
+			Name:          user.Name.String,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerifiedAt.Valid,
+			CreatedAt:     user.CreatedAt.Time.String(),
 		}
 
 		response.SendSuccess(w, http.StatusOK, resp)
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=2000"`
+	Secret string   `json:"secret,omitempty" validate:"omitempty,min=16,max=200"`
+	Events []string `json:"events,omitempty" validate:"omitempty,max=20"`
+}
+
+type UpdateWebhookRequest struct {
+	URL    *string   `json:"url,omitempty" validate:"omitempty,url,max=2000"`
+	Events *[]string `json:"events,omitempty" validate:"omitempty,max=20"`
+	Active *bool     `json:"active,omitempty"`
+}
+
+type WebhookResponse struct {
+	ID        string   `json:"id"`
+	GroupID   string   `json:"group_id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	CreatedBy string   `json:"created_by"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type CreateWebhookResponse struct {
+	WebhookResponse
+	// Secret is only ever returned here
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  string          `json:"next_attempt_at,omitempty"`
+	ResponseStatus *int32          `json:"response_status,omitempty"`
+	ResponseBody   string          `json:"response_body,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	DeliveredAt    string          `json:"delivered_at,omitempty"`
+	CreatedAt      string          `json:"created_at"`
+}
+
+func webhookToResponse(webhook sqlc.GroupWebhook) WebhookResponse {
+	events := webhook.Events
+	if events == nil {
+		events = []string{}
+	}
+	return WebhookResponse{
+		ID:        formatUUID(webhook.ID.Bytes),
+		GroupID:   formatUUID(webhook.GroupID.Bytes),
+		URL:       webhook.Url,
+		Events:    events,
+		Active:    webhook.Active,
+		CreatedBy: formatUUID(webhook.CreatedBy.Bytes),
+		CreatedAt: formatRFC3339(webhook.CreatedAt),
+		UpdatedAt: formatRFC3339(webhook.UpdatedAt),
+	}
+}
+
+func webhookDeliveryToResponse(delivery sqlc.WebhookDelivery) WebhookDeliveryResponse {
+	resp := WebhookDeliveryResponse{
+		ID:           formatUUID(delivery.ID.Bytes),
+		WebhookID:    formatUUID(delivery.WebhookID.Bytes),
+		EventID:      formatUUID(delivery.EventID.Bytes),
+		EventType:    delivery.EventType,
+		Payload:      delivery.Payload,
+		Status:       delivery.Status,
+		Attempts:     delivery.Attempts,
+		ResponseBody: delivery.ResponseBody.String,
+		LastError:    delivery.LastError.String,
+		DeliveredAt:  formatRFC3339(delivery.DeliveredAt),
+		CreatedAt:    formatRFC3339(delivery.CreatedAt),
+	}
+	if delivery.Status == "pending" {
+		resp.NextAttemptAt = formatRFC3339(delivery.NextAttemptAt)
+	}
+	if delivery.ResponseStatus.Valid {
+		resp.ResponseStatus = &delivery.ResponseStatus.Int32
+	}
+	return resp
+}
+
+// sendWebhookError maps webhook service errors to API error codes.
+func sendWebhookError(w http.ResponseWriter, err error, fallbackCode, fallbackMessage string) {
+	switch {
+	case errors.Is(err, service.ErrGroupNotFound):
+		response.SendErrorWithCode(w, http.StatusNotFound, "resource.group.not_found", "Group not found.")
+	case errors.Is(err, service.ErrNotGroupMember):
+		response.SendErrorWithCode(w, http.StatusForbidden, "permission.group.member_required", "You are not a member of this group.")
+	case errors.Is(err, service.ErrInsufficientPermissions):
+		response.SendErrorWithCode(w, http.StatusForbidden, "permission.group.role_required", "Only group owners and admins can manage webhooks.")
+	case errors.Is(err, service.ErrWebhookNotFound):
+		response.SendErrorWithCode(w, http.StatusNotFound, "resource.webhook.not_found", "Webhook not found.")
+	case errors.Is(err, service.ErrWebhookDeliveryNotFound):
+		response.SendErrorWithCode(w, http.StatusNotFound, "resource.webhook.delivery_not_found", "Webhook delivery not found.")
+	case errors.Is(err, service.ErrInvalidWebhookURL):
+		response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.webhook.url_invalid", "Webhook URL must be an http or https URL.")
+	case errors.Is(err, service.ErrInvalidWebhookEvent):
+		response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.webhook.events_invalid", "Unknown webhook event.")
+	case errors.Is(err, service.ErrInvalidWebhookSecret):
+		response.SendErrorWithCode(w, http.StatusUnprocessableEntity, "validation.webhook.secret_invalid", "Webhook secrets must be at least 16 characters.")
+	case errors.Is(err, service.ErrTooManyWebhooks):
+		response.SendErrorWithCode(w, http.StatusConflict, "conflict.webhook.limit_reached", "Delete a webhook before adding another.")
+	default:
+		response.SendErrorWithCode(w, http.StatusInternalServerError, fallbackCode, fallbackMessage)
+	}
+}
+
+// webhookRequestIDs reads the requester and the group, and the webhook when
+// the route has one. It writes the error response itself.
+func webhookRequestIDs(w http.ResponseWriter, r *http.Request, withWebhook bool) (userID, groupID, webhookID pgtype.UUID, ok bool) {
+	userID, ok = middleware.GetUserID(r)
+	if !ok {
+		response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.authorization.unauthorized", "Unauthorized.")
+		return userID, groupID, webhookID, false
+	}
+
+	groupID, err := parseUUID(chi.URLParam(r, "group_id"))
+	if err != nil {
+		response.SendErrorWithCode(w, http.StatusBadRequest, "validation.group.group_id.invalid", "Invalid group id.")
+		return userID, groupID, webhookID, false
+	}
+
+	if withWebhook {
+		webhookID, err = parseUUID(chi.URLParam(r, "webhook_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.webhook.id_invalid", "Invalid webhook ID.")
+			return userID, groupID, webhookID, false
+		}
+	}
+	return userID, groupID, webhookID, true
+}
+
+func ListWebhooksHandler(webhookService service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, _, ok := webhookRequestIDs(w, r, false)
+		if !ok {
+			return
+		}
+
+		webhooks, err := webhookService.ListWebhooks(r.Context(), groupID, userID)
+		if err != nil {
+			sendWebhookError(w, err, "system.webhook.list_failed", "Unable to load webhooks.")
+			return
+		}
+
+		resp := make([]WebhookResponse, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			resp = append(resp, webhookToResponse(webhook))
+		}
+
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+func CreateWebhookHandler(webhookService service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, _, ok := webhookRequestIDs(w, r, false)
+		if !ok {
+			return
+		}
+
+		req, ok := middleware.GetBody[CreateWebhookRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		webhook, err := webhookService.CreateWebhook(r.Context(), service.CreateWebhookInput{
+			GroupID:     groupID,
+			RequesterID: userID,
+			URL:         req.URL,
+			Secret:      req.Secret,
+			Events:      req.Events,
+		})
+		if err != nil {
+			sendWebhookError(w, err, "system.webhook.create_failed", "Unable to create webhook.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusCreated, CreateWebhookResponse{
+			WebhookResponse: webhookToResponse(webhook),
+			Secret:          webhook.Secret,
+		})
+	}
+}
+
+func UpdateWebhookHandler(webhookService service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, webhookID, ok := webhookRequestIDs(w, r, true)
+		if !ok {
+			return
+		}
+
+		req, ok := middleware.GetBody[UpdateWebhookRequest](r)
+		if !ok {
+			response.SendErrorWithCode(w, http.StatusInternalServerError, "system.request.context_invalid", "Invalid request context.")
+			return
+		}
+
+		webhook, err := webhookService.UpdateWebhook(r.Context(), service.UpdateWebhookInput{
+			GroupID:     groupID,
+			WebhookID:   webhookID,
+			RequesterID: userID,
+			URL:         req.URL,
+			Events:      req.Events,
+			Active:      req.Active,
+		})
+		if err != nil {
+			sendWebhookError(w, err, "system.webhook.update_failed", "Unable to update webhook.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusOK, webhookToResponse(webhook))
+	}
+}
+
+func DeleteWebhookHandler(webhookService service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, webhookID, ok := webhookRequestIDs(w, r, true)
+		if !ok {
+			return
+		}
+
+		if err := webhookService.DeleteWebhook(r.Context(), groupID, webhookID, userID); err != nil {
+			sendWebhookError(w, err, "system.webhook.delete_failed", "Unable to delete webhook.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func ListWebhookDeliveriesHandler(webhookService service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, webhookID, ok := webhookRequestIDs(w, r, true)
+		if !ok {
+			return
+		}
+
+		// Pagination
+		limit := int32(20)
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+			limit = int32(min(l, 100))
+		}
+		offset := int32(0)
+		if o, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && o >= 0 {
+			offset = int32(o)
+		}
+
+		deliveries, err := webhookService.ListDeliveries(r.Context(), groupID, webhookID, userID, limit, offset)
+		if err != nil {
+			sendWebhookError(w, err, "system.webhook.deliveries_failed", "Unable to load webhook deliveries.")
+			return
+		}
+
+		resp := make([]WebhookDeliveryResponse, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			resp = append(resp, webhookDeliveryToResponse(delivery))
+		}
+
+		response.SendSuccess(w, http.StatusOK, resp)
+	}
+}
+
+func RedeliverWebhookHandler(webhookService service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, groupID, webhookID, ok := webhookRequestIDs(w, r, true)
+		if !ok {
+			return
+		}
+
+		deliveryID, err := parseUUID(chi.URLParam(r, "delivery_id"))
+		if err != nil {
+			response.SendErrorWithCode(w, http.StatusBadRequest, "validation.webhook.delivery_id_invalid", "Invalid delivery ID.")
+			return
+		}
+
+		delivery, err := webhookService.Redeliver(r.Context(), groupID, webhookID, deliveryID, userID)
+		if err != nil {
+			sendWebhookError(w, err, "system.webhook.redeliver_failed", "Unable to redeliver webhook.")
+			return
+		}
+
+		response.SendSuccess(w, http.StatusAccepted, webhookDeliveryToResponse(delivery))
+	}
+}
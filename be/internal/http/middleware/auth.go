@@ -4,7 +4,9 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
 	"github.com/dhruvsaxena1998/splitplus/internal/repository"
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
@@ -13,6 +15,13 @@ import (
 
 type userIDKey struct{}
 type jtiKey struct{}
+type sessionIDKey struct{}
+type accessTokenKey struct{}
+type authExpiryKey struct{}
+
+// SessionOnly is the scope for routes personal access tokens can't use, such
+// as managing sessions, two-factor authentication and the tokens themselves.
+const SessionOnly = ""
 
 // SetUserID sets the authenticated user ID in the request context
 func SetUserID(ctx context.Context, userID pgtype.UUID) context.Context {
@@ -36,10 +45,102 @@ func GetJTI(r *http.Request) (string, bool) {
 	return jti, ok
 }
 
+// SetSessionID sets the session behind the access token in the request context
+func SetSessionID(ctx context.Context, sessionID pgtype.UUID) context.Context {
+	return context.WithValue(ctx, sessionIDKey{}, sessionID)
+}
+
+// GetSessionID retrieves the current session ID from the request context.
+// Tokens issued before sessions were tracked in claims have none.
+func GetSessionID(r *http.Request) (pgtype.UUID, bool) {
+	sessionID, ok := r.Context().Value(sessionIDKey{}).(pgtype.UUID)
+	return sessionID, ok
+}
+
+// SetAccessToken sets the personal access token the request presented in the request context
+func SetAccessToken(ctx context.Context, pat sqlc.PersonalAccessToken) context.Context {
+	return context.WithValue(ctx, accessTokenKey{}, pat)
+}
+
+// GetAccessToken retrieves the personal access token from the request context.
+// Requests signed in with a JWT have none.
+func GetAccessToken(r *http.Request) (sqlc.PersonalAccessToken, bool) {
+	pat, ok := r.Context().Value(accessTokenKey{}).(sqlc.PersonalAccessToken)
+	return pat, ok
+}
+
+// SetAuthExpiry sets when the credentials the request presented expire in the request context
+func SetAuthExpiry(ctx context.Context, expiresAt time.Time) context.Context {
+	return context.WithValue(ctx, authExpiryKey{}, expiresAt)
+}
+
+// GetAuthExpiry retrieves when the request's credentials expire from the
+// request context. Personal access tokens without an expiry have none.
+func GetAuthExpiry(r *http.Request) (time.Time, bool) {
+	expiresAt, ok := r.Context().Value(authExpiryKey{}).(time.Time)
+	return expiresAt, ok
+}
+
+// AuthenticateAccessToken verifies personal access tokens sent as Bearer
+// tokens and puts them in the request context for RequireAuth. Requests
+// without one pass through untouched, so it runs ahead of every route.
+func AuthenticateAccessToken(tokenService service.PersonalAccessTokenService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || !strings.HasPrefix(token, service.PersonalAccessTokenPrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			pat, err := tokenService.Authenticate(r.Context(), token)
+			if err != nil {
+				switch err {
+				case service.ErrExpiredToken:
+					response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.token.expired", "token has expired")
+				case service.ErrInvalidToken:
+					response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.token.invalid", "invalid token")
+				default:
+					response.SendErrorWithCode(w, http.StatusInternalServerError, "auth.internal.check_failed", "Authentication check failed.")
+				}
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(SetAccessToken(r.Context(), pat)))
+		})
+	}
+}
+
 // RequireAuth is a middleware that ensures the user is authenticated via JWT
-func RequireAuth(jwtService service.JWTService, sessionRepo repository.SessionRepository) func(http.Handler) http.Handler {
+// or a personal access token. scope is the token scope the route needs for
+// changes; reads need service.TokenScopeRead. SessionOnly turns tokens away.
+func RequireAuth(jwtService service.JWTService, sessionRepo repository.SessionRepository, scope string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Personal access tokens were verified by AuthenticateAccessToken
+			if pat, ok := GetAccessToken(r); ok {
+				if scope == SessionOnly {
+					response.SendErrorWithCode(w, http.StatusForbidden, "auth.token.session_required", "Personal access tokens can't be used here.")
+					return
+				}
+
+				required := scope
+				if r.Method == http.MethodGet || r.Method == http.MethodHead {
+					required = service.TokenScopeRead
+				}
+				if !service.TokenHasScope(pat, required) {
+					response.SendErrorWithCode(w, http.StatusForbidden, "auth.token.scope_insufficient", "Token is missing the "+required+" scope.")
+					return
+				}
+
+				ctx := SetUserID(r.Context(), pat.UserID)
+				if pat.ExpiresAt.Valid {
+					ctx = SetAuthExpiry(ctx, pat.ExpiresAt.Time)
+				}
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			// Extract token from Authorization header
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
@@ -95,16 +196,43 @@ func RequireAuth(jwtService service.JWTService, sessionRepo repository.SessionRe
 				return
 			}
 
-			// Set user ID and JTI in context
+			// Reject tokens whose session was revoked or logged out
+			var sessionID pgtype.UUID
+			if claims.SessionID != "" {
+				if err := sessionID.Scan(claims.SessionID); err != nil {
+					response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.token.invalid", "invalid token")
+					return
+				}
+
+				isActive, err := sessionRepo.IsSessionActive(r.Context(), sessionID)
+				if err != nil {
+					response.SendErrorWithCode(w, http.StatusInternalServerError, "auth.internal.check_failed", "Authentication check failed.")
+					return
+				}
+
+				if !isActive {
+					response.SendErrorWithCode(w, http.StatusUnauthorized, "auth.session.revoked", "Session has been revoked.")
+					return
+				}
+			}
+
+			// Set user ID, JTI and session in context
 			ctx := SetUserID(r.Context(), userID)
 			ctx = SetJTI(ctx, claims.ID)
+			if sessionID.Valid {
+				ctx = SetSessionID(ctx, sessionID)
+			}
+			if claims.ExpiresAt != nil {
+				ctx = SetAuthExpiry(ctx, claims.ExpiresAt.Time)
+			}
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
-// ParseAuth is a middleware that parses the authenticated user ID but doesn't require it
+// ParseAuth is a middleware that parses the authenticated user ID but doesn't require it.
+// Only JWTs are considered; personal access tokens count as signed out.
 func ParseAuth(jwtService service.JWTService, sessionRepo repository.SessionRepository) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -145,9 +273,26 @@ func ParseAuth(jwtService service.JWTService, sessionRepo repository.SessionRepo
 				return
 			}
 
-			// Set user ID and JTI in context
+			// Revoked session, but we don't error on optional auth
+			var sessionID pgtype.UUID
+			if claims.SessionID != "" {
+				if err := sessionID.Scan(claims.SessionID); err != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+				isActive, err := sessionRepo.IsSessionActive(r.Context(), sessionID)
+				if err != nil || !isActive {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			// Set user ID, JTI and session in context
 			ctx := SetUserID(r.Context(), userID)
 			ctx = SetJTI(ctx, claims.ID)
+			if sessionID.Valid {
+				ctx = SetSessionID(ctx, sessionID)
+			}
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+type staticAccessTokens map[string]sqlc.PersonalAccessToken
+
+func (s staticAccessTokens) CreateToken(ctx context.Context, input service.CreatePersonalAccessTokenInput) (string, sqlc.PersonalAccessToken, error) {
+	return "", sqlc.PersonalAccessToken{}, nil
+}
+
+func (s staticAccessTokens) ListTokens(ctx context.Context, userID pgtype.UUID) ([]sqlc.PersonalAccessToken, error) {
+	return nil, nil
+}
+
+func (s staticAccessTokens) RevokeToken(ctx context.Context, userID, tokenID pgtype.UUID) error {
+	return nil
+}
+
+func (s staticAccessTokens) Authenticate(ctx context.Context, token string) (sqlc.PersonalAccessToken, error) {
+	pat, ok := s[token]
+	if !ok {
+		return sqlc.PersonalAccessToken{}, service.ErrInvalidToken
+	}
+	return pat, nil
+}
+
+// accessTokenHandler chains the middleware the way the router does; JWTs never
+// reach the JWT service in these tests.
+func accessTokenHandler(tokens staticAccessTokens, scope string) http.Handler {
+	return AuthenticateAccessToken(tokens)(RequireAuth(nil, nil, scope)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := GetUserID(r); !ok {
+			w.WriteHeader(http.StatusTeapot)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})))
+}
+
+func TestRequireAuth_PersonalAccessTokenScopes(t *testing.T) {
+	userID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	tokens := staticAccessTokens{
+		"spp_reader": {UserID: userID, Scopes: []string{service.TokenScopeRead}},
+		"spp_writer": {UserID: userID, Scopes: []string{service.TokenScopeRead, service.TokenScopeExpensesWrite}},
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		method   string
+		scope    string
+		wantCode int
+		wantBody string
+	}{
+		{"read scope can read", "spp_reader", http.MethodGet, service.TokenScopeExpensesWrite, http.StatusOK, ""},
+		{"read scope can't write", "spp_reader", http.MethodPost, service.TokenScopeExpensesWrite, http.StatusForbidden, "auth.token.scope_insufficient"},
+		{"write scope can write", "spp_writer", http.MethodPost, service.TokenScopeExpensesWrite, http.StatusOK, ""},
+		{"write scope is per area", "spp_writer", http.MethodDelete, service.TokenScopeGroupsWrite, http.StatusForbidden, "auth.token.scope_insufficient"},
+		{"session only route", "spp_writer", http.MethodGet, SessionOnly, http.StatusForbidden, "auth.token.session_required"},
+		{"unknown token", "spp_unknown", http.MethodGet, service.TokenScopeRead, http.StatusUnauthorized, "auth.token.invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/groups/1/expenses", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rr := httptest.NewRecorder()
+
+			accessTokenHandler(tokens, tt.scope).ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rr.Code, rr.Body.String())
+			}
+			if tt.wantBody != "" && !strings.Contains(rr.Body.String(), tt.wantBody) {
+				t.Errorf("expected %q in %s", tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthenticateAccessToken_IgnoresOtherCredentials(t *testing.T) {
+	handler := AuthenticateAccessToken(staticAccessTokens{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := GetAccessToken(r); ok {
+			t.Error("expected no personal access token in context")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, header := range []string{"", "Bearer eyJhbGciOiJIUzI1NiJ9.e30.sig", "Basic spp_abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Errorf("%q: expected 200, got %d", header, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+// authRecheckInterval is how often EndWithAuth checks that long-lived
+// requests are still signed in.
+const authRecheckInterval = time.Minute
+
+// EndWithAuth ends long-lived requests, such as event streams, once the
+// credentials RequireAuth accepted stop being valid: the request context is
+// cancelled when they expire, and when a periodic check finds the session
+// revoked or logged out or the personal access token revoked. It runs after
+// RequireAuth.
+func EndWithAuth(sessionRepo repository.SessionRepository, tokenRepo repository.PersonalAccessTokenRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var ctx context.Context
+			var cancel context.CancelFunc
+			if expiresAt, ok := GetAuthExpiry(r); ok {
+				ctx, cancel = context.WithDeadline(r.Context(), expiresAt)
+			} else {
+				ctx, cancel = context.WithCancel(r.Context())
+			}
+			defer cancel()
+
+			go func() {
+				ticker := time.NewTicker(authRecheckInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						if !authStillValid(ctx, r, sessionRepo, tokenRepo) {
+							cancel()
+							return
+						}
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// authStillValid reports whether the credentials behind r haven't been
+// revoked since RequireAuth accepted them. Lookup failures count as valid, so
+// a database hiccup doesn't end every open request.
+func authStillValid(ctx context.Context, r *http.Request, sessionRepo repository.SessionRepository, tokenRepo repository.PersonalAccessTokenRepository) bool {
+	if pat, ok := GetAccessToken(r); ok {
+		_, err := tokenRepo.GetPersonalAccessTokenByHash(ctx, pat.TokenHash)
+		return !errors.Is(err, pgx.ErrNoRows)
+	}
+
+	if jti, ok := GetJTI(r); ok {
+		if blacklisted, err := sessionRepo.IsTokenBlacklisted(ctx, jti); err == nil && blacklisted {
+			return false
+		}
+	}
+	if sessionID, ok := GetSessionID(r); ok {
+		if active, err := sessionRepo.IsSessionActive(ctx, sessionID); err == nil && !active {
+			return false
+		}
+	}
+	return true
+}
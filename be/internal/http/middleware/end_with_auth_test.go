@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+type stubSessions struct {
+	repository.SessionRepository
+	blacklisted, active bool
+	err                 error
+}
+
+func (s stubSessions) IsTokenBlacklisted(ctx context.Context, jti string) (bool, error) {
+	return s.blacklisted, s.err
+}
+
+func (s stubSessions) IsSessionActive(ctx context.Context, sessionID pgtype.UUID) (bool, error) {
+	return s.active, s.err
+}
+
+type stubAccessTokens struct {
+	repository.PersonalAccessTokenRepository
+	err error
+}
+
+func (s stubAccessTokens) GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (sqlc.PersonalAccessToken, error) {
+	return sqlc.PersonalAccessToken{}, s.err
+}
+
+func TestEndWithAuth_EndsWhenCredentialsExpire(t *testing.T) {
+	ended := make(chan struct{})
+	handler := EndWithAuth(stubSessions{active: true}, stubAccessTokens{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			close(ended)
+		case <-time.After(time.Second):
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/me/events", nil)
+	req = req.WithContext(SetAuthExpiry(req.Context(), time.Now().Add(20*time.Millisecond)))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	select {
+	case <-ended:
+	default:
+		t.Error("expected the request to end when its credentials expired")
+	}
+}
+
+func TestAuthStillValid(t *testing.T) {
+	sessionID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+
+	tests := []struct {
+		name     string
+		pat      bool
+		sessions stubSessions
+		tokens   stubAccessTokens
+		want     bool
+	}{
+		{name: "active session", sessions: stubSessions{active: true}, want: true},
+		{name: "revoked session", sessions: stubSessions{active: false}, want: false},
+		{name: "blacklisted token", sessions: stubSessions{active: true, blacklisted: true}, want: false},
+		{name: "session lookup failed", sessions: stubSessions{err: errors.New("connection lost")}, want: true},
+		{name: "personal access token", pat: true, want: true},
+		{name: "revoked personal access token", pat: true, tokens: stubAccessTokens{err: pgx.ErrNoRows}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.pat {
+				ctx = SetAccessToken(ctx, sqlc.PersonalAccessToken{TokenHash: "hash"})
+			} else {
+				ctx = SetSessionID(SetJTI(ctx, "jti"), sessionID)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/users/me/events", nil).WithContext(ctx)
+
+			if got := authStillValid(ctx, req, tt.sessions, tt.tokens); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/response"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+// RateLimitStore counts requests per key in fixed windows. Use the in-memory
+// store for a single API instance and the Postgres store when several replicas
+// need to share their counts.
+type RateLimitStore interface {
+	// Increment counts one request for key and returns the number of requests
+	// in the current window, including this one, and when the window ends.
+	Increment(ctx context.Context, key string, window time.Duration) (count int, resetAt time.Time, err error)
+}
+
+// RateLimitKeyFunc picks who a request is counted against. An empty key
+// exempts the request from the policy.
+type RateLimitKeyFunc func(r *http.Request) string
+
+// RateLimitPolicy allows Limit requests per Window for each key. Name keeps the
+// counters of different routes apart.
+type RateLimitPolicy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	Key    RateLimitKeyFunc
+}
+
+// RateLimitByIP counts requests per client IP. It relies on RealIP to have
+// replaced RemoteAddr with the client address when the API is behind proxies.
+func RateLimitByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "ip:" + r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// RateLimitByUser counts requests per authenticated user. Anonymous requests
+// are not counted, so pair it with RateLimitByIP on routes with optional auth.
+func RateLimitByUser(r *http.Request) string {
+	userID, ok := GetUserID(r)
+	if !ok || !userID.Valid {
+		return ""
+	}
+	return "user:" + userID.String()
+}
+
+// RateLimitByBody counts requests per value of a field in the validated body,
+// like the address a reset link is mailed to. It only sees the body inside
+// ValidateBody; requests without it or with an empty value are not counted.
+func RateLimitByBody[T any](name string, field func(T) string) RateLimitKeyFunc {
+	return func(r *http.Request) string {
+		body, ok := GetBody[T](r)
+		if !ok {
+			return ""
+		}
+		value := strings.ToLower(strings.TrimSpace(field(body)))
+		if value == "" {
+			return ""
+		}
+		return name + ":" + value
+	}
+}
+
+// RateLimit rejects requests over any of the policies with 429 and a
+// Retry-After header. If the store fails the request is let through: an outage
+// of the counter store shouldn't take sign-in down with it.
+func RateLimit(store RateLimitStore, policies ...RateLimitPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, policy := range policies {
+				key := policy.Key(r)
+				if key == "" {
+					continue
+				}
+
+				count, resetAt, err := store.Increment(r.Context(), policy.Name+":"+key, policy.Window)
+				if err != nil {
+					log.Printf("rate limit %s: %v", policy.Name, err)
+					continue
+				}
+
+				if count > policy.Limit {
+					w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(resetAt)))
+					response.SendErrorWithCode(w, http.StatusTooManyRequests, "rate_limit.exceeded", "Too many requests. Please try again later.")
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func retryAfterSeconds(resetAt time.Time) int {
+	seconds := int(math.Ceil(time.Until(resetAt).Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+type rateLimitWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+type memoryRateLimitStore struct {
+	mu        sync.Mutex
+	windows   map[string]rateLimitWindow
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRateLimitStore keeps counters in process memory. Counts are lost on
+// restart and not shared between replicas.
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{
+		windows: make(map[string]rateLimitWindow),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryRateLimitStore) Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	entry, ok := s.windows[key]
+	if !ok || !now.Before(entry.resetAt) {
+		entry = rateLimitWindow{resetAt: now.Add(window)}
+	}
+	entry.count++
+	s.windows[key] = entry
+
+	return entry.count, entry.resetAt, nil
+}
+
+// sweep drops ended windows once a minute so keys seen only once don't pile up.
+func (s *memoryRateLimitStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	for key, entry := range s.windows {
+		if !now.Before(entry.resetAt) {
+			delete(s.windows, key)
+		}
+	}
+	s.nextSweep = now.Add(time.Minute)
+}
+
+type postgresRateLimitStore struct {
+	repo repository.RateLimitRepository
+}
+
+// NewPostgresRateLimitStore keeps counters in the rate_limit_counters table so
+// every replica enforces the same limits.
+func NewPostgresRateLimitStore(repo repository.RateLimitRepository) RateLimitStore {
+	return &postgresRateLimitStore{repo: repo}
+}
+
+func (s *postgresRateLimitStore) Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	row, err := s.repo.IncrementRateLimitCounter(ctx, sqlc.IncrementRateLimitCounterParams{
+		Key:          key,
+		WindowEndsAt: pgtype.Timestamptz{Time: time.Now().Add(window), Valid: true},
+	})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return int(row.Count), row.WindowEndsAt.Time, nil
+}
@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	return 0, time.Time{}, errors.New("store unavailable")
+}
+
+func rateLimitedHandler(store RateLimitStore, policies ...RateLimitPolicy) http.Handler {
+	return RateLimit(store, policies...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func requestFrom(remoteAddr string) *http.Request {
+	req := httptest.NewRequest("POST", "/auth/login", nil)
+	req.RemoteAddr = remoteAddr
+	return req
+}
+
+func TestRateLimit_ByIP(t *testing.T) {
+	handler := rateLimitedHandler(NewMemoryRateLimitStore(), RateLimitPolicy{
+		Name: "test", Limit: 2, Window: time.Minute, Key: RateLimitByIP,
+	})
+
+	for i := 1; i <= 2; i++ {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, requestFrom("10.0.0.1:1234"))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, rr.Code)
+		}
+	}
+
+	// Same IP from another port is the same client
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestFrom("10.0.0.1:5678"))
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rr.Code)
+	}
+	retryAfter, err := strconv.Atoi(rr.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 || retryAfter > 60 {
+		t.Errorf("expected Retry-After between 1 and 60 seconds, got %q", rr.Header().Get("Retry-After"))
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestFrom("10.0.0.2:1234"))
+	if rr.Code != http.StatusOK {
+		t.Errorf("other IP: expected 200, got %d", rr.Code)
+	}
+}
+
+func TestRateLimit_ByUserSkipsAnonymous(t *testing.T) {
+	handler := rateLimitedHandler(NewMemoryRateLimitStore(), RateLimitPolicy{
+		Name: "test", Limit: 1, Window: time.Minute, Key: RateLimitByUser,
+	})
+
+	for i := 0; i < 3; i++ {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, requestFrom("10.0.0.1:1234"))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("anonymous request %d: expected 200, got %d", i, rr.Code)
+		}
+	}
+
+	userRequest := func() *http.Request {
+		req := requestFrom("10.0.0.1:1234")
+		return req.WithContext(SetUserID(req.Context(), testutil.CreateTestUUID(1)))
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, userRequest())
+	if rr.Code != http.StatusOK {
+		t.Fatalf("first user request: expected 200, got %d", rr.Code)
+	}
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, userRequest())
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("second user request: expected 429, got %d", rr.Code)
+	}
+}
+
+func TestRateLimit_ByBody(t *testing.T) {
+	type forgotRequest struct{ Email string }
+	handler := rateLimitedHandler(NewMemoryRateLimitStore(), RateLimitPolicy{
+		Name: "test", Limit: 1, Window: time.Minute,
+		Key: RateLimitByBody("email", func(body forgotRequest) string { return body.Email }),
+	})
+	requestFor := func(remoteAddr, email string) *http.Request {
+		req := requestFrom(remoteAddr)
+		return req.WithContext(context.WithValue(req.Context(), ctxKey[forgotRequest]{}, forgotRequest{Email: email}))
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestFor("10.0.0.1:1234", "ana@example.com"))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("first request: expected 200, got %d", rr.Code)
+	}
+
+	// Another IP asking for the same address, spelled differently, is counted together
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestFor("10.0.0.2:1234", " ANA@example.com"))
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("same address: expected 429, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestFor("10.0.0.1:1234", "bo@example.com"))
+	if rr.Code != http.StatusOK {
+		t.Errorf("other address: expected 200, got %d", rr.Code)
+	}
+
+	// Without a validated body there is nothing to count
+	for i := 0; i < 2; i++ {
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, requestFrom("10.0.0.1:1234"))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("request without body %d: expected 200, got %d", i, rr.Code)
+		}
+	}
+}
+
+func TestRateLimit_PoliciesAreSeparate(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	login := rateLimitedHandler(store, RateLimitPolicy{Name: "login", Limit: 1, Window: time.Minute, Key: RateLimitByIP})
+	signup := rateLimitedHandler(store, RateLimitPolicy{Name: "signup", Limit: 1, Window: time.Minute, Key: RateLimitByIP})
+
+	rr := httptest.NewRecorder()
+	login.ServeHTTP(rr, requestFrom("10.0.0.1:1234"))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("login: expected 200, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	signup.ServeHTTP(rr, requestFrom("10.0.0.1:1234"))
+	if rr.Code != http.StatusOK {
+		t.Errorf("signup: expected 200, got %d", rr.Code)
+	}
+}
+
+func TestRateLimit_StoreFailureLetsRequestsThrough(t *testing.T) {
+	handler := rateLimitedHandler(failingRateLimitStore{}, RateLimitPolicy{
+		Name: "test", Limit: 1, Window: time.Minute, Key: RateLimitByIP,
+	})
+
+	for i := 0; i < 3; i++ {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, requestFrom("10.0.0.1:1234"))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, rr.Code)
+		}
+	}
+}
+
+func TestMemoryRateLimitStore_WindowResets(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := &memoryRateLimitStore{
+		windows: make(map[string]rateLimitWindow),
+		now:     func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		count, resetAt, _ := store.Increment(ctx, "key", time.Minute)
+		if count != i {
+			t.Errorf("expected count %d, got %d", i, count)
+		}
+		if !resetAt.Equal(now.Add(time.Minute)) {
+			t.Errorf("expected window to end at %v, got %v", now.Add(time.Minute), resetAt)
+		}
+	}
+
+	now = now.Add(time.Minute)
+	count, _, _ := store.Increment(ctx, "key", time.Minute)
+	if count != 1 {
+		t.Errorf("expected a new window to start at 1, got %d", count)
+	}
+
+	now = now.Add(2 * time.Minute)
+	store.Increment(ctx, "other", time.Minute)
+	if _, ok := store.windows["key"]; ok {
+		t.Error("expected ended window to be swept")
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RealIP sets RemoteAddr to the client address, for sessions and
+// RateLimitByIP. trustedProxyHops is the number of reverse proxies in front of
+// the API that append to X-Forwarded-For. With none, the socket address is kept
+// and forwarding headers are ignored. Otherwise the client is the entry the
+// outermost trusted proxy appended; anything left of it was sent by the client
+// and can't be trusted.
+func RealIP(trustedProxyHops int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip := forwardedClientIP(r.Header.Values("X-Forwarded-For"), trustedProxyHops); ip != "" {
+				r.RemoteAddr = ip
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedClientIP returns the X-Forwarded-For entry trustedProxyHops from the
+// right, or "" when there aren't that many valid entries.
+func forwardedClientIP(headers []string, trustedProxyHops int) string {
+	if trustedProxyHops <= 0 {
+		return ""
+	}
+
+	var hops []string
+	for _, header := range headers {
+		for _, hop := range strings.Split(header, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	if len(hops) < trustedProxyHops {
+		return ""
+	}
+
+	ip := net.ParseIP(hops[len(hops)-trustedProxyHops])
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRealIP(t *testing.T) {
+	tests := []struct {
+		name      string
+		hops      int
+		forwarded []string
+		realIP    string
+		want      string
+	}{
+		{name: "no proxy ignores forwarding headers", forwarded: []string{"1.1.1.1"}, realIP: "2.2.2.2", want: "10.0.0.1:1234"},
+		{name: "one proxy takes the entry it appended", hops: 1, forwarded: []string{"6.6.6.6, 203.0.113.7"}, want: "203.0.113.7"},
+		{name: "two proxies", hops: 2, forwarded: []string{"6.6.6.6, 203.0.113.7", "10.0.0.2"}, want: "203.0.113.7"},
+		{name: "fewer entries than proxies keeps the socket address", hops: 2, forwarded: []string{"203.0.113.7"}, want: "10.0.0.1:1234"},
+		{name: "invalid entry keeps the socket address", hops: 1, forwarded: []string{"not-an-ip"}, want: "10.0.0.1:1234"},
+		{name: "missing header keeps the socket address", hops: 1, want: "10.0.0.1:1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/auth/login", nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+			for _, value := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			var got string
+			RealIP(tt.hops)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			})).ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
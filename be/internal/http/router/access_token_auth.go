@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+// WithAccessTokenAuth lets RequireAuth accept personal access tokens. Like any
+// middleware it has to be applied before the options that add routes.
+func WithAccessTokenAuth(tokenService service.PersonalAccessTokenService) Option {
+	return optionFunc(func(r chi.Router) {
+		r.Use(middleware.AuthenticateAccessToken(tokenService))
+	})
+}
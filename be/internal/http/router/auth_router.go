@@ -12,20 +12,32 @@ import (
 
 func WithAuthRoutes(
 	authService service.AuthService,
+	accountService service.AccountService,
+	oidcService service.OIDCService,
 	jwtService service.JWTService,
 	sessionRepo repository.SessionRepository,
+	rateLimitStore middleware.RateLimitStore,
 ) Option {
 	return optionFunc(func(r chi.Router) {
 		v := validator.New()
 
 		r.Route("/auth", func(r chi.Router) {
 			// Public routes
-			r.Post("/login", middleware.ValidateBodyWithScope[handlers.LoginRequest](v, "auth")(handlers.LoginHandler(authService)).ServeHTTP)
+			r.With(middleware.RateLimit(rateLimitStore, loginRateLimits...)).Post("/login", middleware.ValidateBodyWithScope[handlers.LoginRequest](v, "auth")(handlers.LoginHandler(authService)).ServeHTTP)
+			r.With(middleware.RateLimit(rateLimitStore, twoFactorRateLimits...)).Post("/two-factor/verify", middleware.ValidateBodyWithScope[handlers.TwoFactorLoginRequest](v, "auth")(handlers.TwoFactorLoginHandler(authService)).ServeHTTP)
 			r.Post("/refresh", middleware.ValidateBodyWithScope[handlers.RefreshTokenRequest](v, "auth")(handlers.RefreshTokenHandler(authService)).ServeHTTP)
+			r.With(middleware.RateLimit(rateLimitStore, forgotPasswordRateLimits...)).Post("/password/forgot", middleware.ValidateBodyWithScope[handlers.ForgotPasswordRequest](v, "auth")(middleware.RateLimit(rateLimitStore, forgotPasswordEmailRateLimits...)(handlers.ForgotPasswordHandler(accountService))).ServeHTTP)
+			r.Post("/password/reset", middleware.ValidateBodyWithScope[handlers.ResetPasswordRequest](v, "auth")(handlers.ResetPasswordHandler(accountService)).ServeHTTP)
+			r.With(middleware.RateLimit(rateLimitStore, verifyEmailRateLimits...)).Post("/email/verify", middleware.ValidateBodyWithScope[handlers.VerifyEmailRequest](v, "auth")(handlers.VerifyEmailHandler(accountService)).ServeHTTP)
+
+			// OpenID Connect sign-in
+			r.Get("/oidc/providers", handlers.ListOIDCProvidersHandler(oidcService))
+			r.Get("/oidc/{provider}/authorize", handlers.StartOIDCLoginHandler(oidcService))
+			r.With(middleware.RateLimit(rateLimitStore, oidcCallbackRateLimits...)).Post("/oidc/{provider}/callback", middleware.ValidateBodyWithScope[handlers.OIDCCallbackRequest](v, "auth")(handlers.OIDCCallbackHandler(oidcService)).ServeHTTP)
 
 			// Protected routes
 			r.Group(func(r chi.Router) {
-				r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+				r.Use(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly))
 				r.Post("/logout", middleware.ValidateBodyWithScope[handlers.LogoutRequest](v, "auth")(handlers.LogoutHandler(authService, jwtService)).ServeHTTP)
 				r.Post("/logout-all", handlers.LogoutAllHandler(authService))
 			})
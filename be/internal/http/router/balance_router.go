@@ -2,6 +2,7 @@ package router
 
 import (
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/http/handlers"
 	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
@@ -9,11 +10,13 @@ import (
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
 )
 
-func WithBalanceRoutes(balanceService service.BalanceService, jwtService service.JWTService, sessionRepo repository.SessionRepository) Option {
+func WithBalanceRoutes(balanceService service.BalanceService, paymentMethodService service.PaymentMethodService, reminderService service.DebtReminderService, jwtService service.JWTService, sessionRepo repository.SessionRepository, rateLimitStore middleware.RateLimitStore) Option {
 	return optionFunc(func(r chi.Router) {
+		v := validator.New()
+
 		// All balance routes require authentication
 		r.Route("/groups/{group_id}/balances", func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeRead))
 
 			// GET /groups/{group_id}/balances - List all balances in group
 			r.Get("/", handlers.ListGroupBalancesHandler(balanceService))
@@ -24,13 +27,19 @@ func WithBalanceRoutes(balanceService service.BalanceService, jwtService service
 
 		// GET /groups/{group_id}/debts - Get simplified "who owes whom" view
 		r.Route("/groups/{group_id}/debts", func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeSettlementsWrite))
 			r.Get("/", handlers.GetSimplifiedDebtsHandler(balanceService))
+
+			// POST /groups/{group_id}/debts/pay - Record a payment link as a pending settlement
+			r.Post("/pay", middleware.ValidateBody[handlers.PayDebtRequest](v)(handlers.PayDebtHandler(paymentMethodService)).ServeHTTP)
+
+			// POST /groups/{group_id}/debts/remind - Email a member a reminder of what they owe the requester
+			r.With(middleware.RateLimit(rateLimitStore, remindRateLimits...)).Post("/remind", middleware.ValidateBody[handlers.RemindDebtorRequest](v)(handlers.RemindDebtorHandler(reminderService)).ServeHTTP)
 		})
 
 		// GET /users/me/balances - Get user's balances across all groups
 		r.Route("/users/me/balances", func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeRead))
 			r.Get("/", handlers.GetOverallUserBalanceHandler(balanceService))
 		})
 	})
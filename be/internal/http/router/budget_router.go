@@ -0,0 +1,44 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/handlers"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+func WithBudgetRoutes(budgetService service.BudgetService, jwtService service.JWTService, sessionRepo repository.SessionRepository) Option {
+	return optionFunc(func(r chi.Router) {
+		v := validator.New()
+
+		r.Route("/groups/{group_id}/budgets", func(r chi.Router) {
+			// Any member can see budgets and how much of them is spent
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeRead))
+
+				// GET / - List group budgets
+				r.Get("/", handlers.ListBudgetsHandler(budgetService))
+
+				// GET /status - Spent and remaining per budget for the current period
+				r.Get("/status", handlers.GetBudgetStatusHandler(budgetService))
+			})
+
+			// Owners and admins manage them
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeGroupsWrite))
+
+				// POST / - Create budget
+				r.Post("/", middleware.ValidateBodyWithScope[handlers.CreateBudgetRequest](v, "budget")(handlers.CreateBudgetHandler(budgetService)).ServeHTTP)
+
+				// PATCH /{budget_id} - Update budget
+				r.Patch("/{budget_id}", middleware.ValidateBodyWithScope[handlers.UpdateBudgetRequest](v, "budget")(handlers.UpdateBudgetHandler(budgetService)).ServeHTTP)
+
+				// DELETE /{budget_id} - Delete budget
+				r.Delete("/{budget_id}", handlers.DeleteBudgetHandler(budgetService))
+			})
+		})
+	})
+}
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/handlers"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+func WithEventStreamRoutes(eventStreamService service.EventStreamService, jwtService service.JWTService, sessionRepo repository.SessionRepository, tokenRepo repository.PersonalAccessTokenRepository) Option {
+	return optionFunc(func(r chi.Router) {
+		// Streams end when the credentials they were opened with stop being valid
+		auth := []func(http.Handler) http.Handler{
+			middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeRead),
+			middleware.EndWithAuth(sessionRepo, tokenRepo),
+		}
+
+		// GET /groups/{group_id}/events - Stream a group's events (Server-Sent Events)
+		r.With(auth...).Get("/groups/{group_id}/events", handlers.GroupEventStreamHandler(eventStreamService))
+
+		// GET /users/me/events - Stream events of every group the user is in
+		r.With(auth...).Get("/users/me/events", handlers.UserEventStreamHandler(eventStreamService))
+	})
+}
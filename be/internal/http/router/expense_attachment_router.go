@@ -0,0 +1,32 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/handlers"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+func WithExpenseAttachmentRoutes(attachmentService service.ExpenseAttachmentService, jwtService service.JWTService, sessionRepo repository.SessionRepository) Option {
+	return optionFunc(func(r chi.Router) {
+		r.Route("/groups/{group_id}/expenses/{expense_id}/attachments", func(r chi.Router) {
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeExpensesWrite))
+
+			// GET / - List attachments
+			r.Get("/", handlers.ListAttachmentsHandler(attachmentService))
+
+			// POST / - Upload attachment (multipart/form-data, field "file")
+			r.Post("/", handlers.UploadAttachmentHandler(attachmentService))
+
+			r.Route("/{attachment_id}", func(r chi.Router) {
+				// GET / - Download attachment
+				r.Get("/", handlers.DownloadAttachmentHandler(attachmentService))
+
+				// DELETE / - Delete attachment
+				r.Delete("/", handlers.DeleteAttachmentHandler(attachmentService))
+			})
+		})
+	})
+}
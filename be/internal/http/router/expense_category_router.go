@@ -18,7 +18,7 @@ func WithExpenseCategoryRoutes(categoryService service.ExpenseCategoryService, j
 
 		// Group category routes - require authentication
 		r.Route("/groups/{group_id}/categories", func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeExpensesWrite))
 
 			r.Get("/", handlers.ListGroupCategoriesHandler(categoryService))
 			r.Post("/", middleware.ValidateBody[handlers.CreateCategoryRequest](v)(handlers.CreateGroupCategoryHandler(categoryService)).ServeHTTP)
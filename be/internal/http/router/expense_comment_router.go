@@ -16,7 +16,7 @@ func WithExpenseCommentRoutes(commentService service.ExpenseCommentService, jwtS
 
 		// Routes for accessing comments via group->expense hierarchy
 		r.Route("/groups/{group_id}/expenses/{expense_id}/comments", func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeExpensesWrite))
 
 			// GET / - List comments
 			r.Get("/", handlers.ListCommentsHandler(commentService))
@@ -16,7 +16,7 @@ func WithExpenseRoutes(expenseService service.ExpenseService, jwtService service
 
 		// All expense routes require authentication
 		r.Route("/groups/{group_id}/expenses", func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeExpensesWrite))
 
 			// GET /groups/{group_id}/expenses - List all expenses for a group
 			r.Get("/", handlers.ListExpensesHandler(expenseService))
@@ -31,6 +31,9 @@ func WithExpenseRoutes(expenseService service.ExpenseService, jwtService service
 			// GET /groups/{group_id}/expenses/search - Search expenses
 			r.Get("/search", handlers.SearchExpensesHandler(expenseService))
 
+			// POST /groups/{group_id}/expenses/import - Bulk import expenses from CSV
+			r.Post("/import", handlers.ImportExpensesHandler(expenseService))
+
 			// Expense-specific routes with {expense_id}
 			r.Route("/{expense_id}", func(r chi.Router) {
 				// GET /groups/{group_id}/expenses/{expense_id} - Get expense by ID
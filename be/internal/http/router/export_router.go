@@ -0,0 +1,21 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/handlers"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+func WithExportRoutes(exportService service.ExportService, jwtService service.JWTService, sessionRepo repository.SessionRepository) Option {
+	return optionFunc(func(r chi.Router) {
+		r.Route("/groups/{group_id}/export", func(r chi.Router) {
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeRead))
+
+			// GET /groups/{group_id}/export?format=csv|json - Stream the group's ledger
+			r.Get("/", handlers.ExportGroupHandler(exportService))
+		})
+	})
+}
@@ -14,42 +14,64 @@ func WithFriendRoutes(
 	friendService service.FriendService,
 	friendExpenseService service.FriendExpenseService,
 	friendSettlementService service.FriendSettlementService,
+	balanceService service.BalanceService,
 	jwtService service.JWTService,
 	sessionRepo repository.SessionRepository,
 ) Option {
 	return optionFunc(func(r chi.Router) {
 		v := validator.New()
 
-		r.Group(func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+		r.Route("/friends", func(r chi.Router) {
+			// Friend requests / contacts
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeFriendsWrite))
 
-			r.Route("/friends", func(r chi.Router) {
-				// Friend requests / contacts
 				r.Post("/requests", middleware.ValidateBody[handlers.SendFriendRequestRequest](v)(handlers.SendFriendRequestHandler(friendService)).ServeHTTP)
-				r.Get("/", handlers.ListFriendsHandler(friendService))
+				r.Get("/", handlers.ListFriendsHandler(friendService, balanceService))
 				r.Get("/requests/incoming", handlers.ListIncomingFriendRequestsHandler(friendService))
 				r.Get("/requests/outgoing", handlers.ListOutgoingFriendRequestsHandler(friendService))
 				r.Post("/requests/{id}/accept", handlers.AcceptFriendRequestHandler(friendService))
 				r.Post("/requests/{id}/decline", handlers.DeclineFriendRequestHandler(friendService))
 				r.Delete("/{friend_id}", handlers.RemoveFriendHandler(friendService))
 
-				// Friend expenses
-				r.Route("/{friend_id}", func(r chi.Router) {
-					r.Post("/expenses",
-						middleware.ValidateBody[handlers.CreateExpenseRequest](v)(
-							handlers.CreateFriendExpenseHandler(friendExpenseService),
-						).ServeHTTP,
-					)
-					r.Get("/expenses", handlers.ListFriendExpensesHandler(friendExpenseService))
-
-					// Friend settlements
-					r.Post("/settlements",
-						middleware.ValidateBody[handlers.CreateFriendSettlementRequest](v)(
-							handlers.CreateFriendSettlementHandler(friendSettlementService),
-						).ServeHTTP,
-					)
-					r.Get("/settlements", handlers.ListFriendSettlementsHandler(friendSettlementService))
-				})
+				// Net balance across direct expenses and shared groups
+				r.Get("/{friend_id}/balance", handlers.GetFriendBalanceHandler(balanceService))
+			})
+
+			// Friend expenses
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeExpensesWrite))
+
+				r.Post("/{friend_id}/expenses",
+					middleware.ValidateBody[handlers.CreateExpenseRequest](v)(
+						handlers.CreateFriendExpenseHandler(friendExpenseService),
+					).ServeHTTP,
+				)
+				r.Get("/{friend_id}/expenses", handlers.ListFriendExpensesHandler(friendExpenseService))
+				r.Put("/{friend_id}/expenses/{expense_id}",
+					middleware.ValidateBody[handlers.UpdateExpenseRequest](v)(
+						handlers.UpdateFriendExpenseHandler(friendExpenseService),
+					).ServeHTTP,
+				)
+				r.Delete("/{friend_id}/expenses/{expense_id}", handlers.DeleteFriendExpenseHandler(friendExpenseService))
+			})
+
+			// Friend settlements
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeSettlementsWrite))
+
+				r.Post("/{friend_id}/settlements",
+					middleware.ValidateBody[handlers.CreateFriendSettlementRequest](v)(
+						handlers.CreateFriendSettlementHandler(friendSettlementService),
+					).ServeHTTP,
+				)
+				r.Get("/{friend_id}/settlements", handlers.ListFriendSettlementsHandler(friendSettlementService))
+				r.Put("/{friend_id}/settlements/{settlement_id}",
+					middleware.ValidateBody[handlers.UpdateFriendSettlementRequest](v)(
+						handlers.UpdateFriendSettlementHandler(friendSettlementService),
+					).ServeHTTP,
+				)
+				r.Delete("/{friend_id}/settlements/{settlement_id}", handlers.DeleteFriendSettlementHandler(friendSettlementService))
 			})
 		})
 	})
@@ -12,14 +12,14 @@ import (
 func WithGroupActivityRoutes(activityService service.GroupActivityService, jwtService service.JWTService, sessionRepo repository.SessionRepository) Option {
 	return optionFunc(func(r chi.Router) {
 		r.Route("/groups/{group_id}/activity", func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeRead))
 
 			// GET / - List group activities
 			r.Get("/", handlers.ListGroupActivitiesHandler(activityService))
 		})
 
 		r.Route("/groups/{group_id}/expenses/{expense_id}/history", func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeRead))
 
 			// GET / - Get expense history
 			r.Get("/", handlers.GetExpenseHistoryHandler(activityService))
@@ -10,13 +10,13 @@ import (
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
 )
 
-func WithGroupRoutes(groupService service.GroupService, invitationService service.GroupInvitationService, jwtService service.JWTService, sessionRepo repository.SessionRepository) Option {
+func WithGroupRoutes(groupService service.GroupService, invitationService service.GroupInvitationService, jwtService service.JWTService, sessionRepo repository.SessionRepository, rateLimitStore middleware.RateLimitStore) Option {
 	return optionFunc(func(r chi.Router) {
 		v := validator.New()
 
 		r.Route("/groups", func(r chi.Router) {
 			// All group routes require authentication
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeGroupsWrite))
 
 			// GET /groups - List all groups for the authenticated user
 			r.Get("/", handlers.ListUserGroupsHandler(groupService))
@@ -30,6 +30,22 @@ func WithGroupRoutes(groupService service.GroupService, invitationService servic
 
 			// Group-specific routes with {group_id}
 			r.Route("/{group_id}", func(r chi.Router) {
+				// PATCH /groups/{group_id} - Update group details (owner/admin)
+				r.Patch("/",
+					middleware.ValidateBodyWithScope[handlers.UpdateGroupRequest](v, "group")(
+						handlers.UpdateGroupHandler(groupService),
+					).ServeHTTP,
+				)
+
+				// DELETE /groups/{group_id}?force=true - Soft-delete group (owner)
+				r.Delete("/", handlers.DeleteGroupHandler(groupService))
+
+				// POST /groups/{group_id}/archive - Archive group (owner/admin)
+				r.Post("/archive", handlers.ArchiveGroupHandler(groupService))
+
+				// POST /groups/{group_id}/restore - Restore archived group (owner/admin)
+				r.Post("/restore", handlers.RestoreGroupHandler(groupService))
+
 				// POST /groups/{group_id}/invitations - Invite user (email-based)
 				r.Post("/invitations",
 					middleware.ValidateBodyWithScope[handlers.CreateInvitationRequest](v, "invitation")(
@@ -37,8 +53,50 @@ func WithGroupRoutes(groupService service.GroupService, invitationService servic
 					).ServeHTTP,
 				)
 
+				// GET /groups/{group_id}/invitations - List invitations (owner/admin)
+				r.Get("/invitations", handlers.ListGroupInvitationsHandler(invitationService))
+
+				// POST /groups/{group_id}/invitations/{invitation_id}/resend - Re-issue with a new expiry (owner/admin)
+				r.Post("/invitations/{invitation_id}/resend", handlers.ResendInvitationHandler(invitationService))
+
+				// DELETE /groups/{group_id}/invitations/{invitation_id} - Revoke pending invitation (owner/admin)
+				r.Delete("/invitations/{invitation_id}", handlers.RevokeInvitationHandler(invitationService))
+
+				// GET /groups/{group_id}/join-links - List shareable join links (owner/admin)
+				r.Get("/join-links", handlers.ListJoinLinksHandler(invitationService))
+
+				// POST /groups/{group_id}/join-links - Create shareable join link (owner/admin)
+				r.Post("/join-links",
+					middleware.ValidateBodyWithScope[handlers.CreateJoinLinkRequest](v, "join_link")(
+						handlers.CreateJoinLinkHandler(invitationService),
+					).ServeHTTP,
+				)
+
+				// DELETE /groups/{group_id}/join-links/{link_id} - Revoke join link (owner/admin)
+				r.Delete("/join-links/{link_id}", handlers.RevokeJoinLinkHandler(invitationService))
+
 				// GET /groups/{group_id}/members - List group members
 				r.Get("/members", handlers.ListGroupMembersHandler(groupService))
+
+				// PATCH /groups/{group_id}/members/{user_id}/role - Promote/demote admin (owner)
+				r.Patch("/members/{user_id}/role",
+					middleware.ValidateBodyWithScope[handlers.UpdateMemberRoleRequest](v, "member")(
+						handlers.UpdateMemberRoleHandler(groupService),
+					).ServeHTTP,
+				)
+
+				// DELETE /groups/{group_id}/members/{user_id}?force=true - Remove member (owner/admin)
+				r.Delete("/members/{user_id}", handlers.RemoveMemberHandler(groupService))
+
+				// POST /groups/{group_id}/transfer-ownership - Hand ownership to another member (owner)
+				r.Post("/transfer-ownership",
+					middleware.ValidateBodyWithScope[handlers.TransferOwnershipRequest](v, "member")(
+						handlers.TransferOwnershipHandler(groupService),
+					).ServeHTTP,
+				)
+
+				// POST /groups/{group_id}/leave?force=true - Leave group
+				r.Post("/leave", handlers.LeaveGroupHandler(groupService))
 			})
 		})
 
@@ -48,12 +106,12 @@ func WithGroupRoutes(groupService service.GroupService, invitationService servic
 			r.Get("/{token}", handlers.GetInvitationHandler(invitationService))
 
 			// POST /invitations/{token}/accept - Accept invitation (Authenticated)
-			r.With(middleware.RequireAuth(jwtService, sessionRepo)).Post("/{token}/accept",
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeGroupsWrite)).Post("/{token}/accept",
 				handlers.AcceptInvitationHandler(invitationService),
 			)
 
-			// POST /invitations/{token}/join - Smart Join (Public, handles auth/registration internally)
-			r.With(middleware.ParseAuth(jwtService, sessionRepo)).Post("/{token}/join", handlers.JoinGroupHandler(invitationService))
+			// POST /invitations/{token}/join - Smart Join for invitations and join links (Public, handles auth/registration internally)
+			r.With(middleware.ParseAuth(jwtService, sessionRepo), middleware.RateLimit(rateLimitStore, joinRateLimits...)).Post("/{token}/join", handlers.JoinGroupHandler(invitationService))
 		})
 	})
 }
@@ -0,0 +1,76 @@
+package router
+
+import (
+	"time"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/handlers"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+)
+
+// Limits for the public routes that take a password or create accounts, and
+// for routes that email other people. Login failures per account are also
+// limited by the lockout in UserService.
+var (
+	loginRateLimits = []middleware.RateLimitPolicy{
+		{Name: "auth.login", Limit: 10, Window: time.Minute, Key: middleware.RateLimitByIP},
+		{Name: "auth.login.hourly", Limit: 100, Window: time.Hour, Key: middleware.RateLimitByIP},
+	}
+
+	// Two-factor codes and OIDC callbacks are limited like logins, but counted
+	// separately so they don't use up the password login budget
+	twoFactorRateLimits = []middleware.RateLimitPolicy{
+		{Name: "auth.two_factor", Limit: 10, Window: time.Minute, Key: middleware.RateLimitByIP},
+		{Name: "auth.two_factor.hourly", Limit: 100, Window: time.Hour, Key: middleware.RateLimitByIP},
+	}
+
+	// Changing two-factor settings takes a code too; counted per user, since
+	// whoever guesses here already holds the session
+	twoFactorSettingsRateLimits = []middleware.RateLimitPolicy{
+		{Name: "users.two_factor", Limit: 10, Window: time.Minute, Key: middleware.RateLimitByUser},
+		{Name: "users.two_factor.hourly", Limit: 100, Window: time.Hour, Key: middleware.RateLimitByUser},
+	}
+
+	oidcCallbackRateLimits = []middleware.RateLimitPolicy{
+		{Name: "auth.oidc", Limit: 10, Window: time.Minute, Key: middleware.RateLimitByIP},
+		{Name: "auth.oidc.hourly", Limit: 100, Window: time.Hour, Key: middleware.RateLimitByIP},
+	}
+
+	// Each request here sends an email or takes a token, so they are limited
+	// per IP, and the reset email also per address so one inbox can't be
+	// flooded from many IPs
+	forgotPasswordRateLimits = []middleware.RateLimitPolicy{
+		{Name: "auth.password.forgot", Limit: 5, Window: time.Minute, Key: middleware.RateLimitByIP},
+		{Name: "auth.password.forgot.hourly", Limit: 30, Window: time.Hour, Key: middleware.RateLimitByIP},
+	}
+	forgotPasswordEmailRateLimits = []middleware.RateLimitPolicy{
+		{Name: "auth.password.forgot.email", Limit: 3, Window: time.Hour, Key: middleware.RateLimitByBody("email", func(body handlers.ForgotPasswordRequest) string {
+			return body.Email
+		})},
+	}
+
+	verifyEmailRateLimits = []middleware.RateLimitPolicy{
+		{Name: "auth.email.verify", Limit: 10, Window: time.Minute, Key: middleware.RateLimitByIP},
+		{Name: "auth.email.verify.hourly", Limit: 100, Window: time.Hour, Key: middleware.RateLimitByIP},
+	}
+
+	// The verification email goes to the signed-in user's own address, so the
+	// user key doubles as the email key
+	resendVerificationRateLimits = []middleware.RateLimitPolicy{
+		{Name: "users.email.verification", Limit: 10, Window: time.Hour, Key: middleware.RateLimitByIP},
+		{Name: "users.email.verification.user", Limit: 3, Window: time.Hour, Key: middleware.RateLimitByUser},
+	}
+
+	signupRateLimits = []middleware.RateLimitPolicy{
+		{Name: "users.create", Limit: 5, Window: time.Hour, Key: middleware.RateLimitByIP},
+	}
+
+	joinRateLimits = []middleware.RateLimitPolicy{
+		{Name: "invitations.join", Limit: 10, Window: time.Minute, Key: middleware.RateLimitByIP},
+		{Name: "invitations.join.user", Limit: 30, Window: time.Hour, Key: middleware.RateLimitByUser},
+	}
+
+	// Each debtor can also be reminded only once a day, see DebtReminderService
+	remindRateLimits = []middleware.RateLimitPolicy{
+		{Name: "debts.remind", Limit: 10, Window: time.Hour, Key: middleware.RateLimitByUser},
+	}
+)
@@ -16,7 +16,7 @@ func WithRecurringExpenseRoutes(recurringExpenseService service.RecurringExpense
 
 		// All recurring expense routes require authentication
 		r.Route("/groups/{group_id}/recurring-expenses", func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeExpensesWrite))
 
 			// GET /groups/{group_id}/recurring-expenses - List all recurring expenses for a group
 			r.Get("/", handlers.ListRecurringExpensesHandler(recurringExpenseService))
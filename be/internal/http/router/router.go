@@ -1,13 +1,17 @@
 package router
 
 import (
+	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
 )
 
 type Option interface {
@@ -22,11 +26,23 @@ func (f optionFunc) Apply(r chi.Router) {
 func New(opts ...Option) http.Handler {
 	r := chi.NewRouter()
 
+	// Client addresses are only taken from X-Forwarded-For when
+	// TRUSTED_PROXY_HOPS says how many proxies in front of the API append to it;
+	// otherwise a client could pick its own address
+	trustedProxyHops := 0
+	if hops := os.Getenv("TRUSTED_PROXY_HOPS"); hops != "" {
+		n, err := strconv.Atoi(hops)
+		if err != nil || n < 0 {
+			log.Fatalf("invalid TRUSTED_PROXY_HOPS: %q", hops)
+		}
+		trustedProxyHops = n
+	}
+
 	// global middleware
-	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
+	r.Use(chimiddleware.RequestID)
+	r.Use(middleware.RealIP(trustedProxyHops))
+	r.Use(chimiddleware.Logger)
+	r.Use(chimiddleware.Recoverer)
 
 	// CORS configuration
 	// Get allowed origins from environment variable, default to localhost:3000 for development
@@ -44,8 +60,8 @@ func New(opts ...Option) http.Handler {
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   origins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "Last-Event-ID", "X-CSRF-Token"},
+		ExposedHeaders:   []string{"Link", "Retry-After"},
 		AllowCredentials: true,
 		MaxAge:           86400, // 24 hours
 	}))
@@ -16,7 +16,7 @@ func WithSettlementRoutes(settlementService service.SettlementService, jwtServic
 
 		// All settlement routes require authentication
 		r.Route("/groups/{group_id}/settlements", func(r chi.Router) {
-			r.Use(middleware.RequireAuth(jwtService, sessionRepo))
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeSettlementsWrite))
 
 			// GET /groups/{group_id}/settlements - List all settlements in group
 			r.Get("/", handlers.ListSettlementsByGroupHandler(settlementService))
@@ -10,6 +10,6 @@ import (
 
 func WithThemeRoutes(themeService service.ThemeService, jwtService service.JWTService, sessionRepo repository.SessionRepository) Option {
 	return optionFunc(func(r chi.Router) {
-		r.With(middleware.RequireAuth(jwtService, sessionRepo)).Get("/themes/presets", handlers.ListThemePresetsHandler(themeService))
+		r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeRead)).Get("/themes/presets", handlers.ListThemePresetsHandler(themeService))
 	})
 }
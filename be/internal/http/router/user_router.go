@@ -10,20 +10,40 @@ import (
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
 )
 
-func WithUserRoutes(userService service.UserService, themeService service.ThemeService, jwtService service.JWTService, sessionRepo repository.SessionRepository) Option {
+func WithUserRoutes(userService service.UserService, accountService service.AccountService, authService service.AuthService, twoFactorService service.TwoFactorService, tokenService service.PersonalAccessTokenService, themeService service.ThemeService, paymentMethodService service.PaymentMethodService, notificationService service.NotificationService, jwtService service.JWTService, sessionRepo repository.SessionRepository, rateLimitStore middleware.RateLimitStore) Option {
 	return optionFunc(func(r chi.Router) {
 		v := validator.New()
 
 		r.Route("/users", func(r chi.Router) {
-			r.Post("/", middleware.ValidateBodyWithScope[handlers.CreateUserRequest](v, "user")(handlers.CreateUserHandler(userService)).ServeHTTP)
-			r.With(middleware.RequireAuth(jwtService, sessionRepo)).Get("/me", handlers.GetMeHandler(userService))
-			r.With(middleware.RequireAuth(jwtService, sessionRepo)).Get("/me/theme/preferences", handlers.GetThemePreferencesHandler(themeService))
-			r.With(middleware.RequireAuth(jwtService, sessionRepo)).Put("/me/theme/preferences", middleware.ValidateBodyWithScope[handlers.UpdateThemePreferencesRequest](v, "theme")(handlers.UpdateThemePreferencesHandler(themeService)).ServeHTTP)
-			r.With(middleware.RequireAuth(jwtService, sessionRepo)).Get("/me/themes", handlers.ListUserThemesHandler(themeService))
-			r.With(middleware.RequireAuth(jwtService, sessionRepo)).Post("/me/themes", middleware.ValidateBodyWithScope[handlers.CreateThemeRequest](v, "theme")(handlers.CreateUserThemeHandler(themeService)).ServeHTTP)
-			r.With(middleware.RequireAuth(jwtService, sessionRepo)).Get("/me/themes/{theme_id}", handlers.GetUserThemeHandler(themeService))
-			r.With(middleware.RequireAuth(jwtService, sessionRepo)).Patch("/me/themes/{theme_id}", middleware.ValidateBodyWithScope[handlers.UpdateThemeRequest](v, "theme")(handlers.UpdateUserThemeHandler(themeService)).ServeHTTP)
-			r.With(middleware.RequireAuth(jwtService, sessionRepo)).Delete("/me/themes/{theme_id}", handlers.DeleteUserThemeHandler(themeService))
+			r.With(middleware.RateLimit(rateLimitStore, signupRateLimits...)).Post("/", middleware.ValidateBodyWithScope[handlers.CreateUserRequest](v, "user")(handlers.CreateUserHandler(userService)).ServeHTTP)
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeRead)).Get("/me", handlers.GetMeHandler(userService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly), middleware.RateLimit(rateLimitStore, resendVerificationRateLimits...)).Post("/me/email/verification", handlers.RequestEmailVerificationHandler(accountService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly)).Get("/me/sessions", handlers.ListSessionsHandler(authService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly)).Delete("/me/sessions/{session_id}", handlers.RevokeSessionHandler(authService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly)).Get("/me/two-factor", handlers.GetTwoFactorStatusHandler(twoFactorService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly)).Post("/me/two-factor/enroll", handlers.EnrollTwoFactorHandler(twoFactorService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly)).Post("/me/two-factor/verify", middleware.ValidateBodyWithScope[handlers.TwoFactorCodeRequest](v, "two_factor")(handlers.VerifyTwoFactorHandler(twoFactorService)).ServeHTTP)
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly), middleware.RateLimit(rateLimitStore, twoFactorSettingsRateLimits...)).Post("/me/two-factor/recovery-codes", middleware.ValidateBodyWithScope[handlers.TwoFactorCodeRequest](v, "two_factor")(handlers.RegenerateRecoveryCodesHandler(twoFactorService)).ServeHTTP)
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly), middleware.RateLimit(rateLimitStore, twoFactorSettingsRateLimits...)).Post("/me/two-factor/disable", middleware.ValidateBodyWithScope[handlers.TwoFactorCodeRequest](v, "two_factor")(handlers.DisableTwoFactorHandler(twoFactorService)).ServeHTTP)
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly)).Get("/me/tokens", handlers.ListPersonalAccessTokensHandler(tokenService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly)).Post("/me/tokens", middleware.ValidateBodyWithScope[handlers.CreatePersonalAccessTokenRequest](v, "token")(handlers.CreatePersonalAccessTokenHandler(tokenService)).ServeHTTP)
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, middleware.SessionOnly)).Delete("/me/tokens/{token_id}", handlers.RevokePersonalAccessTokenHandler(tokenService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Get("/me/theme/preferences", handlers.GetThemePreferencesHandler(themeService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Put("/me/theme/preferences", middleware.ValidateBodyWithScope[handlers.UpdateThemePreferencesRequest](v, "theme")(handlers.UpdateThemePreferencesHandler(themeService)).ServeHTTP)
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Get("/me/themes", handlers.ListUserThemesHandler(themeService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Post("/me/themes", middleware.ValidateBodyWithScope[handlers.CreateThemeRequest](v, "theme")(handlers.CreateUserThemeHandler(themeService)).ServeHTTP)
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Get("/me/themes/{theme_id}", handlers.GetUserThemeHandler(themeService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Patch("/me/themes/{theme_id}", middleware.ValidateBodyWithScope[handlers.UpdateThemeRequest](v, "theme")(handlers.UpdateUserThemeHandler(themeService)).ServeHTTP)
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Delete("/me/themes/{theme_id}", handlers.DeleteUserThemeHandler(themeService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Get("/me/payment-methods", handlers.ListPaymentMethodsHandler(paymentMethodService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Put("/me/payment-methods/{provider}", middleware.ValidateBodyWithScope[handlers.SetPaymentMethodRequest](v, "payment_method")(handlers.SetPaymentMethodHandler(paymentMethodService)).ServeHTTP)
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Delete("/me/payment-methods/{provider}", handlers.DeletePaymentMethodHandler(paymentMethodService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Get("/me/notifications", handlers.ListNotificationsHandler(notificationService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Get("/me/notifications/unread-count", handlers.CountUnreadNotificationsHandler(notificationService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Post("/me/notifications/read-all", handlers.MarkAllNotificationsReadHandler(notificationService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Post("/me/notifications/{notification_id}/read", handlers.MarkNotificationReadHandler(notificationService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Get("/me/notifications/preferences", handlers.GetNotificationPreferencesHandler(notificationService))
+			r.With(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeProfileWrite)).Patch("/me/notifications/preferences", middleware.ValidateBodyWithScope[handlers.UpdateNotificationPreferenceRequest](v, "notification")(handlers.UpdateNotificationPreferenceHandler(notificationService)).ServeHTTP)
 		})
 	})
 }
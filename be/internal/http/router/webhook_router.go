@@ -0,0 +1,39 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/http/handlers"
+	"github.com/dhruvsaxena1998/splitplus/internal/http/middleware"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+func WithWebhookRoutes(webhookService service.WebhookService, jwtService service.JWTService, sessionRepo repository.SessionRepository) Option {
+	return optionFunc(func(r chi.Router) {
+		v := validator.New()
+
+		r.Route("/groups/{group_id}/webhooks", func(r chi.Router) {
+			r.Use(middleware.RequireAuth(jwtService, sessionRepo, service.TokenScopeGroupsWrite))
+
+			// GET / - List group webhooks
+			r.Get("/", handlers.ListWebhooksHandler(webhookService))
+
+			// POST / - Create webhook
+			r.Post("/", middleware.ValidateBodyWithScope[handlers.CreateWebhookRequest](v, "webhook")(handlers.CreateWebhookHandler(webhookService)).ServeHTTP)
+
+			// PATCH /{webhook_id} - Update webhook
+			r.Patch("/{webhook_id}", middleware.ValidateBodyWithScope[handlers.UpdateWebhookRequest](v, "webhook")(handlers.UpdateWebhookHandler(webhookService)).ServeHTTP)
+
+			// DELETE /{webhook_id} - Delete webhook
+			r.Delete("/{webhook_id}", handlers.DeleteWebhookHandler(webhookService))
+
+			// GET /{webhook_id}/deliveries - List deliveries, newest first
+			r.Get("/{webhook_id}/deliveries", handlers.ListWebhookDeliveriesHandler(webhookService))
+
+			// POST /{webhook_id}/deliveries/{delivery_id}/redeliver - Send a delivery's event again
+			r.Post("/{webhook_id}/deliveries/{delivery_id}/redeliver", handlers.RedeliverWebhookHandler(webhookService))
+		})
+	})
+}
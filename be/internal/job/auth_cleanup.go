@@ -5,19 +5,24 @@ import (
 	"log"
 	"time"
 
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
 )
 
 type AuthCleanup struct {
-	authService service.AuthService
-	ticker      *time.Ticker
-	done        chan bool
+	authService    service.AuthService
+	accountService service.AccountService
+	rateLimitRepo  repository.RateLimitRepository
+	ticker         *time.Ticker
+	done           chan bool
 }
 
-func NewAuthCleanup(authService service.AuthService) *AuthCleanup {
+func NewAuthCleanup(authService service.AuthService, accountService service.AccountService, rateLimitRepo repository.RateLimitRepository) *AuthCleanup {
 	return &AuthCleanup{
-		authService: authService,
-		done:        make(chan bool),
+		authService:    authService,
+		accountService: accountService,
+		rateLimitRepo:  rateLimitRepo,
+		done:           make(chan bool),
 	}
 }
 
@@ -51,7 +56,7 @@ func (c *AuthCleanup) Stop() {
 }
 
 func (c *AuthCleanup) cleanup(ctx context.Context) {
-	log.Println("Running auth cleanup: removing expired sessions and blacklisted tokens...")
+	log.Println("Running auth cleanup: removing expired sessions, blacklisted tokens, account tokens and rate limit counters...")
 
 	err := c.authService.CleanupExpiredSessions(ctx)
 	if err != nil {
@@ -59,5 +64,17 @@ func (c *AuthCleanup) cleanup(ctx context.Context) {
 		return
 	}
 
+	err = c.accountService.CleanupExpiredTokens(ctx)
+	if err != nil {
+		log.Printf("Error during account token cleanup: %v", err)
+		return
+	}
+
+	err = c.rateLimitRepo.DeleteExpiredRateLimitCounters(ctx)
+	if err != nil {
+		log.Printf("Error during rate limit cleanup: %v", err)
+		return
+	}
+
 	log.Println("Auth cleanup completed successfully")
 }
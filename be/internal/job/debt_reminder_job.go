@@ -0,0 +1,66 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+// DebtReminderJob emails members of groups with reminders enabled once their
+// debts are large or old enough. The service decides who is due, so running
+// the job often only catches debts sooner; nobody is reminded more than their
+// group's interval allows.
+type DebtReminderJob struct {
+	reminderService service.DebtReminderService
+	ticker          *time.Ticker
+	done            chan bool
+}
+
+func NewDebtReminderJob(reminderService service.DebtReminderService) *DebtReminderJob {
+	return &DebtReminderJob{
+		reminderService: reminderService,
+		done:            make(chan bool),
+	}
+}
+
+func (j *DebtReminderJob) Start(ctx context.Context) {
+	// Check for due reminders every hour
+	j.ticker = time.NewTicker(1 * time.Hour)
+
+	go func() {
+		// Run immediately on startup
+		j.remind(ctx)
+
+		// Then run on ticker
+		for {
+			select {
+			case <-j.ticker.C:
+				j.remind(ctx)
+			case <-ctx.Done():
+				return
+			case <-j.done:
+				return
+			}
+		}
+	}()
+}
+
+func (j *DebtReminderJob) Stop() {
+	if j.ticker != nil {
+		j.ticker.Stop()
+	}
+	close(j.done)
+}
+
+func (j *DebtReminderJob) remind(ctx context.Context) {
+	sent, err := j.reminderService.SendDueReminders(ctx, time.Now())
+	if err != nil {
+		// Errors are per group, so the reminders that did go out are still reported
+		log.Printf("Error sending debt reminders: %v", err)
+	}
+	if sent > 0 {
+		log.Printf("Queued %d debt reminders", sent)
+	}
+}
@@ -0,0 +1,72 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+const emailDispatchBatchSize = 50
+
+// EmailDispatcher drains the email outbox. Failed sends are rescheduled by the
+// email service with exponential backoff.
+type EmailDispatcher struct {
+	emailService service.EmailService
+	ticker       *time.Ticker
+	done         chan bool
+}
+
+func NewEmailDispatcher(emailService service.EmailService) *EmailDispatcher {
+	return &EmailDispatcher{
+		emailService: emailService,
+		done:         make(chan bool),
+	}
+}
+
+func (d *EmailDispatcher) Start(ctx context.Context) {
+	// Poll every 15 seconds
+	d.ticker = time.NewTicker(15 * time.Second)
+
+	go func() {
+		// Run immediately on startup
+		d.dispatch(ctx)
+
+		// Then run on ticker
+		for {
+			select {
+			case <-d.ticker.C:
+				d.dispatch(ctx)
+			case <-ctx.Done():
+				return
+			case <-d.done:
+				return
+			}
+		}
+	}()
+}
+
+func (d *EmailDispatcher) Stop() {
+	if d.ticker != nil {
+		d.ticker.Stop()
+	}
+	close(d.done)
+}
+
+func (d *EmailDispatcher) dispatch(ctx context.Context) {
+	// Keep going while full batches come back, so a backlog drains in one tick
+	for {
+		sent, err := d.emailService.DeliverPending(ctx, emailDispatchBatchSize)
+		if err != nil {
+			log.Printf("Error delivering emails: %v", err)
+			return
+		}
+		if sent > 0 {
+			log.Printf("Delivered %d emails", sent)
+		}
+		if sent < emailDispatchBatchSize {
+			return
+		}
+	}
+}
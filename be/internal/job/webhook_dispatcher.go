@@ -0,0 +1,72 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/service"
+)
+
+const webhookDispatchBatchSize = 50
+
+// WebhookDispatcher sends queued webhook deliveries. Failed deliveries are
+// rescheduled by the webhook service with exponential backoff.
+type WebhookDispatcher struct {
+	webhookService service.WebhookService
+	ticker         *time.Ticker
+	done           chan bool
+}
+
+func NewWebhookDispatcher(webhookService service.WebhookService) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookService: webhookService,
+		done:           make(chan bool),
+	}
+}
+
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	// Poll every 10 seconds
+	d.ticker = time.NewTicker(10 * time.Second)
+
+	go func() {
+		// Run immediately on startup
+		d.dispatch(ctx)
+
+		// Then run on ticker
+		for {
+			select {
+			case <-d.ticker.C:
+				d.dispatch(ctx)
+			case <-ctx.Done():
+				return
+			case <-d.done:
+				return
+			}
+		}
+	}()
+}
+
+func (d *WebhookDispatcher) Stop() {
+	if d.ticker != nil {
+		d.ticker.Stop()
+	}
+	close(d.done)
+}
+
+func (d *WebhookDispatcher) dispatch(ctx context.Context) {
+	// Keep going while full batches come back, so a backlog drains in one tick
+	for {
+		sent, err := d.webhookService.DeliverPending(ctx, webhookDispatchBatchSize)
+		if err != nil {
+			log.Printf("Error delivering webhooks: %v", err)
+			return
+		}
+		if sent > 0 {
+			log.Printf("Delivered %d webhooks", sent)
+		}
+		if sent < webhookDispatchBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+// activityChannel is the NOTIFY channel the group_activities_notify trigger
+// announces new activities on.
+const activityChannel = "group_activities"
+
+// ActivityNotification names a newly logged activity.
+type ActivityNotification struct {
+	ID      pgtype.UUID `json:"id"`
+	GroupID pgtype.UUID `json:"group_id"`
+}
+
+type ActivityStreamRepository interface {
+	GetActivity(ctx context.Context, id pgtype.UUID) (sqlc.GroupActivity, error)
+	ListGroupActivitiesAfter(ctx context.Context, params sqlc.ListGroupActivitiesAfterParams) ([]sqlc.GroupActivity, error)
+	ListUserActivitiesAfter(ctx context.Context, params sqlc.ListUserActivitiesAfterParams) ([]sqlc.GroupActivity, error)
+	ListActiveGroupMemberIDs(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error)
+
+	// ListenForActivities holds a connection listening for new activities and
+	// calls handle for each one, in order, until ctx ends or the connection fails.
+	ListenForActivities(ctx context.Context, handle func(ActivityNotification)) error
+}
+
+type activityStreamRepository struct {
+	queries *sqlc.Queries
+	pool    *pgxpool.Pool
+}
+
+func NewActivityStreamRepository(pool *pgxpool.Pool) ActivityStreamRepository {
+	return &activityStreamRepository{
+		queries: sqlc.New(pool),
+		pool:    pool,
+	}
+}
+
+func (r *activityStreamRepository) GetActivity(ctx context.Context, id pgtype.UUID) (sqlc.GroupActivity, error) {
+	return r.queries.GetGroupActivity(ctx, id)
+}
+
+func (r *activityStreamRepository) ListGroupActivitiesAfter(ctx context.Context, params sqlc.ListGroupActivitiesAfterParams) ([]sqlc.GroupActivity, error) {
+	return r.queries.ListGroupActivitiesAfter(ctx, params)
+}
+
+func (r *activityStreamRepository) ListUserActivitiesAfter(ctx context.Context, params sqlc.ListUserActivitiesAfterParams) ([]sqlc.GroupActivity, error) {
+	return r.queries.ListUserActivitiesAfter(ctx, params)
+}
+
+func (r *activityStreamRepository) ListActiveGroupMemberIDs(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error) {
+	return r.queries.ListActiveGroupMemberIDs(ctx, groupID)
+}
+
+func (r *activityStreamRepository) ListenForActivities(ctx context.Context, handle func(ActivityNotification)) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// A cancelled wait closes the connection, and the pool drops it on
+		// release; a healthy one must stop listening before it is reused.
+		_, _ = conn.Exec(context.Background(), "UNLISTEN *")
+		conn.Release()
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+activityChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var payload ActivityNotification
+		if err := json.Unmarshal([]byte(notification.Payload), &payload); err != nil {
+			continue
+		}
+		handle(payload)
+	}
+}
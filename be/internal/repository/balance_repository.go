@@ -8,14 +8,22 @@ import (
 )
 
 type BalanceRepository interface {
-	GetGroupBalances(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GetGroupBalancesRow, error)
-	GetGroupBalancesWithPending(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GetGroupBalancesWithPendingRow, error)
+	GetGroupBalances(ctx context.Context, params sqlc.GetGroupBalancesParams) ([]sqlc.GetGroupBalancesRow, error)
+	GetGroupBalancesWithPending(ctx context.Context, params sqlc.GetGroupBalancesWithPendingParams) ([]sqlc.GetGroupBalancesWithPendingRow, error)
 	GetUserBalanceInGroup(ctx context.Context, params sqlc.GetUserBalanceInGroupParams) (sqlc.GetUserBalanceInGroupRow, error)
-	GetOverallUserBalance(ctx context.Context, userID pgtype.UUID) ([]sqlc.GetOverallUserBalanceRow, error)
+	GetOverallUserBalance(ctx context.Context, params sqlc.GetOverallUserBalanceParams) ([]sqlc.GetOverallUserBalanceRow, error)
+	ListDirectFriendBalances(ctx context.Context, params sqlc.ListDirectFriendBalancesParams) ([]sqlc.ListDirectFriendBalancesRow, error)
+	ListGroupFriendBalances(ctx context.Context, params sqlc.ListGroupFriendBalancesParams) ([]sqlc.ListGroupFriendBalancesRow, error)
 
 	// Group lookup (for validation)
 	GetGroupByID(ctx context.Context, id pgtype.UUID) (sqlc.Group, error)
 	GetGroupMember(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error)
+
+	// Friendship lookup (for validation)
+	GetFriendship(ctx context.Context, userID, friendUserID pgtype.UUID) (sqlc.Friendship, error)
+
+	// Payment handles of creditors (for payment links)
+	ListPaymentMethodsByUsers(ctx context.Context, userIDs []pgtype.UUID) ([]sqlc.UserPaymentMethod, error)
 }
 
 type balanceRepository struct {
@@ -26,20 +34,28 @@ func NewBalanceRepository(queries *sqlc.Queries) BalanceRepository {
 	return &balanceRepository{queries: queries}
 }
 
-func (r *balanceRepository) GetGroupBalances(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GetGroupBalancesRow, error) {
-	return r.queries.GetGroupBalances(ctx, groupID)
+func (r *balanceRepository) GetGroupBalances(ctx context.Context, params sqlc.GetGroupBalancesParams) ([]sqlc.GetGroupBalancesRow, error) {
+	return r.queries.GetGroupBalances(ctx, params)
 }
 
-func (r *balanceRepository) GetGroupBalancesWithPending(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GetGroupBalancesWithPendingRow, error) {
-	return r.queries.GetGroupBalancesWithPending(ctx, groupID)
+func (r *balanceRepository) GetGroupBalancesWithPending(ctx context.Context, params sqlc.GetGroupBalancesWithPendingParams) ([]sqlc.GetGroupBalancesWithPendingRow, error) {
+	return r.queries.GetGroupBalancesWithPending(ctx, params)
 }
 
 func (r *balanceRepository) GetUserBalanceInGroup(ctx context.Context, params sqlc.GetUserBalanceInGroupParams) (sqlc.GetUserBalanceInGroupRow, error) {
 	return r.queries.GetUserBalanceInGroup(ctx, params)
 }
 
-func (r *balanceRepository) GetOverallUserBalance(ctx context.Context, userID pgtype.UUID) ([]sqlc.GetOverallUserBalanceRow, error) {
-	return r.queries.GetOverallUserBalance(ctx, userID)
+func (r *balanceRepository) GetOverallUserBalance(ctx context.Context, params sqlc.GetOverallUserBalanceParams) ([]sqlc.GetOverallUserBalanceRow, error) {
+	return r.queries.GetOverallUserBalance(ctx, params)
+}
+
+func (r *balanceRepository) ListDirectFriendBalances(ctx context.Context, params sqlc.ListDirectFriendBalancesParams) ([]sqlc.ListDirectFriendBalancesRow, error) {
+	return r.queries.ListDirectFriendBalances(ctx, params)
+}
+
+func (r *balanceRepository) ListGroupFriendBalances(ctx context.Context, params sqlc.ListGroupFriendBalancesParams) ([]sqlc.ListGroupFriendBalancesRow, error) {
+	return r.queries.ListGroupFriendBalances(ctx, params)
 }
 
 func (r *balanceRepository) GetGroupByID(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
@@ -49,3 +65,14 @@ func (r *balanceRepository) GetGroupByID(ctx context.Context, id pgtype.UUID) (s
 func (r *balanceRepository) GetGroupMember(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
 	return r.queries.GetGroupMember(ctx, params)
 }
+
+func (r *balanceRepository) GetFriendship(ctx context.Context, userID, friendUserID pgtype.UUID) (sqlc.Friendship, error) {
+	return r.queries.GetFriendship(ctx, sqlc.GetFriendshipParams{
+		UserID:       userID,
+		FriendUserID: friendUserID,
+	})
+}
+
+func (r *balanceRepository) ListPaymentMethodsByUsers(ctx context.Context, userIDs []pgtype.UUID) ([]sqlc.UserPaymentMethod, error) {
+	return r.queries.ListPaymentMethodsByUsers(ctx, userIDs)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+type BudgetRepository interface {
+	CreateGroupBudget(ctx context.Context, params sqlc.CreateGroupBudgetParams) (sqlc.GroupBudget, error)
+	GetGroupBudget(ctx context.Context, params sqlc.GetGroupBudgetParams) (sqlc.GroupBudget, error)
+	ListGroupBudgets(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupBudget, error)
+	ListBudgetsForCategory(ctx context.Context, params sqlc.ListBudgetsForCategoryParams) ([]sqlc.GroupBudget, error)
+	CountGroupBudgets(ctx context.Context, groupID pgtype.UUID) (int64, error)
+	UpdateGroupBudget(ctx context.Context, params sqlc.UpdateGroupBudgetParams) (sqlc.GroupBudget, error)
+	DeleteGroupBudget(ctx context.Context, params sqlc.DeleteGroupBudgetParams) error
+	GetBudgetSpent(ctx context.Context, params sqlc.GetBudgetSpentParams) (pgtype.Numeric, error)
+}
+
+type budgetRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewBudgetRepository(queries *sqlc.Queries) BudgetRepository {
+	return &budgetRepository{queries: queries}
+}
+
+func (r *budgetRepository) CreateGroupBudget(ctx context.Context, params sqlc.CreateGroupBudgetParams) (sqlc.GroupBudget, error) {
+	return r.queries.CreateGroupBudget(ctx, params)
+}
+
+func (r *budgetRepository) GetGroupBudget(ctx context.Context, params sqlc.GetGroupBudgetParams) (sqlc.GroupBudget, error) {
+	return r.queries.GetGroupBudget(ctx, params)
+}
+
+func (r *budgetRepository) ListGroupBudgets(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupBudget, error) {
+	return r.queries.ListGroupBudgets(ctx, groupID)
+}
+
+func (r *budgetRepository) ListBudgetsForCategory(ctx context.Context, params sqlc.ListBudgetsForCategoryParams) ([]sqlc.GroupBudget, error) {
+	return r.queries.ListBudgetsForCategory(ctx, params)
+}
+
+func (r *budgetRepository) CountGroupBudgets(ctx context.Context, groupID pgtype.UUID) (int64, error) {
+	return r.queries.CountGroupBudgets(ctx, groupID)
+}
+
+func (r *budgetRepository) UpdateGroupBudget(ctx context.Context, params sqlc.UpdateGroupBudgetParams) (sqlc.GroupBudget, error) {
+	return r.queries.UpdateGroupBudget(ctx, params)
+}
+
+func (r *budgetRepository) DeleteGroupBudget(ctx context.Context, params sqlc.DeleteGroupBudgetParams) error {
+	return r.queries.DeleteGroupBudget(ctx, params)
+}
+
+func (r *budgetRepository) GetBudgetSpent(ctx context.Context, params sqlc.GetBudgetSpentParams) (pgtype.Numeric, error) {
+	return r.queries.GetBudgetSpent(ctx, params)
+}
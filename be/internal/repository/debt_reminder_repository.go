@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+type DebtReminderRepository interface {
+	// Transaction support
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	WithTx(tx pgx.Tx) DebtReminderRepository
+
+	ListReminderGroups(ctx context.Context) ([]sqlc.ListReminderGroupsRow, error)
+	TrackDebtors(ctx context.Context, params sqlc.TrackDebtorsParams) error
+	ClearSettledDebtors(ctx context.Context, params sqlc.ClearSettledDebtorsParams) error
+	ListDebtReminderStates(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListDebtReminderStatesRow, error)
+	// ClaimDebtReminder reports whether the reminder was recorded and may be sent.
+	ClaimDebtReminder(ctx context.Context, params sqlc.ClaimDebtReminderParams) (bool, error)
+}
+
+type debtReminderRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewDebtReminderRepository(pool *pgxpool.Pool, queries *sqlc.Queries) DebtReminderRepository {
+	return &debtReminderRepository{pool: pool, queries: queries}
+}
+
+func (r *debtReminderRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+func (r *debtReminderRepository) WithTx(tx pgx.Tx) DebtReminderRepository {
+	return &debtReminderRepository{
+		pool:    r.pool,
+		queries: r.queries.WithTx(tx),
+	}
+}
+
+func (r *debtReminderRepository) ListReminderGroups(ctx context.Context) ([]sqlc.ListReminderGroupsRow, error) {
+	return r.queries.ListReminderGroups(ctx)
+}
+
+func (r *debtReminderRepository) TrackDebtors(ctx context.Context, params sqlc.TrackDebtorsParams) error {
+	return r.queries.TrackDebtors(ctx, params)
+}
+
+func (r *debtReminderRepository) ClearSettledDebtors(ctx context.Context, params sqlc.ClearSettledDebtorsParams) error {
+	return r.queries.ClearSettledDebtors(ctx, params)
+}
+
+func (r *debtReminderRepository) ListDebtReminderStates(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListDebtReminderStatesRow, error) {
+	return r.queries.ListDebtReminderStates(ctx, groupID)
+}
+
+func (r *debtReminderRepository) ClaimDebtReminder(ctx context.Context, params sqlc.ClaimDebtReminderParams) (bool, error) {
+	claimed, err := r.queries.ClaimDebtReminder(ctx, params)
+	return claimed > 0, err
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type EmailOutboxRepository interface {
+	// WithTx queues email in a transaction begun by another repository, so it
+	// is only sent if that transaction commits.
+	WithTx(tx pgx.Tx) EmailOutboxRepository
+
+	EnqueueEmail(ctx context.Context, params sqlc.EnqueueEmailParams) (sqlc.EmailOutbox, error)
+	ClaimDueEmails(ctx context.Context, limit int32) ([]sqlc.EmailOutbox, error)
+	MarkEmailSent(ctx context.Context, id pgtype.UUID) error
+	MarkEmailRetry(ctx context.Context, params sqlc.MarkEmailRetryParams) error
+	MarkEmailFailed(ctx context.Context, params sqlc.MarkEmailFailedParams) error
+}
+
+type emailOutboxRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewEmailOutboxRepository(queries *sqlc.Queries) EmailOutboxRepository {
+	return &emailOutboxRepository{queries: queries}
+}
+
+func (r *emailOutboxRepository) WithTx(tx pgx.Tx) EmailOutboxRepository {
+	return &emailOutboxRepository{queries: r.queries.WithTx(tx)}
+}
+
+func (r *emailOutboxRepository) EnqueueEmail(ctx context.Context, params sqlc.EnqueueEmailParams) (sqlc.EmailOutbox, error) {
+	return r.queries.EnqueueEmail(ctx, params)
+}
+
+func (r *emailOutboxRepository) ClaimDueEmails(ctx context.Context, limit int32) ([]sqlc.EmailOutbox, error) {
+	return r.queries.ClaimDueEmails(ctx, limit)
+}
+
+func (r *emailOutboxRepository) MarkEmailSent(ctx context.Context, id pgtype.UUID) error {
+	return r.queries.MarkEmailSent(ctx, id)
+}
+
+func (r *emailOutboxRepository) MarkEmailRetry(ctx context.Context, params sqlc.MarkEmailRetryParams) error {
+	return r.queries.MarkEmailRetry(ctx, params)
+}
+
+func (r *emailOutboxRepository) MarkEmailFailed(ctx context.Context, params sqlc.MarkEmailFailedParams) error {
+	return r.queries.MarkEmailFailed(ctx, params)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ExpenseAttachmentRepository interface {
+	CreateAttachment(ctx context.Context, params sqlc.CreateExpenseAttachmentParams) (sqlc.ExpenseAttachment, error)
+	GetAttachmentByID(ctx context.Context, id pgtype.UUID) (sqlc.ExpenseAttachment, error)
+	ListAttachments(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseAttachment, error)
+	DeleteAttachment(ctx context.Context, id pgtype.UUID) error
+}
+
+type expenseAttachmentRepository struct {
+	q *sqlc.Queries
+}
+
+func NewExpenseAttachmentRepository(q *sqlc.Queries) ExpenseAttachmentRepository {
+	return &expenseAttachmentRepository{q: q}
+}
+
+func (r *expenseAttachmentRepository) CreateAttachment(ctx context.Context, params sqlc.CreateExpenseAttachmentParams) (sqlc.ExpenseAttachment, error) {
+	return r.q.CreateExpenseAttachment(ctx, params)
+}
+
+func (r *expenseAttachmentRepository) GetAttachmentByID(ctx context.Context, id pgtype.UUID) (sqlc.ExpenseAttachment, error) {
+	return r.q.GetExpenseAttachmentByID(ctx, id)
+}
+
+func (r *expenseAttachmentRepository) ListAttachments(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseAttachment, error) {
+	return r.q.ListExpenseAttachments(ctx, expenseID)
+}
+
+func (r *expenseAttachmentRepository) DeleteAttachment(ctx context.Context, id pgtype.UUID) error {
+	return r.q.DeleteExpenseAttachment(ctx, id)
+}
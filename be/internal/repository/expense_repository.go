@@ -31,6 +31,13 @@ type ExpenseRepository interface {
 	ListExpenseSplits(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ListExpenseSplitsRow, error)
 	DeleteExpenseSplits(ctx context.Context, expenseID pgtype.UUID) error
 
+	// Line item operations
+	CreateExpenseItem(ctx context.Context, params sqlc.CreateExpenseItemParams) (sqlc.ExpenseItem, error)
+	CreateExpenseItemConsumer(ctx context.Context, params sqlc.CreateExpenseItemConsumerParams) (sqlc.ExpenseItemConsumer, error)
+	ListExpenseItems(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseItem, error)
+	ListExpenseItemConsumers(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseItemConsumer, error)
+	DeleteExpenseItems(ctx context.Context, expenseID pgtype.UUID) error
+
 	// Friend expense operations
 	ListFriendExpenses(ctx context.Context, params sqlc.ListFriendExpensesParams) ([]sqlc.Expense, error)
 
@@ -106,6 +113,26 @@ func (r *expenseRepository) DeleteExpenseSplits(ctx context.Context, expenseID p
 	return r.queries.DeleteExpenseSplits(ctx, expenseID)
 }
 
+func (r *expenseRepository) CreateExpenseItem(ctx context.Context, params sqlc.CreateExpenseItemParams) (sqlc.ExpenseItem, error) {
+	return r.queries.CreateExpenseItem(ctx, params)
+}
+
+func (r *expenseRepository) CreateExpenseItemConsumer(ctx context.Context, params sqlc.CreateExpenseItemConsumerParams) (sqlc.ExpenseItemConsumer, error) {
+	return r.queries.CreateExpenseItemConsumer(ctx, params)
+}
+
+func (r *expenseRepository) ListExpenseItems(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseItem, error) {
+	return r.queries.ListExpenseItems(ctx, expenseID)
+}
+
+func (r *expenseRepository) ListExpenseItemConsumers(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseItemConsumer, error) {
+	return r.queries.ListExpenseItemConsumers(ctx, expenseID)
+}
+
+func (r *expenseRepository) DeleteExpenseItems(ctx context.Context, expenseID pgtype.UUID) error {
+	return r.queries.DeleteExpenseItems(ctx, expenseID)
+}
+
 func (r *expenseRepository) ListFriendExpenses(ctx context.Context, params sqlc.ListFriendExpensesParams) ([]sqlc.Expense, error) {
 	return r.queries.ListFriendExpenses(ctx, params)
 }
@@ -19,6 +19,15 @@ type GroupInvitationRepository interface {
 	UpdateInvitationStatus(ctx context.Context, params sqlc.UpdateInvitationStatusParams) (sqlc.GroupInvitation, error)
 	ListInvitationsByGroup(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListInvitationsByGroupRow, error)
 	GetPendingInvitationsByEmail(ctx context.Context, email string) ([]sqlc.GetPendingInvitationsByEmailRow, error)
+	RenewInvitation(ctx context.Context, params sqlc.RenewInvitationParams) (sqlc.GroupInvitation, error)
+
+	// Join links
+	CreateJoinLink(ctx context.Context, params sqlc.CreateJoinLinkParams) (sqlc.GroupJoinLink, error)
+	GetJoinLinkByID(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error)
+	GetJoinLinkByToken(ctx context.Context, token string) (sqlc.GetJoinLinkByTokenRow, error)
+	ListJoinLinksByGroup(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupJoinLink, error)
+	ConsumeJoinLink(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error)
+	RevokeJoinLink(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error)
 }
 
 type groupInvitationRepository struct {
@@ -64,3 +73,31 @@ func (r *groupInvitationRepository) ListInvitationsByGroup(ctx context.Context,
 func (r *groupInvitationRepository) GetPendingInvitationsByEmail(ctx context.Context, email string) ([]sqlc.GetPendingInvitationsByEmailRow, error) {
 	return r.queries.GetPendingInvitationsByEmail(ctx, email)
 }
+
+func (r *groupInvitationRepository) RenewInvitation(ctx context.Context, params sqlc.RenewInvitationParams) (sqlc.GroupInvitation, error) {
+	return r.queries.RenewInvitation(ctx, params)
+}
+
+func (r *groupInvitationRepository) CreateJoinLink(ctx context.Context, params sqlc.CreateJoinLinkParams) (sqlc.GroupJoinLink, error) {
+	return r.queries.CreateJoinLink(ctx, params)
+}
+
+func (r *groupInvitationRepository) GetJoinLinkByID(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error) {
+	return r.queries.GetJoinLinkByID(ctx, id)
+}
+
+func (r *groupInvitationRepository) GetJoinLinkByToken(ctx context.Context, token string) (sqlc.GetJoinLinkByTokenRow, error) {
+	return r.queries.GetJoinLinkByToken(ctx, token)
+}
+
+func (r *groupInvitationRepository) ListJoinLinksByGroup(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupJoinLink, error) {
+	return r.queries.ListJoinLinksByGroup(ctx, groupID)
+}
+
+func (r *groupInvitationRepository) ConsumeJoinLink(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error) {
+	return r.queries.ConsumeJoinLink(ctx, id)
+}
+
+func (r *groupInvitationRepository) RevokeJoinLink(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error) {
+	return r.queries.RevokeJoinLink(ctx, id)
+}
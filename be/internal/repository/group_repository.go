@@ -17,11 +17,19 @@ type GroupRepository interface {
 	// Group operations
 	CreateGroup(ctx context.Context, params sqlc.CreateGroupParams) (sqlc.Group, error)
 	GetGroupByID(ctx context.Context, id pgtype.UUID) (sqlc.Group, error)
+	UpdateGroup(ctx context.Context, params sqlc.UpdateGroupParams) (sqlc.Group, error)
+	ArchiveGroup(ctx context.Context, params sqlc.ArchiveGroupParams) (sqlc.Group, error)
+	RestoreGroup(ctx context.Context, params sqlc.RestoreGroupParams) (sqlc.Group, error)
+	DeleteGroup(ctx context.Context, params sqlc.DeleteGroupParams) error
+	CountGroupExpenses(ctx context.Context, groupID pgtype.UUID) (int64, error)
+	GetGroupBalancesWithPending(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GetGroupBalancesWithPendingRow, error)
 
 	// Group member operations
 	CreateGroupMember(ctx context.Context, params sqlc.CreateGroupMemberParams) (sqlc.GroupMember, error)
 	GetGroupMember(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error)
 	UpdateGroupMemberStatus(ctx context.Context, params sqlc.UpdateGroupMemberStatusParams) (sqlc.GroupMember, error)
+	UpdateGroupMemberRole(ctx context.Context, params sqlc.UpdateGroupMemberRoleParams) (sqlc.GroupMember, error)
+	GetUserBalanceInGroup(ctx context.Context, params sqlc.GetUserBalanceInGroupParams) (sqlc.GetUserBalanceInGroupRow, error)
 	ListGroupMembers(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListGroupMembersRow, error)
 	GetGroupsByUserID(ctx context.Context, userID pgtype.UUID) ([]sqlc.GetGroupsByUserIDRow, error)
 
@@ -57,6 +65,30 @@ func (r *groupRepository) GetGroupByID(ctx context.Context, id pgtype.UUID) (sql
 	return r.queries.GetGroupByID(ctx, id)
 }
 
+func (r *groupRepository) UpdateGroup(ctx context.Context, params sqlc.UpdateGroupParams) (sqlc.Group, error) {
+	return r.queries.UpdateGroup(ctx, params)
+}
+
+func (r *groupRepository) ArchiveGroup(ctx context.Context, params sqlc.ArchiveGroupParams) (sqlc.Group, error) {
+	return r.queries.ArchiveGroup(ctx, params)
+}
+
+func (r *groupRepository) RestoreGroup(ctx context.Context, params sqlc.RestoreGroupParams) (sqlc.Group, error) {
+	return r.queries.RestoreGroup(ctx, params)
+}
+
+func (r *groupRepository) DeleteGroup(ctx context.Context, params sqlc.DeleteGroupParams) error {
+	return r.queries.DeleteGroup(ctx, params)
+}
+
+func (r *groupRepository) CountGroupExpenses(ctx context.Context, groupID pgtype.UUID) (int64, error) {
+	return r.queries.CountGroupExpenses(ctx, groupID)
+}
+
+func (r *groupRepository) GetGroupBalancesWithPending(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GetGroupBalancesWithPendingRow, error) {
+	return r.queries.GetGroupBalancesWithPending(ctx, sqlc.GetGroupBalancesWithPendingParams{GroupID: groupID})
+}
+
 func (r *groupRepository) CreateGroupMember(ctx context.Context, params sqlc.CreateGroupMemberParams) (sqlc.GroupMember, error) {
 	return r.queries.CreateGroupMember(ctx, params)
 }
@@ -69,6 +101,14 @@ func (r *groupRepository) UpdateGroupMemberStatus(ctx context.Context, params sq
 	return r.queries.UpdateGroupMemberStatus(ctx, params)
 }
 
+func (r *groupRepository) UpdateGroupMemberRole(ctx context.Context, params sqlc.UpdateGroupMemberRoleParams) (sqlc.GroupMember, error) {
+	return r.queries.UpdateGroupMemberRole(ctx, params)
+}
+
+func (r *groupRepository) GetUserBalanceInGroup(ctx context.Context, params sqlc.GetUserBalanceInGroupParams) (sqlc.GetUserBalanceInGroupRow, error) {
+	return r.queries.GetUserBalanceInGroup(ctx, params)
+}
+
 func (r *groupRepository) ListGroupMembers(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListGroupMembersRow, error) {
 	return r.queries.ListGroupMembers(ctx, groupID)
 }
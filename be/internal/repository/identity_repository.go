@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+type IdentityRepository interface {
+	GetUserIdentity(ctx context.Context, params sqlc.GetUserIdentityParams) (sqlc.UserIdentity, error)
+	CreateUserIdentity(ctx context.Context, params sqlc.CreateUserIdentityParams) (sqlc.UserIdentity, error)
+	UpdateUserIdentityLogin(ctx context.Context, params sqlc.UpdateUserIdentityLoginParams) error
+
+	// OIDC login state, kept between the redirect to the provider and the callback
+	CreateOIDCLoginState(ctx context.Context, params sqlc.CreateOIDCLoginStateParams) error
+	ConsumeOIDCLoginState(ctx context.Context, state string) (sqlc.OidcLoginState, error)
+	DeleteExpiredOIDCLoginStates(ctx context.Context) error
+}
+
+type identityRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewIdentityRepository(queries *sqlc.Queries) IdentityRepository {
+	return &identityRepository{queries: queries}
+}
+
+func (r *identityRepository) GetUserIdentity(ctx context.Context, params sqlc.GetUserIdentityParams) (sqlc.UserIdentity, error) {
+	return r.queries.GetUserIdentity(ctx, params)
+}
+
+func (r *identityRepository) CreateUserIdentity(ctx context.Context, params sqlc.CreateUserIdentityParams) (sqlc.UserIdentity, error) {
+	return r.queries.CreateUserIdentity(ctx, params)
+}
+
+func (r *identityRepository) UpdateUserIdentityLogin(ctx context.Context, params sqlc.UpdateUserIdentityLoginParams) error {
+	return r.queries.UpdateUserIdentityLogin(ctx, params)
+}
+
+func (r *identityRepository) CreateOIDCLoginState(ctx context.Context, params sqlc.CreateOIDCLoginStateParams) error {
+	return r.queries.CreateOIDCLoginState(ctx, params)
+}
+
+func (r *identityRepository) ConsumeOIDCLoginState(ctx context.Context, state string) (sqlc.OidcLoginState, error) {
+	return r.queries.ConsumeOIDCLoginState(ctx, state)
+}
+
+func (r *identityRepository) DeleteExpiredOIDCLoginStates(ctx context.Context) error {
+	return r.queries.DeleteExpiredOIDCLoginStates(ctx)
+}
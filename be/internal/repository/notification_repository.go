@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+type NotificationRepository interface {
+	CreateNotifications(ctx context.Context, params sqlc.CreateNotificationsParams) error
+	ListUserNotifications(ctx context.Context, params sqlc.ListUserNotificationsParams) ([]sqlc.ListUserNotificationsRow, error)
+	CountUnreadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error)
+	MarkNotificationRead(ctx context.Context, params sqlc.MarkNotificationReadParams) (sqlc.Notification, error)
+	MarkAllNotificationsRead(ctx context.Context, userID pgtype.UUID) (int64, error)
+
+	// Muted categories
+	ListNotificationMutes(ctx context.Context, userID pgtype.UUID) ([]string, error)
+	MuteNotificationCategory(ctx context.Context, params sqlc.MuteNotificationCategoryParams) error
+	UnmuteNotificationCategory(ctx context.Context, params sqlc.UnmuteNotificationCategoryParams) error
+
+	// Recipients of an activity
+	ListExpenseParticipantIDs(ctx context.Context, expenseID pgtype.UUID) ([]pgtype.UUID, error)
+	GetSettlementParticipants(ctx context.Context, settlementID pgtype.UUID) (sqlc.GetSettlementParticipantsRow, error)
+	ListActiveGroupMemberIDs(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error)
+}
+
+type notificationRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewNotificationRepository(queries *sqlc.Queries) NotificationRepository {
+	return &notificationRepository{queries: queries}
+}
+
+func (r *notificationRepository) CreateNotifications(ctx context.Context, params sqlc.CreateNotificationsParams) error {
+	return r.queries.CreateNotifications(ctx, params)
+}
+
+func (r *notificationRepository) ListUserNotifications(ctx context.Context, params sqlc.ListUserNotificationsParams) ([]sqlc.ListUserNotificationsRow, error) {
+	return r.queries.ListUserNotifications(ctx, params)
+}
+
+func (r *notificationRepository) CountUnreadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return r.queries.CountUnreadNotifications(ctx, userID)
+}
+
+func (r *notificationRepository) MarkNotificationRead(ctx context.Context, params sqlc.MarkNotificationReadParams) (sqlc.Notification, error) {
+	return r.queries.MarkNotificationRead(ctx, params)
+}
+
+func (r *notificationRepository) MarkAllNotificationsRead(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return r.queries.MarkAllNotificationsRead(ctx, userID)
+}
+
+func (r *notificationRepository) ListNotificationMutes(ctx context.Context, userID pgtype.UUID) ([]string, error) {
+	return r.queries.ListNotificationMutes(ctx, userID)
+}
+
+func (r *notificationRepository) MuteNotificationCategory(ctx context.Context, params sqlc.MuteNotificationCategoryParams) error {
+	return r.queries.MuteNotificationCategory(ctx, params)
+}
+
+func (r *notificationRepository) UnmuteNotificationCategory(ctx context.Context, params sqlc.UnmuteNotificationCategoryParams) error {
+	return r.queries.UnmuteNotificationCategory(ctx, params)
+}
+
+func (r *notificationRepository) ListExpenseParticipantIDs(ctx context.Context, expenseID pgtype.UUID) ([]pgtype.UUID, error) {
+	return r.queries.ListExpenseParticipantIDs(ctx, expenseID)
+}
+
+func (r *notificationRepository) GetSettlementParticipants(ctx context.Context, settlementID pgtype.UUID) (sqlc.GetSettlementParticipantsRow, error) {
+	return r.queries.GetSettlementParticipants(ctx, settlementID)
+}
+
+func (r *notificationRepository) ListActiveGroupMemberIDs(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error) {
+	return r.queries.ListActiveGroupMemberIDs(ctx, groupID)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PaymentMethodRepository interface {
+	ListUserPaymentMethods(ctx context.Context, userID pgtype.UUID) ([]sqlc.UserPaymentMethod, error)
+	GetUserPaymentMethod(ctx context.Context, params sqlc.GetUserPaymentMethodParams) (sqlc.UserPaymentMethod, error)
+	UpsertUserPaymentMethod(ctx context.Context, params sqlc.UpsertUserPaymentMethodParams) (sqlc.UserPaymentMethod, error)
+	DeleteUserPaymentMethod(ctx context.Context, params sqlc.DeleteUserPaymentMethodParams) error
+}
+
+type paymentMethodRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewPaymentMethodRepository(queries *sqlc.Queries) PaymentMethodRepository {
+	return &paymentMethodRepository{queries: queries}
+}
+
+func (r *paymentMethodRepository) ListUserPaymentMethods(ctx context.Context, userID pgtype.UUID) ([]sqlc.UserPaymentMethod, error) {
+	return r.queries.ListUserPaymentMethods(ctx, userID)
+}
+
+func (r *paymentMethodRepository) GetUserPaymentMethod(ctx context.Context, params sqlc.GetUserPaymentMethodParams) (sqlc.UserPaymentMethod, error) {
+	return r.queries.GetUserPaymentMethod(ctx, params)
+}
+
+func (r *paymentMethodRepository) UpsertUserPaymentMethod(ctx context.Context, params sqlc.UpsertUserPaymentMethodParams) (sqlc.UserPaymentMethod, error) {
+	return r.queries.UpsertUserPaymentMethod(ctx, params)
+}
+
+func (r *paymentMethodRepository) DeleteUserPaymentMethod(ctx context.Context, params sqlc.DeleteUserPaymentMethodParams) error {
+	return r.queries.DeleteUserPaymentMethod(ctx, params)
+}
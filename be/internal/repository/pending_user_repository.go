@@ -15,6 +15,7 @@ type PendingUserRepository interface {
 
 	CreatePendingUser(ctx context.Context, params sqlc.CreatePendingUserParams) (sqlc.PendingUser, error)
 	GetPendingUserByEmail(ctx context.Context, email string) (sqlc.PendingUser, error)
+	GetGroupPendingUserByEmail(ctx context.Context, params sqlc.GetGroupPendingUserByEmailParams) (sqlc.PendingUser, error)
 	GetPendingUserByID(ctx context.Context, id pgtype.UUID) (sqlc.PendingUser, error)
 	UpdatePendingPaymentUserID(ctx context.Context, params sqlc.UpdatePendingPaymentUserIDParams) error
 	UpdatePendingSplitUserID(ctx context.Context, params sqlc.UpdatePendingSplitUserIDParams) error
@@ -51,6 +52,10 @@ func (r *pendingUserRepository) GetPendingUserByEmail(ctx context.Context, email
 	return r.queries.GetPendingUserByEmail(ctx, email)
 }
 
+func (r *pendingUserRepository) GetGroupPendingUserByEmail(ctx context.Context, params sqlc.GetGroupPendingUserByEmailParams) (sqlc.PendingUser, error) {
+	return r.queries.GetGroupPendingUserByEmail(ctx, params)
+}
+
 func (r *pendingUserRepository) GetPendingUserByID(ctx context.Context, id pgtype.UUID) (sqlc.PendingUser, error) {
 	return r.queries.GetPendingUserByID(ctx, id)
 }
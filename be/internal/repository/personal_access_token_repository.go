@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+type PersonalAccessTokenRepository interface {
+	CreatePersonalAccessToken(ctx context.Context, params sqlc.CreatePersonalAccessTokenParams) (sqlc.PersonalAccessToken, error)
+	GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (sqlc.PersonalAccessToken, error)
+	ListUserPersonalAccessTokens(ctx context.Context, userID pgtype.UUID) ([]sqlc.PersonalAccessToken, error)
+	CountUserPersonalAccessTokens(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeleteUserPersonalAccessToken(ctx context.Context, params sqlc.DeleteUserPersonalAccessTokenParams) (pgtype.UUID, error)
+	TouchPersonalAccessToken(ctx context.Context, id pgtype.UUID) error
+}
+
+type personalAccessTokenRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewPersonalAccessTokenRepository(queries *sqlc.Queries) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{queries: queries}
+}
+
+func (r *personalAccessTokenRepository) CreatePersonalAccessToken(ctx context.Context, params sqlc.CreatePersonalAccessTokenParams) (sqlc.PersonalAccessToken, error) {
+	return r.queries.CreatePersonalAccessToken(ctx, params)
+}
+
+func (r *personalAccessTokenRepository) GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (sqlc.PersonalAccessToken, error) {
+	return r.queries.GetPersonalAccessTokenByHash(ctx, tokenHash)
+}
+
+func (r *personalAccessTokenRepository) ListUserPersonalAccessTokens(ctx context.Context, userID pgtype.UUID) ([]sqlc.PersonalAccessToken, error) {
+	return r.queries.ListUserPersonalAccessTokens(ctx, userID)
+}
+
+func (r *personalAccessTokenRepository) CountUserPersonalAccessTokens(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return r.queries.CountUserPersonalAccessTokens(ctx, userID)
+}
+
+func (r *personalAccessTokenRepository) DeleteUserPersonalAccessToken(ctx context.Context, params sqlc.DeleteUserPersonalAccessTokenParams) (pgtype.UUID, error) {
+	return r.queries.DeleteUserPersonalAccessToken(ctx, params)
+}
+
+func (r *personalAccessTokenRepository) TouchPersonalAccessToken(ctx context.Context, id pgtype.UUID) error {
+	return r.queries.TouchPersonalAccessToken(ctx, id)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+type RateLimitRepository interface {
+	IncrementRateLimitCounter(ctx context.Context, params sqlc.IncrementRateLimitCounterParams) (sqlc.IncrementRateLimitCounterRow, error)
+	DeleteExpiredRateLimitCounters(ctx context.Context) error
+}
+
+type rateLimitRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewRateLimitRepository(queries *sqlc.Queries) RateLimitRepository {
+	return &rateLimitRepository{queries: queries}
+}
+
+func (r *rateLimitRepository) IncrementRateLimitCounter(ctx context.Context, params sqlc.IncrementRateLimitCounterParams) (sqlc.IncrementRateLimitCounterRow, error) {
+	return r.queries.IncrementRateLimitCounter(ctx, params)
+}
+
+func (r *rateLimitRepository) DeleteExpiredRateLimitCounters(ctx context.Context) error {
+	return r.queries.DeleteExpiredRateLimitCounters(ctx)
+}
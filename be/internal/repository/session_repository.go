@@ -4,10 +4,16 @@ import (
 	"context"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type SessionRepository interface {
+	// Transaction support
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	WithTx(tx pgx.Tx) SessionRepository
+
 	CreateSession(ctx context.Context, params sqlc.CreateSessionParams) (sqlc.Session, error)
 	GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (sqlc.Session, error)
 	UpdateSessionLastUsed(ctx context.Context, sessionID pgtype.UUID) error
@@ -15,6 +21,12 @@ type SessionRepository interface {
 	DeleteAllUserSessions(ctx context.Context, userID pgtype.UUID) error
 	DeleteExpiredSessions(ctx context.Context) error
 	GetActiveSessionsByUserID(ctx context.Context, userID pgtype.UUID) ([]sqlc.Session, error)
+	GetUserSession(ctx context.Context, params sqlc.GetUserSessionParams) (sqlc.Session, error)
+	DeleteSessionByID(ctx context.Context, sessionID pgtype.UUID) error
+	IsSessionActive(ctx context.Context, sessionID pgtype.UUID) (bool, error)
+	RotateSessionRefreshToken(ctx context.Context, params sqlc.RotateSessionRefreshTokenParams) (sqlc.Session, error)
+	GetRefreshTokenHistory(ctx context.Context, tokenHash string) (sqlc.RefreshTokenHistory, error)
+	CreateSecurityEvent(ctx context.Context, params sqlc.CreateSecurityEventParams) error
 
 	// Token blacklisting
 	BlacklistToken(ctx context.Context, params sqlc.BlacklistTokenParams) error
@@ -23,11 +35,23 @@ type SessionRepository interface {
 }
 
 type sessionRepository struct {
+	pool    *pgxpool.Pool
 	queries *sqlc.Queries
 }
 
-func NewSessionRepository(queries *sqlc.Queries) SessionRepository {
-	return &sessionRepository{queries: queries}
+func NewSessionRepository(pool *pgxpool.Pool, queries *sqlc.Queries) SessionRepository {
+	return &sessionRepository{pool: pool, queries: queries}
+}
+
+func (r *sessionRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+func (r *sessionRepository) WithTx(tx pgx.Tx) SessionRepository {
+	return &sessionRepository{
+		pool:    r.pool,
+		queries: r.queries.WithTx(tx),
+	}
 }
 
 func (r *sessionRepository) CreateSession(ctx context.Context, params sqlc.CreateSessionParams) (sqlc.Session, error) {
@@ -58,6 +82,30 @@ func (r *sessionRepository) GetActiveSessionsByUserID(ctx context.Context, userI
 	return r.queries.GetActiveSessionsByUserID(ctx, userID)
 }
 
+func (r *sessionRepository) GetUserSession(ctx context.Context, params sqlc.GetUserSessionParams) (sqlc.Session, error) {
+	return r.queries.GetUserSession(ctx, params)
+}
+
+func (r *sessionRepository) DeleteSessionByID(ctx context.Context, sessionID pgtype.UUID) error {
+	return r.queries.DeleteSessionByID(ctx, sessionID)
+}
+
+func (r *sessionRepository) IsSessionActive(ctx context.Context, sessionID pgtype.UUID) (bool, error) {
+	return r.queries.IsSessionActive(ctx, sessionID)
+}
+
+func (r *sessionRepository) RotateSessionRefreshToken(ctx context.Context, params sqlc.RotateSessionRefreshTokenParams) (sqlc.Session, error) {
+	return r.queries.RotateSessionRefreshToken(ctx, params)
+}
+
+func (r *sessionRepository) GetRefreshTokenHistory(ctx context.Context, tokenHash string) (sqlc.RefreshTokenHistory, error) {
+	return r.queries.GetRefreshTokenHistory(ctx, tokenHash)
+}
+
+func (r *sessionRepository) CreateSecurityEvent(ctx context.Context, params sqlc.CreateSecurityEventParams) error {
+	return r.queries.CreateSecurityEvent(ctx, params)
+}
+
 func (r *sessionRepository) BlacklistToken(ctx context.Context, params sqlc.BlacklistTokenParams) error {
 	return r.queries.BlacklistToken(ctx, params)
 }
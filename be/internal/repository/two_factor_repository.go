@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+type TwoFactorRepository interface {
+	// Transaction support
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	WithTx(tx pgx.Tx) TwoFactorRepository
+
+	GetUserTwoFactor(ctx context.Context, userID pgtype.UUID) (sqlc.UserTwoFactor, error)
+	UpsertUserTwoFactorSecret(ctx context.Context, params sqlc.UpsertUserTwoFactorSecretParams) (sqlc.UserTwoFactor, error)
+	EnableUserTwoFactor(ctx context.Context, userID pgtype.UUID) error
+	RecordTwoFactorStep(ctx context.Context, params sqlc.RecordTwoFactorStepParams) (sqlc.UserTwoFactor, error)
+	RecordTwoFactorFailure(ctx context.Context, userID pgtype.UUID) (int32, error)
+	LockUserTwoFactor(ctx context.Context, params sqlc.LockUserTwoFactorParams) error
+	ResetTwoFactorFailures(ctx context.Context, userID pgtype.UUID) error
+	DeleteUserTwoFactor(ctx context.Context, userID pgtype.UUID) error
+
+	// Recovery codes
+	CreateRecoveryCodes(ctx context.Context, params sqlc.CreateRecoveryCodesParams) error
+	UseRecoveryCode(ctx context.Context, params sqlc.UseRecoveryCodeParams) (sqlc.UserRecoveryCode, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeleteUserRecoveryCodes(ctx context.Context, userID pgtype.UUID) error
+
+	// Login challenges waiting for a second factor
+	CreateTwoFactorChallenge(ctx context.Context, params sqlc.CreateTwoFactorChallengeParams) error
+	GetTwoFactorChallenge(ctx context.Context, tokenHash string) (sqlc.TwoFactorChallenge, error)
+	RecordTwoFactorChallengeFailure(ctx context.Context, tokenHash string) (int32, error)
+	DeleteTwoFactorChallenge(ctx context.Context, tokenHash string) error
+	DeleteExpiredTwoFactorChallenges(ctx context.Context) error
+}
+
+type twoFactorRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewTwoFactorRepository(pool *pgxpool.Pool, queries *sqlc.Queries) TwoFactorRepository {
+	return &twoFactorRepository{pool: pool, queries: queries}
+}
+
+func (r *twoFactorRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+func (r *twoFactorRepository) WithTx(tx pgx.Tx) TwoFactorRepository {
+	return &twoFactorRepository{
+		pool:    r.pool,
+		queries: r.queries.WithTx(tx),
+	}
+}
+
+func (r *twoFactorRepository) GetUserTwoFactor(ctx context.Context, userID pgtype.UUID) (sqlc.UserTwoFactor, error) {
+	return r.queries.GetUserTwoFactor(ctx, userID)
+}
+
+func (r *twoFactorRepository) UpsertUserTwoFactorSecret(ctx context.Context, params sqlc.UpsertUserTwoFactorSecretParams) (sqlc.UserTwoFactor, error) {
+	return r.queries.UpsertUserTwoFactorSecret(ctx, params)
+}
+
+func (r *twoFactorRepository) EnableUserTwoFactor(ctx context.Context, userID pgtype.UUID) error {
+	return r.queries.EnableUserTwoFactor(ctx, userID)
+}
+
+func (r *twoFactorRepository) RecordTwoFactorStep(ctx context.Context, params sqlc.RecordTwoFactorStepParams) (sqlc.UserTwoFactor, error) {
+	return r.queries.RecordTwoFactorStep(ctx, params)
+}
+
+func (r *twoFactorRepository) RecordTwoFactorFailure(ctx context.Context, userID pgtype.UUID) (int32, error) {
+	return r.queries.RecordTwoFactorFailure(ctx, userID)
+}
+
+func (r *twoFactorRepository) LockUserTwoFactor(ctx context.Context, params sqlc.LockUserTwoFactorParams) error {
+	return r.queries.LockUserTwoFactor(ctx, params)
+}
+
+func (r *twoFactorRepository) ResetTwoFactorFailures(ctx context.Context, userID pgtype.UUID) error {
+	return r.queries.ResetTwoFactorFailures(ctx, userID)
+}
+
+func (r *twoFactorRepository) DeleteUserTwoFactor(ctx context.Context, userID pgtype.UUID) error {
+	return r.queries.DeleteUserTwoFactor(ctx, userID)
+}
+
+func (r *twoFactorRepository) CreateRecoveryCodes(ctx context.Context, params sqlc.CreateRecoveryCodesParams) error {
+	return r.queries.CreateRecoveryCodes(ctx, params)
+}
+
+func (r *twoFactorRepository) UseRecoveryCode(ctx context.Context, params sqlc.UseRecoveryCodeParams) (sqlc.UserRecoveryCode, error) {
+	return r.queries.UseRecoveryCode(ctx, params)
+}
+
+func (r *twoFactorRepository) CountUnusedRecoveryCodes(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return r.queries.CountUnusedRecoveryCodes(ctx, userID)
+}
+
+func (r *twoFactorRepository) DeleteUserRecoveryCodes(ctx context.Context, userID pgtype.UUID) error {
+	return r.queries.DeleteUserRecoveryCodes(ctx, userID)
+}
+
+func (r *twoFactorRepository) CreateTwoFactorChallenge(ctx context.Context, params sqlc.CreateTwoFactorChallengeParams) error {
+	return r.queries.CreateTwoFactorChallenge(ctx, params)
+}
+
+func (r *twoFactorRepository) GetTwoFactorChallenge(ctx context.Context, tokenHash string) (sqlc.TwoFactorChallenge, error) {
+	return r.queries.GetTwoFactorChallenge(ctx, tokenHash)
+}
+
+func (r *twoFactorRepository) RecordTwoFactorChallengeFailure(ctx context.Context, tokenHash string) (int32, error) {
+	return r.queries.RecordTwoFactorChallengeFailure(ctx, tokenHash)
+}
+
+func (r *twoFactorRepository) DeleteTwoFactorChallenge(ctx context.Context, tokenHash string) error {
+	return r.queries.DeleteTwoFactorChallenge(ctx, tokenHash)
+}
+
+func (r *twoFactorRepository) DeleteExpiredTwoFactorChallenges(ctx context.Context) error {
+	return r.queries.DeleteExpiredTwoFactorChallenges(ctx)
+}
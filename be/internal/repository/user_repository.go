@@ -11,6 +11,14 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, params sqlc.CreateUserParams) (sqlc.User, error)
 	GetUserByEmail(ctx context.Context, email string) (sqlc.User, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (sqlc.User, error)
+	MarkUserEmailVerified(ctx context.Context, id pgtype.UUID) error
+	UpdateUserPassword(ctx context.Context, params sqlc.UpdateUserPasswordParams) error
+
+	// Failed sign-in tracking for account lockout
+	GetUserLoginAttempts(ctx context.Context, userID pgtype.UUID) (sqlc.UserLoginAttempt, error)
+	RecordFailedLogin(ctx context.Context, params sqlc.RecordFailedLoginParams) (sqlc.UserLoginAttempt, error)
+	LockUserLogin(ctx context.Context, params sqlc.LockUserLoginParams) error
+	ResetFailedLogins(ctx context.Context, userID pgtype.UUID) error
 }
 
 type userRepository struct {
@@ -32,3 +40,27 @@ func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (sqlc
 func (r *userRepository) GetUserByID(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
 	return r.queries.GetUserByID(ctx, id)
 }
+
+func (r *userRepository) MarkUserEmailVerified(ctx context.Context, id pgtype.UUID) error {
+	return r.queries.MarkUserEmailVerified(ctx, id)
+}
+
+func (r *userRepository) UpdateUserPassword(ctx context.Context, params sqlc.UpdateUserPasswordParams) error {
+	return r.queries.UpdateUserPassword(ctx, params)
+}
+
+func (r *userRepository) GetUserLoginAttempts(ctx context.Context, userID pgtype.UUID) (sqlc.UserLoginAttempt, error) {
+	return r.queries.GetUserLoginAttempts(ctx, userID)
+}
+
+func (r *userRepository) RecordFailedLogin(ctx context.Context, params sqlc.RecordFailedLoginParams) (sqlc.UserLoginAttempt, error) {
+	return r.queries.RecordFailedLogin(ctx, params)
+}
+
+func (r *userRepository) LockUserLogin(ctx context.Context, params sqlc.LockUserLoginParams) error {
+	return r.queries.LockUserLogin(ctx, params)
+}
+
+func (r *userRepository) ResetFailedLogins(ctx context.Context, userID pgtype.UUID) error {
+	return r.queries.ResetFailedLogins(ctx, userID)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type UserTokenRepository interface {
+	CreateUserToken(ctx context.Context, params sqlc.CreateUserTokenParams) (sqlc.UserToken, error)
+	GetUserTokenByHash(ctx context.Context, tokenHash string) (sqlc.UserToken, error)
+	MarkUserTokenUsed(ctx context.Context, id pgtype.UUID) (sqlc.UserToken, error)
+	InvalidateUserTokens(ctx context.Context, params sqlc.InvalidateUserTokensParams) error
+	DeleteExpiredUserTokens(ctx context.Context) error
+}
+
+type userTokenRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewUserTokenRepository(queries *sqlc.Queries) UserTokenRepository {
+	return &userTokenRepository{queries: queries}
+}
+
+func (r *userTokenRepository) CreateUserToken(ctx context.Context, params sqlc.CreateUserTokenParams) (sqlc.UserToken, error) {
+	return r.queries.CreateUserToken(ctx, params)
+}
+
+func (r *userTokenRepository) GetUserTokenByHash(ctx context.Context, tokenHash string) (sqlc.UserToken, error) {
+	return r.queries.GetUserTokenByHash(ctx, tokenHash)
+}
+
+func (r *userTokenRepository) MarkUserTokenUsed(ctx context.Context, id pgtype.UUID) (sqlc.UserToken, error) {
+	return r.queries.MarkUserTokenUsed(ctx, id)
+}
+
+func (r *userTokenRepository) InvalidateUserTokens(ctx context.Context, params sqlc.InvalidateUserTokensParams) error {
+	return r.queries.InvalidateUserTokens(ctx, params)
+}
+
+func (r *userTokenRepository) DeleteExpiredUserTokens(ctx context.Context) error {
+	return r.queries.DeleteExpiredUserTokens(ctx)
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+type WebhookRepository interface {
+	CreateGroupWebhook(ctx context.Context, params sqlc.CreateGroupWebhookParams) (sqlc.GroupWebhook, error)
+	GetGroupWebhook(ctx context.Context, params sqlc.GetGroupWebhookParams) (sqlc.GroupWebhook, error)
+	ListGroupWebhooks(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupWebhook, error)
+	CountGroupWebhooks(ctx context.Context, groupID pgtype.UUID) (int64, error)
+	UpdateGroupWebhook(ctx context.Context, params sqlc.UpdateGroupWebhookParams) (sqlc.GroupWebhook, error)
+	DeleteGroupWebhook(ctx context.Context, params sqlc.DeleteGroupWebhookParams) error
+
+	// Deliveries, which double as the delivery log
+	EnqueueWebhookDeliveries(ctx context.Context, params sqlc.EnqueueWebhookDeliveriesParams) error
+	CreateWebhookDelivery(ctx context.Context, params sqlc.CreateWebhookDeliveryParams) (sqlc.WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, params sqlc.GetWebhookDeliveryParams) (sqlc.WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, params sqlc.ListWebhookDeliveriesParams) ([]sqlc.WebhookDelivery, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, limit int32) ([]sqlc.ClaimDueWebhookDeliveriesRow, error)
+	MarkWebhookDeliveryDelivered(ctx context.Context, params sqlc.MarkWebhookDeliveryDeliveredParams) error
+	MarkWebhookDeliveryRetry(ctx context.Context, params sqlc.MarkWebhookDeliveryRetryParams) error
+	MarkWebhookDeliveryFailed(ctx context.Context, params sqlc.MarkWebhookDeliveryFailedParams) error
+}
+
+type webhookRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewWebhookRepository(queries *sqlc.Queries) WebhookRepository {
+	return &webhookRepository{queries: queries}
+}
+
+func (r *webhookRepository) CreateGroupWebhook(ctx context.Context, params sqlc.CreateGroupWebhookParams) (sqlc.GroupWebhook, error) {
+	return r.queries.CreateGroupWebhook(ctx, params)
+}
+
+func (r *webhookRepository) GetGroupWebhook(ctx context.Context, params sqlc.GetGroupWebhookParams) (sqlc.GroupWebhook, error) {
+	return r.queries.GetGroupWebhook(ctx, params)
+}
+
+func (r *webhookRepository) ListGroupWebhooks(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupWebhook, error) {
+	return r.queries.ListGroupWebhooks(ctx, groupID)
+}
+
+func (r *webhookRepository) CountGroupWebhooks(ctx context.Context, groupID pgtype.UUID) (int64, error) {
+	return r.queries.CountGroupWebhooks(ctx, groupID)
+}
+
+func (r *webhookRepository) UpdateGroupWebhook(ctx context.Context, params sqlc.UpdateGroupWebhookParams) (sqlc.GroupWebhook, error) {
+	return r.queries.UpdateGroupWebhook(ctx, params)
+}
+
+func (r *webhookRepository) DeleteGroupWebhook(ctx context.Context, params sqlc.DeleteGroupWebhookParams) error {
+	return r.queries.DeleteGroupWebhook(ctx, params)
+}
+
+func (r *webhookRepository) EnqueueWebhookDeliveries(ctx context.Context, params sqlc.EnqueueWebhookDeliveriesParams) error {
+	return r.queries.EnqueueWebhookDeliveries(ctx, params)
+}
+
+func (r *webhookRepository) CreateWebhookDelivery(ctx context.Context, params sqlc.CreateWebhookDeliveryParams) (sqlc.WebhookDelivery, error) {
+	return r.queries.CreateWebhookDelivery(ctx, params)
+}
+
+func (r *webhookRepository) GetWebhookDelivery(ctx context.Context, params sqlc.GetWebhookDeliveryParams) (sqlc.WebhookDelivery, error) {
+	return r.queries.GetWebhookDelivery(ctx, params)
+}
+
+func (r *webhookRepository) ListWebhookDeliveries(ctx context.Context, params sqlc.ListWebhookDeliveriesParams) ([]sqlc.WebhookDelivery, error) {
+	return r.queries.ListWebhookDeliveries(ctx, params)
+}
+
+func (r *webhookRepository) ClaimDueWebhookDeliveries(ctx context.Context, limit int32) ([]sqlc.ClaimDueWebhookDeliveriesRow, error) {
+	return r.queries.ClaimDueWebhookDeliveries(ctx, limit)
+}
+
+func (r *webhookRepository) MarkWebhookDeliveryDelivered(ctx context.Context, params sqlc.MarkWebhookDeliveryDeliveredParams) error {
+	return r.queries.MarkWebhookDeliveryDelivered(ctx, params)
+}
+
+func (r *webhookRepository) MarkWebhookDeliveryRetry(ctx context.Context, params sqlc.MarkWebhookDeliveryRetryParams) error {
+	return r.queries.MarkWebhookDeliveryRetry(ctx, params)
+}
+
+func (r *webhookRepository) MarkWebhookDeliveryFailed(ctx context.Context, params sqlc.MarkWebhookDeliveryFailedParams) error {
+	return r.queries.MarkWebhookDeliveryFailed(ctx, params)
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+const (
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposePasswordReset     = "password_reset"
+
+	EmailVerificationTokenTTL = 48 * time.Hour
+	PasswordResetTokenTTL     = time.Hour
+)
+
+var (
+	ErrInvalidAccountToken  = errors.New("invalid or expired token")
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+)
+
+// AccountService handles the emailed-token flows: verifying an address and
+// resetting a forgotten password. Tokens are single use; only their SHA-256
+// hash is stored.
+type AccountService interface {
+	RequestEmailVerification(ctx context.Context, userID pgtype.UUID) error
+	VerifyEmail(ctx context.Context, token string) error
+	// RequestPasswordReset succeeds for unknown emails too, so callers can't probe for accounts.
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	CleanupExpiredTokens(ctx context.Context) error
+}
+
+type accountService struct {
+	userRepo     repository.UserRepository
+	tokenRepo    repository.UserTokenRepository
+	authService  AuthService
+	emailService EmailService
+	mailer       Mailer
+	baseURL      string
+}
+
+// NewAccountService builds links in emails from baseURL, the frontend origin.
+// Password reset email goes through the outbox, so a slow mail server can't
+// make the response time reveal whether an account exists.
+func NewAccountService(
+	userRepo repository.UserRepository,
+	tokenRepo repository.UserTokenRepository,
+	authService AuthService,
+	emailService EmailService,
+	mailer Mailer,
+	baseURL string,
+) AccountService {
+	return &accountService{
+		userRepo:     userRepo,
+		tokenRepo:    tokenRepo,
+		authService:  authService,
+		emailService: emailService,
+		mailer:       mailer,
+		baseURL:      strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (s *accountService) RequestEmailVerification(ctx context.Context, userID pgtype.UUID) error {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if user.EmailVerifiedAt.Valid {
+		return ErrEmailAlreadyVerified
+	}
+
+	token, err := s.issueToken(ctx, user.ID, TokenPurposeEmailVerification, EmailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	link := s.link("/verify-email", token)
+	return s.mailer.Send(ctx, EmailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		TextBody: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %d hours.\n",
+			displayName(user), link, int(EmailVerificationTokenTTL.Hours()),
+		),
+	})
+}
+
+func (s *accountService) VerifyEmail(ctx context.Context, token string) error {
+	userToken, err := s.consumeToken(ctx, token, TokenPurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+	return s.userRepo.MarkUserEmailVerified(ctx, userToken.UserID)
+}
+
+func (s *accountService) RequestPasswordReset(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return ErrUserEmailRequired
+	}
+
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := s.issueToken(ctx, user.ID, TokenPurposePasswordReset, PasswordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.emailService.Enqueue(ctx, EnqueueEmailInput{
+		To:       user.Email,
+		Template: EmailTemplatePasswordReset,
+		Language: user.Language,
+		Data: PasswordResetEmailData{
+			Name:      displayName(user),
+			Link:      s.link("/reset-password", token),
+			ExpiresIn: int(PasswordResetTokenTTL.Minutes()),
+		},
+	})
+	return err
+}
+
+func (s *accountService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	userToken, err := s.consumeToken(ctx, token, TokenPurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
+		ID:           userToken.UserID,
+		PasswordHash: string(hashedPassword),
+	}); err != nil {
+		return err
+	}
+
+	// Any other outstanding reset links are now stale
+	if err := s.tokenRepo.InvalidateUserTokens(ctx, sqlc.InvalidateUserTokensParams{
+		UserID:  userToken.UserID,
+		Purpose: TokenPurposePasswordReset,
+	}); err != nil {
+		return err
+	}
+
+	// Receiving the reset email proves the user owns the address
+	if err := s.userRepo.MarkUserEmailVerified(ctx, userToken.UserID); err != nil {
+		return err
+	}
+
+	// Sign out everywhere, in case the reset was prompted by a compromised account
+	return s.authService.LogoutAllSessions(ctx, userToken.UserID)
+}
+
+func (s *accountService) CleanupExpiredTokens(ctx context.Context) error {
+	return s.tokenRepo.DeleteExpiredUserTokens(ctx)
+}
+
+// issueToken replaces any outstanding token for the same purpose and returns
+// the raw value to put in the email.
+func (s *accountService) issueToken(ctx context.Context, userID pgtype.UUID, purpose string, ttl time.Duration) (string, error) {
+	if err := s.tokenRepo.InvalidateUserTokens(ctx, sqlc.InvalidateUserTokensParams{
+		UserID:  userID,
+		Purpose: purpose,
+	}); err != nil {
+		return "", err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	_, err := s.tokenRepo.CreateUserToken(ctx, sqlc.CreateUserTokenParams{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(ttl), Valid: true},
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// consumeToken looks a token up by hash and marks it used. The update only
+// matches unused, unexpired rows, so concurrent redemptions can't both succeed.
+func (s *accountService) consumeToken(ctx context.Context, token, purpose string) (sqlc.UserToken, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return sqlc.UserToken{}, ErrInvalidAccountToken
+	}
+
+	userToken, err := s.tokenRepo.GetUserTokenByHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.UserToken{}, ErrInvalidAccountToken
+		}
+		return sqlc.UserToken{}, err
+	}
+	if userToken.Purpose != purpose || userToken.UsedAt.Valid || !userToken.ExpiresAt.Time.After(time.Now()) {
+		return sqlc.UserToken{}, ErrInvalidAccountToken
+	}
+
+	userToken, err = s.tokenRepo.MarkUserTokenUsed(ctx, userToken.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.UserToken{}, ErrInvalidAccountToken
+		}
+		return sqlc.UserToken{}, err
+	}
+	return userToken, nil
+}
+
+func (s *accountService) link(path, token string) string {
+	return s.baseURL + path + "?token=" + url.QueryEscape(token)
+}
+
+func displayName(user sqlc.User) string {
+	if user.Name.Valid && strings.TrimSpace(user.Name.String) != "" {
+		return user.Name.String
+	}
+	return user.Email
+}
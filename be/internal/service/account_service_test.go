@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockUserTokenRepository for testing
+type MockUserTokenRepository struct {
+	CreateUserTokenFunc         func(ctx context.Context, params sqlc.CreateUserTokenParams) (sqlc.UserToken, error)
+	GetUserTokenByHashFunc      func(ctx context.Context, tokenHash string) (sqlc.UserToken, error)
+	MarkUserTokenUsedFunc       func(ctx context.Context, id pgtype.UUID) (sqlc.UserToken, error)
+	InvalidateUserTokensFunc    func(ctx context.Context, params sqlc.InvalidateUserTokensParams) error
+	DeleteExpiredUserTokensFunc func(ctx context.Context) error
+}
+
+func (m *MockUserTokenRepository) CreateUserToken(ctx context.Context, params sqlc.CreateUserTokenParams) (sqlc.UserToken, error) {
+	if m.CreateUserTokenFunc != nil {
+		return m.CreateUserTokenFunc(ctx, params)
+	}
+	return sqlc.UserToken{}, nil
+}
+
+func (m *MockUserTokenRepository) GetUserTokenByHash(ctx context.Context, tokenHash string) (sqlc.UserToken, error) {
+	if m.GetUserTokenByHashFunc != nil {
+		return m.GetUserTokenByHashFunc(ctx, tokenHash)
+	}
+	return sqlc.UserToken{}, pgx.ErrNoRows
+}
+
+func (m *MockUserTokenRepository) MarkUserTokenUsed(ctx context.Context, id pgtype.UUID) (sqlc.UserToken, error) {
+	if m.MarkUserTokenUsedFunc != nil {
+		return m.MarkUserTokenUsedFunc(ctx, id)
+	}
+	return sqlc.UserToken{}, pgx.ErrNoRows
+}
+
+func (m *MockUserTokenRepository) InvalidateUserTokens(ctx context.Context, params sqlc.InvalidateUserTokensParams) error {
+	if m.InvalidateUserTokensFunc != nil {
+		return m.InvalidateUserTokensFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockUserTokenRepository) DeleteExpiredUserTokens(ctx context.Context) error {
+	if m.DeleteExpiredUserTokensFunc != nil {
+		return m.DeleteExpiredUserTokensFunc(ctx)
+	}
+	return nil
+}
+
+// MockAuthService for testing; only LogoutAllSessions and SignIn are configurable
+type MockAuthService struct {
+	AuthService
+	LogoutAllSessionsFunc func(ctx context.Context, userID pgtype.UUID) error
+	SignInFunc            func(ctx context.Context, user sqlc.User, userAgent, ipAddress string) (LoginResult, error)
+}
+
+func (m *MockAuthService) SignIn(ctx context.Context, user sqlc.User, userAgent, ipAddress string) (LoginResult, error) {
+	if m.SignInFunc != nil {
+		return m.SignInFunc(ctx, user, userAgent, ipAddress)
+	}
+	return LoginResult{AccessToken: "access-token", RefreshToken: "refresh-token", ExpiresIn: 900}, nil
+}
+
+func (m *MockAuthService) LogoutAllSessions(ctx context.Context, userID pgtype.UUID) error {
+	if m.LogoutAllSessionsFunc != nil {
+		return m.LogoutAllSessionsFunc(ctx, userID)
+	}
+	return nil
+}
+
+// newTokenStore backs a MockUserTokenRepository with a map keyed by token hash.
+func newTokenStore() (*MockUserTokenRepository, map[string]*sqlc.UserToken) {
+	tokens := map[string]*sqlc.UserToken{}
+	repo := &MockUserTokenRepository{
+		CreateUserTokenFunc: func(ctx context.Context, params sqlc.CreateUserTokenParams) (sqlc.UserToken, error) {
+			token := &sqlc.UserToken{
+				ID:        testutil.CreateTestUUID(len(tokens) + 100),
+				UserID:    params.UserID,
+				Purpose:   params.Purpose,
+				TokenHash: params.TokenHash,
+				ExpiresAt: params.ExpiresAt,
+			}
+			tokens[params.TokenHash] = token
+			return *token, nil
+		},
+		GetUserTokenByHashFunc: func(ctx context.Context, tokenHash string) (sqlc.UserToken, error) {
+			if token, ok := tokens[tokenHash]; ok {
+				return *token, nil
+			}
+			return sqlc.UserToken{}, pgx.ErrNoRows
+		},
+		MarkUserTokenUsedFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.UserToken, error) {
+			for _, token := range tokens {
+				if token.ID == id && !token.UsedAt.Valid && token.ExpiresAt.Time.After(time.Now()) {
+					token.UsedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+					return *token, nil
+				}
+			}
+			return sqlc.UserToken{}, pgx.ErrNoRows
+		},
+		InvalidateUserTokensFunc: func(ctx context.Context, params sqlc.InvalidateUserTokensParams) error {
+			for _, token := range tokens {
+				if token.UserID == params.UserID && token.Purpose == params.Purpose && !token.UsedAt.Valid {
+					token.UsedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+				}
+			}
+			return nil
+		},
+	}
+	return repo, tokens
+}
+
+// tokenFromMail pulls the token query param out of the link in an email body.
+func tokenFromMail(t *testing.T, msg EmailMessage) string {
+	t.Helper()
+	for _, field := range strings.Fields(msg.TextBody) {
+		if strings.HasPrefix(field, "http") {
+			u, err := url.Parse(field)
+			if err != nil {
+				t.Fatalf("invalid link in email: %v", err)
+			}
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatalf("no link in email body: %q", msg.TextBody)
+	return ""
+}
+
+// newOutboxEmailService renders queued email like the outbox does and returns
+// it as messages, so tests can read the links out of it.
+func newOutboxEmailService() (EmailService, func() []EmailMessage) {
+	var queued []EmailMessage
+	repo := &MockEmailOutboxRepository{
+		EnqueueEmailFunc: func(ctx context.Context, params sqlc.EnqueueEmailParams) (sqlc.EmailOutbox, error) {
+			queued = append(queued, EmailMessage{
+				To:       params.ToAddress,
+				Subject:  params.Subject,
+				TextBody: params.TextBody,
+				HTMLBody: params.HtmlBody,
+			})
+			return sqlc.EmailOutbox{}, nil
+		},
+	}
+	return NewEmailService(repo, NewMemoryMailer(false)), func() []EmailMessage { return queued }
+}
+
+func TestAccountService_RequestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+	userID := testutil.CreateTestUUID(1)
+
+	tests := []struct {
+		name      string
+		email     string
+		wantMails int
+	}{
+		{name: "known email sends a reset link", email: "  Alice@Example.com ", wantMails: 1},
+		{name: "unknown email succeeds silently", email: "nobody@example.com", wantMails: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &testutil.MockUserRepository{
+				GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+					if email == "alice@example.com" {
+						return sqlc.User{ID: userID, Email: email}, nil
+					}
+					return sqlc.User{}, pgx.ErrNoRows
+				},
+			}
+			tokenRepo, tokens := newTokenStore()
+			emailService, queued := newOutboxEmailService()
+			mailer := NewMemoryMailer(false)
+			svc := NewAccountService(userRepo, tokenRepo, &MockAuthService{}, emailService, mailer, "https://app.example.com/")
+
+			if err := svc.RequestPasswordReset(ctx, tt.email); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(mailer.Messages()) != 0 {
+				t.Errorf("expected the reset email to be queued, not sent inline")
+			}
+
+			messages := queued()
+			if len(messages) != tt.wantMails {
+				t.Fatalf("expected %d emails, got %d", tt.wantMails, len(messages))
+			}
+			if tt.wantMails == 0 {
+				return
+			}
+
+			if !strings.Contains(messages[0].TextBody, "https://app.example.com/reset-password?token=") {
+				t.Errorf("expected reset link in body, got %q", messages[0].TextBody)
+			}
+			raw := tokenFromMail(t, messages[0])
+			stored, ok := tokens[hashToken(raw)]
+			if !ok {
+				t.Fatal("expected token to be stored by its hash")
+			}
+			if stored.TokenHash == raw {
+				t.Error("raw token must not be stored")
+			}
+			if stored.Purpose != TokenPurposePasswordReset {
+				t.Errorf("expected purpose %q, got %q", TokenPurposePasswordReset, stored.Purpose)
+			}
+			if ttl := time.Until(stored.ExpiresAt.Time); ttl <= 0 || ttl > PasswordResetTokenTTL {
+				t.Errorf("unexpected token ttl %v", ttl)
+			}
+		})
+	}
+}
+
+func TestAccountService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	userID := testutil.CreateTestUUID(1)
+
+	userRepo := &testutil.MockUserRepository{
+		GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+			return sqlc.User{ID: userID, Email: email}, nil
+		},
+	}
+	var newHash string
+	userRepo.UpdateUserPasswordFunc = func(ctx context.Context, params sqlc.UpdateUserPasswordParams) error {
+		if params.ID != userID {
+			t.Errorf("password updated for wrong user")
+		}
+		newHash = params.PasswordHash
+		return nil
+	}
+	var loggedOut int
+	authService := &MockAuthService{
+		LogoutAllSessionsFunc: func(ctx context.Context, id pgtype.UUID) error {
+			if id != userID {
+				t.Errorf("sessions revoked for wrong user")
+			}
+			loggedOut++
+			return nil
+		},
+	}
+	tokenRepo, tokens := newTokenStore()
+	emailService, queued := newOutboxEmailService()
+	svc := NewAccountService(userRepo, tokenRepo, authService, emailService, NewMemoryMailer(false), "https://app.example.com")
+
+	if err := svc.RequestPasswordReset(ctx, "alice@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first := tokenFromMail(t, queued()[0])
+	if err := svc.RequestPasswordReset(ctx, "alice@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second := tokenFromMail(t, queued()[1])
+
+	// A newer request supersedes the older link
+	if err := svc.ResetPassword(ctx, first, "new-password"); err != ErrInvalidAccountToken {
+		t.Errorf("expected superseded token to be rejected, got %v", err)
+	}
+
+	if err := svc.ResetPassword(ctx, second, "new-password"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(newHash), []byte("new-password")) != nil {
+		t.Error("expected the new password to be stored as a bcrypt hash")
+	}
+	if loggedOut != 1 {
+		t.Errorf("expected sessions to be revoked once, got %d", loggedOut)
+	}
+
+	// Tokens are single use
+	if err := svc.ResetPassword(ctx, second, "another-password"); err != ErrInvalidAccountToken {
+		t.Errorf("expected reused token to be rejected, got %v", err)
+	}
+
+	// Expired and wrong-purpose tokens are rejected
+	tokens[hashToken("expired")] = &sqlc.UserToken{
+		ID: testutil.CreateTestUUID(50), UserID: userID, Purpose: TokenPurposePasswordReset,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true},
+	}
+	tokens[hashToken("verification")] = &sqlc.UserToken{
+		ID: testutil.CreateTestUUID(51), UserID: userID, Purpose: TokenPurposeEmailVerification,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+	for _, token := range []string{"expired", "verification", "unknown", ""} {
+		if err := svc.ResetPassword(ctx, token, "new-password"); err != ErrInvalidAccountToken {
+			t.Errorf("token %q: expected ErrInvalidAccountToken, got %v", token, err)
+		}
+	}
+	if loggedOut != 1 {
+		t.Errorf("rejected tokens must not revoke sessions, got %d revocations", loggedOut)
+	}
+}
+
+func TestAccountService_EmailVerification(t *testing.T) {
+	ctx := context.Background()
+	userID := testutil.CreateTestUUID(1)
+
+	t.Run("already verified", func(t *testing.T) {
+		userRepo := &testutil.MockUserRepository{
+			GetUserByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+				return sqlc.User{ID: id, Email: "alice@example.com", EmailVerifiedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}}, nil
+			},
+		}
+		tokenRepo, _ := newTokenStore()
+		mailer := NewMemoryMailer(false)
+		svc := NewAccountService(userRepo, tokenRepo, &MockAuthService{}, &MockEmailService{}, mailer, "https://app.example.com")
+
+		if err := svc.RequestEmailVerification(ctx, userID); err != ErrEmailAlreadyVerified {
+			t.Errorf("expected ErrEmailAlreadyVerified, got %v", err)
+		}
+		if len(mailer.Messages()) != 0 {
+			t.Error("expected no email to be sent")
+		}
+	})
+
+	t.Run("verify with emailed token", func(t *testing.T) {
+		var verified []pgtype.UUID
+		userRepo := &testutil.MockUserRepository{
+			GetUserByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+				return sqlc.User{ID: id, Email: "alice@example.com"}, nil
+			},
+			MarkUserEmailVerifiedFunc: func(ctx context.Context, id pgtype.UUID) error {
+				verified = append(verified, id)
+				return nil
+			},
+		}
+		tokenRepo, _ := newTokenStore()
+		mailer := NewMemoryMailer(false)
+		svc := NewAccountService(userRepo, tokenRepo, &MockAuthService{}, &MockEmailService{}, mailer, "https://app.example.com")
+
+		if err := svc.RequestEmailVerification(ctx, userID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		messages := mailer.Messages()
+		if len(messages) != 1 || messages[0].To != "alice@example.com" {
+			t.Fatalf("expected one email to alice, got %+v", messages)
+		}
+
+		token := tokenFromMail(t, messages[0])
+		if err := svc.VerifyEmail(ctx, token); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(verified) != 1 || verified[0] != userID {
+			t.Errorf("expected user to be marked verified, got %v", verified)
+		}
+		if err := svc.VerifyEmail(ctx, token); err != ErrInvalidAccountToken {
+			t.Errorf("expected reused token to be rejected, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrAttachmentObjectNotFound = errors.New("stored file not found")
+	ErrInvalidStorageKey        = errors.New("invalid storage key")
+)
+
+// AttachmentStorage stores attachment bytes under opaque, slash-separated keys.
+// Metadata lives in the database; a backend only needs to round-trip the bytes.
+type AttachmentStorage interface {
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// LocalAttachmentStorage keeps files on the local filesystem under a root
+// directory. It suits single-node deployments and development.
+type LocalAttachmentStorage struct {
+	root string
+}
+
+// NewLocalAttachmentStorage creates the root directory if needed.
+func NewLocalAttachmentStorage(root string) (*LocalAttachmentStorage, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(abs, 0o750); err != nil {
+		return nil, fmt.Errorf("create attachment dir: %w", err)
+	}
+	return &LocalAttachmentStorage{root: abs}, nil
+}
+
+// Put writes to a temporary file and renames it into place, so a failed or
+// cancelled upload never leaves a partial file behind under the final key.
+func (s *LocalAttachmentStorage) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return 0, err
+	}
+	return written, nil
+}
+
+func (s *LocalAttachmentStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(target)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrAttachmentObjectNotFound
+	}
+	return f, err
+}
+
+func (s *LocalAttachmentStorage) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps a key onto the root, rejecting anything that could escape it.
+func (s *LocalAttachmentStorage) path(key string) (string, error) {
+	if key == "" || strings.Contains(key, "\\") || path.IsAbs(key) || path.Clean(key) != key || strings.HasPrefix(key, "..") {
+		return "", ErrInvalidStorageKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -2,31 +2,65 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"time"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/repository"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
-	ErrInvalidRefreshToken = errors.New("invalid refresh token")
-	ErrSessionNotFound     = errors.New("session not found")
+	ErrInvalidRefreshToken        = errors.New("invalid refresh token")
+	ErrSessionNotFound            = errors.New("session not found")
+	ErrCannotRevokeCurrentSession = errors.New("cannot revoke the current session")
+	ErrRefreshTokenReused         = errors.New("refresh token was already used")
 )
 
+const (
+	SecurityEventRefreshTokenReuse = "refresh_token_reuse"
+
+	// refreshTokenReuseGrace tolerates two tabs refreshing with the same token at
+	// once: the one that loses the race is rejected without revoking the session.
+	refreshTokenReuseGrace = 10 * time.Second
+)
+
+// LoginResult is the outcome of a sign-in. Users with two-factor
+// authentication get only a ChallengeToken, which CompleteTwoFactorLogin
+// exchanges for the session tokens once a code is given.
+type LoginResult struct {
+	AccessToken    string
+	RefreshToken   string
+	ExpiresIn      int64
+	ChallengeToken string
+}
+
 type AuthService interface {
-	Login(ctx context.Context, email, password, userAgent, ipAddress string) (accessToken, refreshToken string, expiresIn int64, err error)
-	RefreshToken(ctx context.Context, refreshToken, userAgent, ipAddress string) (accessToken string, expiresIn int64, err error)
+	Login(ctx context.Context, email, password, userAgent, ipAddress string) (LoginResult, error)
+	// SignIn finishes signing in a user who was authenticated some other way,
+	// such as an OpenID Connect provider.
+	SignIn(ctx context.Context, user sqlc.User, userAgent, ipAddress string) (LoginResult, error)
+	CompleteTwoFactorLogin(ctx context.Context, challengeToken, code, userAgent, ipAddress string) (accessToken, refreshToken string, expiresIn int64, err error)
+	// RefreshToken rotates the refresh token: the one presented stops working and
+	// a new one is returned. Presenting a rotated token again revokes the session.
+	RefreshToken(ctx context.Context, refreshToken, userAgent, ipAddress string) (accessToken, newRefreshToken string, expiresIn int64, err error)
 	Logout(ctx context.Context, refreshToken, accessTokenJTI string, userID pgtype.UUID) error
 	LogoutAllSessions(ctx context.Context, userID pgtype.UUID) error
 	RevokeToken(ctx context.Context, tokenJTI string, userID pgtype.UUID, reason string) error
+	ListSessions(ctx context.Context, userID pgtype.UUID) ([]sqlc.Session, error)
+	// RevokeSession signs out one of the user's other devices. The current
+	// session has to use Logout so its refresh token is handed back.
+	RevokeSession(ctx context.Context, userID, sessionID, currentSessionID pgtype.UUID) error
 	CleanupExpiredSessions(ctx context.Context) error
 }
 
 type authService struct {
 	userService        UserService
+	twoFactorService   TwoFactorService
 	sessionRepository  repository.SessionRepository
 	jwtService         JWTService
 	accessTokenExpiry  time.Duration
@@ -35,6 +69,7 @@ type authService struct {
 
 func NewAuthService(
 	userService UserService,
+	twoFactorService TwoFactorService,
 	sessionRepository repository.SessionRepository,
 	jwtService JWTService,
 	accessTokenExpiry time.Duration,
@@ -42,6 +77,7 @@ func NewAuthService(
 ) AuthService {
 	return &authService{
 		userService:        userService,
+		twoFactorService:   twoFactorService,
 		sessionRepository:  sessionRepository,
 		jwtService:         jwtService,
 		accessTokenExpiry:  accessTokenExpiry,
@@ -52,40 +88,82 @@ func NewAuthService(
 func (s *authService) Login(
 	ctx context.Context,
 	email, password, userAgent, ipAddress string,
-) (string, string, int64, error) {
+) (LoginResult, error) {
 	// Authenticate user
 	user, err := s.userService.AuthenticateUser(ctx, email, password)
 	if err != nil {
-		return "", "", 0, err
+		return LoginResult{}, err
 	}
 
-	// Generate access token
-	// Convert pgtype.UUID to uuid.UUID for string conversion
-	userUUID, err := uuid.FromBytes(user.ID.Bytes[:])
+	return s.SignIn(ctx, user, userAgent, ipAddress)
+}
+
+func (s *authService) SignIn(
+	ctx context.Context,
+	user sqlc.User,
+	userAgent, ipAddress string,
+) (LoginResult, error) {
+	// No tokens until the second factor is given
+	enabled, err := s.twoFactorService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return LoginResult{}, err
+	}
+	if enabled {
+		challengeToken, err := s.twoFactorService.CreateChallenge(ctx, user.ID)
+		if err != nil {
+			return LoginResult{}, err
+		}
+		return LoginResult{ChallengeToken: challengeToken}, nil
+	}
+
+	accessToken, refreshToken, expiresIn, err := s.startSession(ctx, user, userAgent, ipAddress)
+	if err != nil {
+		return LoginResult{}, err
+	}
+	return LoginResult{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    expiresIn,
+	}, nil
+}
+
+func (s *authService) CompleteTwoFactorLogin(
+	ctx context.Context,
+	challengeToken, code, userAgent, ipAddress string,
+) (string, string, int64, error) {
+	userID, err := s.twoFactorService.VerifyChallenge(ctx, challengeToken, code)
 	if err != nil {
 		return "", "", 0, err
 	}
-	accessToken, _, err := s.jwtService.GenerateAccessToken(userUUID.String(), user.Email)
+
+	user, err := s.userService.GetUser(ctx, userID)
 	if err != nil {
 		return "", "", 0, err
 	}
 
+	return s.startSession(ctx, user, userAgent, ipAddress)
+}
+
+func (s *authService) startSession(
+	ctx context.Context,
+	user sqlc.User,
+	userAgent, ipAddress string,
+) (string, string, int64, error) {
 	// Generate refresh token (cryptographically secure random string)
 	refreshToken, err := s.jwtService.GenerateRefreshToken()
 	if err != nil {
 		return "", "", 0, err
 	}
 
-	// Store session with refresh token
-	// Note: Refresh token is 32 random bytes base64 encoded, secure enough to store directly
+	// Store session with a hash of the refresh token, so a database leak can't be replayed
 	expiresAt := pgtype.Timestamptz{
 		Time:  time.Now().Add(s.refreshTokenExpiry),
 		Valid: true,
 	}
 
-	_, err = s.sessionRepository.CreateSession(ctx, sqlc.CreateSessionParams{
+	session, err := s.sessionRepository.CreateSession(ctx, sqlc.CreateSessionParams{
 		UserID:           user.ID,
-		RefreshTokenHash: refreshToken, // Storing directly for efficient lookup
+		RefreshTokenHash: hashToken(refreshToken),
 		UserAgent:        pgtype.Text{String: userAgent, Valid: userAgent != ""},
 		IpAddress:        pgtype.Text{String: ipAddress, Valid: ipAddress != ""},
 		ExpiresAt:        expiresAt,
@@ -94,6 +172,17 @@ func (s *authService) Login(
 		return "", "", 0, err
 	}
 
+	// Generate access token tied to the session, so revoking the session cuts it off
+	// Convert pgtype.UUID to uuid.UUID for string conversion
+	userUUID, err := uuid.FromBytes(user.ID.Bytes[:])
+	if err != nil {
+		return "", "", 0, err
+	}
+	accessToken, _, err := s.jwtService.GenerateAccessToken(userUUID.String(), user.Email, sessionIDString(session.ID))
+	if err != nil {
+		return "", "", 0, err
+	}
+
 	expiresIn := int64(s.accessTokenExpiry.Seconds())
 	return accessToken, refreshToken, expiresIn, nil
 }
@@ -101,34 +190,90 @@ func (s *authService) Login(
 func (s *authService) RefreshToken(
 	ctx context.Context,
 	refreshToken, userAgent, ipAddress string,
-) (string, int64, error) {
-	// Look up session by refresh token
-	session, err := s.sessionRepository.GetSessionByRefreshTokenHash(ctx, refreshToken)
+) (string, string, int64, error) {
+	newRefreshToken, err := s.jwtService.GenerateRefreshToken()
 	if err != nil {
-		return "", 0, ErrSessionNotFound
+		return "", "", 0, err
 	}
 
-	// Update last used timestamp
-	_ = s.sessionRepository.UpdateSessionLastUsed(ctx, session.ID)
+	// Swap in the new token only if the presented one is still current
+	tokenHash := hashToken(refreshToken)
+	session, err := s.sessionRepository.RotateSessionRefreshToken(ctx, sqlc.RotateSessionRefreshTokenParams{
+		NewRefreshTokenHash: hashToken(newRefreshToken),
+		RefreshTokenHash:    tokenHash,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", 0, s.checkRefreshTokenReuse(ctx, tokenHash, userAgent, ipAddress)
+		}
+		return "", "", 0, err
+	}
 
 	// Generate new access token
 	// Convert pgtype.UUID to uuid.UUID for string conversion
 	sessionUserUUID, err := uuid.FromBytes(session.UserID.Bytes[:])
 	if err != nil {
-		return "", 0, err
+		return "", "", 0, err
 	}
-	accessToken, _, err := s.jwtService.GenerateAccessToken(sessionUserUUID.String(), "")
+	accessToken, _, err := s.jwtService.GenerateAccessToken(sessionUserUUID.String(), "", sessionIDString(session.ID))
 	if err != nil {
-		return "", 0, err
+		return "", "", 0, err
 	}
 
 	expiresIn := int64(s.accessTokenExpiry.Seconds())
-	return accessToken, expiresIn, nil
+	return accessToken, newRefreshToken, expiresIn, nil
+}
+
+// checkRefreshTokenReuse explains why a refresh token didn't match a session.
+// A token that was already rotated away means someone kept a copy of it, so the
+// session is revoked for both holders and the event is recorded.
+func (s *authService) checkRefreshTokenReuse(ctx context.Context, tokenHash, userAgent, ipAddress string) error {
+	history, err := s.sessionRepository.GetRefreshTokenHistory(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+
+	if time.Since(history.RotatedAt.Time) < refreshTokenReuseGrace {
+		return ErrInvalidRefreshToken
+	}
+
+	// Revoke and record together, so a session is never revoked without a trace
+	tx, err := s.sessionRepository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	txRepo := s.sessionRepository.WithTx(tx)
+
+	// Access tokens carry the session ID, so they stop working along with it
+	if err := txRepo.DeleteSessionByID(ctx, history.SessionID); err != nil {
+		return err
+	}
+
+	if err := txRepo.CreateSecurityEvent(ctx, sqlc.CreateSecurityEventParams{
+		UserID:    history.UserID,
+		SessionID: history.SessionID,
+		EventType: SecurityEventRefreshTokenReuse,
+		IpAddress: pgtype.Text{String: ipAddress, Valid: ipAddress != ""},
+		UserAgent: pgtype.Text{String: userAgent, Valid: userAgent != ""},
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	return ErrRefreshTokenReused
 }
 
 func (s *authService) Logout(ctx context.Context, refreshToken, accessTokenJTI string, userID pgtype.UUID) error {
 	// Delete session by refresh token
-	if err := s.sessionRepository.DeleteSession(ctx, refreshToken); err != nil {
+	if err := s.sessionRepository.DeleteSession(ctx, hashToken(refreshToken)); err != nil {
 		return err
 	}
 
@@ -155,8 +300,7 @@ func (s *authService) Logout(ctx context.Context, refreshToken, accessTokenJTI s
 
 func (s *authService) LogoutAllSessions(ctx context.Context, userID pgtype.UUID) error {
 	// Delete all user sessions
-	// Note: This doesn't blacklist active access tokens since we don't track JTIs per session
-	// Access tokens will naturally expire after their TTL
+	// Access tokens carry their session ID, so RequireAuth rejects them once the session is gone
 	return s.sessionRepository.DeleteAllUserSessions(ctx, userID)
 }
 
@@ -174,9 +318,48 @@ func (s *authService) RevokeToken(ctx context.Context, tokenJTI string, userID p
 	})
 }
 
+func (s *authService) ListSessions(ctx context.Context, userID pgtype.UUID) ([]sqlc.Session, error) {
+	return s.sessionRepository.GetActiveSessionsByUserID(ctx, userID)
+}
+
+func (s *authService) RevokeSession(ctx context.Context, userID, sessionID, currentSessionID pgtype.UUID) error {
+	if currentSessionID.Valid && sessionID == currentSessionID {
+		return ErrCannotRevokeCurrentSession
+	}
+
+	// Scoped to the user so one user can't probe or revoke another's sessions
+	session, err := s.sessionRepository.GetUserSession(ctx, sqlc.GetUserSessionParams{
+		ID:     sessionID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+
+	return s.sessionRepository.DeleteSessionByID(ctx, session.ID)
+}
+
 func (s *authService) CleanupExpiredSessions(ctx context.Context) error {
 	if err := s.sessionRepository.DeleteExpiredSessions(ctx); err != nil {
 		return err
 	}
 	return s.sessionRepository.DeleteExpiredBlacklistedTokens(ctx)
 }
+
+// sessionIDString formats a session ID for the access token "sid" claim.
+func sessionIDString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return uuid.UUID(id.Bytes).String()
+}
+
+// hashToken returns the stored form of a secret token: refresh tokens, account
+// and two-factor tokens, recovery codes and personal access tokens.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,12 +2,17 @@ package service_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
 	"github.com/dhruvsaxena1998/splitplus/internal/service"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,6 +29,11 @@ func (m *MockUserService) CreateUser(ctx context.Context, name, email, password
 	return args.Get(0).(sqlc.User), args.Error(1)
 }
 
+func (m *MockUserService) CreateUserWithoutPassword(ctx context.Context, name, email string) (sqlc.User, error) {
+	args := m.Called(ctx, name, email)
+	return args.Get(0).(sqlc.User), args.Error(1)
+}
+
 func (m *MockUserService) AuthenticateUser(ctx context.Context, email, password string) (sqlc.User, error) {
 	args := m.Called(ctx, email, password)
 	return args.Get(0).(sqlc.User), args.Error(1)
@@ -39,6 +49,16 @@ type MockSessionRepository struct {
 	mock.Mock
 }
 
+func (m *MockSessionRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(pgx.Tx), args.Error(1)
+}
+
+// WithTx returns the mock itself, so expectations cover calls made in a transaction
+func (m *MockSessionRepository) WithTx(tx pgx.Tx) repository.SessionRepository {
+	return m
+}
+
 func (m *MockSessionRepository) CreateSession(ctx context.Context, params sqlc.CreateSessionParams) (sqlc.Session, error) {
 	args := m.Called(ctx, params)
 	return args.Get(0).(sqlc.Session), args.Error(1)
@@ -92,11 +112,98 @@ func (m *MockSessionRepository) GetActiveSessionsByUserID(ctx context.Context, u
 	return args.Get(0).([]sqlc.Session), args.Error(1)
 }
 
+func (m *MockSessionRepository) GetUserSession(ctx context.Context, params sqlc.GetUserSessionParams) (sqlc.Session, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(sqlc.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) DeleteSessionByID(ctx context.Context, sessionID pgtype.UUID) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) IsSessionActive(ctx context.Context, sessionID pgtype.UUID) (bool, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSessionRepository) RotateSessionRefreshToken(ctx context.Context, params sqlc.RotateSessionRefreshTokenParams) (sqlc.Session, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(sqlc.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) GetRefreshTokenHistory(ctx context.Context, tokenHash string) (sqlc.RefreshTokenHistory, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(sqlc.RefreshTokenHistory), args.Error(1)
+}
+
+func (m *MockSessionRepository) CreateSecurityEvent(ctx context.Context, params sqlc.CreateSecurityEventParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+// Mock TwoFactorService
+type MockTwoFactorService struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorService) GetStatus(ctx context.Context, userID pgtype.UUID) (service.TwoFactorStatus, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(service.TwoFactorStatus), args.Error(1)
+}
+
+func (m *MockTwoFactorService) Enroll(ctx context.Context, userID pgtype.UUID) (service.TwoFactorEnrollment, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(service.TwoFactorEnrollment), args.Error(1)
+}
+
+func (m *MockTwoFactorService) ConfirmEnrollment(ctx context.Context, userID pgtype.UUID, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID pgtype.UUID, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTwoFactorService) Disable(ctx context.Context, userID pgtype.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorService) IsEnabled(ctx context.Context, userID pgtype.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorService) CreateChallenge(ctx context.Context, userID pgtype.UUID) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTwoFactorService) VerifyChallenge(ctx context.Context, challengeToken, code string) (pgtype.UUID, error) {
+	args := m.Called(ctx, challengeToken, code)
+	return args.Get(0).(pgtype.UUID), args.Error(1)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 func TestAuthService_Login(t *testing.T) {
 	mockUserService := new(MockUserService)
+	mockTwoFactorService := new(MockTwoFactorService)
 	mockSessionRepo := new(MockSessionRepository)
 	jwtService := service.NewJWTService("test-secret-key-32-chars-long", 15*time.Minute, 24*time.Hour)
-	authService := service.NewAuthService(mockUserService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
+	authService := service.NewAuthService(mockUserService, mockTwoFactorService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -113,16 +220,29 @@ func TestAuthService_Login(t *testing.T) {
 		PasswordHash: "hashed_password",
 	}
 
+	sessionID := pgtype.UUID{}
+	_ = sessionID.Scan("660e8400-e29b-41d4-a716-446655440000")
+
 	t.Run("successful login", func(t *testing.T) {
 		mockUserService.On("AuthenticateUser", ctx, email, password).Return(user, nil).Once()
-		mockSessionRepo.On("CreateSession", ctx, mock.AnythingOfType("sqlc.CreateSessionParams")).Return(sqlc.Session{}, nil).Once()
+		mockTwoFactorService.On("IsEnabled", ctx, userID).Return(false, nil).Once()
+		var stored sqlc.CreateSessionParams
+		mockSessionRepo.On("CreateSession", ctx, mock.AnythingOfType("sqlc.CreateSessionParams")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(sqlc.CreateSessionParams) }).
+			Return(sqlc.Session{ID: sessionID}, nil).Once()
 
-		accessToken, refreshToken, expiresIn, err := authService.Login(ctx, email, password, userAgent, ipAddress)
+		result, err := authService.Login(ctx, email, password, userAgent, ipAddress)
 
 		require.NoError(t, err)
-		assert.NotEmpty(t, accessToken)
-		assert.NotEmpty(t, refreshToken)
-		assert.Greater(t, expiresIn, int64(0))
+		assert.NotEmpty(t, result.AccessToken)
+		assert.NotEmpty(t, result.RefreshToken)
+		assert.Empty(t, result.ChallengeToken)
+		assert.Equal(t, hashToken(result.RefreshToken), stored.RefreshTokenHash, "only the hash of the refresh token is stored")
+		assert.Greater(t, result.ExpiresIn, int64(0))
+
+		claims, err := jwtService.ValidateAccessToken(result.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "660e8400-e29b-41d4-a716-446655440000", claims.SessionID)
 		mockUserService.AssertExpectations(t)
 		mockSessionRepo.AssertExpectations(t)
 	})
@@ -130,7 +250,7 @@ func TestAuthService_Login(t *testing.T) {
 	t.Run("user not found", func(t *testing.T) {
 		mockUserService.On("AuthenticateUser", ctx, email, password).Return(sqlc.User{}, service.ErrUserNotFound).Once()
 
-		_, _, _, err := authService.Login(ctx, email, password, userAgent, ipAddress)
+		_, err := authService.Login(ctx, email, password, userAgent, ipAddress)
 
 		assert.ErrorIs(t, err, service.ErrUserNotFound)
 		mockUserService.AssertExpectations(t)
@@ -139,18 +259,78 @@ func TestAuthService_Login(t *testing.T) {
 	t.Run("invalid password", func(t *testing.T) {
 		mockUserService.On("AuthenticateUser", ctx, email, password).Return(sqlc.User{}, service.ErrInvalidPassword).Once()
 
-		_, _, _, err := authService.Login(ctx, email, password, userAgent, ipAddress)
+		_, err := authService.Login(ctx, email, password, userAgent, ipAddress)
 
 		assert.ErrorIs(t, err, service.ErrInvalidPassword)
 		mockUserService.AssertExpectations(t)
 	})
+
+	t.Run("two-factor enabled returns a challenge instead of tokens", func(t *testing.T) {
+		mockUserService.On("AuthenticateUser", ctx, email, password).Return(user, nil).Once()
+		mockTwoFactorService.On("IsEnabled", ctx, userID).Return(true, nil).Once()
+		mockTwoFactorService.On("CreateChallenge", ctx, userID).Return("challenge-token", nil).Once()
+
+		result, err := authService.Login(ctx, email, password, userAgent, ipAddress)
+
+		require.NoError(t, err)
+		assert.Equal(t, "challenge-token", result.ChallengeToken)
+		assert.Empty(t, result.AccessToken)
+		assert.Empty(t, result.RefreshToken)
+		mockTwoFactorService.AssertExpectations(t)
+	})
+}
+
+func TestAuthService_CompleteTwoFactorLogin(t *testing.T) {
+	mockUserService := new(MockUserService)
+	mockTwoFactorService := new(MockTwoFactorService)
+	mockSessionRepo := new(MockSessionRepository)
+	jwtService := service.NewJWTService("test-secret-key-32-chars-long", 15*time.Minute, 24*time.Hour)
+	authService := service.NewAuthService(mockUserService, mockTwoFactorService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
+
+	ctx := context.Background()
+	userAgent := "TestAgent"
+	ipAddress := "127.0.0.1"
+
+	userID := pgtype.UUID{}
+	_ = userID.Scan("550e8400-e29b-41d4-a716-446655440000")
+	user := sqlc.User{ID: userID, Email: "test@example.com"}
+
+	sessionID := pgtype.UUID{}
+	_ = sessionID.Scan("660e8400-e29b-41d4-a716-446655440000")
+
+	t.Run("valid code starts a session", func(t *testing.T) {
+		mockTwoFactorService.On("VerifyChallenge", ctx, "challenge-token", "123456").Return(userID, nil).Once()
+		mockUserService.On("GetUser", ctx, userID).Return(user, nil).Once()
+		mockSessionRepo.On("CreateSession", ctx, mock.AnythingOfType("sqlc.CreateSessionParams")).
+			Return(sqlc.Session{ID: sessionID}, nil).Once()
+
+		accessToken, refreshToken, expiresIn, err := authService.CompleteTwoFactorLogin(ctx, "challenge-token", "123456", userAgent, ipAddress)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, accessToken)
+		assert.NotEmpty(t, refreshToken)
+		assert.Greater(t, expiresIn, int64(0))
+		mockTwoFactorService.AssertExpectations(t)
+		mockUserService.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid code starts no session", func(t *testing.T) {
+		mockTwoFactorService.On("VerifyChallenge", ctx, "challenge-token", "000000").Return(pgtype.UUID{}, service.ErrInvalidTwoFactorCode).Once()
+
+		_, _, _, err := authService.CompleteTwoFactorLogin(ctx, "challenge-token", "000000", userAgent, ipAddress)
+
+		assert.ErrorIs(t, err, service.ErrInvalidTwoFactorCode)
+		mockTwoFactorService.AssertExpectations(t)
+	})
 }
 
 func TestAuthService_RefreshToken(t *testing.T) {
 	mockUserService := new(MockUserService)
+	mockTwoFactorService := new(MockTwoFactorService)
 	mockSessionRepo := new(MockSessionRepository)
 	jwtService := service.NewJWTService("test-secret-key-32-chars-long", 15*time.Minute, 24*time.Hour)
-	authService := service.NewAuthService(mockUserService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
+	authService := service.NewAuthService(mockUserService, mockTwoFactorService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
 
 	ctx := context.Background()
 	userAgent := "TestAgent"
@@ -165,38 +345,100 @@ func TestAuthService_RefreshToken(t *testing.T) {
 	// Generate a valid refresh token
 	refreshToken, _ := jwtService.GenerateRefreshToken()
 
-	t.Run("successful token refresh", func(t *testing.T) {
+	t.Run("successful token refresh rotates the refresh token", func(t *testing.T) {
 		session := sqlc.Session{
 			ID:     sessionID,
 			UserID: userID,
 		}
 
-		mockSessionRepo.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(session, nil).Once()
-		mockSessionRepo.On("UpdateSessionLastUsed", ctx, sessionID).Return(nil).Once()
+		var rotate sqlc.RotateSessionRefreshTokenParams
+		mockSessionRepo.On("RotateSessionRefreshToken", ctx, mock.AnythingOfType("sqlc.RotateSessionRefreshTokenParams")).
+			Run(func(args mock.Arguments) { rotate = args.Get(1).(sqlc.RotateSessionRefreshTokenParams) }).
+			Return(session, nil).Once()
 
-		accessToken, expiresIn, err := authService.RefreshToken(ctx, refreshToken, userAgent, ipAddress)
+		accessToken, newRefreshToken, expiresIn, err := authService.RefreshToken(ctx, refreshToken, userAgent, ipAddress)
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, accessToken)
 		assert.Greater(t, expiresIn, int64(0))
+		assert.NotEmpty(t, newRefreshToken)
+		assert.NotEqual(t, refreshToken, newRefreshToken)
+		assert.Equal(t, hashToken(refreshToken), rotate.RefreshTokenHash)
+		assert.Equal(t, hashToken(newRefreshToken), rotate.NewRefreshTokenHash)
+
+		claims, err := jwtService.ValidateAccessToken(accessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "660e8400-e29b-41d4-a716-446655440000", claims.SessionID)
 		mockSessionRepo.AssertExpectations(t)
 	})
 
 	t.Run("session not found", func(t *testing.T) {
-		mockSessionRepo.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(sqlc.Session{}, service.ErrSessionNotFound).Once()
+		mockSessionRepo.On("RotateSessionRefreshToken", ctx, mock.Anything).Return(sqlc.Session{}, pgx.ErrNoRows).Once()
+		mockSessionRepo.On("GetRefreshTokenHistory", ctx, hashToken(refreshToken)).Return(sqlc.RefreshTokenHistory{}, pgx.ErrNoRows).Once()
 
-		_, _, err := authService.RefreshToken(ctx, refreshToken, userAgent, ipAddress)
+		_, _, _, err := authService.RefreshToken(ctx, refreshToken, userAgent, ipAddress)
 
 		assert.ErrorIs(t, err, service.ErrSessionNotFound)
 		mockSessionRepo.AssertExpectations(t)
 	})
+
+	t.Run("reused rotated token revokes the session and records the event", func(t *testing.T) {
+		history := sqlc.RefreshTokenHistory{
+			TokenHash: hashToken(refreshToken),
+			SessionID: sessionID,
+			UserID:    userID,
+			RotatedAt: pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+		}
+
+		committed := false
+		tx := &testutil.MockTx{CommitFunc: func(ctx context.Context) error {
+			committed = true
+			return nil
+		}}
+
+		mockSessionRepo.On("RotateSessionRefreshToken", ctx, mock.Anything).Return(sqlc.Session{}, pgx.ErrNoRows).Once()
+		mockSessionRepo.On("GetRefreshTokenHistory", ctx, hashToken(refreshToken)).Return(history, nil).Once()
+		mockSessionRepo.On("BeginTx", ctx).Return(tx, nil).Once()
+		mockSessionRepo.On("DeleteSessionByID", ctx, sessionID).Return(nil).Once()
+		mockSessionRepo.On("CreateSecurityEvent", ctx, sqlc.CreateSecurityEventParams{
+			UserID:    userID,
+			SessionID: sessionID,
+			EventType: service.SecurityEventRefreshTokenReuse,
+			IpAddress: pgtype.Text{String: ipAddress, Valid: true},
+			UserAgent: pgtype.Text{String: userAgent, Valid: true},
+		}).Return(nil).Once()
+
+		_, _, _, err := authService.RefreshToken(ctx, refreshToken, userAgent, ipAddress)
+
+		assert.ErrorIs(t, err, service.ErrRefreshTokenReused)
+		assert.True(t, committed, "expected the revocation and its event to be committed together")
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("token rotated moments ago by a concurrent refresh is rejected without revoking", func(t *testing.T) {
+		history := sqlc.RefreshTokenHistory{
+			TokenHash: hashToken(refreshToken),
+			SessionID: sessionID,
+			UserID:    userID,
+			RotatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		}
+
+		mockSessionRepo.On("RotateSessionRefreshToken", ctx, mock.Anything).Return(sqlc.Session{}, pgx.ErrNoRows).Once()
+		mockSessionRepo.On("GetRefreshTokenHistory", ctx, hashToken(refreshToken)).Return(history, nil).Once()
+
+		_, _, _, err := authService.RefreshToken(ctx, refreshToken, userAgent, ipAddress)
+
+		assert.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+		mockSessionRepo.AssertExpectations(t)
+	})
 }
 
 func TestAuthService_Logout(t *testing.T) {
 	mockUserService := new(MockUserService)
+	mockTwoFactorService := new(MockTwoFactorService)
 	mockSessionRepo := new(MockSessionRepository)
 	jwtService := service.NewJWTService("test-secret-key-32-chars-long", 15*time.Minute, 24*time.Hour)
-	authService := service.NewAuthService(mockUserService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
+	authService := service.NewAuthService(mockUserService, mockTwoFactorService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
 
 	ctx := context.Background()
 	refreshToken := "refresh-token"
@@ -206,7 +448,7 @@ func TestAuthService_Logout(t *testing.T) {
 	_ = userID.Scan("550e8400-e29b-41d4-a716-446655440000")
 
 	t.Run("successful logout with token blacklisting", func(t *testing.T) {
-		mockSessionRepo.On("DeleteSession", ctx, refreshToken).Return(nil).Once()
+		mockSessionRepo.On("DeleteSession", ctx, hashToken(refreshToken)).Return(nil).Once()
 		mockSessionRepo.On("BlacklistToken", ctx, mock.AnythingOfType("sqlc.BlacklistTokenParams")).Return(nil).Once()
 
 		err := authService.Logout(ctx, refreshToken, accessTokenJTI, userID)
@@ -216,7 +458,7 @@ func TestAuthService_Logout(t *testing.T) {
 	})
 
 	t.Run("logout without JTI", func(t *testing.T) {
-		mockSessionRepo.On("DeleteSession", ctx, refreshToken).Return(nil).Once()
+		mockSessionRepo.On("DeleteSession", ctx, hashToken(refreshToken)).Return(nil).Once()
 
 		err := authService.Logout(ctx, refreshToken, "", userID)
 
@@ -225,7 +467,7 @@ func TestAuthService_Logout(t *testing.T) {
 	})
 
 	t.Run("session deletion fails", func(t *testing.T) {
-		mockSessionRepo.On("DeleteSession", ctx, refreshToken).Return(errors.New("db error")).Once()
+		mockSessionRepo.On("DeleteSession", ctx, hashToken(refreshToken)).Return(errors.New("db error")).Once()
 
 		err := authService.Logout(ctx, refreshToken, accessTokenJTI, userID)
 
@@ -236,9 +478,10 @@ func TestAuthService_Logout(t *testing.T) {
 
 func TestAuthService_LogoutAllSessions(t *testing.T) {
 	mockUserService := new(MockUserService)
+	mockTwoFactorService := new(MockTwoFactorService)
 	mockSessionRepo := new(MockSessionRepository)
 	jwtService := service.NewJWTService("test-secret-key-32-chars-long", 15*time.Minute, 24*time.Hour)
-	authService := service.NewAuthService(mockUserService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
+	authService := service.NewAuthService(mockUserService, mockTwoFactorService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
 
 	ctx := context.Background()
 	userID := pgtype.UUID{}
@@ -263,11 +506,55 @@ func TestAuthService_LogoutAllSessions(t *testing.T) {
 	})
 }
 
+func TestAuthService_RevokeSession(t *testing.T) {
+	mockUserService := new(MockUserService)
+	mockTwoFactorService := new(MockTwoFactorService)
+	mockSessionRepo := new(MockSessionRepository)
+	jwtService := service.NewJWTService("test-secret-key-32-chars-long", 15*time.Minute, 24*time.Hour)
+	authService := service.NewAuthService(mockUserService, mockTwoFactorService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
+
+	ctx := context.Background()
+	userID := pgtype.UUID{}
+	_ = userID.Scan("550e8400-e29b-41d4-a716-446655440000")
+	currentID := pgtype.UUID{}
+	_ = currentID.Scan("660e8400-e29b-41d4-a716-446655440000")
+	otherID := pgtype.UUID{}
+	_ = otherID.Scan("770e8400-e29b-41d4-a716-446655440000")
+
+	t.Run("revokes another session", func(t *testing.T) {
+		params := sqlc.GetUserSessionParams{ID: otherID, UserID: userID}
+		mockSessionRepo.On("GetUserSession", ctx, params).Return(sqlc.Session{ID: otherID, UserID: userID}, nil).Once()
+		mockSessionRepo.On("DeleteSessionByID", ctx, otherID).Return(nil).Once()
+
+		err := authService.RevokeSession(ctx, userID, otherID, currentID)
+
+		require.NoError(t, err)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("current session", func(t *testing.T) {
+		err := authService.RevokeSession(ctx, userID, currentID, currentID)
+
+		assert.ErrorIs(t, err, service.ErrCannotRevokeCurrentSession)
+	})
+
+	t.Run("session of another user", func(t *testing.T) {
+		params := sqlc.GetUserSessionParams{ID: otherID, UserID: userID}
+		mockSessionRepo.On("GetUserSession", ctx, params).Return(sqlc.Session{}, pgx.ErrNoRows).Once()
+
+		err := authService.RevokeSession(ctx, userID, otherID, currentID)
+
+		assert.ErrorIs(t, err, service.ErrSessionNotFound)
+		mockSessionRepo.AssertExpectations(t)
+	})
+}
+
 func TestAuthService_CleanupExpiredSessions(t *testing.T) {
 	mockUserService := new(MockUserService)
+	mockTwoFactorService := new(MockTwoFactorService)
 	mockSessionRepo := new(MockSessionRepository)
 	jwtService := service.NewJWTService("test-secret-key-32-chars-long", 15*time.Minute, 24*time.Hour)
-	authService := service.NewAuthService(mockUserService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
+	authService := service.NewAuthService(mockUserService, mockTwoFactorService, mockSessionRepo, jwtService, 15*time.Minute, 24*time.Hour)
 
 	ctx := context.Background()
 
@@ -50,13 +50,52 @@ type DebtResponse struct {
 	CreditorEmail string      `json:"creditor_email"`
 	CreditorName  string      `json:"creditor_name"`
 	Amount        string      `json:"amount"`
+	// PaymentLinks are only filled in for the requester's own debts
+	PaymentLinks []PaymentLink `json:"payment_links,omitempty"`
+}
+
+const (
+	FriendBalanceSourceDirect = "direct"
+	FriendBalanceSourceGroup  = "group"
+)
+
+// CurrencyAmount is a signed amount in a single currency
+type CurrencyAmount struct {
+	CurrencyCode string `json:"currency_code"`
+	Amount       string `json:"amount"`
+}
+
+// FriendBalanceSource is the balance with a friend coming from one place: direct
+// friend expenses and settlements, or a group both users belong to
+type FriendBalanceSource struct {
+	Type         string       `json:"type"`
+	GroupID      *pgtype.UUID `json:"group_id,omitempty"`
+	GroupName    string       `json:"group_name,omitempty"`
+	CurrencyCode string       `json:"currency_code"`
+	Amount       string       `json:"amount"`
+}
+
+// FriendBalanceResponse represents the net balance with a friend per currency.
+// Positive amounts mean the friend owes the user, negative that the user owes the friend
+type FriendBalanceResponse struct {
+	FriendID pgtype.UUID           `json:"friend_id"`
+	Balances []CurrencyAmount      `json:"balances"`
+	Sources  []FriendBalanceSource `json:"sources"`
+}
+
+// BalanceOptions controls which settlements count towards a balance
+type BalanceOptions struct {
+	// ConfirmedOnly ignores settlements the payee has not confirmed yet
+	ConfirmedOnly bool
 }
 
 type BalanceService interface {
-	GetGroupBalances(ctx context.Context, groupID, requesterID pgtype.UUID) ([]BalanceResponse, error)
-	GetUserBalanceInGroup(ctx context.Context, groupID, userID, requesterID pgtype.UUID) (BalanceResponse, error)
-	GetOverallUserBalance(ctx context.Context, userID pgtype.UUID) ([]GroupBalanceResponse, error)
-	GetSimplifiedDebts(ctx context.Context, groupID, requesterID pgtype.UUID) ([]DebtResponse, error)
+	GetGroupBalances(ctx context.Context, groupID, requesterID pgtype.UUID, opts BalanceOptions) ([]BalanceResponse, error)
+	GetUserBalanceInGroup(ctx context.Context, groupID, userID, requesterID pgtype.UUID, opts BalanceOptions) (BalanceResponse, error)
+	GetOverallUserBalance(ctx context.Context, userID pgtype.UUID, opts BalanceOptions) ([]GroupBalanceResponse, error)
+	GetSimplifiedDebts(ctx context.Context, groupID, requesterID pgtype.UUID, opts BalanceOptions) ([]DebtResponse, error)
+	GetFriendBalance(ctx context.Context, userID, friendID pgtype.UUID, opts BalanceOptions) (FriendBalanceResponse, error)
+	ListFriendBalances(ctx context.Context, userID pgtype.UUID, opts BalanceOptions) ([]FriendBalanceResponse, error)
 }
 
 type balanceService struct {
@@ -121,17 +160,17 @@ func numericInterfaceToDecimal(val interface{}) decimal.Decimal {
 }
 
 func (s *balanceService) validateGroupMembership(ctx context.Context, groupID, userID pgtype.UUID) error {
-	_, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+	member, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
 		GroupID: groupID,
 		UserID:  userID,
 	})
-	if err != nil {
+	if err != nil || member.Status == "inactive" {
 		return ErrNotGroupMember
 	}
 	return nil
 }
 
-func (s *balanceService) GetGroupBalances(ctx context.Context, groupID, requesterID pgtype.UUID) ([]BalanceResponse, error) {
+func (s *balanceService) GetGroupBalances(ctx context.Context, groupID, requesterID pgtype.UUID, opts BalanceOptions) ([]BalanceResponse, error) {
 	// Validate group exists
 	_, err := s.repo.GetGroupByID(ctx, groupID)
 	if err != nil {
@@ -144,7 +183,10 @@ func (s *balanceService) GetGroupBalances(ctx context.Context, groupID, requeste
 	}
 
 	// Get balances
-	rows, err := s.repo.GetGroupBalances(ctx, groupID)
+	rows, err := s.repo.GetGroupBalances(ctx, sqlc.GetGroupBalancesParams{
+		GroupID:       groupID,
+		ConfirmedOnly: opts.ConfirmedOnly,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -179,7 +221,7 @@ func (s *balanceService) GetGroupBalances(ctx context.Context, groupID, requeste
 	return balances, nil
 }
 
-func (s *balanceService) GetUserBalanceInGroup(ctx context.Context, groupID, userID, requesterID pgtype.UUID) (BalanceResponse, error) {
+func (s *balanceService) GetUserBalanceInGroup(ctx context.Context, groupID, userID, requesterID pgtype.UUID, opts BalanceOptions) (BalanceResponse, error) {
 	// Validate group exists
 	_, err := s.repo.GetGroupByID(ctx, groupID)
 	if err != nil {
@@ -193,8 +235,9 @@ func (s *balanceService) GetUserBalanceInGroup(ctx context.Context, groupID, use
 
 	// Get balance
 	row, err := s.repo.GetUserBalanceInGroup(ctx, sqlc.GetUserBalanceInGroupParams{
-		GroupID: groupID,
-		UserID:  userID,
+		GroupID:       groupID,
+		ConfirmedOnly: opts.ConfirmedOnly,
+		UserID:        userID,
 	})
 	if err != nil {
 		return BalanceResponse{}, ErrBalanceNotFound
@@ -225,8 +268,11 @@ func (s *balanceService) GetUserBalanceInGroup(ctx context.Context, groupID, use
 	}, nil
 }
 
-func (s *balanceService) GetOverallUserBalance(ctx context.Context, userID pgtype.UUID) ([]GroupBalanceResponse, error) {
-	rows, err := s.repo.GetOverallUserBalance(ctx, userID)
+func (s *balanceService) GetOverallUserBalance(ctx context.Context, userID pgtype.UUID, opts BalanceOptions) ([]GroupBalanceResponse, error) {
+	rows, err := s.repo.GetOverallUserBalance(ctx, sqlc.GetOverallUserBalanceParams{
+		ConfirmedOnly: opts.ConfirmedOnly,
+		UserID:        userID,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -250,9 +296,9 @@ func (s *balanceService) GetOverallUserBalance(ctx context.Context, userID pgtyp
 	return balances, nil
 }
 
-func (s *balanceService) GetSimplifiedDebts(ctx context.Context, groupID, requesterID pgtype.UUID) ([]DebtResponse, error) {
+func (s *balanceService) GetSimplifiedDebts(ctx context.Context, groupID, requesterID pgtype.UUID, opts BalanceOptions) ([]DebtResponse, error) {
 	// Validate group exists
-	_, err := s.repo.GetGroupByID(ctx, groupID)
+	group, err := s.repo.GetGroupByID(ctx, groupID)
 	if err != nil {
 		return nil, ErrGroupNotFound
 	}
@@ -263,11 +309,59 @@ func (s *balanceService) GetSimplifiedDebts(ctx context.Context, groupID, reques
 	}
 
 	// Get per-user balances for the group (including pending users)
-	rows, err := s.repo.GetGroupBalancesWithPending(ctx, groupID)
+	rows, err := s.repo.GetGroupBalancesWithPending(ctx, sqlc.GetGroupBalancesWithPendingParams{
+		GroupID:       groupID,
+		ConfirmedOnly: opts.ConfirmedOnly,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	debts := simplifyDebts(rows)
+	if err := s.attachPaymentLinks(ctx, group, requesterID, debts); err != nil {
+		return nil, err
+	}
+	return debts, nil
+}
+
+// attachPaymentLinks adds links to the creditor's payment handles on debts the
+// requester owes, so handles are only shown to members who need to pay them.
+func (s *balanceService) attachPaymentLinks(ctx context.Context, group sqlc.Group, requesterID pgtype.UUID, debts []DebtResponse) error {
+	var creditorIDs []pgtype.UUID
+	for _, debt := range debts {
+		if debt.DebtorID == requesterID && debt.CreditorID.Valid {
+			creditorIDs = append(creditorIDs, debt.CreditorID)
+		}
+	}
+	if len(creditorIDs) == 0 {
+		return nil
+	}
+
+	methods, err := s.repo.ListPaymentMethodsByUsers(ctx, creditorIDs)
+	if err != nil {
+		return err
+	}
+	byUser := make(map[pgtype.UUID][]sqlc.UserPaymentMethod)
+	for _, method := range methods {
+		byUser[method.UserID] = append(byUser[method.UserID], method)
+	}
+
+	for i := range debts {
+		debt := &debts[i]
+		if debt.DebtorID != requesterID || len(byUser[debt.CreditorID]) == 0 {
+			continue
+		}
+		amount, err := decimal.NewFromString(debt.Amount)
+		if err != nil {
+			return err
+		}
+		debt.PaymentLinks = BuildPaymentLinks(byUser[debt.CreditorID], amount, group.CurrencyCode, debt.CreditorName, "SplitPlus: "+group.Name)
+	}
+	return nil
+}
+
+// simplifyDebts turns per-member balances into a minimal set of debtor -> creditor payments
+func simplifyDebts(rows []sqlc.GetGroupBalancesWithPendingRow) []DebtResponse {
 	// Build debtor / creditor lists from balances
 	type node struct {
 		ID            pgtype.UUID
@@ -309,7 +403,7 @@ func (s *balanceService) GetSimplifiedDebts(ctx context.Context, groupID, reques
 
 	// Nothing to simplify
 	if len(debtors) == 0 || len(creditors) == 0 {
-		return []DebtResponse{}, nil
+		return []DebtResponse{}
 	}
 
 	// Sort debtors (most negative first) and creditors (most positive first)
@@ -373,7 +467,7 @@ func (s *balanceService) GetSimplifiedDebts(ctx context.Context, groupID, reques
 		}
 	}
 
-	return debts, nil
+	return debts
 }
 
 func pendingIDPtr(id pgtype.UUID) *pgtype.UUID {
@@ -382,3 +476,109 @@ func pendingIDPtr(id pgtype.UUID) *pgtype.UUID {
 	}
 	return &id
 }
+
+func (s *balanceService) GetFriendBalance(ctx context.Context, userID, friendID pgtype.UUID, opts BalanceOptions) (FriendBalanceResponse, error) {
+	a, b := canonicalPair(userID, friendID)
+	fr, err := s.repo.GetFriendship(ctx, a, b)
+	if err != nil || fr.Status != "accepted" {
+		return FriendBalanceResponse{}, ErrFriendNotFound
+	}
+
+	sources, err := s.collectFriendBalanceSources(ctx, userID, opts)
+	if err != nil {
+		return FriendBalanceResponse{}, err
+	}
+
+	return newFriendBalanceResponse(friendID, sources[friendID]), nil
+}
+
+// ListFriendBalances returns the balance with every user the requester has a
+// non-zero balance with, whether or not they are still friends
+func (s *balanceService) ListFriendBalances(ctx context.Context, userID pgtype.UUID, opts BalanceOptions) ([]FriendBalanceResponse, error) {
+	sources, err := s.collectFriendBalanceSources(ctx, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]FriendBalanceResponse, 0, len(sources))
+	for friendID, friendSources := range sources {
+		balances = append(balances, newFriendBalanceResponse(friendID, friendSources))
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return lessOrEqualUUID(balances[i].FriendID, balances[j].FriendID)
+	})
+
+	return balances, nil
+}
+
+// collectFriendBalanceSources gathers the user's balance with every counterpart,
+// keyed by counterpart. Group balances are what the two of them owe each other
+// in that group, regardless of how the group's debts get simplified.
+func (s *balanceService) collectFriendBalanceSources(ctx context.Context, userID pgtype.UUID, opts BalanceOptions) (map[pgtype.UUID][]FriendBalanceSource, error) {
+	sources := make(map[pgtype.UUID][]FriendBalanceSource)
+
+	direct, err := s.repo.ListDirectFriendBalances(ctx, sqlc.ListDirectFriendBalancesParams{
+		UserID:        userID,
+		ConfirmedOnly: opts.ConfirmedOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range direct {
+		sources[row.FriendID] = append(sources[row.FriendID], FriendBalanceSource{
+			Type:         FriendBalanceSourceDirect,
+			CurrencyCode: row.CurrencyCode,
+			Amount:       numericInterfaceToDecimal(row.Balance).String(),
+		})
+	}
+
+	groups, err := s.repo.ListGroupFriendBalances(ctx, sqlc.ListGroupFriendBalancesParams{
+		UserID:        userID,
+		ConfirmedOnly: opts.ConfirmedOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range groups {
+		groupID := row.GroupID
+		sources[row.FriendID] = append(sources[row.FriendID], FriendBalanceSource{
+			Type:         FriendBalanceSourceGroup,
+			GroupID:      &groupID,
+			GroupName:    row.GroupName,
+			CurrencyCode: row.CurrencyCode,
+			Amount:       numericInterfaceToDecimal(row.Balance).String(),
+		})
+	}
+
+	return sources, nil
+}
+
+// newFriendBalanceResponse totals sources per currency; currencies that net to
+// zero are left out
+func newFriendBalanceResponse(friendID pgtype.UUID, sources []FriendBalanceSource) FriendBalanceResponse {
+	totals := make(map[string]decimal.Decimal)
+	for _, source := range sources {
+		totals[source.CurrencyCode] = totals[source.CurrencyCode].Add(numericInterfaceToDecimal(source.Amount))
+	}
+
+	balances := make([]CurrencyAmount, 0, len(totals))
+	for currency, amount := range totals {
+		if amount.IsZero() {
+			continue
+		}
+		balances = append(balances, CurrencyAmount{CurrencyCode: currency, Amount: amount.String()})
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].CurrencyCode < balances[j].CurrencyCode
+	})
+
+	if sources == nil {
+		sources = []FriendBalanceSource{}
+	}
+
+	return FriendBalanceResponse{
+		FriendID: friendID,
+		Balances: balances,
+		Sources:  sources,
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockBalanceRepository for testing
+type MockBalanceRepository struct {
+	GetGroupBalancesFunc            func(ctx context.Context, params sqlc.GetGroupBalancesParams) ([]sqlc.GetGroupBalancesRow, error)
+	GetGroupBalancesWithPendingFunc func(ctx context.Context, params sqlc.GetGroupBalancesWithPendingParams) ([]sqlc.GetGroupBalancesWithPendingRow, error)
+	GetUserBalanceInGroupFunc       func(ctx context.Context, params sqlc.GetUserBalanceInGroupParams) (sqlc.GetUserBalanceInGroupRow, error)
+	GetOverallUserBalanceFunc       func(ctx context.Context, params sqlc.GetOverallUserBalanceParams) ([]sqlc.GetOverallUserBalanceRow, error)
+	ListDirectFriendBalancesFunc    func(ctx context.Context, params sqlc.ListDirectFriendBalancesParams) ([]sqlc.ListDirectFriendBalancesRow, error)
+	ListGroupFriendBalancesFunc     func(ctx context.Context, params sqlc.ListGroupFriendBalancesParams) ([]sqlc.ListGroupFriendBalancesRow, error)
+	GetGroupByIDFunc                func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error)
+	GetGroupMemberFunc              func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error)
+	GetFriendshipFunc               func(ctx context.Context, userID, friendUserID pgtype.UUID) (sqlc.Friendship, error)
+	ListPaymentMethodsByUsersFunc   func(ctx context.Context, userIDs []pgtype.UUID) ([]sqlc.UserPaymentMethod, error)
+}
+
+func (m *MockBalanceRepository) GetGroupBalances(ctx context.Context, params sqlc.GetGroupBalancesParams) ([]sqlc.GetGroupBalancesRow, error) {
+	if m.GetGroupBalancesFunc != nil {
+		return m.GetGroupBalancesFunc(ctx, params)
+	}
+	return []sqlc.GetGroupBalancesRow{}, nil
+}
+
+func (m *MockBalanceRepository) GetGroupBalancesWithPending(ctx context.Context, params sqlc.GetGroupBalancesWithPendingParams) ([]sqlc.GetGroupBalancesWithPendingRow, error) {
+	if m.GetGroupBalancesWithPendingFunc != nil {
+		return m.GetGroupBalancesWithPendingFunc(ctx, params)
+	}
+	return []sqlc.GetGroupBalancesWithPendingRow{}, nil
+}
+
+func (m *MockBalanceRepository) GetUserBalanceInGroup(ctx context.Context, params sqlc.GetUserBalanceInGroupParams) (sqlc.GetUserBalanceInGroupRow, error) {
+	if m.GetUserBalanceInGroupFunc != nil {
+		return m.GetUserBalanceInGroupFunc(ctx, params)
+	}
+	return sqlc.GetUserBalanceInGroupRow{}, nil
+}
+
+func (m *MockBalanceRepository) GetOverallUserBalance(ctx context.Context, params sqlc.GetOverallUserBalanceParams) ([]sqlc.GetOverallUserBalanceRow, error) {
+	if m.GetOverallUserBalanceFunc != nil {
+		return m.GetOverallUserBalanceFunc(ctx, params)
+	}
+	return []sqlc.GetOverallUserBalanceRow{}, nil
+}
+
+func (m *MockBalanceRepository) ListDirectFriendBalances(ctx context.Context, params sqlc.ListDirectFriendBalancesParams) ([]sqlc.ListDirectFriendBalancesRow, error) {
+	if m.ListDirectFriendBalancesFunc != nil {
+		return m.ListDirectFriendBalancesFunc(ctx, params)
+	}
+	return []sqlc.ListDirectFriendBalancesRow{}, nil
+}
+
+func (m *MockBalanceRepository) ListGroupFriendBalances(ctx context.Context, params sqlc.ListGroupFriendBalancesParams) ([]sqlc.ListGroupFriendBalancesRow, error) {
+	if m.ListGroupFriendBalancesFunc != nil {
+		return m.ListGroupFriendBalancesFunc(ctx, params)
+	}
+	return []sqlc.ListGroupFriendBalancesRow{}, nil
+}
+
+func (m *MockBalanceRepository) GetGroupByID(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+	if m.GetGroupByIDFunc != nil {
+		return m.GetGroupByIDFunc(ctx, id)
+	}
+	return sqlc.Group{ID: id}, nil
+}
+
+func (m *MockBalanceRepository) GetGroupMember(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+	if m.GetGroupMemberFunc != nil {
+		return m.GetGroupMemberFunc(ctx, params)
+	}
+	return sqlc.GroupMember{GroupID: params.GroupID, UserID: params.UserID, Status: "active"}, nil
+}
+
+func (m *MockBalanceRepository) GetFriendship(ctx context.Context, userID, friendUserID pgtype.UUID) (sqlc.Friendship, error) {
+	if m.GetFriendshipFunc != nil {
+		return m.GetFriendshipFunc(ctx, userID, friendUserID)
+	}
+	return sqlc.Friendship{}, pgx.ErrNoRows
+}
+
+func (m *MockBalanceRepository) ListPaymentMethodsByUsers(ctx context.Context, userIDs []pgtype.UUID) ([]sqlc.UserPaymentMethod, error) {
+	if m.ListPaymentMethodsByUsersFunc != nil {
+		return m.ListPaymentMethodsByUsersFunc(ctx, userIDs)
+	}
+	return []sqlc.UserPaymentMethod{}, nil
+}
+
+// friendBalanceRepo has the user with direct balances towards friend, group A
+// where friend and other each owe the user and group B where the user owes
+// friend.
+func friendBalanceRepo(t *testing.T, userID, friendID, otherID pgtype.UUID) *MockBalanceRepository {
+	groupA := testutil.CreateTestUUID(100)
+	groupB := testutil.CreateTestUUID(101)
+
+	return &MockBalanceRepository{
+		GetFriendshipFunc: func(ctx context.Context, a, b pgtype.UUID) (sqlc.Friendship, error) {
+			return sqlc.Friendship{UserID: a, FriendUserID: b, Status: "accepted"}, nil
+		},
+		ListDirectFriendBalancesFunc: func(ctx context.Context, params sqlc.ListDirectFriendBalancesParams) ([]sqlc.ListDirectFriendBalancesRow, error) {
+			return []sqlc.ListDirectFriendBalancesRow{
+				{FriendID: friendID, CurrencyCode: "EUR", Balance: "-5.00"},
+				{FriendID: friendID, CurrencyCode: "USD", Balance: "10.00"},
+			}, nil
+		},
+		ListGroupFriendBalancesFunc: func(ctx context.Context, params sqlc.ListGroupFriendBalancesParams) ([]sqlc.ListGroupFriendBalancesRow, error) {
+			if params.UserID != userID {
+				t.Errorf("expected balances of user %v, got %v", userID, params.UserID)
+			}
+			return []sqlc.ListGroupFriendBalancesRow{
+				{GroupID: groupB, GroupName: "Flat", CurrencyCode: "EUR", FriendID: friendID, Balance: "-5.00"},
+				{GroupID: groupA, GroupName: "Trip", CurrencyCode: "USD", FriendID: friendID, Balance: "10.00"},
+				{GroupID: groupA, GroupName: "Trip", CurrencyCode: "USD", FriendID: otherID, Balance: "10.00"},
+			}, nil
+		},
+	}
+}
+
+func TestBalanceService_GetFriendBalance(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	friendID := testutil.CreateTestUUID(2)
+	otherID := testutil.CreateTestUUID(3)
+
+	svc := NewBalanceService(friendBalanceRepo(t, userID, friendID, otherID))
+
+	result, err := svc.GetFriendBalance(context.Background(), userID, friendID, BalanceOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{"EUR": "-10", "USD": "20"}
+	if len(result.Balances) != len(want) {
+		t.Fatalf("expected %d currencies, got %+v", len(want), result.Balances)
+	}
+	for _, b := range result.Balances {
+		if want[b.CurrencyCode] != b.Amount {
+			t.Errorf("expected %s balance %s, got %s", b.CurrencyCode, want[b.CurrencyCode], b.Amount)
+		}
+	}
+	if result.Balances[0].CurrencyCode != "EUR" {
+		t.Errorf("expected balances sorted by currency, got %+v", result.Balances)
+	}
+
+	if len(result.Sources) != 4 {
+		t.Fatalf("expected 4 sources, got %+v", result.Sources)
+	}
+	groupSources := 0
+	for _, source := range result.Sources {
+		switch source.Type {
+		case FriendBalanceSourceGroup:
+			groupSources++
+			if source.GroupID == nil {
+				t.Error("expected group source to carry its group id")
+			}
+			if source.GroupName == "Trip" && source.Amount != "10" {
+				t.Errorf("expected friend to owe 10 in Trip, got %s", source.Amount)
+			}
+			if source.GroupName == "Flat" && source.Amount != "-5" {
+				t.Errorf("expected user to owe 5 in Flat, got %s", source.Amount)
+			}
+		case FriendBalanceSourceDirect:
+			if source.GroupID != nil {
+				t.Error("expected direct source without group id")
+			}
+		}
+	}
+	if groupSources != 2 {
+		t.Errorf("expected 2 group sources, got %d", groupSources)
+	}
+}
+
+func TestBalanceService_GetFriendBalance_NotFriends(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	friendID := testutil.CreateTestUUID(2)
+
+	tests := []struct {
+		name       string
+		friendship sqlc.Friendship
+		err        error
+	}{
+		{name: "no friendship", err: pgx.ErrNoRows},
+		{name: "pending request", friendship: sqlc.Friendship{Status: "pending"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockBalanceRepository{
+				GetFriendshipFunc: func(ctx context.Context, a, b pgtype.UUID) (sqlc.Friendship, error) {
+					return tt.friendship, tt.err
+				},
+			}
+			svc := NewBalanceService(repo)
+
+			if _, err := svc.GetFriendBalance(context.Background(), userID, friendID, BalanceOptions{}); !errors.Is(err, ErrFriendNotFound) {
+				t.Errorf("expected ErrFriendNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBalanceService_ListFriendBalances(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	friendID := testutil.CreateTestUUID(2)
+	otherID := testutil.CreateTestUUID(3)
+
+	svc := NewBalanceService(friendBalanceRepo(t, userID, friendID, otherID))
+
+	result, err := svc.ListFriendBalances(context.Background(), userID, BalanceOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected balances with 2 users, got %d", len(result))
+	}
+	if result[0].FriendID != friendID || result[1].FriendID != otherID {
+		t.Errorf("expected results ordered by friend id, got %v, %v", result[0].FriendID, result[1].FriendID)
+	}
+	if len(result[1].Balances) != 1 || result[1].Balances[0].Amount != "10" {
+		t.Errorf("expected other user to owe 10 USD, got %+v", result[1].Balances)
+	}
+}
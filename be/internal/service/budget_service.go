@@ -0,0 +1,553 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+// Budget periods. Weekly budgets run Monday to Sunday and monthly ones by
+// calendar month; a custom budget covers its start and end dates once.
+const (
+	BudgetPeriodWeekly  = "weekly"
+	BudgetPeriodMonthly = "monthly"
+	BudgetPeriodCustom  = "custom"
+)
+
+const (
+	maxBudgetsPerGroup    = 50
+	maxBudgetThresholds   = 5
+	maxBudgetThreshold    = 1000
+	maxBudgetNameLength   = 100
+	budgetThresholdAction = "budget_threshold_crossed"
+)
+
+// defaultBudgetThresholds alert when a budget is nearly and fully spent.
+var defaultBudgetThresholds = []int32{80, 100}
+
+var (
+	ErrBudgetNotFound          = errors.New("budget not found")
+	ErrInvalidBudgetName       = errors.New("budget name is required")
+	ErrInvalidBudgetAmount     = errors.New("budget amount must be greater than zero")
+	ErrInvalidBudgetPeriod     = errors.New("budget period must be weekly, monthly or custom")
+	ErrInvalidBudgetDates      = errors.New("custom budgets need a start date on or before their end date")
+	ErrInvalidBudgetThresholds = errors.New("budget thresholds must be up to 5 percentages between 1 and 1000")
+	ErrTooManyBudgets          = errors.New("too many budgets for this group")
+)
+
+type CreateBudgetInput struct {
+	GroupID     pgtype.UUID
+	RequesterID pgtype.UUID
+	CategoryID  pgtype.UUID // invalid for a group-wide budget
+	Name        string
+	Amount      string
+	Period      string
+	StartDate   *time.Time // custom budgets only
+	EndDate     *time.Time
+	Thresholds  []int32 // empty uses 80 and 100
+}
+
+// UpdateBudgetInput changes the fields that are set. Setting CategoryID to an
+// invalid UUID makes the budget group-wide.
+type UpdateBudgetInput struct {
+	GroupID     pgtype.UUID
+	BudgetID    pgtype.UUID
+	RequesterID pgtype.UUID
+	CategoryID  *pgtype.UUID
+	Name        *string
+	Amount      *string
+	Period      *string
+	StartDate   *time.Time
+	EndDate     *time.Time
+	Thresholds  *[]int32
+}
+
+// BudgetStatus is a budget's spending over one of its periods.
+type BudgetStatus struct {
+	Budget      sqlc.GroupBudget
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Spent       decimal.Decimal
+	// Remaining is negative once the budget is overspent
+	Remaining         decimal.Decimal
+	ThresholdsReached []int32
+}
+
+// BudgetService manages group budgets and alerts on them. Spending is the
+// group's expenses dated within a budget's period, converted into the group
+// currency. Every member can see budgets; only owners and admins manage them.
+type BudgetService interface {
+	ListBudgets(ctx context.Context, groupID, requesterID pgtype.UUID) ([]sqlc.GroupBudget, error)
+	CreateBudget(ctx context.Context, input CreateBudgetInput) (sqlc.GroupBudget, error)
+	UpdateBudget(ctx context.Context, input UpdateBudgetInput) (sqlc.GroupBudget, error)
+	DeleteBudget(ctx context.Context, groupID, budgetID, requesterID pgtype.UUID) error
+
+	// GetStatus reports every budget's spending in its period containing date.
+	GetStatus(ctx context.Context, groupID, requesterID pgtype.UUID, date time.Time) ([]BudgetStatus, error)
+	// CheckExpense logs a budget_threshold_crossed activity for every budget
+	// the saved expense pushed past one of its thresholds. previous is the
+	// expense before an update, nil for a new one.
+	CheckExpense(ctx context.Context, actorID pgtype.UUID, expense sqlc.Expense, previous *sqlc.Expense) error
+}
+
+type budgetService struct {
+	repo            repository.BudgetRepository
+	groupRepo       repository.GroupRepository
+	categoryRepo    repository.ExpenseCategoryRepository
+	activityService GroupActivityService
+}
+
+func NewBudgetService(
+	repo repository.BudgetRepository,
+	groupRepo repository.GroupRepository,
+	categoryRepo repository.ExpenseCategoryRepository,
+	activityService GroupActivityService,
+) BudgetService {
+	return &budgetService{
+		repo:            repo,
+		groupRepo:       groupRepo,
+		categoryRepo:    categoryRepo,
+		activityService: activityService,
+	}
+}
+
+// budgetSpec is a budget's editable fields, validated together.
+type budgetSpec struct {
+	categoryID pgtype.UUID
+	name       string
+	amount     pgtype.Numeric
+	period     string
+	startDate  pgtype.Date
+	endDate    pgtype.Date
+	thresholds []int32
+}
+
+func (s *budgetService) ListBudgets(ctx context.Context, groupID, requesterID pgtype.UUID) ([]sqlc.GroupBudget, error) {
+	if err := s.requireMember(ctx, groupID, requesterID, false); err != nil {
+		return nil, err
+	}
+	return s.repo.ListGroupBudgets(ctx, groupID)
+}
+
+func (s *budgetService) CreateBudget(ctx context.Context, input CreateBudgetInput) (sqlc.GroupBudget, error) {
+	if err := s.requireMember(ctx, input.GroupID, input.RequesterID, true); err != nil {
+		return sqlc.GroupBudget{}, err
+	}
+
+	amount, err := parseBudgetAmount(input.Amount)
+	if err != nil {
+		return sqlc.GroupBudget{}, err
+	}
+	spec := budgetSpec{
+		categoryID: input.CategoryID,
+		name:       input.Name,
+		amount:     amount,
+		period:     input.Period,
+		startDate:  optionalDate(input.StartDate),
+		endDate:    optionalDate(input.EndDate),
+		thresholds: input.Thresholds,
+	}
+	if err := s.validateSpec(ctx, input.GroupID, &spec); err != nil {
+		return sqlc.GroupBudget{}, err
+	}
+
+	count, err := s.repo.CountGroupBudgets(ctx, input.GroupID)
+	if err != nil {
+		return sqlc.GroupBudget{}, fmt.Errorf("failed to count budgets: %w", err)
+	}
+	if count >= maxBudgetsPerGroup {
+		return sqlc.GroupBudget{}, ErrTooManyBudgets
+	}
+
+	budget, err := s.repo.CreateGroupBudget(ctx, sqlc.CreateGroupBudgetParams{
+		GroupID:    input.GroupID,
+		CategoryID: spec.categoryID,
+		Name:       spec.name,
+		Amount:     spec.amount,
+		Period:     spec.period,
+		StartDate:  spec.startDate,
+		EndDate:    spec.endDate,
+		Thresholds: spec.thresholds,
+		CreatedBy:  input.RequesterID,
+	})
+	if err != nil {
+		return sqlc.GroupBudget{}, fmt.Errorf("failed to create budget: %w", err)
+	}
+	return budget, nil
+}
+
+func (s *budgetService) UpdateBudget(ctx context.Context, input UpdateBudgetInput) (sqlc.GroupBudget, error) {
+	budget, err := s.getBudget(ctx, input.GroupID, input.BudgetID, input.RequesterID)
+	if err != nil {
+		return sqlc.GroupBudget{}, err
+	}
+
+	spec := budgetSpec{
+		categoryID: budget.CategoryID,
+		name:       budget.Name,
+		amount:     budget.Amount,
+		period:     budget.Period,
+		startDate:  budget.StartDate,
+		endDate:    budget.EndDate,
+		thresholds: budget.Thresholds,
+	}
+	if input.CategoryID != nil {
+		spec.categoryID = *input.CategoryID
+	}
+	if input.Name != nil {
+		spec.name = *input.Name
+	}
+	if input.Amount != nil {
+		if spec.amount, err = parseBudgetAmount(*input.Amount); err != nil {
+			return sqlc.GroupBudget{}, err
+		}
+	}
+	if input.Period != nil {
+		spec.period = *input.Period
+	}
+	if input.StartDate != nil {
+		spec.startDate = optionalDate(input.StartDate)
+	}
+	if input.EndDate != nil {
+		spec.endDate = optionalDate(input.EndDate)
+	}
+	if input.Thresholds != nil {
+		spec.thresholds = *input.Thresholds
+	}
+	if err := s.validateSpec(ctx, input.GroupID, &spec); err != nil {
+		return sqlc.GroupBudget{}, err
+	}
+
+	updated, err := s.repo.UpdateGroupBudget(ctx, sqlc.UpdateGroupBudgetParams{
+		ID:         budget.ID,
+		GroupID:    budget.GroupID,
+		CategoryID: spec.categoryID,
+		Name:       spec.name,
+		Amount:     spec.amount,
+		Period:     spec.period,
+		StartDate:  spec.startDate,
+		EndDate:    spec.endDate,
+		Thresholds: spec.thresholds,
+	})
+	if err != nil {
+		return sqlc.GroupBudget{}, fmt.Errorf("failed to update budget: %w", err)
+	}
+	return updated, nil
+}
+
+func (s *budgetService) DeleteBudget(ctx context.Context, groupID, budgetID, requesterID pgtype.UUID) error {
+	if _, err := s.getBudget(ctx, groupID, budgetID, requesterID); err != nil {
+		return err
+	}
+	return s.repo.DeleteGroupBudget(ctx, sqlc.DeleteGroupBudgetParams{ID: budgetID, GroupID: groupID})
+}
+
+func (s *budgetService) GetStatus(ctx context.Context, groupID, requesterID pgtype.UUID, date time.Time) ([]BudgetStatus, error) {
+	if err := s.requireMember(ctx, groupID, requesterID, false); err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.repo.ListGroupBudgets(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		start, end := budgetPeriod(budget, date)
+		spent, err := s.spent(ctx, budget, start, end)
+		if err != nil {
+			return nil, err
+		}
+		limit, err := numericToDecimal(budget.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		reached := []int32{}
+		for _, threshold := range budget.Thresholds {
+			if spent.GreaterThanOrEqual(thresholdAmount(limit, threshold)) {
+				reached = append(reached, threshold)
+			}
+		}
+
+		statuses = append(statuses, BudgetStatus{
+			Budget:            budget,
+			PeriodStart:       start,
+			PeriodEnd:         end,
+			Spent:             spent,
+			Remaining:         limit.Sub(spent),
+			ThresholdsReached: reached,
+		})
+	}
+	return statuses, nil
+}
+
+func (s *budgetService) CheckExpense(ctx context.Context, actorID pgtype.UUID, expense sqlc.Expense, previous *sqlc.Expense) error {
+	if expense.Type != "group" || !expense.GroupID.Valid || expense.DeletedAt.Valid {
+		return nil
+	}
+
+	budgets, err := s.repo.ListBudgetsForCategory(ctx, sqlc.ListBudgetsForCategoryParams{
+		GroupID:    expense.GroupID,
+		CategoryID: expense.CategoryID,
+	})
+	if err != nil || len(budgets) == 0 {
+		return err
+	}
+
+	added, err := convertedExpenseAmount(expense)
+	if err != nil {
+		return err
+	}
+	removed := decimal.Zero
+	if previous != nil {
+		if removed, err = convertedExpenseAmount(*previous); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, budget := range budgets {
+		start, end := budgetPeriod(budget, expense.Date.Time)
+		if !dateWithin(expense.Date.Time, start, end) {
+			continue
+		}
+
+		// Spending already includes the saved expense; take it back out, and
+		// put back what the expense counted for before it was updated
+		spent, err := s.spent(ctx, budget, start, end)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		before := spent.Sub(added)
+		if previous != nil && budgetCounts(budget, *previous) && dateWithin(previous.Date.Time, start, end) {
+			before = before.Add(removed)
+		}
+
+		limit, err := numericToDecimal(budget.Amount)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// Only the highest threshold crossed is reported, so one large
+		// expense doesn't raise several alerts at once
+		var crossed int32
+		for _, threshold := range budget.Thresholds {
+			at := thresholdAmount(limit, threshold)
+			if before.LessThan(at) && spent.GreaterThanOrEqual(at) && threshold > crossed {
+				crossed = threshold
+			}
+		}
+		if crossed == 0 {
+			continue
+		}
+
+		metadata := map[string]interface{}{
+			"name":         budget.Name,
+			"threshold":    crossed,
+			"amount":       limit.StringFixed(2),
+			"spent":        spent.StringFixed(2),
+			"period_start": start.Format(time.DateOnly),
+			"period_end":   end.Format(time.DateOnly),
+			"expense_id":   uuidToString(expense.ID),
+		}
+		if budget.CategoryID.Valid {
+			metadata["category_id"] = uuidToString(budget.CategoryID)
+		}
+		if err := s.activityService.LogActivity(ctx, LogActivityInput{
+			GroupID:    budget.GroupID,
+			UserID:     actorID,
+			Action:     budgetThresholdAction,
+			EntityType: "budget",
+			EntityID:   budget.ID,
+			Metadata:   metadata,
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *budgetService) spent(ctx context.Context, budget sqlc.GroupBudget, start, end time.Time) (decimal.Decimal, error) {
+	spent, err := s.repo.GetBudgetSpent(ctx, sqlc.GetBudgetSpentParams{
+		GroupID:    budget.GroupID,
+		StartDate:  pgtype.Date{Time: start, Valid: true},
+		EndDate:    pgtype.Date{Time: end, Valid: true},
+		CategoryID: budget.CategoryID,
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return numericToDecimal(spent)
+}
+
+func (s *budgetService) validateSpec(ctx context.Context, groupID pgtype.UUID, spec *budgetSpec) error {
+	spec.name = strings.TrimSpace(spec.name)
+	if spec.name == "" || len(spec.name) > maxBudgetNameLength {
+		return ErrInvalidBudgetName
+	}
+
+	switch spec.period {
+	case BudgetPeriodWeekly, BudgetPeriodMonthly:
+		// Recurring periods are fixed to the calendar
+		spec.startDate = pgtype.Date{}
+		spec.endDate = pgtype.Date{}
+	case BudgetPeriodCustom:
+		if !spec.startDate.Valid || !spec.endDate.Valid || spec.endDate.Time.Before(spec.startDate.Time) {
+			return ErrInvalidBudgetDates
+		}
+	default:
+		return ErrInvalidBudgetPeriod
+	}
+
+	thresholds, err := normalizeBudgetThresholds(spec.thresholds)
+	if err != nil {
+		return err
+	}
+	spec.thresholds = thresholds
+
+	if spec.categoryID.Valid {
+		category, err := s.categoryRepo.GetCategoryByID(ctx, spec.categoryID)
+		if err != nil {
+			return ErrCategoryNotFound
+		}
+		if category.GroupID != groupID {
+			return ErrCategoryNotInGroup
+		}
+	}
+	return nil
+}
+
+func (s *budgetService) getBudget(ctx context.Context, groupID, budgetID, requesterID pgtype.UUID) (sqlc.GroupBudget, error) {
+	if err := s.requireMember(ctx, groupID, requesterID, true); err != nil {
+		return sqlc.GroupBudget{}, err
+	}
+
+	budget, err := s.repo.GetGroupBudget(ctx, sqlc.GetGroupBudgetParams{ID: budgetID, GroupID: groupID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.GroupBudget{}, ErrBudgetNotFound
+		}
+		return sqlc.GroupBudget{}, err
+	}
+	return budget, nil
+}
+
+// requireMember checks the requester is an active member of the group, and
+// with manage also an owner or admin.
+func (s *budgetService) requireMember(ctx context.Context, groupID, requesterID pgtype.UUID, manage bool) error {
+	if _, err := s.groupRepo.GetGroupByID(ctx, groupID); err != nil {
+		return ErrGroupNotFound
+	}
+
+	member, err := s.groupRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+		GroupID: groupID,
+		UserID:  requesterID,
+	})
+	if err != nil || member.Status != "active" {
+		return ErrNotGroupMember
+	}
+	if manage && member.Role != "owner" && member.Role != "admin" {
+		return ErrInsufficientPermissions
+	}
+	return nil
+}
+
+// budgetPeriod returns the first and last day of the budget's period that
+// contains date. A custom budget has a single period whatever the date.
+func budgetPeriod(budget sqlc.GroupBudget, date time.Time) (time.Time, time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	switch budget.Period {
+	case BudgetPeriodWeekly:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 6)
+	case BudgetPeriodMonthly:
+		start := day.AddDate(0, 0, 1-day.Day())
+		return start, start.AddDate(0, 1, -1)
+	default:
+		return budget.StartDate.Time, budget.EndDate.Time
+	}
+}
+
+func dateWithin(date, start, end time.Time) bool {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return !day.Before(start) && !day.After(end)
+}
+
+// budgetCounts reports whether the expense counts towards the budget, ignoring dates.
+func budgetCounts(budget sqlc.GroupBudget, expense sqlc.Expense) bool {
+	if expense.Type != "group" || expense.DeletedAt.Valid || expense.GroupID != budget.GroupID {
+		return false
+	}
+	return !budget.CategoryID.Valid || budget.CategoryID == expense.CategoryID
+}
+
+// convertedExpenseAmount is the expense amount in its group's currency.
+func convertedExpenseAmount(expense sqlc.Expense) (decimal.Decimal, error) {
+	amount, err := numericToDecimal(expense.Amount)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	rate := decimal.NewFromInt(1)
+	if expense.ExchangeRate.Valid {
+		if rate, err = numericToDecimal(expense.ExchangeRate); err != nil {
+			return decimal.Zero, err
+		}
+	}
+	return amount.Mul(rate), nil
+}
+
+func thresholdAmount(limit decimal.Decimal, threshold int32) decimal.Decimal {
+	return limit.Mul(decimal.NewFromInt32(threshold)).Div(decimal.NewFromInt(100))
+}
+
+func parseBudgetAmount(value string) (pgtype.Numeric, error) {
+	amount, err := decimal.NewFromString(strings.TrimSpace(value))
+	// Budgets are stored as DECIMAL(10, 2)
+	if err != nil || !amount.IsPositive() || !amount.Equal(amount.Round(2)) || amount.GreaterThanOrEqual(decimal.NewFromInt(100_000_000)) {
+		return pgtype.Numeric{}, ErrInvalidBudgetAmount
+	}
+	numeric, err := stringToNumeric(amount.String())
+	if err != nil {
+		return pgtype.Numeric{}, ErrInvalidBudgetAmount
+	}
+	return numeric, nil
+}
+
+// normalizeBudgetThresholds sorts the thresholds and drops duplicates.
+func normalizeBudgetThresholds(thresholds []int32) ([]int32, error) {
+	if len(thresholds) == 0 {
+		return slices.Clone(defaultBudgetThresholds), nil
+	}
+	if len(thresholds) > maxBudgetThresholds {
+		return nil, ErrInvalidBudgetThresholds
+	}
+	for _, threshold := range thresholds {
+		if threshold < 1 || threshold > maxBudgetThreshold {
+			return nil, ErrInvalidBudgetThresholds
+		}
+	}
+	normalized := slices.Clone(thresholds)
+	slices.Sort(normalized)
+	return slices.Compact(normalized), nil
+}
+
+func optionalDate(t *time.Time) pgtype.Date {
+	if t == nil {
+		return pgtype.Date{}
+	}
+	return pgtype.Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+}
@@ -0,0 +1,534 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockBudgetRepository for testing
+type MockBudgetRepository struct {
+	CreateGroupBudgetFunc      func(ctx context.Context, params sqlc.CreateGroupBudgetParams) (sqlc.GroupBudget, error)
+	GetGroupBudgetFunc         func(ctx context.Context, params sqlc.GetGroupBudgetParams) (sqlc.GroupBudget, error)
+	ListGroupBudgetsFunc       func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupBudget, error)
+	ListBudgetsForCategoryFunc func(ctx context.Context, params sqlc.ListBudgetsForCategoryParams) ([]sqlc.GroupBudget, error)
+	CountGroupBudgetsFunc      func(ctx context.Context, groupID pgtype.UUID) (int64, error)
+	UpdateGroupBudgetFunc      func(ctx context.Context, params sqlc.UpdateGroupBudgetParams) (sqlc.GroupBudget, error)
+	DeleteGroupBudgetFunc      func(ctx context.Context, params sqlc.DeleteGroupBudgetParams) error
+	GetBudgetSpentFunc         func(ctx context.Context, params sqlc.GetBudgetSpentParams) (pgtype.Numeric, error)
+}
+
+func (m *MockBudgetRepository) CreateGroupBudget(ctx context.Context, params sqlc.CreateGroupBudgetParams) (sqlc.GroupBudget, error) {
+	if m.CreateGroupBudgetFunc != nil {
+		return m.CreateGroupBudgetFunc(ctx, params)
+	}
+	return sqlc.GroupBudget{}, nil
+}
+
+func (m *MockBudgetRepository) GetGroupBudget(ctx context.Context, params sqlc.GetGroupBudgetParams) (sqlc.GroupBudget, error) {
+	if m.GetGroupBudgetFunc != nil {
+		return m.GetGroupBudgetFunc(ctx, params)
+	}
+	return sqlc.GroupBudget{}, pgx.ErrNoRows
+}
+
+func (m *MockBudgetRepository) ListGroupBudgets(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupBudget, error) {
+	if m.ListGroupBudgetsFunc != nil {
+		return m.ListGroupBudgetsFunc(ctx, groupID)
+	}
+	return nil, nil
+}
+
+func (m *MockBudgetRepository) ListBudgetsForCategory(ctx context.Context, params sqlc.ListBudgetsForCategoryParams) ([]sqlc.GroupBudget, error) {
+	if m.ListBudgetsForCategoryFunc != nil {
+		return m.ListBudgetsForCategoryFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+func (m *MockBudgetRepository) CountGroupBudgets(ctx context.Context, groupID pgtype.UUID) (int64, error) {
+	if m.CountGroupBudgetsFunc != nil {
+		return m.CountGroupBudgetsFunc(ctx, groupID)
+	}
+	return 0, nil
+}
+
+func (m *MockBudgetRepository) UpdateGroupBudget(ctx context.Context, params sqlc.UpdateGroupBudgetParams) (sqlc.GroupBudget, error) {
+	if m.UpdateGroupBudgetFunc != nil {
+		return m.UpdateGroupBudgetFunc(ctx, params)
+	}
+	return sqlc.GroupBudget{}, nil
+}
+
+func (m *MockBudgetRepository) DeleteGroupBudget(ctx context.Context, params sqlc.DeleteGroupBudgetParams) error {
+	if m.DeleteGroupBudgetFunc != nil {
+		return m.DeleteGroupBudgetFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockBudgetRepository) GetBudgetSpent(ctx context.Context, params sqlc.GetBudgetSpentParams) (pgtype.Numeric, error) {
+	if m.GetBudgetSpentFunc != nil {
+		return m.GetBudgetSpentFunc(ctx, params)
+	}
+	return stringToNumeric("0")
+}
+
+func budgetTestGroupRepo(groupID, userID pgtype.UUID, role string) *testutil.MockGroupRepository {
+	return &testutil.MockGroupRepository{
+		GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+			if id != groupID {
+				return sqlc.Group{}, pgx.ErrNoRows
+			}
+			return testutil.CreateTestGroup(groupID, "Trip", userID), nil
+		},
+		GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+			if params.UserID != userID {
+				return sqlc.GroupMember{}, pgx.ErrNoRows
+			}
+			return testutil.CreateTestGroupMember(testutil.CreateTestUUID(99), groupID, userID, role, "active"), nil
+		},
+	}
+}
+
+func testBudget(groupID pgtype.UUID, amount string, thresholds ...int32) sqlc.GroupBudget {
+	numeric, _ := stringToNumeric(amount)
+	return sqlc.GroupBudget{
+		ID:         testutil.CreateTestUUID(50),
+		GroupID:    groupID,
+		Name:       "Food",
+		Amount:     numeric,
+		Period:     BudgetPeriodMonthly,
+		Thresholds: thresholds,
+	}
+}
+
+func testBudgetExpense(groupID pgtype.UUID, amount string, date time.Time) sqlc.Expense {
+	numeric, _ := stringToNumeric(amount)
+	rate, _ := stringToNumeric("1")
+	return sqlc.Expense{
+		ID:           testutil.CreateTestUUID(60),
+		GroupID:      groupID,
+		Type:         "group",
+		Amount:       numeric,
+		ExchangeRate: rate,
+		Date:         pgtype.Date{Time: date, Valid: true},
+	}
+}
+
+func TestBudgetPeriod(t *testing.T) {
+	custom := sqlc.GroupBudget{
+		Period:    BudgetPeriodCustom,
+		StartDate: pgtype.Date{Time: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndDate:   pgtype.Date{Time: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	tests := []struct {
+		name      string
+		budget    sqlc.GroupBudget
+		date      time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "weekly starts on monday",
+			budget:    sqlc.GroupBudget{Period: BudgetPeriodWeekly},
+			date:      time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC), // Thursday
+			wantStart: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "weekly on a sunday",
+			budget:    sqlc.GroupBudget{Period: BudgetPeriodWeekly},
+			date:      time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "monthly covers the calendar month",
+			budget:    sqlc.GroupBudget{Period: BudgetPeriodMonthly},
+			date:      time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "custom ignores the date",
+			budget:    custom,
+			date:      time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+			wantStart: custom.StartDate.Time,
+			wantEnd:   custom.EndDate.Time,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := budgetPeriod(tt.budget, tt.date)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("expected %v - %v, got %v - %v", tt.wantStart, tt.wantEnd, start, end)
+			}
+		})
+	}
+}
+
+func TestNormalizeBudgetThresholds(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds []int32
+		want       []int32
+		wantErr    bool
+	}{
+		{name: "defaults when empty", want: []int32{80, 100}},
+		{name: "sorted and deduplicated", thresholds: []int32{100, 50, 100, 75}, want: []int32{50, 75, 100}},
+		{name: "above 100 allowed", thresholds: []int32{150}, want: []int32{150}},
+		{name: "zero", thresholds: []int32{0}, wantErr: true},
+		{name: "too large", thresholds: []int32{1001}, wantErr: true},
+		{name: "too many", thresholds: []int32{10, 20, 30, 40, 50, 60}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeBudgetThresholds(tt.thresholds)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBudgetThresholds) {
+					t.Fatalf("expected ErrInvalidBudgetThresholds, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateBudget(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	adminID := testutil.CreateTestUUID(2)
+	categoryID := testutil.CreateTestUUID(3)
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	categoryRepo := &MockExpenseCategoryRepository{
+		GetCategoryByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.ExpenseCategory, error) {
+			if id == categoryID {
+				return sqlc.ExpenseCategory{ID: categoryID, GroupID: groupID}, nil
+			}
+			return sqlc.ExpenseCategory{ID: id, GroupID: testutil.CreateTestUUID(9)}, nil
+		},
+	}
+
+	valid := CreateBudgetInput{
+		GroupID:     groupID,
+		RequesterID: adminID,
+		CategoryID:  categoryID,
+		Name:        "  Food ",
+		Amount:      "500.50",
+		Period:      BudgetPeriodMonthly,
+		StartDate:   &start,
+		Thresholds:  []int32{100, 50},
+	}
+
+	tests := []struct {
+		name    string
+		role    string
+		modify  func(input *CreateBudgetInput)
+		count   int64
+		wantErr error
+	}{
+		{name: "success", role: "admin"},
+		{name: "member cannot manage", role: "member", wantErr: ErrInsufficientPermissions},
+		{name: "blank name", role: "owner", modify: func(i *CreateBudgetInput) { i.Name = " " }, wantErr: ErrInvalidBudgetName},
+		{name: "zero amount", role: "owner", modify: func(i *CreateBudgetInput) { i.Amount = "0" }, wantErr: ErrInvalidBudgetAmount},
+		{name: "too many decimals", role: "owner", modify: func(i *CreateBudgetInput) { i.Amount = "1.005" }, wantErr: ErrInvalidBudgetAmount},
+		{name: "unknown period", role: "owner", modify: func(i *CreateBudgetInput) { i.Period = "yearly" }, wantErr: ErrInvalidBudgetPeriod},
+		{name: "custom without end", role: "owner", modify: func(i *CreateBudgetInput) { i.Period = BudgetPeriodCustom }, wantErr: ErrInvalidBudgetDates},
+		{
+			name: "custom ending before start",
+			role: "owner",
+			modify: func(i *CreateBudgetInput) {
+				i.Period = BudgetPeriodCustom
+				i.StartDate, i.EndDate = &end, &start
+			},
+			wantErr: ErrInvalidBudgetDates,
+		},
+		{name: "category from another group", role: "owner", modify: func(i *CreateBudgetInput) { i.CategoryID = testutil.CreateTestUUID(8) }, wantErr: ErrCategoryNotInGroup},
+		{name: "limit reached", role: "owner", count: maxBudgetsPerGroup, wantErr: ErrTooManyBudgets},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *sqlc.CreateGroupBudgetParams
+			repo := &MockBudgetRepository{
+				CountGroupBudgetsFunc: func(ctx context.Context, id pgtype.UUID) (int64, error) {
+					return tt.count, nil
+				},
+				CreateGroupBudgetFunc: func(ctx context.Context, params sqlc.CreateGroupBudgetParams) (sqlc.GroupBudget, error) {
+					created = &params
+					return sqlc.GroupBudget{GroupID: params.GroupID, Name: params.Name}, nil
+				},
+			}
+			svc := NewBudgetService(repo, budgetTestGroupRepo(groupID, adminID, tt.role), categoryRepo, &MockGroupActivityService{})
+
+			input := valid
+			if tt.modify != nil {
+				tt.modify(&input)
+			}
+			_, err := svc.CreateBudget(context.Background(), input)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				if created != nil {
+					t.Error("budget should not be created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if created.Name != "Food" {
+				t.Errorf("expected trimmed name, got %q", created.Name)
+			}
+			if created.StartDate.Valid || created.EndDate.Valid {
+				t.Error("monthly budgets should not keep dates")
+			}
+			if !slices.Equal(created.Thresholds, []int32{50, 100}) {
+				t.Errorf("expected sorted thresholds, got %v", created.Thresholds)
+			}
+			if created.CategoryID != categoryID {
+				t.Error("expected category to be kept")
+			}
+		})
+	}
+}
+
+func TestUpdateBudget_ClearsCategory(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	adminID := testutil.CreateTestUUID(2)
+	budget := testBudget(groupID, "100", 80, 100)
+	budget.CategoryID = testutil.CreateTestUUID(3)
+
+	var updated sqlc.UpdateGroupBudgetParams
+	repo := &MockBudgetRepository{
+		GetGroupBudgetFunc: func(ctx context.Context, params sqlc.GetGroupBudgetParams) (sqlc.GroupBudget, error) {
+			return budget, nil
+		},
+		UpdateGroupBudgetFunc: func(ctx context.Context, params sqlc.UpdateGroupBudgetParams) (sqlc.GroupBudget, error) {
+			updated = params
+			return sqlc.GroupBudget{}, nil
+		},
+	}
+	svc := NewBudgetService(repo, budgetTestGroupRepo(groupID, adminID, "owner"), &MockExpenseCategoryRepository{}, &MockGroupActivityService{})
+
+	amount := "250"
+	_, err := svc.UpdateBudget(context.Background(), UpdateBudgetInput{
+		GroupID:     groupID,
+		BudgetID:    budget.ID,
+		RequesterID: adminID,
+		CategoryID:  &pgtype.UUID{},
+		Amount:      &amount,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.CategoryID.Valid {
+		t.Error("expected the budget to become group-wide")
+	}
+	if updated.Name != budget.Name || !slices.Equal(updated.Thresholds, budget.Thresholds) {
+		t.Error("unset fields should be kept")
+	}
+	if got, _ := numericToDecimal(updated.Amount); got.String() != "250" {
+		t.Errorf("expected amount 250, got %s", got)
+	}
+}
+
+func TestDeleteBudget_NotFound(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	adminID := testutil.CreateTestUUID(2)
+	svc := NewBudgetService(&MockBudgetRepository{}, budgetTestGroupRepo(groupID, adminID, "admin"), &MockExpenseCategoryRepository{}, &MockGroupActivityService{})
+
+	err := svc.DeleteBudget(context.Background(), groupID, testutil.CreateTestUUID(50), adminID)
+	if !errors.Is(err, ErrBudgetNotFound) {
+		t.Fatalf("expected ErrBudgetNotFound, got %v", err)
+	}
+}
+
+func TestGetBudgetStatus(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	memberID := testutil.CreateTestUUID(2)
+	date := time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)
+
+	var spentParams sqlc.GetBudgetSpentParams
+	repo := &MockBudgetRepository{
+		ListGroupBudgetsFunc: func(ctx context.Context, id pgtype.UUID) ([]sqlc.GroupBudget, error) {
+			return []sqlc.GroupBudget{testBudget(groupID, "200", 50, 80, 100)}, nil
+		},
+		GetBudgetSpentFunc: func(ctx context.Context, params sqlc.GetBudgetSpentParams) (pgtype.Numeric, error) {
+			spentParams = params
+			return stringToNumeric("170.25")
+		},
+	}
+	svc := NewBudgetService(repo, budgetTestGroupRepo(groupID, memberID, "member"), &MockExpenseCategoryRepository{}, &MockGroupActivityService{})
+
+	statuses, err := svc.GetStatus(context.Background(), groupID, memberID, date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 status, got %d", len(statuses))
+	}
+
+	status := statuses[0]
+	if !spentParams.StartDate.Time.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || !spentParams.EndDate.Time.Equal(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected spending for March, got %v - %v", spentParams.StartDate.Time, spentParams.EndDate.Time)
+	}
+	if status.Spent.StringFixed(2) != "170.25" || status.Remaining.StringFixed(2) != "29.75" {
+		t.Errorf("expected 170.25 spent and 29.75 remaining, got %s and %s", status.Spent, status.Remaining)
+	}
+	if !slices.Equal(status.ThresholdsReached, []int32{50, 80}) {
+		t.Errorf("expected thresholds 50 and 80 reached, got %v", status.ThresholdsReached)
+	}
+}
+
+func TestGetBudgetStatus_NotMember(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	svc := NewBudgetService(&MockBudgetRepository{}, budgetTestGroupRepo(groupID, testutil.CreateTestUUID(2), "member"), &MockExpenseCategoryRepository{}, &MockGroupActivityService{})
+
+	_, err := svc.GetStatus(context.Background(), groupID, testutil.CreateTestUUID(3), time.Now())
+	if !errors.Is(err, ErrNotGroupMember) {
+		t.Fatalf("expected ErrNotGroupMember, got %v", err)
+	}
+}
+
+func TestCheckExpense(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	actorID := testutil.CreateTestUUID(2)
+	date := time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		spent         string // total after the save
+		expense       sqlc.Expense
+		previous      *sqlc.Expense
+		wantThreshold int32 // 0 for no alert
+	}{
+		{
+			name:          "new expense crosses one threshold",
+			spent:         "85",
+			expense:       testBudgetExpense(groupID, "10", date),
+			wantThreshold: 80,
+		},
+		{
+			name:          "reports only the highest threshold crossed",
+			spent:         "120",
+			expense:       testBudgetExpense(groupID, "60", date),
+			wantThreshold: 100,
+		},
+		{
+			name:    "already past the threshold",
+			spent:   "95",
+			expense: testBudgetExpense(groupID, "10", date),
+		},
+		{
+			name:    "below every threshold",
+			spent:   "50",
+			expense: testBudgetExpense(groupID, "10", date),
+		},
+		{
+			name:    "update that only shrinks",
+			spent:   "90",
+			expense: testBudgetExpense(groupID, "10", date),
+			previous: func() *sqlc.Expense {
+				e := testBudgetExpense(groupID, "20", date)
+				return &e
+			}(),
+		},
+		{
+			name:    "update that grows an expense",
+			spent:   "101",
+			expense: testBudgetExpense(groupID, "30", date),
+			previous: func() *sqlc.Expense {
+				e := testBudgetExpense(groupID, "20", date)
+				return &e
+			}(),
+			wantThreshold: 100,
+		},
+		{
+			name:    "update moving an expense into the period",
+			spent:   "85",
+			expense: testBudgetExpense(groupID, "10", date),
+			previous: func() *sqlc.Expense {
+				e := testBudgetExpense(groupID, "10", date.AddDate(0, -1, 0))
+				return &e
+			}(),
+			wantThreshold: 80,
+		},
+		{
+			name:  "personal expenses are ignored",
+			spent: "85",
+			expense: func() sqlc.Expense {
+				e := testBudgetExpense(groupID, "10", date)
+				e.Type = "personal"
+				return e
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockBudgetRepository{
+				ListBudgetsForCategoryFunc: func(ctx context.Context, params sqlc.ListBudgetsForCategoryParams) ([]sqlc.GroupBudget, error) {
+					return []sqlc.GroupBudget{testBudget(groupID, "100", 80, 100)}, nil
+				},
+				GetBudgetSpentFunc: func(ctx context.Context, params sqlc.GetBudgetSpentParams) (pgtype.Numeric, error) {
+					return stringToNumeric(tt.spent)
+				},
+			}
+			var logged []LogActivityInput
+			activities := &MockGroupActivityService{
+				LogActivityFunc: func(ctx context.Context, input LogActivityInput) error {
+					logged = append(logged, input)
+					return nil
+				},
+			}
+			svc := NewBudgetService(repo, &testutil.MockGroupRepository{}, &MockExpenseCategoryRepository{}, activities)
+
+			if err := svc.CheckExpense(context.Background(), actorID, tt.expense, tt.previous); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantThreshold == 0 {
+				if len(logged) != 0 {
+					t.Fatalf("expected no alert, got %+v", logged)
+				}
+				return
+			}
+			if len(logged) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(logged))
+			}
+			alert := logged[0]
+			if alert.Action != budgetThresholdAction || alert.EntityType != "budget" || alert.UserID != actorID {
+				t.Errorf("unexpected activity %+v", alert)
+			}
+			if alert.Metadata["threshold"] != tt.wantThreshold {
+				t.Errorf("expected threshold %d, got %v", tt.wantThreshold, alert.Metadata["threshold"])
+			}
+			if alert.Metadata["spent"] != decimal.RequireFromString(tt.spent).StringFixed(2) {
+				t.Errorf("expected spent %s, got %v", tt.spent, alert.Metadata["spent"])
+			}
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+const (
+	// Defaults for the "reminders" object in groups.settings
+	defaultReminderMinAmount    = "50"
+	defaultReminderAfterDays    = 14
+	defaultReminderIntervalDays = 7
+
+	// Reminders aren't delivered between these local hours of the debtor
+	reminderQuietHoursStart = 21
+	reminderQuietHoursEnd   = 9
+
+	// manualReminderCooldown is how long a debtor is left alone after any
+	// reminder before a creditor can send one by hand.
+	manualReminderCooldown = 24 * time.Hour
+)
+
+var (
+	ErrInvalidReminderSettings = errors.New("invalid reminder settings")
+	ErrReminderTooSoon         = errors.New("this member was reminded recently")
+)
+
+// ReminderSettings are a group's automatic reminder settings, stored under
+// "reminders" in groups.settings:
+//
+//	{"reminders": {"enabled": true, "min_amount": "50", "after_days": 14, "interval_days": 7}}
+//
+// Once enabled, members are reminded when they owe at least MinAmount in total
+// or have owed money for After, at most once every Interval.
+type ReminderSettings struct {
+	Enabled   bool
+	MinAmount decimal.Decimal
+	After     time.Duration
+	Interval  time.Duration
+}
+
+// ParseReminderSettings reads the reminder settings out of a group's settings,
+// filling in defaults for missing fields.
+func ParseReminderSettings(groupSettings []byte) (ReminderSettings, error) {
+	var raw struct {
+		Reminders *struct {
+			Enabled      bool             `json:"enabled"`
+			MinAmount    *decimal.Decimal `json:"min_amount"`
+			AfterDays    *int             `json:"after_days"`
+			IntervalDays *int             `json:"interval_days"`
+		} `json:"reminders"`
+	}
+	if len(groupSettings) > 0 {
+		if err := json.Unmarshal(groupSettings, &raw); err != nil {
+			return ReminderSettings{}, ErrInvalidReminderSettings
+		}
+	}
+
+	settings := ReminderSettings{
+		MinAmount: decimal.RequireFromString(defaultReminderMinAmount),
+		After:     defaultReminderAfterDays * 24 * time.Hour,
+		Interval:  defaultReminderIntervalDays * 24 * time.Hour,
+	}
+	if raw.Reminders == nil {
+		return settings, nil
+	}
+
+	r := raw.Reminders
+	settings.Enabled = r.Enabled
+	if r.MinAmount != nil {
+		if r.MinAmount.IsNegative() {
+			return ReminderSettings{}, ErrInvalidReminderSettings
+		}
+		settings.MinAmount = *r.MinAmount
+	}
+	if r.AfterDays != nil {
+		if *r.AfterDays < 0 || *r.AfterDays > 365 {
+			return ReminderSettings{}, ErrInvalidReminderSettings
+		}
+		settings.After = time.Duration(*r.AfterDays) * 24 * time.Hour
+	}
+	if r.IntervalDays != nil {
+		if *r.IntervalDays < 1 || *r.IntervalDays > 365 {
+			return ReminderSettings{}, ErrInvalidReminderSettings
+		}
+		settings.Interval = time.Duration(*r.IntervalDays) * 24 * time.Hour
+	}
+	return settings, nil
+}
+
+// DebtReminderService emails members who owe money in a group. Reminders are
+// sent by the worker according to each group's ReminderSettings, or by a
+// creditor by hand. Either way a debtor gets at most one reminder per interval,
+// and delivery waits out the debtor's quiet hours.
+type DebtReminderService interface {
+	// SendDueReminders reminds every debtor who is due in groups with
+	// reminders enabled and reports how many reminders were queued.
+	SendDueReminders(ctx context.Context, now time.Time) (int, error)
+	// RemindDebtor sends the debtor a reminder of what they owe the creditor.
+	RemindDebtor(ctx context.Context, groupID, creditorID, debtorID pgtype.UUID) error
+}
+
+type debtReminderService struct {
+	repo           repository.DebtReminderRepository
+	groupRepo      repository.GroupRepository
+	balanceService BalanceService
+	emailService   EmailService
+	baseURL        string
+}
+
+// NewDebtReminderService links reminder emails to the group under baseURL, the frontend origin.
+func NewDebtReminderService(
+	repo repository.DebtReminderRepository,
+	groupRepo repository.GroupRepository,
+	balanceService BalanceService,
+	emailService EmailService,
+	baseURL string,
+) DebtReminderService {
+	return &debtReminderService{
+		repo:           repo,
+		groupRepo:      groupRepo,
+		balanceService: balanceService,
+		emailService:   emailService,
+		baseURL:        strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (s *debtReminderService) SendDueReminders(ctx context.Context, now time.Time) (int, error) {
+	groups, err := s.repo.ListReminderGroups(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, group := range groups {
+		if ctx.Err() != nil {
+			break
+		}
+		n, err := s.remindGroup(ctx, group, now)
+		sent += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("group %s: %w", uuidToString(group.ID), err))
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (s *debtReminderService) remindGroup(ctx context.Context, group sqlc.ListReminderGroupsRow, now time.Time) (int, error) {
+	settings, err := ParseReminderSettings(group.Settings)
+	if err != nil || !settings.Enabled {
+		return 0, err
+	}
+
+	// The owner is always a member, so the debts can be read on their behalf
+	debts, err := s.balanceService.GetSimplifiedDebts(ctx, group.ID, group.OwnerID, BalanceOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	// Pending users have no account to remind
+	byDebtor := make(map[pgtype.UUID][]DebtResponse)
+	debtorIDs := []pgtype.UUID{}
+	for _, debt := range debts {
+		if !debt.DebtorID.Valid {
+			continue
+		}
+		if _, ok := byDebtor[debt.DebtorID]; !ok {
+			debtorIDs = append(debtorIDs, debt.DebtorID)
+		}
+		byDebtor[debt.DebtorID] = append(byDebtor[debt.DebtorID], debt)
+	}
+
+	if err := s.repo.TrackDebtors(ctx, sqlc.TrackDebtorsParams{
+		GroupID: group.ID,
+		Now:     pgtype.Timestamptz{Time: now, Valid: true},
+		UserIds: debtorIDs,
+	}); err != nil {
+		return 0, err
+	}
+	if err := s.repo.ClearSettledDebtors(ctx, sqlc.ClearSettledDebtorsParams{GroupID: group.ID, UserIds: debtorIDs}); err != nil {
+		return 0, err
+	}
+
+	states, err := s.repo.ListDebtReminderStates(ctx, group.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, state := range states {
+		owed := byDebtor[state.UserID]
+		if len(owed) == 0 {
+			continue
+		}
+		total := sumDebts(owed)
+		if total.LessThan(settings.MinAmount) && now.Sub(state.OwingSince.Time) < settings.After {
+			continue
+		}
+		if state.LastRemindedAt.Valid && now.Sub(state.LastRemindedAt.Time) < settings.Interval {
+			continue
+		}
+
+		ok, err := s.send(ctx, debtReminder{
+			groupID:        group.ID,
+			groupName:      group.Name,
+			currencyCode:   group.CurrencyCode,
+			debtorID:       state.UserID,
+			email:          state.Email,
+			name:           state.Name.String,
+			language:       state.Language,
+			timezone:       state.Timezone,
+			debts:          owed,
+			remindedBefore: now.Add(-settings.Interval),
+		}, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (s *debtReminderService) RemindDebtor(ctx context.Context, groupID, creditorID, debtorID pgtype.UUID) error {
+	debts, err := s.balanceService.GetSimplifiedDebts(ctx, groupID, creditorID, BalanceOptions{})
+	if err != nil {
+		return err
+	}
+
+	var owed []DebtResponse
+	for _, debt := range debts {
+		if debt.DebtorID.Valid && debt.DebtorID == debtorID && debt.CreditorID == creditorID {
+			owed = append(owed, debt)
+		}
+	}
+	if len(owed) == 0 {
+		return ErrDebtNotFound
+	}
+
+	group, err := s.groupRepo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return ErrGroupNotFound
+	}
+	debtor, err := s.groupRepo.GetUserByID(ctx, debtorID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	ok, err := s.send(ctx, debtReminder{
+		groupID:        groupID,
+		groupName:      group.Name,
+		currencyCode:   group.CurrencyCode,
+		debtorID:       debtorID,
+		email:          debtor.Email,
+		name:           debtor.Name.String,
+		language:       debtor.Language,
+		timezone:       debtor.Timezone,
+		debts:          owed,
+		requestedBy:    owed[0].CreditorName,
+		remindedBefore: now.Add(-manualReminderCooldown),
+	}, now)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrReminderTooSoon
+	}
+	return nil
+}
+
+type debtReminder struct {
+	groupID      pgtype.UUID
+	groupName    string
+	currencyCode string
+	debtorID     pgtype.UUID
+	email        string
+	name         string
+	language     string
+	timezone     string
+	debts        []DebtResponse
+	requestedBy  string
+	// remindedBefore is the latest earlier reminder that still allows this one
+	remindedBefore time.Time
+}
+
+// send records the reminder and queues its email in one transaction, so a
+// reminder is never recorded without its email. It reports false without
+// sending when the debtor was reminded after r.remindedBefore.
+func (s *debtReminderService) send(ctx context.Context, r debtReminder, now time.Time) (bool, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	claimed, err := s.repo.WithTx(tx).ClaimDebtReminder(ctx, sqlc.ClaimDebtReminderParams{
+		GroupID:        r.groupID,
+		UserID:         r.debtorID,
+		Now:            pgtype.Timestamptz{Time: now, Valid: true},
+		RemindedBefore: pgtype.Timestamptz{Time: r.remindedBefore, Valid: true},
+	})
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	lines := make([]DebtReminderLine, 0, len(r.debts))
+	for _, debt := range r.debts {
+		lines = append(lines, DebtReminderLine{CreditorName: debt.CreditorName, Amount: debt.Amount})
+	}
+	name := r.name
+	if name == "" {
+		name = r.email
+	}
+
+	if _, err := s.emailService.WithTx(tx).Enqueue(ctx, EnqueueEmailInput{
+		To:       r.email,
+		Template: EmailTemplateDebtReminder,
+		Language: r.language,
+		Data: DebtReminderEmailData{
+			DebtorName:   name,
+			GroupName:    r.groupName,
+			CurrencyCode: r.currencyCode,
+			Total:        sumDebts(r.debts).StringFixed(2),
+			Debts:        lines,
+			RequestedBy:  r.requestedBy,
+			Link:         s.baseURL + "/app/groups/" + uuidToString(r.groupID),
+		},
+		SendAfter: reminderSendTime(now, r.timezone),
+	}); err != nil {
+		return false, fmt.Errorf("queue reminder email: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func sumDebts(debts []DebtResponse) decimal.Decimal {
+	total := decimal.Zero
+	for _, debt := range debts {
+		amount, err := decimal.NewFromString(debt.Amount)
+		if err == nil {
+			total = total.Add(amount)
+		}
+	}
+	return total
+}
+
+// reminderSendTime is now, or the end of the quiet hours when now falls in
+// them in the given timezone. Unknown timezones are treated as UTC.
+func reminderSendTime(now time.Time, timezone string) time.Time {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	hour := local.Hour()
+	if hour >= reminderQuietHoursEnd && hour < reminderQuietHoursStart {
+		return now
+	}
+
+	end := time.Date(local.Year(), local.Month(), local.Day(), reminderQuietHoursEnd, 0, 0, 0, loc)
+	if hour >= reminderQuietHoursStart {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockDebtReminderRepository for testing
+type MockDebtReminderRepository struct {
+	BeginTxFunc                func(ctx context.Context) (pgx.Tx, error)
+	WithTxFunc                 func(tx pgx.Tx) repository.DebtReminderRepository
+	ListReminderGroupsFunc     func(ctx context.Context) ([]sqlc.ListReminderGroupsRow, error)
+	TrackDebtorsFunc           func(ctx context.Context, params sqlc.TrackDebtorsParams) error
+	ClearSettledDebtorsFunc    func(ctx context.Context, params sqlc.ClearSettledDebtorsParams) error
+	ListDebtReminderStatesFunc func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListDebtReminderStatesRow, error)
+	ClaimDebtReminderFunc      func(ctx context.Context, params sqlc.ClaimDebtReminderParams) (bool, error)
+}
+
+func (m *MockDebtReminderRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	if m.BeginTxFunc != nil {
+		return m.BeginTxFunc(ctx)
+	}
+	return &testutil.MockTx{}, nil
+}
+
+func (m *MockDebtReminderRepository) WithTx(tx pgx.Tx) repository.DebtReminderRepository {
+	if m.WithTxFunc != nil {
+		return m.WithTxFunc(tx)
+	}
+	return m
+}
+
+func (m *MockDebtReminderRepository) ListReminderGroups(ctx context.Context) ([]sqlc.ListReminderGroupsRow, error) {
+	if m.ListReminderGroupsFunc != nil {
+		return m.ListReminderGroupsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockDebtReminderRepository) TrackDebtors(ctx context.Context, params sqlc.TrackDebtorsParams) error {
+	if m.TrackDebtorsFunc != nil {
+		return m.TrackDebtorsFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockDebtReminderRepository) ClearSettledDebtors(ctx context.Context, params sqlc.ClearSettledDebtorsParams) error {
+	if m.ClearSettledDebtorsFunc != nil {
+		return m.ClearSettledDebtorsFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockDebtReminderRepository) ListDebtReminderStates(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListDebtReminderStatesRow, error) {
+	if m.ListDebtReminderStatesFunc != nil {
+		return m.ListDebtReminderStatesFunc(ctx, groupID)
+	}
+	return nil, nil
+}
+
+func (m *MockDebtReminderRepository) ClaimDebtReminder(ctx context.Context, params sqlc.ClaimDebtReminderParams) (bool, error) {
+	if m.ClaimDebtReminderFunc != nil {
+		return m.ClaimDebtReminderFunc(ctx, params)
+	}
+	return true, nil
+}
+
+// MockBalanceService for testing
+type MockBalanceService struct {
+	BalanceService
+	GetSimplifiedDebtsFunc func(ctx context.Context, groupID, requesterID pgtype.UUID, opts BalanceOptions) ([]DebtResponse, error)
+}
+
+func (m *MockBalanceService) GetSimplifiedDebts(ctx context.Context, groupID, requesterID pgtype.UUID, opts BalanceOptions) ([]DebtResponse, error) {
+	if m.GetSimplifiedDebtsFunc != nil {
+		return m.GetSimplifiedDebtsFunc(ctx, groupID, requesterID, opts)
+	}
+	return nil, nil
+}
+
+func TestParseReminderSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		want     ReminderSettings
+		wantErr  bool
+	}{
+		{
+			name:     "defaults when missing",
+			settings: `{}`,
+			want:     ReminderSettings{MinAmount: decimal.NewFromInt(50), After: 14 * 24 * time.Hour, Interval: 7 * 24 * time.Hour},
+		},
+		{
+			name:     "custom values",
+			settings: `{"reminders":{"enabled":true,"min_amount":"20.50","after_days":3,"interval_days":2}}`,
+			want:     ReminderSettings{Enabled: true, MinAmount: decimal.RequireFromString("20.50"), After: 3 * 24 * time.Hour, Interval: 2 * 24 * time.Hour},
+		},
+		{
+			name:     "numeric min amount",
+			settings: `{"reminders":{"enabled":true,"min_amount":10}}`,
+			want:     ReminderSettings{Enabled: true, MinAmount: decimal.NewFromInt(10), After: 14 * 24 * time.Hour, Interval: 7 * 24 * time.Hour},
+		},
+		{name: "negative min amount", settings: `{"reminders":{"min_amount":"-1"}}`, wantErr: true},
+		{name: "zero interval", settings: `{"reminders":{"interval_days":0}}`, wantErr: true},
+		{name: "wrong type", settings: `{"reminders":{"enabled":"yes"}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseReminderSettings([]byte(tt.settings))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidReminderSettings) {
+					t.Fatalf("expected ErrInvalidReminderSettings, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Enabled != tt.want.Enabled || !got.MinAmount.Equal(tt.want.MinAmount) || got.After != tt.want.After || got.Interval != tt.want.Interval {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReminderSendTime(t *testing.T) {
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		timezone string
+		want     time.Time
+	}{
+		{
+			name:     "daytime sends now",
+			now:      time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC),
+			timezone: "UTC",
+			want:     time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "late evening waits for next morning",
+			now:      time.Date(2026, 3, 4, 22, 30, 0, 0, time.UTC),
+			timezone: "UTC",
+			want:     time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "early morning waits until nine",
+			now:      time.Date(2026, 3, 4, 6, 0, 0, 0, time.UTC),
+			timezone: "UTC",
+			want:     time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "uses the debtor's timezone",
+			now:      time.Date(2026, 3, 4, 17, 0, 0, 0, time.UTC), // 22:30 in Kolkata
+			timezone: "Asia/Kolkata",
+			want:     time.Date(2026, 3, 5, 9, 0, 0, 0, kolkata),
+		},
+		{
+			name:     "unknown timezone falls back to UTC",
+			now:      time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC),
+			timezone: "Mars/Olympus",
+			want:     time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reminderSendTime(tt.now, tt.timezone); !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSendDueReminders(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	ownerID := testutil.CreateTestUUID(2)
+	bigDebtor := testutil.CreateTestUUID(3)
+	oldDebtor := testutil.CreateTestUUID(4)
+	newDebtor := testutil.CreateTestUUID(5)
+	recentDebtor := testutil.CreateTestUUID(6)
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	reminderRepo := &MockDebtReminderRepository{
+		ListReminderGroupsFunc: func(ctx context.Context) ([]sqlc.ListReminderGroupsRow, error) {
+			return []sqlc.ListReminderGroupsRow{
+				{ID: groupID, Name: "Trip", CurrencyCode: "USD", OwnerID: ownerID, Settings: []byte(`{"reminders":{"enabled":true}}`)},
+			}, nil
+		},
+		ListDebtReminderStatesFunc: func(ctx context.Context, id pgtype.UUID) ([]sqlc.ListDebtReminderStatesRow, error) {
+			return []sqlc.ListDebtReminderStatesRow{
+				{UserID: bigDebtor, Email: "big@example.com", Timezone: "UTC", OwingSince: pgtype.Timestamptz{Time: now, Valid: true}},
+				{UserID: oldDebtor, Email: "old@example.com", Timezone: "UTC", OwingSince: pgtype.Timestamptz{Time: now.Add(-20 * day), Valid: true}},
+				{UserID: newDebtor, Email: "new@example.com", Timezone: "UTC", OwingSince: pgtype.Timestamptz{Time: now.Add(-day), Valid: true}},
+				{
+					UserID: recentDebtor, Email: "recent@example.com", Timezone: "UTC",
+					OwingSince:     pgtype.Timestamptz{Time: now.Add(-30 * day), Valid: true},
+					LastRemindedAt: pgtype.Timestamptz{Time: now.Add(-2 * day), Valid: true},
+				},
+			}, nil
+		},
+	}
+
+	var tracked []pgtype.UUID
+	reminderRepo.TrackDebtorsFunc = func(ctx context.Context, params sqlc.TrackDebtorsParams) error {
+		tracked = params.UserIds
+		return nil
+	}
+
+	balanceService := &MockBalanceService{
+		GetSimplifiedDebtsFunc: func(ctx context.Context, id, requesterID pgtype.UUID, opts BalanceOptions) ([]DebtResponse, error) {
+			if requesterID != ownerID {
+				t.Errorf("expected debts to be read as the owner")
+			}
+			return []DebtResponse{
+				{DebtorID: bigDebtor, CreditorID: ownerID, CreditorName: "Owner", Amount: "80.00"},
+				{DebtorID: oldDebtor, CreditorID: ownerID, CreditorName: "Owner", Amount: "5.00"},
+				{DebtorID: newDebtor, CreditorID: ownerID, CreditorName: "Owner", Amount: "5.00"},
+				{DebtorID: recentDebtor, CreditorID: ownerID, CreditorName: "Owner", Amount: "500.00"},
+				// Pending users can't be reminded
+				{DebtorPendingUserID: &groupID, CreditorID: ownerID, Amount: "100.00"},
+			}, nil
+		},
+	}
+
+	var sentTo []string
+	emailService := &MockEmailService{
+		EnqueueFunc: func(ctx context.Context, input EnqueueEmailInput) (sqlc.EmailOutbox, error) {
+			sentTo = append(sentTo, input.To)
+			if input.Template != EmailTemplateDebtReminder {
+				t.Errorf("unexpected template %q", input.Template)
+			}
+			return sqlc.EmailOutbox{}, nil
+		},
+	}
+
+	svc := NewDebtReminderService(reminderRepo, &testutil.MockGroupRepository{}, balanceService, emailService, "https://app.example.com")
+	sent, err := svc.SendDueReminders(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tracked) != 4 {
+		t.Errorf("expected 4 registered debtors to be tracked, got %d", len(tracked))
+	}
+	if sent != 2 || len(sentTo) != 2 || sentTo[0] != "big@example.com" || sentTo[1] != "old@example.com" {
+		t.Errorf("expected reminders for the big and old debts, got %d %v", sent, sentTo)
+	}
+}
+
+func TestSendDueRemindersSkipsDisabledGroups(t *testing.T) {
+	reminderRepo := &MockDebtReminderRepository{
+		ListReminderGroupsFunc: func(ctx context.Context) ([]sqlc.ListReminderGroupsRow, error) {
+			return []sqlc.ListReminderGroupsRow{{ID: testutil.CreateTestUUID(1), Settings: []byte(`{"reminders":{"enabled":false}}`)}}, nil
+		},
+	}
+	balanceService := &MockBalanceService{
+		GetSimplifiedDebtsFunc: func(ctx context.Context, id, requesterID pgtype.UUID, opts BalanceOptions) ([]DebtResponse, error) {
+			t.Error("debts should not be loaded for a disabled group")
+			return nil, nil
+		},
+	}
+
+	svc := NewDebtReminderService(reminderRepo, &testutil.MockGroupRepository{}, balanceService, &MockEmailService{}, "")
+	if sent, err := svc.SendDueReminders(context.Background(), time.Now()); err != nil || sent != 0 {
+		t.Errorf("expected nothing sent, got %d, %v", sent, err)
+	}
+}
+
+func TestRemindDebtor(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	creditorID := testutil.CreateTestUUID(2)
+	debtorID := testutil.CreateTestUUID(3)
+	otherID := testutil.CreateTestUUID(4)
+
+	tests := []struct {
+		name        string
+		debtorID    pgtype.UUID
+		claimed     bool
+		expectedErr error
+	}{
+		{name: "sends reminder", debtorID: debtorID, claimed: true},
+		{name: "reminded recently", debtorID: debtorID, claimed: false, expectedErr: ErrReminderTooSoon},
+		{name: "debtor owes someone else", debtorID: otherID, claimed: true, expectedErr: ErrDebtNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var claim sqlc.ClaimDebtReminderParams
+			reminderRepo := &MockDebtReminderRepository{
+				ClaimDebtReminderFunc: func(ctx context.Context, params sqlc.ClaimDebtReminderParams) (bool, error) {
+					claim = params
+					return tt.claimed, nil
+				},
+			}
+			balanceService := &MockBalanceService{
+				GetSimplifiedDebtsFunc: func(ctx context.Context, id, requesterID pgtype.UUID, opts BalanceOptions) ([]DebtResponse, error) {
+					return []DebtResponse{
+						{DebtorID: debtorID, CreditorID: creditorID, CreditorName: "Asha", Amount: "12.50"},
+						{DebtorID: otherID, CreditorID: debtorID, CreditorName: "Ben", Amount: "3.00"},
+					}, nil
+				},
+			}
+			groupRepo := &testutil.MockGroupRepository{
+				GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+					return testutil.CreateTestGroup(groupID, "Trip", creditorID), nil
+				},
+				GetUserByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+					return sqlc.User{ID: id, Email: "debtor@example.com", Timezone: "UTC"}, nil
+				},
+			}
+
+			var data DebtReminderEmailData
+			emailService := &MockEmailService{
+				EnqueueFunc: func(ctx context.Context, input EnqueueEmailInput) (sqlc.EmailOutbox, error) {
+					data = input.Data.(DebtReminderEmailData)
+					return sqlc.EmailOutbox{}, nil
+				},
+			}
+
+			svc := NewDebtReminderService(reminderRepo, groupRepo, balanceService, emailService, "https://app.example.com/")
+			err := svc.RemindDebtor(context.Background(), groupID, creditorID, tt.debtorID)
+			if err != tt.expectedErr {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if claim.Now.Time.Sub(claim.RemindedBefore.Time) != manualReminderCooldown {
+				t.Errorf("expected a %v cooldown, got %v", manualReminderCooldown, claim.Now.Time.Sub(claim.RemindedBefore.Time))
+			}
+			if data.RequestedBy != "Asha" || data.Total != "12.50" || len(data.Debts) != 1 {
+				t.Errorf("unexpected email data %+v", data)
+			}
+			if data.Link != "https://app.example.com/app/groups/"+uuidToString(groupID) {
+				t.Errorf("unexpected link %q", data.Link)
+			}
+		})
+	}
+}
+
+func TestRemindDebtor_EmailNotQueued(t *testing.T) {
+	creditorID := testutil.CreateTestUUID(2)
+	debtorID := testutil.CreateTestUUID(3)
+
+	committed := false
+	reminderRepo := &MockDebtReminderRepository{
+		BeginTxFunc: func(ctx context.Context) (pgx.Tx, error) {
+			return &testutil.MockTx{CommitFunc: func(ctx context.Context) error {
+				committed = true
+				return nil
+			}}, nil
+		},
+	}
+	balanceService := &MockBalanceService{
+		GetSimplifiedDebtsFunc: func(ctx context.Context, id, requesterID pgtype.UUID, opts BalanceOptions) ([]DebtResponse, error) {
+			return []DebtResponse{{DebtorID: debtorID, CreditorID: creditorID, CreditorName: "Asha", Amount: "12.50"}}, nil
+		},
+	}
+	groupRepo := &testutil.MockGroupRepository{
+		GetUserByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+			return sqlc.User{ID: id, Email: "debtor@example.com", Timezone: "UTC"}, nil
+		},
+	}
+	emailService := &MockEmailService{
+		EnqueueFunc: func(ctx context.Context, input EnqueueEmailInput) (sqlc.EmailOutbox, error) {
+			return sqlc.EmailOutbox{}, errors.New("outbox unavailable")
+		},
+	}
+
+	svc := NewDebtReminderService(reminderRepo, groupRepo, balanceService, emailService, "")
+	if err := svc.RemindDebtor(context.Background(), testutil.CreateTestUUID(1), creditorID, debtorID); err == nil {
+		t.Fatal("expected an error")
+	}
+	if committed {
+		t.Error("expected the reminder claim to be rolled back with the email")
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+const (
+	DefaultEmailMaxAttempts = 8
+
+	emailRetryBaseDelay = time.Minute
+	emailRetryMaxDelay  = 6 * time.Hour
+)
+
+type EnqueueEmailInput struct {
+	To       string
+	Template string
+	Language string
+	Data     any
+	// SendAfter holds the email back until then; zero sends it right away
+	SendAfter time.Time
+}
+
+// EmailService queues templated email in the outbox. Delivery happens later in
+// the worker, so a slow or unavailable mail server never fails a request.
+type EmailService interface {
+	Enqueue(ctx context.Context, input EnqueueEmailInput) (sqlc.EmailOutbox, error)
+	// WithTx queues email as part of tx, so it is only sent if tx commits.
+	WithTx(tx pgx.Tx) EmailService
+	// DeliverPending sends up to batchSize due messages and reports how many were sent.
+	DeliverPending(ctx context.Context, batchSize int) (int, error)
+}
+
+type emailService struct {
+	repo   repository.EmailOutboxRepository
+	mailer Mailer
+}
+
+func NewEmailService(repo repository.EmailOutboxRepository, mailer Mailer) EmailService {
+	return &emailService{repo: repo, mailer: mailer}
+}
+
+func (s *emailService) WithTx(tx pgx.Tx) EmailService {
+	return &emailService{repo: s.repo.WithTx(tx), mailer: s.mailer}
+}
+
+func (s *emailService) Enqueue(ctx context.Context, input EnqueueEmailInput) (sqlc.EmailOutbox, error) {
+	to, err := mail.ParseAddress(input.To)
+	if err != nil {
+		return sqlc.EmailOutbox{}, ErrInvalidEmailAddress
+	}
+
+	subject, text, html, err := RenderEmail(input.Template, input.Language, input.Data)
+	if err != nil {
+		return sqlc.EmailOutbox{}, fmt.Errorf("render %s email: %w", input.Template, err)
+	}
+
+	return s.repo.EnqueueEmail(ctx, sqlc.EnqueueEmailParams{
+		Template:    input.Template,
+		ToAddress:   to.Address,
+		Subject:     subject,
+		TextBody:    text,
+		HtmlBody:    html,
+		MaxAttempts: DefaultEmailMaxAttempts,
+		SendAfter:   pgtype.Timestamptz{Time: input.SendAfter, Valid: !input.SendAfter.IsZero()},
+	})
+}
+
+func (s *emailService) DeliverPending(ctx context.Context, batchSize int) (int, error) {
+	emails, err := s.repo.ClaimDueEmails(ctx, int32(batchSize))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, email := range emails {
+		if ctx.Err() != nil {
+			// Claimed rows become due again once their lock goes stale
+			break
+		}
+
+		sendErr := s.mailer.Send(ctx, EmailMessage{
+			To:       email.ToAddress,
+			Subject:  email.Subject,
+			TextBody: email.TextBody,
+			HTMLBody: email.HtmlBody,
+		})
+		if sendErr == nil {
+			if err := s.repo.MarkEmailSent(ctx, email.ID); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			sent++
+			continue
+		}
+
+		lastError := pgtype.Text{String: truncateError(sendErr.Error()), Valid: true}
+		if errors.Is(sendErr, ErrInvalidEmailAddress) || email.Attempts >= email.MaxAttempts {
+			err = s.repo.MarkEmailFailed(ctx, sqlc.MarkEmailFailedParams{ID: email.ID, LastError: lastError})
+		} else {
+			err = s.repo.MarkEmailRetry(ctx, sqlc.MarkEmailRetryParams{
+				ID:            email.ID,
+				NextAttemptAt: pgtype.Timestamptz{Time: time.Now().Add(emailRetryDelay(int(email.Attempts))), Valid: true},
+				LastError:     lastError,
+			})
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// emailRetryDelay doubles the wait after every failed attempt: 1m, 2m, 4m, ...
+// capped at emailRetryMaxDelay.
+func emailRetryDelay(attempts int) time.Duration {
+	return backoffDelay(attempts, emailRetryBaseDelay, emailRetryMaxDelay)
+}
+
+// backoffDelay is base after the first attempt, doubling with every attempt
+// after that, capped at max.
+func backoffDelay(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
+
+func truncateError(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if len(msg) > 1000 {
+		return msg[:1000]
+	}
+	return msg
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockEmailService for testing
+type MockEmailService struct {
+	EnqueueFunc        func(ctx context.Context, input EnqueueEmailInput) (sqlc.EmailOutbox, error)
+	DeliverPendingFunc func(ctx context.Context, batchSize int) (int, error)
+	WithTxFunc         func(tx pgx.Tx) EmailService
+}
+
+func (m *MockEmailService) Enqueue(ctx context.Context, input EnqueueEmailInput) (sqlc.EmailOutbox, error) {
+	if m.EnqueueFunc != nil {
+		return m.EnqueueFunc(ctx, input)
+	}
+	return sqlc.EmailOutbox{}, nil
+}
+
+func (m *MockEmailService) WithTx(tx pgx.Tx) EmailService {
+	if m.WithTxFunc != nil {
+		return m.WithTxFunc(tx)
+	}
+	return m
+}
+
+func (m *MockEmailService) DeliverPending(ctx context.Context, batchSize int) (int, error) {
+	if m.DeliverPendingFunc != nil {
+		return m.DeliverPendingFunc(ctx, batchSize)
+	}
+	return 0, nil
+}
+
+// MockEmailOutboxRepository for testing
+type MockEmailOutboxRepository struct {
+	EnqueueEmailFunc    func(ctx context.Context, params sqlc.EnqueueEmailParams) (sqlc.EmailOutbox, error)
+	ClaimDueEmailsFunc  func(ctx context.Context, limit int32) ([]sqlc.EmailOutbox, error)
+	MarkEmailSentFunc   func(ctx context.Context, id pgtype.UUID) error
+	MarkEmailRetryFunc  func(ctx context.Context, params sqlc.MarkEmailRetryParams) error
+	MarkEmailFailedFunc func(ctx context.Context, params sqlc.MarkEmailFailedParams) error
+}
+
+func (m *MockEmailOutboxRepository) WithTx(tx pgx.Tx) repository.EmailOutboxRepository {
+	return m
+}
+
+func (m *MockEmailOutboxRepository) EnqueueEmail(ctx context.Context, params sqlc.EnqueueEmailParams) (sqlc.EmailOutbox, error) {
+	if m.EnqueueEmailFunc != nil {
+		return m.EnqueueEmailFunc(ctx, params)
+	}
+	return sqlc.EmailOutbox{}, nil
+}
+
+func (m *MockEmailOutboxRepository) ClaimDueEmails(ctx context.Context, limit int32) ([]sqlc.EmailOutbox, error) {
+	if m.ClaimDueEmailsFunc != nil {
+		return m.ClaimDueEmailsFunc(ctx, limit)
+	}
+	return []sqlc.EmailOutbox{}, nil
+}
+
+func (m *MockEmailOutboxRepository) MarkEmailSent(ctx context.Context, id pgtype.UUID) error {
+	if m.MarkEmailSentFunc != nil {
+		return m.MarkEmailSentFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockEmailOutboxRepository) MarkEmailRetry(ctx context.Context, params sqlc.MarkEmailRetryParams) error {
+	if m.MarkEmailRetryFunc != nil {
+		return m.MarkEmailRetryFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockEmailOutboxRepository) MarkEmailFailed(ctx context.Context, params sqlc.MarkEmailFailedParams) error {
+	if m.MarkEmailFailedFunc != nil {
+		return m.MarkEmailFailedFunc(ctx, params)
+	}
+	return nil
+}
+
+// failingMailer fails every send with err.
+type failingMailer struct {
+	err error
+}
+
+func (m failingMailer) Send(ctx context.Context, msg EmailMessage) error {
+	return m.err
+}
+
+func TestEmailService_Enqueue_RendersLocalizedTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		language    string
+		wantSubject string
+	}{
+		{name: "english", language: "en", wantSubject: "Olivia invited you to join Trip <3 on SplitPlus"},
+		{name: "regional variant falls back to base language", language: "es-MX", wantSubject: "Olivia te invitó a unirte a Trip <3 en SplitPlus"},
+		{name: "unknown language falls back to english", language: "xx", wantSubject: "Olivia invited you to join Trip <3 on SplitPlus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queued sqlc.EnqueueEmailParams
+			repo := &MockEmailOutboxRepository{
+				EnqueueEmailFunc: func(ctx context.Context, params sqlc.EnqueueEmailParams) (sqlc.EmailOutbox, error) {
+					queued = params
+					return sqlc.EmailOutbox{}, nil
+				},
+			}
+			svc := NewEmailService(repo, NewMemoryMailer(false))
+
+			_, err := svc.Enqueue(context.Background(), EnqueueEmailInput{
+				To:       "Alice <alice@example.com>",
+				Template: EmailTemplateGroupInvitation,
+				Language: tt.language,
+				Data: GroupInvitationEmailData{
+					InviterName: "Olivia",
+					GroupName:   "Trip <3",
+					Link:        "https://app.example.com/invite/abc",
+					ExpiresIn:   7,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if queued.ToAddress != "alice@example.com" {
+				t.Errorf("expected bare address, got %q", queued.ToAddress)
+			}
+			if queued.Subject != tt.wantSubject {
+				t.Errorf("expected subject %q, got %q", tt.wantSubject, queued.Subject)
+			}
+			if !strings.Contains(queued.TextBody, "https://app.example.com/invite/abc") {
+				t.Errorf("expected link in text body, got %q", queued.TextBody)
+			}
+			if !strings.Contains(queued.HtmlBody, "Trip &lt;3") {
+				t.Errorf("expected escaped group name in html body, got %q", queued.HtmlBody)
+			}
+			if queued.MaxAttempts != DefaultEmailMaxAttempts {
+				t.Errorf("expected max attempts %d, got %d", DefaultEmailMaxAttempts, queued.MaxAttempts)
+			}
+		})
+	}
+}
+
+func TestEmailService_Enqueue_Errors(t *testing.T) {
+	svc := NewEmailService(&MockEmailOutboxRepository{}, NewMemoryMailer(false))
+
+	if _, err := svc.Enqueue(context.Background(), EnqueueEmailInput{To: "nope", Template: EmailTemplateGroupInvitation}); err != ErrInvalidEmailAddress {
+		t.Errorf("expected ErrInvalidEmailAddress, got %v", err)
+	}
+	if _, err := svc.Enqueue(context.Background(), EnqueueEmailInput{To: "a@example.com", Template: "missing"}); !errors.Is(err, ErrUnknownEmailTemplate) {
+		t.Errorf("expected ErrUnknownEmailTemplate, got %v", err)
+	}
+}
+
+func TestEmailService_Enqueue_DebtReminderWithSendAfter(t *testing.T) {
+	var queued sqlc.EnqueueEmailParams
+	repo := &MockEmailOutboxRepository{
+		EnqueueEmailFunc: func(ctx context.Context, params sqlc.EnqueueEmailParams) (sqlc.EmailOutbox, error) {
+			queued = params
+			return sqlc.EmailOutbox{}, nil
+		},
+	}
+	svc := NewEmailService(repo, NewMemoryMailer(false))
+
+	sendAfter := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	_, err := svc.Enqueue(context.Background(), EnqueueEmailInput{
+		To:       "bob@example.com",
+		Template: EmailTemplateDebtReminder,
+		Data: DebtReminderEmailData{
+			DebtorName:   "Bob",
+			GroupName:    "Trip",
+			CurrencyCode: "USD",
+			Total:        "30.00",
+			Debts:        []DebtReminderLine{{CreditorName: "Asha", Amount: "20.00"}, {CreditorName: "Chen", Amount: "10.00"}},
+			RequestedBy:  "Asha",
+			Link:         "https://app.example.com/app/groups/1",
+		},
+		SendAfter: sendAfter,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !queued.SendAfter.Valid || !queued.SendAfter.Time.Equal(sendAfter) {
+		t.Errorf("expected send after %v, got %+v", sendAfter, queued.SendAfter)
+	}
+	for _, want := range []string{"Asha", "Chen", "30.00 USD", "https://app.example.com/app/groups/1"} {
+		if !strings.Contains(queued.TextBody, want) {
+			t.Errorf("expected %q in text body, got %q", want, queued.TextBody)
+		}
+	}
+
+	if _, err := svc.Enqueue(context.Background(), EnqueueEmailInput{To: "bob@example.com", Template: EmailTemplateGroupInvitation, Data: GroupInvitationEmailData{}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if queued.SendAfter.Valid {
+		t.Errorf("expected no send after by default, got %+v", queued.SendAfter)
+	}
+}
+
+func TestEmailService_DeliverPending(t *testing.T) {
+	email := sqlc.EmailOutbox{
+		ID:          testutil.CreateTestUUID(1),
+		ToAddress:   "alice@example.com",
+		Subject:     "Hello",
+		TextBody:    "Body",
+		MaxAttempts: 3,
+	}
+
+	tests := []struct {
+		name      string
+		attempts  int32
+		mailer    Mailer
+		wantSent  int
+		wantState string
+		wantDelay time.Duration
+	}{
+		{name: "sent", attempts: 1, mailer: NewMemoryMailer(false), wantSent: 1, wantState: "sent"},
+		{name: "first failure retries after base delay", attempts: 1, mailer: failingMailer{errors.New("smtp down")}, wantState: "retry", wantDelay: time.Minute},
+		{name: "later failure backs off", attempts: 2, mailer: failingMailer{errors.New("smtp down")}, wantState: "retry", wantDelay: 2 * time.Minute},
+		{name: "last attempt fails permanently", attempts: 3, mailer: failingMailer{errors.New("smtp down")}, wantState: "failed"},
+		{name: "invalid address fails immediately", attempts: 1, mailer: failingMailer{ErrInvalidEmailAddress}, wantState: "failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claimed := email
+			claimed.Attempts = tt.attempts
+
+			state := ""
+			var retryAt time.Time
+			repo := &MockEmailOutboxRepository{
+				ClaimDueEmailsFunc: func(ctx context.Context, limit int32) ([]sqlc.EmailOutbox, error) {
+					return []sqlc.EmailOutbox{claimed}, nil
+				},
+				MarkEmailSentFunc: func(ctx context.Context, id pgtype.UUID) error {
+					state = "sent"
+					return nil
+				},
+				MarkEmailRetryFunc: func(ctx context.Context, params sqlc.MarkEmailRetryParams) error {
+					state = "retry"
+					retryAt = params.NextAttemptAt.Time
+					if params.LastError.String == "" {
+						t.Error("expected last error to be recorded")
+					}
+					return nil
+				},
+				MarkEmailFailedFunc: func(ctx context.Context, params sqlc.MarkEmailFailedParams) error {
+					state = "failed"
+					return nil
+				},
+			}
+
+			svc := NewEmailService(repo, tt.mailer)
+			before := time.Now()
+			sent, err := svc.DeliverPending(context.Background(), 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sent != tt.wantSent {
+				t.Errorf("expected %d sent, got %d", tt.wantSent, sent)
+			}
+			if state != tt.wantState {
+				t.Errorf("expected state %q, got %q", tt.wantState, state)
+			}
+			if tt.wantDelay > 0 {
+				delay := retryAt.Sub(before)
+				if delay < tt.wantDelay || delay > tt.wantDelay+time.Second {
+					t.Errorf("expected retry in ~%v, got %v", tt.wantDelay, delay)
+				}
+			}
+		})
+	}
+}
+
+func TestEmailRetryDelay_Capped(t *testing.T) {
+	if got := emailRetryDelay(30); got != emailRetryMaxDelay {
+		t.Errorf("expected delay capped at %v, got %v", emailRetryMaxDelay, got)
+	}
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+const (
+	EmailTemplateGroupInvitation = "group_invitation"
+	EmailTemplateDebtReminder    = "debt_reminder"
+	EmailTemplatePasswordReset   = "password_reset"
+
+	defaultEmailLanguage = "en"
+)
+
+var ErrUnknownEmailTemplate = errors.New("unknown email template")
+
+// GroupInvitationEmailData is the data for EmailTemplateGroupInvitation.
+type GroupInvitationEmailData struct {
+	InviterName string
+	GroupName   string
+	Link        string
+	ExpiresIn   int // days
+}
+
+// DebtReminderEmailData is the data for EmailTemplateDebtReminder.
+type DebtReminderEmailData struct {
+	DebtorName   string
+	GroupName    string
+	CurrencyCode string
+	Total        string
+	Debts        []DebtReminderLine
+	RequestedBy  string // set when a creditor sent the reminder by hand
+	Link         string
+}
+
+// DebtReminderLine is one creditor the debtor owes.
+type DebtReminderLine struct {
+	CreditorName string
+	Amount       string
+}
+
+// PasswordResetEmailData is the data for EmailTemplatePasswordReset.
+type PasswordResetEmailData struct {
+	Name      string
+	Link      string
+	ExpiresIn int // minutes
+}
+
+// emailTemplateSource is one localized variant of a template. Subject and Text
+// are rendered as text/template, HTML as html/template so data is escaped.
+type emailTemplateSource struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type emailTemplate struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// emailTemplateSources maps template name -> language -> source. Every template
+// must have an entry for defaultEmailLanguage.
+var emailTemplateSources = map[string]map[string]emailTemplateSource{
+	EmailTemplateGroupInvitation: {
+		"en": {
+			Subject: `{{.InviterName}} invited you to join {{.GroupName}} on SplitPlus`,
+			Text: `Hi,
+
+{{.InviterName}} invited you to join "{{.GroupName}}" on SplitPlus to share expenses.
+
+Accept the invitation here:
+{{.Link}}
+
+The invitation expires in {{.ExpiresIn}} days. If you weren't expecting it, you can ignore this email.
+`,
+			HTML: `<p>Hi,</p>
+<p>{{.InviterName}} invited you to join <strong>{{.GroupName}}</strong> on SplitPlus to share expenses.</p>
+<p><a href="{{.Link}}">Accept the invitation</a></p>
+<p>The invitation expires in {{.ExpiresIn}} days. If you weren't expecting it, you can ignore this email.</p>
+`,
+		},
+		"es": {
+			Subject: `{{.InviterName}} te invitó a unirte a {{.GroupName}} en SplitPlus`,
+			Text: `Hola:
+
+{{.InviterName}} te invitó a unirte a "{{.GroupName}}" en SplitPlus para compartir gastos.
+
+Acepta la invitación aquí:
+{{.Link}}
+
+La invitación caduca en {{.ExpiresIn}} días. Si no la esperabas, puedes ignorar este correo.
+`,
+			HTML: `<p>Hola:</p>
+<p>{{.InviterName}} te invitó a unirte a <strong>{{.GroupName}}</strong> en SplitPlus para compartir gastos.</p>
+<p><a href="{{.Link}}">Aceptar la invitación</a></p>
+<p>La invitación caduca en {{.ExpiresIn}} días. Si no la esperabas, puedes ignorar este correo.</p>
+`,
+		},
+		"fr": {
+			Subject: `{{.InviterName}} vous invite à rejoindre {{.GroupName}} sur SplitPlus`,
+			Text: `Bonjour,
+
+{{.InviterName}} vous invite à rejoindre « {{.GroupName}} » sur SplitPlus pour partager vos dépenses.
+
+Acceptez l'invitation ici :
+{{.Link}}
+
+L'invitation expire dans {{.ExpiresIn}} jours. Si vous ne l'attendiez pas, vous pouvez ignorer cet e-mail.
+`,
+			HTML: `<p>Bonjour,</p>
+<p>{{.InviterName}} vous invite à rejoindre <strong>{{.GroupName}}</strong> sur SplitPlus pour partager vos dépenses.</p>
+<p><a href="{{.Link}}">Accepter l'invitation</a></p>
+<p>L'invitation expire dans {{.ExpiresIn}} jours. Si vous ne l'attendiez pas, vous pouvez ignorer cet e-mail.</p>
+`,
+		},
+	},
+	EmailTemplateDebtReminder: {
+		"en": {
+			Subject: `Reminder: you owe {{.Total}} {{.CurrencyCode}} in {{.GroupName}}`,
+			Text: `Hi {{.DebtorName}},
+
+{{if .RequestedBy}}{{.RequestedBy}} sent you a reminder: you{{else}}Just a reminder that you{{end}} owe {{.Total}} {{.CurrencyCode}} in "{{.GroupName}}" on SplitPlus.
+{{range .Debts}}
+- {{.Amount}} {{$.CurrencyCode}} to {{.CreditorName}}{{end}}
+
+Settle up here:
+{{.Link}}
+`,
+			HTML: `<p>Hi {{.DebtorName}},</p>
+<p>{{if .RequestedBy}}{{.RequestedBy}} sent you a reminder: you{{else}}Just a reminder that you{{end}} owe <strong>{{.Total}} {{.CurrencyCode}}</strong> in <strong>{{.GroupName}}</strong> on SplitPlus.</p>
+<ul>{{range .Debts}}
+<li>{{.Amount}} {{$.CurrencyCode}} to {{.CreditorName}}</li>{{end}}
+</ul>
+<p><a href="{{.Link}}">Settle up</a></p>
+`,
+		},
+		"es": {
+			Subject: `Recordatorio: debes {{.Total}} {{.CurrencyCode}} en {{.GroupName}}`,
+			Text: `Hola, {{.DebtorName}}:
+
+{{if .RequestedBy}}{{.RequestedBy}} te envió un recordatorio: debes{{else}}Te recordamos que debes{{end}} {{.Total}} {{.CurrencyCode}} en "{{.GroupName}}" en SplitPlus.
+{{range .Debts}}
+- {{.Amount}} {{$.CurrencyCode}} a {{.CreditorName}}{{end}}
+
+Salda tus cuentas aquí:
+{{.Link}}
+`,
+			HTML: `<p>Hola, {{.DebtorName}}:</p>
+<p>{{if .RequestedBy}}{{.RequestedBy}} te envió un recordatorio: debes{{else}}Te recordamos que debes{{end}} <strong>{{.Total}} {{.CurrencyCode}}</strong> en <strong>{{.GroupName}}</strong> en SplitPlus.</p>
+<ul>{{range .Debts}}
+<li>{{.Amount}} {{$.CurrencyCode}} a {{.CreditorName}}</li>{{end}}
+</ul>
+<p><a href="{{.Link}}">Saldar cuentas</a></p>
+`,
+		},
+		"fr": {
+			Subject: `Rappel : vous devez {{.Total}} {{.CurrencyCode}} dans {{.GroupName}}`,
+			Text: `Bonjour {{.DebtorName}},
+
+{{if .RequestedBy}}{{.RequestedBy}} vous envoie un rappel : vous devez{{else}}Petit rappel : vous devez{{end}} {{.Total}} {{.CurrencyCode}} dans « {{.GroupName}} » sur SplitPlus.
+{{range .Debts}}
+- {{.Amount}} {{$.CurrencyCode}} à {{.CreditorName}}{{end}}
+
+Réglez vos comptes ici :
+{{.Link}}
+`,
+			HTML: `<p>Bonjour {{.DebtorName}},</p>
+<p>{{if .RequestedBy}}{{.RequestedBy}} vous envoie un rappel : vous devez{{else}}Petit rappel : vous devez{{end}} <strong>{{.Total}} {{.CurrencyCode}}</strong> dans <strong>{{.GroupName}}</strong> sur SplitPlus.</p>
+<ul>{{range .Debts}}
+<li>{{.Amount}} {{$.CurrencyCode}} à {{.CreditorName}}</li>{{end}}
+</ul>
+<p><a href="{{.Link}}">Régler mes comptes</a></p>
+`,
+		},
+	},
+	EmailTemplatePasswordReset: {
+		"en": {
+			Subject: `Reset your password`,
+			Text: `Hi {{.Name}},
+
+Someone asked to reset the password for your account. To choose a new password, open the link below:
+
+{{.Link}}
+
+The link expires in {{.ExpiresIn}} minutes. If you didn't ask for this, you can ignore this email.
+`,
+			HTML: `<p>Hi {{.Name}},</p>
+<p>Someone asked to reset the password for your account.</p>
+<p><a href="{{.Link}}">Choose a new password</a></p>
+<p>The link expires in {{.ExpiresIn}} minutes. If you didn't ask for this, you can ignore this email.</p>
+`,
+		},
+		"es": {
+			Subject: `Restablece tu contraseña`,
+			Text: `Hola, {{.Name}}:
+
+Alguien pidió restablecer la contraseña de tu cuenta. Para elegir una contraseña nueva, abre el enlace:
+
+{{.Link}}
+
+El enlace caduca en {{.ExpiresIn}} minutos. Si no lo pediste, puedes ignorar este correo.
+`,
+			HTML: `<p>Hola, {{.Name}}:</p>
+<p>Alguien pidió restablecer la contraseña de tu cuenta.</p>
+<p><a href="{{.Link}}">Elegir una contraseña nueva</a></p>
+<p>El enlace caduca en {{.ExpiresIn}} minutos. Si no lo pediste, puedes ignorar este correo.</p>
+`,
+		},
+		"fr": {
+			Subject: `Réinitialisez votre mot de passe`,
+			Text: `Bonjour {{.Name}},
+
+Quelqu'un a demandé la réinitialisation du mot de passe de votre compte. Pour choisir un nouveau mot de passe, ouvrez le lien ci-dessous :
+
+{{.Link}}
+
+Le lien expire dans {{.ExpiresIn}} minutes. Si vous n'êtes pas à l'origine de cette demande, vous pouvez ignorer cet e-mail.
+`,
+			HTML: `<p>Bonjour {{.Name}},</p>
+<p>Quelqu'un a demandé la réinitialisation du mot de passe de votre compte.</p>
+<p><a href="{{.Link}}">Choisir un nouveau mot de passe</a></p>
+<p>Le lien expire dans {{.ExpiresIn}} minutes. Si vous n'êtes pas à l'origine de cette demande, vous pouvez ignorer cet e-mail.</p>
+`,
+		},
+	},
+}
+
+// emailTemplates is parsed once at startup; a broken template is a programming
+// error, so parsing panics.
+var emailTemplates = parseEmailTemplates(emailTemplateSources)
+
+func parseEmailTemplates(sources map[string]map[string]emailTemplateSource) map[string]map[string]emailTemplate {
+	parsed := make(map[string]map[string]emailTemplate, len(sources))
+	for name, languages := range sources {
+		if _, ok := languages[defaultEmailLanguage]; !ok {
+			panic(fmt.Sprintf("email template %q has no %q variant", name, defaultEmailLanguage))
+		}
+		parsed[name] = make(map[string]emailTemplate, len(languages))
+		for lang, src := range languages {
+			id := name + "." + lang
+			parsed[name][lang] = emailTemplate{
+				subject: texttemplate.Must(texttemplate.New(id + ".subject").Option("missingkey=error").Parse(src.Subject)),
+				text:    texttemplate.Must(texttemplate.New(id + ".text").Option("missingkey=error").Parse(src.Text)),
+				html:    htmltemplate.Must(htmltemplate.New(id + ".html").Option("missingkey=error").Parse(src.HTML)),
+			}
+		}
+	}
+	return parsed
+}
+
+// RenderEmail renders a template in the requested language. Regional variants
+// such as "es-MX" fall back to "es", and unknown languages to English.
+func RenderEmail(name, language string, data any) (subject, text, html string, err error) {
+	languages, ok := emailTemplates[name]
+	if !ok {
+		return "", "", "", fmt.Errorf("%w: %s", ErrUnknownEmailTemplate, name)
+	}
+	tmpl := languages[resolveEmailLanguage(languages, language)]
+
+	var b strings.Builder
+	if err := tmpl.subject.Execute(&b, data); err != nil {
+		return "", "", "", err
+	}
+	subject = strings.TrimSpace(b.String())
+
+	b.Reset()
+	if err := tmpl.text.Execute(&b, data); err != nil {
+		return "", "", "", err
+	}
+	text = b.String()
+
+	b.Reset()
+	if err := tmpl.html.Execute(&b, data); err != nil {
+		return "", "", "", err
+	}
+	html = b.String()
+
+	return subject, text, html, nil
+}
+
+func resolveEmailLanguage(languages map[string]emailTemplate, language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if _, ok := languages[language]; ok {
+		return language
+	}
+	if base, _, found := strings.Cut(strings.ReplaceAll(language, "_", "-"), "-"); found {
+		if _, ok := languages[base]; ok {
+			return base
+		}
+	}
+	return defaultEmailLanguage
+}
@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+// Types of the events pushed to group and user streams.
+const (
+	StreamEventActivity       = "activity"
+	StreamEventBalanceChanged = "balance_changed"
+	StreamEventComment        = "comment"
+	// StreamEventReset tells the client it missed more events than can be
+	// replayed and should reload instead.
+	StreamEventReset = "reset"
+)
+
+const (
+	// streamBufferSize live events can queue for a subscriber; one that falls
+	// further behind is disconnected and catches up by resuming.
+	streamBufferSize = 64
+	// streamReplayLimit caps how many missed activities a resume replays.
+	streamReplayLimit = 500
+
+	streamListenRetryBaseDelay = time.Second
+	streamListenRetryMaxDelay  = 30 * time.Second
+)
+
+// StreamEvent is one Server-Sent Event. ID is the activity behind it, which
+// clients send back as Last-Event-ID to resume.
+type StreamEvent struct {
+	ID   string
+	Type string
+	Data any
+}
+
+// StreamActivity is the data of an activity event.
+type StreamActivity struct {
+	ID         string          `json:"id"`
+	GroupID    string          `json:"group_id"`
+	UserID     string          `json:"user_id"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// StreamBalanceChange is the data of a balance_changed event; clients refetch
+// the group's balances.
+type StreamBalanceChange struct {
+	GroupID string `json:"group_id"`
+	Action  string `json:"action"`
+}
+
+// StreamComment is the data of a comment event.
+type StreamComment struct {
+	GroupID   string          `json:"group_id"`
+	ExpenseID string          `json:"expense_id"`
+	Action    string          `json:"action"`
+	Comment   json.RawMessage `json:"comment,omitempty"`
+}
+
+// StreamSubscription delivers events until Close is called. Events is closed
+// when the subscription ends, including when the server drops a subscriber
+// that can't keep up; clients then reconnect with Last-Event-ID.
+type StreamSubscription struct {
+	Events <-chan StreamEvent
+	Close  func()
+}
+
+// EventStreamService pushes group activity to connected clients as it is
+// logged. New activities are announced through Postgres NOTIFY, so every API
+// replica sees activities logged by any other replica or by the worker.
+type EventStreamService interface {
+	// SubscribeGroup streams one group's events to one of its active members.
+	// With lastEventID set, activities logged after it are replayed first.
+	SubscribeGroup(ctx context.Context, groupID, userID, lastEventID pgtype.UUID) (*StreamSubscription, error)
+	// SubscribeUser streams the events of every group the user is an active member of.
+	SubscribeUser(ctx context.Context, userID, lastEventID pgtype.UUID) (*StreamSubscription, error)
+	// Run listens for new activities until ctx ends, reconnecting after failures.
+	Run(ctx context.Context)
+}
+
+type streamSubscriber struct {
+	groupID pgtype.UUID // set for group streams
+	userID  pgtype.UUID
+	live    chan sqlc.GroupActivity
+}
+
+type eventStreamService struct {
+	repo      repository.ActivityStreamRepository
+	groupRepo repository.GroupRepository
+
+	mu          sync.Mutex
+	subscribers map[*streamSubscriber]struct{}
+}
+
+func NewEventStreamService(repo repository.ActivityStreamRepository, groupRepo repository.GroupRepository) EventStreamService {
+	return &eventStreamService{
+		repo:        repo,
+		groupRepo:   groupRepo,
+		subscribers: make(map[*streamSubscriber]struct{}),
+	}
+}
+
+func (s *eventStreamService) SubscribeGroup(ctx context.Context, groupID, userID, lastEventID pgtype.UUID) (*StreamSubscription, error) {
+	if _, err := s.groupRepo.GetGroupByID(ctx, groupID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrGroupNotFound
+		}
+		return nil, err
+	}
+
+	member, err := s.groupRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+		GroupID: groupID,
+		UserID:  userID,
+	})
+	if err != nil || member.Status != "active" {
+		return nil, ErrNotGroupMember
+	}
+
+	return s.subscribe(ctx, &streamSubscriber{groupID: groupID, userID: userID}, func() ([]sqlc.GroupActivity, error) {
+		return s.repo.ListGroupActivitiesAfter(ctx, sqlc.ListGroupActivitiesAfterParams{
+			GroupID: groupID,
+			AfterID: lastEventID,
+			Limit:   streamReplayLimit + 1,
+		})
+	}, lastEventID.Valid)
+}
+
+func (s *eventStreamService) SubscribeUser(ctx context.Context, userID, lastEventID pgtype.UUID) (*StreamSubscription, error) {
+	return s.subscribe(ctx, &streamSubscriber{userID: userID}, func() ([]sqlc.GroupActivity, error) {
+		return s.repo.ListUserActivitiesAfter(ctx, sqlc.ListUserActivitiesAfterParams{
+			UserID:  userID,
+			AfterID: lastEventID,
+			Limit:   streamReplayLimit + 1,
+		})
+	}, lastEventID.Valid)
+}
+
+// subscribe registers sub before loading the replay, so nothing logged in
+// between is lost; live activities that were also replayed are skipped.
+func (s *eventStreamService) subscribe(ctx context.Context, sub *streamSubscriber, loadReplay func() ([]sqlc.GroupActivity, error), resuming bool) (*StreamSubscription, error) {
+	sub.live = make(chan sqlc.GroupActivity, streamBufferSize)
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+
+	var replay []sqlc.GroupActivity
+	if resuming {
+		var err error
+		if replay, err = loadReplay(); err != nil {
+			s.remove(sub)
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	events := make(chan StreamEvent)
+
+	go func() {
+		defer close(events)
+		defer s.remove(sub)
+
+		send := func(event StreamEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if len(replay) > streamReplayLimit {
+			// Too much was missed; the client reloads and follows live events
+			replay = nil
+			if !send(StreamEvent{Type: StreamEventReset}) {
+				return
+			}
+		}
+
+		seen := make(map[[16]byte]struct{}, len(replay))
+		for _, activity := range replay {
+			seen[activity.ID.Bytes] = struct{}{}
+			for _, event := range streamEventsFor(activity) {
+				if !send(event) {
+					return
+				}
+			}
+		}
+
+		for {
+			select {
+			case activity, ok := <-sub.live:
+				if !ok {
+					return
+				}
+				if _, replayed := seen[activity.ID.Bytes]; replayed {
+					continue
+				}
+				for _, event := range streamEventsFor(activity) {
+					if !send(event) {
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return &StreamSubscription{Events: events, Close: cancel}, nil
+}
+
+func (s *eventStreamService) remove(sub *streamSubscriber) {
+	s.mu.Lock()
+	delete(s.subscribers, sub)
+	s.mu.Unlock()
+}
+
+func (s *eventStreamService) Run(ctx context.Context) {
+	failures := 0
+	for {
+		err := s.repo.ListenForActivities(ctx, func(notification repository.ActivityNotification) {
+			failures = 0
+			s.dispatch(ctx, notification)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Activities logged while reconnecting would never reach current
+		// subscribers, so disconnect them; they resume from their last event.
+		s.dropAll()
+
+		failures++
+		log.Printf("event stream listener stopped, retrying: %v", err)
+		select {
+		case <-time.After(backoffDelay(failures, streamListenRetryBaseDelay, streamListenRetryMaxDelay)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatch hands a new activity to every subscriber that should see it.
+// Membership is checked again for every activity, so a group stream ends once
+// its member is removed or leaves, or the group is deleted.
+func (s *eventStreamService) dispatch(ctx context.Context, notification repository.ActivityNotification) {
+	s.mu.Lock()
+	interested := false
+	for sub := range s.subscribers {
+		interested = interested || !sub.groupID.Valid || sub.groupID == notification.GroupID
+	}
+	s.mu.Unlock()
+	if !interested {
+		return
+	}
+
+	activity, err := s.repo.GetActivity(ctx, notification.ID)
+	if err != nil {
+		log.Printf("event stream: failed to load activity: %v", err)
+		return
+	}
+
+	// A deleted group streams to no one, as if it had no members left
+	var ids []pgtype.UUID
+	_, membersErr := s.groupRepo.GetGroupByID(ctx, activity.GroupID)
+	if errors.Is(membersErr, pgx.ErrNoRows) {
+		membersErr = nil
+	} else if membersErr == nil {
+		ids, membersErr = s.repo.ListActiveGroupMemberIDs(ctx, activity.GroupID)
+	}
+	if membersErr != nil {
+		log.Printf("event stream: failed to load group members: %v", membersErr)
+	}
+
+	members := map[[16]byte]struct{}{}
+	for _, id := range ids {
+		members[id.Bytes] = struct{}{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscribers {
+		if sub.groupID.Valid && sub.groupID != activity.GroupID {
+			continue
+		}
+		if _, ok := members[sub.userID.Bytes]; !ok {
+			// Group streams of former members end. Without the members nobody
+			// can be checked, so every candidate is disconnected and resumes.
+			if sub.groupID.Valid || membersErr != nil {
+				close(sub.live)
+				delete(s.subscribers, sub)
+			}
+			continue
+		}
+
+		select {
+		case sub.live <- activity:
+		default:
+			// Too far behind; the client resumes from its last event
+			close(sub.live)
+			delete(s.subscribers, sub)
+		}
+	}
+}
+
+func (s *eventStreamService) dropAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscribers {
+		close(sub.live)
+		delete(s.subscribers, sub)
+	}
+}
+
+// streamEventsFor turns an activity into the events clients receive: the
+// activity itself, plus a balance or comment event when it affects those.
+func streamEventsFor(activity sqlc.GroupActivity) []StreamEvent {
+	id := uuidToString(activity.ID)
+	groupID := uuidToString(activity.GroupID)
+
+	var metadata json.RawMessage
+	if len(activity.Metadata) > 0 && string(activity.Metadata) != "null" {
+		metadata = activity.Metadata
+	}
+
+	events := []StreamEvent{{
+		ID:   id,
+		Type: StreamEventActivity,
+		Data: StreamActivity{
+			ID:         id,
+			GroupID:    groupID,
+			UserID:     uuidToString(activity.UserID),
+			Action:     activity.Action,
+			EntityType: activity.EntityType,
+			EntityID:   uuidToString(activity.EntityID),
+			Metadata:   metadata,
+			CreatedAt:  activity.CreatedAt.Time.UTC(),
+		},
+	}}
+
+	switch {
+	case strings.HasPrefix(activity.Action, "expense_"), strings.HasPrefix(activity.Action, "settlement_"):
+		events = append(events, StreamEvent{
+			ID:   id,
+			Type: StreamEventBalanceChanged,
+			Data: StreamBalanceChange{GroupID: groupID, Action: activity.Action},
+		})
+	case strings.HasPrefix(activity.Action, "comment_"):
+		var comment struct {
+			Comment json.RawMessage `json:"comment"`
+		}
+		_ = json.Unmarshal(activity.Metadata, &comment)
+		events = append(events, StreamEvent{
+			ID:   id,
+			Type: StreamEventComment,
+			Data: StreamComment{
+				GroupID:   groupID,
+				ExpenseID: uuidToString(activity.EntityID),
+				Action:    activity.Action,
+				Comment:   comment.Comment,
+			},
+		})
+	}
+
+	return events
+}
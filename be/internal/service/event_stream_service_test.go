@@ -0,0 +1,449 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockActivityStreamRepository for testing
+type MockActivityStreamRepository struct {
+	GetActivityFunc              func(ctx context.Context, id pgtype.UUID) (sqlc.GroupActivity, error)
+	ListGroupActivitiesAfterFunc func(ctx context.Context, params sqlc.ListGroupActivitiesAfterParams) ([]sqlc.GroupActivity, error)
+	ListUserActivitiesAfterFunc  func(ctx context.Context, params sqlc.ListUserActivitiesAfterParams) ([]sqlc.GroupActivity, error)
+	ListActiveGroupMemberIDsFunc func(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error)
+	ListenForActivitiesFunc      func(ctx context.Context, handle func(repository.ActivityNotification)) error
+}
+
+func (m *MockActivityStreamRepository) GetActivity(ctx context.Context, id pgtype.UUID) (sqlc.GroupActivity, error) {
+	if m.GetActivityFunc != nil {
+		return m.GetActivityFunc(ctx, id)
+	}
+	return sqlc.GroupActivity{}, nil
+}
+
+func (m *MockActivityStreamRepository) ListGroupActivitiesAfter(ctx context.Context, params sqlc.ListGroupActivitiesAfterParams) ([]sqlc.GroupActivity, error) {
+	if m.ListGroupActivitiesAfterFunc != nil {
+		return m.ListGroupActivitiesAfterFunc(ctx, params)
+	}
+	return []sqlc.GroupActivity{}, nil
+}
+
+func (m *MockActivityStreamRepository) ListUserActivitiesAfter(ctx context.Context, params sqlc.ListUserActivitiesAfterParams) ([]sqlc.GroupActivity, error) {
+	if m.ListUserActivitiesAfterFunc != nil {
+		return m.ListUserActivitiesAfterFunc(ctx, params)
+	}
+	return []sqlc.GroupActivity{}, nil
+}
+
+func (m *MockActivityStreamRepository) ListActiveGroupMemberIDs(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error) {
+	if m.ListActiveGroupMemberIDsFunc != nil {
+		return m.ListActiveGroupMemberIDsFunc(ctx, groupID)
+	}
+	return []pgtype.UUID{}, nil
+}
+
+func (m *MockActivityStreamRepository) ListenForActivities(ctx context.Context, handle func(repository.ActivityNotification)) error {
+	if m.ListenForActivitiesFunc != nil {
+		return m.ListenForActivitiesFunc(ctx, handle)
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// activityStore serves activities by ID to the event stream service.
+type activityStore map[[16]byte]sqlc.GroupActivity
+
+func (s activityStore) add(n int, groupID pgtype.UUID, action string) sqlc.GroupActivity {
+	activity := sqlc.GroupActivity{
+		ID:       testutil.CreateTestUUID(n),
+		GroupID:  groupID,
+		UserID:   testutil.CreateTestUUID(900),
+		Action:   action,
+		EntityID: testutil.CreateTestUUID(800),
+		Metadata: []byte(`{}`),
+	}
+	s[activity.ID.Bytes] = activity
+	return activity
+}
+
+// repo serves the stored activities; members are the active members of every group.
+func (s activityStore) repo(members ...pgtype.UUID) *MockActivityStreamRepository {
+	return &MockActivityStreamRepository{
+		GetActivityFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.GroupActivity, error) {
+			activity, ok := s[id.Bytes]
+			if !ok {
+				return sqlc.GroupActivity{}, errors.New("not found")
+			}
+			return activity, nil
+		},
+		ListActiveGroupMemberIDsFunc: func(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error) {
+			return members, nil
+		},
+	}
+}
+
+func notify(svc EventStreamService, activity sqlc.GroupActivity) {
+	svc.(*eventStreamService).dispatch(context.Background(), repository.ActivityNotification{ID: activity.ID, GroupID: activity.GroupID})
+}
+
+// nextEvent waits for the next event, failing the test if none arrives.
+func nextEvent(t *testing.T, sub *StreamSubscription) StreamEvent {
+	t.Helper()
+	select {
+	case event, ok := <-sub.Events:
+		if !ok {
+			t.Fatal("expected an event, stream ended")
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return StreamEvent{}
+}
+
+func expectNoEvent(t *testing.T, sub *StreamSubscription) {
+	t.Helper()
+	select {
+	case event, ok := <-sub.Events:
+		if ok {
+			t.Fatalf("expected no event, got %+v", event)
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestStreamEventsFor(t *testing.T) {
+	activity := sqlc.GroupActivity{
+		ID:       testutil.CreateTestUUID(1),
+		GroupID:  testutil.CreateTestUUID(2),
+		EntityID: testutil.CreateTestUUID(3),
+		Metadata: []byte(`{"comment":{"id":"c1","snippet":"hi"}}`),
+	}
+
+	tests := []struct {
+		action    string
+		wantTypes []string
+	}{
+		{action: "expense_created", wantTypes: []string{StreamEventActivity, StreamEventBalanceChanged}},
+		{action: "settlement_completed", wantTypes: []string{StreamEventActivity, StreamEventBalanceChanged}},
+		{action: "comment_added", wantTypes: []string{StreamEventActivity, StreamEventComment}},
+		{action: "group_updated", wantTypes: []string{StreamEventActivity}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			a := activity
+			a.Action = tt.action
+			events := streamEventsFor(a)
+
+			if len(events) != len(tt.wantTypes) {
+				t.Fatalf("expected %d events, got %+v", len(tt.wantTypes), events)
+			}
+			for i, event := range events {
+				if event.Type != tt.wantTypes[i] {
+					t.Errorf("event %d: expected %s, got %s", i, tt.wantTypes[i], event.Type)
+				}
+				if event.ID != uuidToString(activity.ID) {
+					t.Errorf("expected every event to carry the activity id, got %q", event.ID)
+				}
+			}
+			if tt.action == "comment_added" {
+				comment := events[1].Data.(StreamComment)
+				if string(comment.Comment) != `{"id":"c1","snippet":"hi"}` || comment.ExpenseID != uuidToString(activity.EntityID) {
+					t.Errorf("unexpected comment event %+v", comment)
+				}
+			}
+		})
+	}
+}
+
+func TestEventStreamService_SubscribeGroup(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	otherGroupID := testutil.CreateTestUUID(2)
+	userID := testutil.CreateTestUUID(3)
+
+	store := activityStore{}
+	svc := NewEventStreamService(store.repo(userID), groupWithRole("member"))
+
+	sub, err := svc.SubscribeGroup(context.Background(), groupID, userID, pgtype.UUID{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sub.Close()
+
+	notify(svc, store.add(10, otherGroupID, "group_updated"))
+	notify(svc, store.add(11, groupID, "group_updated"))
+
+	event := nextEvent(t, sub)
+	if event.Type != StreamEventActivity || event.ID != uuidToString(testutil.CreateTestUUID(11)) {
+		t.Errorf("expected only the group's activity, got %+v", event)
+	}
+	expectNoEvent(t, sub)
+}
+
+func TestEventStreamService_SubscribeGroup_RequiresMembership(t *testing.T) {
+	groupRepo := &testutil.MockGroupRepository{
+		GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+			return sqlc.GroupMember{Status: "inactive"}, nil
+		},
+	}
+	svc := NewEventStreamService(&MockActivityStreamRepository{}, groupRepo)
+
+	_, err := svc.SubscribeGroup(context.Background(), testutil.CreateTestUUID(1), testutil.CreateTestUUID(2), pgtype.UUID{})
+	if !errors.Is(err, ErrNotGroupMember) {
+		t.Errorf("expected ErrNotGroupMember, got %v", err)
+	}
+}
+
+func TestEventStreamService_SubscribeGroup_DeletedGroup(t *testing.T) {
+	groupRepo := groupWithRole("member")
+	groupRepo.GetGroupByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+		return sqlc.Group{}, pgx.ErrNoRows
+	}
+	svc := NewEventStreamService(&MockActivityStreamRepository{}, groupRepo)
+
+	_, err := svc.SubscribeGroup(context.Background(), testutil.CreateTestUUID(1), testutil.CreateTestUUID(2), pgtype.UUID{})
+	if !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+}
+
+func TestEventStreamService_DeletedGroupStopsStreaming(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	userID := testutil.CreateTestUUID(3)
+
+	store := activityStore{}
+	groupRepo := groupWithRole("member")
+	svc := NewEventStreamService(store.repo(userID), groupRepo)
+
+	groupSub, err := svc.SubscribeGroup(context.Background(), groupID, userID, pgtype.UUID{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer groupSub.Close()
+	userSub, err := svc.SubscribeUser(context.Background(), userID, pgtype.UUID{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer userSub.Close()
+
+	groupRepo.GetGroupByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+		return sqlc.Group{}, pgx.ErrNoRows
+	}
+	notify(svc, store.add(10, groupID, "expense_created"))
+
+	select {
+	case event, ok := <-groupSub.Events:
+		if ok {
+			t.Errorf("expected the group stream to end, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the group stream to end")
+	}
+	expectNoEvent(t, userSub)
+}
+
+func TestEventStreamService_SubscribeGroup_EndsWhenNoLongerMember(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	userID := testutil.CreateTestUUID(3)
+
+	tests := []struct {
+		name    string
+		members []pgtype.UUID
+		err     error
+	}{
+		{name: "removed or left", members: []pgtype.UUID{testutil.CreateTestUUID(4)}},
+		{name: "members unavailable", err: errors.New("connection lost")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := activityStore{}
+			repo := store.repo()
+			repo.ListActiveGroupMemberIDsFunc = func(ctx context.Context, id pgtype.UUID) ([]pgtype.UUID, error) {
+				return tt.members, tt.err
+			}
+			svc := NewEventStreamService(repo, groupWithRole("member"))
+
+			sub, err := svc.SubscribeGroup(context.Background(), groupID, userID, pgtype.UUID{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer sub.Close()
+
+			notify(svc, store.add(10, groupID, "member_removed"))
+
+			select {
+			case event, ok := <-sub.Events:
+				if ok {
+					t.Errorf("expected the stream to end, got %+v", event)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for the stream to end")
+			}
+		})
+	}
+}
+
+func TestEventStreamService_SubscribeGroup_Resume(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	lastEventID := testutil.CreateTestUUID(10)
+
+	store := activityStore{}
+	missed := store.add(11, groupID, "group_updated")
+	repo := store.repo(testutil.CreateTestUUID(3))
+
+	var svc EventStreamService
+	repo.ListGroupActivitiesAfterFunc = func(ctx context.Context, params sqlc.ListGroupActivitiesAfterParams) ([]sqlc.GroupActivity, error) {
+		if params.AfterID != lastEventID || params.GroupID != groupID {
+			t.Errorf("unexpected replay params %+v", params)
+		}
+		// Logged while the replay loads, so it is both replayed and live
+		notify(svc, missed)
+		return []sqlc.GroupActivity{missed}, nil
+	}
+	svc = NewEventStreamService(repo, groupWithRole("member"))
+
+	sub, err := svc.SubscribeGroup(context.Background(), groupID, testutil.CreateTestUUID(3), lastEventID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sub.Close()
+
+	if event := nextEvent(t, sub); event.ID != uuidToString(missed.ID) {
+		t.Errorf("expected the missed activity to be replayed, got %+v", event)
+	}
+	expectNoEvent(t, sub)
+
+	notify(svc, store.add(12, groupID, "group_updated"))
+	if event := nextEvent(t, sub); event.ID != uuidToString(testutil.CreateTestUUID(12)) {
+		t.Errorf("expected live events after the replay, got %+v", event)
+	}
+}
+
+func TestEventStreamService_SubscribeGroup_ResumeTooFarBehind(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	repo := &MockActivityStreamRepository{
+		ListGroupActivitiesAfterFunc: func(ctx context.Context, params sqlc.ListGroupActivitiesAfterParams) ([]sqlc.GroupActivity, error) {
+			return make([]sqlc.GroupActivity, params.Limit), nil
+		},
+	}
+	svc := NewEventStreamService(repo, groupWithRole("member"))
+
+	sub, err := svc.SubscribeGroup(context.Background(), groupID, testutil.CreateTestUUID(3), testutil.CreateTestUUID(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sub.Close()
+
+	if event := nextEvent(t, sub); event.Type != StreamEventReset {
+		t.Errorf("expected a reset event, got %+v", event)
+	}
+	expectNoEvent(t, sub)
+}
+
+func TestEventStreamService_SubscribeUser(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	otherGroupID := testutil.CreateTestUUID(2)
+	userID := testutil.CreateTestUUID(3)
+
+	store := activityStore{}
+	repo := store.repo()
+	repo.ListActiveGroupMemberIDsFunc = func(ctx context.Context, id pgtype.UUID) ([]pgtype.UUID, error) {
+		if id == groupID {
+			return []pgtype.UUID{userID}, nil
+		}
+		return []pgtype.UUID{testutil.CreateTestUUID(4)}, nil
+	}
+	svc := NewEventStreamService(repo, &testutil.MockGroupRepository{})
+
+	sub, err := svc.SubscribeUser(context.Background(), userID, pgtype.UUID{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sub.Close()
+
+	notify(svc, store.add(10, otherGroupID, "expense_created"))
+	notify(svc, store.add(11, groupID, "expense_created"))
+
+	for _, want := range []string{StreamEventActivity, StreamEventBalanceChanged} {
+		if event := nextEvent(t, sub); event.Type != want || event.ID != uuidToString(testutil.CreateTestUUID(11)) {
+			t.Errorf("expected %s of the user's group, got %+v", want, event)
+		}
+	}
+	expectNoEvent(t, sub)
+}
+
+func TestEventStreamService_DropsSlowSubscribers(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	store := activityStore{}
+	svc := NewEventStreamService(store.repo(testutil.CreateTestUUID(3)), groupWithRole("member"))
+
+	sub, err := svc.SubscribeGroup(context.Background(), groupID, testutil.CreateTestUUID(3), pgtype.UUID{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sub.Close()
+
+	// Nobody reads the stream, so the buffer fills up
+	for i := 0; i < streamBufferSize+2; i++ {
+		notify(svc, store.add(100+i, groupID, "group_updated"))
+	}
+
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	if received == 0 || received > streamBufferSize+1 {
+		t.Errorf("expected the buffered events and then the end of the stream, got %d events", received)
+	}
+}
+
+func TestEventStreamService_Run_DisconnectsSubscribersWhenListenerFails(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listening := make(chan struct{})
+	fail := make(chan struct{})
+	calls := 0
+	repo := &MockActivityStreamRepository{
+		ListenForActivitiesFunc: func(ctx context.Context, handle func(repository.ActivityNotification)) error {
+			calls++
+			if calls == 1 {
+				close(listening)
+				<-fail
+				return errors.New("connection lost")
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+	svc := NewEventStreamService(repo, groupWithRole("member"))
+	go svc.Run(ctx)
+	<-listening
+
+	sub, err := svc.SubscribeGroup(ctx, testutil.CreateTestUUID(1), testutil.CreateTestUUID(2), pgtype.UUID{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sub.Close()
+
+	close(fail)
+	select {
+	case _, ok := <-sub.Events:
+		if ok {
+			t.Error("expected the stream to end")
+		}
+	case <-time.After(time.Second):
+		t.Error("expected subscribers to be disconnected")
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidCurrencyCode     = errors.New("currency code must be a 3-letter ISO 4217 code")
+	ErrInvalidExchangeRate     = errors.New("exchange rate must be greater than zero")
+	ErrExchangeRateUnavailable = errors.New("no exchange rate available for currency pair")
+)
+
+// exchangeRatePrecision matches the scale of expenses.exchange_rate.
+const exchangeRatePrecision int32 = 8
+
+// ExchangeRateProvider looks up the rate that converts one unit of `from` into `to`
+// on the given date. Implementations may call out to a remote API; the expense
+// service snapshots whatever rate is returned so historical balances never move.
+type ExchangeRateProvider interface {
+	GetRate(ctx context.Context, from, to string, date time.Time) (decimal.Decimal, error)
+}
+
+// StaticRateProvider serves rates from a fixed table. It is used when no remote
+// rate source is configured (offline deployments) and in tests.
+type StaticRateProvider struct {
+	rates map[string]decimal.Decimal
+}
+
+// NewStaticRateProvider builds a provider from a "FROM/TO" -> rate table.
+// The inverse direction is derived automatically when only one side is given.
+func NewStaticRateProvider(rates map[string]string) (*StaticRateProvider, error) {
+	p := &StaticRateProvider{rates: make(map[string]decimal.Decimal, len(rates))}
+	for pair, value := range rates {
+		from, to, ok := strings.Cut(pair, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid currency pair %q", pair)
+		}
+		from, err := normalizeCurrencyCode(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid currency pair %q: %w", pair, err)
+		}
+		to, err = normalizeCurrencyCode(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid currency pair %q: %w", pair, err)
+		}
+		rate, err := decimal.NewFromString(strings.TrimSpace(value))
+		if err != nil || rate.LessThanOrEqual(decimal.Zero) {
+			return nil, fmt.Errorf("invalid rate for %q: %w", pair, ErrInvalidExchangeRate)
+		}
+		p.rates[from+"/"+to] = rate
+	}
+	return p, nil
+}
+
+// ParseStaticRates parses a comma-separated list such as "EUR/USD=1.08,GBP/USD=1.27",
+// the format used by the EXCHANGE_RATES environment variable.
+func ParseStaticRates(spec string) (*StaticRateProvider, error) {
+	rates := map[string]string{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pair, rate, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid exchange rate entry %q", entry)
+		}
+		rates[strings.TrimSpace(pair)] = rate
+	}
+	return NewStaticRateProvider(rates)
+}
+
+func (p *StaticRateProvider) GetRate(ctx context.Context, from, to string, date time.Time) (decimal.Decimal, error) {
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+	if rate, ok := p.rates[from+"/"+to]; ok {
+		return rate, nil
+	}
+	if inverse, ok := p.rates[to+"/"+from]; ok {
+		return decimal.NewFromInt(1).DivRound(inverse, exchangeRatePrecision), nil
+	}
+	return decimal.Zero, ErrExchangeRateUnavailable
+}
+
+var _ ExchangeRateProvider = (*StaticRateProvider)(nil)
+
+// unitExchangeRate is the rate stored for expenses already in the target currency.
+func unitExchangeRate() pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(1), Exp: 0, Valid: true}
+}
+
+func normalizeCurrencyCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", ErrInvalidCurrencyCode
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", ErrInvalidCurrencyCode
+		}
+	}
+	return code, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestStaticRateProvider_GetRate(t *testing.T) {
+	provider, err := ParseStaticRates("EUR/USD=1.25, GBP/USD=1.27")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		from          string
+		to            string
+		expectedRate  string
+		expectedError error
+	}{
+		{name: "same currency", from: "USD", to: "USD", expectedRate: "1"},
+		{name: "direct pair", from: "EUR", to: "USD", expectedRate: "1.25"},
+		{name: "inverse pair", from: "USD", to: "EUR", expectedRate: "0.8"},
+		{name: "unknown pair", from: "JPY", to: "USD", expectedError: ErrExchangeRateUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := provider.GetRate(context.Background(), tt.from, tt.to, time.Now())
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !rate.Equal(decimal.RequireFromString(tt.expectedRate)) {
+				t.Errorf("expected rate %s, got %s", tt.expectedRate, rate)
+			}
+		})
+	}
+}
+
+func TestParseStaticRates(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{name: "empty spec", spec: ""},
+		{name: "valid spec", spec: "EUR/USD=1.08,GBP/USD=1.27"},
+		{name: "missing rate", spec: "EUR/USD", wantErr: true},
+		{name: "missing pair separator", spec: "EURUSD=1.08", wantErr: true},
+		{name: "invalid currency", spec: "EURO/USD=1.08", wantErr: true},
+		{name: "non-positive rate", spec: "EUR/USD=0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStaticRates(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
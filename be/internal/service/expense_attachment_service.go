@@ -0,0 +1,287 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+// DefaultMaxAttachmentSize is used when no limit is configured.
+const DefaultMaxAttachmentSize int64 = 10 << 20
+
+var (
+	ErrAttachmentNotFound         = errors.New("attachment not found")
+	ErrAttachmentTooLarge         = errors.New("attachment exceeds the maximum size")
+	ErrAttachmentEmpty            = errors.New("attachment is empty")
+	ErrAttachmentTypeNotAllowed   = errors.New("attachment type not allowed; upload an image or a PDF")
+	ErrAttachmentPermissionDenied = errors.New("only the uploader or the expense creator can delete an attachment")
+)
+
+// allowedAttachmentTypes are the sniffed content types we accept: receipt photos
+// and PDFs. Anything else (including HTML and SVG) is rejected so downloads
+// can never be rendered as active content.
+var allowedAttachmentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+type UploadAttachmentInput struct {
+	GroupID    pgtype.UUID
+	ExpenseID  pgtype.UUID
+	UploadedBy pgtype.UUID
+	FileName   string
+	Content    io.Reader
+}
+
+type ExpenseAttachmentService interface {
+	UploadAttachment(ctx context.Context, input UploadAttachmentInput) (sqlc.ExpenseAttachment, error)
+	ListAttachments(ctx context.Context, groupID, expenseID, requesterID pgtype.UUID) ([]sqlc.ExpenseAttachment, error)
+	// OpenAttachment returns the attachment metadata and its content; the caller must close the reader.
+	OpenAttachment(ctx context.Context, groupID, expenseID, attachmentID, requesterID pgtype.UUID) (sqlc.ExpenseAttachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, groupID, expenseID, attachmentID, requesterID pgtype.UUID) error
+}
+
+type expenseAttachmentService struct {
+	repo            repository.ExpenseAttachmentRepository
+	expenseService  ExpenseService
+	activityService GroupActivityService
+	storage         AttachmentStorage
+	maxSize         int64
+}
+
+func NewExpenseAttachmentService(
+	repo repository.ExpenseAttachmentRepository,
+	expenseService ExpenseService,
+	activityService GroupActivityService,
+	storage AttachmentStorage,
+	maxSize int64,
+) ExpenseAttachmentService {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxAttachmentSize
+	}
+	return &expenseAttachmentService{
+		repo:            repo,
+		expenseService:  expenseService,
+		activityService: activityService,
+		storage:         storage,
+		maxSize:         maxSize,
+	}
+}
+
+// getExpense loads an expense for a group member. GetExpenseByID already runs
+// validateGroupMembership; the group check stops IDs being mixed across groups.
+func (s *expenseAttachmentService) getExpense(ctx context.Context, groupID, expenseID, requesterID pgtype.UUID) (sqlc.Expense, error) {
+	expense, err := s.expenseService.GetExpenseByID(ctx, expenseID, requesterID)
+	if err != nil {
+		return sqlc.Expense{}, err
+	}
+	if expense.GroupID != groupID {
+		return sqlc.Expense{}, ErrExpenseNotFound
+	}
+	return expense, nil
+}
+
+func (s *expenseAttachmentService) getAttachment(ctx context.Context, expenseID, attachmentID pgtype.UUID) (sqlc.ExpenseAttachment, error) {
+	attachment, err := s.repo.GetAttachmentByID(ctx, attachmentID)
+	if err != nil || attachment.ExpenseID != expenseID {
+		return sqlc.ExpenseAttachment{}, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+func (s *expenseAttachmentService) UploadAttachment(ctx context.Context, input UploadAttachmentInput) (sqlc.ExpenseAttachment, error) {
+	expense, err := s.getExpense(ctx, input.GroupID, input.ExpenseID, input.UploadedBy)
+	if err != nil {
+		return sqlc.ExpenseAttachment{}, err
+	}
+
+	// Sniff the type from the first bytes; the client's Content-Type is ignored
+	content := bufio.NewReaderSize(input.Content, 512)
+	head, err := content.Peek(512)
+	if err != nil && err != io.EOF && !errors.Is(err, bufio.ErrBufferFull) {
+		return sqlc.ExpenseAttachment{}, err
+	}
+	if len(head) == 0 {
+		return sqlc.ExpenseAttachment{}, ErrAttachmentEmpty
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !allowedAttachmentTypes[contentType] {
+		return sqlc.ExpenseAttachment{}, ErrAttachmentTypeNotAllowed
+	}
+
+	key := fmt.Sprintf("expenses/%s/%s", uuidToString(expense.ID), uuid.NewString())
+	hash := sha256.New()
+	limited := &maxSizeReader{r: io.TeeReader(content, hash), remaining: s.maxSize}
+
+	size, err := s.storage.Put(ctx, key, limited)
+	if err != nil {
+		_ = s.storage.Delete(ctx, key)
+		if limited.exceeded {
+			return sqlc.ExpenseAttachment{}, ErrAttachmentTooLarge
+		}
+		return sqlc.ExpenseAttachment{}, err
+	}
+
+	attachment, err := s.repo.CreateAttachment(ctx, sqlc.CreateExpenseAttachmentParams{
+		ExpenseID:      expense.ID,
+		UploadedBy:     input.UploadedBy,
+		FileName:       sanitizeFileName(input.FileName),
+		ContentType:    contentType,
+		SizeBytes:      size,
+		ChecksumSha256: hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:     key,
+	})
+	if err != nil {
+		_ = s.storage.Delete(ctx, key)
+		return sqlc.ExpenseAttachment{}, err
+	}
+
+	// Log activity
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    expense.GroupID,
+		UserID:     input.UploadedBy,
+		Action:     "attachment_added",
+		EntityType: "expense",
+		EntityID:   expense.ID,
+		Metadata: map[string]interface{}{
+			"version": 1,
+			"attachment": map[string]interface{}{
+				"id":           attachment.ID,
+				"file_name":    attachment.FileName,
+				"content_type": attachment.ContentType,
+				"size_bytes":   attachment.SizeBytes,
+			},
+		},
+	})
+
+	return attachment, nil
+}
+
+func (s *expenseAttachmentService) ListAttachments(ctx context.Context, groupID, expenseID, requesterID pgtype.UUID) ([]sqlc.ExpenseAttachment, error) {
+	if _, err := s.getExpense(ctx, groupID, expenseID, requesterID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAttachments(ctx, expenseID)
+}
+
+func (s *expenseAttachmentService) OpenAttachment(ctx context.Context, groupID, expenseID, attachmentID, requesterID pgtype.UUID) (sqlc.ExpenseAttachment, io.ReadCloser, error) {
+	if _, err := s.getExpense(ctx, groupID, expenseID, requesterID); err != nil {
+		return sqlc.ExpenseAttachment{}, nil, err
+	}
+
+	attachment, err := s.getAttachment(ctx, expenseID, attachmentID)
+	if err != nil {
+		return sqlc.ExpenseAttachment{}, nil, err
+	}
+
+	content, err := s.storage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, ErrAttachmentObjectNotFound) {
+			return sqlc.ExpenseAttachment{}, nil, ErrAttachmentNotFound
+		}
+		return sqlc.ExpenseAttachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (s *expenseAttachmentService) DeleteAttachment(ctx context.Context, groupID, expenseID, attachmentID, requesterID pgtype.UUID) error {
+	expense, err := s.getExpense(ctx, groupID, expenseID, requesterID)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := s.getAttachment(ctx, expenseID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if attachment.UploadedBy != requesterID && expense.CreatedBy != requesterID {
+		return ErrAttachmentPermissionDenied
+	}
+
+	if err := s.repo.DeleteAttachment(ctx, attachment.ID); err != nil {
+		return err
+	}
+	// The row is gone, so a leftover file is only wasted space; don't fail the request over it
+	_ = s.storage.Delete(ctx, attachment.StorageKey)
+
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    expense.GroupID,
+		UserID:     requesterID,
+		Action:     "attachment_deleted",
+		EntityType: "expense",
+		EntityID:   expense.ID,
+		Metadata: map[string]interface{}{
+			"version": 1,
+			"attachment": map[string]interface{}{
+				"id":        attachment.ID,
+				"file_name": attachment.FileName,
+			},
+		},
+	})
+
+	return nil
+}
+
+// maxSizeReader fails once more than `remaining` bytes have been read.
+type maxSizeReader struct {
+	r         io.Reader
+	remaining int64
+	exceeded  bool
+}
+
+func (m *maxSizeReader) Read(p []byte) (int, error) {
+	if m.remaining < 0 {
+		m.exceeded = true
+		return 0, ErrAttachmentTooLarge
+	}
+	// Read one byte past the limit so an exactly-full file isn't rejected
+	if int64(len(p)) > m.remaining+1 {
+		p = p[:m.remaining+1]
+	}
+	n, err := m.r.Read(p)
+	m.remaining -= int64(n)
+	if m.remaining < 0 {
+		m.exceeded = true
+		return n, ErrAttachmentTooLarge
+	}
+	return n, err
+}
+
+// sanitizeFileName keeps only the base name and drops control characters, so
+// the stored name is safe to echo back in a Content-Disposition header.
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[:255])
+	}
+	return name
+}
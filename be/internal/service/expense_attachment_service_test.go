@@ -0,0 +1,302 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockExpenseAttachmentRepository for testing
+type MockExpenseAttachmentRepository struct {
+	CreateAttachmentFunc  func(ctx context.Context, params sqlc.CreateExpenseAttachmentParams) (sqlc.ExpenseAttachment, error)
+	GetAttachmentByIDFunc func(ctx context.Context, id pgtype.UUID) (sqlc.ExpenseAttachment, error)
+	ListAttachmentsFunc   func(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseAttachment, error)
+	DeleteAttachmentFunc  func(ctx context.Context, id pgtype.UUID) error
+}
+
+func (m *MockExpenseAttachmentRepository) CreateAttachment(ctx context.Context, params sqlc.CreateExpenseAttachmentParams) (sqlc.ExpenseAttachment, error) {
+	if m.CreateAttachmentFunc != nil {
+		return m.CreateAttachmentFunc(ctx, params)
+	}
+	return sqlc.ExpenseAttachment{}, nil
+}
+
+func (m *MockExpenseAttachmentRepository) GetAttachmentByID(ctx context.Context, id pgtype.UUID) (sqlc.ExpenseAttachment, error) {
+	if m.GetAttachmentByIDFunc != nil {
+		return m.GetAttachmentByIDFunc(ctx, id)
+	}
+	return sqlc.ExpenseAttachment{}, pgx.ErrNoRows
+}
+
+func (m *MockExpenseAttachmentRepository) ListAttachments(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseAttachment, error) {
+	if m.ListAttachmentsFunc != nil {
+		return m.ListAttachmentsFunc(ctx, expenseID)
+	}
+	return []sqlc.ExpenseAttachment{}, nil
+}
+
+func (m *MockExpenseAttachmentRepository) DeleteAttachment(ctx context.Context, id pgtype.UUID) error {
+	if m.DeleteAttachmentFunc != nil {
+		return m.DeleteAttachmentFunc(ctx, id)
+	}
+	return nil
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestExpenseAttachmentService_UploadAttachment(t *testing.T) {
+	uploader := testutil.CreateTestUUID(1)
+	groupID := testutil.CreateTestUUID(20)
+	otherGroupID := testutil.CreateTestUUID(21)
+	expenseID := testutil.CreateTestUUID(30)
+
+	tests := []struct {
+		name            string
+		groupID         pgtype.UUID
+		content         []byte
+		expenseErr      error
+		expectedError   error
+		expectedType    string
+		expectActivity  bool
+		expectFileCount int
+	}{
+		{
+			name:            "png receipt",
+			groupID:         groupID,
+			content:         append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, 100)...),
+			expectedType:    "image/png",
+			expectActivity:  true,
+			expectFileCount: 1,
+		},
+		{
+			name:            "pdf receipt",
+			groupID:         groupID,
+			content:         []byte("%PDF-1.7\n%receipt"),
+			expectedType:    "application/pdf",
+			expectActivity:  true,
+			expectFileCount: 1,
+		},
+		{
+			name:          "html is rejected whatever the client claims",
+			groupID:       groupID,
+			content:       []byte("<html><script>alert(1)</script></html>"),
+			expectedError: ErrAttachmentTypeNotAllowed,
+		},
+		{
+			name:          "too large",
+			groupID:       groupID,
+			content:       append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, 2048)...),
+			expectedError: ErrAttachmentTooLarge,
+		},
+		{
+			name:          "empty file",
+			groupID:       groupID,
+			content:       []byte{},
+			expectedError: ErrAttachmentEmpty,
+		},
+		{
+			name:          "not a group member",
+			groupID:       groupID,
+			content:       pngHeader,
+			expenseErr:    ErrNotGroupMember,
+			expectedError: ErrNotGroupMember,
+		},
+		{
+			name:          "expense belongs to another group",
+			groupID:       otherGroupID,
+			content:       pngHeader,
+			expectedError: ErrExpenseNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			storage, err := NewLocalAttachmentStorage(dir)
+			if err != nil {
+				t.Fatalf("failed to create storage: %v", err)
+			}
+
+			expSvc := &MockExpenseService{
+				GetExpenseByIDFunc: func(ctx context.Context, id, userID pgtype.UUID) (sqlc.Expense, error) {
+					if tt.expenseErr != nil {
+						return sqlc.Expense{}, tt.expenseErr
+					}
+					return sqlc.Expense{ID: id, GroupID: groupID, CreatedBy: uploader}, nil
+				},
+			}
+
+			var created sqlc.CreateExpenseAttachmentParams
+			repo := &MockExpenseAttachmentRepository{
+				CreateAttachmentFunc: func(ctx context.Context, params sqlc.CreateExpenseAttachmentParams) (sqlc.ExpenseAttachment, error) {
+					created = params
+					return sqlc.ExpenseAttachment{
+						ID:          testutil.CreateTestUUID(40),
+						ExpenseID:   params.ExpenseID,
+						FileName:    params.FileName,
+						ContentType: params.ContentType,
+						SizeBytes:   params.SizeBytes,
+						StorageKey:  params.StorageKey,
+					}, nil
+				},
+			}
+
+			activityLogged := false
+			actSvc := &MockGroupActivityService{
+				LogActivityFunc: func(ctx context.Context, input LogActivityInput) error {
+					activityLogged = input.Action == "attachment_added"
+					return nil
+				},
+			}
+
+			svc := NewExpenseAttachmentService(repo, expSvc, actSvc, storage, 1024)
+			attachment, err := svc.UploadAttachment(context.Background(), UploadAttachmentInput{
+				GroupID:    tt.groupID,
+				ExpenseID:  expenseID,
+				UploadedBy: uploader,
+				FileName:   "../../receipt.png",
+				Content:    bytes.NewReader(tt.content),
+			})
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if activityLogged != tt.expectActivity {
+				t.Errorf("expected activity logged %v, got %v", tt.expectActivity, activityLogged)
+			}
+			if files := countFiles(t, dir); files != tt.expectFileCount {
+				t.Errorf("expected %d stored files, got %d", tt.expectFileCount, files)
+			}
+			if tt.expectedError != nil {
+				return
+			}
+
+			if attachment.ContentType != tt.expectedType {
+				t.Errorf("expected content type %s, got %s", tt.expectedType, attachment.ContentType)
+			}
+			if created.FileName != "receipt.png" {
+				t.Errorf("expected sanitized file name, got %q", created.FileName)
+			}
+			if created.SizeBytes != int64(len(tt.content)) {
+				t.Errorf("expected size %d, got %d", len(tt.content), created.SizeBytes)
+			}
+
+			content, err := storage.Open(context.Background(), created.StorageKey)
+			if err != nil {
+				t.Fatalf("stored file missing: %v", err)
+			}
+			defer content.Close()
+			stored, _ := io.ReadAll(content)
+			if !bytes.Equal(stored, tt.content) {
+				t.Error("stored content does not match upload")
+			}
+		})
+	}
+}
+
+func TestExpenseAttachmentService_DeleteAttachment(t *testing.T) {
+	creator := testutil.CreateTestUUID(1)
+	uploader := testutil.CreateTestUUID(2)
+	bystander := testutil.CreateTestUUID(3)
+	groupID := testutil.CreateTestUUID(20)
+	expenseID := testutil.CreateTestUUID(30)
+	attachmentID := testutil.CreateTestUUID(40)
+
+	tests := []struct {
+		name          string
+		requesterID   pgtype.UUID
+		attachmentFor pgtype.UUID
+		expectedError error
+	}{
+		{name: "uploader can delete", requesterID: uploader, attachmentFor: expenseID},
+		{name: "expense creator can delete", requesterID: creator, attachmentFor: expenseID},
+		{name: "other member cannot delete", requesterID: bystander, attachmentFor: expenseID, expectedError: ErrAttachmentPermissionDenied},
+		{name: "attachment of another expense", requesterID: uploader, attachmentFor: testutil.CreateTestUUID(31), expectedError: ErrAttachmentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, err := NewLocalAttachmentStorage(t.TempDir())
+			if err != nil {
+				t.Fatalf("failed to create storage: %v", err)
+			}
+			key := "expenses/test/file"
+			if _, err := storage.Put(context.Background(), key, bytes.NewReader(pngHeader)); err != nil {
+				t.Fatalf("failed to seed storage: %v", err)
+			}
+
+			deleted := false
+			repo := &MockExpenseAttachmentRepository{
+				GetAttachmentByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.ExpenseAttachment, error) {
+					return sqlc.ExpenseAttachment{ID: id, ExpenseID: tt.attachmentFor, UploadedBy: uploader, StorageKey: key}, nil
+				},
+				DeleteAttachmentFunc: func(ctx context.Context, id pgtype.UUID) error {
+					deleted = true
+					return nil
+				},
+			}
+			expSvc := &MockExpenseService{
+				GetExpenseByIDFunc: func(ctx context.Context, id, userID pgtype.UUID) (sqlc.Expense, error) {
+					return sqlc.Expense{ID: id, GroupID: groupID, CreatedBy: creator}, nil
+				},
+			}
+
+			svc := NewExpenseAttachmentService(repo, expSvc, &MockGroupActivityService{}, storage, 0)
+			err = svc.DeleteAttachment(context.Background(), groupID, expenseID, attachmentID, tt.requesterID)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if deleted != (tt.expectedError == nil) {
+				t.Errorf("expected row deleted %v, got %v", tt.expectedError == nil, deleted)
+			}
+			_, openErr := storage.Open(context.Background(), key)
+			if fileGone := errors.Is(openErr, ErrAttachmentObjectNotFound); fileGone != (tt.expectedError == nil) {
+				t.Errorf("expected file removed %v, got %v", tt.expectedError == nil, fileGone)
+			}
+		})
+	}
+}
+
+func TestLocalAttachmentStorage_RejectsUnsafeKeys(t *testing.T) {
+	storage, err := NewLocalAttachmentStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	for _, key := range []string{"", "../escape", "/etc/passwd", "a/../../b", "a\\b", "./a"} {
+		if _, err := storage.Put(context.Background(), key, bytes.NewReader(pngHeader)); !errors.Is(err, ErrInvalidStorageKey) {
+			t.Errorf("key %q: expected ErrInvalidStorageKey, got %v", key, err)
+		}
+	}
+}
+
+func countFiles(t *testing.T, dir string) int {
+	t.Helper()
+	count := 0
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			count++
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to walk storage dir: %v", err)
+	}
+	return count
+}
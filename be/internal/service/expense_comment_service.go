@@ -61,19 +61,23 @@ func (s *expenseCommentService) CreateComment(ctx context.Context, expenseID, us
 	}
 
 	// Log activity
+	metadata := map[string]interface{}{
+		"version": 1,
+		"comment": map[string]interface{}{
+			"id":      result.ID,
+			"snippet": truncateString(comment, 50),
+		},
+	}
+	if mentions := parseMentions(comment); len(mentions) > 0 {
+		metadata["mentions"] = mentions
+	}
 	_ = s.activityService.LogActivity(ctx, LogActivityInput{
 		GroupID:    expense.GroupID,
 		UserID:     userID,
 		Action:     "comment_added",
 		EntityType: "expense",
 		EntityID:   expenseID,
-		Metadata: map[string]interface{}{
-			"version": 1,
-			"comment": map[string]interface{}{
-				"id":      result.ID,
-				"snippet": truncateString(comment, 50),
-			},
-		},
+		Metadata:   metadata,
 	})
 
 	return s.repo.GetCommentByID(ctx, result.ID)
@@ -93,9 +93,15 @@ func (m *MockExpenseService) GetExpensePayments(ctx context.Context, expenseID,
 func (m *MockExpenseService) GetExpenseSplits(ctx context.Context, expenseID, requesterID pgtype.UUID) ([]sqlc.ListExpenseSplitsRow, error) {
 	return []sqlc.ListExpenseSplitsRow{}, nil
 }
+func (m *MockExpenseService) GetExpenseItems(ctx context.Context, expenseID, requesterID pgtype.UUID) ([]ExpenseItemDetail, error) {
+	return []ExpenseItemDetail{}, nil
+}
 func (m *MockExpenseService) SearchExpenses(ctx context.Context, input SearchExpensesInput, requesterID pgtype.UUID) ([]sqlc.Expense, error) {
 	return []sqlc.Expense{}, nil
 }
+func (m *MockExpenseService) ImportExpenses(ctx context.Context, input ImportExpensesInput) (ImportExpensesResult, error) {
+	return ImportExpensesResult{}, nil
+}
 
 func TestExpenseCommentService_CreateComment(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,476 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+// MaxImportRows caps a single import so the whole file fits in one transaction.
+const MaxImportRows = 1000
+
+var (
+	ErrImportInvalidCSV     = errors.New("file is not valid CSV")
+	ErrImportEmpty          = errors.New("file has no expense rows")
+	ErrImportTooManyRows    = fmt.Errorf("file has more than %d expense rows", MaxImportRows)
+	ErrImportMissingColumn  = errors.New("a mapped column is missing from the CSV header")
+	ErrImportInvalidMapping = errors.New("invalid column mapping")
+	ErrImportDateFormat     = errors.New("unsupported date format")
+)
+
+// ImportColumnMapping names the CSV header that holds each field. Empty optional
+// fields are skipped. Payer and participants take emails or user IDs; lists
+// (participants, split values, tags) are separated by ";".
+type ImportColumnMapping struct {
+	Date         string
+	Title        string
+	Amount       string
+	Currency     string
+	Notes        string
+	Category     string // category slug
+	Tags         string
+	Payer        string
+	Participants string
+	SplitType    string // equal (default), percentage, shares or fixed
+	SplitValues  string // one value per participant for non-equal splits
+}
+
+// DefaultImportColumnMapping expects headers named after the fields, e.g.
+// "date", "title", "split_values".
+func DefaultImportColumnMapping() ImportColumnMapping {
+	return ImportColumnMapping{
+		Date:         "date",
+		Title:        "title",
+		Amount:       "amount",
+		Currency:     "currency",
+		Notes:        "notes",
+		Category:     "category",
+		Tags:         "tags",
+		Payer:        "payer",
+		Participants: "participants",
+		SplitType:    "split_type",
+		SplitValues:  "split_values",
+	}
+}
+
+// importDateLayouts are the date formats a caller can pick from.
+var importDateLayouts = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"DD/MM/YYYY": "02/01/2006",
+	"MM/DD/YYYY": "01/02/2006",
+	"DD.MM.YYYY": "02.01.2006",
+}
+
+type ImportExpensesInput struct {
+	GroupID     pgtype.UUID
+	RequesterID pgtype.UUID
+	CSV         io.Reader
+	Mapping     ImportColumnMapping
+	DateFormat  string // one of the importDateLayouts keys; defaults to YYYY-MM-DD
+	DryRun      bool
+}
+
+// ImportRowError points at a problem in the source file. Row is the 1-based
+// line number in the CSV, counting the header.
+type ImportRowError struct {
+	Row     int
+	Column  string
+	Message string
+}
+
+// ImportExpensesResult reports what an import did. When any row fails nothing
+// is written, and Errors lists every failure so the file can be fixed in one go.
+type ImportExpensesResult struct {
+	DryRun    bool
+	TotalRows int
+	Imported  int
+	Errors    []ImportRowError
+	Expenses  []sqlc.Expense
+}
+
+// importRow is a parsed CSV line waiting to be committed.
+type importRow struct {
+	line     int
+	prepared preparedExpense
+}
+
+func (s *expenseService) ImportExpenses(ctx context.Context, input ImportExpensesInput) (ImportExpensesResult, error) {
+	group, err := s.repo.GetGroupByID(ctx, input.GroupID)
+	if err != nil {
+		return ImportExpensesResult{}, ErrExpenseNotFound
+	}
+	if err := s.validateGroupMembership(ctx, input.GroupID, input.RequesterID); err != nil {
+		return ImportExpensesResult{}, err
+	}
+	if group.ArchivedAt.Valid {
+		return ImportExpensesResult{}, ErrGroupArchived
+	}
+
+	layout := importDateLayouts["YYYY-MM-DD"]
+	if input.DateFormat != "" {
+		l, ok := importDateLayouts[strings.ToUpper(input.DateFormat)]
+		if !ok {
+			return ImportExpensesResult{}, ErrImportDateFormat
+		}
+		layout = l
+	}
+
+	mapping := input.Mapping
+	if mapping.Date == "" || mapping.Title == "" || mapping.Amount == "" || mapping.Payer == "" || mapping.Participants == "" {
+		return ImportExpensesResult{}, ErrImportInvalidMapping
+	}
+
+	reader := csv.NewReader(input.CSV)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return ImportExpensesResult{}, ErrImportEmpty
+	}
+	if err != nil {
+		return ImportExpensesResult{}, fmt.Errorf("%w: %w", ErrImportInvalidCSV, err)
+	}
+
+	columns, err := resolveImportColumns(header, mapping)
+	if err != nil {
+		return ImportExpensesResult{}, err
+	}
+
+	resolver := &importResolver{s: s, groupID: input.GroupID, mapping: mapping, people: map[string]importPerson{}, categories: map[string]pgtype.UUID{}}
+	result := ImportExpensesResult{DryRun: input.DryRun}
+	var rows []importRow
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ImportExpensesResult{}, fmt.Errorf("%w: %w", ErrImportInvalidCSV, err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		result.TotalRows++
+		if result.TotalRows > MaxImportRows {
+			return ImportExpensesResult{}, ErrImportTooManyRows
+		}
+
+		expenseInput, rowErrors := resolver.parseRow(ctx, record, columns, layout, input.RequesterID)
+		for i := range rowErrors {
+			rowErrors[i].Row = line
+		}
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+
+		prepared, err := s.prepareExpense(ctx, group, expenseInput)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Row: line, Message: err.Error()})
+			continue
+		}
+		rows = append(rows, importRow{line: line, prepared: prepared})
+	}
+
+	if result.TotalRows == 0 {
+		return ImportExpensesResult{}, ErrImportEmpty
+	}
+	if input.DryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	// All rows are valid, write them in one transaction
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return ImportExpensesResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	txRepo := s.repo.WithTx(tx)
+	result.Expenses = make([]sqlc.Expense, 0, len(rows))
+	for _, row := range rows {
+		created, err := s.insertExpense(ctx, txRepo, row.prepared)
+		if err != nil {
+			return ImportExpensesResult{}, fmt.Errorf("row %d: %w", row.line, err)
+		}
+		result.Expenses = append(result.Expenses, created.Expense)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return ImportExpensesResult{}, err
+	}
+	result.Imported = len(result.Expenses)
+
+	// Log a single activity for the batch rather than one per row
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    input.GroupID,
+		UserID:     input.RequesterID,
+		Action:     "expenses_imported",
+		EntityType: "group",
+		EntityID:   input.GroupID,
+		Metadata: map[string]interface{}{
+			"count": result.Imported,
+		},
+	})
+
+	return result, nil
+}
+
+// importColumns holds the index of each mapped header, or -1 when unmapped.
+type importColumns struct {
+	date, title, amount, currency, notes, category, tags, payer, participants, splitType, splitValues int
+}
+
+func resolveImportColumns(header []string, mapping ImportColumnMapping) (importColumns, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	var missing []string
+	lookup := func(name string, required bool) int {
+		if name == "" {
+			return -1
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			// Optional columns that are absent are simply skipped
+			if required {
+				missing = append(missing, name)
+			}
+			return -1
+		}
+		return i
+	}
+
+	cols := importColumns{
+		date:         lookup(mapping.Date, true),
+		title:        lookup(mapping.Title, true),
+		amount:       lookup(mapping.Amount, true),
+		payer:        lookup(mapping.Payer, true),
+		participants: lookup(mapping.Participants, true),
+		currency:     lookup(mapping.Currency, false),
+		notes:        lookup(mapping.Notes, false),
+		category:     lookup(mapping.Category, false),
+		tags:         lookup(mapping.Tags, false),
+		splitType:    lookup(mapping.SplitType, false),
+		splitValues:  lookup(mapping.SplitValues, false),
+	}
+	if len(missing) > 0 {
+		return importColumns{}, fmt.Errorf("%w: %s", ErrImportMissingColumn, strings.Join(missing, ", "))
+	}
+	return cols, nil
+}
+
+// importPerson is a payer or participant resolved from an email or ID.
+type importPerson struct {
+	userID        pgtype.UUID
+	pendingUserID *pgtype.UUID
+}
+
+// importResolver caches lookups across rows; imports tend to repeat the same
+// handful of people and categories.
+type importResolver struct {
+	s          *expenseService
+	groupID    pgtype.UUID
+	mapping    ImportColumnMapping
+	people     map[string]importPerson
+	categories map[string]pgtype.UUID
+}
+
+func (r *importResolver) parseRow(ctx context.Context, record []string, cols importColumns, layout string, requesterID pgtype.UUID) (CreateExpenseInput, []ImportRowError) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var errs []ImportRowError
+	fail := func(column, format string, args ...interface{}) {
+		errs = append(errs, ImportRowError{Column: column, Message: fmt.Sprintf(format, args...)})
+	}
+
+	input := CreateExpenseInput{
+		GroupID:      r.groupID,
+		Title:        field(cols.title),
+		Notes:        field(cols.notes),
+		CurrencyCode: field(cols.currency),
+		CreatedBy:    requesterID,
+		Tags:         splitImportList(field(cols.tags)),
+	}
+
+	m := r.mapping
+
+	if input.Title == "" {
+		fail(m.Title, "title is required")
+	}
+
+	date, err := time.Parse(layout, field(cols.date))
+	if err != nil {
+		fail(m.Date, "date %q does not match the expected format", field(cols.date))
+	}
+	input.Date = date
+
+	input.Amount = field(cols.amount)
+	if _, err := stringToNumeric(input.Amount); err != nil {
+		fail(m.Amount, "amount %q is not a number", input.Amount)
+	}
+
+	if slug := field(cols.category); slug != "" {
+		categoryID, err := r.category(ctx, slug)
+		if err != nil {
+			fail(m.Category, "unknown category %q", slug)
+		} else {
+			input.CategoryID = &categoryID
+		}
+	}
+
+	payer, err := r.person(ctx, field(cols.payer))
+	if err != nil {
+		fail(m.Payer, "%v", err)
+	} else {
+		input.Payments = []PaymentInput{{UserID: payer.userID, PendingUserID: payer.pendingUserID, Amount: input.Amount}}
+	}
+
+	participants := splitImportList(field(cols.participants))
+	if len(participants) == 0 {
+		fail(m.Participants, "at least one participant is required")
+	}
+
+	splitType := strings.ToLower(field(cols.splitType))
+	if splitType == "" {
+		splitType = "equal"
+	}
+	switch splitType {
+	case "equal", "percentage", "shares", "fixed":
+	default:
+		fail(m.SplitType, "unknown split type %q", splitType)
+		return input, errs
+	}
+	values := splitImportList(field(cols.splitValues))
+	if splitType != "equal" && len(values) != len(participants) {
+		fail(m.SplitValues, "expected %d split values, got %d", len(participants), len(values))
+	}
+
+	for i, identifier := range participants {
+		person, err := r.person(ctx, identifier)
+		if err != nil {
+			fail(m.Participants, "%v", err)
+			continue
+		}
+
+		split := SplitInput{UserID: person.userID, PendingUserID: person.pendingUserID, Type: splitType}
+		if splitType != "equal" && i < len(values) {
+			value := values[i]
+			switch splitType {
+			case "percentage":
+				split.Percentage = &value
+			case "shares":
+				shares, err := strconv.Atoi(value)
+				if err != nil {
+					fail(m.SplitValues, "shares value %q is not a whole number", value)
+				}
+				split.Shares = &shares
+			case "fixed":
+				split.Amount = &value
+			}
+		}
+		input.Splits = append(input.Splits, split)
+	}
+
+	return input, errs
+}
+
+// person resolves a payer or participant. Emails must belong to an active group
+// member or a pending user invited to the group; IDs are passed through and
+// checked like API input.
+func (r *importResolver) person(ctx context.Context, identifier string) (importPerson, error) {
+	if identifier == "" {
+		return importPerson{}, errors.New("value is required")
+	}
+	key := strings.ToLower(identifier)
+	if person, ok := r.people[key]; ok {
+		return person, nil
+	}
+
+	var person importPerson
+	if id, err := parseImportUUID(identifier); err == nil {
+		person = importPerson{userID: id}
+	} else if _, err := mail.ParseAddress(identifier); err == nil {
+		user, err := r.s.userRepo.GetUserByEmail(ctx, key)
+		if err == nil {
+			if err := r.s.validateGroupMembership(ctx, r.groupID, user.ID); err != nil {
+				return importPerson{}, fmt.Errorf("%s is not a member of this group", identifier)
+			}
+			person = importPerson{userID: user.ID}
+		} else {
+			pending, err := r.s.pendingUserRepo.GetGroupPendingUserByEmail(ctx, sqlc.GetGroupPendingUserByEmailParams{
+				GroupID: r.groupID,
+				Email:   key,
+			})
+			if err != nil {
+				return importPerson{}, fmt.Errorf("no group member with email %s", identifier)
+			}
+			person = importPerson{pendingUserID: &pending.ID}
+		}
+	} else {
+		return importPerson{}, fmt.Errorf("%q is not an email or user id", identifier)
+	}
+
+	r.people[key] = person
+	return person, nil
+}
+
+func (r *importResolver) category(ctx context.Context, slug string) (pgtype.UUID, error) {
+	key := strings.ToLower(slug)
+	if id, ok := r.categories[key]; ok {
+		return id, nil
+	}
+	category, err := r.s.categoryRepo.GetCategoryBySlug(ctx, r.groupID, key)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	r.categories[key] = category.ID
+	return category.ID, nil
+}
+
+func parseImportUUID(s string) (pgtype.UUID, error) {
+	var id pgtype.UUID
+	if err := id.Scan(s); err != nil {
+		return pgtype.UUID{}, err
+	}
+	return id, nil
+}
+
+func splitImportList(value string) []string {
+	var items []string
+	for _, part := range strings.Split(value, ";") {
+		if part = strings.TrimSpace(part); part != "" {
+			items = append(items, part)
+		}
+	}
+	return items
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
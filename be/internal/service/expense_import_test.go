@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+func TestExpenseService_ImportExpenses(t *testing.T) {
+	alice := testutil.CreateTestUUID(1)
+	bob := testutil.CreateTestUUID(2)
+	outsider := testutil.CreateTestUUID(3)
+	pendingCarol := testutil.CreateTestUUID(4)
+	groupID := testutil.CreateTestUUID(20)
+	foodID := testutil.CreateTestUUID(30)
+
+	users := map[string]pgtype.UUID{
+		"alice@example.com": alice,
+		"bob@example.com":   bob,
+		"eve@example.com":   outsider,
+	}
+
+	tests := []struct {
+		name            string
+		csv             string
+		mapping         *ImportColumnMapping
+		dateFormat      string
+		dryRun          bool
+		expectedError   error
+		expectedRows    int
+		expectedErrors  []ImportRowError
+		expectedCreated int
+		expectCommit    bool
+	}{
+		{
+			name: "imports all rows in one transaction",
+			csv: "date,title,amount,category,tags,payer,participants,split_type,split_values\n" +
+				"2026-01-05,Dinner,90.00,food,trip;food,alice@example.com,alice@example.com;bob@example.com,,\n" +
+				"2026-01-06,Taxi,30,,,bob@example.com,alice@example.com;bob@example.com;carol@example.com,shares,1;1;1\n",
+			expectedRows:    2,
+			expectedCreated: 2,
+			expectCommit:    true,
+		},
+		{
+			name: "dry run validates without writing",
+			csv: "date,title,amount,payer,participants\n" +
+				"2026-01-05,Dinner,90.00,alice@example.com,alice@example.com;bob@example.com\n",
+			dryRun:       true,
+			expectedRows: 1,
+		},
+		{
+			name: "custom mapping and date format",
+			csv: "Booked On,Description,Cost,Paid By,Split With\n" +
+				"05/01/2026,Hotel,200,bob@example.com,alice@example.com;bob@example.com\n",
+			mapping: &ImportColumnMapping{
+				Date:         "Booked On",
+				Title:        "Description",
+				Amount:       "Cost",
+				Payer:        "Paid By",
+				Participants: "Split With",
+			},
+			dateFormat:      "DD/MM/YYYY",
+			expectedRows:    1,
+			expectedCreated: 1,
+			expectCommit:    true,
+		},
+		{
+			name: "reports every bad row and writes nothing",
+			csv: "date,title,amount,category,payer,participants,split_type,split_values\n" +
+				"2026-01-05,Dinner,90.00,food,alice@example.com,alice@example.com;bob@example.com,,\n" +
+				"not-a-date,,abc,snacks,eve@example.com,bob@example.com,,\n" +
+				"2026-01-07,Fuel,50,,alice@example.com,alice@example.com;bob@example.com,percentage,60;30\n",
+			expectedRows: 3,
+			expectedErrors: []ImportRowError{
+				{Row: 3, Column: "title"},
+				{Row: 3, Column: "date"},
+				{Row: 3, Column: "amount"},
+				{Row: 3, Column: "category"},
+				{Row: 3, Column: "payer"},
+				{Row: 4, Column: ""},
+			},
+		},
+		{
+			name: "pending user not invited to the group",
+			csv: "date,title,amount,payer,participants\n" +
+				"2026-01-05,Dinner,90.00,alice@example.com,alice@example.com;dave@example.com\n",
+			expectedRows:   1,
+			expectedErrors: []ImportRowError{{Row: 2, Column: "participants"}},
+		},
+		{
+			name:          "missing mapped column",
+			csv:           "date,title,amount,payer\n2026-01-05,Dinner,90.00,alice@example.com\n",
+			expectedError: ErrImportMissingColumn,
+		},
+		{
+			name:          "header only",
+			csv:           "date,title,amount,payer,participants\n",
+			expectedError: ErrImportEmpty,
+		},
+		{
+			name:          "unknown date format",
+			csv:           "date,title,amount,payer,participants\n",
+			dateFormat:    "YYYY/DD/MM",
+			expectedError: ErrImportDateFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			committed := false
+			created := 0
+
+			mock := &MockExpenseRepository{}
+			mock.GetGroupByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+				return testutil.CreateTestGroup(groupID, "Trip", alice), nil
+			}
+			mock.GetGroupMemberFunc = func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+				if params.UserID == outsider {
+					return sqlc.GroupMember{}, pgx.ErrNoRows
+				}
+				return testutil.CreateTestGroupMember(testutil.CreateTestUUID(200), params.GroupID, params.UserID, "member", "active"), nil
+			}
+			mock.BeginTxFunc = func(ctx context.Context) (pgx.Tx, error) {
+				return &testutil.MockTx{CommitFunc: func(ctx context.Context) error {
+					committed = true
+					return nil
+				}}, nil
+			}
+			mock.CreateExpenseFunc = func(ctx context.Context, params sqlc.CreateExpenseParams) (sqlc.Expense, error) {
+				created++
+				return sqlc.Expense{ID: testutil.CreateTestUUID(100 + created), GroupID: params.GroupID, Title: params.Title, Tags: params.Tags}, nil
+			}
+
+			categoryRepo := &MockExpenseCategoryRepository{
+				GetCategoryBySlugFunc: func(ctx context.Context, groupID pgtype.UUID, slug string) (sqlc.ExpenseCategory, error) {
+					if slug == "food" {
+						return sqlc.ExpenseCategory{ID: foodID, GroupID: groupID, Slug: slug}, nil
+					}
+					return sqlc.ExpenseCategory{}, pgx.ErrNoRows
+				},
+				GetCategoryByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.ExpenseCategory, error) {
+					return sqlc.ExpenseCategory{ID: foodID, GroupID: groupID, Slug: "food"}, nil
+				},
+			}
+			userRepo := &testutil.MockUserRepository{
+				GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+					if id, ok := users[email]; ok {
+						return sqlc.User{ID: id, Email: email}, nil
+					}
+					return sqlc.User{}, pgx.ErrNoRows
+				},
+			}
+			pendingUserRepo := &testutil.MockPendingUserRepository{
+				GetGroupPendingUserByEmailFunc: func(ctx context.Context, params sqlc.GetGroupPendingUserByEmailParams) (sqlc.PendingUser, error) {
+					if params.GroupID == groupID && params.Email == "carol@example.com" {
+						return sqlc.PendingUser{ID: pendingCarol, Email: params.Email}, nil
+					}
+					return sqlc.PendingUser{}, pgx.ErrNoRows
+				},
+			}
+
+			mapping := DefaultImportColumnMapping()
+			if tt.mapping != nil {
+				mapping = *tt.mapping
+			}
+
+			svc := NewExpenseService(mock, categoryRepo, &MockGroupActivityService{}, userRepo, pendingUserRepo, nil, nil)
+			result, err := svc.ImportExpenses(context.Background(), ImportExpensesInput{
+				GroupID:     groupID,
+				RequesterID: alice,
+				CSV:         strings.NewReader(tt.csv),
+				Mapping:     mapping,
+				DateFormat:  tt.dateFormat,
+				DryRun:      tt.dryRun,
+			})
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.TotalRows != tt.expectedRows {
+				t.Errorf("expected %d rows, got %d", tt.expectedRows, result.TotalRows)
+			}
+			if len(result.Errors) != len(tt.expectedErrors) {
+				t.Fatalf("expected %d row errors, got %d: %+v", len(tt.expectedErrors), len(result.Errors), result.Errors)
+			}
+			for i, expected := range tt.expectedErrors {
+				if result.Errors[i].Row != expected.Row || result.Errors[i].Column != expected.Column {
+					t.Errorf("error %d: expected row %d column %q, got row %d column %q (%s)",
+						i, expected.Row, expected.Column, result.Errors[i].Row, result.Errors[i].Column, result.Errors[i].Message)
+				}
+			}
+			if created != tt.expectedCreated || result.Imported != tt.expectedCreated {
+				t.Errorf("expected %d expenses created, got %d (imported %d)", tt.expectedCreated, created, result.Imported)
+			}
+			if committed != tt.expectCommit {
+				t.Errorf("expected commit %v, got %v", tt.expectCommit, committed)
+			}
+		})
+	}
+}
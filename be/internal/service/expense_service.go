@@ -26,6 +26,10 @@ var (
 	ErrSharesRequired          = errors.New("shares required and must be > 0 for shares splits")
 	ErrMixedSplitTypes         = errors.New("all splits must have the same type")
 	ErrInvalidSplitType        = errors.New("invalid split type")
+	ErrReceiptTotalMismatch    = errors.New("receipt items plus tax and tip minus discount must equal expense amount")
+	ErrItemConsumersRequired   = errors.New("each receipt item needs at least one consumer")
+	ErrDuplicateItemConsumer   = errors.New("a consumer can only be listed once per receipt item")
+	ErrSplitsWithReceipt       = errors.New("itemized expenses derive splits from receipt items; omit splits")
 )
 
 type PaymentInput struct {
@@ -44,6 +48,34 @@ type SplitInput struct {
 	Amount        *string
 }
 
+type ItemConsumerInput struct {
+	UserID        pgtype.UUID
+	PendingUserID *pgtype.UUID
+}
+
+// ReceiptItemInput is one receipt line, shared equally by its consumers.
+type ReceiptItemInput struct {
+	Name      string
+	Amount    string
+	Consumers []ItemConsumerInput
+}
+
+// ReceiptInput replaces Splits for itemized expenses. Tax, tip and discount are
+// spread across consumers in proportion to their item subtotals.
+type ReceiptInput struct {
+	Items    []ReceiptItemInput
+	Tax      *string
+	Tip      *string
+	Discount *string
+}
+
+// ExpenseItemDetail is a stored receipt line with its consumers. Tax, tip and
+// discount rows have no consumers.
+type ExpenseItemDetail struct {
+	Item      sqlc.ExpenseItem
+	Consumers []sqlc.ExpenseItemConsumer
+}
+
 // calculatedSplit is the result after backend calculation
 type calculatedSplit struct {
 	UserID        pgtype.UUID
@@ -59,12 +91,14 @@ type CreateExpenseInput struct {
 	Notes        string
 	Amount       string
 	CurrencyCode string
+	ExchangeRate *string // optional manual rate into the group currency
 	Date         time.Time
 	CategoryID   *pgtype.UUID
 	Tags         []string
 	CreatedBy    pgtype.UUID
 	Payments     []PaymentInput
 	Splits       []SplitInput
+	Receipt      *ReceiptInput // itemized expenses only; mutually exclusive with Splits
 }
 
 type UpdateExpenseInput struct {
@@ -73,12 +107,14 @@ type UpdateExpenseInput struct {
 	Notes        string
 	Amount       string
 	CurrencyCode string
+	ExchangeRate *string // optional manual rate into the group currency
 	Date         time.Time
 	CategoryID   *pgtype.UUID
 	Tags         []string
 	UpdatedBy    pgtype.UUID
 	Payments     []PaymentInput
 	Splits       []SplitInput
+	Receipt      *ReceiptInput // itemized expenses only; mutually exclusive with Splits
 }
 
 type SearchExpensesInput struct {
@@ -100,6 +136,7 @@ type CreateExpenseResult struct {
 	Expense  sqlc.Expense
 	Payments []sqlc.ExpensePayment
 	Splits   []sqlc.ExpenseSplit
+	Items    []ExpenseItemDetail
 }
 
 type ExpenseService interface {
@@ -110,7 +147,9 @@ type ExpenseService interface {
 	DeleteExpense(ctx context.Context, expenseID, requesterID pgtype.UUID) error
 	GetExpensePayments(ctx context.Context, expenseID, requesterID pgtype.UUID) ([]sqlc.ListExpensePaymentsRow, error)
 	GetExpenseSplits(ctx context.Context, expenseID, requesterID pgtype.UUID) ([]sqlc.ListExpenseSplitsRow, error)
+	GetExpenseItems(ctx context.Context, expenseID, requesterID pgtype.UUID) ([]ExpenseItemDetail, error)
 	SearchExpenses(ctx context.Context, input SearchExpensesInput, requesterID pgtype.UUID) ([]sqlc.Expense, error)
+	ImportExpenses(ctx context.Context, input ImportExpensesInput) (ImportExpensesResult, error)
 }
 
 type expenseService struct {
@@ -119,6 +158,8 @@ type expenseService struct {
 	activityService GroupActivityService
 	userRepo        repository.UserRepository
 	pendingUserRepo repository.PendingUserRepository
+	rateProvider    ExchangeRateProvider
+	budgetService   BudgetService
 }
 
 func NewExpenseService(
@@ -127,6 +168,8 @@ func NewExpenseService(
 	activityService GroupActivityService,
 	userRepo repository.UserRepository,
 	pendingUserRepo repository.PendingUserRepository,
+	rateProvider ExchangeRateProvider,
+	budgetService BudgetService,
 ) ExpenseService {
 	return &expenseService{
 		repo:            repo,
@@ -134,6 +177,8 @@ func NewExpenseService(
 		activityService: activityService,
 		userRepo:        userRepo,
 		pendingUserRepo: pendingUserRepo,
+		rateProvider:    rateProvider,
+		budgetService:   budgetService,
 	}
 }
 
@@ -206,16 +251,77 @@ func numericToDecimal(n pgtype.Numeric) (decimal.Decimal, error) {
 }
 
 func (s *expenseService) validateGroupMembership(ctx context.Context, groupID, userID pgtype.UUID) error {
-	_, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+	member, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
 		GroupID: groupID,
 		UserID:  userID,
 	})
-	if err != nil {
+	if err != nil || member.Status == "inactive" {
 		return ErrNotGroupMember
 	}
 	return nil
 }
 
+// resolveCurrency returns the currency an expense is recorded in and the rate that
+// converts it into the group currency. A manually supplied rate wins over the
+// provider so users can enter the rate their bank actually charged. When an
+// existing expense keeps its currency and no manual rate is given, the original
+// snapshot is reused so edits don't silently move historical balances.
+func (s *expenseService) resolveCurrency(
+	ctx context.Context,
+	requested string,
+	manualRate *string,
+	groupCurrency string,
+	date time.Time,
+	previous *sqlc.Expense,
+) (string, pgtype.Numeric, error) {
+	currencyCode := groupCurrency
+	if previous != nil && previous.CurrencyCode != "" {
+		currencyCode = previous.CurrencyCode
+	}
+	if strings.TrimSpace(requested) != "" {
+		code, err := normalizeCurrencyCode(requested)
+		if err != nil {
+			return "", pgtype.Numeric{}, err
+		}
+		currencyCode = code
+	}
+
+	if currencyCode == groupCurrency {
+		return currencyCode, unitExchangeRate(), nil
+	}
+
+	var rate decimal.Decimal
+	switch {
+	case manualRate != nil && strings.TrimSpace(*manualRate) != "":
+		manual, err := decimal.NewFromString(strings.TrimSpace(*manualRate))
+		if err != nil {
+			return "", pgtype.Numeric{}, ErrInvalidExchangeRate
+		}
+		rate = manual
+	case previous != nil && previous.CurrencyCode == currencyCode && previous.ExchangeRate.Valid:
+		return currencyCode, previous.ExchangeRate, nil
+	default:
+		if s.rateProvider == nil {
+			return "", pgtype.Numeric{}, ErrExchangeRateUnavailable
+		}
+		fetched, err := s.rateProvider.GetRate(ctx, currencyCode, groupCurrency, date)
+		if err != nil {
+			return "", pgtype.Numeric{}, err
+		}
+		rate = fetched
+	}
+
+	rate = rate.Round(exchangeRatePrecision)
+	if rate.LessThanOrEqual(decimal.Zero) {
+		return "", pgtype.Numeric{}, ErrInvalidExchangeRate
+	}
+	rateNumeric, err := stringToNumeric(rate.String())
+	if err != nil {
+		return "", pgtype.Numeric{}, ErrInvalidExchangeRate
+	}
+	return currencyCode, rateNumeric, nil
+}
+
 func (s *expenseService) validatePaymentsTotal(expenseAmount string, payments []PaymentInput) error {
 	expenseDecimal, err := decimal.NewFromString(expenseAmount)
 	if err != nil {
@@ -293,22 +399,11 @@ func calculateEqualSplits(total string, splits []SplitInput) ([]calculatedSplit,
 		return nil, ErrInvalidAmount
 	}
 
-	count := len(splits)
+	amounts := splitEvenly(totalDec, len(splits))
 
-	// Calculate base amount (rounded down to 2 decimal places)
-	baseAmount := totalDec.Div(decimal.NewFromInt(int64(count))).Round(2)
-
-	// Calculate remainder to add to last split
-	allocatedTotal := baseAmount.Mul(decimal.NewFromInt(int64(count - 1)))
-	lastAmount := totalDec.Sub(allocatedTotal)
-
-	result := make([]calculatedSplit, count)
+	result := make([]calculatedSplit, len(splits))
 	for i, split := range splits {
-		if i == count-1 {
-			result[i].Amount = lastAmount.String()
-		} else {
-			result[i].Amount = baseAmount.String()
-		}
+		result[i].Amount = amounts[i].String()
 		result[i].UserID = split.UserID
 		result[i].PendingUserID = split.PendingUserID
 		result[i].Type = "equal"
@@ -317,6 +412,82 @@ func calculateEqualSplits(total string, splits []SplitInput) ([]calculatedSplit,
 	return result, nil
 }
 
+// splitEvenly divides total into count parts rounded to 2 decimal places. The
+// last part absorbs the rounding remainder so the parts always sum to total.
+func splitEvenly(total decimal.Decimal, count int) []decimal.Decimal {
+	// Calculate base amount (rounded to 2 decimal places)
+	baseAmount := total.Div(decimal.NewFromInt(int64(count))).Round(2)
+
+	// Calculate remainder to add to last part
+	allocatedTotal := baseAmount.Mul(decimal.NewFromInt(int64(count - 1)))
+
+	amounts := make([]decimal.Decimal, count)
+	for i := range amounts {
+		amounts[i] = baseAmount
+	}
+	amounts[count-1] = total.Sub(allocatedTotal)
+	return amounts
+}
+
+// convertToGroupCurrency converts amounts, which sum to total, into the group
+// currency at rate. The converted total is rounded to cents once and the
+// largest amount absorbs the rounding remainder, so an expense's converted
+// payments and converted splits always sum to the same figure.
+func convertToGroupCurrency(total string, amounts []string, rate pgtype.Numeric) ([]pgtype.Numeric, error) {
+	totalDec, err := decimal.NewFromString(total)
+	if err != nil {
+		return nil, ErrInvalidAmount
+	}
+	rateDec, err := numericToDecimal(rate)
+	if err != nil {
+		return nil, ErrInvalidExchangeRate
+	}
+
+	converted := make([]decimal.Decimal, len(amounts))
+	largest := 0
+	var allocated, largestAmount decimal.Decimal
+	for i, value := range amounts {
+		amount, err := decimal.NewFromString(value)
+		if err != nil {
+			return nil, ErrInvalidAmount
+		}
+		converted[i] = amount.Mul(rateDec).Round(2)
+		allocated = allocated.Add(converted[i])
+		if i == 0 || amount.GreaterThan(largestAmount) {
+			largest, largestAmount = i, amount
+		}
+	}
+	if len(converted) > 0 {
+		converted[largest] = converted[largest].Add(totalDec.Mul(rateDec).Round(2).Sub(allocated))
+	}
+
+	result := make([]pgtype.Numeric, len(converted))
+	for i, amount := range converted {
+		if result[i], err = stringToNumeric(amount.String()); err != nil {
+			return nil, ErrInvalidAmount
+		}
+	}
+	return result, nil
+}
+
+// paymentAmounts returns the amount of each payment, in order.
+func paymentAmounts(payments []PaymentInput) []string {
+	amounts := make([]string, len(payments))
+	for i, payment := range payments {
+		amounts[i] = payment.Amount
+	}
+	return amounts
+}
+
+// splitAmounts returns the amount of each calculated split, in order.
+func splitAmounts(splits []calculatedSplit) []string {
+	amounts := make([]string, len(splits))
+	for i, split := range splits {
+		amounts[i] = split.Amount
+	}
+	return amounts
+}
+
 func calculatePercentageSplits(total string, splits []SplitInput) ([]calculatedSplit, error) {
 	totalDec, err := decimal.NewFromString(total)
 	if err != nil {
@@ -434,6 +605,233 @@ func validateFixedSplits(total string, splits []SplitInput) ([]calculatedSplit,
 	return result, nil
 }
 
+// calculateExpenseSplits derives splits from the explicit split list or, for
+// itemized expenses, from the receipt line items.
+func calculateExpenseSplits(expenseAmount string, splits []SplitInput, receipt *ReceiptInput) ([]calculatedSplit, error) {
+	if receipt == nil {
+		return calculateSplitAmounts(expenseAmount, splits)
+	}
+	if len(splits) > 0 {
+		return nil, ErrSplitsWithReceipt
+	}
+	return calculateItemizedSplits(expenseAmount, *receipt)
+}
+
+// participantKey identifies a split participant, who may be a user or a pending user.
+type participantKey struct {
+	id      [16]byte
+	pending bool
+}
+
+// calculateItemizedSplits shares each item equally between its consumers, then
+// spreads tax + tip - discount in proportion to each consumer's item subtotal.
+// Rounding follows calculateEqualSplits: the last share absorbs the remainder.
+func calculateItemizedSplits(total string, receipt ReceiptInput) ([]calculatedSplit, error) {
+	totalDec, err := decimal.NewFromString(total)
+	if err != nil {
+		return nil, ErrInvalidAmount
+	}
+	if len(receipt.Items) == 0 {
+		return nil, errors.New("at least one receipt item is required")
+	}
+
+	type consumerShare struct {
+		userID        pgtype.UUID
+		pendingUserID *pgtype.UUID
+		subtotal      decimal.Decimal
+	}
+
+	var shares []*consumerShare
+	byKey := map[participantKey]*consumerShare{}
+	var itemsTotal decimal.Decimal
+
+	for _, item := range receipt.Items {
+		amount, err := decimal.NewFromString(item.Amount)
+		if err != nil || amount.LessThan(decimal.Zero) || !amount.Equal(amount.Round(2)) {
+			return nil, ErrInvalidAmount
+		}
+		if len(item.Consumers) == 0 {
+			return nil, ErrItemConsumersRequired
+		}
+
+		parts := splitEvenly(amount, len(item.Consumers))
+		seen := map[participantKey]bool{}
+		for i, consumer := range item.Consumers {
+			var key participantKey
+			if consumer.UserID.Valid {
+				key = participantKey{id: consumer.UserID.Bytes}
+			} else if consumer.PendingUserID != nil && consumer.PendingUserID.Valid {
+				key = participantKey{id: consumer.PendingUserID.Bytes, pending: true}
+			} else {
+				return nil, errors.New("item consumer must have user_id or pending_user_id")
+			}
+			if seen[key] {
+				return nil, ErrDuplicateItemConsumer
+			}
+			seen[key] = true
+
+			share, ok := byKey[key]
+			if !ok {
+				share = &consumerShare{userID: consumer.UserID, pendingUserID: consumer.PendingUserID}
+				byKey[key] = share
+				shares = append(shares, share)
+			}
+			share.subtotal = share.subtotal.Add(parts[i])
+		}
+		itemsTotal = itemsTotal.Add(amount)
+	}
+
+	tax, err := parseOptionalAmount(receipt.Tax)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := parseOptionalAmount(receipt.Tip)
+	if err != nil {
+		return nil, err
+	}
+	discount, err := parseOptionalAmount(receipt.Discount)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustment := tax.Add(tip).Sub(discount)
+	if !itemsTotal.Add(adjustment).Equal(totalDec) {
+		return nil, ErrReceiptTotalMismatch
+	}
+
+	result := make([]calculatedSplit, len(shares))
+	var allocatedAdjustment decimal.Decimal
+
+	for i, share := range shares {
+		var portion decimal.Decimal
+		if i == len(shares)-1 {
+			// Last consumer gets remainder to ensure exact total
+			portion = adjustment.Sub(allocatedAdjustment)
+		} else if !itemsTotal.IsZero() {
+			portion = adjustment.Mul(share.subtotal).Div(itemsTotal).Round(2)
+			allocatedAdjustment = allocatedAdjustment.Add(portion)
+		}
+
+		amount := share.subtotal.Add(portion)
+		if amount.LessThan(decimal.Zero) {
+			return nil, ErrInvalidAmount
+		}
+
+		result[i].UserID = share.userID
+		result[i].PendingUserID = share.pendingUserID
+		result[i].Amount = amount.String()
+		result[i].Type = "itemized"
+		subtotal := share.subtotal.String()
+		result[i].ShareValue = &subtotal
+	}
+	return result, nil
+}
+
+// parseOptionalAmount parses an optional non-negative amount with at most 2
+// decimals, treating nil as zero.
+func parseOptionalAmount(value *string) (decimal.Decimal, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return decimal.Zero, nil
+	}
+	amount, err := decimal.NewFromString(strings.TrimSpace(*value))
+	if err != nil || amount.LessThan(decimal.Zero) || !amount.Equal(amount.Round(2)) {
+		return decimal.Zero, ErrInvalidAmount
+	}
+	return amount, nil
+}
+
+// createReceiptItems stores the receipt lines, their consumers and any tax, tip
+// and discount rows for an itemized expense.
+func (s *expenseService) createReceiptItems(ctx context.Context, txRepo repository.ExpenseRepository, expenseID pgtype.UUID, receipt *ReceiptInput) ([]ExpenseItemDetail, error) {
+	if receipt == nil {
+		return nil, nil
+	}
+
+	details := make([]ExpenseItemDetail, 0, len(receipt.Items)+3)
+	for i, itemInput := range receipt.Items {
+		amount, err := stringToNumeric(itemInput.Amount)
+		if err != nil {
+			return nil, ErrInvalidAmount
+		}
+
+		item, err := txRepo.CreateExpenseItem(ctx, sqlc.CreateExpenseItemParams{
+			ExpenseID: expenseID,
+			Kind:      "item",
+			Name:      strings.TrimSpace(itemInput.Name),
+			Amount:    amount,
+			Position:  int32(i),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		consumers := make([]sqlc.ExpenseItemConsumer, 0, len(itemInput.Consumers))
+		for _, consumerInput := range itemInput.Consumers {
+			var userID, pendingUserID pgtype.UUID
+			if consumerInput.UserID.Valid {
+				// Resolve UserID which might be a PendingUserID
+				userID, pendingUserID, err = s.resolveUserOrPendingUser(ctx, consumerInput.UserID)
+				if err != nil {
+					return nil, err
+				}
+			} else if consumerInput.PendingUserID != nil && consumerInput.PendingUserID.Valid {
+				pendingUserID = *consumerInput.PendingUserID
+			} else {
+				return nil, errors.New("item consumer must have user_id or pending_user_id")
+			}
+
+			consumer, err := txRepo.CreateExpenseItemConsumer(ctx, sqlc.CreateExpenseItemConsumerParams{
+				ExpenseItemID: item.ID,
+				UserID:        userID,
+				PendingUserID: pendingUserID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			consumers = append(consumers, consumer)
+		}
+
+		details = append(details, ExpenseItemDetail{Item: item, Consumers: consumers})
+	}
+
+	adjustments := []struct {
+		kind  string
+		value *string
+	}{
+		{"tax", receipt.Tax},
+		{"tip", receipt.Tip},
+		{"discount", receipt.Discount},
+	}
+	for _, adj := range adjustments {
+		amount, err := parseOptionalAmount(adj.value)
+		if err != nil {
+			return nil, err
+		}
+		if amount.IsZero() {
+			continue
+		}
+
+		amountNumeric, err := stringToNumeric(amount.String())
+		if err != nil {
+			return nil, ErrInvalidAmount
+		}
+
+		item, err := txRepo.CreateExpenseItem(ctx, sqlc.CreateExpenseItemParams{
+			ExpenseID: expenseID,
+			Kind:      adj.kind,
+			Name:      adj.kind,
+			Amount:    amountNumeric,
+			Position:  int32(len(details)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, ExpenseItemDetail{Item: item, Consumers: []sqlc.ExpenseItemConsumer{}})
+	}
+
+	return details, nil
+}
+
 func (s *expenseService) CreateExpense(ctx context.Context, input CreateExpenseInput) (CreateExpenseResult, error) {
 	// Validate group exists
 	group, err := s.repo.GetGroupByID(ctx, input.GroupID)
@@ -446,48 +844,112 @@ func (s *expenseService) CreateExpense(ctx context.Context, input CreateExpenseI
 		return CreateExpenseResult{}, err
 	}
 
+	// Archived groups are read-only
+	if group.ArchivedAt.Valid {
+		return CreateExpenseResult{}, ErrGroupArchived
+	}
+
+	prepared, err := s.prepareExpense(ctx, group, input)
+	if err != nil {
+		return CreateExpenseResult{}, err
+	}
+
+	// Start transaction
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return CreateExpenseResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := s.insertExpense(ctx, s.repo.WithTx(tx), prepared)
+	if err != nil {
+		return CreateExpenseResult{}, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return CreateExpenseResult{}, err
+	}
+
+	// Log activity
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    input.GroupID,
+		UserID:     input.CreatedBy,
+		Action:     "expense_created",
+		EntityType: "expense",
+		EntityID:   result.Expense.ID,
+		Metadata: buildExpenseActivityMetadata(result.Expense, input.Amount, prepared.expense.CurrencyCode, result.Payments, result.Splits, nil),
+	})
+	_ = s.checkBudgets(ctx, input.CreatedBy, result.Expense, nil)
+
+	return result, nil
+}
+
+// preparedExpense is a fully validated CreateExpenseInput, ready to be written.
+// Payment and split params are missing only the expense ID.
+type preparedExpense struct {
+	expense  sqlc.CreateExpenseParams
+	payments []sqlc.CreateExpensePaymentParams
+	splits   []sqlc.CreateExpenseSplitParams
+	receipt  *ReceiptInput
+}
+
+// prepareExpense validates an expense for an existing, writable group and
+// resolves everything it references. It does not write anything, so it also
+// backs dry runs.
+func (s *expenseService) prepareExpense(ctx context.Context, group sqlc.Group, input CreateExpenseInput) (preparedExpense, error) {
 	// Validate amount
 	expenseAmount, err := decimal.NewFromString(input.Amount)
 	if err != nil || expenseAmount.LessThanOrEqual(decimal.Zero) {
-		return CreateExpenseResult{}, ErrInvalidAmount
+		return preparedExpense{}, ErrInvalidAmount
 	}
 
-	// Always use group currency for group expenses
-	currencyCode := group.CurrencyCode
+	// Resolve the expense currency and snapshot its rate into the group currency
+	currencyCode, exchangeRate, err := s.resolveCurrency(ctx, input.CurrencyCode, input.ExchangeRate, group.CurrencyCode, input.Date, nil)
+	if err != nil {
+		return preparedExpense{}, err
+	}
 
 	// Validate payments
 	if len(input.Payments) == 0 {
-		return CreateExpenseResult{}, errors.New("at least one payment is required")
+		return preparedExpense{}, errors.New("at least one payment is required")
 	}
 	if err := s.validatePaymentsTotal(input.Amount, input.Payments); err != nil {
-		return CreateExpenseResult{}, err
+		return preparedExpense{}, err
 	}
 
 	// Calculate split amounts
-	calculatedSplits, err := calculateSplitAmounts(input.Amount, input.Splits)
+	calculatedSplits, err := calculateExpenseSplits(input.Amount, input.Splits, input.Receipt)
 	if err != nil {
-		return CreateExpenseResult{}, err
+		return preparedExpense{}, err
 	}
 
 	// Validate title
 	title := strings.TrimSpace(input.Title)
 	if title == "" {
-		return CreateExpenseResult{}, errors.New("title is required")
+		return preparedExpense{}, errors.New("title is required")
 	}
 
 	// Validate category if provided
 	if err := s.validateCategory(ctx, input.CategoryID, input.GroupID); err != nil {
-		return CreateExpenseResult{}, err
+		return preparedExpense{}, err
 	}
 
 	// Convert amount to numeric
 	amountNumeric, err := stringToNumeric(input.Amount)
 	if err != nil {
-		return CreateExpenseResult{}, ErrInvalidAmount
+		return preparedExpense{}, ErrInvalidAmount
 	}
 
-	// Convert date
-	date := pgtype.Date{Time: input.Date, Valid: true}
+	// Convert payments and splits into the group currency
+	convertedPayments, err := convertToGroupCurrency(input.Amount, paymentAmounts(input.Payments), exchangeRate)
+	if err != nil {
+		return preparedExpense{}, err
+	}
+	convertedSplits, err := convertToGroupCurrency(input.Amount, splitAmounts(calculatedSplits), exchangeRate)
+	if err != nil {
+		return preparedExpense{}, err
+	}
 
 	// Convert category_id
 	var categoryID pgtype.UUID
@@ -501,38 +963,30 @@ func (s *expenseService) CreateExpense(ctx context.Context, input CreateExpenseI
 		tags = input.Tags
 	}
 
-	// Start transaction
-	tx, err := s.repo.BeginTx(ctx)
-	if err != nil {
-		return CreateExpenseResult{}, err
-	}
-	defer tx.Rollback(ctx)
-
-	txRepo := s.repo.WithTx(tx)
-
-	// Create expense
-	expense, err := txRepo.CreateExpense(ctx, sqlc.CreateExpenseParams{
-		GroupID:      input.GroupID,
-		Type:         "group",
-		Title:        title,
-		Notes:        pgtype.Text{String: input.Notes, Valid: input.Notes != ""},
-		Amount:       amountNumeric,
-		CurrencyCode: currencyCode,
-		Date:         date,
-		CategoryID:   categoryID,
-		Tags:         tags,
-		CreatedBy:    input.CreatedBy,
-	})
-	if err != nil {
-		return CreateExpenseResult{}, err
+	prepared := preparedExpense{
+		expense: sqlc.CreateExpenseParams{
+			GroupID:      input.GroupID,
+			Type:         "group",
+			Title:        title,
+			Notes:        pgtype.Text{String: input.Notes, Valid: input.Notes != ""},
+			Amount:       amountNumeric,
+			CurrencyCode: currencyCode,
+			ExchangeRate: exchangeRate,
+			Date:         pgtype.Date{Time: input.Date, Valid: true},
+			CategoryID:   categoryID,
+			Tags:         tags,
+			CreatedBy:    input.CreatedBy,
+		},
+		payments: make([]sqlc.CreateExpensePaymentParams, 0, len(input.Payments)),
+		splits:   make([]sqlc.CreateExpenseSplitParams, 0, len(calculatedSplits)),
+		receipt:  input.Receipt,
 	}
 
-	// Create payments
-	payments := make([]sqlc.ExpensePayment, 0, len(input.Payments))
-	for _, paymentInput := range input.Payments {
+	// Resolve payers
+	for i, paymentInput := range input.Payments {
 		paymentAmount, err := stringToNumeric(paymentInput.Amount)
 		if err != nil {
-			return CreateExpenseResult{}, ErrInvalidAmount
+			return preparedExpense{}, ErrInvalidAmount
 		}
 
 		var userID, pendingUserID pgtype.UUID
@@ -540,33 +994,28 @@ func (s *expenseService) CreateExpense(ctx context.Context, input CreateExpenseI
 			// Resolve UserID which might be a PendingUserID
 			userID, pendingUserID, err = s.resolveUserOrPendingUser(ctx, paymentInput.UserID)
 			if err != nil {
-				return CreateExpenseResult{}, err
+				return preparedExpense{}, err
 			}
 		} else if paymentInput.PendingUserID != nil && paymentInput.PendingUserID.Valid {
 			pendingUserID = *paymentInput.PendingUserID
 		} else {
-			return CreateExpenseResult{}, errors.New("payment must have user_id or pending_user_id")
+			return preparedExpense{}, errors.New("payment must have user_id or pending_user_id")
 		}
 
-		payment, err := txRepo.CreateExpensePayment(ctx, sqlc.CreateExpensePaymentParams{
-			ExpenseID:     expense.ID,
-			UserID:        userID,
-			PendingUserID: pendingUserID,
-			Amount:        paymentAmount,
-			PaymentMethod: pgtype.Text{String: paymentInput.PaymentMethod, Valid: paymentInput.PaymentMethod != ""},
+		prepared.payments = append(prepared.payments, sqlc.CreateExpensePaymentParams{
+			UserID:                userID,
+			PendingUserID:         pendingUserID,
+			Amount:                paymentAmount,
+			PaymentMethod:         pgtype.Text{String: paymentInput.PaymentMethod, Valid: paymentInput.PaymentMethod != ""},
+			AmountInGroupCurrency: convertedPayments[i],
 		})
-		if err != nil {
-			return CreateExpenseResult{}, err
-		}
-		payments = append(payments, payment)
 	}
 
-	// Create splits using calculated amounts
-	splits := make([]sqlc.ExpenseSplit, 0, len(calculatedSplits))
-	for _, calcSplit := range calculatedSplits {
+	// Resolve split participants using calculated amounts
+	for i, calcSplit := range calculatedSplits {
 		splitAmount, err := stringToNumeric(calcSplit.Amount)
 		if err != nil {
-			return CreateExpenseResult{}, ErrInvalidAmount
+			return preparedExpense{}, ErrInvalidAmount
 		}
 
 		var userID, pendingUserID pgtype.UUID
@@ -574,55 +1023,77 @@ func (s *expenseService) CreateExpense(ctx context.Context, input CreateExpenseI
 			// Resolve UserID which might be a PendingUserID
 			userID, pendingUserID, err = s.resolveUserOrPendingUser(ctx, calcSplit.UserID)
 			if err != nil {
-				return CreateExpenseResult{}, err
+				return preparedExpense{}, err
 			}
 		} else if calcSplit.PendingUserID != nil && calcSplit.PendingUserID.Valid {
 			pendingUserID = *calcSplit.PendingUserID
 		} else {
-			return CreateExpenseResult{}, errors.New("split must have user_id or pending_user_id")
+			return preparedExpense{}, errors.New("split must have user_id or pending_user_id")
 		}
 
 		var shareValue pgtype.Numeric
 		if calcSplit.ShareValue != nil {
 			shareValue, err = stringToNumeric(*calcSplit.ShareValue)
 			if err != nil {
-				return CreateExpenseResult{}, ErrInvalidAmount
+				return preparedExpense{}, ErrInvalidAmount
 			}
 		}
 
-		split, err := txRepo.CreateExpenseSplit(ctx, sqlc.CreateExpenseSplitParams{
-			ExpenseID:     expense.ID,
-			UserID:        userID,
-			PendingUserID: pendingUserID,
-			AmountOwned:   splitAmount,
-			SplitType:     calcSplit.Type,
-			ShareValue:    shareValue,
+		prepared.splits = append(prepared.splits, sqlc.CreateExpenseSplitParams{
+			UserID:                userID,
+			PendingUserID:         pendingUserID,
+			AmountOwned:           splitAmount,
+			SplitType:             calcSplit.Type,
+			ShareValue:            shareValue,
+			AmountInGroupCurrency: convertedSplits[i],
 		})
+	}
+
+	return prepared, nil
+}
+
+// insertExpense writes a prepared expense with its payments, splits and receipt
+// items through txRepo. The caller owns the transaction.
+func (s *expenseService) insertExpense(ctx context.Context, txRepo repository.ExpenseRepository, prepared preparedExpense) (CreateExpenseResult, error) {
+	// Create expense
+	expense, err := txRepo.CreateExpense(ctx, prepared.expense)
+	if err != nil {
+		return CreateExpenseResult{}, err
+	}
+
+	// Create payments
+	payments := make([]sqlc.ExpensePayment, 0, len(prepared.payments))
+	for _, params := range prepared.payments {
+		params.ExpenseID = expense.ID
+		payment, err := txRepo.CreateExpensePayment(ctx, params)
+		if err != nil {
+			return CreateExpenseResult{}, err
+		}
+		payments = append(payments, payment)
+	}
+
+	// Create splits
+	splits := make([]sqlc.ExpenseSplit, 0, len(prepared.splits))
+	for _, params := range prepared.splits {
+		params.ExpenseID = expense.ID
+		split, err := txRepo.CreateExpenseSplit(ctx, params)
 		if err != nil {
 			return CreateExpenseResult{}, err
 		}
 		splits = append(splits, split)
 	}
 
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
+	// Store receipt line items for itemized expenses
+	items, err := s.createReceiptItems(ctx, txRepo, expense.ID, prepared.receipt)
+	if err != nil {
 		return CreateExpenseResult{}, err
 	}
 
-	// Log activity
-	_ = s.activityService.LogActivity(ctx, LogActivityInput{
-		GroupID:    input.GroupID,
-		UserID:     input.CreatedBy,
-		Action:     "expense_created",
-		EntityType: "expense",
-		EntityID:   expense.ID,
-		Metadata: buildExpenseActivityMetadata(expense, input.Amount, currencyCode, payments, splits, nil),
-	})
-
 	return CreateExpenseResult{
 		Expense:  expense,
 		Payments: payments,
 		Splits:   splits,
+		Items:    items,
 	}, nil
 }
 
@@ -682,7 +1153,7 @@ func (s *expenseService) UpdateExpense(ctx context.Context, input UpdateExpenseI
 	}
 
 	// Calculate split amounts
-	calculatedSplits, err := calculateSplitAmounts(input.Amount, input.Splits)
+	calculatedSplits, err := calculateExpenseSplits(input.Amount, input.Splits, input.Receipt)
 	if err != nil {
 		return CreateExpenseResult{}, err
 	}
@@ -698,12 +1169,18 @@ func (s *expenseService) UpdateExpense(ctx context.Context, input UpdateExpenseI
 		return CreateExpenseResult{}, err
 	}
 
-	// Always use group currency for group expenses
+	// Resolve the expense currency, keeping the original rate snapshot when unchanged
 	group, err := s.repo.GetGroupByID(ctx, expense.GroupID)
 	if err != nil {
 		return CreateExpenseResult{}, ErrExpenseNotFound
 	}
-	currencyCode := group.CurrencyCode
+	if group.ArchivedAt.Valid {
+		return CreateExpenseResult{}, ErrGroupArchived
+	}
+	currencyCode, exchangeRate, err := s.resolveCurrency(ctx, input.CurrencyCode, input.ExchangeRate, group.CurrencyCode, input.Date, &expense)
+	if err != nil {
+		return CreateExpenseResult{}, err
+	}
 
 	// Convert amount to numeric
 	amountNumeric, err := stringToNumeric(input.Amount)
@@ -711,6 +1188,16 @@ func (s *expenseService) UpdateExpense(ctx context.Context, input UpdateExpenseI
 		return CreateExpenseResult{}, ErrInvalidAmount
 	}
 
+	// Convert payments and splits into the group currency
+	convertedPayments, err := convertToGroupCurrency(input.Amount, paymentAmounts(input.Payments), exchangeRate)
+	if err != nil {
+		return CreateExpenseResult{}, err
+	}
+	convertedSplits, err := convertToGroupCurrency(input.Amount, splitAmounts(calculatedSplits), exchangeRate)
+	if err != nil {
+		return CreateExpenseResult{}, err
+	}
+
 	// Convert date
 	date := pgtype.Date{Time: input.Date, Valid: true}
 
@@ -742,6 +1229,7 @@ func (s *expenseService) UpdateExpense(ctx context.Context, input UpdateExpenseI
 		Notes:        pgtype.Text{String: input.Notes, Valid: input.Notes != ""},
 		Amount:       amountNumeric,
 		CurrencyCode: currencyCode,
+		ExchangeRate: exchangeRate,
 		Date:         date,
 		CategoryID:   categoryID,
 		Tags:         tags,
@@ -758,10 +1246,13 @@ func (s *expenseService) UpdateExpense(ctx context.Context, input UpdateExpenseI
 	if err := txRepo.DeleteExpenseSplits(ctx, input.ExpenseID); err != nil {
 		return CreateExpenseResult{}, err
 	}
+	if err := txRepo.DeleteExpenseItems(ctx, input.ExpenseID); err != nil {
+		return CreateExpenseResult{}, err
+	}
 
 	// Create new payments
 	payments := make([]sqlc.ExpensePayment, 0, len(input.Payments))
-	for _, paymentInput := range input.Payments {
+	for i, paymentInput := range input.Payments {
 		paymentAmount, err := stringToNumeric(paymentInput.Amount)
 		if err != nil {
 			return CreateExpenseResult{}, ErrInvalidAmount
@@ -780,11 +1271,12 @@ func (s *expenseService) UpdateExpense(ctx context.Context, input UpdateExpenseI
 		}
 
 		payment, err := txRepo.CreateExpensePayment(ctx, sqlc.CreateExpensePaymentParams{
-			ExpenseID:     updatedExpense.ID,
-			UserID:        userID,
-			PendingUserID: pendingUserID,
-			Amount:        paymentAmount,
-			PaymentMethod: pgtype.Text{String: paymentInput.PaymentMethod, Valid: paymentInput.PaymentMethod != ""},
+			ExpenseID:             updatedExpense.ID,
+			UserID:                userID,
+			PendingUserID:         pendingUserID,
+			Amount:                paymentAmount,
+			PaymentMethod:         pgtype.Text{String: paymentInput.PaymentMethod, Valid: paymentInput.PaymentMethod != ""},
+			AmountInGroupCurrency: convertedPayments[i],
 		})
 		if err != nil {
 			return CreateExpenseResult{}, err
@@ -794,7 +1286,7 @@ func (s *expenseService) UpdateExpense(ctx context.Context, input UpdateExpenseI
 
 	// Create new splits using calculated amounts
 	splits := make([]sqlc.ExpenseSplit, 0, len(calculatedSplits))
-	for _, calcSplit := range calculatedSplits {
+	for i, calcSplit := range calculatedSplits {
 		splitAmount, err := stringToNumeric(calcSplit.Amount)
 		if err != nil {
 			return CreateExpenseResult{}, ErrInvalidAmount
@@ -821,12 +1313,13 @@ func (s *expenseService) UpdateExpense(ctx context.Context, input UpdateExpenseI
 		}
 
 		split, err := txRepo.CreateExpenseSplit(ctx, sqlc.CreateExpenseSplitParams{
-			ExpenseID:     updatedExpense.ID,
-			UserID:        userID,
-			PendingUserID: pendingUserID,
-			AmountOwned:   splitAmount,
-			SplitType:     calcSplit.Type,
-			ShareValue:    shareValue,
+			ExpenseID:             updatedExpense.ID,
+			UserID:                userID,
+			PendingUserID:         pendingUserID,
+			AmountOwned:           splitAmount,
+			SplitType:             calcSplit.Type,
+			ShareValue:            shareValue,
+			AmountInGroupCurrency: convertedSplits[i],
 		})
 		if err != nil {
 			return CreateExpenseResult{}, err
@@ -834,6 +1327,12 @@ func (s *expenseService) UpdateExpense(ctx context.Context, input UpdateExpenseI
 		splits = append(splits, split)
 	}
 
+	// Store receipt line items for itemized expenses
+	items, err := s.createReceiptItems(ctx, txRepo, updatedExpense.ID, input.Receipt)
+	if err != nil {
+		return CreateExpenseResult{}, err
+	}
+
 	// Commit transaction
 	if err := tx.Commit(ctx); err != nil {
 		return CreateExpenseResult{}, err
@@ -848,14 +1347,25 @@ func (s *expenseService) UpdateExpense(ctx context.Context, input UpdateExpenseI
 		EntityID:   updatedExpense.ID,
 		Metadata: buildExpenseActivityMetadata(updatedExpense, input.Amount, currencyCode, payments, splits, &expense),
 	})
+	_ = s.checkBudgets(ctx, input.UpdatedBy, updatedExpense, &expense)
 
 	return CreateExpenseResult{
 		Expense:  updatedExpense,
 		Payments: payments,
 		Splits:   splits,
+		Items:    items,
 	}, nil
 }
 
+// checkBudgets alerts on the budgets a saved expense pushed past a threshold.
+// Like activity logging, callers drop the error so it never fails the save.
+func (s *expenseService) checkBudgets(ctx context.Context, actorID pgtype.UUID, expense sqlc.Expense, previous *sqlc.Expense) error {
+	if s.budgetService == nil {
+		return nil
+	}
+	return s.budgetService.CheckExpense(ctx, actorID, expense, previous)
+}
+
 func (s *expenseService) DeleteExpense(ctx context.Context, expenseID, requesterID pgtype.UUID) error {
 	expense, err := s.repo.GetExpenseByID(ctx, expenseID)
 	if err != nil {
@@ -912,6 +1422,44 @@ func (s *expenseService) GetExpenseSplits(ctx context.Context, expenseID, reques
 	return s.repo.ListExpenseSplits(ctx, expenseID)
 }
 
+// GetExpenseItems returns the receipt lines of an itemized expense with their
+// consumers. Non-itemized expenses return an empty list.
+func (s *expenseService) GetExpenseItems(ctx context.Context, expenseID, requesterID pgtype.UUID) ([]ExpenseItemDetail, error) {
+	expense, err := s.repo.GetExpenseByID(ctx, expenseID)
+	if err != nil {
+		return nil, ErrExpenseNotFound
+	}
+
+	// Validate user is group member
+	if err := s.validateGroupMembership(ctx, expense.GroupID, requesterID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListExpenseItems(ctx, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	consumers, err := s.repo.ListExpenseItemConsumers(ctx, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	byItem := make(map[[16]byte][]sqlc.ExpenseItemConsumer, len(items))
+	for _, consumer := range consumers {
+		byItem[consumer.ExpenseItemID.Bytes] = append(byItem[consumer.ExpenseItemID.Bytes], consumer)
+	}
+
+	details := make([]ExpenseItemDetail, len(items))
+	for i, item := range items {
+		itemConsumers := byItem[item.ID.Bytes]
+		if itemConsumers == nil {
+			itemConsumers = []sqlc.ExpenseItemConsumer{}
+		}
+		details[i] = ExpenseItemDetail{Item: item, Consumers: itemConsumers}
+	}
+	return details, nil
+}
+
 func (s *expenseService) SearchExpenses(ctx context.Context, input SearchExpensesInput, requesterID pgtype.UUID) ([]sqlc.Expense, error) {
 	// Validate group exists
 	_, err := s.repo.GetGroupByID(ctx, input.GroupID)
@@ -988,13 +1536,18 @@ func buildExpenseActivityMetadata(
 	splits []sqlc.ExpenseSplit,
 	before *sqlc.Expense,
 ) map[string]interface{} {
+	summary := map[string]interface{}{
+		"title":         expense.Title,
+		"amount":        amount,
+		"currency_code": currencyCode,
+	}
+	if expense.ExchangeRate.Valid {
+		summary["exchange_rate"] = numericToStringSafe(expense.ExchangeRate)
+	}
+
 	metadata := map[string]interface{}{
-		"version": 1,
-		"summary": map[string]interface{}{
-			"title":         expense.Title,
-			"amount":        amount,
-			"currency_code": currencyCode,
-		},
+		"version":    1,
+		"summary":    summary,
 		"split_type": splitTypeFromSplits(splits),
 		"splits":     buildActivitySplits(splits),
 		"payments":   buildActivityPayments(payments),
@@ -8,6 +8,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/repository"
@@ -16,23 +17,28 @@ import (
 
 // MockExpenseRepository for testing
 type MockExpenseRepository struct {
-	BeginTxFunc               func(ctx context.Context) (pgx.Tx, error)
-	WithTxFunc                func(tx pgx.Tx) repository.ExpenseRepository
-	CreateExpenseFunc         func(ctx context.Context, params sqlc.CreateExpenseParams) (sqlc.Expense, error)
-	GetExpenseByIDFunc        func(ctx context.Context, id pgtype.UUID) (sqlc.Expense, error)
-	ListExpensesByGroupFunc   func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.Expense, error)
-	ListFriendExpensesFunc    func(ctx context.Context, params sqlc.ListFriendExpensesParams) ([]sqlc.Expense, error)
-	UpdateExpenseFunc         func(ctx context.Context, params sqlc.UpdateExpenseParams) (sqlc.Expense, error)
-	DeleteExpenseFunc         func(ctx context.Context, id pgtype.UUID) error
-	CreateExpensePaymentFunc  func(ctx context.Context, params sqlc.CreateExpensePaymentParams) (sqlc.ExpensePayment, error)
-	ListExpensePaymentsFunc   func(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ListExpensePaymentsRow, error)
-	DeleteExpensePaymentsFunc func(ctx context.Context, expenseID pgtype.UUID) error
-	CreateExpenseSplitFunc    func(ctx context.Context, params sqlc.CreateExpenseSplitParams) (sqlc.ExpenseSplit, error)
-	ListExpenseSplitsFunc     func(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ListExpenseSplitsRow, error)
-	DeleteExpenseSplitsFunc   func(ctx context.Context, expenseID pgtype.UUID) error
-	GetGroupByIDFunc          func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error)
-	GetGroupMemberFunc        func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error)
-	SearchExpensesFunc        func(ctx context.Context, params sqlc.SearchExpensesParams) ([]sqlc.Expense, error)
+	BeginTxFunc                   func(ctx context.Context) (pgx.Tx, error)
+	WithTxFunc                    func(tx pgx.Tx) repository.ExpenseRepository
+	CreateExpenseFunc             func(ctx context.Context, params sqlc.CreateExpenseParams) (sqlc.Expense, error)
+	GetExpenseByIDFunc            func(ctx context.Context, id pgtype.UUID) (sqlc.Expense, error)
+	ListExpensesByGroupFunc       func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.Expense, error)
+	ListFriendExpensesFunc        func(ctx context.Context, params sqlc.ListFriendExpensesParams) ([]sqlc.Expense, error)
+	UpdateExpenseFunc             func(ctx context.Context, params sqlc.UpdateExpenseParams) (sqlc.Expense, error)
+	DeleteExpenseFunc             func(ctx context.Context, id pgtype.UUID) error
+	CreateExpensePaymentFunc      func(ctx context.Context, params sqlc.CreateExpensePaymentParams) (sqlc.ExpensePayment, error)
+	ListExpensePaymentsFunc       func(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ListExpensePaymentsRow, error)
+	DeleteExpensePaymentsFunc     func(ctx context.Context, expenseID pgtype.UUID) error
+	CreateExpenseSplitFunc        func(ctx context.Context, params sqlc.CreateExpenseSplitParams) (sqlc.ExpenseSplit, error)
+	ListExpenseSplitsFunc         func(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ListExpenseSplitsRow, error)
+	DeleteExpenseSplitsFunc       func(ctx context.Context, expenseID pgtype.UUID) error
+	CreateExpenseItemFunc         func(ctx context.Context, params sqlc.CreateExpenseItemParams) (sqlc.ExpenseItem, error)
+	CreateExpenseItemConsumerFunc func(ctx context.Context, params sqlc.CreateExpenseItemConsumerParams) (sqlc.ExpenseItemConsumer, error)
+	ListExpenseItemsFunc          func(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseItem, error)
+	ListExpenseItemConsumersFunc  func(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseItemConsumer, error)
+	DeleteExpenseItemsFunc        func(ctx context.Context, expenseID pgtype.UUID) error
+	GetGroupByIDFunc              func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error)
+	GetGroupMemberFunc            func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error)
+	SearchExpensesFunc            func(ctx context.Context, params sqlc.SearchExpensesParams) ([]sqlc.Expense, error)
 }
 
 func (m *MockExpenseRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
@@ -154,6 +160,41 @@ func (m *MockExpenseRepository) SearchExpenses(ctx context.Context, params sqlc.
 	return []sqlc.Expense{}, nil
 }
 
+func (m *MockExpenseRepository) CreateExpenseItem(ctx context.Context, params sqlc.CreateExpenseItemParams) (sqlc.ExpenseItem, error) {
+	if m.CreateExpenseItemFunc != nil {
+		return m.CreateExpenseItemFunc(ctx, params)
+	}
+	return sqlc.ExpenseItem{ExpenseID: params.ExpenseID, Kind: params.Kind, Name: params.Name, Amount: params.Amount, Position: params.Position}, nil
+}
+
+func (m *MockExpenseRepository) CreateExpenseItemConsumer(ctx context.Context, params sqlc.CreateExpenseItemConsumerParams) (sqlc.ExpenseItemConsumer, error) {
+	if m.CreateExpenseItemConsumerFunc != nil {
+		return m.CreateExpenseItemConsumerFunc(ctx, params)
+	}
+	return sqlc.ExpenseItemConsumer{ExpenseItemID: params.ExpenseItemID, UserID: params.UserID, PendingUserID: params.PendingUserID}, nil
+}
+
+func (m *MockExpenseRepository) ListExpenseItems(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseItem, error) {
+	if m.ListExpenseItemsFunc != nil {
+		return m.ListExpenseItemsFunc(ctx, expenseID)
+	}
+	return []sqlc.ExpenseItem{}, nil
+}
+
+func (m *MockExpenseRepository) ListExpenseItemConsumers(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.ExpenseItemConsumer, error) {
+	if m.ListExpenseItemConsumersFunc != nil {
+		return m.ListExpenseItemConsumersFunc(ctx, expenseID)
+	}
+	return []sqlc.ExpenseItemConsumer{}, nil
+}
+
+func (m *MockExpenseRepository) DeleteExpenseItems(ctx context.Context, expenseID pgtype.UUID) error {
+	if m.DeleteExpenseItemsFunc != nil {
+		return m.DeleteExpenseItemsFunc(ctx, expenseID)
+	}
+	return nil
+}
+
 var _ repository.ExpenseRepository = (*MockExpenseRepository)(nil)
 
 func strPtr(s string) *string {
@@ -655,7 +696,7 @@ func TestExpenseService_CreateExpense(t *testing.T) {
 			if tt.mockUserSetup != nil {
 				tt.mockUserSetup(mockUserRepo, mockPendingUserRepo)
 			}
-			service := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo)
+			service := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo, nil, nil)
 			tt.input.GroupID = groupID
 
 			_, err := service.CreateExpense(context.Background(), tt.input)
@@ -674,6 +715,343 @@ func TestExpenseService_CreateExpense(t *testing.T) {
 	}
 }
 
+func TestExpenseService_CreateExpense_MultiCurrency(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	otherUserID := testutil.CreateTestUUID(2)
+	groupID := testutil.CreateTestUUID(20)
+
+	rates, err := NewStaticRateProvider(map[string]string{"EUR/USD": "1.08"})
+	if err != nil {
+		t.Fatalf("failed to build rate provider: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		currencyCode     string
+		exchangeRate     *string
+		expectedCurrency string
+		expectedRate     string
+		expectedError    error
+	}{
+		{
+			name:             "defaults to group currency",
+			expectedCurrency: "USD",
+			expectedRate:     "1",
+		},
+		{
+			name:             "rate from provider",
+			currencyCode:     "eur",
+			expectedCurrency: "EUR",
+			expectedRate:     "1.08",
+		},
+		{
+			name:             "manual rate overrides provider",
+			currencyCode:     "EUR",
+			exchangeRate:     strPtr("1.1"),
+			expectedCurrency: "EUR",
+			expectedRate:     "1.1",
+		},
+		{
+			name:          "unknown currency pair",
+			currencyCode:  "JPY",
+			expectedError: ErrExchangeRateUnavailable,
+		},
+		{
+			name:          "invalid manual rate",
+			currencyCode:  "EUR",
+			exchangeRate:  strPtr("-2"),
+			expectedError: ErrInvalidExchangeRate,
+		},
+		{
+			name:          "invalid currency code",
+			currencyCode:  "EURO",
+			expectedError: ErrInvalidCurrencyCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created sqlc.CreateExpenseParams
+			mock := &MockExpenseRepository{}
+			mock.GetGroupByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+				return testutil.CreateTestGroup(groupID, "Test Group", userID), nil
+			}
+			mock.GetGroupMemberFunc = func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+				return testutil.CreateTestGroupMember(testutil.CreateTestUUID(200), params.GroupID, params.UserID, "member", "active"), nil
+			}
+			mock.CreateExpenseFunc = func(ctx context.Context, params sqlc.CreateExpenseParams) (sqlc.Expense, error) {
+				created = params
+				return sqlc.Expense{ID: testutil.CreateTestUUID(100), GroupID: params.GroupID, Title: params.Title, Amount: params.Amount, CurrencyCode: params.CurrencyCode, ExchangeRate: params.ExchangeRate}, nil
+			}
+			mock.CreateExpensePaymentFunc = func(ctx context.Context, params sqlc.CreateExpensePaymentParams) (sqlc.ExpensePayment, error) {
+				return sqlc.ExpensePayment{ID: testutil.CreateTestUUID(300), ExpenseID: params.ExpenseID, UserID: params.UserID, Amount: params.Amount}, nil
+			}
+			mock.CreateExpenseSplitFunc = func(ctx context.Context, params sqlc.CreateExpenseSplitParams) (sqlc.ExpenseSplit, error) {
+				return sqlc.ExpenseSplit{ID: testutil.CreateTestUUID(400), ExpenseID: params.ExpenseID, UserID: params.UserID, AmountOwned: params.AmountOwned, SplitType: params.SplitType}, nil
+			}
+
+			svc := NewExpenseService(mock, &MockExpenseCategoryRepository{}, &MockGroupActivityService{}, &testutil.MockUserRepository{}, &testutil.MockPendingUserRepository{}, rates, nil)
+			_, err := svc.CreateExpense(context.Background(), CreateExpenseInput{
+				GroupID:      groupID,
+				Title:        "Dinner in Paris",
+				Amount:       "100.00",
+				CurrencyCode: tt.currencyCode,
+				ExchangeRate: tt.exchangeRate,
+				Date:         time.Now(),
+				CreatedBy:    userID,
+				Payments:     []PaymentInput{{UserID: userID, Amount: "100.00"}},
+				Splits: []SplitInput{
+					{UserID: userID, Type: "equal"},
+					{UserID: otherUserID, Type: "equal"},
+				},
+			})
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if created.CurrencyCode != tt.expectedCurrency {
+				t.Errorf("expected currency %s, got %s", tt.expectedCurrency, created.CurrencyCode)
+			}
+			rate, err := numericToDecimal(created.ExchangeRate)
+			if err != nil {
+				t.Fatalf("invalid exchange rate: %v", err)
+			}
+			if !rate.Equal(decimal.RequireFromString(tt.expectedRate)) {
+				t.Errorf("expected exchange rate %s, got %s", tt.expectedRate, rate)
+			}
+		})
+	}
+}
+
+func TestExpenseService_CreateExpense_ConvertedBalancesNetToZero(t *testing.T) {
+	userA := testutil.CreateTestUUID(1)
+	userB := testutil.CreateTestUUID(2)
+	userC := testutil.CreateTestUUID(3)
+	groupID := testutil.CreateTestUUID(20)
+
+	tests := []struct {
+		name              string
+		amount            string
+		rate              string
+		expectedConverted string
+	}{
+		{name: "three-way split at an awkward rate", amount: "100.00", rate: "0.333333", expectedConverted: "33.33"},
+		{name: "per-line rounding would drift", amount: "10.01", rate: "0.333333", expectedConverted: "3.34"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balances := map[pgtype.UUID]decimal.Decimal{}
+			var totalPaid decimal.Decimal
+
+			mock := &MockExpenseRepository{}
+			mock.GetGroupByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+				return testutil.CreateTestGroup(groupID, "Test Group", userA), nil
+			}
+			mock.GetGroupMemberFunc = func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+				return testutil.CreateTestGroupMember(testutil.CreateTestUUID(200), params.GroupID, params.UserID, "member", "active"), nil
+			}
+			mock.CreateExpensePaymentFunc = func(ctx context.Context, params sqlc.CreateExpensePaymentParams) (sqlc.ExpensePayment, error) {
+				converted, err := numericToDecimal(params.AmountInGroupCurrency)
+				if err != nil {
+					t.Fatalf("invalid converted payment: %v", err)
+				}
+				balances[params.UserID] = balances[params.UserID].Add(converted)
+				totalPaid = totalPaid.Add(converted)
+				return sqlc.ExpensePayment{ExpenseID: params.ExpenseID, UserID: params.UserID, Amount: params.Amount}, nil
+			}
+			mock.CreateExpenseSplitFunc = func(ctx context.Context, params sqlc.CreateExpenseSplitParams) (sqlc.ExpenseSplit, error) {
+				converted, err := numericToDecimal(params.AmountInGroupCurrency)
+				if err != nil {
+					t.Fatalf("invalid converted split: %v", err)
+				}
+				balances[params.UserID] = balances[params.UserID].Sub(converted)
+				return sqlc.ExpenseSplit{ExpenseID: params.ExpenseID, UserID: params.UserID, AmountOwned: params.AmountOwned, SplitType: params.SplitType}, nil
+			}
+
+			svc := NewExpenseService(mock, &MockExpenseCategoryRepository{}, &MockGroupActivityService{}, &testutil.MockUserRepository{}, &testutil.MockPendingUserRepository{}, nil, nil)
+			_, err := svc.CreateExpense(context.Background(), CreateExpenseInput{
+				GroupID:      groupID,
+				Title:        "Dinner in Paris",
+				Amount:       tt.amount,
+				CurrencyCode: "EUR",
+				ExchangeRate: strPtr(tt.rate),
+				Date:         time.Now(),
+				CreatedBy:    userA,
+				Payments:     []PaymentInput{{UserID: userA, Amount: tt.amount}},
+				Splits: []SplitInput{
+					{UserID: userA, Type: "equal"},
+					{UserID: userB, Type: "equal"},
+					{UserID: userC, Type: "equal"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !totalPaid.Equal(decimal.RequireFromString(tt.expectedConverted)) {
+				t.Errorf("expected %s paid in the group currency, got %s", tt.expectedConverted, totalPaid)
+			}
+			var sum decimal.Decimal
+			for _, balance := range balances {
+				sum = sum.Add(balance)
+			}
+			if !sum.IsZero() {
+				t.Errorf("expected balances to sum to 0, got %s (%v)", sum, balances)
+			}
+		})
+	}
+}
+
+func TestExpenseService_CreateExpense_Itemized(t *testing.T) {
+	userA := testutil.CreateTestUUID(1)
+	userB := testutil.CreateTestUUID(2)
+	userC := testutil.CreateTestUUID(3)
+	groupID := testutil.CreateTestUUID(20)
+
+	dinner := ReceiptInput{
+		Items: []ReceiptItemInput{
+			{Name: "Pizza", Amount: "30.00", Consumers: []ItemConsumerInput{{UserID: userA}, {UserID: userB}, {UserID: userC}}},
+			{Name: "Wine", Amount: "20.00", Consumers: []ItemConsumerInput{{UserID: userA}, {UserID: userB}}},
+			{Name: "Salad", Amount: "7.00", Consumers: []ItemConsumerInput{{UserID: userC}}},
+		},
+		Tax:      strPtr("5.70"),
+		Tip:      strPtr("8.55"),
+		Discount: strPtr("2.25"),
+	}
+
+	tests := []struct {
+		name          string
+		amount        string
+		receipt       ReceiptInput
+		splits        []SplitInput
+		expected      map[pgtype.UUID]string
+		expectedItems int
+		expectedError error
+	}{
+		{
+			name:          "tax tip and discount spread by subtotal",
+			amount:        "69.00",
+			receipt:       dinner,
+			expected:      map[pgtype.UUID]string{userA: "24.21", userB: "24.21", userC: "20.58"},
+			expectedItems: 6,
+		},
+		{
+			name:   "item rounding remainder goes to last consumer",
+			amount: "10.00",
+			receipt: ReceiptInput{Items: []ReceiptItemInput{
+				{Name: "Nachos", Amount: "10.00", Consumers: []ItemConsumerInput{{UserID: userA}, {UserID: userB}, {UserID: userC}}},
+			}},
+			expected:      map[pgtype.UUID]string{userA: "3.33", userB: "3.33", userC: "3.34"},
+			expectedItems: 1,
+		},
+		{
+			name:          "receipt total mismatch",
+			amount:        "70.00",
+			receipt:       dinner,
+			expectedError: ErrReceiptTotalMismatch,
+		},
+		{
+			name:   "item without consumers",
+			amount: "10.00",
+			receipt: ReceiptInput{Items: []ReceiptItemInput{
+				{Name: "Nachos", Amount: "10.00"},
+			}},
+			expectedError: ErrItemConsumersRequired,
+		},
+		{
+			name:   "item amount with more than 2 decimals",
+			amount: "10.00",
+			receipt: ReceiptInput{Items: []ReceiptItemInput{
+				{Name: "Nachos", Amount: "9.995", Consumers: []ItemConsumerInput{{UserID: userA}}},
+				{Name: "Salsa", Amount: "0.005", Consumers: []ItemConsumerInput{{UserID: userB}}},
+			}},
+			expectedError: ErrInvalidAmount,
+		},
+		{
+			name:   "duplicate consumer on an item",
+			amount: "10.00",
+			receipt: ReceiptInput{Items: []ReceiptItemInput{
+				{Name: "Nachos", Amount: "10.00", Consumers: []ItemConsumerInput{{UserID: userA}, {UserID: userA}}},
+			}},
+			expectedError: ErrDuplicateItemConsumer,
+		},
+		{
+			name:          "splits cannot be combined with a receipt",
+			amount:        "69.00",
+			receipt:       dinner,
+			splits:        []SplitInput{{UserID: userA, Type: "equal"}},
+			expectedError: ErrSplitsWithReceipt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			splitAmounts := map[pgtype.UUID]string{}
+			itemCount := 0
+			mock := &MockExpenseRepository{}
+			mock.GetGroupByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+				return testutil.CreateTestGroup(groupID, "Test Group", userA), nil
+			}
+			mock.GetGroupMemberFunc = func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+				return testutil.CreateTestGroupMember(testutil.CreateTestUUID(200), params.GroupID, params.UserID, "member", "active"), nil
+			}
+			mock.CreateExpenseFunc = func(ctx context.Context, params sqlc.CreateExpenseParams) (sqlc.Expense, error) {
+				return sqlc.Expense{ID: testutil.CreateTestUUID(100), GroupID: params.GroupID, Amount: params.Amount}, nil
+			}
+			mock.CreateExpenseSplitFunc = func(ctx context.Context, params sqlc.CreateExpenseSplitParams) (sqlc.ExpenseSplit, error) {
+				if params.SplitType != "itemized" {
+					t.Errorf("expected split type itemized, got %s", params.SplitType)
+				}
+				amount, _ := numericToDecimal(params.AmountOwned)
+				splitAmounts[params.UserID] = amount.StringFixed(2)
+				return sqlc.ExpenseSplit{ExpenseID: params.ExpenseID, UserID: params.UserID, AmountOwned: params.AmountOwned, SplitType: params.SplitType}, nil
+			}
+			mock.CreateExpenseItemFunc = func(ctx context.Context, params sqlc.CreateExpenseItemParams) (sqlc.ExpenseItem, error) {
+				itemCount++
+				return sqlc.ExpenseItem{ID: testutil.CreateTestUUID(500 + itemCount), ExpenseID: params.ExpenseID, Kind: params.Kind, Amount: params.Amount}, nil
+			}
+
+			receipt := tt.receipt
+			svc := NewExpenseService(mock, &MockExpenseCategoryRepository{}, &MockGroupActivityService{}, &testutil.MockUserRepository{}, &testutil.MockPendingUserRepository{}, nil, nil)
+			result, err := svc.CreateExpense(context.Background(), CreateExpenseInput{
+				GroupID:   groupID,
+				Title:     "Dinner",
+				Amount:    tt.amount,
+				Date:      time.Now(),
+				CreatedBy: userA,
+				Payments:  []PaymentInput{{UserID: userA, Amount: tt.amount}},
+				Splits:    tt.splits,
+				Receipt:   &receipt,
+			})
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for userID, expected := range tt.expected {
+				if splitAmounts[userID] != expected {
+					t.Errorf("expected %s for user %s, got %s", expected, uuidToString(userID), splitAmounts[userID])
+				}
+			}
+			if len(result.Items) != tt.expectedItems {
+				t.Errorf("expected %d receipt rows, got %d", tt.expectedItems, len(result.Items))
+			}
+		})
+	}
+}
+
 func TestExpenseService_GetExpenseByID(t *testing.T) {
 	userID := testutil.CreateTestUUID(1)
 	expenseID := testutil.CreateTestUUID(10)
@@ -742,7 +1120,7 @@ func TestExpenseService_GetExpenseByID(t *testing.T) {
 			mockActivitySvc := &MockGroupActivityService{}
 			mockUserRepo := &testutil.MockUserRepository{}
 			mockPendingUserRepo := &testutil.MockPendingUserRepository{}
-			svc := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo)
+			svc := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo, nil, nil)
 			_, err := svc.GetExpenseByID(context.Background(), tt.expenseID, tt.requesterID)
 
 			if tt.expectedError != nil {
@@ -823,7 +1201,7 @@ func TestExpenseService_ListExpensesByGroup(t *testing.T) {
 			mockActivitySvc := &MockGroupActivityService{}
 			mockUserRepo := &testutil.MockUserRepository{}
 			mockPendingUserRepo := &testutil.MockPendingUserRepository{}
-			svc := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo)
+			svc := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo, nil, nil)
 			_, err := svc.ListExpensesByGroup(context.Background(), tt.groupID, tt.requesterID)
 
 			if tt.expectedError != nil {
@@ -1252,7 +1630,7 @@ func TestExpenseService_UpdateExpense(t *testing.T) {
 			mockActivitySvc := &MockGroupActivityService{}
 			mockUserRepo := &testutil.MockUserRepository{}
 			mockPendingUserRepo := &testutil.MockPendingUserRepository{}
-			service := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo)
+			service := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo, nil, nil)
 			_, err := service.UpdateExpense(context.Background(), tt.input)
 
 			if tt.expectedError != nil {
@@ -1341,7 +1719,7 @@ func TestExpenseService_DeleteExpense(t *testing.T) {
 			mockActivitySvc := &MockGroupActivityService{}
 			mockUserRepo := &testutil.MockUserRepository{}
 			mockPendingUserRepo := &testutil.MockPendingUserRepository{}
-			svc := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo)
+			svc := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo, nil, nil)
 			err := svc.DeleteExpense(context.Background(), tt.expenseID, tt.requesterID)
 
 			if tt.expectedError != nil {
@@ -1483,7 +1861,7 @@ func TestExpenseService_GetExpensePaymentsAndSplits(t *testing.T) {
 			mockActivitySvc := &MockGroupActivityService{}
 			mockUserRepo := &testutil.MockUserRepository{}
 			mockPendingUserRepo := &testutil.MockPendingUserRepository{}
-			svc := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo)
+			svc := NewExpenseService(mock, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo, nil, nil)
 
 			if tt.expectPaymentsOK || tt.expectedError != nil {
 				_, err := svc.GetExpensePayments(context.Background(), tt.expenseID, tt.requesterID)
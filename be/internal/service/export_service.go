@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+var (
+	ErrInvalidDateRange = errors.New("start_date must be on or before end_date")
+)
+
+// ExportFilter narrows a group export. Dates are inclusive. A category filter
+// only matches expenses, so settlements are left out when it is set.
+type ExportFilter struct {
+	GroupID     pgtype.UUID
+	RequesterID pgtype.UUID
+	StartDate   *time.Time
+	EndDate     *time.Time
+	CategoryID  *pgtype.UUID
+}
+
+// ExportedExpense is one expense with everything attached to it.
+type ExportedExpense struct {
+	Expense  sqlc.Expense
+	Category *sqlc.ExpenseCategory
+	Payments []sqlc.ListExpensePaymentsRow
+	Splits   []sqlc.ListExpenseSplitsRow
+	Items    []ExpenseItemDetail
+	Comments []sqlc.ListExpenseCommentsRow
+}
+
+// ExportWriter receives a group's ledger one record at a time so callers can
+// stream it out without holding the whole export in memory. Begin is only
+// called once access has been checked, so errors before it can still be
+// reported as a normal API error.
+type ExportWriter interface {
+	Begin(group sqlc.Group, categories []sqlc.ExpenseCategory) error
+	WriteExpense(expense ExportedExpense) error
+	WriteSettlement(settlement sqlc.ListSettlementsByGroupRow) error
+	End() error
+}
+
+type ExportService interface {
+	ExportGroup(ctx context.Context, filter ExportFilter, w ExportWriter) error
+}
+
+type exportService struct {
+	expenseRepo    repository.ExpenseRepository
+	settlementRepo repository.SettlementRepository
+	categoryRepo   repository.ExpenseCategoryRepository
+	commentRepo    repository.ExpenseCommentRepository
+}
+
+func NewExportService(
+	expenseRepo repository.ExpenseRepository,
+	settlementRepo repository.SettlementRepository,
+	categoryRepo repository.ExpenseCategoryRepository,
+	commentRepo repository.ExpenseCommentRepository,
+) ExportService {
+	return &exportService{
+		expenseRepo:    expenseRepo,
+		settlementRepo: settlementRepo,
+		categoryRepo:   categoryRepo,
+		commentRepo:    commentRepo,
+	}
+}
+
+func (s *exportService) ExportGroup(ctx context.Context, filter ExportFilter, w ExportWriter) error {
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return ErrInvalidDateRange
+	}
+
+	group, err := s.expenseRepo.GetGroupByID(ctx, filter.GroupID)
+	if err != nil {
+		return ErrGroupNotFound
+	}
+
+	member, err := s.expenseRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+		GroupID: filter.GroupID,
+		UserID:  filter.RequesterID,
+	})
+	if err != nil || member.Status == "inactive" {
+		return ErrNotGroupMember
+	}
+
+	categories, err := s.categoryRepo.ListCategoriesForGroup(ctx, filter.GroupID)
+	if err != nil {
+		return err
+	}
+	categoryByID := make(map[[16]byte]sqlc.ExpenseCategory, len(categories))
+	for _, category := range categories {
+		categoryByID[category.ID.Bytes] = category
+	}
+
+	expenses, err := s.expenseRepo.ListExpensesByGroup(ctx, filter.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if err := w.Begin(group, categories); err != nil {
+		return err
+	}
+
+	for _, expense := range expenses {
+		if !filter.matchesExpense(expense) {
+			continue
+		}
+
+		record, err := s.loadExpense(ctx, expense)
+		if err != nil {
+			return err
+		}
+		if category, ok := categoryByID[expense.CategoryID.Bytes]; ok && expense.CategoryID.Valid {
+			record.Category = &category
+		}
+
+		if err := w.WriteExpense(record); err != nil {
+			return err
+		}
+	}
+
+	if filter.CategoryID == nil {
+		settlements, err := s.settlementRepo.ListSettlementsByGroup(ctx, filter.GroupID)
+		if err != nil {
+			return err
+		}
+		for _, settlement := range settlements {
+			if !filter.matchesDate(settlementDate(settlement)) {
+				continue
+			}
+			if err := w.WriteSettlement(settlement); err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.End()
+}
+
+// loadExpense fetches the payments, splits, receipt items and comments of a single expense.
+func (s *exportService) loadExpense(ctx context.Context, expense sqlc.Expense) (ExportedExpense, error) {
+	payments, err := s.expenseRepo.ListExpensePayments(ctx, expense.ID)
+	if err != nil {
+		return ExportedExpense{}, err
+	}
+	splits, err := s.expenseRepo.ListExpenseSplits(ctx, expense.ID)
+	if err != nil {
+		return ExportedExpense{}, err
+	}
+	comments, err := s.commentRepo.ListComments(ctx, expense.ID)
+	if err != nil {
+		return ExportedExpense{}, err
+	}
+
+	items, err := s.expenseRepo.ListExpenseItems(ctx, expense.ID)
+	if err != nil {
+		return ExportedExpense{}, err
+	}
+	var details []ExpenseItemDetail
+	if len(items) > 0 {
+		consumers, err := s.expenseRepo.ListExpenseItemConsumers(ctx, expense.ID)
+		if err != nil {
+			return ExportedExpense{}, err
+		}
+		byItem := make(map[[16]byte][]sqlc.ExpenseItemConsumer, len(items))
+		for _, consumer := range consumers {
+			byItem[consumer.ExpenseItemID.Bytes] = append(byItem[consumer.ExpenseItemID.Bytes], consumer)
+		}
+		details = make([]ExpenseItemDetail, len(items))
+		for i, item := range items {
+			details[i] = ExpenseItemDetail{Item: item, Consumers: byItem[item.ID.Bytes]}
+		}
+	}
+
+	return ExportedExpense{
+		Expense:  expense,
+		Payments: payments,
+		Splits:   splits,
+		Items:    details,
+		Comments: comments,
+	}, nil
+}
+
+func (f ExportFilter) matchesExpense(expense sqlc.Expense) bool {
+	if f.CategoryID != nil && (!expense.CategoryID.Valid || expense.CategoryID.Bytes != f.CategoryID.Bytes) {
+		return false
+	}
+	if !expense.Date.Valid {
+		return f.StartDate == nil && f.EndDate == nil
+	}
+	return f.matchesDate(expense.Date.Time)
+}
+
+func (f ExportFilter) matchesDate(t time.Time) bool {
+	day := t.Format("2006-01-02")
+	if f.StartDate != nil && day < f.StartDate.Format("2006-01-02") {
+		return false
+	}
+	if f.EndDate != nil && day > f.EndDate.Format("2006-01-02") {
+		return false
+	}
+	return true
+}
+
+// settlementDate is when the money moved, falling back to when it was recorded.
+func settlementDate(settlement sqlc.ListSettlementsByGroupRow) time.Time {
+	if settlement.PaidAt.Valid {
+		return settlement.PaidAt.Time
+	}
+	return settlement.CreatedAt.Time
+}
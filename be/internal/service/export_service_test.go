@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+// MockSettlementRepository for testing
+type MockSettlementRepository struct {
+	CreateSettlementFunc           func(ctx context.Context, params sqlc.CreateSettlementParams) (sqlc.Settlement, error)
+	GetSettlementByIDFunc          func(ctx context.Context, id pgtype.UUID) (sqlc.Settlement, error)
+	ListSettlementsByGroupFunc     func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListSettlementsByGroupRow, error)
+	ListSettlementsByUserFunc      func(ctx context.Context, userID pgtype.UUID) ([]sqlc.ListSettlementsByUserRow, error)
+	UpdateSettlementStatusFunc     func(ctx context.Context, params sqlc.UpdateSettlementStatusParams) (sqlc.Settlement, error)
+	UpdateSettlementFunc           func(ctx context.Context, params sqlc.UpdateSettlementParams) (sqlc.Settlement, error)
+	DeleteSettlementFunc           func(ctx context.Context, id pgtype.UUID) error
+	ListFriendSettlementsFunc      func(ctx context.Context, params sqlc.ListFriendSettlementsParams) ([]sqlc.ListFriendSettlementsRow, error)
+	GetGroupByIDFunc               func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error)
+	GetGroupMemberFunc             func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error)
+	HasPendingMemberInvitationFunc func(ctx context.Context, params sqlc.HasPendingMemberInvitationParams) (bool, error)
+}
+
+func (m *MockSettlementRepository) CreateSettlement(ctx context.Context, params sqlc.CreateSettlementParams) (sqlc.Settlement, error) {
+	if m.CreateSettlementFunc != nil {
+		return m.CreateSettlementFunc(ctx, params)
+	}
+	return sqlc.Settlement{}, nil
+}
+
+func (m *MockSettlementRepository) GetSettlementByID(ctx context.Context, id pgtype.UUID) (sqlc.Settlement, error) {
+	if m.GetSettlementByIDFunc != nil {
+		return m.GetSettlementByIDFunc(ctx, id)
+	}
+	return sqlc.Settlement{}, errors.New("not found")
+}
+
+func (m *MockSettlementRepository) ListSettlementsByGroup(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListSettlementsByGroupRow, error) {
+	if m.ListSettlementsByGroupFunc != nil {
+		return m.ListSettlementsByGroupFunc(ctx, groupID)
+	}
+	return []sqlc.ListSettlementsByGroupRow{}, nil
+}
+
+func (m *MockSettlementRepository) ListSettlementsByUser(ctx context.Context, userID pgtype.UUID) ([]sqlc.ListSettlementsByUserRow, error) {
+	if m.ListSettlementsByUserFunc != nil {
+		return m.ListSettlementsByUserFunc(ctx, userID)
+	}
+	return []sqlc.ListSettlementsByUserRow{}, nil
+}
+
+func (m *MockSettlementRepository) UpdateSettlementStatus(ctx context.Context, params sqlc.UpdateSettlementStatusParams) (sqlc.Settlement, error) {
+	if m.UpdateSettlementStatusFunc != nil {
+		return m.UpdateSettlementStatusFunc(ctx, params)
+	}
+	return sqlc.Settlement{}, nil
+}
+
+func (m *MockSettlementRepository) UpdateSettlement(ctx context.Context, params sqlc.UpdateSettlementParams) (sqlc.Settlement, error) {
+	if m.UpdateSettlementFunc != nil {
+		return m.UpdateSettlementFunc(ctx, params)
+	}
+	return sqlc.Settlement{}, nil
+}
+
+func (m *MockSettlementRepository) DeleteSettlement(ctx context.Context, id pgtype.UUID) error {
+	if m.DeleteSettlementFunc != nil {
+		return m.DeleteSettlementFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockSettlementRepository) ListFriendSettlements(ctx context.Context, params sqlc.ListFriendSettlementsParams) ([]sqlc.ListFriendSettlementsRow, error) {
+	if m.ListFriendSettlementsFunc != nil {
+		return m.ListFriendSettlementsFunc(ctx, params)
+	}
+	return []sqlc.ListFriendSettlementsRow{}, nil
+}
+
+func (m *MockSettlementRepository) GetGroupByID(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+	if m.GetGroupByIDFunc != nil {
+		return m.GetGroupByIDFunc(ctx, id)
+	}
+	return sqlc.Group{}, errors.New("not found")
+}
+
+func (m *MockSettlementRepository) GetGroupMember(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+	if m.GetGroupMemberFunc != nil {
+		return m.GetGroupMemberFunc(ctx, params)
+	}
+	return sqlc.GroupMember{}, errors.New("not found")
+}
+
+func (m *MockSettlementRepository) HasPendingMemberInvitation(ctx context.Context, params sqlc.HasPendingMemberInvitationParams) (bool, error) {
+	if m.HasPendingMemberInvitationFunc != nil {
+		return m.HasPendingMemberInvitationFunc(ctx, params)
+	}
+	return false, nil
+}
+
+// recordingExportWriter collects what the export service hands it.
+type recordingExportWriter struct {
+	begun       bool
+	ended       bool
+	expenses    []ExportedExpense
+	settlements []sqlc.ListSettlementsByGroupRow
+}
+
+func (w *recordingExportWriter) Begin(group sqlc.Group, categories []sqlc.ExpenseCategory) error {
+	w.begun = true
+	return nil
+}
+
+func (w *recordingExportWriter) WriteExpense(expense ExportedExpense) error {
+	w.expenses = append(w.expenses, expense)
+	return nil
+}
+
+func (w *recordingExportWriter) WriteSettlement(settlement sqlc.ListSettlementsByGroupRow) error {
+	w.settlements = append(w.settlements, settlement)
+	return nil
+}
+
+func (w *recordingExportWriter) End() error {
+	w.ended = true
+	return nil
+}
+
+func TestExportService_ExportGroup(t *testing.T) {
+	groupID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	userID := pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+	foodID := pgtype.UUID{Bytes: [16]byte{3}, Valid: true}
+	travelID := pgtype.UUID{Bytes: [16]byte{4}, Valid: true}
+
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	datePtr := func(s string) *time.Time {
+		d := day(s)
+		return &d
+	}
+
+	expenses := []sqlc.Expense{
+		{ID: pgtype.UUID{Bytes: [16]byte{10}, Valid: true}, CategoryID: foodID, Date: pgtype.Date{Time: day("2026-01-05"), Valid: true}},
+		{ID: pgtype.UUID{Bytes: [16]byte{11}, Valid: true}, CategoryID: travelID, Date: pgtype.Date{Time: day("2026-02-10"), Valid: true}},
+		{ID: pgtype.UUID{Bytes: [16]byte{12}, Valid: true}, Date: pgtype.Date{Time: day("2026-03-15"), Valid: true}},
+	}
+	settlements := []sqlc.ListSettlementsByGroupRow{
+		{ID: pgtype.UUID{Bytes: [16]byte{20}, Valid: true}, PaidAt: pgtype.Timestamptz{Time: day("2026-01-20"), Valid: true}},
+		{ID: pgtype.UUID{Bytes: [16]byte{21}, Valid: true}, CreatedAt: pgtype.Timestamptz{Time: day("2026-03-01"), Valid: true}},
+	}
+
+	tests := []struct {
+		name            string
+		filter          ExportFilter
+		memberStatus    string
+		memberErr       error
+		wantErr         error
+		wantBegun       bool
+		wantExpenses    int
+		wantSettlements int
+	}{
+		{
+			name:            "exports everything without filters",
+			filter:          ExportFilter{},
+			memberStatus:    "active",
+			wantBegun:       true,
+			wantExpenses:    3,
+			wantSettlements: 2,
+		},
+		{
+			name:            "date range filters expenses and settlements",
+			filter:          ExportFilter{StartDate: datePtr("2026-01-05"), EndDate: datePtr("2026-02-10")},
+			memberStatus:    "active",
+			wantBegun:       true,
+			wantExpenses:    2,
+			wantSettlements: 1,
+		},
+		{
+			name:            "category filter skips settlements",
+			filter:          ExportFilter{CategoryID: &travelID},
+			memberStatus:    "active",
+			wantBegun:       true,
+			wantExpenses:    1,
+			wantSettlements: 0,
+		},
+		{
+			name:         "start after end",
+			filter:       ExportFilter{StartDate: datePtr("2026-02-01"), EndDate: datePtr("2026-01-01")},
+			memberStatus: "active",
+			wantErr:      ErrInvalidDateRange,
+		},
+		{
+			name:      "not a member",
+			memberErr: errors.New("not found"),
+			wantErr:   ErrNotGroupMember,
+		},
+		{
+			name:         "inactive member",
+			memberStatus: "inactive",
+			wantErr:      ErrNotGroupMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expenseRepo := &MockExpenseRepository{
+				GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+					return sqlc.Group{ID: groupID, Name: "Trip"}, nil
+				},
+				GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+					if tt.memberErr != nil {
+						return sqlc.GroupMember{}, tt.memberErr
+					}
+					return sqlc.GroupMember{GroupID: groupID, UserID: userID, Status: tt.memberStatus}, nil
+				},
+				ListExpensesByGroupFunc: func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.Expense, error) {
+					return expenses, nil
+				},
+			}
+			categoryRepo := &MockExpenseCategoryRepository{
+				ListCategoriesForGroupFunc: func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ExpenseCategory, error) {
+					return []sqlc.ExpenseCategory{
+						{ID: foodID, Slug: "food"},
+						{ID: travelID, Slug: "travel"},
+					}, nil
+				},
+			}
+			settlementRepo := &MockSettlementRepository{
+				ListSettlementsByGroupFunc: func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListSettlementsByGroupRow, error) {
+					return settlements, nil
+				},
+			}
+
+			svc := NewExportService(expenseRepo, settlementRepo, categoryRepo, &MockExpenseCommentRepository{})
+
+			filter := tt.filter
+			filter.GroupID = groupID
+			filter.RequesterID = userID
+
+			w := &recordingExportWriter{}
+			err := svc.ExportGroup(context.Background(), filter, w)
+
+			if err != tt.wantErr {
+				t.Fatalf("ExportGroup() error = %v, want %v", err, tt.wantErr)
+			}
+			if w.begun != tt.wantBegun {
+				t.Errorf("Begin called = %v, want %v", w.begun, tt.wantBegun)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !w.ended {
+				t.Error("End was not called")
+			}
+			if len(w.expenses) != tt.wantExpenses {
+				t.Errorf("expenses = %d, want %d", len(w.expenses), tt.wantExpenses)
+			}
+			if len(w.settlements) != tt.wantSettlements {
+				t.Errorf("settlements = %d, want %d", len(w.settlements), tt.wantSettlements)
+			}
+			for _, e := range w.expenses {
+				if e.Expense.CategoryID.Valid && (e.Category == nil || e.Category.ID != e.Expense.CategoryID) {
+					t.Errorf("expense %v missing its category", e.Expense.ID.Bytes[0])
+				}
+			}
+		})
+	}
+}
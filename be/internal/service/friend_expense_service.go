@@ -18,6 +18,8 @@ type FriendExpenseService interface {
 	GetFriendExpenseByID(ctx context.Context, expenseID, requesterID, friendID pgtype.UUID) (sqlc.Expense, error)
 	GetFriendExpensePayments(ctx context.Context, expenseID, requesterID, friendID pgtype.UUID) ([]sqlc.ListExpensePaymentsRow, error)
 	GetFriendExpenseSplits(ctx context.Context, expenseID, requesterID, friendID pgtype.UUID) ([]sqlc.ListExpenseSplitsRow, error)
+	UpdateFriendExpense(ctx context.Context, expenseID, requesterID, friendID pgtype.UUID, input UpdateExpenseInput) (CreateExpenseResult, error)
+	DeleteFriendExpense(ctx context.Context, expenseID, requesterID, friendID pgtype.UUID) error
 }
 
 type friendExpenseService struct {
@@ -86,10 +88,9 @@ func (s *friendExpenseService) CreateFriendExpense(ctx context.Context, creatorI
 		return CreateExpenseResult{}, err
 	}
 
-	// Validate amount
-	expenseAmount, err := decimal.NewFromString(input.Amount)
-	if err != nil || expenseAmount.LessThanOrEqual(decimal.Zero) {
-		return CreateExpenseResult{}, ErrInvalidAmount
+	prepared, err := s.prepareFriendExpense(input.Title, input.Amount, input.Payments, input.Splits, creatorID, friendID)
+	if err != nil {
+		return CreateExpenseResult{}, err
 	}
 
 	// Use default currency if not provided
@@ -98,37 +99,74 @@ func (s *friendExpenseService) CreateFriendExpense(ctx context.Context, creatorI
 		currencyCode = "USD" // Default for friend expenses
 	}
 
-	// Validate payments
-	if len(input.Payments) == 0 {
-		return CreateExpenseResult{}, errors.New("at least one payment is required")
-	}
-	if err := s.validatePaymentsTotal(input.Amount, input.Payments); err != nil {
+	// Convert date
+	date := pgtype.Date{Time: input.Date, Valid: true}
+
+	// Start transaction
+	tx, err := s.expenseRepo.BeginTx(ctx)
+	if err != nil {
 		return CreateExpenseResult{}, err
 	}
-	if err := s.validateTwoUsersOnly(input.Payments, input.Splits, creatorID, friendID); err != nil {
+	defer tx.Rollback(ctx)
+
+	txRepo := s.expenseRepo.WithTx(tx)
+
+	// Create expense with type='friend' and group_id=NULL
+	expense, err := txRepo.CreateExpense(ctx, sqlc.CreateExpenseParams{
+		GroupID:      pgtype.UUID{Valid: false}, // NULL for friend expenses
+		Type:         "friend",
+		Title:        prepared.title,
+		Notes:        pgtype.Text{String: input.Notes, Valid: input.Notes != ""},
+		Amount:       prepared.amount,
+		CurrencyCode: currencyCode,
+		ExchangeRate: unitExchangeRate(),
+		Date:         date,
+		CreatedBy:    creatorID,
+	})
+	if err != nil {
 		return CreateExpenseResult{}, err
 	}
 
-	// Calculate split amounts
-	calculatedSplits, err := calculateSplitAmounts(input.Amount, input.Splits)
+	payments, splits, err := createFriendPaymentsAndSplits(ctx, txRepo, expense.ID, input.Payments, prepared.splits)
 	if err != nil {
 		return CreateExpenseResult{}, err
 	}
 
-	// Validate title
-	title := strings.TrimSpace(input.Title)
-	if title == "" {
-		return CreateExpenseResult{}, errors.New("title is required")
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return CreateExpenseResult{}, err
 	}
 
-	// Convert amount to numeric
-	amountNumeric, err := stringToNumeric(input.Amount)
+	return CreateExpenseResult{
+		Expense:  expense,
+		Payments: payments,
+		Splits:   splits,
+	}, nil
+}
+
+// UpdateFriendExpense replaces the expense details, payments and splits. Either
+// friend may edit a shared expense.
+func (s *friendExpenseService) UpdateFriendExpense(ctx context.Context, expenseID, requesterID, friendID pgtype.UUID, input UpdateExpenseInput) (CreateExpenseResult, error) {
+	expense, err := s.getSharedFriendExpense(ctx, expenseID, requesterID, friendID)
 	if err != nil {
-		return CreateExpenseResult{}, ErrInvalidAmount
+		return CreateExpenseResult{}, err
 	}
 
-	// Convert date
-	date := pgtype.Date{Time: input.Date, Valid: true}
+	prepared, err := s.prepareFriendExpense(input.Title, input.Amount, input.Payments, input.Splits, requesterID, friendID)
+	if err != nil {
+		return CreateExpenseResult{}, err
+	}
+
+	// Keep the current currency if not provided
+	currencyCode := strings.TrimSpace(input.CurrencyCode)
+	if currencyCode == "" {
+		currencyCode = expense.CurrencyCode
+	}
+
+	tags := []string{}
+	if input.Tags != nil {
+		tags = input.Tags
+	}
 
 	// Start transaction
 	tx, err := s.expenseRepo.BeginTx(ctx)
@@ -139,38 +177,117 @@ func (s *friendExpenseService) CreateFriendExpense(ctx context.Context, creatorI
 
 	txRepo := s.expenseRepo.WithTx(tx)
 
-	// Create expense with type='friend' and group_id=NULL
-	expense, err := txRepo.CreateExpense(ctx, sqlc.CreateExpenseParams{
-		GroupID:      pgtype.UUID{Valid: false}, // NULL for friend expenses
-		Type:         "friend",
-		Title:        title,
+	updatedExpense, err := txRepo.UpdateExpense(ctx, sqlc.UpdateExpenseParams{
+		ID:           expense.ID,
+		Title:        prepared.title,
 		Notes:        pgtype.Text{String: input.Notes, Valid: input.Notes != ""},
-		Amount:       amountNumeric,
+		Amount:       prepared.amount,
 		CurrencyCode: currencyCode,
-		Date:         date,
-		CreatedBy:    creatorID,
+		ExchangeRate: unitExchangeRate(),
+		Date:         pgtype.Date{Time: input.Date, Valid: true},
+		Tags:         tags,
+		UpdatedBy:    requesterID,
 	})
 	if err != nil {
 		return CreateExpenseResult{}, err
 	}
 
+	// Replace payments and splits
+	if err := txRepo.DeleteExpensePayments(ctx, expense.ID); err != nil {
+		return CreateExpenseResult{}, err
+	}
+	if err := txRepo.DeleteExpenseSplits(ctx, expense.ID); err != nil {
+		return CreateExpenseResult{}, err
+	}
+
+	payments, splits, err := createFriendPaymentsAndSplits(ctx, txRepo, expense.ID, input.Payments, prepared.splits)
+	if err != nil {
+		return CreateExpenseResult{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return CreateExpenseResult{}, err
+	}
+
+	return CreateExpenseResult{
+		Expense:  updatedExpense,
+		Payments: payments,
+		Splits:   splits,
+	}, nil
+}
+
+func (s *friendExpenseService) DeleteFriendExpense(ctx context.Context, expenseID, requesterID, friendID pgtype.UUID) error {
+	if _, err := s.getSharedFriendExpense(ctx, expenseID, requesterID, friendID); err != nil {
+		return err
+	}
+	return s.expenseRepo.DeleteExpense(ctx, expenseID)
+}
+
+// preparedFriendExpense holds the validated values shared by create and update.
+type preparedFriendExpense struct {
+	title  string
+	amount pgtype.Numeric
+	splits []calculatedSplit
+}
+
+func (s *friendExpenseService) prepareFriendExpense(title, amount string, payments []PaymentInput, splits []SplitInput, userID, friendID pgtype.UUID) (preparedFriendExpense, error) {
+	// Validate amount
+	expenseAmount, err := decimal.NewFromString(amount)
+	if err != nil || expenseAmount.LessThanOrEqual(decimal.Zero) {
+		return preparedFriendExpense{}, ErrInvalidAmount
+	}
+
+	// Validate payments
+	if len(payments) == 0 {
+		return preparedFriendExpense{}, errors.New("at least one payment is required")
+	}
+	if err := s.validatePaymentsTotal(amount, payments); err != nil {
+		return preparedFriendExpense{}, err
+	}
+	if err := s.validateTwoUsersOnly(payments, splits, userID, friendID); err != nil {
+		return preparedFriendExpense{}, err
+	}
+
+	// Calculate split amounts
+	calculatedSplits, err := calculateSplitAmounts(amount, splits)
+	if err != nil {
+		return preparedFriendExpense{}, err
+	}
+
+	// Validate title
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return preparedFriendExpense{}, errors.New("title is required")
+	}
+
+	// Convert amount to numeric
+	amountNumeric, err := stringToNumeric(amount)
+	if err != nil {
+		return preparedFriendExpense{}, ErrInvalidAmount
+	}
+
+	return preparedFriendExpense{title: title, amount: amountNumeric, splits: calculatedSplits}, nil
+}
+
+func createFriendPaymentsAndSplits(ctx context.Context, txRepo repository.ExpenseRepository, expenseID pgtype.UUID, paymentInputs []PaymentInput, calculatedSplits []calculatedSplit) ([]sqlc.ExpensePayment, []sqlc.ExpenseSplit, error) {
 	// Create payments
-	payments := make([]sqlc.ExpensePayment, 0, len(input.Payments))
-	for _, paymentInput := range input.Payments {
+	payments := make([]sqlc.ExpensePayment, 0, len(paymentInputs))
+	for _, paymentInput := range paymentInputs {
 		paymentAmount, err := stringToNumeric(paymentInput.Amount)
 		if err != nil {
-			return CreateExpenseResult{}, ErrInvalidAmount
+			return nil, nil, ErrInvalidAmount
 		}
 
 		payment, err := txRepo.CreateExpensePayment(ctx, sqlc.CreateExpensePaymentParams{
-			ExpenseID:     expense.ID,
-			UserID:        paymentInput.UserID,
-			PendingUserID: pgtype.UUID{Valid: false},
-			Amount:        paymentAmount,
-			PaymentMethod: pgtype.Text{String: paymentInput.PaymentMethod, Valid: paymentInput.PaymentMethod != ""},
+			ExpenseID:             expenseID,
+			UserID:                paymentInput.UserID,
+			PendingUserID:         pgtype.UUID{Valid: false},
+			Amount:                paymentAmount,
+			PaymentMethod:         pgtype.Text{String: paymentInput.PaymentMethod, Valid: paymentInput.PaymentMethod != ""},
+			AmountInGroupCurrency: paymentAmount, // friend expenses have no group currency to convert into
 		})
 		if err != nil {
-			return CreateExpenseResult{}, err
+			return nil, nil, err
 		}
 		payments = append(payments, payment)
 	}
@@ -180,41 +297,71 @@ func (s *friendExpenseService) CreateFriendExpense(ctx context.Context, creatorI
 	for _, calcSplit := range calculatedSplits {
 		splitAmount, err := stringToNumeric(calcSplit.Amount)
 		if err != nil {
-			return CreateExpenseResult{}, ErrInvalidAmount
+			return nil, nil, ErrInvalidAmount
 		}
 
 		var shareValue pgtype.Numeric
 		if calcSplit.ShareValue != nil {
 			shareValue, err = stringToNumeric(*calcSplit.ShareValue)
 			if err != nil {
-				return CreateExpenseResult{}, ErrInvalidAmount
+				return nil, nil, ErrInvalidAmount
 			}
 		}
 
 		split, err := txRepo.CreateExpenseSplit(ctx, sqlc.CreateExpenseSplitParams{
-			ExpenseID:     expense.ID,
-			UserID:        calcSplit.UserID,
-			PendingUserID: pgtype.UUID{Valid: false},
-			AmountOwned:   splitAmount,
-			SplitType:     calcSplit.Type,
-			ShareValue:    shareValue,
+			ExpenseID:             expenseID,
+			UserID:                calcSplit.UserID,
+			PendingUserID:         pgtype.UUID{Valid: false},
+			AmountOwned:           splitAmount,
+			SplitType:             calcSplit.Type,
+			ShareValue:            shareValue,
+			AmountInGroupCurrency: splitAmount, // friend expenses have no group currency to convert into
 		})
 		if err != nil {
-			return CreateExpenseResult{}, err
+			return nil, nil, err
 		}
 		splits = append(splits, split)
 	}
 
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
-		return CreateExpenseResult{}, err
+	return payments, splits, nil
+}
+
+// getSharedFriendExpense loads a friend expense for modification. Unlike
+// GetFriendExpenseByID it requires every payer and split to be one of the two
+// friends, so an expense can only be changed from the friendship it belongs to.
+func (s *friendExpenseService) getSharedFriendExpense(ctx context.Context, expenseID, requesterID, friendID pgtype.UUID) (sqlc.Expense, error) {
+	expense, err := s.GetFriendExpenseByID(ctx, expenseID, requesterID, friendID)
+	if err != nil {
+		return sqlc.Expense{}, err
 	}
 
-	return CreateExpenseResult{
-		Expense:  expense,
-		Payments: payments,
-		Splits:   splits,
-	}, nil
+	payments, err := s.expenseRepo.ListExpensePayments(ctx, expenseID)
+	if err != nil {
+		return sqlc.Expense{}, err
+	}
+	splits, err := s.expenseRepo.ListExpenseSplits(ctx, expenseID)
+	if err != nil {
+		return sqlc.Expense{}, err
+	}
+
+	involvesFriend := false
+	for _, p := range payments {
+		if p.UserID != requesterID && p.UserID != friendID {
+			return sqlc.Expense{}, ErrExpenseNotFound
+		}
+		involvesFriend = involvesFriend || p.UserID == friendID
+	}
+	for _, sp := range splits {
+		if sp.UserID != requesterID && sp.UserID != friendID {
+			return sqlc.Expense{}, ErrExpenseNotFound
+		}
+		involvesFriend = involvesFriend || sp.UserID == friendID
+	}
+	if !involvesFriend {
+		return sqlc.Expense{}, ErrExpenseNotFound
+	}
+
+	return expense, nil
 }
 
 func (s *friendExpenseService) ListFriendExpenses(ctx context.Context, userID, friendID pgtype.UUID) ([]sqlc.Expense, error) {
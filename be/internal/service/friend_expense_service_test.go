@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+func acceptedFriendRepo() *MockFriendRepository {
+	return &MockFriendRepository{
+		GetFriendshipFunc: func(ctx context.Context, userID, friendUserID pgtype.UUID) (sqlc.Friendship, error) {
+			return sqlc.Friendship{Status: "accepted"}, nil
+		},
+	}
+}
+
+func TestFriendExpenseService_UpdateFriendExpense(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	friendID := testutil.CreateTestUUID(2)
+	otherID := testutil.CreateTestUUID(3)
+	expenseID := testutil.CreateTestUUID(100)
+
+	tests := []struct {
+		name          string
+		expense       sqlc.Expense
+		existingUsers []pgtype.UUID // payers and split users on the stored expense
+		input         UpdateExpenseInput
+		expectedError error
+		wantErrText   string
+	}{
+		{
+			name:          "updates amount and splits equally",
+			expense:       sqlc.Expense{ID: expenseID, Type: "friend", CreatedBy: userID, CurrencyCode: "EUR"},
+			existingUsers: []pgtype.UUID{userID, friendID},
+			input: UpdateExpenseInput{
+				Title:    " Dinner ",
+				Amount:   "90.00",
+				Date:     time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+				Payments: []PaymentInput{{UserID: friendID, Amount: "90.00"}},
+				Splits:   []SplitInput{{UserID: userID, Type: "equal"}, {UserID: friendID, Type: "equal"}},
+			},
+		},
+		{
+			name:          "rejects a third user",
+			expense:       sqlc.Expense{ID: expenseID, Type: "friend", CreatedBy: userID},
+			existingUsers: []pgtype.UUID{userID, friendID},
+			input: UpdateExpenseInput{
+				Title:    "Dinner",
+				Amount:   "90.00",
+				Payments: []PaymentInput{{UserID: userID, Amount: "90.00"}},
+				Splits:   []SplitInput{{UserID: userID, Type: "equal"}, {UserID: otherID, Type: "equal"}},
+			},
+			wantErrText: "splits must only involve the two friends",
+		},
+		{
+			name:          "rejects payments that don't add up",
+			expense:       sqlc.Expense{ID: expenseID, Type: "friend", CreatedBy: userID},
+			existingUsers: []pgtype.UUID{userID, friendID},
+			input: UpdateExpenseInput{
+				Title:    "Dinner",
+				Amount:   "90.00",
+				Payments: []PaymentInput{{UserID: userID, Amount: "80.00"}},
+				Splits:   []SplitInput{{UserID: userID, Type: "equal"}, {UserID: friendID, Type: "equal"}},
+			},
+			expectedError: ErrPaymentTotalMismatch,
+		},
+		{
+			name:          "expense shared with a different friend is not found",
+			expense:       sqlc.Expense{ID: expenseID, Type: "friend", CreatedBy: userID},
+			existingUsers: []pgtype.UUID{userID, otherID},
+			input: UpdateExpenseInput{
+				Title:    "Dinner",
+				Amount:   "90.00",
+				Payments: []PaymentInput{{UserID: userID, Amount: "90.00"}},
+				Splits:   []SplitInput{{UserID: userID, Type: "equal"}, {UserID: friendID, Type: "equal"}},
+			},
+			expectedError: ErrExpenseNotFound,
+		},
+		{
+			name:          "group expense is not found",
+			expense:       sqlc.Expense{ID: expenseID, Type: "group", CreatedBy: userID},
+			existingUsers: []pgtype.UUID{userID, friendID},
+			input:         UpdateExpenseInput{Title: "Dinner", Amount: "90.00"},
+			expectedError: ErrExpenseNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated sqlc.UpdateExpenseParams
+			var createdSplits []sqlc.CreateExpenseSplitParams
+			deletedLines := 0
+			repo := &MockExpenseRepository{
+				GetExpenseByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Expense, error) {
+					return tt.expense, nil
+				},
+				ListExpensePaymentsFunc: func(ctx context.Context, id pgtype.UUID) ([]sqlc.ListExpensePaymentsRow, error) {
+					return []sqlc.ListExpensePaymentsRow{{UserID: tt.existingUsers[0]}}, nil
+				},
+				ListExpenseSplitsFunc: func(ctx context.Context, id pgtype.UUID) ([]sqlc.ListExpenseSplitsRow, error) {
+					rows := make([]sqlc.ListExpenseSplitsRow, len(tt.existingUsers))
+					for i, u := range tt.existingUsers {
+						rows[i] = sqlc.ListExpenseSplitsRow{UserID: u}
+					}
+					return rows, nil
+				},
+				UpdateExpenseFunc: func(ctx context.Context, params sqlc.UpdateExpenseParams) (sqlc.Expense, error) {
+					updated = params
+					return sqlc.Expense{ID: params.ID, Title: params.Title}, nil
+				},
+				DeleteExpensePaymentsFunc: func(ctx context.Context, id pgtype.UUID) error {
+					deletedLines++
+					return nil
+				},
+				DeleteExpenseSplitsFunc: func(ctx context.Context, id pgtype.UUID) error {
+					deletedLines++
+					return nil
+				},
+				CreateExpenseSplitFunc: func(ctx context.Context, params sqlc.CreateExpenseSplitParams) (sqlc.ExpenseSplit, error) {
+					createdSplits = append(createdSplits, params)
+					return sqlc.ExpenseSplit{UserID: params.UserID}, nil
+				},
+			}
+
+			svc := NewFriendExpenseService(repo, acceptedFriendRepo())
+			result, err := svc.UpdateFriendExpense(context.Background(), expenseID, userID, friendID, tt.input)
+
+			if tt.wantErrText != "" {
+				if err == nil || err.Error() != tt.wantErrText {
+					t.Fatalf("expected error %q, got %v", tt.wantErrText, err)
+				}
+				return
+			}
+			if err != tt.expectedError {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if tt.expectedError != nil {
+				return
+			}
+
+			if updated.Title != "Dinner" {
+				t.Errorf("expected trimmed title, got %q", updated.Title)
+			}
+			if updated.CurrencyCode != "EUR" {
+				t.Errorf("expected currency to be kept, got %q", updated.CurrencyCode)
+			}
+			if deletedLines != 2 {
+				t.Errorf("expected payments and splits to be replaced, got %d deletes", deletedLines)
+			}
+			if len(createdSplits) != 2 || len(result.Splits) != 2 {
+				t.Fatalf("expected 2 splits, got %d", len(createdSplits))
+			}
+			if got := numericToStringSafe(createdSplits[0].AmountOwned); got != "45" && got != "45.00" {
+				t.Errorf("expected equal split of 45, got %s", got)
+			}
+		})
+	}
+}
+
+func TestFriendExpenseService_DeleteFriendExpense(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	friendID := testutil.CreateTestUUID(2)
+	expenseID := testutil.CreateTestUUID(100)
+
+	deleted := false
+	repo := &MockExpenseRepository{
+		GetExpenseByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Expense, error) {
+			return sqlc.Expense{ID: id, Type: "friend", CreatedBy: friendID}, nil
+		},
+		ListExpenseSplitsFunc: func(ctx context.Context, id pgtype.UUID) ([]sqlc.ListExpenseSplitsRow, error) {
+			return []sqlc.ListExpenseSplitsRow{{UserID: userID}, {UserID: friendID}}, nil
+		},
+		DeleteExpenseFunc: func(ctx context.Context, id pgtype.UUID) error {
+			deleted = true
+			return nil
+		},
+	}
+
+	svc := NewFriendExpenseService(repo, acceptedFriendRepo())
+	if err := svc.DeleteFriendExpense(context.Background(), expenseID, userID, friendID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !deleted {
+		t.Error("expected expense to be deleted")
+	}
+
+	svc = NewFriendExpenseService(repo, &MockFriendRepository{})
+	if err := svc.DeleteFriendExpense(context.Background(), expenseID, userID, friendID); err != ErrFriendNotFound {
+		t.Errorf("expected ErrFriendNotFound without a friendship, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 
@@ -17,6 +18,8 @@ type FriendSettlementService interface {
 	ListFriendSettlements(ctx context.Context, userID, friendID pgtype.UUID) ([]sqlc.ListFriendSettlementsRow, error)
 	GetFriendSettlementByID(ctx context.Context, settlementID, requesterID, friendID pgtype.UUID) (sqlc.Settlement, error)
 	UpdateFriendSettlementStatus(ctx context.Context, settlementID, requesterID, friendID pgtype.UUID, status string) (sqlc.Settlement, error)
+	UpdateFriendSettlement(ctx context.Context, settlementID, requesterID, friendID pgtype.UUID, input UpdateFriendSettlementInput) (sqlc.Settlement, error)
+	DeleteFriendSettlement(ctx context.Context, settlementID, requesterID, friendID pgtype.UUID) error
 }
 
 type CreateFriendSettlementInput struct {
@@ -30,6 +33,16 @@ type CreateFriendSettlementInput struct {
 	Notes                string
 }
 
+// UpdateFriendSettlementInput replaces the editable fields; payer and payee are fixed.
+type UpdateFriendSettlementInput struct {
+	Amount               string
+	CurrencyCode         string
+	Status               string
+	PaymentMethod        string
+	TransactionReference string
+	Notes                string
+}
+
 type friendSettlementService struct {
 	settlementRepo repository.SettlementRepository
 	friendRepo     repository.FriendRepository
@@ -119,6 +132,11 @@ func (s *friendSettlementService) CreateFriendSettlement(ctx context.Context, cr
 		return sqlc.Settlement{}, err
 	}
 
+	// Only the payee can record a payment as already received
+	if status == "completed" && input.PayeeID != creatorID {
+		return sqlc.Settlement{}, ErrSettlementForbidden
+	}
+
 	// Use default currency if not provided
 	currencyCode := strings.TrimSpace(input.CurrencyCode)
 	if currencyCode == "" {
@@ -190,18 +208,97 @@ func (s *friendSettlementService) GetFriendSettlementByID(ctx context.Context, s
 
 func (s *friendSettlementService) UpdateFriendSettlementStatus(ctx context.Context, settlementID, requesterID, friendID pgtype.UUID, status string) (sqlc.Settlement, error) {
 	// Validate settlement access
-	if _, err := s.GetFriendSettlementByID(ctx, settlementID, requesterID, friendID); err != nil {
+	settlement, err := s.GetFriendSettlementByID(ctx, settlementID, requesterID, friendID)
+	if err != nil {
+		return sqlc.Settlement{}, err
+	}
+
+	// Validate status and who may move it there
+	if err := s.validateStatus(status); err != nil {
 		return sqlc.Settlement{}, err
 	}
+	if err := checkSettlementTransition(settlement, status, requesterID); err != nil {
+		return sqlc.Settlement{}, err
+	}
+
+	// No row means someone else moved it first
+	updated, err := s.settlementRepo.UpdateSettlementStatus(ctx, sqlc.UpdateSettlementStatusParams{
+		Status:        status,
+		UpdatedBy:     requesterID,
+		ID:            settlementID,
+		CurrentStatus: settlement.Status,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.Settlement{}, ErrInvalidStatusTransition
+	}
+	return updated, err
+}
+
+func (s *friendSettlementService) UpdateFriendSettlement(ctx context.Context, settlementID, requesterID, friendID pgtype.UUID, input UpdateFriendSettlementInput) (sqlc.Settlement, error) {
+	settlement, err := s.getSharedFriendSettlement(ctx, settlementID, requesterID, friendID)
+	if err != nil {
+		return sqlc.Settlement{}, err
+	}
+
+	// Validate amount
+	amount, err := decimal.NewFromString(input.Amount)
+	if err != nil || amount.LessThanOrEqual(decimal.Zero) {
+		return sqlc.Settlement{}, ErrInvalidAmount
+	}
 
 	// Validate status
+	status := strings.TrimSpace(input.Status)
+	if status == "" {
+		status = settlement.Status
+	}
 	if err := s.validateStatus(status); err != nil {
 		return sqlc.Settlement{}, err
 	}
+	if status != settlement.Status {
+		if err := checkSettlementTransition(settlement, status, requesterID); err != nil {
+			return sqlc.Settlement{}, err
+		}
+	}
+
+	// Keep the current currency if not provided
+	currencyCode := strings.TrimSpace(input.CurrencyCode)
+	if currencyCode == "" {
+		currencyCode = settlement.CurrencyCode
+	}
+
+	amountNumeric, err := stringToNumeric(input.Amount)
+	if err != nil {
+		return sqlc.Settlement{}, ErrInvalidAmount
+	}
 
-	return s.settlementRepo.UpdateSettlementStatus(ctx, sqlc.UpdateSettlementStatusParams{
-		ID:        settlementID,
-		Status:    status,
-		UpdatedBy: requesterID,
+	return s.settlementRepo.UpdateSettlement(ctx, sqlc.UpdateSettlementParams{
+		ID:                   settlement.ID,
+		Amount:               amountNumeric,
+		CurrencyCode:         currencyCode,
+		Status:               status,
+		PaymentMethod:        pgtype.Text{String: input.PaymentMethod, Valid: input.PaymentMethod != ""},
+		TransactionReference: pgtype.Text{String: input.TransactionReference, Valid: input.TransactionReference != ""},
+		Notes:                pgtype.Text{String: input.Notes, Valid: input.Notes != ""},
+		UpdatedBy:            requesterID,
 	})
 }
+
+func (s *friendSettlementService) DeleteFriendSettlement(ctx context.Context, settlementID, requesterID, friendID pgtype.UUID) error {
+	if _, err := s.getSharedFriendSettlement(ctx, settlementID, requesterID, friendID); err != nil {
+		return err
+	}
+	return s.settlementRepo.DeleteSettlement(ctx, settlementID)
+}
+
+// getSharedFriendSettlement loads a friend settlement for modification and
+// checks it was made between the requester and this friend.
+func (s *friendSettlementService) getSharedFriendSettlement(ctx context.Context, settlementID, requesterID, friendID pgtype.UUID) (sqlc.Settlement, error) {
+	settlement, err := s.GetFriendSettlementByID(ctx, settlementID, requesterID, friendID)
+	if err != nil {
+		return sqlc.Settlement{}, err
+	}
+	if settlement.PayerID != friendID && settlement.PayeeID != friendID {
+		return sqlc.Settlement{}, ErrSettlementNotFound
+	}
+	return settlement, nil
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+func TestFriendSettlementService_UpdateFriendSettlement(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	friendID := testutil.CreateTestUUID(2)
+	otherID := testutil.CreateTestUUID(3)
+	settlementID := testutil.CreateTestUUID(100)
+
+	tests := []struct {
+		name          string
+		settlement    sqlc.Settlement
+		input         UpdateFriendSettlementInput
+		expectedError error
+		wantStatus    string
+	}{
+		{
+			name:       "keeps status and currency when omitted",
+			settlement: sqlc.Settlement{ID: settlementID, Type: "friend", PayerID: userID, PayeeID: friendID, Status: "completed", CurrencyCode: "EUR"},
+			input:      UpdateFriendSettlementInput{Amount: "25.00"},
+			wantStatus: "completed",
+		},
+		{
+			name:          "invalid amount",
+			settlement:    sqlc.Settlement{ID: settlementID, Type: "friend", PayerID: userID, PayeeID: friendID, Status: "pending"},
+			input:         UpdateFriendSettlementInput{Amount: "-1"},
+			expectedError: ErrInvalidAmount,
+		},
+		{
+			name:          "invalid status",
+			settlement:    sqlc.Settlement{ID: settlementID, Type: "friend", PayerID: userID, PayeeID: friendID, Status: "pending"},
+			input:         UpdateFriendSettlementInput{Amount: "10", Status: "done"},
+			expectedError: ErrInvalidStatus,
+		},
+		{
+			name:          "payer cannot mark their own payment completed",
+			settlement:    sqlc.Settlement{ID: settlementID, Type: "friend", PayerID: userID, PayeeID: friendID, Status: "pending"},
+			input:         UpdateFriendSettlementInput{Amount: "10", Status: "completed"},
+			expectedError: ErrSettlementForbidden,
+		},
+		{
+			name:       "payee confirms the payment",
+			settlement: sqlc.Settlement{ID: settlementID, Type: "friend", PayerID: friendID, PayeeID: userID, Status: "pending"},
+			input:      UpdateFriendSettlementInput{Amount: "10", Status: "completed"},
+			wantStatus: "completed",
+		},
+		{
+			name:          "completed settlement cannot be reopened",
+			settlement:    sqlc.Settlement{ID: settlementID, Type: "friend", PayerID: friendID, PayeeID: userID, Status: "completed"},
+			input:         UpdateFriendSettlementInput{Amount: "10", Status: "pending"},
+			expectedError: ErrInvalidStatusTransition,
+		},
+		{
+			name:          "settlement with a different friend is not found",
+			settlement:    sqlc.Settlement{ID: settlementID, Type: "friend", PayerID: userID, PayeeID: otherID, Status: "pending"},
+			input:         UpdateFriendSettlementInput{Amount: "10"},
+			expectedError: ErrSettlementNotFound,
+		},
+		{
+			name:          "group settlement is not found",
+			settlement:    sqlc.Settlement{ID: settlementID, Type: "group", PayerID: userID, PayeeID: friendID, Status: "pending"},
+			input:         UpdateFriendSettlementInput{Amount: "10"},
+			expectedError: ErrSettlementNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated sqlc.UpdateSettlementParams
+			repo := &MockSettlementRepository{
+				GetSettlementByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Settlement, error) {
+					return tt.settlement, nil
+				},
+				UpdateSettlementFunc: func(ctx context.Context, params sqlc.UpdateSettlementParams) (sqlc.Settlement, error) {
+					updated = params
+					return sqlc.Settlement{ID: params.ID, Status: params.Status}, nil
+				},
+			}
+
+			svc := NewFriendSettlementService(repo, acceptedFriendRepo())
+			_, err := svc.UpdateFriendSettlement(context.Background(), settlementID, userID, friendID, tt.input)
+
+			if err != tt.expectedError {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if tt.expectedError != nil {
+				return
+			}
+
+			if updated.Status != tt.wantStatus {
+				t.Errorf("expected status %q, got %q", tt.wantStatus, updated.Status)
+			}
+			if updated.CurrencyCode != tt.settlement.CurrencyCode {
+				t.Errorf("expected currency %q, got %q", tt.settlement.CurrencyCode, updated.CurrencyCode)
+			}
+			if updated.UpdatedBy != userID {
+				t.Errorf("expected updated_by to be the requester")
+			}
+		})
+	}
+}
+
+func TestFriendSettlementService_DeleteFriendSettlement(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	friendID := testutil.CreateTestUUID(2)
+	settlementID := testutil.CreateTestUUID(100)
+
+	deleted := false
+	repo := &MockSettlementRepository{
+		GetSettlementByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Settlement, error) {
+			return sqlc.Settlement{ID: id, Type: "friend", PayerID: friendID, PayeeID: userID}, nil
+		},
+		DeleteSettlementFunc: func(ctx context.Context, id pgtype.UUID) error {
+			deleted = true
+			return nil
+		},
+	}
+
+	svc := NewFriendSettlementService(repo, acceptedFriendRepo())
+	if err := svc.DeleteFriendSettlement(context.Background(), settlementID, userID, friendID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !deleted {
+		t.Error("expected settlement to be deleted")
+	}
+}
+
+func TestFriendSettlementService_CreateFriendSettlement_Completed(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	friendID := testutil.CreateTestUUID(2)
+
+	tests := []struct {
+		name          string
+		payerID       pgtype.UUID
+		payeeID       pgtype.UUID
+		expectedError error
+	}{
+		{name: "payee records a received payment", payerID: friendID, payeeID: userID},
+		{name: "payer cannot record it as received", payerID: userID, payeeID: friendID, expectedError: ErrSettlementForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			repo := &MockSettlementRepository{
+				CreateSettlementFunc: func(ctx context.Context, params sqlc.CreateSettlementParams) (sqlc.Settlement, error) {
+					created = true
+					return sqlc.Settlement{Status: params.Status}, nil
+				},
+			}
+
+			svc := NewFriendSettlementService(repo, acceptedFriendRepo())
+			_, err := svc.CreateFriendSettlement(context.Background(), userID, friendID, CreateFriendSettlementInput{
+				PayerID: tt.payerID,
+				PayeeID: tt.payeeID,
+				Amount:  "10",
+				Status:  "completed",
+			})
+
+			if err != tt.expectedError {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if created != (tt.expectedError == nil) {
+				t.Errorf("expected created=%v", tt.expectedError == nil)
+			}
+		})
+	}
+}
+
+func TestFriendSettlementService_UpdateFriendSettlementStatus(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	friendID := testutil.CreateTestUUID(2)
+	settlementID := testutil.CreateTestUUID(100)
+
+	tests := []struct {
+		name          string
+		settlement    sqlc.Settlement
+		status        string
+		expectedError error
+	}{
+		{
+			name:       "payee confirms",
+			settlement: sqlc.Settlement{ID: settlementID, Type: "friend", PayerID: friendID, PayeeID: userID, Status: "pending"},
+			status:     "completed",
+		},
+		{
+			name:          "payer cannot confirm",
+			settlement:    sqlc.Settlement{ID: settlementID, Type: "friend", PayerID: userID, PayeeID: friendID, Status: "pending"},
+			status:        "completed",
+			expectedError: ErrSettlementForbidden,
+		},
+		{
+			name:          "confirmed settlement cannot be undone",
+			settlement:    sqlc.Settlement{ID: settlementID, Type: "friend", PayerID: userID, PayeeID: friendID, Status: "completed"},
+			status:        "cancelled",
+			expectedError: ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := false
+			repo := &MockSettlementRepository{
+				GetSettlementByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Settlement, error) {
+					return tt.settlement, nil
+				},
+				UpdateSettlementStatusFunc: func(ctx context.Context, params sqlc.UpdateSettlementStatusParams) (sqlc.Settlement, error) {
+					updated = true
+					return sqlc.Settlement{ID: params.ID, Status: params.Status}, nil
+				},
+			}
+
+			svc := NewFriendSettlementService(repo, acceptedFriendRepo())
+			_, err := svc.UpdateFriendSettlementStatus(context.Background(), settlementID, userID, friendID, tt.status)
+
+			if err != tt.expectedError {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if updated != (tt.expectedError == nil) {
+				t.Errorf("expected updated=%v", tt.expectedError == nil)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/repository"
@@ -24,12 +25,19 @@ type GroupActivityService interface {
 	GetExpenseHistory(ctx context.Context, expenseID pgtype.UUID) ([]sqlc.GetExpenseHistoryRow, error)
 }
 
+// ActivityListener is told about every activity once it is logged, so other
+// features can react to group events without each service calling them.
+type ActivityListener interface {
+	ActivityLogged(ctx context.Context, activity sqlc.GroupActivity) error
+}
+
 type groupActivityService struct {
-	repo repository.GroupActivityRepository
+	repo      repository.GroupActivityRepository
+	listeners []ActivityListener
 }
 
-func NewGroupActivityService(repo repository.GroupActivityRepository) GroupActivityService {
-	return &groupActivityService{repo: repo}
+func NewGroupActivityService(repo repository.GroupActivityRepository, listeners ...ActivityListener) GroupActivityService {
+	return &groupActivityService{repo: repo, listeners: listeners}
 }
 
 func (s *groupActivityService) LogActivity(ctx context.Context, input LogActivityInput) error {
@@ -38,7 +46,7 @@ func (s *groupActivityService) LogActivity(ctx context.Context, input LogActivit
 		return err
 	}
 
-	_, err = s.repo.CreateActivity(ctx, sqlc.CreateGroupActivityParams{
+	activity, err := s.repo.CreateActivity(ctx, sqlc.CreateGroupActivityParams{
 		GroupID:    input.GroupID,
 		UserID:     input.UserID,
 		Action:     input.Action,
@@ -46,8 +54,17 @@ func (s *groupActivityService) LogActivity(ctx context.Context, input LogActivit
 		EntityID:   input.EntityID,
 		Metadata:   metadataJSON,
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	var errs []error
+	for _, listener := range s.listeners {
+		if err := listener.ActivityLogged(ctx, activity); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func (s *groupActivityService) ListGroupActivities(ctx context.Context, groupID pgtype.UUID, limit, offset int32) ([]sqlc.ListGroupActivitiesRow, error) {
@@ -216,6 +216,40 @@ func TestGroupActivityService_LogActivity(t *testing.T) {
 	}
 }
 
+// activityListenerFunc adapts a function to ActivityListener.
+type activityListenerFunc func(ctx context.Context, activity sqlc.GroupActivity) error
+
+func (f activityListenerFunc) ActivityLogged(ctx context.Context, activity sqlc.GroupActivity) error {
+	return f(ctx, activity)
+}
+
+func TestGroupActivityService_LogActivity_NotifiesListeners(t *testing.T) {
+	repo := &MockGroupActivityRepository{
+		CreateActivityFunc: func(ctx context.Context, params sqlc.CreateGroupActivityParams) (sqlc.GroupActivity, error) {
+			return sqlc.GroupActivity{ID: testutil.CreateTestUUID(1), Action: params.Action}, nil
+		},
+	}
+
+	var notified []string
+	ok := activityListenerFunc(func(ctx context.Context, activity sqlc.GroupActivity) error {
+		notified = append(notified, activity.Action)
+		return nil
+	})
+	failing := activityListenerFunc(func(ctx context.Context, activity sqlc.GroupActivity) error {
+		return errors.New("listener down")
+	})
+
+	service := NewGroupActivityService(repo, failing, ok)
+	err := service.LogActivity(context.Background(), LogActivityInput{Action: "expense_created"})
+
+	if err == nil || err.Error() != "listener down" {
+		t.Errorf("expected the listener error, got %v", err)
+	}
+	if len(notified) != 1 || notified[0] != "expense_created" {
+		t.Errorf("expected later listeners to still be notified, got %v", notified)
+	}
+}
+
 func TestGroupActivityService_ListGroupActivities(t *testing.T) {
 	tests := []struct {
 		name          string
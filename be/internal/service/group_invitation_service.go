@@ -11,6 +11,7 @@ import (
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -20,6 +21,12 @@ var (
 	ErrInvitationEmailMismatch            = errors.New("logged in user email does not match invitation email")
 	ErrPasswordRequiredForExistingAccount = errors.New("password required for existing account")
 	ErrPasswordRequiredToCreateAccount    = errors.New("password required to create account")
+	ErrInvitationNotPending               = errors.New("invitation is no longer pending")
+	ErrEmailRequiredForJoinLink           = errors.New("email required to join with a link")
+	ErrJoinLinkNotFound                   = errors.New("join link not found")
+	ErrJoinLinkRevoked                    = errors.New("join link already revoked")
+	ErrInvalidJoinLinkMaxUses             = errors.New("max uses must be positive")
+	ErrInvalidJoinLinkExpiry              = errors.New("join link expiry must be between 1 hour and 30 days")
 )
 
 type CreateInvitationInput struct {
@@ -37,6 +44,7 @@ type AcceptInvitationInput struct {
 
 type JoinGroupInput struct {
 	Token               string
+	Email               string      // Required for join links if not authenticated
 	Password            string      // Required if not authenticated
 	Name                string      // Optional for new users
 	AuthenticatedUserID pgtype.UUID // Present if user is already logged in
@@ -47,48 +55,88 @@ type InvitationDetails struct {
 	GroupName    string
 	InviterName  string
 	InviterEmail string
+	// JoinLink is set when the token belongs to a shareable join link rather
+	// than an emailed invitation; Invitation.Email is then empty.
+	JoinLink bool
+}
+
+type CreateJoinLinkInput struct {
+	GroupID   pgtype.UUID
+	CreatedBy pgtype.UUID
+	MaxUses   *int32        // nil for unlimited
+	ExpiresIn time.Duration // zero uses joinLinkDefaultTTL
 }
 
 type GroupInvitationService interface {
-	CreateInvitation(ctx context.Context, input CreateInvitationInput) (string, error)
+	// CreateInvitation records the invitation and queues the invitation email; the token is only sent by email.
+	CreateInvitation(ctx context.Context, input CreateInvitationInput) (sqlc.GroupInvitation, error)
 	GetInvitation(ctx context.Context, token string) (InvitationDetails, error)
 	AcceptInvitation(ctx context.Context, input AcceptInvitationInput) (sqlc.GroupMember, error)
 	JoinGroup(ctx context.Context, input JoinGroupInput) (sqlc.User, sqlc.GroupMember, error)
 	ListPendingInvitations(ctx context.Context, email string) ([]sqlc.GetPendingInvitationsByEmailRow, error)
+
+	// Invitation management (owner/admin)
+	ListGroupInvitations(ctx context.Context, groupID, requesterID pgtype.UUID) ([]sqlc.ListInvitationsByGroupRow, error)
+	// ResendInvitation issues a new token and expiry, invalidating the old link, and emails it again.
+	ResendInvitation(ctx context.Context, groupID, invitationID, requesterID pgtype.UUID) (sqlc.GroupInvitation, error)
+	RevokeInvitation(ctx context.Context, groupID, invitationID, requesterID pgtype.UUID) error
+
+	// Shareable join links (owner/admin)
+	CreateJoinLink(ctx context.Context, input CreateJoinLinkInput) (sqlc.GroupJoinLink, error)
+	ListJoinLinks(ctx context.Context, groupID, requesterID pgtype.UUID) ([]sqlc.GroupJoinLink, error)
+	RevokeJoinLink(ctx context.Context, groupID, linkID, requesterID pgtype.UUID) error
 }
 
+const (
+	invitationTTL = 7 * 24 * time.Hour
+
+	joinLinkDefaultTTL = 7 * 24 * time.Hour
+	joinLinkMinTTL     = time.Hour
+	joinLinkMaxTTL     = 30 * 24 * time.Hour
+)
+
 type groupInvitationService struct {
-	invRepo     repository.GroupInvitationRepository
-	pendingRepo repository.PendingUserRepository
-	groupRepo   repository.GroupRepository
-	userRepo    repository.UserRepository
-	userService UserService
+	invRepo         repository.GroupInvitationRepository
+	pendingRepo     repository.PendingUserRepository
+	groupRepo       repository.GroupRepository
+	userRepo        repository.UserRepository
+	userService     UserService
+	activityService GroupActivityService
+	emailService    EmailService
+	baseURL         string
 }
 
+// NewGroupInvitationService builds invitation links in emails from baseURL, the frontend origin.
 func NewGroupInvitationService(
 	invRepo repository.GroupInvitationRepository,
 	pendingRepo repository.PendingUserRepository,
 	groupRepo repository.GroupRepository,
 	userRepo repository.UserRepository,
 	userService UserService,
+	activityService GroupActivityService,
+	emailService EmailService,
+	baseURL string,
 ) GroupInvitationService {
 	return &groupInvitationService{
-		invRepo:     invRepo,
-		pendingRepo: pendingRepo,
-		groupRepo:   groupRepo,
-		userRepo:    userRepo,
-		userService: userService,
+		invRepo:         invRepo,
+		pendingRepo:     pendingRepo,
+		groupRepo:       groupRepo,
+		userRepo:        userRepo,
+		userService:     userService,
+		activityService: activityService,
+		emailService:    emailService,
+		baseURL:         strings.TrimRight(baseURL, "/"),
 	}
 }
 
-func (s *groupInvitationService) CreateInvitation(ctx context.Context, input CreateInvitationInput) (string, error) {
+func (s *groupInvitationService) CreateInvitation(ctx context.Context, input CreateInvitationInput) (sqlc.GroupInvitation, error) {
 	// 1. Check permissions (inviter must be member)
-	_, err := s.groupRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+	inviter, err := s.groupRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
 		GroupID: input.GroupID,
 		UserID:  input.InvitedBy,
 	})
-	if err != nil {
-		return "", ErrNotGroupMember
+	if err != nil || inviter.Status == "inactive" {
+		return sqlc.GroupInvitation{}, ErrNotGroupMember
 	}
 	// TODO: restrict role assignment based on inviter's role? (e.g. only admin can invite admins)
 	// For now, allow any member to invite others as 'member'.
@@ -107,18 +155,24 @@ func (s *groupInvitationService) CreateInvitation(ctx context.Context, input Cre
 		Name:  pgtype.Text{String: input.Name, Valid: input.Name != ""},
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to create pending user: %w", err)
+		return sqlc.GroupInvitation{}, fmt.Errorf("failed to create pending user: %w", err)
 	}
 
 	// 3. Generate Token
 	token, err := generateToken(32)
 	if err != nil {
-		return "", err
+		return sqlc.GroupInvitation{}, err
+	}
+
+	// 4. Create Invitation, together with its email so neither exists without the other
+	tx, err := s.invRepo.BeginTx(ctx)
+	if err != nil {
+		return sqlc.GroupInvitation{}, err
 	}
+	defer tx.Rollback(ctx)
 
-	// 4. Create Invitation
-	expiresAt := time.Now().Add(7 * 24 * time.Hour) // 7 days expiry
-	_, err = s.invRepo.CreateInvitation(ctx, sqlc.CreateInvitationParams{
+	expiresAt := time.Now().Add(invitationTTL)
+	invitation, err := s.invRepo.WithTx(tx).CreateInvitation(ctx, sqlc.CreateInvitationParams{
 		GroupID:   input.GroupID,
 		Email:     email,
 		Token:     token,
@@ -128,27 +182,251 @@ func (s *groupInvitationService) CreateInvitation(ctx context.Context, input Cre
 		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to create invitation: %w", err)
+		return sqlc.GroupInvitation{}, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	// 5. Queue the invitation email; the worker delivers it
+	if err := s.sendInvitationEmail(ctx, tx, invitation); err != nil {
+		return sqlc.GroupInvitation{}, fmt.Errorf("failed to queue invitation email: %w", err)
 	}
 
-	// 5. Send Email (Mock)
-	// In real app, call email service here.
-	// For now, just logging or doing nothing.
-	return token, nil
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.GroupInvitation{}, err
+	}
+	return invitation, nil
 }
 
-func (s *groupInvitationService) GetInvitation(ctx context.Context, token string) (InvitationDetails, error) {
-	inv, err := s.invRepo.GetInvitationByToken(ctx, token)
+// sendInvitationEmail queues the email in tx, the invitation's transaction. It
+// writes in the invitee's language when they already have an account, and
+// otherwise in the inviter's, who likely shares it.
+func (s *groupInvitationService) sendInvitationEmail(ctx context.Context, tx pgx.Tx, invitation sqlc.GroupInvitation) error {
+	group, err := s.groupRepo.GetGroupByID(ctx, invitation.GroupID)
+	if err != nil {
+		return err
+	}
+
+	inviter, err := s.userRepo.GetUserByID(ctx, invitation.InvitedBy)
+	if err != nil {
+		return err
+	}
+
+	language := inviter.Language
+	if invitee, err := s.userRepo.GetUserByEmail(ctx, invitation.Email); err == nil && invitee.Language != "" {
+		language = invitee.Language
+	}
+
+	_, err = s.emailService.WithTx(tx).Enqueue(ctx, EnqueueEmailInput{
+		To:       invitation.Email,
+		Template: EmailTemplateGroupInvitation,
+		Language: language,
+		Data: GroupInvitationEmailData{
+			InviterName: displayName(inviter),
+			GroupName:   group.Name,
+			Link:        s.baseURL + "/invite/" + invitation.Token,
+			ExpiresIn:   int(invitationTTL.Hours() / 24),
+		},
+	})
+	return err
+}
+
+func (s *groupInvitationService) ListGroupInvitations(ctx context.Context, groupID, requesterID pgtype.UUID) ([]sqlc.ListInvitationsByGroupRow, error) {
+	if err := s.requireInvitationManager(ctx, groupID, requesterID); err != nil {
+		return nil, err
+	}
+	return s.invRepo.ListInvitationsByGroup(ctx, groupID)
+}
+
+func (s *groupInvitationService) ResendInvitation(ctx context.Context, groupID, invitationID, requesterID pgtype.UUID) (sqlc.GroupInvitation, error) {
+	if err := s.requireInvitationManager(ctx, groupID, requesterID); err != nil {
+		return sqlc.GroupInvitation{}, err
+	}
+
+	invitation, err := s.getGroupInvitation(ctx, groupID, invitationID)
+	if err != nil {
+		return sqlc.GroupInvitation{}, err
+	}
+	// Expired invitations keep status "pending", so they can be re-issued too
+	if invitation.Status != "pending" {
+		return sqlc.GroupInvitation{}, ErrInvitationNotPending
+	}
+
+	token, err := generateToken(32)
+	if err != nil {
+		return sqlc.GroupInvitation{}, err
+	}
+
+	tx, err := s.invRepo.BeginTx(ctx)
+	if err != nil {
+		return sqlc.GroupInvitation{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	invitation, err = s.invRepo.WithTx(tx).RenewInvitation(ctx, sqlc.RenewInvitationParams{
+		ID:        invitation.ID,
+		Token:     token,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(invitationTTL), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.GroupInvitation{}, ErrInvitationNotPending
+		}
+		return sqlc.GroupInvitation{}, fmt.Errorf("failed to renew invitation: %w", err)
+	}
+
+	if err := s.sendInvitationEmail(ctx, tx, invitation); err != nil {
+		return sqlc.GroupInvitation{}, fmt.Errorf("failed to queue invitation email: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.GroupInvitation{}, err
+	}
+	return invitation, nil
+}
+
+func (s *groupInvitationService) RevokeInvitation(ctx context.Context, groupID, invitationID, requesterID pgtype.UUID) error {
+	if err := s.requireInvitationManager(ctx, groupID, requesterID); err != nil {
+		return err
+	}
+
+	invitation, err := s.getGroupInvitation(ctx, groupID, invitationID)
 	if err != nil {
-		return InvitationDetails{}, ErrInvitationNotFound
+		return err
+	}
+	if invitation.Status != "pending" {
+		return ErrInvitationNotPending
+	}
+
+	_, err = s.invRepo.UpdateInvitationStatus(ctx, sqlc.UpdateInvitationStatusParams{
+		ID:     invitation.ID,
+		Status: "revoked",
+	})
+	return err
+}
+
+func (s *groupInvitationService) CreateJoinLink(ctx context.Context, input CreateJoinLinkInput) (sqlc.GroupJoinLink, error) {
+	if err := s.requireInvitationManager(ctx, input.GroupID, input.CreatedBy); err != nil {
+		return sqlc.GroupJoinLink{}, err
+	}
+
+	maxUses := pgtype.Int4{}
+	if input.MaxUses != nil {
+		if *input.MaxUses <= 0 {
+			return sqlc.GroupJoinLink{}, ErrInvalidJoinLinkMaxUses
+		}
+		maxUses = pgtype.Int4{Int32: *input.MaxUses, Valid: true}
+	}
+
+	ttl := input.ExpiresIn
+	if ttl == 0 {
+		ttl = joinLinkDefaultTTL
+	}
+	if ttl < joinLinkMinTTL || ttl > joinLinkMaxTTL {
+		return sqlc.GroupJoinLink{}, ErrInvalidJoinLinkExpiry
+	}
+
+	token, err := generateToken(32)
+	if err != nil {
+		return sqlc.GroupJoinLink{}, err
+	}
+
+	link, err := s.invRepo.CreateJoinLink(ctx, sqlc.CreateJoinLinkParams{
+		GroupID:   input.GroupID,
+		Token:     token,
+		MaxUses:   maxUses,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(ttl), Valid: true},
+		CreatedBy: input.CreatedBy,
+	})
+	if err != nil {
+		return sqlc.GroupJoinLink{}, fmt.Errorf("failed to create join link: %w", err)
+	}
+	return link, nil
+}
+
+func (s *groupInvitationService) ListJoinLinks(ctx context.Context, groupID, requesterID pgtype.UUID) ([]sqlc.GroupJoinLink, error) {
+	if err := s.requireInvitationManager(ctx, groupID, requesterID); err != nil {
+		return nil, err
+	}
+	return s.invRepo.ListJoinLinksByGroup(ctx, groupID)
+}
+
+func (s *groupInvitationService) RevokeJoinLink(ctx context.Context, groupID, linkID, requesterID pgtype.UUID) error {
+	if err := s.requireInvitationManager(ctx, groupID, requesterID); err != nil {
+		return err
+	}
+
+	link, err := s.invRepo.GetJoinLinkByID(ctx, linkID)
+	if err != nil || link.GroupID != groupID {
+		return ErrJoinLinkNotFound
+	}
+
+	if _, err := s.invRepo.RevokeJoinLink(ctx, link.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrJoinLinkRevoked
+		}
+		return err
+	}
+	return nil
+}
+
+// requireInvitationManager verifies the requester is an active owner or admin of the group.
+func (s *groupInvitationService) requireInvitationManager(ctx context.Context, groupID, requesterID pgtype.UUID) error {
+	if _, err := s.groupRepo.GetGroupByID(ctx, groupID); err != nil {
+		return ErrGroupNotFound
+	}
+
+	member, err := s.groupRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+		GroupID: groupID,
+		UserID:  requesterID,
+	})
+	if err != nil || member.Status != "active" {
+		return ErrNotGroupMember
+	}
+	if member.Role != "owner" && member.Role != "admin" {
+		return ErrInsufficientPermissions
 	}
+	return nil
+}
 
-	details := InvitationDetails{
-		Invitation: inv,
-		GroupName:  inv.GroupName,
+// getGroupInvitation loads an invitation by ID, treating one from another group as missing.
+func (s *groupInvitationService) getGroupInvitation(ctx context.Context, groupID, invitationID pgtype.UUID) (sqlc.GroupInvitation, error) {
+	invitation, err := s.invRepo.GetInvitationByID(ctx, invitationID)
+	if err != nil || invitation.GroupID != groupID {
+		return sqlc.GroupInvitation{}, ErrInvitationNotFound
 	}
+	return invitation, nil
+}
 
-	inviter, err := s.userRepo.GetUserByID(ctx, inv.InvitedBy)
+func (s *groupInvitationService) GetInvitation(ctx context.Context, token string) (InvitationDetails, error) {
+	var details InvitationDetails
+	inv, err := s.invRepo.GetInvitationByToken(ctx, token)
+	if err == nil {
+		details = InvitationDetails{
+			Invitation: inv,
+			GroupName:  inv.GroupName,
+		}
+	} else {
+		link, linkErr := s.invRepo.GetJoinLinkByToken(ctx, token)
+		if linkErr != nil {
+			return InvitationDetails{}, ErrInvitationNotFound
+		}
+		details = InvitationDetails{
+			Invitation: sqlc.GetInvitationByTokenRow{
+				ID:        link.ID,
+				GroupID:   link.GroupID,
+				Token:     link.Token,
+				Role:      "member",
+				Status:    "pending",
+				InvitedBy: link.CreatedBy,
+				ExpiresAt: link.ExpiresAt,
+				CreatedAt: link.CreatedAt,
+				GroupName: link.GroupName,
+			},
+			GroupName: link.GroupName,
+			JoinLink:  true,
+		}
+	}
+
+	inviter, err := s.userRepo.GetUserByID(ctx, details.Invitation.InvitedBy)
 	if err == nil {
 		details.InviterEmail = inviter.Email
 		if inviter.Name.Valid {
@@ -160,18 +438,23 @@ func (s *groupInvitationService) GetInvitation(ctx context.Context, token string
 }
 
 func (s *groupInvitationService) AcceptInvitation(ctx context.Context, input AcceptInvitationInput) (sqlc.GroupMember, error) {
-	// 1. Get Invitation
+	// 1. Get Invitation, falling back to a shareable join link
 	inv, err := s.invRepo.GetInvitationByToken(ctx, input.Token)
 	if err != nil {
-		return sqlc.GroupMember{}, ErrInvitationNotFound
+		link, linkErr := s.invRepo.GetJoinLinkByToken(ctx, input.Token)
+		if linkErr != nil {
+			return sqlc.GroupMember{}, ErrInvitationNotFound
+		}
+		return s.acceptJoinLink(ctx, link, input.UserID)
 	}
 
-	// 2. Check if user is already member
-	_, err = s.groupRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+	// 2. Check if user is already member (former members who left can rejoin)
+	existing, err := s.groupRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
 		GroupID: inv.GroupID,
 		UserID:  input.UserID,
 	})
-	if err == nil {
+	rejoining := err == nil && existing.Status == "inactive"
+	if err == nil && !rejoining {
 		// Already member
 		// Update invitation to accepted anyway? Or return error?
 		// If return error, frontend handles "You are already a member".
@@ -193,19 +476,15 @@ func (s *groupInvitationService) AcceptInvitation(ctx context.Context, input Acc
 	qTx := s.groupRepo.WithTx(tx)
 	invTx := s.invRepo.WithTx(tx)
 
-	// Add Member
-	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
-	member, err := qTx.CreateGroupMember(ctx, sqlc.CreateGroupMemberParams{
+	member, err := addGroupMember(ctx, qTx, rejoining, sqlc.CreateGroupMemberParams{
 		GroupID:   inv.GroupID,
 		UserID:    input.UserID,
 		Role:      inv.Role,
-		Status:    "active",
 		InvitedBy: inv.InvitedBy,
 		InvitedAt: inv.CreatedAt,
-		JoinedAt:  now,
 	})
 	if err != nil {
-		return sqlc.GroupMember{}, fmt.Errorf("failed to add member: %w", err)
+		return sqlc.GroupMember{}, err
 	}
 
 	// Update Invitation
@@ -270,6 +549,109 @@ func (s *groupInvitationService) AcceptInvitation(ctx context.Context, input Acc
 		return sqlc.GroupMember{}, err
 	}
 
+	s.logMemberJoined(ctx, member, "invitation")
+
+	return member, nil
+}
+
+// acceptJoinLink adds the user through a shareable link. Each join uses up one
+// of the link's uses; members who are already active don't consume one. Link
+// joins carry no email, so pending-user expenses are not claimed here.
+func (s *groupInvitationService) acceptJoinLink(ctx context.Context, link sqlc.GetJoinLinkByTokenRow, userID pgtype.UUID) (sqlc.GroupMember, error) {
+	existing, err := s.groupRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+		GroupID: link.GroupID,
+		UserID:  userID,
+	})
+	rejoining := err == nil && existing.Status == "inactive"
+	if err == nil && !rejoining {
+		return sqlc.GroupMember{}, ErrAlreadyMember
+	}
+
+	tx, err := s.groupRepo.BeginTx(ctx)
+	if err != nil {
+		return sqlc.GroupMember{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Consuming first means concurrent joins can't exceed max_uses
+	if _, err := s.invRepo.WithTx(tx).ConsumeJoinLink(ctx, link.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// The link was used up or revoked meanwhile, or its group is gone
+			group, groupErr := s.groupRepo.GetGroupByID(ctx, link.GroupID)
+			if errors.Is(groupErr, pgx.ErrNoRows) || (groupErr == nil && group.ArchivedAt.Valid) {
+				return sqlc.GroupMember{}, ErrGroupNotFound
+			}
+			return sqlc.GroupMember{}, ErrInvitationNotFound
+		}
+		return sqlc.GroupMember{}, fmt.Errorf("failed to use join link: %w", err)
+	}
+
+	member, err := addGroupMember(ctx, s.groupRepo.WithTx(tx), rejoining, sqlc.CreateGroupMemberParams{
+		GroupID:   link.GroupID,
+		UserID:    userID,
+		Role:      "member",
+		InvitedBy: link.CreatedBy,
+		InvitedAt: link.CreatedAt,
+	})
+	if err != nil {
+		return sqlc.GroupMember{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.GroupMember{}, err
+	}
+
+	s.logMemberJoined(ctx, member, "join_link")
+
+	return member, nil
+}
+
+// logMemberJoined records the join in the group's activity; via is how the
+// member got in, "invitation" or "join_link".
+func (s *groupInvitationService) logMemberJoined(ctx context.Context, member sqlc.GroupMember, via string) {
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    member.GroupID,
+		UserID:     member.UserID,
+		Action:     "member_joined",
+		EntityType: "member",
+		EntityID:   member.ID,
+		Metadata: map[string]interface{}{
+			"user_id": uuidToString(member.UserID),
+			"role":    member.Role,
+			"via":     via,
+		},
+	})
+}
+
+// addGroupMember creates the membership, or reactivates the previous one so
+// history stays attached.
+func addGroupMember(ctx context.Context, qTx repository.GroupRepository, rejoining bool, params sqlc.CreateGroupMemberParams) (sqlc.GroupMember, error) {
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	var member sqlc.GroupMember
+	var err error
+	if rejoining {
+		if _, err = qTx.UpdateGroupMemberRole(ctx, sqlc.UpdateGroupMemberRoleParams{
+			GroupID: params.GroupID,
+			UserID:  params.UserID,
+			Role:    params.Role,
+		}); err != nil {
+			return sqlc.GroupMember{}, fmt.Errorf("failed to restore member role: %w", err)
+		}
+		member, err = qTx.UpdateGroupMemberStatus(ctx, sqlc.UpdateGroupMemberStatusParams{
+			GroupID:  params.GroupID,
+			UserID:   params.UserID,
+			Status:   "active",
+			JoinedAt: now,
+		})
+	} else {
+		params.Status = "active"
+		params.JoinedAt = now
+		member, err = qTx.CreateGroupMember(ctx, params)
+	}
+	if err != nil {
+		return sqlc.GroupMember{}, fmt.Errorf("failed to add member: %w", err)
+	}
 	return member, nil
 }
 
@@ -278,10 +660,14 @@ func (s *groupInvitationService) ListPendingInvitations(ctx context.Context, ema
 }
 
 func (s *groupInvitationService) JoinGroup(ctx context.Context, input JoinGroupInput) (sqlc.User, sqlc.GroupMember, error) {
-	// 1. Get Invitation
+	// 1. Get Invitation, falling back to a shareable join link
 	inv, err := s.invRepo.GetInvitationByToken(ctx, input.Token)
 	if err != nil {
-		return sqlc.User{}, sqlc.GroupMember{}, ErrInvitationNotFound
+		link, linkErr := s.invRepo.GetJoinLinkByToken(ctx, input.Token)
+		if linkErr != nil {
+			return sqlc.User{}, sqlc.GroupMember{}, ErrInvitationNotFound
+		}
+		return s.joinWithLink(ctx, link, input)
 	}
 
 	var user sqlc.User
@@ -349,6 +735,61 @@ func (s *groupInvitationService) JoinGroup(ctx context.Context, input JoinGroupI
 	return user, member, nil
 }
 
+// joinWithLink is JoinGroup for shareable links. A link isn't tied to an email,
+// so anonymous callers supply their own to sign in or register.
+func (s *groupInvitationService) joinWithLink(ctx context.Context, link sqlc.GetJoinLinkByTokenRow, input JoinGroupInput) (sqlc.User, sqlc.GroupMember, error) {
+	var user sqlc.User
+	var err error
+
+	if input.AuthenticatedUserID.Valid {
+		user, err = s.userRepo.GetUserByID(ctx, input.AuthenticatedUserID)
+		if err != nil {
+			return sqlc.User{}, sqlc.GroupMember{}, ErrUserNotFound
+		}
+	} else {
+		email := strings.ToLower(strings.TrimSpace(input.Email))
+		if email == "" {
+			return sqlc.User{}, sqlc.GroupMember{}, ErrEmailRequiredForJoinLink
+		}
+
+		if _, err := s.userRepo.GetUserByEmail(ctx, email); err == nil {
+			if input.Password == "" {
+				return sqlc.User{}, sqlc.GroupMember{}, ErrPasswordRequiredForExistingAccount
+			}
+			user, err = s.userService.AuthenticateUser(ctx, email, input.Password)
+			if err != nil {
+				return sqlc.User{}, sqlc.GroupMember{}, err
+			}
+		} else {
+			if input.Password == "" {
+				return sqlc.User{}, sqlc.GroupMember{}, ErrPasswordRequiredToCreateAccount
+			}
+			name := input.Name
+			if name == "" {
+				name = strings.Split(email, "@")[0]
+			}
+			user, err = s.userService.CreateUser(ctx, name, email, input.Password)
+			if err != nil {
+				return sqlc.User{}, sqlc.GroupMember{}, err
+			}
+		}
+	}
+
+	member, err := s.acceptJoinLink(ctx, link, user.ID)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyMember) {
+			m, _ := s.groupRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+				GroupID: link.GroupID,
+				UserID:  user.ID,
+			})
+			return user, m, nil
+		}
+		return sqlc.User{}, sqlc.GroupMember{}, err
+	}
+
+	return user, member, nil
+}
+
 func generateToken(length int) (string, error) {
 	bytes := make([]byte, length)
 	if _, err := rand.Read(bytes); err != nil {
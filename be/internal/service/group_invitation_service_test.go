@@ -25,7 +25,7 @@ func TestGroupInvitationService_CreateInvitation(t *testing.T) {
 		input         CreateInvitationInput
 		mockSetup     func(*testutil.MockGroupInvitationRepository, *testutil.MockPendingUserRepository, *testutil.MockGroupRepository, *testutil.MockUserRepository)
 		expectedError error
-		validate      func(*testing.T, string)
+		validate      func(*testing.T, sqlc.GroupInvitation, []EnqueueEmailInput)
 	}{
 		{
 			name: "successful invitation - existing user",
@@ -49,6 +49,16 @@ func TestGroupInvitationService_CreateInvitation(t *testing.T) {
 					return sqlc.PendingUser{ID: testutil.CreateTestUUID(200), Email: params.Email}, nil
 				}
 				
+				mockGroup.GetGroupByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+					return sqlc.Group{ID: id, Name: "Trip"}, nil
+				}
+				mockUser.GetUserByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+					return sqlc.User{ID: id, Email: "owner@example.com", Name: pgtype.Text{String: "Olivia", Valid: true}, Language: "es"}, nil
+				}
+				mockUser.GetUserByEmailFunc = func(ctx context.Context, email string) (sqlc.User, error) {
+					return sqlc.User{}, pgx.ErrNoRows
+				}
+
 				// Mock creating invitation
 				mockInv.CreateInvitationFunc = func(ctx context.Context, params sqlc.CreateInvitationParams) (sqlc.GroupInvitation, error) {
 					return sqlc.GroupInvitation{
@@ -62,10 +72,31 @@ func TestGroupInvitationService_CreateInvitation(t *testing.T) {
 				}
 			},
 			expectedError: nil,
-			validate: func(t *testing.T, token string) {
-				if token == "" {
+			validate: func(t *testing.T, invitation sqlc.GroupInvitation, emails []EnqueueEmailInput) {
+				if invitation.Token == "" {
 					t.Errorf("expected token, got empty string")
 				}
+				if len(emails) != 1 {
+					t.Fatalf("expected 1 queued email, got %d", len(emails))
+				}
+				email := emails[0]
+				if email.To != "user@example.com" || email.Template != EmailTemplateGroupInvitation {
+					t.Errorf("unexpected email %+v", email)
+				}
+				// The invitee has no account, so the inviter's language is used
+				if email.Language != "es" {
+					t.Errorf("expected language es, got %q", email.Language)
+				}
+				data, ok := email.Data.(GroupInvitationEmailData)
+				if !ok {
+					t.Fatalf("unexpected email data %T", email.Data)
+				}
+				if data.Link != "https://app.example.com/invite/"+invitation.Token {
+					t.Errorf("unexpected invitation link %q", data.Link)
+				}
+				if data.InviterName != "Olivia" || data.GroupName != "Trip" {
+					t.Errorf("unexpected email data %+v", data)
+				}
 			},
 		},
 	}
@@ -80,7 +111,14 @@ func TestGroupInvitationService_CreateInvitation(t *testing.T) {
 			tt.mockSetup(mockInv, mockPending, mockGroup, mockUser)
 			
 			mockSvc := &testutil.MockUserService{}
-			svc := NewGroupInvitationService(mockInv, mockPending, mockGroup, mockUser, mockSvc)
+			var emails []EnqueueEmailInput
+			mockEmail := &MockEmailService{
+				EnqueueFunc: func(ctx context.Context, input EnqueueEmailInput) (sqlc.EmailOutbox, error) {
+					emails = append(emails, input)
+					return sqlc.EmailOutbox{}, nil
+				},
+			}
+			svc := NewGroupInvitationService(mockInv, mockPending, mockGroup, mockUser, mockSvc, &MockGroupActivityService{}, mockEmail, "https://app.example.com/")
 			result, err := svc.CreateInvitation(context.Background(), tt.input)
 
 			if tt.expectedError != nil {
@@ -100,7 +138,7 @@ func TestGroupInvitationService_CreateInvitation(t *testing.T) {
 			}
 
 			if tt.validate != nil {
-				tt.validate(t, result)
+				tt.validate(t, result, emails)
 			}
 		})
 	}
@@ -188,7 +226,7 @@ func TestGroupInvitationService_AcceptInvitation(t *testing.T) {
 			tt.mockSetup(mockInv, mockPending, mockGroup, mockUser)
 			
 			mockSvc := &testutil.MockUserService{}
-			svc := NewGroupInvitationService(mockInv, mockPending, mockGroup, mockUser, mockSvc)
+			svc := NewGroupInvitationService(mockInv, mockPending, mockGroup, mockUser, mockSvc, &MockGroupActivityService{}, &MockEmailService{}, "https://app.example.com")
 			_, err := svc.AcceptInvitation(context.Background(), tt.input)
 
 			if tt.expectedError != nil {
@@ -340,7 +378,7 @@ func TestGroupInvitationService_JoinGroup(t *testing.T) {
 			
 			tt.mockSetup(mockInv, mockPending, mockGroup, mockUser, mockSvc)
 			
-			svc := NewGroupInvitationService(mockInv, mockPending, mockGroup, mockUser, mockSvc)
+			svc := NewGroupInvitationService(mockInv, mockPending, mockGroup, mockUser, mockSvc, &MockGroupActivityService{}, &MockEmailService{}, "https://app.example.com")
 			_, _, err := svc.JoinGroup(context.Background(), tt.input)
 
 			if tt.expectedError != nil {
@@ -360,3 +398,374 @@ func TestGroupInvitationService_JoinGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupInvitationService_ManageInvitations(t *testing.T) {
+	groupID := testutil.CreateTestUUID(10)
+	adminID := testutil.CreateTestUUID(1)
+	memberID := testutil.CreateTestUUID(2)
+	invitationID := testutil.CreateTestUUID(100)
+
+	tests := []struct {
+		name          string
+		requesterID   pgtype.UUID
+		invitation    sqlc.GroupInvitation
+		action        string // "resend" or "revoke"
+		expectedError error
+	}{
+		{
+			name:        "admin resends pending invitation",
+			requesterID: adminID,
+			invitation:  sqlc.GroupInvitation{ID: invitationID, GroupID: groupID, Email: "bob@example.com", Token: "old-token", Status: "pending", InvitedBy: adminID},
+			action:      "resend",
+		},
+		{
+			name:        "admin revokes pending invitation",
+			requesterID: adminID,
+			invitation:  sqlc.GroupInvitation{ID: invitationID, GroupID: groupID, Status: "pending"},
+			action:      "revoke",
+		},
+		{
+			name:          "member cannot revoke",
+			requesterID:   memberID,
+			invitation:    sqlc.GroupInvitation{ID: invitationID, GroupID: groupID, Status: "pending"},
+			action:        "revoke",
+			expectedError: ErrInsufficientPermissions,
+		},
+		{
+			name:          "accepted invitation cannot be resent",
+			requesterID:   adminID,
+			invitation:    sqlc.GroupInvitation{ID: invitationID, GroupID: groupID, Status: "accepted"},
+			action:        "resend",
+			expectedError: ErrInvitationNotPending,
+		},
+		{
+			name:          "invitation from another group is not found",
+			requesterID:   adminID,
+			invitation:    sqlc.GroupInvitation{ID: invitationID, GroupID: testutil.CreateTestUUID(11), Status: "pending"},
+			action:        "revoke",
+			expectedError: ErrInvitationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGroup := &testutil.MockGroupRepository{
+				GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+					return sqlc.Group{ID: id, Name: "Trip"}, nil
+				},
+				GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+					role := "member"
+					if params.UserID == adminID {
+						role = "admin"
+					}
+					return testutil.CreateTestGroupMember(testutil.CreateTestUUID(50), groupID, params.UserID, role, "active"), nil
+				},
+			}
+			mockUser := &testutil.MockUserRepository{
+				GetUserByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+					return testutil.CreateTestUser(id, "admin@example.com"), nil
+				},
+				GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+					return sqlc.User{}, pgx.ErrNoRows
+				},
+			}
+
+			var renewed sqlc.RenewInvitationParams
+			var newStatus string
+			mockInv := &testutil.MockGroupInvitationRepository{
+				GetInvitationByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.GroupInvitation, error) {
+					return tt.invitation, nil
+				},
+				RenewInvitationFunc: func(ctx context.Context, params sqlc.RenewInvitationParams) (sqlc.GroupInvitation, error) {
+					renewed = params
+					inv := tt.invitation
+					inv.Token = params.Token
+					inv.ExpiresAt = params.ExpiresAt
+					return inv, nil
+				},
+				UpdateInvitationStatusFunc: func(ctx context.Context, params sqlc.UpdateInvitationStatusParams) (sqlc.GroupInvitation, error) {
+					newStatus = params.Status
+					return sqlc.GroupInvitation{}, nil
+				},
+			}
+
+			var emails []EnqueueEmailInput
+			mockEmail := &MockEmailService{
+				EnqueueFunc: func(ctx context.Context, input EnqueueEmailInput) (sqlc.EmailOutbox, error) {
+					emails = append(emails, input)
+					return sqlc.EmailOutbox{}, nil
+				},
+			}
+
+			svc := NewGroupInvitationService(mockInv, &testutil.MockPendingUserRepository{}, mockGroup, mockUser, &testutil.MockUserService{}, &MockGroupActivityService{}, mockEmail, "https://app.example.com")
+
+			var err error
+			if tt.action == "resend" {
+				_, err = svc.ResendInvitation(context.Background(), groupID, invitationID, tt.requesterID)
+			} else {
+				err = svc.RevokeInvitation(context.Background(), groupID, invitationID, tt.requesterID)
+			}
+
+			if err != tt.expectedError {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if tt.expectedError != nil {
+				return
+			}
+
+			switch tt.action {
+			case "resend":
+				if renewed.Token == "" || renewed.Token == tt.invitation.Token {
+					t.Errorf("expected a new token, got %q", renewed.Token)
+				}
+				if time.Until(renewed.ExpiresAt.Time) < invitationTTL-time.Minute {
+					t.Errorf("expected expiry to be extended, got %v", renewed.ExpiresAt.Time)
+				}
+				if len(emails) != 1 {
+					t.Fatalf("expected 1 queued email, got %d", len(emails))
+				}
+				if data := emails[0].Data.(GroupInvitationEmailData); data.Link != "https://app.example.com/invite/"+renewed.Token {
+					t.Errorf("expected link with new token, got %q", data.Link)
+				}
+			case "revoke":
+				if newStatus != "revoked" {
+					t.Errorf("expected status revoked, got %q", newStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupInvitationService_CreateJoinLink(t *testing.T) {
+	groupID := testutil.CreateTestUUID(10)
+	adminID := testutil.CreateTestUUID(1)
+	zero := int32(0)
+	five := int32(5)
+
+	tests := []struct {
+		name          string
+		input         CreateJoinLinkInput
+		expectedError error
+		wantTTL       time.Duration
+	}{
+		{name: "defaults to unlimited uses and a week", input: CreateJoinLinkInput{GroupID: groupID, CreatedBy: adminID}, wantTTL: joinLinkDefaultTTL},
+		{name: "limited uses and custom expiry", input: CreateJoinLinkInput{GroupID: groupID, CreatedBy: adminID, MaxUses: &five, ExpiresIn: 24 * time.Hour}, wantTTL: 24 * time.Hour},
+		{name: "zero max uses", input: CreateJoinLinkInput{GroupID: groupID, CreatedBy: adminID, MaxUses: &zero}, expectedError: ErrInvalidJoinLinkMaxUses},
+		{name: "expiry too long", input: CreateJoinLinkInput{GroupID: groupID, CreatedBy: adminID, ExpiresIn: 60 * 24 * time.Hour}, expectedError: ErrInvalidJoinLinkExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGroup := &testutil.MockGroupRepository{
+				GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+					return sqlc.Group{ID: id}, nil
+				},
+				GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+					return testutil.CreateTestGroupMember(testutil.CreateTestUUID(50), groupID, params.UserID, "owner", "active"), nil
+				},
+			}
+			var created sqlc.CreateJoinLinkParams
+			mockInv := &testutil.MockGroupInvitationRepository{
+				CreateJoinLinkFunc: func(ctx context.Context, params sqlc.CreateJoinLinkParams) (sqlc.GroupJoinLink, error) {
+					created = params
+					return sqlc.GroupJoinLink{GroupID: params.GroupID, Token: params.Token, MaxUses: params.MaxUses, ExpiresAt: params.ExpiresAt}, nil
+				},
+			}
+
+			svc := NewGroupInvitationService(mockInv, &testutil.MockPendingUserRepository{}, mockGroup, &testutil.MockUserRepository{}, &testutil.MockUserService{}, &MockGroupActivityService{}, &MockEmailService{}, "https://app.example.com")
+			_, err := svc.CreateJoinLink(context.Background(), tt.input)
+
+			if err != tt.expectedError {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if tt.expectedError != nil {
+				return
+			}
+
+			if created.Token == "" {
+				t.Error("expected a token")
+			}
+			if (tt.input.MaxUses != nil) != created.MaxUses.Valid {
+				t.Errorf("expected max uses valid=%v, got %v", tt.input.MaxUses != nil, created.MaxUses.Valid)
+			}
+			if ttl := time.Until(created.ExpiresAt.Time); ttl < tt.wantTTL-time.Minute || ttl > tt.wantTTL {
+				t.Errorf("expected expiry in ~%v, got %v", tt.wantTTL, ttl)
+			}
+		})
+	}
+}
+
+func TestGroupInvitationService_JoinGroup_WithJoinLink(t *testing.T) {
+	groupID := testutil.CreateTestUUID(10)
+	linkID := testutil.CreateTestUUID(100)
+	creatorID := testutil.CreateTestUUID(1)
+	userID := testutil.CreateTestUUID(2)
+
+	tests := []struct {
+		name          string
+		input         JoinGroupInput
+		existing      *sqlc.GroupMember
+		consumeErr    error
+		group         sqlc.Group
+		groupErr      error
+		expectedError error
+		wantConsumed  bool
+	}{
+		{
+			name:         "new account joins with email and password",
+			input:        JoinGroupInput{Token: "link-token", Email: "New@Example.com", Password: "password123"},
+			wantConsumed: true,
+		},
+		{
+			name:         "logged in user joins",
+			input:        JoinGroupInput{Token: "link-token", AuthenticatedUserID: userID},
+			wantConsumed: true,
+		},
+		{
+			name:          "anonymous join requires email",
+			input:         JoinGroupInput{Token: "link-token", Password: "password123"},
+			expectedError: ErrEmailRequiredForJoinLink,
+		},
+		{
+			name:          "exhausted link",
+			input:         JoinGroupInput{Token: "link-token", AuthenticatedUserID: userID},
+			consumeErr:    pgx.ErrNoRows,
+			expectedError: ErrInvitationNotFound,
+			wantConsumed:  true,
+		},
+		{
+			name:          "group deleted after the link was looked up",
+			input:         JoinGroupInput{Token: "link-token", AuthenticatedUserID: userID},
+			consumeErr:    pgx.ErrNoRows,
+			groupErr:      pgx.ErrNoRows,
+			expectedError: ErrGroupNotFound,
+			wantConsumed:  true,
+		},
+		{
+			name:          "group archived after the link was looked up",
+			input:         JoinGroupInput{Token: "link-token", AuthenticatedUserID: userID},
+			consumeErr:    pgx.ErrNoRows,
+			group:         sqlc.Group{ID: groupID, ArchivedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}},
+			expectedError: ErrGroupNotFound,
+			wantConsumed:  true,
+		},
+		{
+			name:     "active member does not use up the link",
+			input:    JoinGroupInput{Token: "link-token", AuthenticatedUserID: userID},
+			existing: &sqlc.GroupMember{GroupID: groupID, UserID: userID, Role: "member", Status: "active"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumed := false
+			mockInv := &testutil.MockGroupInvitationRepository{
+				GetInvitationByTokenFunc: func(ctx context.Context, token string) (sqlc.GetInvitationByTokenRow, error) {
+					return sqlc.GetInvitationByTokenRow{}, pgx.ErrNoRows
+				},
+				GetJoinLinkByTokenFunc: func(ctx context.Context, token string) (sqlc.GetJoinLinkByTokenRow, error) {
+					return sqlc.GetJoinLinkByTokenRow{ID: linkID, GroupID: groupID, Token: token, CreatedBy: creatorID}, nil
+				},
+				ConsumeJoinLinkFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error) {
+					consumed = true
+					return sqlc.GroupJoinLink{ID: id}, tt.consumeErr
+				},
+			}
+
+			var added sqlc.CreateGroupMemberParams
+			mockGroup := &testutil.MockGroupRepository{
+				GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+					return tt.group, tt.groupErr
+				},
+				GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+					if tt.existing != nil {
+						return *tt.existing, nil
+					}
+					return sqlc.GroupMember{}, pgx.ErrNoRows
+				},
+				CreateGroupMemberFunc: func(ctx context.Context, params sqlc.CreateGroupMemberParams) (sqlc.GroupMember, error) {
+					added = params
+					return sqlc.GroupMember{GroupID: params.GroupID, UserID: params.UserID, Role: params.Role, Status: params.Status}, nil
+				},
+			}
+			mockUser := &testutil.MockUserRepository{
+				GetUserByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+					return testutil.CreateTestUser(id, "user@example.com"), nil
+				},
+				GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+					return sqlc.User{}, pgx.ErrNoRows
+				},
+			}
+			mockSvc := &testutil.MockUserService{
+				CreateUserFunc: func(ctx context.Context, name, email, password string) (sqlc.User, error) {
+					if email != "new@example.com" {
+						t.Errorf("expected normalized email, got %q", email)
+					}
+					return testutil.CreateTestUser(userID, email), nil
+				},
+			}
+
+			svc := NewGroupInvitationService(mockInv, &testutil.MockPendingUserRepository{}, mockGroup, mockUser, mockSvc, &MockGroupActivityService{}, &MockEmailService{}, "https://app.example.com")
+			_, member, err := svc.JoinGroup(context.Background(), tt.input)
+
+			if err != tt.expectedError {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if consumed != tt.wantConsumed {
+				t.Errorf("expected consumed=%v, got %v", tt.wantConsumed, consumed)
+			}
+			if tt.expectedError != nil || tt.existing != nil {
+				return
+			}
+
+			if member.Status != "active" || member.Role != "member" {
+				t.Errorf("expected active member, got %+v", member)
+			}
+			if added.InvitedBy != creatorID {
+				t.Errorf("expected link creator as inviter, got %v", added.InvitedBy)
+			}
+		})
+	}
+}
+
+func TestGroupInvitationService_CreateInvitation_EmailNotQueued(t *testing.T) {
+	groupID := testutil.CreateTestUUID(10)
+	inviterID := testutil.CreateTestUUID(1)
+
+	committed := false
+	mockInv := &testutil.MockGroupInvitationRepository{
+		BeginTxFunc: func(ctx context.Context) (pgx.Tx, error) {
+			return &testutil.MockTx{CommitFunc: func(ctx context.Context) error {
+				committed = true
+				return nil
+			}}, nil
+		},
+		CreateInvitationFunc: func(ctx context.Context, params sqlc.CreateInvitationParams) (sqlc.GroupInvitation, error) {
+			return sqlc.GroupInvitation{GroupID: params.GroupID, Email: params.Email, Token: params.Token, InvitedBy: params.InvitedBy}, nil
+		},
+	}
+	mockGroup := &testutil.MockGroupRepository{
+		GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+			return testutil.CreateTestGroupMember(testutil.CreateTestUUID(50), groupID, inviterID, "owner", "active"), nil
+		},
+	}
+	mockUser := &testutil.MockUserRepository{
+		GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+			return sqlc.User{}, pgx.ErrNoRows
+		},
+	}
+	mockEmail := &MockEmailService{
+		EnqueueFunc: func(ctx context.Context, input EnqueueEmailInput) (sqlc.EmailOutbox, error) {
+			return sqlc.EmailOutbox{}, errors.New("outbox unavailable")
+		},
+	}
+
+	svc := NewGroupInvitationService(mockInv, &testutil.MockPendingUserRepository{}, mockGroup, mockUser, &testutil.MockUserService{}, &MockGroupActivityService{}, mockEmail, "https://app.example.com")
+	_, err := svc.CreateInvitation(context.Background(), CreateInvitationInput{GroupID: groupID, Email: "user@example.com", InvitedBy: inviterID})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if committed {
+		t.Error("expected the invitation to be rolled back with its email")
+	}
+}
@@ -2,11 +2,13 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/repository"
@@ -19,8 +21,28 @@ var (
 	ErrAlreadyMember           = errors.New("user is already a member of this group")
 	ErrNoPendingInvitation     = errors.New("no pending invitation found")
 	ErrInvalidGroupName        = errors.New("group name is required")
+	ErrGroupArchived           = errors.New("group is archived")
+	ErrGroupNotArchived        = errors.New("group is not archived")
+	ErrGroupHasBalances        = errors.New("group has outstanding balances")
+	ErrGroupCurrencyLocked     = errors.New("group currency cannot be changed once expenses exist")
+	ErrInvalidSplitMethod      = errors.New("invalid default split method")
+	ErrInvalidGroupSettings    = errors.New("group settings must be a JSON object")
+	ErrMemberNotFound          = errors.New("member not found in this group")
+	ErrInvalidMemberRole       = errors.New("role must be admin or member")
+	ErrCannotModifyOwner       = errors.New("the group owner cannot be changed this way; transfer ownership instead")
+	ErrCannotModifySelf        = errors.New("you cannot change your own membership this way")
+	ErrOwnerCannotLeave        = errors.New("the group owner must transfer ownership before leaving")
+	ErrMemberHasBalance        = errors.New("member has an outstanding balance in this group")
 )
 
+// validSplitMethods mirrors the split types accepted by the expense service.
+var validSplitMethods = map[string]bool{
+	"equal":      true,
+	"percentage": true,
+	"shares":     true,
+	"fixed":      true,
+}
+
 type CreateGroupInput struct {
 	Name         string
 	Description  string
@@ -28,6 +50,38 @@ type CreateGroupInput struct {
 	CreatedBy    pgtype.UUID
 }
 
+// UpdateGroupInput carries a partial update; nil fields are left unchanged.
+type UpdateGroupInput struct {
+	GroupID            pgtype.UUID
+	Name               *string
+	Description        *string
+	CurrencyCode       *string
+	DefaultSplitMethod *string
+	Settings           json.RawMessage
+	UpdatedBy          pgtype.UUID
+}
+
+type UpdateMemberRoleInput struct {
+	GroupID     pgtype.UUID
+	UserID      pgtype.UUID
+	Role        string
+	RequesterID pgtype.UUID
+}
+
+// MemberBalanceError is returned when a member with a non-zero balance would be
+// removed without force; Balance lets callers show the amount in the warning.
+type MemberBalanceError struct {
+	Balance string
+}
+
+func (e *MemberBalanceError) Error() string {
+	return ErrMemberHasBalance.Error()
+}
+
+func (e *MemberBalanceError) Unwrap() error {
+	return ErrMemberHasBalance
+}
+
 type CreateGroupResult struct {
 	Group      sqlc.Group
 	Membership sqlc.GroupMember
@@ -54,6 +108,14 @@ type GroupService interface {
 	CreateGroup(ctx context.Context, input CreateGroupInput) (CreateGroupResult, error)
 	ListGroupMembers(ctx context.Context, groupID, requesterID pgtype.UUID) ([]GroupMemberDetail, error)
 	ListUserGroups(ctx context.Context, userID pgtype.UUID) ([]sqlc.GetGroupsByUserIDRow, error)
+	UpdateGroup(ctx context.Context, input UpdateGroupInput) (sqlc.Group, error)
+	ArchiveGroup(ctx context.Context, groupID, requesterID pgtype.UUID) (sqlc.Group, error)
+	RestoreGroup(ctx context.Context, groupID, requesterID pgtype.UUID) (sqlc.Group, error)
+	DeleteGroup(ctx context.Context, groupID, requesterID pgtype.UUID, force bool) error
+	UpdateMemberRole(ctx context.Context, input UpdateMemberRoleInput) (sqlc.GroupMember, error)
+	TransferOwnership(ctx context.Context, groupID, newOwnerID, requesterID pgtype.UUID) error
+	RemoveMember(ctx context.Context, groupID, userID, requesterID pgtype.UUID, force bool) error
+	LeaveGroup(ctx context.Context, groupID, userID pgtype.UUID, force bool) error
 }
 
 type groupService struct {
@@ -151,11 +213,11 @@ func (s *groupService) ListGroupMembers(ctx context.Context, groupID, requesterI
 	}
 
 	// Verify requester is a member
-	_, err = s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+	member, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
 		GroupID: groupID,
 		UserID:  requesterID,
 	})
-	if err != nil {
+	if err != nil || member.Status == "inactive" {
 		return nil, ErrNotGroupMember
 	}
 
@@ -223,3 +285,480 @@ func (s *groupService) ListGroupMembers(ctx context.Context, groupID, requesterI
 func (s *groupService) ListUserGroups(ctx context.Context, userID pgtype.UUID) ([]sqlc.GetGroupsByUserIDRow, error) {
 	return s.repo.GetGroupsByUserID(ctx, userID)
 }
+
+// requireGroupRole loads the group and verifies the requester is an active member
+// holding one of the given roles.
+func (s *groupService) requireGroupRole(ctx context.Context, groupID, userID pgtype.UUID, roles ...string) (sqlc.Group, sqlc.GroupMember, error) {
+	group, err := s.repo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return sqlc.Group{}, sqlc.GroupMember{}, ErrGroupNotFound
+	}
+
+	member, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+		GroupID: groupID,
+		UserID:  userID,
+	})
+	if err != nil || member.Status != "active" {
+		return sqlc.Group{}, sqlc.GroupMember{}, ErrNotGroupMember
+	}
+
+	for _, role := range roles {
+		if member.Role == role {
+			return group, member, nil
+		}
+	}
+	return sqlc.Group{}, sqlc.GroupMember{}, ErrInsufficientPermissions
+}
+
+func (s *groupService) UpdateGroup(ctx context.Context, input UpdateGroupInput) (sqlc.Group, error) {
+	group, _, err := s.requireGroupRole(ctx, input.GroupID, input.UpdatedBy, "owner", "admin")
+	if err != nil {
+		return sqlc.Group{}, err
+	}
+	if group.ArchivedAt.Valid {
+		return sqlc.Group{}, ErrGroupArchived
+	}
+
+	params := sqlc.UpdateGroupParams{
+		ID:                 group.ID,
+		Name:               group.Name,
+		Description:        group.Description,
+		CurrencyCode:       group.CurrencyCode,
+		DefaultSplitMethod: group.DefaultSplitMethod,
+		Settings:           group.Settings,
+		UpdatedBy:          input.UpdatedBy,
+	}
+	changes := map[string]interface{}{}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return sqlc.Group{}, ErrInvalidGroupName
+		}
+		if name != group.Name {
+			changes["name"] = map[string]interface{}{"from": group.Name, "to": name}
+		}
+		params.Name = name
+	}
+
+	if input.Description != nil {
+		description := strings.TrimSpace(*input.Description)
+		if description != group.Description.String {
+			changes["description"] = map[string]interface{}{"from": group.Description.String, "to": description}
+		}
+		params.Description = pgtype.Text{String: description, Valid: description != ""}
+	}
+
+	if input.CurrencyCode != nil {
+		currencyCode, err := normalizeCurrencyCode(*input.CurrencyCode)
+		if err != nil {
+			return sqlc.Group{}, err
+		}
+		if currencyCode != group.CurrencyCode {
+			// Existing exchange-rate snapshots are relative to the current currency
+			count, err := s.repo.CountGroupExpenses(ctx, group.ID)
+			if err != nil {
+				return sqlc.Group{}, err
+			}
+			if count > 0 {
+				return sqlc.Group{}, ErrGroupCurrencyLocked
+			}
+			changes["currency_code"] = map[string]interface{}{"from": group.CurrencyCode, "to": currencyCode}
+		}
+		params.CurrencyCode = currencyCode
+	}
+
+	if input.DefaultSplitMethod != nil {
+		method := strings.ToLower(strings.TrimSpace(*input.DefaultSplitMethod))
+		if !validSplitMethods[method] {
+			return sqlc.Group{}, ErrInvalidSplitMethod
+		}
+		if method != group.DefaultSplitMethod {
+			changes["default_split_method"] = map[string]interface{}{"from": group.DefaultSplitMethod, "to": method}
+		}
+		params.DefaultSplitMethod = method
+	}
+
+	if input.Settings != nil {
+		var settings map[string]interface{}
+		if err := json.Unmarshal(input.Settings, &settings); err != nil || settings == nil {
+			return sqlc.Group{}, ErrInvalidGroupSettings
+		}
+		normalized, err := json.Marshal(settings)
+		if err != nil {
+			return sqlc.Group{}, ErrInvalidGroupSettings
+		}
+		if _, err := ParseReminderSettings(normalized); err != nil {
+			return sqlc.Group{}, ErrInvalidReminderSettings
+		}
+		changes["settings"] = true
+		params.Settings = normalized
+	}
+
+	updated, err := s.repo.UpdateGroup(ctx, params)
+	if err != nil {
+		return sqlc.Group{}, err
+	}
+
+	if len(changes) > 0 {
+		_ = s.activityService.LogActivity(ctx, LogActivityInput{
+			GroupID:    updated.ID,
+			UserID:     input.UpdatedBy,
+			Action:     "group_updated",
+			EntityType: "group",
+			EntityID:   updated.ID,
+			Metadata: map[string]interface{}{
+				"changes": changes,
+			},
+		})
+	}
+
+	return updated, nil
+}
+
+func (s *groupService) ArchiveGroup(ctx context.Context, groupID, requesterID pgtype.UUID) (sqlc.Group, error) {
+	group, _, err := s.requireGroupRole(ctx, groupID, requesterID, "owner", "admin")
+	if err != nil {
+		return sqlc.Group{}, err
+	}
+	if group.ArchivedAt.Valid {
+		return sqlc.Group{}, ErrGroupArchived
+	}
+
+	archived, err := s.repo.ArchiveGroup(ctx, sqlc.ArchiveGroupParams{
+		ID:        groupID,
+		UpdatedBy: requesterID,
+	})
+	if err != nil {
+		return sqlc.Group{}, err
+	}
+
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    groupID,
+		UserID:     requesterID,
+		Action:     "group_archived",
+		EntityType: "group",
+		EntityID:   groupID,
+		Metadata: map[string]interface{}{
+			"name": archived.Name,
+		},
+	})
+
+	return archived, nil
+}
+
+func (s *groupService) RestoreGroup(ctx context.Context, groupID, requesterID pgtype.UUID) (sqlc.Group, error) {
+	group, _, err := s.requireGroupRole(ctx, groupID, requesterID, "owner", "admin")
+	if err != nil {
+		return sqlc.Group{}, err
+	}
+	if !group.ArchivedAt.Valid {
+		return sqlc.Group{}, ErrGroupNotArchived
+	}
+
+	restored, err := s.repo.RestoreGroup(ctx, sqlc.RestoreGroupParams{
+		ID:        groupID,
+		UpdatedBy: requesterID,
+	})
+	if err != nil {
+		return sqlc.Group{}, err
+	}
+
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    groupID,
+		UserID:     requesterID,
+		Action:     "group_restored",
+		EntityType: "group",
+		EntityID:   groupID,
+		Metadata: map[string]interface{}{
+			"name": restored.Name,
+		},
+	})
+
+	return restored, nil
+}
+
+// DeleteGroup soft-deletes a group. Only the owner may delete, and unless force is
+// set the group must be fully settled so nobody loses track of money they are owed.
+func (s *groupService) DeleteGroup(ctx context.Context, groupID, requesterID pgtype.UUID, force bool) error {
+	group, _, err := s.requireGroupRole(ctx, groupID, requesterID, "owner")
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		settled, err := s.isGroupSettled(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if !settled {
+			return ErrGroupHasBalances
+		}
+	}
+
+	if err := s.repo.DeleteGroup(ctx, sqlc.DeleteGroupParams{
+		ID:        groupID,
+		UpdatedBy: requesterID,
+	}); err != nil {
+		return err
+	}
+
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    groupID,
+		UserID:     requesterID,
+		Action:     "group_deleted",
+		EntityType: "group",
+		EntityID:   groupID,
+		Metadata: map[string]interface{}{
+			"name":   group.Name,
+			"forced": force,
+		},
+	})
+
+	return nil
+}
+
+// isGroupSettled reports whether every member (including pending users) has a
+// zero balance once rounded to cents.
+func (s *groupService) isGroupSettled(ctx context.Context, groupID pgtype.UUID) (bool, error) {
+	rows, err := s.repo.GetGroupBalancesWithPending(ctx, groupID)
+	if err != nil {
+		return false, err
+	}
+	for _, row := range rows {
+		balance := numericInterfaceToDecimal(row.TotalPaid).Sub(numericInterfaceToDecimal(row.TotalOwed))
+		if !balance.Round(2).Equal(decimal.Zero) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// getActiveMember returns the membership of a user who is currently in the group.
+func (s *groupService) getActiveMember(ctx context.Context, groupID, userID pgtype.UUID) (sqlc.GroupMember, error) {
+	member, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+		GroupID: groupID,
+		UserID:  userID,
+	})
+	if err != nil || member.Status != "active" {
+		return sqlc.GroupMember{}, ErrMemberNotFound
+	}
+	return member, nil
+}
+
+// UpdateMemberRole promotes a member to admin or demotes an admin to member.
+// Only the owner may change roles; ownership itself moves via TransferOwnership.
+func (s *groupService) UpdateMemberRole(ctx context.Context, input UpdateMemberRoleInput) (sqlc.GroupMember, error) {
+	role := strings.ToLower(strings.TrimSpace(input.Role))
+	if role != "admin" && role != "member" {
+		return sqlc.GroupMember{}, ErrInvalidMemberRole
+	}
+
+	if _, _, err := s.requireGroupRole(ctx, input.GroupID, input.RequesterID, "owner"); err != nil {
+		return sqlc.GroupMember{}, err
+	}
+	if input.UserID == input.RequesterID {
+		return sqlc.GroupMember{}, ErrCannotModifySelf
+	}
+
+	target, err := s.getActiveMember(ctx, input.GroupID, input.UserID)
+	if err != nil {
+		return sqlc.GroupMember{}, err
+	}
+	if target.Role == "owner" {
+		return sqlc.GroupMember{}, ErrCannotModifyOwner
+	}
+	if target.Role == role {
+		return target, nil
+	}
+
+	updated, err := s.repo.UpdateGroupMemberRole(ctx, sqlc.UpdateGroupMemberRoleParams{
+		GroupID: input.GroupID,
+		UserID:  input.UserID,
+		Role:    role,
+	})
+	if err != nil {
+		return sqlc.GroupMember{}, err
+	}
+
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    input.GroupID,
+		UserID:     input.RequesterID,
+		Action:     "member_role_changed",
+		EntityType: "member",
+		EntityID:   updated.ID,
+		Metadata: map[string]interface{}{
+			"user_id":  uuidToString(input.UserID),
+			"old_role": target.Role,
+			"new_role": role,
+		},
+	})
+
+	return updated, nil
+}
+
+// TransferOwnership hands the owner role to another active member. The previous
+// owner stays in the group as an admin.
+func (s *groupService) TransferOwnership(ctx context.Context, groupID, newOwnerID, requesterID pgtype.UUID) error {
+	if _, _, err := s.requireGroupRole(ctx, groupID, requesterID, "owner"); err != nil {
+		return err
+	}
+	if newOwnerID == requesterID {
+		return ErrCannotModifySelf
+	}
+
+	target, err := s.getActiveMember(ctx, groupID, newOwnerID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	txRepo := s.repo.WithTx(tx)
+
+	if _, err := txRepo.UpdateGroupMemberRole(ctx, sqlc.UpdateGroupMemberRoleParams{
+		GroupID: groupID,
+		UserID:  requesterID,
+		Role:    "admin",
+	}); err != nil {
+		return err
+	}
+
+	if _, err := txRepo.UpdateGroupMemberRole(ctx, sqlc.UpdateGroupMemberRoleParams{
+		GroupID: groupID,
+		UserID:  newOwnerID,
+		Role:    "owner",
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    groupID,
+		UserID:     requesterID,
+		Action:     "ownership_transferred",
+		EntityType: "member",
+		EntityID:   target.ID,
+		Metadata: map[string]interface{}{
+			"from_user_id": uuidToString(requesterID),
+			"to_user_id":   uuidToString(newOwnerID),
+		},
+	})
+
+	return nil
+}
+
+// RemoveMember marks another member inactive. Owners can remove anyone but
+// themselves; admins can only remove regular members. Expenses, splits and
+// settlements are left untouched so the member's history stays in balances.
+func (s *groupService) RemoveMember(ctx context.Context, groupID, userID, requesterID pgtype.UUID, force bool) error {
+	_, requester, err := s.requireGroupRole(ctx, groupID, requesterID, "owner", "admin")
+	if err != nil {
+		return err
+	}
+	if userID == requesterID {
+		return ErrCannotModifySelf
+	}
+
+	target, err := s.getActiveMember(ctx, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if target.Role == "owner" {
+		return ErrCannotModifyOwner
+	}
+	if requester.Role == "admin" && target.Role == "admin" {
+		return ErrInsufficientPermissions
+	}
+
+	balance, err := s.deactivateMember(ctx, target, force)
+	if err != nil {
+		return err
+	}
+
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    groupID,
+		UserID:     requesterID,
+		Action:     "member_removed",
+		EntityType: "member",
+		EntityID:   target.ID,
+		Metadata: map[string]interface{}{
+			"user_id": uuidToString(userID),
+			"role":    target.Role,
+			"balance": balance.String(),
+			"forced":  force,
+		},
+	})
+
+	return nil
+}
+
+// LeaveGroup marks the caller inactive. The owner has to transfer ownership first.
+func (s *groupService) LeaveGroup(ctx context.Context, groupID, userID pgtype.UUID, force bool) error {
+	if _, err := s.repo.GetGroupByID(ctx, groupID); err != nil {
+		return ErrGroupNotFound
+	}
+
+	member, err := s.getActiveMember(ctx, groupID, userID)
+	if err != nil {
+		return ErrNotGroupMember
+	}
+	if member.Role == "owner" {
+		return ErrOwnerCannotLeave
+	}
+
+	balance, err := s.deactivateMember(ctx, member, force)
+	if err != nil {
+		return err
+	}
+
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    groupID,
+		UserID:     userID,
+		Action:     "member_left",
+		EntityType: "member",
+		EntityID:   member.ID,
+		Metadata: map[string]interface{}{
+			"user_id": uuidToString(userID),
+			"balance": balance.String(),
+			"forced":  force,
+		},
+	})
+
+	return nil
+}
+
+// deactivateMember checks the member's balance and flips their status to inactive.
+// A non-zero balance returns a MemberBalanceError unless force is set.
+func (s *groupService) deactivateMember(ctx context.Context, member sqlc.GroupMember, force bool) (decimal.Decimal, error) {
+	row, err := s.repo.GetUserBalanceInGroup(ctx, sqlc.GetUserBalanceInGroupParams{
+		GroupID: member.GroupID,
+		UserID:  member.UserID,
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	balance := numericInterfaceToDecimal(row.Balance).Round(2)
+	if !balance.IsZero() && !force {
+		return balance, &MemberBalanceError{Balance: balance.StringFixed(2)}
+	}
+
+	if _, err := s.repo.UpdateGroupMemberStatus(ctx, sqlc.UpdateGroupMemberStatusParams{
+		GroupID:  member.GroupID,
+		UserID:   member.UserID,
+		Status:   "inactive",
+		JoinedAt: member.JoinedAt,
+	}); err != nil {
+		return decimal.Zero, err
+	}
+
+	return balance, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
@@ -396,3 +397,403 @@ func TestGroupService_ListUserGroups(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupService_UpdateGroup(t *testing.T) {
+	ownerID := testutil.CreateTestUUID(1)
+	memberID := testutil.CreateTestUUID(2)
+	groupID := testutil.CreateTestUUID(10)
+	newName := "Renamed"
+	newCurrency := "eur"
+	badMethod := "random"
+
+	tests := []struct {
+		name          string
+		input         UpdateGroupInput
+		role          string
+		archived      bool
+		expenseCount  int64
+		expectedError error
+		validate      func(*testing.T, sqlc.UpdateGroupParams)
+	}{
+		{
+			name:  "owner renames group",
+			input: UpdateGroupInput{GroupID: groupID, Name: &newName, UpdatedBy: ownerID},
+			role:  "owner",
+			validate: func(t *testing.T, params sqlc.UpdateGroupParams) {
+				if params.Name != newName {
+					t.Errorf("expected name %s, got %s", newName, params.Name)
+				}
+				if params.CurrencyCode != "USD" {
+					t.Errorf("expected currency to stay USD, got %s", params.CurrencyCode)
+				}
+			},
+		},
+		{
+			name:  "admin changes currency with no expenses",
+			input: UpdateGroupInput{GroupID: groupID, CurrencyCode: &newCurrency, UpdatedBy: ownerID},
+			role:  "admin",
+			validate: func(t *testing.T, params sqlc.UpdateGroupParams) {
+				if params.CurrencyCode != "EUR" {
+					t.Errorf("expected currency EUR, got %s", params.CurrencyCode)
+				}
+			},
+		},
+		{
+			name:          "currency locked once expenses exist",
+			input:         UpdateGroupInput{GroupID: groupID, CurrencyCode: &newCurrency, UpdatedBy: ownerID},
+			role:          "owner",
+			expenseCount:  3,
+			expectedError: ErrGroupCurrencyLocked,
+		},
+		{
+			name:          "member cannot update",
+			input:         UpdateGroupInput{GroupID: groupID, Name: &newName, UpdatedBy: memberID},
+			role:          "member",
+			expectedError: ErrInsufficientPermissions,
+		},
+		{
+			name:          "archived group cannot be updated",
+			input:         UpdateGroupInput{GroupID: groupID, Name: &newName, UpdatedBy: ownerID},
+			role:          "owner",
+			archived:      true,
+			expectedError: ErrGroupArchived,
+		},
+		{
+			name:          "invalid split method",
+			input:         UpdateGroupInput{GroupID: groupID, DefaultSplitMethod: &badMethod, UpdatedBy: ownerID},
+			role:          "owner",
+			expectedError: ErrInvalidSplitMethod,
+		},
+		{
+			name:          "settings must be an object",
+			input:         UpdateGroupInput{GroupID: groupID, Settings: []byte(`[1,2]`), UpdatedBy: ownerID},
+			role:          "owner",
+			expectedError: ErrInvalidGroupSettings,
+		},
+		{
+			name:          "invalid reminder settings",
+			input:         UpdateGroupInput{GroupID: groupID, Settings: []byte(`{"reminders":{"enabled":true,"interval_days":0}}`), UpdatedBy: ownerID},
+			role:          "owner",
+			expectedError: ErrInvalidReminderSettings,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured sqlc.UpdateGroupParams
+			mockRepo := &testutil.MockGroupRepository{
+				GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+					group := testutil.CreateTestGroup(groupID, "Trip", ownerID)
+					if tt.archived {
+						group.ArchivedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+					}
+					return group, nil
+				},
+				GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+					return testutil.CreateTestGroupMember(testutil.CreateTestUUID(100), params.GroupID, params.UserID, tt.role, "active"), nil
+				},
+				CountGroupExpensesFunc: func(ctx context.Context, id pgtype.UUID) (int64, error) {
+					return tt.expenseCount, nil
+				},
+				UpdateGroupFunc: func(ctx context.Context, params sqlc.UpdateGroupParams) (sqlc.Group, error) {
+					captured = params
+					return sqlc.Group{ID: params.ID, Name: params.Name, CurrencyCode: params.CurrencyCode}, nil
+				},
+			}
+
+			svc := NewGroupService(mockRepo, &testutil.MockGroupInvitationRepository{}, &MockGroupActivityService{})
+			_, err := svc.UpdateGroup(context.Background(), tt.input)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.validate != nil {
+				tt.validate(t, captured)
+			}
+		})
+	}
+}
+
+func TestGroupService_ArchiveAndRestoreGroup(t *testing.T) {
+	ownerID := testutil.CreateTestUUID(1)
+	groupID := testutil.CreateTestUUID(10)
+
+	newRepo := func(archived bool) *testutil.MockGroupRepository {
+		return &testutil.MockGroupRepository{
+			GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+				group := testutil.CreateTestGroup(groupID, "Trip", ownerID)
+				group.ArchivedAt = pgtype.Timestamptz{Time: time.Now(), Valid: archived}
+				return group, nil
+			},
+			GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+				return testutil.CreateTestGroupMember(testutil.CreateTestUUID(100), params.GroupID, params.UserID, "owner", "active"), nil
+			},
+		}
+	}
+
+	svc := NewGroupService(newRepo(false), &testutil.MockGroupInvitationRepository{}, &MockGroupActivityService{})
+	if _, err := svc.ArchiveGroup(context.Background(), groupID, ownerID); err != nil {
+		t.Errorf("unexpected error archiving active group: %v", err)
+	}
+	if _, err := svc.RestoreGroup(context.Background(), groupID, ownerID); !errors.Is(err, ErrGroupNotArchived) {
+		t.Errorf("expected %v restoring active group, got %v", ErrGroupNotArchived, err)
+	}
+
+	svc = NewGroupService(newRepo(true), &testutil.MockGroupInvitationRepository{}, &MockGroupActivityService{})
+	if _, err := svc.ArchiveGroup(context.Background(), groupID, ownerID); !errors.Is(err, ErrGroupArchived) {
+		t.Errorf("expected %v archiving archived group, got %v", ErrGroupArchived, err)
+	}
+	if _, err := svc.RestoreGroup(context.Background(), groupID, ownerID); err != nil {
+		t.Errorf("unexpected error restoring archived group: %v", err)
+	}
+}
+
+func TestGroupService_DeleteGroup(t *testing.T) {
+	ownerID := testutil.CreateTestUUID(1)
+	adminID := testutil.CreateTestUUID(2)
+	groupID := testutil.CreateTestUUID(10)
+
+	settled := []sqlc.GetGroupBalancesWithPendingRow{
+		{UserID: ownerID, TotalPaid: "50.00", TotalOwed: "50.00"},
+		{UserID: adminID, TotalPaid: "25.004", TotalOwed: "25.00"},
+	}
+	unsettled := []sqlc.GetGroupBalancesWithPendingRow{
+		{UserID: ownerID, TotalPaid: "100.00", TotalOwed: "50.00"},
+		{UserID: adminID, TotalPaid: "0", TotalOwed: "50.00"},
+	}
+
+	tests := []struct {
+		name          string
+		requesterID   pgtype.UUID
+		role          string
+		balances      []sqlc.GetGroupBalancesWithPendingRow
+		force         bool
+		expectDelete  bool
+		expectedError error
+	}{
+		{name: "settled group is deleted", requesterID: ownerID, role: "owner", balances: settled, expectDelete: true},
+		{name: "unsettled group is blocked", requesterID: ownerID, role: "owner", balances: unsettled, expectedError: ErrGroupHasBalances},
+		{name: "force deletes unsettled group", requesterID: ownerID, role: "owner", balances: unsettled, force: true, expectDelete: true},
+		{name: "admin cannot delete", requesterID: adminID, role: "admin", balances: settled, expectedError: ErrInsufficientPermissions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			mockRepo := &testutil.MockGroupRepository{
+				GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+					return testutil.CreateTestGroup(groupID, "Trip", ownerID), nil
+				},
+				GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+					return testutil.CreateTestGroupMember(testutil.CreateTestUUID(100), params.GroupID, params.UserID, tt.role, "active"), nil
+				},
+				GetGroupBalancesWithPendingFunc: func(ctx context.Context, id pgtype.UUID) ([]sqlc.GetGroupBalancesWithPendingRow, error) {
+					return tt.balances, nil
+				},
+				DeleteGroupFunc: func(ctx context.Context, params sqlc.DeleteGroupParams) error {
+					deleted = true
+					return nil
+				},
+			}
+
+			svc := NewGroupService(mockRepo, &testutil.MockGroupInvitationRepository{}, &MockGroupActivityService{})
+			err := svc.DeleteGroup(context.Background(), groupID, tt.requesterID, tt.force)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if deleted != tt.expectDelete {
+				t.Errorf("expected delete=%v, got %v", tt.expectDelete, deleted)
+			}
+		})
+	}
+}
+
+func TestGroupService_UpdateMemberRole(t *testing.T) {
+	ownerID := testutil.CreateTestUUID(1)
+	adminID := testutil.CreateTestUUID(2)
+	memberID := testutil.CreateTestUUID(3)
+	groupID := testutil.CreateTestUUID(10)
+
+	roles := map[pgtype.UUID]string{ownerID: "owner", adminID: "admin", memberID: "member"}
+
+	tests := []struct {
+		name          string
+		requesterID   pgtype.UUID
+		userID        pgtype.UUID
+		role          string
+		expectedError error
+	}{
+		{name: "owner promotes member", requesterID: ownerID, userID: memberID, role: "admin"},
+		{name: "owner demotes admin", requesterID: ownerID, userID: adminID, role: "member"},
+		{name: "admin cannot change roles", requesterID: adminID, userID: memberID, role: "admin", expectedError: ErrInsufficientPermissions},
+		{name: "owner role cannot be assigned", requesterID: ownerID, userID: memberID, role: "owner", expectedError: ErrInvalidMemberRole},
+		{name: "owner cannot demote self", requesterID: ownerID, userID: ownerID, role: "member", expectedError: ErrCannotModifySelf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &testutil.MockGroupRepository{
+				GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+					return testutil.CreateTestGroup(groupID, "Trip", ownerID), nil
+				},
+				GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+					return testutil.CreateTestGroupMember(testutil.CreateTestUUID(100), params.GroupID, params.UserID, roles[params.UserID], "active"), nil
+				},
+				UpdateGroupMemberRoleFunc: func(ctx context.Context, params sqlc.UpdateGroupMemberRoleParams) (sqlc.GroupMember, error) {
+					return testutil.CreateTestGroupMember(testutil.CreateTestUUID(100), params.GroupID, params.UserID, params.Role, "active"), nil
+				},
+			}
+
+			svc := NewGroupService(mockRepo, &testutil.MockGroupInvitationRepository{}, &MockGroupActivityService{})
+			member, err := svc.UpdateMemberRole(context.Background(), UpdateMemberRoleInput{
+				GroupID:     groupID,
+				UserID:      tt.userID,
+				Role:        tt.role,
+				RequesterID: tt.requesterID,
+			})
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if member.Role != tt.role {
+				t.Errorf("expected role %q, got %q", tt.role, member.Role)
+			}
+		})
+	}
+}
+
+func TestGroupService_TransferOwnership(t *testing.T) {
+	ownerID := testutil.CreateTestUUID(1)
+	memberID := testutil.CreateTestUUID(3)
+	groupID := testutil.CreateTestUUID(10)
+
+	newRoles := map[pgtype.UUID]string{}
+	committed := false
+	mockRepo := &testutil.MockGroupRepository{
+		GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+			return testutil.CreateTestGroup(groupID, "Trip", ownerID), nil
+		},
+		GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+			role := "member"
+			if params.UserID == ownerID {
+				role = "owner"
+			}
+			return testutil.CreateTestGroupMember(testutil.CreateTestUUID(100), params.GroupID, params.UserID, role, "active"), nil
+		},
+		UpdateGroupMemberRoleFunc: func(ctx context.Context, params sqlc.UpdateGroupMemberRoleParams) (sqlc.GroupMember, error) {
+			newRoles[params.UserID] = params.Role
+			return sqlc.GroupMember{}, nil
+		},
+		BeginTxFunc: func(ctx context.Context) (pgx.Tx, error) {
+			return &testutil.MockTx{CommitFunc: func(ctx context.Context) error {
+				committed = true
+				return nil
+			}}, nil
+		},
+	}
+
+	svc := NewGroupService(mockRepo, &testutil.MockGroupInvitationRepository{}, &MockGroupActivityService{})
+	if err := svc.TransferOwnership(context.Background(), groupID, memberID, ownerID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !committed {
+		t.Errorf("expected transaction to be committed")
+	}
+	if newRoles[ownerID] != "admin" || newRoles[memberID] != "owner" {
+		t.Errorf("unexpected role changes: %v", newRoles)
+	}
+
+	if err := svc.TransferOwnership(context.Background(), groupID, ownerID, memberID); !errors.Is(err, ErrInsufficientPermissions) {
+		t.Errorf("expected %v for non-owner, got %v", ErrInsufficientPermissions, err)
+	}
+}
+
+func TestGroupService_RemoveMemberAndLeave(t *testing.T) {
+	ownerID := testutil.CreateTestUUID(1)
+	adminID := testutil.CreateTestUUID(2)
+	memberID := testutil.CreateTestUUID(3)
+	otherAdminID := testutil.CreateTestUUID(4)
+	groupID := testutil.CreateTestUUID(10)
+
+	roles := map[pgtype.UUID]string{ownerID: "owner", adminID: "admin", memberID: "member", otherAdminID: "admin"}
+
+	tests := []struct {
+		name             string
+		leave            bool
+		requesterID      pgtype.UUID
+		userID           pgtype.UUID
+		balance          string
+		force            bool
+		expectDeactivate bool
+		expectedError    error
+	}{
+		{name: "admin removes settled member", requesterID: adminID, userID: memberID, balance: "0", expectDeactivate: true},
+		{name: "admin cannot remove admin", requesterID: adminID, userID: otherAdminID, balance: "0", expectedError: ErrInsufficientPermissions},
+		{name: "owner cannot be removed", requesterID: adminID, userID: ownerID, balance: "0", expectedError: ErrCannotModifyOwner},
+		{name: "member with balance is blocked", requesterID: ownerID, userID: memberID, balance: "-12.5", expectedError: ErrMemberHasBalance},
+		{name: "force removes member with balance", requesterID: ownerID, userID: memberID, balance: "-12.5", force: true, expectDeactivate: true},
+		{name: "member leaves settled group", leave: true, userID: memberID, balance: "0.001", expectDeactivate: true},
+		{name: "member with balance cannot leave", leave: true, userID: memberID, balance: "20", expectedError: ErrMemberHasBalance},
+		{name: "owner cannot leave", leave: true, userID: ownerID, balance: "0", expectedError: ErrOwnerCannotLeave},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deactivated := false
+			mockRepo := &testutil.MockGroupRepository{
+				GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+					return testutil.CreateTestGroup(groupID, "Trip", ownerID), nil
+				},
+				GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+					return testutil.CreateTestGroupMember(testutil.CreateTestUUID(100), params.GroupID, params.UserID, roles[params.UserID], "active"), nil
+				},
+				GetUserBalanceInGroupFunc: func(ctx context.Context, params sqlc.GetUserBalanceInGroupParams) (sqlc.GetUserBalanceInGroupRow, error) {
+					return sqlc.GetUserBalanceInGroupRow{Balance: tt.balance}, nil
+				},
+				UpdateGroupMemberStatusFunc: func(ctx context.Context, params sqlc.UpdateGroupMemberStatusParams) (sqlc.GroupMember, error) {
+					if params.Status != "inactive" {
+						t.Errorf("expected status inactive, got %q", params.Status)
+					}
+					deactivated = true
+					return sqlc.GroupMember{}, nil
+				},
+			}
+
+			svc := NewGroupService(mockRepo, &testutil.MockGroupInvitationRepository{}, &MockGroupActivityService{})
+			var err error
+			if tt.leave {
+				err = svc.LeaveGroup(context.Background(), groupID, tt.userID, tt.force)
+			} else {
+				err = svc.RemoveMember(context.Background(), groupID, tt.userID, tt.requesterID, tt.force)
+			}
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if deactivated != tt.expectDeactivate {
+				t.Errorf("expected deactivate=%v, got %v", tt.expectDeactivate, deactivated)
+			}
+		})
+	}
+}
@@ -18,11 +18,13 @@ var (
 type AccessTokenClaims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
+	// SessionID is the session whose refresh token issued this access token
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
 type JWTService interface {
-	GenerateAccessToken(userID, email string) (string, string, error) // returns token and JTI
+	GenerateAccessToken(userID, email, sessionID string) (string, string, error) // returns token and JTI
 	GenerateRefreshToken() (string, error)
 	ValidateAccessToken(tokenString string) (*AccessTokenClaims, error)
 	ParseAccessToken(tokenString string) (*AccessTokenClaims, error) // Parse without validation
@@ -42,13 +44,14 @@ func NewJWTService(secret string, accessTokenExpiry, refreshTokenExpiry time.Dur
 	}
 }
 
-func (s *jwtService) GenerateAccessToken(userID, email string) (string, string, error) {
+func (s *jwtService) GenerateAccessToken(userID, email, sessionID string) (string, string, error) {
 	jti := uuid.New().String()
 	now := time.Now()
 
 	claims := AccessTokenClaims{
-		UserID: userID,
-		Email:  email,
+		UserID:    userID,
+		Email:     email,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        jti,
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -15,7 +15,7 @@ func TestJWTService_GenerateAccessToken(t *testing.T) {
 		userID := "123e4567-e89b-12d3-a456-426614174000"
 		email := "test@example.com"
 
-		token, jti, err := jwtService.GenerateAccessToken(userID, email)
+		token, jti, err := jwtService.GenerateAccessToken(userID, email, "")
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, token)
@@ -26,10 +26,10 @@ func TestJWTService_GenerateAccessToken(t *testing.T) {
 		userID := "123e4567-e89b-12d3-a456-426614174000"
 		email := "test@example.com"
 
-		_, jti1, err := jwtService.GenerateAccessToken(userID, email)
+		_, jti1, err := jwtService.GenerateAccessToken(userID, email, "")
 		require.NoError(t, err)
 
-		_, jti2, err := jwtService.GenerateAccessToken(userID, email)
+		_, jti2, err := jwtService.GenerateAccessToken(userID, email, "")
 		require.NoError(t, err)
 
 		assert.NotEqual(t, jti1, jti2, "JTI should be unique for each token")
@@ -43,13 +43,16 @@ func TestJWTService_ValidateAccessToken(t *testing.T) {
 		userID := "123e4567-e89b-12d3-a456-426614174000"
 		email := "test@example.com"
 
-		token, jti, err := jwtService.GenerateAccessToken(userID, email)
+		sessionID := "223e4567-e89b-12d3-a456-426614174000"
+
+		token, jti, err := jwtService.GenerateAccessToken(userID, email, sessionID)
 		require.NoError(t, err)
 
 		claims, err := jwtService.ValidateAccessToken(token)
 		require.NoError(t, err)
 		assert.Equal(t, userID, claims.UserID)
 		assert.Equal(t, email, claims.Email)
+		assert.Equal(t, sessionID, claims.SessionID)
 		assert.Equal(t, jti, claims.ID)
 	})
 
@@ -64,7 +67,7 @@ func TestJWTService_ValidateAccessToken(t *testing.T) {
 	t.Run("rejects token with wrong signature", func(t *testing.T) {
 		// Create token with different secret
 		otherService := NewJWTService("different-secret-key-32-chars-long", 15*time.Minute, 24*time.Hour)
-		token, _, err := otherService.GenerateAccessToken("123", "test@example.com")
+		token, _, err := otherService.GenerateAccessToken("123", "test@example.com", "")
 		require.NoError(t, err)
 
 		// Try to validate with original service
@@ -76,7 +79,7 @@ func TestJWTService_ValidateAccessToken(t *testing.T) {
 	t.Run("rejects expired token", func(t *testing.T) {
 		// Create service with very short expiry
 		shortLivedService := NewJWTService("test-secret-key-at-least-32-chars", 1*time.Millisecond, 24*time.Hour)
-		token, _, err := shortLivedService.GenerateAccessToken("123", "test@example.com")
+		token, _, err := shortLivedService.GenerateAccessToken("123", "test@example.com", "")
 		require.NoError(t, err)
 
 		// Wait for token to expire
@@ -117,7 +120,7 @@ func TestJWTService_ParseAccessToken(t *testing.T) {
 		userID := "123e4567-e89b-12d3-a456-426614174000"
 		email := "test@example.com"
 
-		token, jti, err := jwtService.GenerateAccessToken(userID, email)
+		token, jti, err := jwtService.GenerateAccessToken(userID, email, "")
 		require.NoError(t, err)
 
 		claims, err := jwtService.ParseAccessToken(token)
@@ -130,7 +133,7 @@ func TestJWTService_ParseAccessToken(t *testing.T) {
 	t.Run("parses expired token without error", func(t *testing.T) {
 		// Create service with very short expiry
 		shortLivedService := NewJWTService("test-secret-key-at-least-32-chars", 1*time.Millisecond, 24*time.Hour)
-		token, _, err := shortLivedService.GenerateAccessToken("123", "test@example.com")
+		token, _, err := shortLivedService.GenerateAccessToken("123", "test@example.com", "")
 		require.NoError(t, err)
 
 		// Wait for token to expire
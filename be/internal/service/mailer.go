@@ -0,0 +1,240 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrInvalidEmailAddress = errors.New("invalid email address")
+
+// EmailMessage is a single outgoing email. HTMLBody is optional; when set the
+// message is sent as multipart/alternative with TextBody as the fallback.
+type EmailMessage struct {
+	To       string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// Mailer delivers transactional email.
+type Mailer interface {
+	Send(ctx context.Context, msg EmailMessage) error
+}
+
+// SMTPConfig holds the settings for SMTPMailer.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPConfigFromEnv reads SMTP_HOST, SMTP_PORT, SMTP_USERNAME, SMTP_PASSWORD and
+// MAIL_FROM. An empty Host means SMTP is not configured.
+func SMTPConfigFromEnv() (SMTPConfig, error) {
+	cfg := SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     587,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("MAIL_FROM"),
+	}
+	if v := os.Getenv("SMTP_PORT"); v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil || port <= 0 {
+			return SMTPConfig{}, fmt.Errorf("invalid SMTP_PORT %q", v)
+		}
+		cfg.Port = port
+	}
+	if cfg.From == "" {
+		cfg.From = "SplitPlus <no-reply@localhost>"
+	}
+	if _, err := mail.ParseAddress(cfg.From); err != nil {
+		return SMTPConfig{}, fmt.Errorf("invalid MAIL_FROM: %w", err)
+	}
+	return cfg, nil
+}
+
+// NewMailer returns an SMTPMailer when cfg has a host, and a MemoryMailer that
+// logs every message otherwise, so development works without an SMTP server.
+func NewMailer(cfg SMTPConfig) Mailer {
+	if cfg.Host == "" {
+		return NewMemoryMailer(true)
+	}
+	return NewSMTPMailer(cfg)
+}
+
+// SMTPMailer sends email through an SMTP server, upgrading to TLS with
+// STARTTLS whenever the server offers it.
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg EmailMessage) error {
+	from, err := mail.ParseAddress(m.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return ErrInvalidEmailAddress
+	}
+
+	body, err := buildMessage(from, to, msg)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("smtp dial: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(time.Now().Add(30 * time.Second))
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.cfg.Host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("smtp rcpt to: %w", err)
+	}
+	wc, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := wc.Write(body); err != nil {
+		wc.Close()
+		return fmt.Errorf("smtp write: %w", err)
+	}
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMessage renders the RFC 5322 message. Header values come from parsed
+// addresses or are Q-encoded, so user input can't inject extra headers.
+func buildMessage(from, to *mail.Address, msg EmailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+
+	header("From", from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", strings.Join(strings.Fields(msg.Subject), " ")))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+
+	if msg.HTMLBody == "" {
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "8bit")
+		buf.WriteString("\r\n")
+		buf.WriteString(normalizeNewlines(msg.TextBody))
+		return buf.Bytes(), nil
+	}
+
+	var random [12]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return nil, err
+	}
+	boundary := "splitplus-" + hex.EncodeToString(random[:])
+
+	header("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", boundary))
+	buf.WriteString("\r\n")
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.TextBody},
+		{"text/html; charset=utf-8", msg.HTMLBody},
+	} {
+		fmt.Fprintf(&buf, "--%s\r\n", boundary)
+		header("Content-Type", part.contentType)
+		header("Content-Transfer-Encoding", "8bit")
+		buf.WriteString("\r\n")
+		buf.WriteString(normalizeNewlines(part.body))
+		buf.WriteString("\r\n")
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+	return buf.Bytes(), nil
+}
+
+func normalizeNewlines(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
+
+// MemoryMailer keeps sent messages in memory. It is used in tests and, with
+// logging enabled, in development where the logged body carries the links.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []EmailMessage
+	logging  bool
+}
+
+func NewMemoryMailer(logging bool) *MemoryMailer {
+	return &MemoryMailer{logging: logging}
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, msg EmailMessage) error {
+	if _, err := mail.ParseAddress(msg.To); err != nil {
+		return ErrInvalidEmailAddress
+	}
+
+	m.mu.Lock()
+	m.messages = append(m.messages, msg)
+	m.mu.Unlock()
+
+	if m.logging {
+		log.Printf("mail to %s: %s\n%s", msg.To, msg.Subject, msg.TextBody)
+	}
+	return nil
+}
+
+// Messages returns a copy of everything sent so far.
+func (m *MemoryMailer) Messages() []EmailMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]EmailMessage(nil), m.messages...)
+}
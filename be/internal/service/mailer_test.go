@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestBuildMessage(t *testing.T) {
+	from := &mail.Address{Name: "SplitPlus", Address: "no-reply@example.com"}
+	to := &mail.Address{Address: "alice@example.com"}
+
+	t.Run("plain text", func(t *testing.T) {
+		body, err := buildMessage(from, to, EmailMessage{
+			Subject:  "Hello\r\nBcc: attacker@example.com",
+			TextBody: "line one\nline two",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		msg := string(body)
+		if strings.Contains(msg, "\r\nBcc:") {
+			t.Errorf("subject newline injected a header:\n%s", msg)
+		}
+		if !strings.Contains(msg, "Content-Type: text/plain; charset=utf-8") {
+			t.Errorf("expected text/plain content type:\n%s", msg)
+		}
+		if !strings.HasSuffix(msg, "line one\r\nline two") {
+			t.Errorf("expected CRLF line endings in body:\n%q", msg)
+		}
+	})
+
+	t.Run("html alternative", func(t *testing.T) {
+		body, err := buildMessage(from, to, EmailMessage{
+			Subject:  "Hello",
+			TextBody: "plain",
+			HTMLBody: "<p>html</p>",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		msg := string(body)
+		if !strings.Contains(msg, "multipart/alternative") || !strings.Contains(msg, "text/html; charset=utf-8") {
+			t.Errorf("expected multipart/alternative with an html part:\n%s", msg)
+		}
+	})
+}
+
+func TestMemoryMailer_RejectsInvalidRecipient(t *testing.T) {
+	mailer := NewMemoryMailer(false)
+	if err := mailer.Send(context.Background(), EmailMessage{To: "not an email"}); err != ErrInvalidEmailAddress {
+		t.Errorf("expected ErrInvalidEmailAddress, got %v", err)
+	}
+	if len(mailer.Messages()) != 0 {
+		t.Error("expected nothing to be recorded")
+	}
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+// Notification categories, which users can mute one by one.
+const (
+	NotificationCategoryExpenses    = "expenses"
+	NotificationCategoryComments    = "comments"
+	NotificationCategorySettlements = "settlements"
+	NotificationCategoryMembers     = "members"
+)
+
+// NotificationCategories lists every category in the order preferences are shown.
+var NotificationCategories = []string{
+	NotificationCategoryExpenses,
+	NotificationCategoryComments,
+	NotificationCategorySettlements,
+	NotificationCategoryMembers,
+}
+
+// notificationCategoriesByAction is the category of the notifications created
+// for each group activity. Activities missing here don't notify anyone.
+var notificationCategoriesByAction = map[string]string{
+	"expense_created":        NotificationCategoryExpenses,
+	"expense_updated":        NotificationCategoryExpenses,
+	"expense_deleted":        NotificationCategoryExpenses,
+	"attachment_added":       NotificationCategoryExpenses,
+	"attachment_deleted":     NotificationCategoryExpenses,
+	"comment_added":          NotificationCategoryComments,
+	"settlement_created":     NotificationCategorySettlements,
+	"settlement_updated":     NotificationCategorySettlements,
+	"settlement_resubmitted": NotificationCategorySettlements,
+	"settlement_completed":   NotificationCategorySettlements,
+	"settlement_disputed":    NotificationCategorySettlements,
+	"settlement_cancelled":   NotificationCategorySettlements,
+	"settlement_deleted":     NotificationCategorySettlements,
+	"member_role_changed":    NotificationCategoryMembers,
+	"member_removed":         NotificationCategoryMembers,
+	"ownership_transferred":  NotificationCategoryMembers,
+}
+
+// mentionPattern matches the @[Name](user-id) mentions clients insert into comments.
+var mentionPattern = regexp.MustCompile(`@\[[^\]]*\]\(([0-9a-fA-F-]{36})\)`)
+
+var (
+	ErrNotificationNotFound        = errors.New("notification not found")
+	ErrInvalidNotificationCategory = errors.New("invalid notification category")
+)
+
+type ListNotificationsInput struct {
+	UserID     pgtype.UUID
+	UnreadOnly bool
+	Limit      int32
+	Offset     int32
+}
+
+// NotificationPreference tells whether a user receives one category.
+type NotificationPreference struct {
+	Category string `json:"category"`
+	Muted    bool   `json:"muted"`
+}
+
+// NotificationService keeps each user's inbox. As an ActivityListener it
+// notifies the people an activity is about: an expense's payers and split
+// members, a settlement's two sides, mentioned users and the affected member.
+// Whoever did the activity is never notified of it.
+type NotificationService interface {
+	ActivityListener
+
+	ListNotifications(ctx context.Context, input ListNotificationsInput) ([]sqlc.ListUserNotificationsRow, error)
+	CountUnread(ctx context.Context, userID pgtype.UUID) (int64, error)
+	MarkRead(ctx context.Context, userID, notificationID pgtype.UUID) (sqlc.Notification, error)
+	// MarkAllRead returns how many notifications were unread.
+	MarkAllRead(ctx context.Context, userID pgtype.UUID) (int64, error)
+
+	GetPreferences(ctx context.Context, userID pgtype.UUID) ([]NotificationPreference, error)
+	SetPreference(ctx context.Context, userID pgtype.UUID, category string, muted bool) ([]NotificationPreference, error)
+}
+
+type notificationService struct {
+	repo repository.NotificationRepository
+}
+
+func NewNotificationService(repo repository.NotificationRepository) NotificationService {
+	return &notificationService{repo: repo}
+}
+
+func (s *notificationService) ActivityLogged(ctx context.Context, activity sqlc.GroupActivity) error {
+	category, ok := notificationCategoriesByAction[activity.Action]
+	if !ok {
+		return nil
+	}
+
+	recipients, err := s.recipients(ctx, category, activity)
+	if err != nil {
+		return fmt.Errorf("failed to resolve notification recipients: %w", err)
+	}
+	recipients = slices.DeleteFunc(recipients, func(id pgtype.UUID) bool {
+		return !id.Valid || id == activity.UserID
+	})
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	metadata := activity.Metadata
+	if len(metadata) == 0 || string(metadata) == "null" {
+		metadata = []byte("{}")
+	}
+
+	return s.repo.CreateNotifications(ctx, sqlc.CreateNotificationsParams{
+		ActivityID: activity.ID,
+		GroupID:    activity.GroupID,
+		ActorID:    activity.UserID,
+		Category:   category,
+		Action:     activity.Action,
+		EntityType: activity.EntityType,
+		EntityID:   activity.EntityID,
+		Metadata:   metadata,
+		UserIds:    recipients,
+	})
+}
+
+// recipients may contain the actor and duplicates; the insert ignores
+// duplicates and the caller drops the actor.
+func (s *notificationService) recipients(ctx context.Context, category string, activity sqlc.GroupActivity) ([]pgtype.UUID, error) {
+	var metadata struct {
+		UserID   string   `json:"user_id"`
+		ToUserID string   `json:"to_user_id"`
+		Mentions []string `json:"mentions"`
+	}
+	if len(activity.Metadata) > 0 {
+		_ = json.Unmarshal(activity.Metadata, &metadata)
+	}
+
+	switch category {
+	case NotificationCategoryExpenses:
+		return s.repo.ListExpenseParticipantIDs(ctx, activity.EntityID)
+
+	case NotificationCategoryComments:
+		recipients, err := s.repo.ListExpenseParticipantIDs(ctx, activity.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		if len(metadata.Mentions) == 0 {
+			return recipients, nil
+		}
+		// Only members can be mentioned into a group's conversation
+		members, err := s.repo.ListActiveGroupMemberIDs(ctx, activity.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		for _, mention := range metadata.Mentions {
+			if id, err := parseUUIDString(mention); err == nil && slices.Contains(members, id) {
+				recipients = append(recipients, id)
+			}
+		}
+		return recipients, nil
+
+	case NotificationCategorySettlements:
+		settlement, err := s.repo.GetSettlementParticipants(ctx, activity.EntityID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []pgtype.UUID{settlement.PayerID, settlement.PayeeID}, nil
+
+	case NotificationCategoryMembers:
+		target := metadata.UserID
+		if activity.Action == "ownership_transferred" {
+			target = metadata.ToUserID
+		}
+		id, err := parseUUIDString(target)
+		if err != nil {
+			return nil, nil
+		}
+		return []pgtype.UUID{id}, nil
+	}
+	return nil, nil
+}
+
+func (s *notificationService) ListNotifications(ctx context.Context, input ListNotificationsInput) ([]sqlc.ListUserNotificationsRow, error) {
+	return s.repo.ListUserNotifications(ctx, sqlc.ListUserNotificationsParams{
+		UserID:     input.UserID,
+		UnreadOnly: input.UnreadOnly,
+		Limit:      input.Limit,
+		Offset:     input.Offset,
+	})
+}
+
+func (s *notificationService) CountUnread(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return s.repo.CountUnreadNotifications(ctx, userID)
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, userID, notificationID pgtype.UUID) (sqlc.Notification, error) {
+	notification, err := s.repo.MarkNotificationRead(ctx, sqlc.MarkNotificationReadParams{
+		ID:     notificationID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.Notification{}, ErrNotificationNotFound
+		}
+		return sqlc.Notification{}, err
+	}
+	return notification, nil
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return s.repo.MarkAllNotificationsRead(ctx, userID)
+}
+
+func (s *notificationService) GetPreferences(ctx context.Context, userID pgtype.UUID) ([]NotificationPreference, error) {
+	muted, err := s.repo.ListNotificationMutes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make([]NotificationPreference, 0, len(NotificationCategories))
+	for _, category := range NotificationCategories {
+		preferences = append(preferences, NotificationPreference{
+			Category: category,
+			Muted:    slices.Contains(muted, category),
+		})
+	}
+	return preferences, nil
+}
+
+func (s *notificationService) SetPreference(ctx context.Context, userID pgtype.UUID, category string, muted bool) ([]NotificationPreference, error) {
+	if !slices.Contains(NotificationCategories, category) {
+		return nil, ErrInvalidNotificationCategory
+	}
+
+	var err error
+	if muted {
+		err = s.repo.MuteNotificationCategory(ctx, sqlc.MuteNotificationCategoryParams{UserID: userID, Category: category})
+	} else {
+		err = s.repo.UnmuteNotificationCategory(ctx, sqlc.UnmuteNotificationCategoryParams{UserID: userID, Category: category})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(ctx, userID)
+}
+
+// parseMentions returns the distinct user ids mentioned in a comment.
+func parseMentions(comment string) []string {
+	var ids []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(comment, -1) {
+		if !slices.Contains(ids, match[1]) {
+			ids = append(ids, match[1])
+		}
+	}
+	return ids
+}
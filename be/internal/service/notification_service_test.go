@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockNotificationRepository for testing
+type MockNotificationRepository struct {
+	CreateNotificationsFunc        func(ctx context.Context, params sqlc.CreateNotificationsParams) error
+	ListUserNotificationsFunc      func(ctx context.Context, params sqlc.ListUserNotificationsParams) ([]sqlc.ListUserNotificationsRow, error)
+	CountUnreadNotificationsFunc   func(ctx context.Context, userID pgtype.UUID) (int64, error)
+	MarkNotificationReadFunc       func(ctx context.Context, params sqlc.MarkNotificationReadParams) (sqlc.Notification, error)
+	MarkAllNotificationsReadFunc   func(ctx context.Context, userID pgtype.UUID) (int64, error)
+	ListNotificationMutesFunc      func(ctx context.Context, userID pgtype.UUID) ([]string, error)
+	MuteNotificationCategoryFunc   func(ctx context.Context, params sqlc.MuteNotificationCategoryParams) error
+	UnmuteNotificationCategoryFunc func(ctx context.Context, params sqlc.UnmuteNotificationCategoryParams) error
+	ListExpenseParticipantIDsFunc  func(ctx context.Context, expenseID pgtype.UUID) ([]pgtype.UUID, error)
+	GetSettlementParticipantsFunc  func(ctx context.Context, settlementID pgtype.UUID) (sqlc.GetSettlementParticipantsRow, error)
+	ListActiveGroupMemberIDsFunc   func(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error)
+}
+
+func (m *MockNotificationRepository) CreateNotifications(ctx context.Context, params sqlc.CreateNotificationsParams) error {
+	if m.CreateNotificationsFunc != nil {
+		return m.CreateNotificationsFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockNotificationRepository) ListUserNotifications(ctx context.Context, params sqlc.ListUserNotificationsParams) ([]sqlc.ListUserNotificationsRow, error) {
+	if m.ListUserNotificationsFunc != nil {
+		return m.ListUserNotificationsFunc(ctx, params)
+	}
+	return []sqlc.ListUserNotificationsRow{}, nil
+}
+
+func (m *MockNotificationRepository) CountUnreadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if m.CountUnreadNotificationsFunc != nil {
+		return m.CountUnreadNotificationsFunc(ctx, userID)
+	}
+	return 0, nil
+}
+
+func (m *MockNotificationRepository) MarkNotificationRead(ctx context.Context, params sqlc.MarkNotificationReadParams) (sqlc.Notification, error) {
+	if m.MarkNotificationReadFunc != nil {
+		return m.MarkNotificationReadFunc(ctx, params)
+	}
+	return sqlc.Notification{}, pgx.ErrNoRows
+}
+
+func (m *MockNotificationRepository) MarkAllNotificationsRead(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if m.MarkAllNotificationsReadFunc != nil {
+		return m.MarkAllNotificationsReadFunc(ctx, userID)
+	}
+	return 0, nil
+}
+
+func (m *MockNotificationRepository) ListNotificationMutes(ctx context.Context, userID pgtype.UUID) ([]string, error) {
+	if m.ListNotificationMutesFunc != nil {
+		return m.ListNotificationMutesFunc(ctx, userID)
+	}
+	return []string{}, nil
+}
+
+func (m *MockNotificationRepository) MuteNotificationCategory(ctx context.Context, params sqlc.MuteNotificationCategoryParams) error {
+	if m.MuteNotificationCategoryFunc != nil {
+		return m.MuteNotificationCategoryFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockNotificationRepository) UnmuteNotificationCategory(ctx context.Context, params sqlc.UnmuteNotificationCategoryParams) error {
+	if m.UnmuteNotificationCategoryFunc != nil {
+		return m.UnmuteNotificationCategoryFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockNotificationRepository) ListExpenseParticipantIDs(ctx context.Context, expenseID pgtype.UUID) ([]pgtype.UUID, error) {
+	if m.ListExpenseParticipantIDsFunc != nil {
+		return m.ListExpenseParticipantIDsFunc(ctx, expenseID)
+	}
+	return []pgtype.UUID{}, nil
+}
+
+func (m *MockNotificationRepository) GetSettlementParticipants(ctx context.Context, settlementID pgtype.UUID) (sqlc.GetSettlementParticipantsRow, error) {
+	if m.GetSettlementParticipantsFunc != nil {
+		return m.GetSettlementParticipantsFunc(ctx, settlementID)
+	}
+	return sqlc.GetSettlementParticipantsRow{}, pgx.ErrNoRows
+}
+
+func (m *MockNotificationRepository) ListActiveGroupMemberIDs(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error) {
+	if m.ListActiveGroupMemberIDsFunc != nil {
+		return m.ListActiveGroupMemberIDsFunc(ctx, groupID)
+	}
+	return []pgtype.UUID{}, nil
+}
+
+func TestNotificationService_ActivityLogged(t *testing.T) {
+	actor := testutil.CreateTestUUID(1)
+	payer := testutil.CreateTestUUID(2)
+	member := testutil.CreateTestUUID(3)
+	outsider := testutil.CreateTestUUID(4)
+	entityID := testutil.CreateTestUUID(10)
+
+	repo := &MockNotificationRepository{
+		ListExpenseParticipantIDsFunc: func(ctx context.Context, expenseID pgtype.UUID) ([]pgtype.UUID, error) {
+			return []pgtype.UUID{actor, payer}, nil
+		},
+		GetSettlementParticipantsFunc: func(ctx context.Context, settlementID pgtype.UUID) (sqlc.GetSettlementParticipantsRow, error) {
+			return sqlc.GetSettlementParticipantsRow{PayerID: actor, PayeeID: payer}, nil
+		},
+		ListActiveGroupMemberIDsFunc: func(ctx context.Context, groupID pgtype.UUID) ([]pgtype.UUID, error) {
+			return []pgtype.UUID{actor, payer, member}, nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		action         string
+		metadata       string
+		wantCategory   string
+		wantRecipients []pgtype.UUID
+	}{
+		{"expense participants", "expense_created", `{}`, NotificationCategoryExpenses, []pgtype.UUID{payer}},
+		{"attachment notifies participants", "attachment_added", `{}`, NotificationCategoryExpenses, []pgtype.UUID{payer}},
+		{"settlement sides", "settlement_completed", `{}`, NotificationCategorySettlements, []pgtype.UUID{payer}},
+		{
+			"mentioned members",
+			"comment_added",
+			`{"mentions":["` + uuidToString(member) + `","` + uuidToString(outsider) + `"]}`,
+			NotificationCategoryComments,
+			[]pgtype.UUID{payer, member},
+		},
+		{"removed member", "member_removed", `{"user_id":"` + uuidToString(member) + `"}`, NotificationCategoryMembers, []pgtype.UUID{member}},
+		{"new owner", "ownership_transferred", `{"from_user_id":"` + uuidToString(actor) + `","to_user_id":"` + uuidToString(member) + `"}`, NotificationCategoryMembers, []pgtype.UUID{member}},
+		{"not notified", "group_updated", `{}`, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *sqlc.CreateNotificationsParams
+			repo.CreateNotificationsFunc = func(ctx context.Context, params sqlc.CreateNotificationsParams) error {
+				created = &params
+				return nil
+			}
+			svc := NewNotificationService(repo)
+
+			err := svc.ActivityLogged(context.Background(), sqlc.GroupActivity{
+				ID:       testutil.CreateTestUUID(20),
+				GroupID:  testutil.CreateTestUUID(21),
+				UserID:   actor,
+				Action:   tt.action,
+				EntityID: entityID,
+				Metadata: []byte(tt.metadata),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantRecipients == nil {
+				if created != nil {
+					t.Fatalf("expected %s not to notify anyone", tt.action)
+				}
+				return
+			}
+			if created == nil {
+				t.Fatal("expected notifications to be created")
+			}
+			if created.Category != tt.wantCategory || created.Action != tt.action || created.ActorID != actor {
+				t.Errorf("unexpected params %+v", created)
+			}
+			if !slices.Equal(created.UserIds, tt.wantRecipients) {
+				t.Errorf("expected recipients %v, got %v", tt.wantRecipients, created.UserIds)
+			}
+		})
+	}
+}
+
+func TestNotificationService_ActivityLogged_OnlyActor(t *testing.T) {
+	actor := testutil.CreateTestUUID(1)
+	repo := &MockNotificationRepository{
+		ListExpenseParticipantIDsFunc: func(ctx context.Context, expenseID pgtype.UUID) ([]pgtype.UUID, error) {
+			return []pgtype.UUID{actor}, nil
+		},
+		CreateNotificationsFunc: func(ctx context.Context, params sqlc.CreateNotificationsParams) error {
+			t.Fatal("expected no notifications for the actor's own expense")
+			return nil
+		},
+	}
+
+	err := NewNotificationService(repo).ActivityLogged(context.Background(), sqlc.GroupActivity{
+		UserID: actor,
+		Action: "expense_updated",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNotificationService_MarkRead(t *testing.T) {
+	svc := NewNotificationService(&MockNotificationRepository{})
+
+	_, err := svc.MarkRead(context.Background(), testutil.CreateTestUUID(1), testutil.CreateTestUUID(2))
+	if !errors.Is(err, ErrNotificationNotFound) {
+		t.Fatalf("expected ErrNotificationNotFound, got %v", err)
+	}
+}
+
+func TestNotificationService_SetPreference(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	muted := []string{NotificationCategorySettlements}
+	repo := &MockNotificationRepository{
+		ListNotificationMutesFunc: func(ctx context.Context, id pgtype.UUID) ([]string, error) {
+			return muted, nil
+		},
+		MuteNotificationCategoryFunc: func(ctx context.Context, params sqlc.MuteNotificationCategoryParams) error {
+			muted = append(muted, params.Category)
+			return nil
+		},
+		UnmuteNotificationCategoryFunc: func(ctx context.Context, params sqlc.UnmuteNotificationCategoryParams) error {
+			muted = slices.DeleteFunc(muted, func(c string) bool { return c == params.Category })
+			return nil
+		},
+	}
+	svc := NewNotificationService(repo)
+
+	if _, err := svc.SetPreference(context.Background(), userID, "marketing", true); !errors.Is(err, ErrInvalidNotificationCategory) {
+		t.Fatalf("expected ErrInvalidNotificationCategory, got %v", err)
+	}
+
+	preferences, err := svc.SetPreference(context.Background(), userID, NotificationCategoryComments, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []NotificationPreference{
+		{Category: NotificationCategoryExpenses, Muted: false},
+		{Category: NotificationCategoryComments, Muted: true},
+		{Category: NotificationCategorySettlements, Muted: true},
+		{Category: NotificationCategoryMembers, Muted: false},
+	}
+	if !slices.Equal(preferences, want) {
+		t.Errorf("expected %v, got %v", want, preferences)
+	}
+
+	preferences, err = svc.SetPreference(context.Background(), userID, NotificationCategorySettlements, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preferences[2].Muted {
+		t.Error("expected settlements to be unmuted")
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	id := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	tests := []struct {
+		comment string
+		want    []string
+	}{
+		{"no mentions here", nil},
+		{"thanks @[Asha](" + id + ")!", []string{id}},
+		{"@[Asha](" + id + ") and again @[Asha](" + id + ")", []string{id}},
+		{"plain @Asha and broken @[Asha](not-an-id)", nil},
+	}
+
+	for _, tt := range tests {
+		if got := parseMentions(tt.comment); !slices.Equal(got, tt.want) {
+			t.Errorf("parseMentions(%q) = %v, want %v", tt.comment, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// oidcClient speaks the parts of OpenID Connect the login flow needs:
+// discovery, the authorization code exchange and ID token verification.
+// Discovery and signing keys are fetched on first use and cached.
+type oidcClient struct {
+	config     OIDCProviderConfig
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]*rsa.PublicKey
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcTokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+// oidcIDTokenClaims are the ID token claims used to find or link the account.
+type oidcIDTokenClaims struct {
+	Email         string   `json:"email"`
+	EmailVerified oidcBool `json:"email_verified"`
+	Name          string   `json:"name"`
+	Nonce         string   `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// oidcBool accepts email_verified both as a JSON boolean and as the string
+// some providers send.
+type oidcBool bool
+
+func (b *oidcBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	case "false", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+func newOIDCClient(config OIDCProviderConfig, httpClient *http.Client) *oidcClient {
+	return &oidcClient{config: config, httpClient: httpClient}
+}
+
+// authorizationURL builds the provider URL the browser is sent to.
+func (c *oidcClient) authorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", c.config.ClientID)
+	query.Set("redirect_uri", c.config.RedirectURL)
+	query.Set("scope", strings.Join(c.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// exchangeCode trades the authorization code for tokens and returns the
+// verified ID token claims.
+func (c *oidcClient) exchangeCode(ctx context.Context, code, codeVerifier, nonce string) (oidcIDTokenClaims, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return oidcIDTokenClaims{}, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.config.RedirectURL)
+	form.Set("client_id", c.config.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if c.config.ClientSecret != "" {
+		form.Set("client_secret", c.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return oidcIDTokenClaims{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return oidcIDTokenClaims{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// An invalid or already used code is the usual cause; it's the caller's fault
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: token endpoint returned %d: %s", ErrOIDCCodeInvalid, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var tokens oidcTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return oidcIDTokenClaims{}, fmt.Errorf("decode token response: %w", err)
+	}
+	if tokens.IDToken == "" {
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: token response has no id_token", ErrOIDCTokenInvalid)
+	}
+
+	return c.verifyIDToken(ctx, tokens.IDToken, discovery.Issuer, nonce)
+}
+
+func (c *oidcClient) verifyIDToken(ctx context.Context, rawToken, issuer, nonce string) (oidcIDTokenClaims, error) {
+	var claims oidcIDTokenClaims
+	_, err := jwt.ParseWithClaims(rawToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return c.getKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(issuer),
+		jwt.WithAudience(c.config.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: %v", ErrOIDCTokenInvalid, err)
+	}
+
+	// The nonce ties the token to the login this server started
+	if claims.Nonce != nonce {
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: nonce mismatch", ErrOIDCTokenInvalid)
+	}
+	if claims.Subject == "" {
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: missing subject", ErrOIDCTokenInvalid)
+	}
+	return claims, nil
+}
+
+func (c *oidcClient) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.discovery != nil {
+		return c.discovery, nil
+	}
+
+	var discovery oidcDiscovery
+	if err := c.getJSON(ctx, strings.TrimRight(c.config.Issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("oidc discovery for %s: %w", c.config.Name, err)
+	}
+	// OIDC Discovery 4.3: the document must be for the configured issuer
+	if strings.TrimRight(discovery.Issuer, "/") != strings.TrimRight(c.config.Issuer, "/") {
+		return nil, fmt.Errorf("oidc discovery for %s: issuer %q does not match %q", c.config.Name, discovery.Issuer, c.config.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc discovery for %s: missing endpoints", c.config.Name)
+	}
+
+	c.discovery = &discovery
+	return c.discovery, nil
+}
+
+// getKey returns the signing key for kid, refetching the key set once when the
+// kid is unknown so provider key rotation is picked up.
+func (c *oidcClient) getKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	key, ok := c.keys[kid]
+	c.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := c.fetchKeys(ctx, discovery.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+
+	key, ok = keys[kid]
+	if !ok {
+		// A key set with a single key may leave kid out of the token
+		if kid == "" && len(keys) == 1 {
+			for _, only := range keys {
+				return only, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+func (c *oidcClient) fetchKeys(ctx context.Context, jwksURI string) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := c.getJSON(ctx, jwksURI, &set); err != nil {
+		return nil, fmt.Errorf("fetch signing keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no usable RSA signing keys")
+	}
+	return keys, nil
+}
+
+func (c *oidcClient) getJSON(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", endpoint, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
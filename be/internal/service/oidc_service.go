@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+const (
+	// OIDCLoginStateTTL is how long the user has to finish signing in at the
+	// provider before the login has to be started again.
+	OIDCLoginStateTTL = 10 * time.Minute
+
+	oidcHTTPTimeout = 10 * time.Second
+)
+
+var defaultOIDCScopes = []string{"openid", "email", "profile"}
+
+var (
+	ErrOIDCProviderNotFound   = errors.New("unknown sign-in provider")
+	ErrOIDCStateInvalid       = errors.New("sign-in request expired or was already used")
+	ErrOIDCCodeInvalid        = errors.New("authorization code was rejected by the provider")
+	ErrOIDCTokenInvalid       = errors.New("provider returned an invalid ID token")
+	ErrOIDCEmailNotVerified   = errors.New("provider did not confirm the email address")
+	ErrOIDCAccountNotVerified = errors.New("existing account has an unverified email")
+)
+
+// OIDCProviderConfig is an OpenID Connect provider users can sign in with.
+// RedirectURL is the frontend page the provider sends the user back to; it
+// must be registered with the provider.
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// OIDCProvidersFromEnv reads OIDC_PROVIDERS, a comma separated list of provider
+// names, and for each name OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID,
+// OIDC_<NAME>_CLIENT_SECRET, OIDC_<NAME>_REDIRECT_URL and the optional
+// space separated OIDC_<NAME>_SCOPES.
+func OIDCProvidersFromEnv() ([]OIDCProviderConfig, error) {
+	var providers []OIDCProviderConfig
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		cfg := OIDCProviderConfig{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+		if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+			return nil, fmt.Errorf("OIDC provider %q needs %sISSUER, %sCLIENT_ID and %sREDIRECT_URL", name, prefix, prefix, prefix)
+		}
+		providers = append(providers, cfg)
+	}
+	return providers, nil
+}
+
+// OIDCService signs users in through external OpenID Connect providers using
+// the authorization code flow with PKCE. The provider account is linked to the
+// user with the same verified email, or to a new user when there is none, and
+// the sign-in finishes through AuthService like a password login.
+type OIDCService interface {
+	ListProviders() []string
+	// StartLogin returns the provider URL to send the browser to, and the PKCE
+	// code verifier the browser keeps until it completes the login.
+	StartLogin(ctx context.Context, provider string) (authorizationURL, codeVerifier string, err error)
+	// CompleteLogin handles the code and state the provider redirected back with.
+	// codeVerifier must be the one StartLogin gave the same browser, so a code
+	// and state from someone else's login are rejected. Like a password login,
+	// it ends in a two-factor challenge when the user has two-factor
+	// authentication enabled.
+	CompleteLogin(ctx context.Context, provider, code, state, codeVerifier, userAgent, ipAddress string) (LoginResult, error)
+}
+
+type oidcService struct {
+	clients      map[string]*oidcClient
+	identityRepo repository.IdentityRepository
+	userRepo     repository.UserRepository
+	userService  UserService
+	authService  AuthService
+}
+
+func NewOIDCService(
+	providers []OIDCProviderConfig,
+	identityRepo repository.IdentityRepository,
+	userRepo repository.UserRepository,
+	userService UserService,
+	authService AuthService,
+) OIDCService {
+	httpClient := &http.Client{Timeout: oidcHTTPTimeout}
+
+	clients := make(map[string]*oidcClient, len(providers))
+	for _, cfg := range providers {
+		if len(cfg.Scopes) == 0 {
+			cfg.Scopes = defaultOIDCScopes
+		}
+		clients[cfg.Name] = newOIDCClient(cfg, httpClient)
+	}
+
+	return &oidcService{
+		clients:      clients,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		userService:  userService,
+		authService:  authService,
+	}
+}
+
+func (s *oidcService) ListProviders() []string {
+	names := make([]string, 0, len(s.clients))
+	for name := range s.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *oidcService) StartLogin(ctx context.Context, provider string) (string, string, error) {
+	client, ok := s.clients[provider]
+	if !ok {
+		return "", "", ErrOIDCProviderNotFound
+	}
+
+	// Abandoned logins are cleared here rather than by a job
+	if err := s.identityRepo.DeleteExpiredOIDCLoginStates(ctx); err != nil {
+		return "", "", err
+	}
+
+	state, err := randomURLToken()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomURLToken()
+	if err != nil {
+		return "", "", err
+	}
+	codeVerifier, err := randomURLToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	authorizationURL, err := client.authorizationURL(ctx, state, nonce, pkceChallenge(codeVerifier))
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := s.identityRepo.CreateOIDCLoginState(ctx, sqlc.CreateOIDCLoginStateParams{
+		State:        state,
+		Provider:     provider,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    pgtype.Timestamptz{Time: time.Now().Add(OIDCLoginStateTTL), Valid: true},
+	}); err != nil {
+		return "", "", err
+	}
+
+	return authorizationURL, codeVerifier, nil
+}
+
+func (s *oidcService) CompleteLogin(ctx context.Context, provider, code, state, codeVerifier, userAgent, ipAddress string) (LoginResult, error) {
+	client, ok := s.clients[provider]
+	if !ok {
+		return LoginResult{}, ErrOIDCProviderNotFound
+	}
+
+	loginState, err := s.identityRepo.ConsumeOIDCLoginState(ctx, state)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return LoginResult{}, ErrOIDCStateInvalid
+		}
+		return LoginResult{}, err
+	}
+	if loginState.Provider != provider {
+		return LoginResult{}, ErrOIDCStateInvalid
+	}
+	// Only the browser that started the login holds the verifier; this stops
+	// a code and state from another login being submitted here
+	if subtle.ConstantTimeCompare([]byte(loginState.CodeVerifier), []byte(codeVerifier)) != 1 {
+		return LoginResult{}, ErrOIDCStateInvalid
+	}
+
+	claims, err := client.exchangeCode(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	user, err := s.resolveUser(ctx, provider, claims)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	return s.authService.SignIn(ctx, user, userAgent, ipAddress)
+}
+
+// resolveUser finds the user for a provider account: the one already linked to
+// it, else the one with the same email, else a new user.
+func (s *oidcService) resolveUser(ctx context.Context, provider string, claims oidcIDTokenClaims) (sqlc.User, error) {
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+	emailText := pgtype.Text{String: email, Valid: email != ""}
+
+	identity, err := s.identityRepo.GetUserIdentity(ctx, sqlc.GetUserIdentityParams{
+		Provider: provider,
+		Subject:  claims.Subject,
+	})
+	if err == nil {
+		if err := s.identityRepo.UpdateUserIdentityLogin(ctx, sqlc.UpdateUserIdentityLoginParams{
+			ID:    identity.ID,
+			Email: emailText,
+		}); err != nil {
+			return sqlc.User{}, err
+		}
+		user, err := s.userRepo.GetUserByID(ctx, identity.UserID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return sqlc.User{}, ErrUserNotFound
+			}
+			return sqlc.User{}, err
+		}
+		return user, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.User{}, err
+	}
+
+	// Linking by email is only safe when the provider vouches for the address
+	if email == "" || !bool(claims.EmailVerified) {
+		return sqlc.User{}, ErrOIDCEmailNotVerified
+	}
+
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	switch {
+	case err == nil:
+		// Someone could have signed up with this email without owning it and
+		// still know the password, so only verified accounts are linked
+		if !user.EmailVerifiedAt.Valid {
+			return sqlc.User{}, ErrOIDCAccountNotVerified
+		}
+	case errors.Is(err, pgx.ErrNoRows):
+		// No password: the account signs in through the provider until the
+		// user sets one with a password reset
+		user, err = s.userService.CreateUserWithoutPassword(ctx, claims.Name, email)
+		if err != nil {
+			return sqlc.User{}, err
+		}
+	default:
+		return sqlc.User{}, err
+	}
+
+	if _, err := s.identityRepo.CreateUserIdentity(ctx, sqlc.CreateUserIdentityParams{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    emailText,
+	}); err != nil {
+		return sqlc.User{}, err
+	}
+
+	return user, nil
+}
+
+// randomURLToken returns 32 random bytes, URL-safe encoded.
+func randomURLToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// pkceChallenge derives the S256 code challenge from a PKCE code verifier (RFC 7636).
+func pkceChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
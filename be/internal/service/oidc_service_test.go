@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockIdentityRepository for testing
+type MockIdentityRepository struct {
+	GetUserIdentityFunc              func(ctx context.Context, params sqlc.GetUserIdentityParams) (sqlc.UserIdentity, error)
+	CreateUserIdentityFunc           func(ctx context.Context, params sqlc.CreateUserIdentityParams) (sqlc.UserIdentity, error)
+	UpdateUserIdentityLoginFunc      func(ctx context.Context, params sqlc.UpdateUserIdentityLoginParams) error
+	CreateOIDCLoginStateFunc         func(ctx context.Context, params sqlc.CreateOIDCLoginStateParams) error
+	ConsumeOIDCLoginStateFunc        func(ctx context.Context, state string) (sqlc.OidcLoginState, error)
+	DeleteExpiredOIDCLoginStatesFunc func(ctx context.Context) error
+}
+
+func (m *MockIdentityRepository) GetUserIdentity(ctx context.Context, params sqlc.GetUserIdentityParams) (sqlc.UserIdentity, error) {
+	if m.GetUserIdentityFunc != nil {
+		return m.GetUserIdentityFunc(ctx, params)
+	}
+	return sqlc.UserIdentity{}, pgx.ErrNoRows
+}
+
+func (m *MockIdentityRepository) CreateUserIdentity(ctx context.Context, params sqlc.CreateUserIdentityParams) (sqlc.UserIdentity, error) {
+	if m.CreateUserIdentityFunc != nil {
+		return m.CreateUserIdentityFunc(ctx, params)
+	}
+	return sqlc.UserIdentity{UserID: params.UserID, Provider: params.Provider, Subject: params.Subject}, nil
+}
+
+func (m *MockIdentityRepository) UpdateUserIdentityLogin(ctx context.Context, params sqlc.UpdateUserIdentityLoginParams) error {
+	if m.UpdateUserIdentityLoginFunc != nil {
+		return m.UpdateUserIdentityLoginFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockIdentityRepository) CreateOIDCLoginState(ctx context.Context, params sqlc.CreateOIDCLoginStateParams) error {
+	if m.CreateOIDCLoginStateFunc != nil {
+		return m.CreateOIDCLoginStateFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockIdentityRepository) ConsumeOIDCLoginState(ctx context.Context, state string) (sqlc.OidcLoginState, error) {
+	if m.ConsumeOIDCLoginStateFunc != nil {
+		return m.ConsumeOIDCLoginStateFunc(ctx, state)
+	}
+	return sqlc.OidcLoginState{}, pgx.ErrNoRows
+}
+
+func (m *MockIdentityRepository) DeleteExpiredOIDCLoginStates(ctx context.Context) error {
+	if m.DeleteExpiredOIDCLoginStatesFunc != nil {
+		return m.DeleteExpiredOIDCLoginStatesFunc(ctx)
+	}
+	return nil
+}
+
+// newOIDCStateStore backs the login state methods of a MockIdentityRepository
+// with a map, deleting states as they are consumed.
+func newOIDCStateStore() (*MockIdentityRepository, map[string]*sqlc.OidcLoginState) {
+	states := map[string]*sqlc.OidcLoginState{}
+	repo := &MockIdentityRepository{
+		CreateOIDCLoginStateFunc: func(ctx context.Context, params sqlc.CreateOIDCLoginStateParams) error {
+			states[params.State] = &sqlc.OidcLoginState{
+				State:        params.State,
+				Provider:     params.Provider,
+				CodeVerifier: params.CodeVerifier,
+				Nonce:        params.Nonce,
+				ExpiresAt:    params.ExpiresAt,
+			}
+			return nil
+		},
+		ConsumeOIDCLoginStateFunc: func(ctx context.Context, state string) (sqlc.OidcLoginState, error) {
+			loginState, ok := states[state]
+			if !ok || !loginState.ExpiresAt.Time.After(time.Now()) {
+				return sqlc.OidcLoginState{}, pgx.ErrNoRows
+			}
+			delete(states, state)
+			return *loginState, nil
+		},
+	}
+	return repo, states
+}
+
+func newTestOIDCService(provider *testutil.MockOIDCProvider, identityRepo *MockIdentityRepository, userRepo *testutil.MockUserRepository, authService *MockAuthService) OIDCService {
+	return NewOIDCService([]OIDCProviderConfig{{
+		Name:        "mock",
+		Issuer:      provider.Issuer(),
+		ClientID:    provider.ClientID,
+		RedirectURL: "http://localhost:3000/auth/callback/mock",
+	}}, identityRepo, userRepo, NewUserService(userRepo), authService)
+}
+
+func TestOIDCService_CompleteLogin(t *testing.T) {
+	provider := testutil.NewMockOIDCProvider("splitplus-test")
+	defer provider.Close()
+
+	existingID := testutil.CreateTestUUID(1)
+	linkedID := testutil.CreateTestUUID(2)
+	newID := testutil.CreateTestUUID(3)
+	verified := pgtype.Timestamptz{Time: time.Now().Add(-24 * time.Hour), Valid: true}
+
+	tests := []struct {
+		name          string
+		user          testutil.MockOIDCUser
+		linked        bool
+		localAccount  *sqlc.User
+		expectedUser  pgtype.UUID
+		expectedError error
+		expectCreate  bool
+		expectLink    bool
+	}{
+		{
+			name:         "new user is created and linked",
+			user:         testutil.MockOIDCUser{Subject: "sub-new", Email: "New@Example.com", EmailVerified: true, Name: "New User"},
+			expectedUser: newID,
+			expectCreate: true,
+			expectLink:   true,
+		},
+		{
+			name:         "existing account is linked by verified email",
+			user:         testutil.MockOIDCUser{Subject: "sub-existing", Email: "existing@example.com", EmailVerified: true},
+			localAccount: &sqlc.User{ID: existingID, Email: "existing@example.com", EmailVerifiedAt: verified},
+			expectedUser: existingID,
+			expectLink:   true,
+		},
+		{
+			name:         "linked identity signs in its user even if the email changed",
+			user:         testutil.MockOIDCUser{Subject: "sub-linked", Email: "changed@example.com", EmailVerified: false},
+			linked:       true,
+			expectedUser: linkedID,
+		},
+		{
+			name:          "unverified provider email is refused",
+			user:          testutil.MockOIDCUser{Subject: "sub-unverified", Email: "existing@example.com", EmailVerified: false},
+			localAccount:  &sqlc.User{ID: existingID, Email: "existing@example.com", EmailVerifiedAt: verified},
+			expectedError: ErrOIDCEmailNotVerified,
+		},
+		{
+			name:          "unverified local account is not linked",
+			user:          testutil.MockOIDCUser{Subject: "sub-existing", Email: "existing@example.com", EmailVerified: true},
+			localAccount:  &sqlc.User{ID: existingID, Email: "existing@example.com"},
+			expectedError: ErrOIDCAccountNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identityRepo, _ := newOIDCStateStore()
+			identityRepo.GetUserIdentityFunc = func(ctx context.Context, params sqlc.GetUserIdentityParams) (sqlc.UserIdentity, error) {
+				if tt.linked && params.Provider == "mock" && params.Subject == tt.user.Subject {
+					return sqlc.UserIdentity{ID: testutil.CreateTestUUID(50), UserID: linkedID, Provider: "mock", Subject: tt.user.Subject}, nil
+				}
+				return sqlc.UserIdentity{}, pgx.ErrNoRows
+			}
+			linked := false
+			identityRepo.CreateUserIdentityFunc = func(ctx context.Context, params sqlc.CreateUserIdentityParams) (sqlc.UserIdentity, error) {
+				linked = true
+				if params.Subject != tt.user.Subject || params.Provider != "mock" {
+					t.Errorf("unexpected identity %s/%s", params.Provider, params.Subject)
+				}
+				return sqlc.UserIdentity{UserID: params.UserID}, nil
+			}
+
+			created := false
+			userRepo := &testutil.MockUserRepository{
+				GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+					if tt.localAccount != nil && tt.localAccount.Email == email {
+						return *tt.localAccount, nil
+					}
+					return sqlc.User{}, pgx.ErrNoRows
+				},
+				GetUserByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+					return sqlc.User{ID: id}, nil
+				},
+				CreateUserFunc: func(ctx context.Context, params sqlc.CreateUserParams) (sqlc.User, error) {
+					created = true
+					if params.Email != "new@example.com" {
+						t.Errorf("expected normalized email, got %q", params.Email)
+					}
+					if params.PasswordHash != unusablePasswordHash {
+						t.Errorf("expected an unusable password for an OIDC account, got %q", params.PasswordHash)
+					}
+					return sqlc.User{ID: newID, Email: params.Email}, nil
+				},
+			}
+
+			var sessionUser pgtype.UUID
+			authService := &MockAuthService{
+				SignInFunc: func(ctx context.Context, user sqlc.User, userAgent, ipAddress string) (LoginResult, error) {
+					sessionUser = user.ID
+					return LoginResult{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 900}, nil
+				},
+			}
+
+			svc := newTestOIDCService(provider, identityRepo, userRepo, authService)
+			ctx := context.Background()
+
+			authorizationURL, codeVerifier, err := svc.StartLogin(ctx, "mock")
+			if err != nil {
+				t.Fatalf("StartLogin: %v", err)
+			}
+			code, state, err := provider.Authorize(authorizationURL, tt.user)
+			if err != nil {
+				t.Fatalf("Authorize: %v", err)
+			}
+
+			result, err := svc.CompleteLogin(ctx, "mock", code, state, codeVerifier, "agent", "127.0.0.1")
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if tt.expectedError != nil {
+				if sessionUser.Valid {
+					t.Error("expected no session to be started")
+				}
+				return
+			}
+
+			if result.AccessToken != "access" || result.RefreshToken != "refresh" {
+				t.Errorf("expected tokens from AuthService.SignIn, got %q/%q", result.AccessToken, result.RefreshToken)
+			}
+			if sessionUser != tt.expectedUser {
+				t.Errorf("expected session for %v, got %v", tt.expectedUser, sessionUser)
+			}
+			if created != tt.expectCreate {
+				t.Errorf("expected created=%v, got %v", tt.expectCreate, created)
+			}
+			if linked != tt.expectLink {
+				t.Errorf("expected linked=%v, got %v", tt.expectLink, linked)
+			}
+		})
+	}
+}
+
+func TestOIDCService_CompleteLogin_RejectsTampering(t *testing.T) {
+	provider := testutil.NewMockOIDCProvider("splitplus-test")
+	defer provider.Close()
+
+	user := testutil.MockOIDCUser{Subject: "sub", Email: "user@example.com", EmailVerified: true}
+
+	tests := []struct {
+		name          string
+		tamper        func(states map[string]*sqlc.OidcLoginState, state string)
+		verifier      string // submitted instead of the one StartLogin returned
+		provider      string
+		expectedError error
+	}{
+		{
+			name: "PKCE verifier that doesn't match the challenge",
+			tamper: func(states map[string]*sqlc.OidcLoginState, state string) {
+				states[state].CodeVerifier = "someone-elses-verifier"
+			},
+			verifier:      "someone-elses-verifier",
+			provider:      "mock",
+			expectedError: ErrOIDCCodeInvalid,
+		},
+		{
+			name:          "code and state from another browser's login",
+			verifier:      "attacker-browser-verifier",
+			provider:      "mock",
+			expectedError: ErrOIDCStateInvalid,
+		},
+		{
+			name: "nonce that doesn't match the ID token",
+			tamper: func(states map[string]*sqlc.OidcLoginState, state string) {
+				states[state].Nonce = "other-nonce"
+			},
+			provider:      "mock",
+			expectedError: ErrOIDCTokenInvalid,
+		},
+		{
+			name: "expired state",
+			tamper: func(states map[string]*sqlc.OidcLoginState, state string) {
+				states[state].ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+			},
+			provider:      "mock",
+			expectedError: ErrOIDCStateInvalid,
+		},
+		{
+			name: "state from another provider",
+			tamper: func(states map[string]*sqlc.OidcLoginState, state string) {
+				states[state].Provider = "other"
+			},
+			provider:      "mock",
+			expectedError: ErrOIDCStateInvalid,
+		},
+		{
+			name:          "unknown provider",
+			provider:      "nope",
+			expectedError: ErrOIDCProviderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identityRepo, states := newOIDCStateStore()
+			svc := newTestOIDCService(provider, identityRepo, &testutil.MockUserRepository{
+				GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+					return sqlc.User{}, pgx.ErrNoRows
+				},
+			}, &MockAuthService{})
+			ctx := context.Background()
+
+			authorizationURL, codeVerifier, err := svc.StartLogin(ctx, "mock")
+			if err != nil {
+				t.Fatalf("StartLogin: %v", err)
+			}
+			code, state, err := provider.Authorize(authorizationURL, user)
+			if err != nil {
+				t.Fatalf("Authorize: %v", err)
+			}
+			if tt.tamper != nil {
+				tt.tamper(states, state)
+			}
+			if tt.verifier != "" {
+				codeVerifier = tt.verifier
+			}
+
+			_, err = svc.CompleteLogin(ctx, tt.provider, code, state, codeVerifier, "", "")
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestOIDCService_CompleteLogin_StateIsSingleUse(t *testing.T) {
+	provider := testutil.NewMockOIDCProvider("splitplus-test")
+	defer provider.Close()
+
+	identityRepo, _ := newOIDCStateStore()
+	svc := newTestOIDCService(provider, identityRepo, &testutil.MockUserRepository{
+		GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+			return sqlc.User{}, pgx.ErrNoRows
+		},
+	}, &MockAuthService{})
+	ctx := context.Background()
+
+	authorizationURL, codeVerifier, err := svc.StartLogin(ctx, "mock")
+	if err != nil {
+		t.Fatalf("StartLogin: %v", err)
+	}
+	code, state, err := provider.Authorize(authorizationURL, testutil.MockOIDCUser{Subject: "sub", Email: "user@example.com", EmailVerified: true})
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+
+	if _, err := svc.CompleteLogin(ctx, "mock", code, state, codeVerifier, "", ""); err != nil {
+		t.Fatalf("first callback: %v", err)
+	}
+	if _, err := svc.CompleteLogin(ctx, "mock", code, state, codeVerifier, "", ""); !errors.Is(err, ErrOIDCStateInvalid) {
+		t.Errorf("replayed callback: expected %v, got %v", ErrOIDCStateInvalid, err)
+	}
+}
+
+func TestOIDCService_ListProviders(t *testing.T) {
+	svc := NewOIDCService([]OIDCProviderConfig{
+		{Name: "microsoft", Issuer: "https://login.example.com", ClientID: "a", RedirectURL: "http://localhost"},
+		{Name: "google", Issuer: "https://accounts.example.com", ClientID: "b", RedirectURL: "http://localhost"},
+	}, &MockIdentityRepository{}, &testutil.MockUserRepository{}, &testutil.MockUserService{}, &MockAuthService{})
+
+	providers := svc.ListProviders()
+	if len(providers) != 2 || providers[0] != "google" || providers[1] != "microsoft" {
+		t.Errorf("expected sorted providers [google microsoft], got %v", providers)
+	}
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+const (
+	PaymentProviderUPI    = "upi"
+	PaymentProviderPayPal = "paypal"
+	PaymentProviderVenmo  = "venmo"
+	PaymentProviderIBAN   = "iban"
+)
+
+var (
+	ErrPaymentProviderInvalid = errors.New("invalid payment provider")
+	ErrPaymentHandleInvalid   = errors.New("invalid payment handle")
+	ErrPaymentMethodNotFound  = errors.New("payment method not found")
+	ErrDebtNotFound           = errors.New("no outstanding debt to this member")
+	ErrPaymentAmountInvalid   = errors.New("amount must be positive and not more than the outstanding debt")
+)
+
+var (
+	upiHandlePattern    = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,255}@[a-z][a-z0-9]{1,63}$`)
+	paypalHandlePattern = regexp.MustCompile(`^[A-Za-z0-9]{1,20}$`)
+	venmoHandlePattern  = regexp.MustCompile(`^[A-Za-z0-9_-]{5,30}$`)
+	ibanPattern         = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+)
+
+// paymentProviderCurrencies limits providers that only move a single currency.
+// Providers missing from the map accept any currency.
+var paymentProviderCurrencies = map[string]string{
+	PaymentProviderUPI:   "INR",
+	PaymentProviderVenmo: "USD",
+}
+
+// PaymentLink is a ready-made URI that opens the creditor's payment app with
+// the amount filled in.
+type PaymentLink struct {
+	Provider string `json:"provider"`
+	Handle   string `json:"handle"`
+	URL      string `json:"url"`
+}
+
+type SetPaymentMethodInput struct {
+	UserID   pgtype.UUID
+	Provider string
+	Handle   string
+	Priority int32
+}
+
+type PayDebtInput struct {
+	GroupID              pgtype.UUID
+	PayerID              pgtype.UUID
+	CreditorID           pgtype.UUID
+	Provider             string
+	Amount               string // optional, defaults to the whole outstanding debt
+	TransactionReference string
+}
+
+// PaymentMethodService manages the payment handles on a user's profile and turns
+// a used payment link into a settlement waiting for the creditor to confirm.
+type PaymentMethodService interface {
+	ListPaymentMethods(ctx context.Context, userID pgtype.UUID) ([]sqlc.UserPaymentMethod, error)
+	SetPaymentMethod(ctx context.Context, input SetPaymentMethodInput) (sqlc.UserPaymentMethod, error)
+	DeletePaymentMethod(ctx context.Context, userID pgtype.UUID, provider string) error
+	PayDebt(ctx context.Context, input PayDebtInput) (sqlc.Settlement, error)
+}
+
+type paymentMethodService struct {
+	repo              repository.PaymentMethodRepository
+	balanceService    BalanceService
+	settlementService SettlementService
+}
+
+func NewPaymentMethodService(repo repository.PaymentMethodRepository, balanceService BalanceService, settlementService SettlementService) PaymentMethodService {
+	return &paymentMethodService{
+		repo:              repo,
+		balanceService:    balanceService,
+		settlementService: settlementService,
+	}
+}
+
+func (s *paymentMethodService) ListPaymentMethods(ctx context.Context, userID pgtype.UUID) ([]sqlc.UserPaymentMethod, error) {
+	return s.repo.ListUserPaymentMethods(ctx, userID)
+}
+
+func (s *paymentMethodService) SetPaymentMethod(ctx context.Context, input SetPaymentMethodInput) (sqlc.UserPaymentMethod, error) {
+	handle, err := NormalizePaymentHandle(input.Provider, input.Handle)
+	if err != nil {
+		return sqlc.UserPaymentMethod{}, err
+	}
+
+	return s.repo.UpsertUserPaymentMethod(ctx, sqlc.UpsertUserPaymentMethodParams{
+		UserID:   input.UserID,
+		Provider: input.Provider,
+		Handle:   handle,
+		Priority: input.Priority,
+	})
+}
+
+func (s *paymentMethodService) DeletePaymentMethod(ctx context.Context, userID pgtype.UUID, provider string) error {
+	_, err := s.repo.GetUserPaymentMethod(ctx, sqlc.GetUserPaymentMethodParams{UserID: userID, Provider: provider})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPaymentMethodNotFound
+		}
+		return err
+	}
+
+	return s.repo.DeleteUserPaymentMethod(ctx, sqlc.DeleteUserPaymentMethodParams{UserID: userID, Provider: provider})
+}
+
+func (s *paymentMethodService) PayDebt(ctx context.Context, input PayDebtInput) (sqlc.Settlement, error) {
+	if !isPaymentProvider(input.Provider) {
+		return sqlc.Settlement{}, ErrPaymentProviderInvalid
+	}
+
+	// Pending settlements already count against the debt, so paying the same
+	// link twice finds nothing left to pay
+	debts, err := s.balanceService.GetSimplifiedDebts(ctx, input.GroupID, input.PayerID, BalanceOptions{})
+	if err != nil {
+		return sqlc.Settlement{}, err
+	}
+
+	var outstanding decimal.Decimal
+	found := false
+	for _, debt := range debts {
+		if debt.DebtorID == input.PayerID && debt.CreditorID == input.CreditorID {
+			outstanding, err = decimal.NewFromString(debt.Amount)
+			if err != nil {
+				return sqlc.Settlement{}, err
+			}
+			found = true
+			break
+		}
+	}
+	if !found {
+		return sqlc.Settlement{}, ErrDebtNotFound
+	}
+
+	amount := outstanding
+	if strings.TrimSpace(input.Amount) != "" {
+		amount, err = decimal.NewFromString(strings.TrimSpace(input.Amount))
+		if err != nil || amount.LessThanOrEqual(decimal.Zero) || amount.GreaterThan(outstanding) {
+			return sqlc.Settlement{}, ErrPaymentAmountInvalid
+		}
+	}
+
+	if _, err := s.repo.GetUserPaymentMethod(ctx, sqlc.GetUserPaymentMethodParams{
+		UserID:   input.CreditorID,
+		Provider: input.Provider,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.Settlement{}, ErrPaymentMethodNotFound
+		}
+		return sqlc.Settlement{}, err
+	}
+
+	return s.settlementService.CreateSettlement(ctx, CreateSettlementInput{
+		GroupID:              input.GroupID,
+		PayerID:              input.PayerID,
+		PayeeID:              input.CreditorID,
+		Amount:               amount.StringFixed(2),
+		Status:               "pending",
+		PaymentMethod:        input.Provider,
+		TransactionReference: input.TransactionReference,
+		Notes:                "Paid with a " + paymentProviderLabel(input.Provider) + " payment link",
+		CreatedBy:            input.PayerID,
+	})
+}
+
+// NormalizePaymentHandle validates a handle for provider and returns it in the
+// form stored on the profile. Common pasted forms such as "@name" or a full
+// paypal.me URL are accepted.
+func NormalizePaymentHandle(provider, handle string) (string, error) {
+	handle = strings.TrimSpace(handle)
+
+	switch provider {
+	case PaymentProviderUPI:
+		handle = strings.ToLower(handle)
+		if !upiHandlePattern.MatchString(handle) {
+			return "", ErrPaymentHandleInvalid
+		}
+	case PaymentProviderPayPal:
+		for _, prefix := range []string{"https://", "http://", "www.", "paypal.me/", "@"} {
+			if len(handle) >= len(prefix) && strings.EqualFold(handle[:len(prefix)], prefix) {
+				handle = handle[len(prefix):]
+			}
+		}
+		handle = strings.TrimSuffix(handle, "/")
+		if !paypalHandlePattern.MatchString(handle) {
+			return "", ErrPaymentHandleInvalid
+		}
+	case PaymentProviderVenmo:
+		handle = strings.TrimPrefix(handle, "@")
+		if !venmoHandlePattern.MatchString(handle) {
+			return "", ErrPaymentHandleInvalid
+		}
+	case PaymentProviderIBAN:
+		handle = strings.ToUpper(strings.Join(strings.Fields(handle), ""))
+		if !ibanPattern.MatchString(handle) || !validIBANChecksum(handle) {
+			return "", ErrPaymentHandleInvalid
+		}
+	default:
+		return "", ErrPaymentProviderInvalid
+	}
+
+	return handle, nil
+}
+
+// BuildPaymentLinks returns a link for every method that can receive
+// currencyCode, in the order the methods are given (the creditor's preference).
+func BuildPaymentLinks(methods []sqlc.UserPaymentMethod, amount decimal.Decimal, currencyCode, payeeName, note string) []PaymentLink {
+	currencyCode = strings.ToUpper(currencyCode)
+	value := amount.StringFixed(2)
+
+	links := make([]PaymentLink, 0, len(methods))
+	for _, method := range methods {
+		if only, ok := paymentProviderCurrencies[method.Provider]; ok && only != currencyCode {
+			continue
+		}
+
+		var link string
+		switch method.Provider {
+		case PaymentProviderUPI:
+			link = "upi://pay?" + encodeQuery("pa", method.Handle, "pn", payeeName, "am", value, "cu", currencyCode, "tn", note)
+		case PaymentProviderPayPal:
+			link = "https://paypal.me/" + url.PathEscape(method.Handle) + "/" + value + currencyCode
+		case PaymentProviderVenmo:
+			link = "https://venmo.com/" + url.PathEscape(method.Handle) + "?" + encodeQuery("txn", "pay", "amount", value, "note", note)
+		case PaymentProviderIBAN:
+			// RFC 8905 payto URI
+			link = "payto://iban/" + method.Handle + "?" + encodeQuery("amount", currencyCode+":"+value, "receiver-name", payeeName, "message", note)
+		default:
+			continue
+		}
+
+		links = append(links, PaymentLink{Provider: method.Provider, Handle: method.Handle, URL: link})
+	}
+	return links
+}
+
+// queryValueReplacer undoes escaping that payment apps don't expect: spaces
+// must be %20 rather than "+", and "@" and ":" are valid in a query as-is.
+var queryValueReplacer = strings.NewReplacer("+", "%20", "%40", "@", "%3A", ":")
+
+// encodeQuery encodes key/value pairs in the given order, skipping empty values.
+func encodeQuery(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			continue
+		}
+		parts = append(parts, pairs[i]+"="+queryValueReplacer.Replace(url.QueryEscape(pairs[i+1])))
+	}
+	return strings.Join(parts, "&")
+}
+
+// validIBANChecksum runs the ISO 13616 mod-97 check.
+func validIBANChecksum(iban string) bool {
+	rearranged := iban[4:] + iban[:4]
+
+	var digits strings.Builder
+	for _, r := range rearranged {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(big.NewInt(int64(r-'A') + 10).String())
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok {
+		return false
+	}
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+func isPaymentProvider(provider string) bool {
+	switch provider {
+	case PaymentProviderUPI, PaymentProviderPayPal, PaymentProviderVenmo, PaymentProviderIBAN:
+		return true
+	}
+	return false
+}
+
+func paymentProviderLabel(provider string) string {
+	switch provider {
+	case PaymentProviderUPI:
+		return "UPI"
+	case PaymentProviderPayPal:
+		return "PayPal"
+	case PaymentProviderVenmo:
+		return "Venmo"
+	case PaymentProviderIBAN:
+		return "bank transfer"
+	}
+	return provider
+}
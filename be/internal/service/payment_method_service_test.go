@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockPaymentMethodRepository for testing
+type MockPaymentMethodRepository struct {
+	ListUserPaymentMethodsFunc  func(ctx context.Context, userID pgtype.UUID) ([]sqlc.UserPaymentMethod, error)
+	GetUserPaymentMethodFunc    func(ctx context.Context, params sqlc.GetUserPaymentMethodParams) (sqlc.UserPaymentMethod, error)
+	UpsertUserPaymentMethodFunc func(ctx context.Context, params sqlc.UpsertUserPaymentMethodParams) (sqlc.UserPaymentMethod, error)
+	DeleteUserPaymentMethodFunc func(ctx context.Context, params sqlc.DeleteUserPaymentMethodParams) error
+}
+
+func (m *MockPaymentMethodRepository) ListUserPaymentMethods(ctx context.Context, userID pgtype.UUID) ([]sqlc.UserPaymentMethod, error) {
+	if m.ListUserPaymentMethodsFunc != nil {
+		return m.ListUserPaymentMethodsFunc(ctx, userID)
+	}
+	return []sqlc.UserPaymentMethod{}, nil
+}
+
+func (m *MockPaymentMethodRepository) GetUserPaymentMethod(ctx context.Context, params sqlc.GetUserPaymentMethodParams) (sqlc.UserPaymentMethod, error) {
+	if m.GetUserPaymentMethodFunc != nil {
+		return m.GetUserPaymentMethodFunc(ctx, params)
+	}
+	return sqlc.UserPaymentMethod{}, pgx.ErrNoRows
+}
+
+func (m *MockPaymentMethodRepository) UpsertUserPaymentMethod(ctx context.Context, params sqlc.UpsertUserPaymentMethodParams) (sqlc.UserPaymentMethod, error) {
+	if m.UpsertUserPaymentMethodFunc != nil {
+		return m.UpsertUserPaymentMethodFunc(ctx, params)
+	}
+	return sqlc.UserPaymentMethod{UserID: params.UserID, Provider: params.Provider, Handle: params.Handle, Priority: params.Priority}, nil
+}
+
+func (m *MockPaymentMethodRepository) DeleteUserPaymentMethod(ctx context.Context, params sqlc.DeleteUserPaymentMethodParams) error {
+	if m.DeleteUserPaymentMethodFunc != nil {
+		return m.DeleteUserPaymentMethodFunc(ctx, params)
+	}
+	return nil
+}
+
+func TestNormalizePaymentHandle(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		handle   string
+		want     string
+		wantErr  error
+	}{
+		{name: "upi lowercased", provider: PaymentProviderUPI, handle: " Alice.Doe@OkAxis ", want: "alice.doe@okaxis"},
+		{name: "upi without bank", provider: PaymentProviderUPI, handle: "alice", wantErr: ErrPaymentHandleInvalid},
+		{name: "paypal url", provider: PaymentProviderPayPal, handle: "https://www.PayPal.me/AliceD/", want: "AliceD"},
+		{name: "paypal at sign", provider: PaymentProviderPayPal, handle: "@AliceD", want: "AliceD"},
+		{name: "paypal with path", provider: PaymentProviderPayPal, handle: "paypal.me/alice/10", wantErr: ErrPaymentHandleInvalid},
+		{name: "venmo at sign", provider: PaymentProviderVenmo, handle: "@alice-doe", want: "alice-doe"},
+		{name: "venmo too short", provider: PaymentProviderVenmo, handle: "bob", wantErr: ErrPaymentHandleInvalid},
+		{name: "iban with spaces", provider: PaymentProviderIBAN, handle: "de89 3704 0044 0532 0130 00", want: "DE89370400440532013000"},
+		{name: "iban bad checksum", provider: PaymentProviderIBAN, handle: "DE88370400440532013000", wantErr: ErrPaymentHandleInvalid},
+		{name: "unknown provider", provider: "cash", handle: "anything", wantErr: ErrPaymentProviderInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePaymentHandle(tt.provider, tt.handle)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildPaymentLinks(t *testing.T) {
+	methods := []sqlc.UserPaymentMethod{
+		{Provider: PaymentProviderUPI, Handle: "bob@okaxis"},
+		{Provider: PaymentProviderPayPal, Handle: "BobB"},
+		{Provider: PaymentProviderVenmo, Handle: "bob-b"},
+		{Provider: PaymentProviderIBAN, Handle: "DE89370400440532013000"},
+	}
+
+	tests := []struct {
+		name     string
+		currency string
+		want     []string
+	}{
+		{
+			name:     "inr skips venmo",
+			currency: "INR",
+			want: []string{
+				"upi://pay?pa=bob@okaxis&pn=Bob%20B&am=42.50&cu=INR&tn=SplitPlus:%20Trip",
+				"https://paypal.me/BobB/42.50INR",
+				"payto://iban/DE89370400440532013000?amount=INR:42.50&receiver-name=Bob%20B&message=SplitPlus:%20Trip",
+			},
+		},
+		{
+			name:     "usd skips upi",
+			currency: "usd",
+			want: []string{
+				"https://paypal.me/BobB/42.50USD",
+				"https://venmo.com/bob-b?txn=pay&amount=42.50&note=SplitPlus:%20Trip",
+				"payto://iban/DE89370400440532013000?amount=USD:42.50&receiver-name=Bob%20B&message=SplitPlus:%20Trip",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := BuildPaymentLinks(methods, decimal.RequireFromString("42.5"), tt.currency, "Bob B", "SplitPlus: Trip")
+			if len(links) != len(tt.want) {
+				t.Fatalf("expected %d links, got %d: %+v", len(tt.want), len(links), links)
+			}
+			for i, want := range tt.want {
+				if links[i].URL != want {
+					t.Errorf("link %d: expected %q, got %q", i, want, links[i].URL)
+				}
+			}
+		})
+	}
+}
+
+func TestBalanceService_GetSimplifiedDebts_PaymentLinks(t *testing.T) {
+	groupID := testutil.CreateTestUUID(100)
+	aliceID := testutil.CreateTestUUID(1)
+	bobID := testutil.CreateTestUUID(2)
+	carolID := testutil.CreateTestUUID(3)
+
+	repo := &MockBalanceRepository{
+		GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+			return sqlc.Group{ID: id, Name: "Trip", CurrencyCode: "INR"}, nil
+		},
+		GetGroupBalancesWithPendingFunc: func(ctx context.Context, params sqlc.GetGroupBalancesWithPendingParams) ([]sqlc.GetGroupBalancesWithPendingRow, error) {
+			return []sqlc.GetGroupBalancesWithPendingRow{
+				{UserID: aliceID, TotalPaid: "0", TotalOwed: "30"},
+				{UserID: bobID, TotalPaid: "60", TotalOwed: "20"},
+				{UserID: carolID, TotalPaid: "0", TotalOwed: "10"},
+			}, nil
+		},
+		ListPaymentMethodsByUsersFunc: func(ctx context.Context, userIDs []pgtype.UUID) ([]sqlc.UserPaymentMethod, error) {
+			if len(userIDs) != 1 || userIDs[0] != bobID {
+				t.Errorf("expected only the requester's creditor to be looked up, got %v", userIDs)
+			}
+			return []sqlc.UserPaymentMethod{{UserID: bobID, Provider: PaymentProviderUPI, Handle: "bob@okaxis"}}, nil
+		},
+	}
+
+	debts, err := NewBalanceService(repo).GetSimplifiedDebts(context.Background(), groupID, aliceID, BalanceOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, debt := range debts {
+		switch debt.DebtorID {
+		case aliceID:
+			if len(debt.PaymentLinks) != 1 || debt.PaymentLinks[0].Provider != PaymentProviderUPI {
+				t.Errorf("expected a upi link on alice's debt, got %+v", debt.PaymentLinks)
+			}
+		default:
+			if len(debt.PaymentLinks) != 0 {
+				t.Errorf("expected no links on someone else's debt, got %+v", debt.PaymentLinks)
+			}
+		}
+	}
+}
+
+func TestPaymentMethodService_PayDebt(t *testing.T) {
+	groupID := testutil.CreateTestUUID(100)
+	aliceID := testutil.CreateTestUUID(1)
+	bobID := testutil.CreateTestUUID(2)
+
+	tests := []struct {
+		name       string
+		creditorID pgtype.UUID
+		provider   string
+		amount     string
+		hasMethod  bool
+		wantErr    error
+		wantAmount string
+	}{
+		{name: "defaults to the full debt", creditorID: bobID, provider: PaymentProviderUPI, hasMethod: true, wantAmount: "30.00"},
+		{name: "partial payment", creditorID: bobID, provider: PaymentProviderUPI, amount: "12.5", hasMethod: true, wantAmount: "12.50"},
+		{name: "more than owed", creditorID: bobID, provider: PaymentProviderUPI, amount: "31", hasMethod: true, wantErr: ErrPaymentAmountInvalid},
+		{name: "creditor lacks provider", creditorID: bobID, provider: PaymentProviderVenmo, wantErr: ErrPaymentMethodNotFound},
+		{name: "nothing owed", creditorID: testutil.CreateTestUUID(3), provider: PaymentProviderUPI, hasMethod: true, wantErr: ErrDebtNotFound},
+		{name: "unknown provider", creditorID: bobID, provider: "cash", wantErr: ErrPaymentProviderInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balanceRepo := &MockBalanceRepository{
+				GetGroupBalancesWithPendingFunc: func(ctx context.Context, params sqlc.GetGroupBalancesWithPendingParams) ([]sqlc.GetGroupBalancesWithPendingRow, error) {
+					return []sqlc.GetGroupBalancesWithPendingRow{
+						{UserID: aliceID, TotalPaid: "0", TotalOwed: "30"},
+						{UserID: bobID, TotalPaid: "30", TotalOwed: "0"},
+					}, nil
+				},
+			}
+			methodRepo := &MockPaymentMethodRepository{
+				GetUserPaymentMethodFunc: func(ctx context.Context, params sqlc.GetUserPaymentMethodParams) (sqlc.UserPaymentMethod, error) {
+					if !tt.hasMethod {
+						return sqlc.UserPaymentMethod{}, pgx.ErrNoRows
+					}
+					return sqlc.UserPaymentMethod{UserID: params.UserID, Provider: params.Provider}, nil
+				},
+			}
+
+			var created sqlc.CreateSettlementParams
+			settlementRepo := memberSettlementRepo()
+			settlementRepo.CreateSettlementFunc = func(ctx context.Context, params sqlc.CreateSettlementParams) (sqlc.Settlement, error) {
+				created = params
+				return sqlc.Settlement{Status: params.Status}, nil
+			}
+
+			svc := NewPaymentMethodService(
+				methodRepo,
+				NewBalanceService(balanceRepo),
+				NewSettlementService(settlementRepo, &MockGroupActivityService{}),
+			)
+			_, err := svc.PayDebt(context.Background(), PayDebtInput{
+				GroupID:    groupID,
+				PayerID:    aliceID,
+				CreditorID: tt.creditorID,
+				Provider:   tt.provider,
+				Amount:     tt.amount,
+			})
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if created.Status != "pending" {
+				t.Errorf("expected pending settlement, got %q", created.Status)
+			}
+			if created.PayerID != aliceID || created.PayeeID != bobID {
+				t.Errorf("expected alice -> bob settlement, got %v -> %v", created.PayerID, created.PayeeID)
+			}
+			if got := decimal.RequireFromString(numericToStringSafe(created.Amount)); !got.Equal(decimal.RequireFromString(tt.wantAmount)) {
+				t.Errorf("expected amount %s, got %s", tt.wantAmount, got)
+			}
+			if created.PaymentMethod.String != tt.provider {
+				t.Errorf("expected payment method %q, got %q", tt.provider, created.PaymentMethod.String)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+// PersonalAccessTokenPrefix starts every personal access token, so they can be
+// told apart from JWTs in the Authorization header and spotted by secret scanners.
+const PersonalAccessTokenPrefix = "spp_"
+
+// Scopes a personal access token can be granted. Reads of any kind need
+// TokenScopeRead; each write scope covers changes to one area.
+const (
+	TokenScopeRead             = "read"
+	TokenScopeExpensesWrite    = "expenses:write"
+	TokenScopeSettlementsWrite = "settlements:write"
+	TokenScopeGroupsWrite      = "groups:write"
+	TokenScopeFriendsWrite     = "friends:write"
+	TokenScopeProfileWrite     = "profile:write"
+)
+
+// TokenScopes lists every scope a personal access token can be granted.
+var TokenScopes = []string{
+	TokenScopeRead,
+	TokenScopeExpensesWrite,
+	TokenScopeSettlementsWrite,
+	TokenScopeGroupsWrite,
+	TokenScopeFriendsWrite,
+	TokenScopeProfileWrite,
+}
+
+const (
+	personalAccessTokenMaxTTL = 365 * 24 * time.Hour
+	maxPersonalAccessTokens   = 50
+
+	// personalAccessTokenPrefixLength characters of the token are kept in the
+	// clear, enough to recognise it in the token list.
+	personalAccessTokenPrefixLength = 12
+)
+
+var (
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+	ErrInvalidTokenScope           = errors.New("invalid token scope")
+	ErrInvalidTokenExpiry          = errors.New("invalid token expiry")
+	ErrTooManyAccessTokens         = errors.New("too many personal access tokens")
+)
+
+type CreatePersonalAccessTokenInput struct {
+	UserID    pgtype.UUID
+	Name      string
+	Scopes    []string
+	ExpiresIn time.Duration // zero never expires
+}
+
+// PersonalAccessTokenService manages the long-lived API tokens users create for
+// scripts and integrations.
+type PersonalAccessTokenService interface {
+	// CreateToken returns the new token. It is only ever shown this once.
+	CreateToken(ctx context.Context, input CreatePersonalAccessTokenInput) (token string, pat sqlc.PersonalAccessToken, err error)
+	ListTokens(ctx context.Context, userID pgtype.UUID) ([]sqlc.PersonalAccessToken, error)
+	RevokeToken(ctx context.Context, userID, tokenID pgtype.UUID) error
+	// Authenticate returns the token a request presented and records its use.
+	// It fails with ErrInvalidToken or ErrExpiredToken, like access tokens do.
+	Authenticate(ctx context.Context, token string) (sqlc.PersonalAccessToken, error)
+}
+
+type personalAccessTokenService struct {
+	repo repository.PersonalAccessTokenRepository
+}
+
+func NewPersonalAccessTokenService(repo repository.PersonalAccessTokenRepository) PersonalAccessTokenService {
+	return &personalAccessTokenService{repo: repo}
+}
+
+func (s *personalAccessTokenService) CreateToken(ctx context.Context, input CreatePersonalAccessTokenInput) (string, sqlc.PersonalAccessToken, error) {
+	scopes, err := normalizeTokenScopes(input.Scopes)
+	if err != nil {
+		return "", sqlc.PersonalAccessToken{}, err
+	}
+
+	if input.ExpiresIn < 0 || input.ExpiresIn > personalAccessTokenMaxTTL {
+		return "", sqlc.PersonalAccessToken{}, ErrInvalidTokenExpiry
+	}
+	expiresAt := pgtype.Timestamptz{}
+	if input.ExpiresIn > 0 {
+		expiresAt = pgtype.Timestamptz{Time: time.Now().Add(input.ExpiresIn), Valid: true}
+	}
+
+	count, err := s.repo.CountUserPersonalAccessTokens(ctx, input.UserID)
+	if err != nil {
+		return "", sqlc.PersonalAccessToken{}, fmt.Errorf("failed to count personal access tokens: %w", err)
+	}
+	if count >= maxPersonalAccessTokens {
+		return "", sqlc.PersonalAccessToken{}, ErrTooManyAccessTokens
+	}
+
+	secret, err := randomURLToken()
+	if err != nil {
+		return "", sqlc.PersonalAccessToken{}, err
+	}
+	token := PersonalAccessTokenPrefix + secret
+
+	pat, err := s.repo.CreatePersonalAccessToken(ctx, sqlc.CreatePersonalAccessTokenParams{
+		UserID:      input.UserID,
+		Name:        strings.TrimSpace(input.Name),
+		TokenHash:   hashToken(token),
+		TokenPrefix: token[:personalAccessTokenPrefixLength],
+		Scopes:      scopes,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return "", sqlc.PersonalAccessToken{}, fmt.Errorf("failed to create personal access token: %w", err)
+	}
+	return token, pat, nil
+}
+
+func (s *personalAccessTokenService) ListTokens(ctx context.Context, userID pgtype.UUID) ([]sqlc.PersonalAccessToken, error) {
+	return s.repo.ListUserPersonalAccessTokens(ctx, userID)
+}
+
+func (s *personalAccessTokenService) RevokeToken(ctx context.Context, userID, tokenID pgtype.UUID) error {
+	_, err := s.repo.DeleteUserPersonalAccessToken(ctx, sqlc.DeleteUserPersonalAccessTokenParams{
+		ID:     tokenID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrPersonalAccessTokenNotFound
+	}
+	return err
+}
+
+func (s *personalAccessTokenService) Authenticate(ctx context.Context, token string) (sqlc.PersonalAccessToken, error) {
+	if !strings.HasPrefix(token, PersonalAccessTokenPrefix) {
+		return sqlc.PersonalAccessToken{}, ErrInvalidToken
+	}
+
+	pat, err := s.repo.GetPersonalAccessTokenByHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.PersonalAccessToken{}, ErrInvalidToken
+		}
+		return sqlc.PersonalAccessToken{}, err
+	}
+
+	if pat.ExpiresAt.Valid && !pat.ExpiresAt.Time.After(time.Now()) {
+		return sqlc.PersonalAccessToken{}, ErrExpiredToken
+	}
+
+	if err := s.repo.TouchPersonalAccessToken(ctx, pat.ID); err != nil {
+		return sqlc.PersonalAccessToken{}, fmt.Errorf("failed to record token use: %w", err)
+	}
+	return pat, nil
+}
+
+// TokenHasScope reports whether a personal access token was granted scope.
+func TokenHasScope(pat sqlc.PersonalAccessToken, scope string) bool {
+	return slices.Contains(pat.Scopes, scope)
+}
+
+// normalizeTokenScopes checks requested scopes and drops duplicates.
+func normalizeTokenScopes(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return nil, ErrInvalidTokenScope
+	}
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		if !slices.Contains(TokenScopes, scope) {
+			return nil, ErrInvalidTokenScope
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
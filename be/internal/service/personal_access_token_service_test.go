@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+)
+
+// MockPersonalAccessTokenRepository for testing
+type MockPersonalAccessTokenRepository struct {
+	CreatePersonalAccessTokenFunc     func(ctx context.Context, params sqlc.CreatePersonalAccessTokenParams) (sqlc.PersonalAccessToken, error)
+	GetPersonalAccessTokenByHashFunc  func(ctx context.Context, tokenHash string) (sqlc.PersonalAccessToken, error)
+	ListUserPersonalAccessTokensFunc  func(ctx context.Context, userID pgtype.UUID) ([]sqlc.PersonalAccessToken, error)
+	CountUserPersonalAccessTokensFunc func(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeleteUserPersonalAccessTokenFunc func(ctx context.Context, params sqlc.DeleteUserPersonalAccessTokenParams) (pgtype.UUID, error)
+	TouchPersonalAccessTokenFunc      func(ctx context.Context, id pgtype.UUID) error
+}
+
+func (m *MockPersonalAccessTokenRepository) CreatePersonalAccessToken(ctx context.Context, params sqlc.CreatePersonalAccessTokenParams) (sqlc.PersonalAccessToken, error) {
+	if m.CreatePersonalAccessTokenFunc != nil {
+		return m.CreatePersonalAccessTokenFunc(ctx, params)
+	}
+	return sqlc.PersonalAccessToken{}, nil
+}
+
+func (m *MockPersonalAccessTokenRepository) GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (sqlc.PersonalAccessToken, error) {
+	if m.GetPersonalAccessTokenByHashFunc != nil {
+		return m.GetPersonalAccessTokenByHashFunc(ctx, tokenHash)
+	}
+	return sqlc.PersonalAccessToken{}, pgx.ErrNoRows
+}
+
+func (m *MockPersonalAccessTokenRepository) ListUserPersonalAccessTokens(ctx context.Context, userID pgtype.UUID) ([]sqlc.PersonalAccessToken, error) {
+	if m.ListUserPersonalAccessTokensFunc != nil {
+		return m.ListUserPersonalAccessTokensFunc(ctx, userID)
+	}
+	return []sqlc.PersonalAccessToken{}, nil
+}
+
+func (m *MockPersonalAccessTokenRepository) CountUserPersonalAccessTokens(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if m.CountUserPersonalAccessTokensFunc != nil {
+		return m.CountUserPersonalAccessTokensFunc(ctx, userID)
+	}
+	return 0, nil
+}
+
+func (m *MockPersonalAccessTokenRepository) DeleteUserPersonalAccessToken(ctx context.Context, params sqlc.DeleteUserPersonalAccessTokenParams) (pgtype.UUID, error) {
+	if m.DeleteUserPersonalAccessTokenFunc != nil {
+		return m.DeleteUserPersonalAccessTokenFunc(ctx, params)
+	}
+	return pgtype.UUID{}, pgx.ErrNoRows
+}
+
+func (m *MockPersonalAccessTokenRepository) TouchPersonalAccessToken(ctx context.Context, id pgtype.UUID) error {
+	if m.TouchPersonalAccessTokenFunc != nil {
+		return m.TouchPersonalAccessTokenFunc(ctx, id)
+	}
+	return nil
+}
+
+// newPersonalAccessTokenStore backs a MockPersonalAccessTokenRepository with a
+// slice that behaves like the queries.
+func newPersonalAccessTokenStore() (*MockPersonalAccessTokenRepository, *[]sqlc.PersonalAccessToken) {
+	tokens := &[]sqlc.PersonalAccessToken{}
+	nextID := byte(0)
+	repo := &MockPersonalAccessTokenRepository{
+		CreatePersonalAccessTokenFunc: func(ctx context.Context, params sqlc.CreatePersonalAccessTokenParams) (sqlc.PersonalAccessToken, error) {
+			nextID++
+			pat := sqlc.PersonalAccessToken{
+				ID:          pgtype.UUID{Bytes: [16]byte{nextID}, Valid: true},
+				UserID:      params.UserID,
+				Name:        params.Name,
+				TokenHash:   params.TokenHash,
+				TokenPrefix: params.TokenPrefix,
+				Scopes:      params.Scopes,
+				ExpiresAt:   params.ExpiresAt,
+				CreatedAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			}
+			*tokens = append(*tokens, pat)
+			return pat, nil
+		},
+		GetPersonalAccessTokenByHashFunc: func(ctx context.Context, tokenHash string) (sqlc.PersonalAccessToken, error) {
+			for _, pat := range *tokens {
+				if pat.TokenHash == tokenHash {
+					return pat, nil
+				}
+			}
+			return sqlc.PersonalAccessToken{}, pgx.ErrNoRows
+		},
+		CountUserPersonalAccessTokensFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+			var count int64
+			for _, pat := range *tokens {
+				if pat.UserID == userID {
+					count++
+				}
+			}
+			return count, nil
+		},
+		DeleteUserPersonalAccessTokenFunc: func(ctx context.Context, params sqlc.DeleteUserPersonalAccessTokenParams) (pgtype.UUID, error) {
+			for i, pat := range *tokens {
+				if pat.ID == params.ID && pat.UserID == params.UserID {
+					*tokens = append((*tokens)[:i], (*tokens)[i+1:]...)
+					return pat.ID, nil
+				}
+			}
+			return pgtype.UUID{}, pgx.ErrNoRows
+		},
+		TouchPersonalAccessTokenFunc: func(ctx context.Context, id pgtype.UUID) error {
+			for i := range *tokens {
+				if (*tokens)[i].ID == id {
+					(*tokens)[i].LastUsedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+				}
+			}
+			return nil
+		},
+	}
+	return repo, tokens
+}
+
+func TestPersonalAccessTokenService_CreateAndAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	repo, tokens := newPersonalAccessTokenStore()
+	svc := NewPersonalAccessTokenService(repo)
+	userID := mustUUID(t, "11111111-1111-1111-1111-111111111111")
+
+	token, pat, err := svc.CreateToken(ctx, CreatePersonalAccessTokenInput{
+		UserID:    userID,
+		Name:      "  expense importer ",
+		Scopes:    []string{TokenScopeRead, TokenScopeExpensesWrite, TokenScopeRead},
+		ExpiresIn: 30 * 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+
+	if !strings.HasPrefix(token, PersonalAccessTokenPrefix) {
+		t.Errorf("expected token to start with %q, got %q", PersonalAccessTokenPrefix, token)
+	}
+	if pat.Name != "expense importer" {
+		t.Errorf("expected trimmed name, got %q", pat.Name)
+	}
+	if len(pat.Scopes) != 2 {
+		t.Errorf("expected duplicate scopes to be dropped, got %v", pat.Scopes)
+	}
+	if !strings.HasPrefix(token, pat.TokenPrefix) {
+		t.Errorf("expected prefix %q to start the token", pat.TokenPrefix)
+	}
+	if (*tokens)[0].TokenHash == token || strings.Contains((*tokens)[0].TokenHash, token) {
+		t.Error("expected only a hash of the token to be stored")
+	}
+	if !pat.ExpiresAt.Valid {
+		t.Error("expected an expiry")
+	}
+
+	got, err := svc.Authenticate(ctx, token)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if got.UserID != userID {
+		t.Errorf("expected token for user %v, got %v", userID, got.UserID)
+	}
+	if !(*tokens)[0].LastUsedAt.Valid {
+		t.Error("expected last use to be recorded")
+	}
+	if !TokenHasScope(got, TokenScopeExpensesWrite) || TokenHasScope(got, TokenScopeGroupsWrite) {
+		t.Errorf("unexpected scopes %v", got.Scopes)
+	}
+}
+
+func TestPersonalAccessTokenService_CreateToken_Validation(t *testing.T) {
+	ctx := context.Background()
+	userID := mustUUID(t, "11111111-1111-1111-1111-111111111111")
+
+	tests := []struct {
+		name    string
+		input   CreatePersonalAccessTokenInput
+		count   int64
+		wantErr error
+	}{
+		{
+			name:    "no scopes",
+			input:   CreatePersonalAccessTokenInput{UserID: userID, Name: "ci"},
+			wantErr: ErrInvalidTokenScope,
+		},
+		{
+			name:    "unknown scope",
+			input:   CreatePersonalAccessTokenInput{UserID: userID, Name: "ci", Scopes: []string{"admin"}},
+			wantErr: ErrInvalidTokenScope,
+		},
+		{
+			name:    "expiry too far out",
+			input:   CreatePersonalAccessTokenInput{UserID: userID, Name: "ci", Scopes: []string{TokenScopeRead}, ExpiresIn: 400 * 24 * time.Hour},
+			wantErr: ErrInvalidTokenExpiry,
+		},
+		{
+			name:    "token limit reached",
+			input:   CreatePersonalAccessTokenInput{UserID: userID, Name: "ci", Scopes: []string{TokenScopeRead}},
+			count:   maxPersonalAccessTokens,
+			wantErr: ErrTooManyAccessTokens,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockPersonalAccessTokenRepository{
+				CountUserPersonalAccessTokensFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+					return tt.count, nil
+				},
+				CreatePersonalAccessTokenFunc: func(ctx context.Context, params sqlc.CreatePersonalAccessTokenParams) (sqlc.PersonalAccessToken, error) {
+					t.Fatal("expected no token to be created")
+					return sqlc.PersonalAccessToken{}, nil
+				},
+			}
+
+			_, _, err := NewPersonalAccessTokenService(repo).CreateToken(ctx, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPersonalAccessTokenService_Authenticate_Rejects(t *testing.T) {
+	ctx := context.Background()
+	repo, tokens := newPersonalAccessTokenStore()
+	svc := NewPersonalAccessTokenService(repo)
+	userID := mustUUID(t, "11111111-1111-1111-1111-111111111111")
+
+	token, _, err := svc.CreateToken(ctx, CreatePersonalAccessTokenInput{UserID: userID, Name: "ci", Scopes: []string{TokenScopeRead}})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+
+	if _, err := svc.Authenticate(ctx, "spp_unknown"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("unknown token: expected ErrInvalidToken, got %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, strings.TrimPrefix(token, PersonalAccessTokenPrefix)); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("token without prefix: expected ErrInvalidToken, got %v", err)
+	}
+
+	(*tokens)[0].ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+	if _, err := svc.Authenticate(ctx, token); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("expired token: expected ErrExpiredToken, got %v", err)
+	}
+	if (*tokens)[0].LastUsedAt.Valid {
+		t.Error("expected rejected tokens not to be marked used")
+	}
+}
+
+func TestPersonalAccessTokenService_RevokeToken(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newPersonalAccessTokenStore()
+	svc := NewPersonalAccessTokenService(repo)
+	userID := mustUUID(t, "11111111-1111-1111-1111-111111111111")
+	otherID := mustUUID(t, "22222222-2222-2222-2222-222222222222")
+
+	token, pat, err := svc.CreateToken(ctx, CreatePersonalAccessTokenInput{UserID: userID, Name: "ci", Scopes: []string{TokenScopeRead}})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+
+	if err := svc.RevokeToken(ctx, otherID, pat.ID); !errors.Is(err, ErrPersonalAccessTokenNotFound) {
+		t.Fatalf("other user: expected ErrPersonalAccessTokenNotFound, got %v", err)
+	}
+	if err := svc.RevokeToken(ctx, userID, pat.ID); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("revoked token: expected ErrInvalidToken, got %v", err)
+	}
+}
@@ -89,11 +89,11 @@ func NewRecurringExpenseService(repo repository.RecurringExpenseRepository, expe
 }
 
 func (s *recurringExpenseService) validateGroupMembership(ctx context.Context, groupID, userID pgtype.UUID) error {
-	_, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+	member, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
 		GroupID: groupID,
 		UserID:  userID,
 	})
-	if err != nil {
+	if err != nil || member.Status == "inactive" {
 		return ErrNotGroupMember
 	}
 	return nil
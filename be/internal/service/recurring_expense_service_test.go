@@ -386,7 +386,7 @@ func TestRecurringExpenseService_CreateRecurringExpense(t *testing.T) {
 			mockActivitySvc := &MockGroupActivityService{}
 			mockUserRepo := &testutil.MockUserRepository{}
 			mockPendingUserRepo := &testutil.MockPendingUserRepository{}
-			expenseService := NewExpenseService(mockExpenseRepo, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo)
+			expenseService := NewExpenseService(mockExpenseRepo, mockCategoryRepo, mockActivitySvc, mockUserRepo, mockPendingUserRepo, nil, nil)
 			service := NewRecurringExpenseService(mockRepo, expenseService)
 			ctx := context.Background()
 
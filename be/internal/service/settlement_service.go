@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 
@@ -16,8 +17,46 @@ var (
 	ErrSettlementNotFound = errors.New("settlement not found")
 	ErrInvalidSettlement  = errors.New("invalid settlement")
 	ErrInvalidStatus      = errors.New("invalid status")
+
+	ErrInvalidStatusTransition = errors.New("settlement cannot move to this status")
+	ErrSettlementForbidden     = errors.New("not allowed to make this change to the settlement")
+	ErrSettlementFinalized     = errors.New("settlement is already completed or cancelled")
+	ErrSettlementCurrency      = errors.New("settlements must be in the group currency")
+)
+
+// settlementRole is a side of a settlement. Roles combine as a bit set.
+type settlementRole uint8
+
+const (
+	settlementRolePayer settlementRole = 1 << iota
+	settlementRolePayee
 )
 
+// settlementTransitions maps current status -> next status -> roles allowed to
+// make the move. The payer records a payment as pending, the payee confirms or
+// disputes it, and either side can cancel it until it is confirmed. The payer
+// may re-submit a disputed payment. completed and cancelled are final.
+var settlementTransitions = map[string]map[string]settlementRole{
+	"pending": {
+		"completed": settlementRolePayee,
+		"disputed":  settlementRolePayee,
+		"cancelled": settlementRolePayer | settlementRolePayee,
+	},
+	"disputed": {
+		"pending":   settlementRolePayer,
+		"completed": settlementRolePayee,
+		"cancelled": settlementRolePayer | settlementRolePayee,
+	},
+}
+
+// settlementActivityActions is the group activity logged for each new status.
+var settlementActivityActions = map[string]string{
+	"pending":   "settlement_resubmitted",
+	"completed": "settlement_completed",
+	"disputed":  "settlement_disputed",
+	"cancelled": "settlement_cancelled",
+}
+
 type CreateSettlementInput struct {
 	GroupID              pgtype.UUID
 	PayerID              pgtype.UUID
@@ -47,6 +86,7 @@ type UpdateSettlementInput struct {
 type UpdateSettlementStatusInput struct {
 	SettlementID pgtype.UUID
 	Status       string
+	Reason       string
 	UpdatedBy    pgtype.UUID
 }
 
@@ -73,11 +113,11 @@ func NewSettlementService(repo repository.SettlementRepository, activityService
 }
 
 func (s *settlementService) validateGroupMembership(ctx context.Context, groupID, userID pgtype.UUID) error {
-	_, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+	member, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
 		GroupID: groupID,
 		UserID:  userID,
 	})
-	if err != nil {
+	if err != nil || member.Status == "inactive" {
 		return ErrNotGroupMember
 	}
 	return nil
@@ -87,6 +127,7 @@ func (s *settlementService) validateStatus(status string) error {
 	validStatuses := map[string]bool{
 		"pending":   true,
 		"completed": true,
+		"disputed":  true,
 		"cancelled": true,
 	}
 	if !validStatuses[status] {
@@ -95,6 +136,32 @@ func (s *settlementService) validateStatus(status string) error {
 	return nil
 }
 
+// settlementRoles reports which sides of the settlement userID acts for. Pending
+// (unregistered) participants cannot act themselves, so whoever recorded the
+// settlement acts for them.
+func settlementRoles(settlement sqlc.Settlement, userID pgtype.UUID) settlementRole {
+	var roles settlementRole
+	if settlement.PayerID == userID || (!settlement.PayerID.Valid && settlement.CreatedBy == userID) {
+		roles |= settlementRolePayer
+	}
+	if settlement.PayeeID == userID || (!settlement.PayeeID.Valid && settlement.CreatedBy == userID) {
+		roles |= settlementRolePayee
+	}
+	return roles
+}
+
+// checkSettlementTransition validates userID moving settlement to status.
+func checkSettlementTransition(settlement sqlc.Settlement, status string, userID pgtype.UUID) error {
+	allowed, ok := settlementTransitions[settlement.Status][status]
+	if !ok {
+		return ErrInvalidStatusTransition
+	}
+	if settlementRoles(settlement, userID)&allowed == 0 {
+		return ErrSettlementForbidden
+	}
+	return nil
+}
+
 func hasExactlyOneParticipant(userID, pendingUserID pgtype.UUID) bool {
 	return userID.Valid != pendingUserID.Valid
 }
@@ -110,7 +177,14 @@ func (s *settlementService) validateParticipantInGroup(
 	}
 
 	if userID.Valid {
-		return s.validateGroupMembership(ctx, groupID, userID)
+		// Former (inactive) members may still pay or receive what they owe
+		if _, err := s.repo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+			GroupID: groupID,
+			UserID:  userID,
+		}); err != nil {
+			return ErrNotGroupMember
+		}
+		return nil
 	}
 
 	isPendingMember, err := s.repo.HasPendingMemberInvitation(ctx, sqlc.HasPendingMemberInvitationParams{
@@ -124,6 +198,22 @@ func (s *settlementService) validateParticipantInGroup(
 	return nil
 }
 
+// settlementCurrency returns the currency of a group settlement. Balances add
+// settlements up as recorded, so only the group currency is accepted.
+func settlementCurrency(requested, groupCurrency string) (string, error) {
+	if strings.TrimSpace(requested) == "" {
+		return groupCurrency, nil
+	}
+	code, err := normalizeCurrencyCode(requested)
+	if err != nil {
+		return "", err
+	}
+	if code != groupCurrency {
+		return "", ErrSettlementCurrency
+	}
+	return code, nil
+}
+
 func (s *settlementService) CreateSettlement(ctx context.Context, input CreateSettlementInput) (sqlc.Settlement, error) {
 	// Validate group exists
 	group, err := s.repo.GetGroupByID(ctx, input.GroupID)
@@ -175,10 +265,31 @@ func (s *settlementService) CreateSettlement(ctx context.Context, input CreateSe
 		return sqlc.Settlement{}, err
 	}
 
-	// Use group currency if not provided
-	currencyCode := strings.TrimSpace(input.CurrencyCode)
-	if currencyCode == "" {
-		currencyCode = group.CurrencyCode
+	// New settlements are recorded by the payer and wait for the payee to
+	// confirm them, unless the payee is the one recording the payment. A
+	// member records payments for a pending (unregistered) payer.
+	recorded := sqlc.Settlement{
+		PayerID:   input.PayerID,
+		PayeeID:   input.PayeeID,
+		CreatedBy: input.CreatedBy,
+	}
+	switch status {
+	case "pending":
+		if settlementRoles(recorded, input.CreatedBy)&settlementRolePayer == 0 {
+			return sqlc.Settlement{}, ErrSettlementForbidden
+		}
+	case "completed":
+		if settlementRoles(recorded, input.CreatedBy)&settlementRolePayee == 0 {
+			return sqlc.Settlement{}, ErrSettlementForbidden
+		}
+	default:
+		return sqlc.Settlement{}, ErrInvalidStatusTransition
+	}
+
+	// Settlements are recorded in the group currency
+	currencyCode, err := settlementCurrency(input.CurrencyCode, group.CurrencyCode)
+	if err != nil {
+		return sqlc.Settlement{}, err
 	}
 
 	// Convert amount to numeric
@@ -271,6 +382,14 @@ func (s *settlementService) UpdateSettlement(ctx context.Context, input UpdateSe
 		return sqlc.Settlement{}, err
 	}
 
+	// Only the payer and payee may edit, and only until it is settled
+	if settlementRoles(settlement, input.UpdatedBy) == 0 {
+		return sqlc.Settlement{}, ErrSettlementForbidden
+	}
+	if settlement.Status == "completed" || settlement.Status == "cancelled" {
+		return sqlc.Settlement{}, ErrSettlementFinalized
+	}
+
 	// Validate amount
 	amount, err := decimal.NewFromString(input.Amount)
 	if err != nil || amount.LessThanOrEqual(decimal.Zero) {
@@ -281,6 +400,11 @@ func (s *settlementService) UpdateSettlement(ctx context.Context, input UpdateSe
 	if err := s.validateStatus(input.Status); err != nil {
 		return sqlc.Settlement{}, err
 	}
+	if input.Status != settlement.Status {
+		if err := checkSettlementTransition(settlement, input.Status, input.UpdatedBy); err != nil {
+			return sqlc.Settlement{}, err
+		}
+	}
 
 	// Get group for currency
 	group, err := s.repo.GetGroupByID(ctx, settlement.GroupID)
@@ -288,10 +412,10 @@ func (s *settlementService) UpdateSettlement(ctx context.Context, input UpdateSe
 		return sqlc.Settlement{}, ErrGroupNotFound
 	}
 
-	// Use group currency if not provided
-	currencyCode := strings.TrimSpace(input.CurrencyCode)
-	if currencyCode == "" {
-		currencyCode = group.CurrencyCode
+	// Settlements are recorded in the group currency
+	currencyCode, err := settlementCurrency(input.CurrencyCode, group.CurrencyCode)
+	if err != nil {
+		return sqlc.Settlement{}, err
 	}
 
 	// Convert amount to numeric
@@ -323,8 +447,9 @@ func (s *settlementService) UpdateSettlement(ctx context.Context, input UpdateSe
 		EntityType: "settlement",
 		EntityID:   updatedSettlement.ID,
 		Metadata: map[string]interface{}{
-			"amount": input.Amount,
-			"status": input.Status,
+			"amount":     input.Amount,
+			"status":     input.Status,
+			"old_status": settlement.Status,
 		},
 	})
 
@@ -343,47 +468,47 @@ func (s *settlementService) UpdateSettlementStatus(ctx context.Context, input Up
 		return sqlc.Settlement{}, err
 	}
 
-	// Validate status
+	// Validate status and who may move it there
 	if err := s.validateStatus(input.Status); err != nil {
 		return sqlc.Settlement{}, err
 	}
+	if err := checkSettlementTransition(settlement, input.Status, input.UpdatedBy); err != nil {
+		return sqlc.Settlement{}, err
+	}
+
+	reason := strings.TrimSpace(input.Reason)
 
-	// Update status
+	// Update status; no row means someone else moved it first
 	updatedSettlement, err := s.repo.UpdateSettlementStatus(ctx, sqlc.UpdateSettlementStatusParams{
-		ID:        input.SettlementID,
-		Status:    input.Status,
-		UpdatedBy: input.UpdatedBy,
+		Status:        input.Status,
+		DisputeReason: pgtype.Text{String: reason, Valid: reason != ""},
+		UpdatedBy:     input.UpdatedBy,
+		ID:            input.SettlementID,
+		CurrentStatus: settlement.Status,
 	})
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.Settlement{}, ErrInvalidStatusTransition
+		}
 		return sqlc.Settlement{}, err
 	}
 
-	// Log activity if completed
-	if input.Status == "completed" && settlement.Status != "completed" {
-		_ = s.activityService.LogActivity(ctx, LogActivityInput{
-			GroupID:    settlement.GroupID,
-			UserID:     input.UpdatedBy,
-			Action:     "settlement_completed",
-			EntityType: "settlement",
-			EntityID:   updatedSettlement.ID,
-			Metadata: map[string]interface{}{
-				"status": "completed",
-			},
-		})
-	} else if settlement.Status != input.Status {
-		// Log generic status update
-		_ = s.activityService.LogActivity(ctx, LogActivityInput{
-			GroupID:    settlement.GroupID,
-			UserID:     input.UpdatedBy,
-			Action:     "settlement_status_updated",
-			EntityType: "settlement",
-			EntityID:   updatedSettlement.ID,
-			Metadata: map[string]interface{}{
-				"old_status": settlement.Status,
-				"new_status": input.Status,
-			},
-		})
+	// Log activity
+	metadata := map[string]interface{}{
+		"old_status": settlement.Status,
+		"new_status": input.Status,
+	}
+	if reason != "" {
+		metadata["reason"] = reason
 	}
+	_ = s.activityService.LogActivity(ctx, LogActivityInput{
+		GroupID:    settlement.GroupID,
+		UserID:     input.UpdatedBy,
+		Action:     settlementActivityActions[input.Status],
+		EntityType: "settlement",
+		EntityID:   updatedSettlement.ID,
+		Metadata:   metadata,
+	})
 
 	return updatedSettlement, nil
 }
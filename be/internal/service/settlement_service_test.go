@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// memberSettlementRepo treats everyone as an active member of any group.
+func memberSettlementRepo() *MockSettlementRepository {
+	return &MockSettlementRepository{
+		GetGroupByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error) {
+			return sqlc.Group{ID: id, CurrencyCode: "USD"}, nil
+		},
+		GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+			return sqlc.GroupMember{GroupID: params.GroupID, UserID: params.UserID, Status: "active"}, nil
+		},
+	}
+}
+
+func TestSettlementService_UpdateSettlementStatus(t *testing.T) {
+	groupID := testutil.CreateTestUUID(100)
+	settlementID := testutil.CreateTestUUID(200)
+	payerID := testutil.CreateTestUUID(1)
+	payeeID := testutil.CreateTestUUID(2)
+	otherID := testutil.CreateTestUUID(3)
+	pendingPayeeID := testutil.CreateTestUUID(4)
+
+	tests := []struct {
+		name        string
+		current     string
+		status      string
+		requester   pgtype.UUID
+		pendingPaid bool // payee is a pending (unregistered) member
+		wantErr     error
+		wantAction  string
+	}{
+		{name: "payee confirms", current: "pending", status: "completed", requester: payeeID, wantAction: "settlement_completed"},
+		{name: "payee disputes", current: "pending", status: "disputed", requester: payeeID, wantAction: "settlement_disputed"},
+		{name: "payer cancels", current: "pending", status: "cancelled", requester: payerID, wantAction: "settlement_cancelled"},
+		{name: "payee cancels", current: "pending", status: "cancelled", requester: payeeID, wantAction: "settlement_cancelled"},
+		{name: "payer resubmits disputed", current: "disputed", status: "pending", requester: payerID, wantAction: "settlement_resubmitted"},
+		{name: "payee confirms disputed", current: "disputed", status: "completed", requester: payeeID, wantAction: "settlement_completed"},
+		{name: "payer cannot confirm", current: "pending", status: "completed", requester: payerID, wantErr: ErrSettlementForbidden},
+		{name: "payer cannot dispute", current: "pending", status: "disputed", requester: payerID, wantErr: ErrSettlementForbidden},
+		{name: "other member cannot cancel", current: "pending", status: "cancelled", requester: otherID, wantErr: ErrSettlementForbidden},
+		{name: "completed is final", current: "completed", status: "cancelled", requester: payeeID, wantErr: ErrInvalidStatusTransition},
+		{name: "cancelled is final", current: "cancelled", status: "pending", requester: payerID, wantErr: ErrInvalidStatusTransition},
+		{name: "same status", current: "pending", status: "pending", requester: payerID, wantErr: ErrInvalidStatusTransition},
+		{name: "unknown status", current: "pending", status: "paid", requester: payeeID, wantErr: ErrInvalidStatus},
+		{name: "recorder confirms for pending payee", current: "pending", status: "completed", requester: payerID, pendingPaid: true, wantAction: "settlement_completed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settlement := sqlc.Settlement{
+				ID:        settlementID,
+				GroupID:   groupID,
+				PayerID:   payerID,
+				PayeeID:   payeeID,
+				Status:    tt.current,
+				CreatedBy: payerID,
+			}
+			if tt.pendingPaid {
+				settlement.PayeeID = pgtype.UUID{}
+				settlement.PayeePendingUserID = pendingPayeeID
+			}
+
+			var updated sqlc.UpdateSettlementStatusParams
+			repo := memberSettlementRepo()
+			repo.GetSettlementByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.Settlement, error) {
+				return settlement, nil
+			}
+			repo.UpdateSettlementStatusFunc = func(ctx context.Context, params sqlc.UpdateSettlementStatusParams) (sqlc.Settlement, error) {
+				updated = params
+				result := settlement
+				result.Status = params.Status
+				return result, nil
+			}
+
+			action := ""
+			activity := &MockGroupActivityService{
+				LogActivityFunc: func(ctx context.Context, input LogActivityInput) error {
+					action = input.Action
+					return nil
+				},
+			}
+
+			svc := NewSettlementService(repo, activity)
+			_, err := svc.UpdateSettlementStatus(context.Background(), UpdateSettlementStatusInput{
+				SettlementID: settlementID,
+				Status:       tt.status,
+				Reason:       " never arrived ",
+				UpdatedBy:    tt.requester,
+			})
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				if action != "" {
+					t.Errorf("expected no activity, got %q", action)
+				}
+				return
+			}
+
+			if updated.CurrentStatus != tt.current {
+				t.Errorf("expected update guarded by status %q, got %q", tt.current, updated.CurrentStatus)
+			}
+			if updated.DisputeReason.String != "never arrived" {
+				t.Errorf("expected trimmed reason, got %q", updated.DisputeReason.String)
+			}
+			if action != tt.wantAction {
+				t.Errorf("expected activity %q, got %q", tt.wantAction, action)
+			}
+		})
+	}
+}
+
+func TestSettlementService_UpdateSettlementStatus_ConcurrentChange(t *testing.T) {
+	payeeID := testutil.CreateTestUUID(2)
+
+	repo := memberSettlementRepo()
+	repo.GetSettlementByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.Settlement, error) {
+		return sqlc.Settlement{ID: id, PayerID: testutil.CreateTestUUID(1), PayeeID: payeeID, Status: "pending"}, nil
+	}
+	repo.UpdateSettlementStatusFunc = func(ctx context.Context, params sqlc.UpdateSettlementStatusParams) (sqlc.Settlement, error) {
+		return sqlc.Settlement{}, pgx.ErrNoRows
+	}
+
+	svc := NewSettlementService(repo, &MockGroupActivityService{})
+	_, err := svc.UpdateSettlementStatus(context.Background(), UpdateSettlementStatusInput{
+		SettlementID: testutil.CreateTestUUID(200),
+		Status:       "completed",
+		UpdatedBy:    payeeID,
+	})
+	if err != ErrInvalidStatusTransition {
+		t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+	}
+}
+
+func TestSettlementService_CreateSettlement_Status(t *testing.T) {
+	payerID := testutil.CreateTestUUID(1)
+	payeeID := testutil.CreateTestUUID(2)
+	otherID := testutil.CreateTestUUID(3)
+
+	tests := []struct {
+		name      string
+		status    string
+		createdBy pgtype.UUID
+		wantErr   error
+		want      string
+	}{
+		{name: "defaults to pending", createdBy: payerID, want: "pending"},
+		{name: "payee records a received payment", status: "completed", createdBy: payeeID, want: "completed"},
+		{name: "payee cannot record a pending payment", createdBy: payeeID, wantErr: ErrSettlementForbidden},
+		{name: "other member cannot record a pending payment", createdBy: otherID, wantErr: ErrSettlementForbidden},
+		{name: "payer cannot self-confirm", status: "completed", createdBy: payerID, wantErr: ErrSettlementForbidden},
+		{name: "other member cannot record as completed", status: "completed", createdBy: otherID, wantErr: ErrSettlementForbidden},
+		{name: "cannot start cancelled", status: "cancelled", createdBy: payerID, wantErr: ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created sqlc.CreateSettlementParams
+			repo := memberSettlementRepo()
+			repo.CreateSettlementFunc = func(ctx context.Context, params sqlc.CreateSettlementParams) (sqlc.Settlement, error) {
+				created = params
+				return sqlc.Settlement{Status: params.Status}, nil
+			}
+
+			svc := NewSettlementService(repo, &MockGroupActivityService{})
+			_, err := svc.CreateSettlement(context.Background(), CreateSettlementInput{
+				GroupID:   testutil.CreateTestUUID(100),
+				PayerID:   payerID,
+				PayeeID:   payeeID,
+				Amount:    "25.00",
+				Status:    tt.status,
+				CreatedBy: tt.createdBy,
+			})
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && created.Status != tt.want {
+				t.Errorf("expected status %q, got %q", tt.want, created.Status)
+			}
+		})
+	}
+}
+
+func TestSettlementService_CreateSettlement_Currency(t *testing.T) {
+	payerID := testutil.CreateTestUUID(1)
+
+	tests := []struct {
+		name     string
+		currency string
+		wantErr  error
+		want     string
+	}{
+		{name: "defaults to the group currency", want: "USD"},
+		{name: "group currency", currency: "usd", want: "USD"},
+		{name: "other currency", currency: "EUR", wantErr: ErrSettlementCurrency},
+		{name: "invalid currency", currency: "EURO", wantErr: ErrInvalidCurrencyCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created sqlc.CreateSettlementParams
+			repo := memberSettlementRepo()
+			repo.CreateSettlementFunc = func(ctx context.Context, params sqlc.CreateSettlementParams) (sqlc.Settlement, error) {
+				created = params
+				return sqlc.Settlement{Status: params.Status}, nil
+			}
+
+			svc := NewSettlementService(repo, &MockGroupActivityService{})
+			_, err := svc.CreateSettlement(context.Background(), CreateSettlementInput{
+				GroupID:      testutil.CreateTestUUID(100),
+				PayerID:      payerID,
+				PayeeID:      testutil.CreateTestUUID(2),
+				Amount:       "50.00",
+				CurrencyCode: tt.currency,
+				CreatedBy:    payerID,
+			})
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && created.CurrencyCode != tt.want {
+				t.Errorf("expected currency %q, got %q", tt.want, created.CurrencyCode)
+			}
+		})
+	}
+}
+
+func TestSettlementService_UpdateSettlement_Finalized(t *testing.T) {
+	payerID := testutil.CreateTestUUID(1)
+
+	repo := memberSettlementRepo()
+	repo.GetSettlementByIDFunc = func(ctx context.Context, id pgtype.UUID) (sqlc.Settlement, error) {
+		return sqlc.Settlement{ID: id, PayerID: payerID, PayeeID: testutil.CreateTestUUID(2), Status: "completed"}, nil
+	}
+
+	svc := NewSettlementService(repo, &MockGroupActivityService{})
+	_, err := svc.UpdateSettlement(context.Background(), UpdateSettlementInput{
+		SettlementID: testutil.CreateTestUUID(200),
+		Amount:       "10.00",
+		Status:       "completed",
+		UpdatedBy:    payerID,
+	})
+	if err != ErrSettlementFinalized {
+		t.Errorf("expected ErrSettlementFinalized, got %v", err)
+	}
+}
+
+func TestSettlementService_CreateSettlement_PendingPayer(t *testing.T) {
+	memberID := testutil.CreateTestUUID(1)
+	payeeID := testutil.CreateTestUUID(2)
+
+	repo := memberSettlementRepo()
+	repo.HasPendingMemberInvitationFunc = func(ctx context.Context, params sqlc.HasPendingMemberInvitationParams) (bool, error) {
+		return true, nil
+	}
+	created := false
+	repo.CreateSettlementFunc = func(ctx context.Context, params sqlc.CreateSettlementParams) (sqlc.Settlement, error) {
+		created = true
+		return sqlc.Settlement{Status: params.Status}, nil
+	}
+
+	svc := NewSettlementService(repo, &MockGroupActivityService{})
+	_, err := svc.CreateSettlement(context.Background(), CreateSettlementInput{
+		GroupID:            testutil.CreateTestUUID(100),
+		PayerPendingUserID: testutil.CreateTestUUID(50),
+		PayeeID:            payeeID,
+		Amount:             "25.00",
+		CreatedBy:          memberID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !created {
+		t.Error("expected the settlement to be recorded for the pending payer")
+	}
+}
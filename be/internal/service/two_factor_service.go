@@ -0,0 +1,465 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+const (
+	// TwoFactorChallengeTTL is how long the user has to enter a code after the
+	// password step of a sign-in.
+	TwoFactorChallengeTTL = 5 * time.Minute
+
+	// twoFactorChallengeMaxAttempts wrong codes end the challenge, so the code
+	// can't be guessed without going through the password step again.
+	twoFactorChallengeMaxAttempts = 5
+
+	// twoFactorSettingsMaxAttempts wrong codes in a row lock the settings that
+	// need a code (turning two-factor authentication off, new recovery codes)
+	// for twoFactorSettingsLockout, so a stolen session can't guess the code.
+	twoFactorSettingsMaxAttempts = 5
+	twoFactorSettingsLockout     = 15 * time.Minute
+
+	recoveryCodeCount = 10
+	totpIssuer        = "SplitPlus"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled      = errors.New("two-factor enrollment has not been started")
+	ErrTwoFactorNotEnabled       = errors.New("two-factor authentication is not enabled")
+	ErrInvalidTwoFactorCode      = errors.New("invalid two-factor code")
+	ErrTwoFactorChallengeInvalid = errors.New("two-factor challenge expired or was already used")
+	ErrTwoFactorLocked           = errors.New("too many invalid two-factor codes")
+)
+
+// TwoFactorStatus describes a user's two-factor setup.
+type TwoFactorStatus struct {
+	Enabled                bool
+	EnabledAt              pgtype.Timestamptz
+	RecoveryCodesRemaining int64
+}
+
+// TwoFactorEnrollment is what the user needs to add the account to an
+// authenticator app: a provisioning URI to show as a QR code, and the secret
+// for typing in by hand.
+type TwoFactorEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// TwoFactorService manages TOTP two-factor authentication and the sign-in
+// challenges that wait for a second factor. Wherever a code is asked for, a
+// 6 digit authenticator code or an unused recovery code is accepted, except
+// when confirming enrollment, which proves the authenticator works.
+type TwoFactorService interface {
+	GetStatus(ctx context.Context, userID pgtype.UUID) (TwoFactorStatus, error)
+	// Enroll starts (or restarts) enrollment with a new secret. Two-factor
+	// authentication is enabled once ConfirmEnrollment sees a code for it.
+	Enroll(ctx context.Context, userID pgtype.UUID) (TwoFactorEnrollment, error)
+	// ConfirmEnrollment enables two-factor authentication and returns the
+	// recovery codes. They are only ever shown this once.
+	ConfirmEnrollment(ctx context.Context, userID pgtype.UUID, code string) (recoveryCodes []string, err error)
+	// RegenerateRecoveryCodes replaces all recovery codes with new ones.
+	RegenerateRecoveryCodes(ctx context.Context, userID pgtype.UUID, code string) (recoveryCodes []string, err error)
+	Disable(ctx context.Context, userID pgtype.UUID, code string) error
+
+	IsEnabled(ctx context.Context, userID pgtype.UUID) (bool, error)
+	// CreateChallenge returns the token that stands in for a sign-in until the
+	// second factor is given.
+	CreateChallenge(ctx context.Context, userID pgtype.UUID) (challengeToken string, err error)
+	// VerifyChallenge checks the code for a challenge and returns the user it
+	// was created for. The challenge can't be used again.
+	VerifyChallenge(ctx context.Context, challengeToken, code string) (pgtype.UUID, error)
+}
+
+type twoFactorService struct {
+	repo     repository.TwoFactorRepository
+	userRepo repository.UserRepository
+}
+
+func NewTwoFactorService(repo repository.TwoFactorRepository, userRepo repository.UserRepository) TwoFactorService {
+	return &twoFactorService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *twoFactorService) GetStatus(ctx context.Context, userID pgtype.UUID) (TwoFactorStatus, error) {
+	twoFactor, err := s.repo.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TwoFactorStatus{}, nil
+		}
+		return TwoFactorStatus{}, err
+	}
+	if !twoFactor.EnabledAt.Valid {
+		return TwoFactorStatus{}, nil
+	}
+
+	remaining, err := s.repo.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return TwoFactorStatus{}, err
+	}
+
+	return TwoFactorStatus{
+		Enabled:                true,
+		EnabledAt:              twoFactor.EnabledAt,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+func (s *twoFactorService) Enroll(ctx context.Context, userID pgtype.UUID) (TwoFactorEnrollment, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TwoFactorEnrollment{}, ErrUserNotFound
+		}
+		return TwoFactorEnrollment{}, err
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+
+	// No row comes back when two-factor authentication is already enabled
+	twoFactor, err := s.repo.UpsertUserTwoFactorSecret(ctx, sqlc.UpsertUserTwoFactorSecretParams{
+		UserID: userID,
+		Secret: secret,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TwoFactorEnrollment{}, ErrTwoFactorAlreadyEnabled
+		}
+		return TwoFactorEnrollment{}, err
+	}
+
+	return TwoFactorEnrollment{
+		Secret:          twoFactor.Secret,
+		ProvisioningURI: totpProvisioningURI(totpIssuer, user.Email, twoFactor.Secret),
+	}, nil
+}
+
+func (s *twoFactorService) ConfirmEnrollment(ctx context.Context, userID pgtype.UUID, code string) ([]string, error) {
+	twoFactor, err := s.repo.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTwoFactorNotEnrolled
+		}
+		return nil, err
+	}
+	if twoFactor.EnabledAt.Valid {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := s.verifyTOTP(ctx, twoFactor, normalizeTwoFactorCode(code)); err != nil {
+		return nil, err
+	}
+
+	// Two-factor authentication only turns on together with the recovery codes
+	// the user is about to be shown
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	txRepo := s.repo.WithTx(tx)
+
+	recoveryCodes, err := replaceRecoveryCodes(ctx, txRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := txRepo.EnableUserTwoFactor(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (s *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID pgtype.UUID, code string) ([]string, error) {
+	twoFactor, err := s.getEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifySettingsCode(ctx, twoFactor, code); err != nil {
+		return nil, err
+	}
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	recoveryCodes, err := replaceRecoveryCodes(ctx, s.repo.WithTx(tx), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (s *twoFactorService) Disable(ctx context.Context, userID pgtype.UUID, code string) error {
+	twoFactor, err := s.getEnabled(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.verifySettingsCode(ctx, twoFactor, code); err != nil {
+		return err
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	txRepo := s.repo.WithTx(tx)
+
+	if err := txRepo.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+	if err := txRepo.DeleteUserTwoFactor(ctx, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *twoFactorService) IsEnabled(ctx context.Context, userID pgtype.UUID) (bool, error) {
+	_, err := s.getEnabled(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrTwoFactorNotEnabled) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *twoFactorService) CreateChallenge(ctx context.Context, userID pgtype.UUID) (string, error) {
+	// Abandoned challenges are cleared here rather than by a job
+	if err := s.repo.DeleteExpiredTwoFactorChallenges(ctx); err != nil {
+		return "", err
+	}
+
+	token, err := randomURLToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.repo.CreateTwoFactorChallenge(ctx, sqlc.CreateTwoFactorChallengeParams{
+		TokenHash: hashToken(token),
+		UserID:    userID,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(TwoFactorChallengeTTL), Valid: true},
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *twoFactorService) VerifyChallenge(ctx context.Context, challengeToken, code string) (pgtype.UUID, error) {
+	tokenHash := hashToken(challengeToken)
+	challenge, err := s.repo.GetTwoFactorChallenge(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.UUID{}, ErrTwoFactorChallengeInvalid
+		}
+		return pgtype.UUID{}, err
+	}
+
+	twoFactor, err := s.getEnabled(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, ErrTwoFactorNotEnabled) {
+			// Disabled since the challenge was created; the user signs in again
+			if err := s.repo.DeleteTwoFactorChallenge(ctx, tokenHash); err != nil {
+				return pgtype.UUID{}, err
+			}
+			return pgtype.UUID{}, ErrTwoFactorChallengeInvalid
+		}
+		return pgtype.UUID{}, err
+	}
+
+	if err := s.verifyCode(ctx, twoFactor, code); err != nil {
+		if !errors.Is(err, ErrInvalidTwoFactorCode) {
+			return pgtype.UUID{}, err
+		}
+		attempts, failErr := s.repo.RecordTwoFactorChallengeFailure(ctx, tokenHash)
+		if failErr != nil {
+			return pgtype.UUID{}, failErr
+		}
+		if attempts >= twoFactorChallengeMaxAttempts {
+			if err := s.repo.DeleteTwoFactorChallenge(ctx, tokenHash); err != nil {
+				return pgtype.UUID{}, err
+			}
+			return pgtype.UUID{}, ErrTwoFactorChallengeInvalid
+		}
+		return pgtype.UUID{}, err
+	}
+
+	if err := s.repo.DeleteTwoFactorChallenge(ctx, tokenHash); err != nil {
+		return pgtype.UUID{}, err
+	}
+	return challenge.UserID, nil
+}
+
+func (s *twoFactorService) getEnabled(ctx context.Context, userID pgtype.UUID) (sqlc.UserTwoFactor, error) {
+	twoFactor, err := s.repo.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.UserTwoFactor{}, ErrTwoFactorNotEnabled
+		}
+		return sqlc.UserTwoFactor{}, err
+	}
+	if !twoFactor.EnabledAt.Valid {
+		return sqlc.UserTwoFactor{}, ErrTwoFactorNotEnabled
+	}
+	return twoFactor, nil
+}
+
+// verifyCode accepts an authenticator code or an unused recovery code.
+func (s *twoFactorService) verifyCode(ctx context.Context, twoFactor sqlc.UserTwoFactor, code string) error {
+	code = normalizeTwoFactorCode(code)
+	if isTOTPCode(code) {
+		return s.verifyTOTP(ctx, twoFactor, code)
+	}
+
+	_, err := s.repo.UseRecoveryCode(ctx, sqlc.UseRecoveryCodeParams{
+		UserID:   twoFactor.UserID,
+		CodeHash: hashToken(code),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvalidTwoFactorCode
+		}
+		return err
+	}
+	return nil
+}
+
+// verifySettingsCode is verifyCode for changing two-factor settings while
+// signed in. Like a sign-in challenge it only allows a few wrong codes, then
+// the settings stay locked for twoFactorSettingsLockout.
+func (s *twoFactorService) verifySettingsCode(ctx context.Context, twoFactor sqlc.UserTwoFactor, code string) error {
+	if twoFactor.LockedUntil.Valid && twoFactor.LockedUntil.Time.After(time.Now()) {
+		return ErrTwoFactorLocked
+	}
+
+	if err := s.verifyCode(ctx, twoFactor, code); err != nil {
+		if !errors.Is(err, ErrInvalidTwoFactorCode) {
+			return err
+		}
+		attempts, failErr := s.repo.RecordTwoFactorFailure(ctx, twoFactor.UserID)
+		if failErr != nil {
+			return failErr
+		}
+		if attempts >= twoFactorSettingsMaxAttempts {
+			if err := s.repo.LockUserTwoFactor(ctx, sqlc.LockUserTwoFactorParams{
+				UserID:      twoFactor.UserID,
+				LockedUntil: pgtype.Timestamptz{Time: time.Now().Add(twoFactorSettingsLockout), Valid: true},
+			}); err != nil {
+				return err
+			}
+			return ErrTwoFactorLocked
+		}
+		return err
+	}
+
+	if twoFactor.FailedAttempts > 0 {
+		return s.repo.ResetTwoFactorFailures(ctx, twoFactor.UserID)
+	}
+	return nil
+}
+
+func (s *twoFactorService) verifyTOTP(ctx context.Context, twoFactor sqlc.UserTwoFactor, code string) error {
+	step, ok := matchTOTP(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	// Recording the step fails if this code (or a later one) was already used
+	_, err := s.repo.RecordTwoFactorStep(ctx, sqlc.RecordTwoFactorStepParams{
+		UserID:       twoFactor.UserID,
+		LastUsedStep: step,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvalidTwoFactorCode
+		}
+		return err
+	}
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, repo repository.TwoFactorRepository, userID pgtype.UUID) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		hashes[i] = hashToken(normalizeTwoFactorCode(code))
+	}
+
+	if err := repo.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := repo.CreateRecoveryCodes(ctx, sqlc.CreateRecoveryCodesParams{
+		UserID:     userID,
+		CodeHashes: hashes,
+	}); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// generateRecoveryCode returns an 80-bit code formatted for reading and typing,
+// like "k7qd-m2xa-pf4t-9wzb".
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	encoded := strings.ToLower(totpEncoding.EncodeToString(b))
+	return encoded[0:4] + "-" + encoded[4:8] + "-" + encoded[8:12] + "-" + encoded[12:16], nil
+}
+
+// normalizeTwoFactorCode drops the spaces and dashes people type or paste
+// along with a code, and the case of recovery codes.
+func normalizeTwoFactorCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totpDigits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,591 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockTwoFactorRepository for testing
+type MockTwoFactorRepository struct {
+	BeginTxFunc                          func(ctx context.Context) (pgx.Tx, error)
+	WithTxFunc                           func(tx pgx.Tx) repository.TwoFactorRepository
+	GetUserTwoFactorFunc                 func(ctx context.Context, userID pgtype.UUID) (sqlc.UserTwoFactor, error)
+	UpsertUserTwoFactorSecretFunc        func(ctx context.Context, params sqlc.UpsertUserTwoFactorSecretParams) (sqlc.UserTwoFactor, error)
+	EnableUserTwoFactorFunc              func(ctx context.Context, userID pgtype.UUID) error
+	RecordTwoFactorStepFunc              func(ctx context.Context, params sqlc.RecordTwoFactorStepParams) (sqlc.UserTwoFactor, error)
+	RecordTwoFactorFailureFunc           func(ctx context.Context, userID pgtype.UUID) (int32, error)
+	LockUserTwoFactorFunc                func(ctx context.Context, params sqlc.LockUserTwoFactorParams) error
+	ResetTwoFactorFailuresFunc           func(ctx context.Context, userID pgtype.UUID) error
+	DeleteUserTwoFactorFunc              func(ctx context.Context, userID pgtype.UUID) error
+	CreateRecoveryCodesFunc              func(ctx context.Context, params sqlc.CreateRecoveryCodesParams) error
+	UseRecoveryCodeFunc                  func(ctx context.Context, params sqlc.UseRecoveryCodeParams) (sqlc.UserRecoveryCode, error)
+	CountUnusedRecoveryCodesFunc         func(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeleteUserRecoveryCodesFunc          func(ctx context.Context, userID pgtype.UUID) error
+	CreateTwoFactorChallengeFunc         func(ctx context.Context, params sqlc.CreateTwoFactorChallengeParams) error
+	GetTwoFactorChallengeFunc            func(ctx context.Context, tokenHash string) (sqlc.TwoFactorChallenge, error)
+	RecordTwoFactorChallengeFailureFunc  func(ctx context.Context, tokenHash string) (int32, error)
+	DeleteTwoFactorChallengeFunc         func(ctx context.Context, tokenHash string) error
+	DeleteExpiredTwoFactorChallengesFunc func(ctx context.Context) error
+}
+
+func (m *MockTwoFactorRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	if m.BeginTxFunc != nil {
+		return m.BeginTxFunc(ctx)
+	}
+	return &testutil.MockTx{}, nil
+}
+
+func (m *MockTwoFactorRepository) WithTx(tx pgx.Tx) repository.TwoFactorRepository {
+	if m.WithTxFunc != nil {
+		return m.WithTxFunc(tx)
+	}
+	return m
+}
+
+func (m *MockTwoFactorRepository) GetUserTwoFactor(ctx context.Context, userID pgtype.UUID) (sqlc.UserTwoFactor, error) {
+	if m.GetUserTwoFactorFunc != nil {
+		return m.GetUserTwoFactorFunc(ctx, userID)
+	}
+	return sqlc.UserTwoFactor{}, pgx.ErrNoRows
+}
+
+func (m *MockTwoFactorRepository) UpsertUserTwoFactorSecret(ctx context.Context, params sqlc.UpsertUserTwoFactorSecretParams) (sqlc.UserTwoFactor, error) {
+	if m.UpsertUserTwoFactorSecretFunc != nil {
+		return m.UpsertUserTwoFactorSecretFunc(ctx, params)
+	}
+	return sqlc.UserTwoFactor{UserID: params.UserID, Secret: params.Secret}, nil
+}
+
+func (m *MockTwoFactorRepository) EnableUserTwoFactor(ctx context.Context, userID pgtype.UUID) error {
+	if m.EnableUserTwoFactorFunc != nil {
+		return m.EnableUserTwoFactorFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockTwoFactorRepository) RecordTwoFactorStep(ctx context.Context, params sqlc.RecordTwoFactorStepParams) (sqlc.UserTwoFactor, error) {
+	if m.RecordTwoFactorStepFunc != nil {
+		return m.RecordTwoFactorStepFunc(ctx, params)
+	}
+	return sqlc.UserTwoFactor{UserID: params.UserID, LastUsedStep: params.LastUsedStep}, nil
+}
+
+func (m *MockTwoFactorRepository) RecordTwoFactorFailure(ctx context.Context, userID pgtype.UUID) (int32, error) {
+	if m.RecordTwoFactorFailureFunc != nil {
+		return m.RecordTwoFactorFailureFunc(ctx, userID)
+	}
+	return 1, nil
+}
+
+func (m *MockTwoFactorRepository) LockUserTwoFactor(ctx context.Context, params sqlc.LockUserTwoFactorParams) error {
+	if m.LockUserTwoFactorFunc != nil {
+		return m.LockUserTwoFactorFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockTwoFactorRepository) ResetTwoFactorFailures(ctx context.Context, userID pgtype.UUID) error {
+	if m.ResetTwoFactorFailuresFunc != nil {
+		return m.ResetTwoFactorFailuresFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockTwoFactorRepository) DeleteUserTwoFactor(ctx context.Context, userID pgtype.UUID) error {
+	if m.DeleteUserTwoFactorFunc != nil {
+		return m.DeleteUserTwoFactorFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockTwoFactorRepository) CreateRecoveryCodes(ctx context.Context, params sqlc.CreateRecoveryCodesParams) error {
+	if m.CreateRecoveryCodesFunc != nil {
+		return m.CreateRecoveryCodesFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockTwoFactorRepository) UseRecoveryCode(ctx context.Context, params sqlc.UseRecoveryCodeParams) (sqlc.UserRecoveryCode, error) {
+	if m.UseRecoveryCodeFunc != nil {
+		return m.UseRecoveryCodeFunc(ctx, params)
+	}
+	return sqlc.UserRecoveryCode{}, pgx.ErrNoRows
+}
+
+func (m *MockTwoFactorRepository) CountUnusedRecoveryCodes(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if m.CountUnusedRecoveryCodesFunc != nil {
+		return m.CountUnusedRecoveryCodesFunc(ctx, userID)
+	}
+	return 0, nil
+}
+
+func (m *MockTwoFactorRepository) DeleteUserRecoveryCodes(ctx context.Context, userID pgtype.UUID) error {
+	if m.DeleteUserRecoveryCodesFunc != nil {
+		return m.DeleteUserRecoveryCodesFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockTwoFactorRepository) CreateTwoFactorChallenge(ctx context.Context, params sqlc.CreateTwoFactorChallengeParams) error {
+	if m.CreateTwoFactorChallengeFunc != nil {
+		return m.CreateTwoFactorChallengeFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockTwoFactorRepository) GetTwoFactorChallenge(ctx context.Context, tokenHash string) (sqlc.TwoFactorChallenge, error) {
+	if m.GetTwoFactorChallengeFunc != nil {
+		return m.GetTwoFactorChallengeFunc(ctx, tokenHash)
+	}
+	return sqlc.TwoFactorChallenge{}, pgx.ErrNoRows
+}
+
+func (m *MockTwoFactorRepository) RecordTwoFactorChallengeFailure(ctx context.Context, tokenHash string) (int32, error) {
+	if m.RecordTwoFactorChallengeFailureFunc != nil {
+		return m.RecordTwoFactorChallengeFailureFunc(ctx, tokenHash)
+	}
+	return 1, nil
+}
+
+func (m *MockTwoFactorRepository) DeleteTwoFactorChallenge(ctx context.Context, tokenHash string) error {
+	if m.DeleteTwoFactorChallengeFunc != nil {
+		return m.DeleteTwoFactorChallengeFunc(ctx, tokenHash)
+	}
+	return nil
+}
+
+func (m *MockTwoFactorRepository) DeleteExpiredTwoFactorChallenges(ctx context.Context) error {
+	if m.DeleteExpiredTwoFactorChallengesFunc != nil {
+		return m.DeleteExpiredTwoFactorChallengesFunc(ctx)
+	}
+	return nil
+}
+
+// twoFactorStore is the state behind a MockTwoFactorRepository from newTwoFactorStore.
+type twoFactorStore struct {
+	twoFactor     map[pgtype.UUID]*sqlc.UserTwoFactor
+	recoveryCodes map[pgtype.UUID]map[string]bool // code hash -> used
+	challenges    map[string]*sqlc.TwoFactorChallenge
+}
+
+// newTwoFactorStore backs a MockTwoFactorRepository with maps that behave like
+// the queries, including the replay and single use guards.
+func newTwoFactorStore() (*MockTwoFactorRepository, *twoFactorStore) {
+	store := &twoFactorStore{
+		twoFactor:     map[pgtype.UUID]*sqlc.UserTwoFactor{},
+		recoveryCodes: map[pgtype.UUID]map[string]bool{},
+		challenges:    map[string]*sqlc.TwoFactorChallenge{},
+	}
+	repo := &MockTwoFactorRepository{
+		GetUserTwoFactorFunc: func(ctx context.Context, userID pgtype.UUID) (sqlc.UserTwoFactor, error) {
+			if tf, ok := store.twoFactor[userID]; ok {
+				return *tf, nil
+			}
+			return sqlc.UserTwoFactor{}, pgx.ErrNoRows
+		},
+		UpsertUserTwoFactorSecretFunc: func(ctx context.Context, params sqlc.UpsertUserTwoFactorSecretParams) (sqlc.UserTwoFactor, error) {
+			if tf, ok := store.twoFactor[params.UserID]; ok && tf.EnabledAt.Valid {
+				return sqlc.UserTwoFactor{}, pgx.ErrNoRows
+			}
+			store.twoFactor[params.UserID] = &sqlc.UserTwoFactor{UserID: params.UserID, Secret: params.Secret}
+			return *store.twoFactor[params.UserID], nil
+		},
+		EnableUserTwoFactorFunc: func(ctx context.Context, userID pgtype.UUID) error {
+			store.twoFactor[userID].EnabledAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+			return nil
+		},
+		RecordTwoFactorStepFunc: func(ctx context.Context, params sqlc.RecordTwoFactorStepParams) (sqlc.UserTwoFactor, error) {
+			tf, ok := store.twoFactor[params.UserID]
+			if !ok || tf.LastUsedStep >= params.LastUsedStep {
+				return sqlc.UserTwoFactor{}, pgx.ErrNoRows
+			}
+			tf.LastUsedStep = params.LastUsedStep
+			return *tf, nil
+		},
+		RecordTwoFactorFailureFunc: func(ctx context.Context, userID pgtype.UUID) (int32, error) {
+			store.twoFactor[userID].FailedAttempts++
+			return store.twoFactor[userID].FailedAttempts, nil
+		},
+		LockUserTwoFactorFunc: func(ctx context.Context, params sqlc.LockUserTwoFactorParams) error {
+			store.twoFactor[params.UserID].FailedAttempts = 0
+			store.twoFactor[params.UserID].LockedUntil = params.LockedUntil
+			return nil
+		},
+		ResetTwoFactorFailuresFunc: func(ctx context.Context, userID pgtype.UUID) error {
+			store.twoFactor[userID].FailedAttempts = 0
+			return nil
+		},
+		DeleteUserTwoFactorFunc: func(ctx context.Context, userID pgtype.UUID) error {
+			delete(store.twoFactor, userID)
+			return nil
+		},
+		CreateRecoveryCodesFunc: func(ctx context.Context, params sqlc.CreateRecoveryCodesParams) error {
+			codes := map[string]bool{}
+			for _, hash := range params.CodeHashes {
+				codes[hash] = false
+			}
+			store.recoveryCodes[params.UserID] = codes
+			return nil
+		},
+		UseRecoveryCodeFunc: func(ctx context.Context, params sqlc.UseRecoveryCodeParams) (sqlc.UserRecoveryCode, error) {
+			used, ok := store.recoveryCodes[params.UserID][params.CodeHash]
+			if !ok || used {
+				return sqlc.UserRecoveryCode{}, pgx.ErrNoRows
+			}
+			store.recoveryCodes[params.UserID][params.CodeHash] = true
+			return sqlc.UserRecoveryCode{UserID: params.UserID, CodeHash: params.CodeHash}, nil
+		},
+		CountUnusedRecoveryCodesFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+			var count int64
+			for _, used := range store.recoveryCodes[userID] {
+				if !used {
+					count++
+				}
+			}
+			return count, nil
+		},
+		DeleteUserRecoveryCodesFunc: func(ctx context.Context, userID pgtype.UUID) error {
+			delete(store.recoveryCodes, userID)
+			return nil
+		},
+		CreateTwoFactorChallengeFunc: func(ctx context.Context, params sqlc.CreateTwoFactorChallengeParams) error {
+			store.challenges[params.TokenHash] = &sqlc.TwoFactorChallenge{
+				TokenHash: params.TokenHash,
+				UserID:    params.UserID,
+				ExpiresAt: params.ExpiresAt,
+			}
+			return nil
+		},
+		GetTwoFactorChallengeFunc: func(ctx context.Context, tokenHash string) (sqlc.TwoFactorChallenge, error) {
+			challenge, ok := store.challenges[tokenHash]
+			if !ok || !challenge.ExpiresAt.Time.After(time.Now()) {
+				return sqlc.TwoFactorChallenge{}, pgx.ErrNoRows
+			}
+			return *challenge, nil
+		},
+		RecordTwoFactorChallengeFailureFunc: func(ctx context.Context, tokenHash string) (int32, error) {
+			store.challenges[tokenHash].FailedAttempts++
+			return store.challenges[tokenHash].FailedAttempts, nil
+		},
+		DeleteTwoFactorChallengeFunc: func(ctx context.Context, tokenHash string) error {
+			delete(store.challenges, tokenHash)
+			return nil
+		},
+	}
+	return repo, store
+}
+
+func newTestTwoFactorService(userID pgtype.UUID) (TwoFactorService, *twoFactorStore) {
+	repo, store := newTwoFactorStore()
+	userRepo := &testutil.MockUserRepository{
+		GetUserByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+			if id != userID {
+				return sqlc.User{}, pgx.ErrNoRows
+			}
+			return sqlc.User{ID: id, Email: "ana@example.com"}, nil
+		},
+	}
+	return NewTwoFactorService(repo, userRepo), store
+}
+
+// enableTwoFactor enrolls the user and confirms with the current code, returning
+// the secret and recovery codes.
+func enableTwoFactor(t *testing.T, svc TwoFactorService, store *twoFactorStore, userID pgtype.UUID) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	enrollment, err := svc.Enroll(ctx, userID)
+	if err != nil {
+		t.Fatalf("Enroll: %v", err)
+	}
+	code, _ := totpCode(enrollment.Secret, totpStep(time.Now()))
+	recoveryCodes, err := svc.ConfirmEnrollment(ctx, userID, code)
+	if err != nil {
+		t.Fatalf("ConfirmEnrollment: %v", err)
+	}
+	// Let the tests use the next code without tripping the replay guard
+	store.twoFactor[userID].LastUsedStep = 0
+	return enrollment.Secret, recoveryCodes
+}
+
+func TestTwoFactorService_Enrollment(t *testing.T) {
+	ctx := context.Background()
+	userID := testutil.CreateTestUUID(1)
+	svc, store := newTestTwoFactorService(userID)
+
+	enrollment, err := svc.Enroll(ctx, userID)
+	if err != nil {
+		t.Fatalf("Enroll: %v", err)
+	}
+	if !strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/SplitPlus:ana@example.com?") ||
+		!strings.Contains(enrollment.ProvisioningURI, "secret="+enrollment.Secret) {
+		t.Errorf("unexpected provisioning URI %s", enrollment.ProvisioningURI)
+	}
+
+	enabled, _ := svc.IsEnabled(ctx, userID)
+	if enabled {
+		t.Fatal("expected two-factor to stay off until the enrollment is confirmed")
+	}
+
+	if _, err := svc.ConfirmEnrollment(ctx, userID, "000000"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("wrong code: expected %v, got %v", ErrInvalidTwoFactorCode, err)
+	}
+
+	code, _ := totpCode(enrollment.Secret, totpStep(time.Now()))
+	recoveryCodes, err := svc.ConfirmEnrollment(ctx, userID, code)
+	if err != nil {
+		t.Fatalf("ConfirmEnrollment: %v", err)
+	}
+	if len(recoveryCodes) != recoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %d", recoveryCodeCount, len(recoveryCodes))
+	}
+	for _, recoveryCode := range recoveryCodes {
+		if _, stored := store.recoveryCodes[userID][recoveryCode]; stored {
+			t.Fatal("recovery codes must be stored hashed")
+		}
+	}
+
+	status, err := svc.GetStatus(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if !status.Enabled || status.RecoveryCodesRemaining != int64(recoveryCodeCount) {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	if _, err := svc.Enroll(ctx, userID); !errors.Is(err, ErrTwoFactorAlreadyEnabled) {
+		t.Errorf("re-enroll: expected %v, got %v", ErrTwoFactorAlreadyEnabled, err)
+	}
+}
+
+func TestTwoFactorService_ConfirmEnrollment_RequiresEnroll(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	svc, _ := newTestTwoFactorService(userID)
+
+	if _, err := svc.ConfirmEnrollment(context.Background(), userID, "123456"); !errors.Is(err, ErrTwoFactorNotEnrolled) {
+		t.Errorf("expected %v, got %v", ErrTwoFactorNotEnrolled, err)
+	}
+}
+
+func TestTwoFactorService_ConfirmEnrollment_OneTransaction(t *testing.T) {
+	ctx := context.Background()
+	userID := testutil.CreateTestUUID(1)
+	repo, _ := newTwoFactorStore()
+	committed := false
+	repo.BeginTxFunc = func(ctx context.Context) (pgx.Tx, error) {
+		return &testutil.MockTx{CommitFunc: func(ctx context.Context) error {
+			committed = true
+			return nil
+		}}, nil
+	}
+	dbErr := errors.New("connection reset")
+	repo.EnableUserTwoFactorFunc = func(ctx context.Context, userID pgtype.UUID) error {
+		return dbErr
+	}
+	svc := NewTwoFactorService(repo, &testutil.MockUserRepository{
+		GetUserByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
+			return sqlc.User{ID: id, Email: "ana@example.com"}, nil
+		},
+	})
+
+	enrollment, err := svc.Enroll(ctx, userID)
+	if err != nil {
+		t.Fatalf("Enroll: %v", err)
+	}
+	code, _ := totpCode(enrollment.Secret, totpStep(time.Now()))
+	if _, err := svc.ConfirmEnrollment(ctx, userID, code); !errors.Is(err, dbErr) {
+		t.Fatalf("expected %v, got %v", dbErr, err)
+	}
+	if committed {
+		t.Error("expected the recovery codes to roll back with the failed enable")
+	}
+}
+
+func TestTwoFactorService_VerifyChallenge(t *testing.T) {
+	ctx := context.Background()
+	userID := testutil.CreateTestUUID(1)
+
+	t.Run("authenticator code", func(t *testing.T) {
+		svc, store := newTestTwoFactorService(userID)
+		secret, _ := enableTwoFactor(t, svc, store, userID)
+		code, _ := totpCode(secret, totpStep(time.Now()))
+
+		challenge, err := svc.CreateChallenge(ctx, userID)
+		if err != nil {
+			t.Fatalf("CreateChallenge: %v", err)
+		}
+		got, err := svc.VerifyChallenge(ctx, challenge, code[:3]+" "+code[3:])
+		if err != nil {
+			t.Fatalf("VerifyChallenge: %v", err)
+		}
+		if got != userID {
+			t.Errorf("expected user %v, got %v", userID, got)
+		}
+
+		if _, err := svc.VerifyChallenge(ctx, challenge, code); !errors.Is(err, ErrTwoFactorChallengeInvalid) {
+			t.Errorf("reused challenge: expected %v, got %v", ErrTwoFactorChallengeInvalid, err)
+		}
+
+		// The same code can't sign in again through a new challenge
+		challenge, _ = svc.CreateChallenge(ctx, userID)
+		if _, err := svc.VerifyChallenge(ctx, challenge, code); !errors.Is(err, ErrInvalidTwoFactorCode) {
+			t.Errorf("replayed code: expected %v, got %v", ErrInvalidTwoFactorCode, err)
+		}
+	})
+
+	t.Run("recovery code works once", func(t *testing.T) {
+		svc, store := newTestTwoFactorService(userID)
+		_, recoveryCodes := enableTwoFactor(t, svc, store, userID)
+
+		challenge, _ := svc.CreateChallenge(ctx, userID)
+		if _, err := svc.VerifyChallenge(ctx, challenge, strings.ToUpper(recoveryCodes[0])); err != nil {
+			t.Fatalf("VerifyChallenge: %v", err)
+		}
+
+		challenge, _ = svc.CreateChallenge(ctx, userID)
+		if _, err := svc.VerifyChallenge(ctx, challenge, recoveryCodes[0]); !errors.Is(err, ErrInvalidTwoFactorCode) {
+			t.Errorf("used recovery code: expected %v, got %v", ErrInvalidTwoFactorCode, err)
+		}
+
+		status, _ := svc.GetStatus(ctx, userID)
+		if status.RecoveryCodesRemaining != int64(recoveryCodeCount-1) {
+			t.Errorf("expected %d recovery codes left, got %d", recoveryCodeCount-1, status.RecoveryCodesRemaining)
+		}
+	})
+
+	t.Run("too many wrong codes end the challenge", func(t *testing.T) {
+		svc, store := newTestTwoFactorService(userID)
+		secret, _ := enableTwoFactor(t, svc, store, userID)
+		challenge, _ := svc.CreateChallenge(ctx, userID)
+
+		for i := 1; i < twoFactorChallengeMaxAttempts; i++ {
+			if _, err := svc.VerifyChallenge(ctx, challenge, "wrong-code"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+				t.Fatalf("attempt %d: expected %v, got %v", i, ErrInvalidTwoFactorCode, err)
+			}
+		}
+		if _, err := svc.VerifyChallenge(ctx, challenge, "wrong-code"); !errors.Is(err, ErrTwoFactorChallengeInvalid) {
+			t.Fatalf("last attempt: expected %v, got %v", ErrTwoFactorChallengeInvalid, err)
+		}
+
+		code, _ := totpCode(secret, totpStep(time.Now()))
+		if _, err := svc.VerifyChallenge(ctx, challenge, code); !errors.Is(err, ErrTwoFactorChallengeInvalid) {
+			t.Errorf("after lockout: expected %v, got %v", ErrTwoFactorChallengeInvalid, err)
+		}
+	})
+
+	t.Run("expired challenge", func(t *testing.T) {
+		svc, store := newTestTwoFactorService(userID)
+		secret, _ := enableTwoFactor(t, svc, store, userID)
+		challenge, _ := svc.CreateChallenge(ctx, userID)
+		store.challenges[hashToken(challenge)].ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Second), Valid: true}
+
+		code, _ := totpCode(secret, totpStep(time.Now()))
+		if _, err := svc.VerifyChallenge(ctx, challenge, code); !errors.Is(err, ErrTwoFactorChallengeInvalid) {
+			t.Errorf("expected %v, got %v", ErrTwoFactorChallengeInvalid, err)
+		}
+	})
+}
+
+func TestTwoFactorService_Disable(t *testing.T) {
+	ctx := context.Background()
+	userID := testutil.CreateTestUUID(1)
+	svc, store := newTestTwoFactorService(userID)
+	secret, _ := enableTwoFactor(t, svc, store, userID)
+
+	if err := svc.Disable(ctx, userID, "000000"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("wrong code: expected %v, got %v", ErrInvalidTwoFactorCode, err)
+	}
+
+	code, _ := totpCode(secret, totpStep(time.Now()))
+	if err := svc.Disable(ctx, userID, code); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+
+	enabled, err := svc.IsEnabled(ctx, userID)
+	if err != nil || enabled {
+		t.Errorf("expected two-factor off, got enabled=%v err=%v", enabled, err)
+	}
+	if len(store.recoveryCodes[userID]) != 0 {
+		t.Error("expected recovery codes to be deleted")
+	}
+	if err := svc.Disable(ctx, userID, code); !errors.Is(err, ErrTwoFactorNotEnabled) {
+		t.Errorf("disable again: expected %v, got %v", ErrTwoFactorNotEnabled, err)
+	}
+}
+
+func TestTwoFactorService_SettingsLockout(t *testing.T) {
+	ctx := context.Background()
+	userID := testutil.CreateTestUUID(1)
+
+	t.Run("too many wrong codes lock the settings", func(t *testing.T) {
+		svc, store := newTestTwoFactorService(userID)
+		secret, _ := enableTwoFactor(t, svc, store, userID)
+
+		for i := 1; i < twoFactorSettingsMaxAttempts; i++ {
+			if err := svc.Disable(ctx, userID, "000000"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+				t.Fatalf("attempt %d: expected %v, got %v", i, ErrInvalidTwoFactorCode, err)
+			}
+		}
+		if _, err := svc.RegenerateRecoveryCodes(ctx, userID, "000000"); !errors.Is(err, ErrTwoFactorLocked) {
+			t.Fatalf("last attempt: expected %v, got %v", ErrTwoFactorLocked, err)
+		}
+
+		code, _ := totpCode(secret, totpStep(time.Now()))
+		if err := svc.Disable(ctx, userID, code); !errors.Is(err, ErrTwoFactorLocked) {
+			t.Errorf("while locked: expected %v, got %v", ErrTwoFactorLocked, err)
+		}
+
+		store.twoFactor[userID].LockedUntil = pgtype.Timestamptz{Time: time.Now().Add(-time.Second), Valid: true}
+		if err := svc.Disable(ctx, userID, code); err != nil {
+			t.Errorf("after lockout: %v", err)
+		}
+	})
+
+	t.Run("a valid code resets the count", func(t *testing.T) {
+		svc, store := newTestTwoFactorService(userID)
+		_, recoveryCodes := enableTwoFactor(t, svc, store, userID)
+
+		for i := 1; i < twoFactorSettingsMaxAttempts; i++ {
+			_ = svc.Disable(ctx, userID, "000000")
+		}
+		if _, err := svc.RegenerateRecoveryCodes(ctx, userID, recoveryCodes[0]); err != nil {
+			t.Fatalf("RegenerateRecoveryCodes: %v", err)
+		}
+		if got := store.twoFactor[userID].FailedAttempts; got != 0 {
+			t.Errorf("expected failed attempts reset, got %d", got)
+		}
+	})
+}
+
+func TestTwoFactorService_RegenerateRecoveryCodes(t *testing.T) {
+	ctx := context.Background()
+	userID := testutil.CreateTestUUID(1)
+	svc, store := newTestTwoFactorService(userID)
+	_, oldCodes := enableTwoFactor(t, svc, store, userID)
+
+	newCodes, err := svc.RegenerateRecoveryCodes(ctx, userID, oldCodes[0])
+	if err != nil {
+		t.Fatalf("RegenerateRecoveryCodes: %v", err)
+	}
+	if len(newCodes) != recoveryCodeCount {
+		t.Fatalf("expected %d codes, got %d", recoveryCodeCount, len(newCodes))
+	}
+
+	challenge, _ := svc.CreateChallenge(ctx, userID)
+	if _, err := svc.VerifyChallenge(ctx, challenge, oldCodes[1]); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Errorf("old code: expected %v, got %v", ErrInvalidTwoFactorCode, err)
+	}
+	if _, err := svc.VerifyChallenge(ctx, challenge, newCodes[1]); err != nil {
+		t.Errorf("new code: %v", err)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
 
@@ -18,10 +20,29 @@ var (
 	ErrUserNotFound      = errors.New("user not found")
 	ErrInvalidPassword   = errors.New("invalid password")
 	ErrUserEmailRequired = errors.New("email is required")
+	ErrAccountLocked     = errors.New("account temporarily locked after too many failed sign-in attempts")
 )
 
+// Progressive lockout: after loginLockoutThreshold failures in a row the account
+// is locked for loginLockoutBase, and every further failure doubles the lock up
+// to loginLockoutMax. Failures stop counting after loginFailureWindow.
+const (
+	loginLockoutThreshold = 5
+	loginLockoutBase      = time.Minute
+	loginLockoutMax       = time.Hour
+	loginFailureWindow    = 24 * time.Hour
+)
+
+// unusablePasswordHash marks accounts without a password. It is not a bcrypt
+// hash, so no password matches it.
+const unusablePasswordHash = "!"
+
 type UserService interface {
 	CreateUser(ctx context.Context, name string, email string, password string) (sqlc.User, error)
+	// CreateUserWithoutPassword creates an account whose email an identity
+	// provider has verified. It can't sign in with a password until the user
+	// sets one through a password reset.
+	CreateUserWithoutPassword(ctx context.Context, name string, email string) (sqlc.User, error)
 	AuthenticateUser(ctx context.Context, email string, password string) (sqlc.User, error)
 	GetUser(ctx context.Context, id pgtype.UUID) (sqlc.User, error)
 }
@@ -40,20 +61,35 @@ func (s *userService) CreateUser(
 	email string,
 	password string,
 ) (sqlc.User, error) {
-	email = strings.ToLower(strings.TrimSpace(email))
-	if email == "" {
-		return sqlc.User{}, ErrUserEmailRequired
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return sqlc.User{}, err
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return s.createUser(ctx, pgtype.Text{String: name, Valid: true}, email, string(hashedPassword))
+}
+
+func (s *userService) CreateUserWithoutPassword(ctx context.Context, name string, email string) (sqlc.User, error) {
+	user, err := s.createUser(ctx, pgtype.Text{String: name, Valid: name != ""}, email, unusablePasswordHash)
 	if err != nil {
 		return sqlc.User{}, err
 	}
+	if err := s.repo.MarkUserEmailVerified(ctx, user.ID); err != nil {
+		return sqlc.User{}, err
+	}
+	return user, nil
+}
+
+func (s *userService) createUser(ctx context.Context, name pgtype.Text, email string, passwordHash string) (sqlc.User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return sqlc.User{}, ErrUserEmailRequired
+	}
 
 	user, err := s.repo.CreateUser(ctx, sqlc.CreateUserParams{
-		Name:         pgtype.Text{String: name, Valid: true},
+		Name:         name,
 		Email:        email,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: passwordHash,
 	})
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -77,14 +113,70 @@ func (s *userService) AuthenticateUser(ctx context.Context, email string, passwo
 		return sqlc.User{}, ErrUserNotFound
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
-	if err != nil {
+	// A locked account is rejected before the password is checked, so guesses
+	// made during the lock learn nothing
+	attempts, err := s.repo.GetUserLoginAttempts(ctx, user.ID)
+	hasFailures := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.User{}, err
+	}
+	if hasFailures && attempts.LockedUntil.Valid && attempts.LockedUntil.Time.After(time.Now()) {
+		return sqlc.User{}, ErrAccountLocked
+	}
+
+	if user.PasswordHash == unusablePasswordHash ||
+		bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		if err := s.recordFailedLogin(ctx, user.ID); err != nil {
+			return sqlc.User{}, err
+		}
 		return sqlc.User{}, ErrInvalidPassword
 	}
 
+	if hasFailures {
+		if err := s.repo.ResetFailedLogins(ctx, user.ID); err != nil {
+			return sqlc.User{}, err
+		}
+	}
+
 	return user, nil
 }
 
+func (s *userService) recordFailedLogin(ctx context.Context, userID pgtype.UUID) error {
+	attempts, err := s.repo.RecordFailedLogin(ctx, sqlc.RecordFailedLoginParams{
+		UserID:      userID,
+		WindowStart: pgtype.Timestamptz{Time: time.Now().Add(-loginFailureWindow), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	lock := loginLockoutDuration(int(attempts.FailedAttempts))
+	if lock == 0 {
+		return nil
+	}
+	return s.repo.LockUserLogin(ctx, sqlc.LockUserLoginParams{
+		UserID:      userID,
+		LockedUntil: pgtype.Timestamptz{Time: time.Now().Add(lock), Valid: true},
+	})
+}
+
+// loginLockoutDuration returns how long to lock an account after the given
+// number of consecutive failed sign-ins, or zero when it stays unlocked.
+func loginLockoutDuration(failedAttempts int) time.Duration {
+	if failedAttempts < loginLockoutThreshold {
+		return 0
+	}
+
+	lock := loginLockoutBase
+	for i := loginLockoutThreshold; i < failedAttempts; i++ {
+		lock *= 2
+		if lock >= loginLockoutMax {
+			return loginLockoutMax
+		}
+	}
+	return lock
+}
+
 func (s *userService) GetUser(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
 	user, err := s.repo.GetUserByID(ctx, id)
 	if err != nil {
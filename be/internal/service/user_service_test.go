@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
 	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
@@ -130,3 +134,178 @@ func TestUserService_CreateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_CreateUserWithoutPassword(t *testing.T) {
+	ctx := context.Background()
+	userID := testutil.CreateTestUUID(1)
+
+	var stored sqlc.User
+	verified := false
+	repo := &testutil.MockUserRepository{
+		CreateUserFunc: func(ctx context.Context, params sqlc.CreateUserParams) (sqlc.User, error) {
+			stored = sqlc.User{ID: userID, Email: params.Email, Name: params.Name, PasswordHash: params.PasswordHash}
+			return stored, nil
+		},
+		MarkUserEmailVerifiedFunc: func(ctx context.Context, id pgtype.UUID) error {
+			verified = id == userID
+			return nil
+		},
+		GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+			return stored, nil
+		},
+		GetUserLoginAttemptsFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.UserLoginAttempt, error) {
+			return sqlc.UserLoginAttempt{}, pgx.ErrNoRows
+		},
+	}
+	svc := NewUserService(repo)
+
+	user, err := svc.CreateUserWithoutPassword(ctx, "", " Ana@Example.com ")
+	if err != nil {
+		t.Fatalf("CreateUserWithoutPassword: %v", err)
+	}
+	if user.Email != "ana@example.com" || user.Name.Valid {
+		t.Errorf("expected normalized email and no name, got %q %+v", user.Email, user.Name)
+	}
+	if !verified {
+		t.Error("expected the email to be marked verified")
+	}
+	if user.PasswordHash != unusablePasswordHash {
+		t.Errorf("expected an unusable password hash, got %q", user.PasswordHash)
+	}
+
+	for _, password := range []string{"", unusablePasswordHash} {
+		if _, err := svc.AuthenticateUser(ctx, "ana@example.com", password); !errors.Is(err, ErrInvalidPassword) {
+			t.Errorf("password %q: expected %v, got %v", password, ErrInvalidPassword, err)
+		}
+	}
+}
+
+func TestUserService_AuthenticateUser_Lockout(t *testing.T) {
+	userID := testutil.CreateTestUUID(1)
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	user := testutil.CreateTestUser(userID, "test@example.com")
+	user.PasswordHash = string(hash)
+
+	future := pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true}
+	past := pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+
+	tests := []struct {
+		name          string
+		password      string
+		attempts      *sqlc.UserLoginAttempt
+		failedCount   int32
+		expectedError error
+		expectLock    bool
+		expectReset   bool
+	}{
+		{
+			name:     "correct password without failures",
+			password: "correct-password",
+		},
+		{
+			name:        "correct password clears earlier failures",
+			password:    "correct-password",
+			attempts:    &sqlc.UserLoginAttempt{UserID: userID, FailedAttempts: 3},
+			expectReset: true,
+		},
+		{
+			name:          "wrong password below threshold",
+			password:      "wrong",
+			failedCount:   2,
+			expectedError: ErrInvalidPassword,
+		},
+		{
+			name:          "wrong password reaching threshold locks the account",
+			password:      "wrong",
+			failedCount:   loginLockoutThreshold,
+			expectedError: ErrInvalidPassword,
+			expectLock:    true,
+		},
+		{
+			name:          "locked account rejects even the correct password",
+			password:      "correct-password",
+			attempts:      &sqlc.UserLoginAttempt{UserID: userID, FailedAttempts: 5, LockedUntil: future},
+			expectedError: ErrAccountLocked,
+		},
+		{
+			name:        "expired lock allows sign-in",
+			password:    "correct-password",
+			attempts:    &sqlc.UserLoginAttempt{UserID: userID, FailedAttempts: 5, LockedUntil: past},
+			expectReset: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locked := false
+			reset := false
+			recorded := false
+			mockRepo := &testutil.MockUserRepository{
+				GetUserByEmailFunc: func(ctx context.Context, email string) (sqlc.User, error) {
+					return user, nil
+				},
+				GetUserLoginAttemptsFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.UserLoginAttempt, error) {
+					if tt.attempts == nil {
+						return sqlc.UserLoginAttempt{}, pgx.ErrNoRows
+					}
+					return *tt.attempts, nil
+				},
+				RecordFailedLoginFunc: func(ctx context.Context, params sqlc.RecordFailedLoginParams) (sqlc.UserLoginAttempt, error) {
+					recorded = true
+					return sqlc.UserLoginAttempt{UserID: params.UserID, FailedAttempts: tt.failedCount}, nil
+				},
+				LockUserLoginFunc: func(ctx context.Context, params sqlc.LockUserLoginParams) error {
+					locked = true
+					if !params.LockedUntil.Time.After(time.Now()) {
+						t.Errorf("expected lock in the future, got %v", params.LockedUntil.Time)
+					}
+					return nil
+				},
+				ResetFailedLoginsFunc: func(ctx context.Context, id pgtype.UUID) error {
+					reset = true
+					return nil
+				},
+			}
+
+			service := NewUserService(mockRepo)
+			_, err := service.AuthenticateUser(context.Background(), "test@example.com", tt.password)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+			if recorded != (tt.expectedError == ErrInvalidPassword) {
+				t.Errorf("expected failure recorded=%v, got %v", tt.expectedError == ErrInvalidPassword, recorded)
+			}
+			if locked != tt.expectLock {
+				t.Errorf("expected locked=%v, got %v", tt.expectLock, locked)
+			}
+			if reset != tt.expectReset {
+				t.Errorf("expected reset=%v, got %v", tt.expectReset, reset)
+			}
+		})
+	}
+}
+
+func TestLoginLockoutDuration(t *testing.T) {
+	tests := []struct {
+		failedAttempts int
+		expected       time.Duration
+	}{
+		{failedAttempts: 1, expected: 0},
+		{failedAttempts: loginLockoutThreshold - 1, expected: 0},
+		{failedAttempts: loginLockoutThreshold, expected: time.Minute},
+		{failedAttempts: loginLockoutThreshold + 1, expected: 2 * time.Minute},
+		{failedAttempts: loginLockoutThreshold + 3, expected: 8 * time.Minute},
+		{failedAttempts: loginLockoutThreshold + 10, expected: loginLockoutMax},
+		{failedAttempts: 1000, expected: loginLockoutMax},
+	}
+
+	for _, tt := range tests {
+		if got := loginLockoutDuration(tt.failedAttempts); got != tt.expected {
+			t.Errorf("loginLockoutDuration(%d) = %v, want %v", tt.failedAttempts, got, tt.expected)
+		}
+	}
+}
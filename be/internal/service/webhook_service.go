@@ -0,0 +1,552 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/repository"
+)
+
+// Events a webhook can subscribe to.
+const (
+	WebhookEventExpenseCreated          = "expense.created"
+	WebhookEventExpenseUpdated          = "expense.updated"
+	WebhookEventExpenseDeleted          = "expense.deleted"
+	WebhookEventSettlementCreated       = "settlement.created"
+	WebhookEventSettlementStatusChanged = "settlement.status_changed"
+	WebhookEventSettlementDeleted       = "settlement.deleted"
+	WebhookEventMemberJoined            = "member.joined"
+	WebhookEventMemberLeft              = "member.left"
+	WebhookEventMemberRemoved           = "member.removed"
+	WebhookEventCommentAdded            = "comment.added"
+)
+
+// WebhookEvents lists every event a webhook can subscribe to.
+var WebhookEvents = []string{
+	WebhookEventExpenseCreated,
+	WebhookEventExpenseUpdated,
+	WebhookEventExpenseDeleted,
+	WebhookEventSettlementCreated,
+	WebhookEventSettlementStatusChanged,
+	WebhookEventSettlementDeleted,
+	WebhookEventMemberJoined,
+	WebhookEventMemberLeft,
+	WebhookEventMemberRemoved,
+	WebhookEventCommentAdded,
+}
+
+// webhookEventsByAction is the webhook event sent for each group activity.
+// Activities missing here aren't sent to webhooks.
+var webhookEventsByAction = map[string]string{
+	"expense_created":        WebhookEventExpenseCreated,
+	"expense_updated":        WebhookEventExpenseUpdated,
+	"expense_deleted":        WebhookEventExpenseDeleted,
+	"settlement_created":     WebhookEventSettlementCreated,
+	"settlement_resubmitted": WebhookEventSettlementStatusChanged,
+	"settlement_completed":   WebhookEventSettlementStatusChanged,
+	"settlement_disputed":    WebhookEventSettlementStatusChanged,
+	"settlement_cancelled":   WebhookEventSettlementStatusChanged,
+	"settlement_deleted":     WebhookEventSettlementDeleted,
+	"member_joined":          WebhookEventMemberJoined,
+	"member_left":            WebhookEventMemberLeft,
+	"member_removed":         WebhookEventMemberRemoved,
+	"comment_added":          WebhookEventCommentAdded,
+}
+
+const (
+	// Headers sent with every delivery
+	WebhookSignatureHeader = "X-SplitPlus-Signature"
+	WebhookEventHeader     = "X-SplitPlus-Event"
+	WebhookDeliveryHeader  = "X-SplitPlus-Delivery"
+
+	DefaultWebhookMaxAttempts = 8
+
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = 6 * time.Hour
+	webhookTimeout        = 10 * time.Second
+
+	maxWebhooksPerGroup      = 10
+	webhookSecretPrefix      = "whsec_"
+	webhookMinSecretLength   = 16
+	webhookResponseBodyLimit = 1024
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL       = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidWebhookEvent     = errors.New("invalid webhook event")
+	ErrInvalidWebhookSecret    = errors.New("webhook secret is too short")
+	ErrTooManyWebhooks         = errors.New("too many webhooks for this group")
+	errWebhookPrivateAddress   = errors.New("webhook url resolves to a private address")
+)
+
+// webhookBlockedPrefixes are ranges that aren't on the public internet but
+// that net/netip has no predicate for, checked on top of loopback, private,
+// unspecified and link-local addresses.
+var webhookBlockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("224.0.0.0/4"),   // multicast
+	netip.MustParsePrefix("fc00::/7"),      // unique local
+	netip.MustParsePrefix("ff00::/8"),      // multicast
+}
+
+type CreateWebhookInput struct {
+	GroupID     pgtype.UUID
+	RequesterID pgtype.UUID
+	URL         string
+	Secret      string   // empty generates one
+	Events      []string // empty subscribes to every event
+}
+
+// UpdateWebhookInput changes the fields that are set.
+type UpdateWebhookInput struct {
+	GroupID     pgtype.UUID
+	WebhookID   pgtype.UUID
+	RequesterID pgtype.UUID
+	URL         *string
+	Events      *[]string
+	Active      *bool
+}
+
+// WebhookEvent is the JSON body of every delivery. ID is the same for every
+// delivery of an event, including redeliveries.
+type WebhookEvent struct {
+	ID        string           `json:"id"`
+	Type      string           `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	GroupID   string           `json:"group_id"`
+	ActorID   string           `json:"actor_id"`
+	Data      WebhookEventData `json:"data"`
+}
+
+// WebhookEventData is the group activity behind the event.
+type WebhookEventData struct {
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id,omitempty"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+}
+
+// WebhookService manages group webhook subscriptions and delivers group events
+// to them. Events are queued as activities are logged and sent by the worker,
+// so a slow receiver never holds up a request. Only group owners and admins can
+// manage a group's webhooks.
+type WebhookService interface {
+	ActivityListener
+
+	ListWebhooks(ctx context.Context, groupID, requesterID pgtype.UUID) ([]sqlc.GroupWebhook, error)
+	CreateWebhook(ctx context.Context, input CreateWebhookInput) (sqlc.GroupWebhook, error)
+	UpdateWebhook(ctx context.Context, input UpdateWebhookInput) (sqlc.GroupWebhook, error)
+	DeleteWebhook(ctx context.Context, groupID, webhookID, requesterID pgtype.UUID) error
+
+	ListDeliveries(ctx context.Context, groupID, webhookID, requesterID pgtype.UUID, limit, offset int32) ([]sqlc.WebhookDelivery, error)
+	// Redeliver queues the event of a past delivery again as a new delivery.
+	Redeliver(ctx context.Context, groupID, webhookID, deliveryID, requesterID pgtype.UUID) (sqlc.WebhookDelivery, error)
+	// DeliverPending sends up to batchSize due deliveries and reports how many succeeded.
+	DeliverPending(ctx context.Context, batchSize int) (int, error)
+}
+
+type webhookService struct {
+	repo      repository.WebhookRepository
+	groupRepo repository.GroupRepository
+	client    *http.Client
+}
+
+// NewWebhookService sends deliveries with client; see NewWebhookHTTPClient.
+func NewWebhookService(repo repository.WebhookRepository, groupRepo repository.GroupRepository, client *http.Client) WebhookService {
+	return &webhookService{repo: repo, groupRepo: groupRepo, client: client}
+}
+
+// NewWebhookHTTPClient returns the client deliveries are sent with. It doesn't
+// follow redirects and, unless allowPrivateNetworks is set (for local
+// development), refuses to connect to loopback and private addresses so
+// webhooks can't be pointed at internal services.
+func NewWebhookHTTPClient(allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{Timeout: webhookTimeout}
+	if !allowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || webhookAddressBlocked(addrPort.Addr()) {
+				return errWebhookPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookAddressBlocked reports whether deliveries may not connect to addr.
+// IPv4-mapped IPv6 addresses are checked as the IPv4 address they carry.
+func webhookAddressBlocked(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() {
+		return true
+	}
+	for _, prefix := range webhookBlockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// SignWebhookPayload returns the signature header value for a delivery:
+// "t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<timestamp>.<body>">". Receivers
+// recompute it with the webhook secret and should reject old timestamps.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func (s *webhookService) ActivityLogged(ctx context.Context, activity sqlc.GroupActivity) error {
+	eventType, ok := webhookEventsByAction[activity.Action]
+	if !ok {
+		return nil
+	}
+
+	event := WebhookEvent{
+		ID:        uuidToString(activity.ID),
+		Type:      eventType,
+		CreatedAt: activity.CreatedAt.Time.UTC(),
+		GroupID:   uuidToString(activity.GroupID),
+		ActorID:   uuidToString(activity.UserID),
+		Data: WebhookEventData{
+			Action:     activity.Action,
+			EntityType: activity.EntityType,
+			EntityID:   uuidToString(activity.EntityID),
+		},
+	}
+	if len(activity.Metadata) > 0 && string(activity.Metadata) != "null" {
+		event.Data.Metadata = activity.Metadata
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.EnqueueWebhookDeliveries(ctx, sqlc.EnqueueWebhookDeliveriesParams{
+		EventID:     activity.ID,
+		EventType:   eventType,
+		Payload:     payload,
+		MaxAttempts: DefaultWebhookMaxAttempts,
+		GroupID:     activity.GroupID,
+	}); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context, groupID, requesterID pgtype.UUID) ([]sqlc.GroupWebhook, error) {
+	if err := s.requireWebhookManager(ctx, groupID, requesterID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListGroupWebhooks(ctx, groupID)
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, input CreateWebhookInput) (sqlc.GroupWebhook, error) {
+	if err := s.requireWebhookManager(ctx, input.GroupID, input.RequesterID); err != nil {
+		return sqlc.GroupWebhook{}, err
+	}
+
+	webhookURL, err := normalizeWebhookURL(input.URL)
+	if err != nil {
+		return sqlc.GroupWebhook{}, err
+	}
+	events, err := normalizeWebhookEvents(input.Events)
+	if err != nil {
+		return sqlc.GroupWebhook{}, err
+	}
+
+	secret := strings.TrimSpace(input.Secret)
+	if secret == "" {
+		token, err := randomURLToken()
+		if err != nil {
+			return sqlc.GroupWebhook{}, err
+		}
+		secret = webhookSecretPrefix + token
+	} else if len(secret) < webhookMinSecretLength {
+		return sqlc.GroupWebhook{}, ErrInvalidWebhookSecret
+	}
+
+	count, err := s.repo.CountGroupWebhooks(ctx, input.GroupID)
+	if err != nil {
+		return sqlc.GroupWebhook{}, fmt.Errorf("failed to count webhooks: %w", err)
+	}
+	if count >= maxWebhooksPerGroup {
+		return sqlc.GroupWebhook{}, ErrTooManyWebhooks
+	}
+
+	webhook, err := s.repo.CreateGroupWebhook(ctx, sqlc.CreateGroupWebhookParams{
+		GroupID:   input.GroupID,
+		Url:       webhookURL,
+		Secret:    secret,
+		Events:    events,
+		CreatedBy: input.RequesterID,
+	})
+	if err != nil {
+		return sqlc.GroupWebhook{}, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) UpdateWebhook(ctx context.Context, input UpdateWebhookInput) (sqlc.GroupWebhook, error) {
+	webhook, err := s.getWebhook(ctx, input.GroupID, input.WebhookID, input.RequesterID)
+	if err != nil {
+		return sqlc.GroupWebhook{}, err
+	}
+
+	params := sqlc.UpdateGroupWebhookParams{
+		ID:      webhook.ID,
+		GroupID: webhook.GroupID,
+		Url:     webhook.Url,
+		Events:  webhook.Events,
+		Active:  webhook.Active,
+	}
+	if input.URL != nil {
+		if params.Url, err = normalizeWebhookURL(*input.URL); err != nil {
+			return sqlc.GroupWebhook{}, err
+		}
+	}
+	if input.Events != nil {
+		if params.Events, err = normalizeWebhookEvents(*input.Events); err != nil {
+			return sqlc.GroupWebhook{}, err
+		}
+	}
+	if input.Active != nil {
+		params.Active = *input.Active
+	}
+
+	updated, err := s.repo.UpdateGroupWebhook(ctx, params)
+	if err != nil {
+		return sqlc.GroupWebhook{}, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return updated, nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, groupID, webhookID, requesterID pgtype.UUID) error {
+	if _, err := s.getWebhook(ctx, groupID, webhookID, requesterID); err != nil {
+		return err
+	}
+	return s.repo.DeleteGroupWebhook(ctx, sqlc.DeleteGroupWebhookParams{ID: webhookID, GroupID: groupID})
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, groupID, webhookID, requesterID pgtype.UUID, limit, offset int32) ([]sqlc.WebhookDelivery, error) {
+	if _, err := s.getWebhook(ctx, groupID, webhookID, requesterID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListWebhookDeliveries(ctx, sqlc.ListWebhookDeliveriesParams{
+		WebhookID: webhookID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+}
+
+func (s *webhookService) Redeliver(ctx context.Context, groupID, webhookID, deliveryID, requesterID pgtype.UUID) (sqlc.WebhookDelivery, error) {
+	if _, err := s.getWebhook(ctx, groupID, webhookID, requesterID); err != nil {
+		return sqlc.WebhookDelivery{}, err
+	}
+
+	delivery, err := s.repo.GetWebhookDelivery(ctx, sqlc.GetWebhookDeliveryParams{ID: deliveryID, WebhookID: webhookID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.WebhookDelivery{}, ErrWebhookDeliveryNotFound
+		}
+		return sqlc.WebhookDelivery{}, err
+	}
+
+	redelivery, err := s.repo.CreateWebhookDelivery(ctx, sqlc.CreateWebhookDeliveryParams{
+		WebhookID:   delivery.WebhookID,
+		EventID:     delivery.EventID,
+		EventType:   delivery.EventType,
+		Payload:     delivery.Payload,
+		MaxAttempts: DefaultWebhookMaxAttempts,
+	})
+	if err != nil {
+		return sqlc.WebhookDelivery{}, fmt.Errorf("failed to queue redelivery: %w", err)
+	}
+	return redelivery, nil
+}
+
+func (s *webhookService) DeliverPending(ctx context.Context, batchSize int) (int, error) {
+	deliveries, err := s.repo.ClaimDueWebhookDeliveries(ctx, int32(batchSize))
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	var errs []error
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			// Claimed rows become due again once their lock goes stale
+			break
+		}
+
+		statusCode, body, sendErr := s.send(ctx, delivery)
+		responseStatus := pgtype.Int4{Int32: int32(statusCode), Valid: statusCode != 0}
+		responseBody := pgtype.Text{String: body, Valid: statusCode != 0}
+
+		if sendErr == nil {
+			if err := s.repo.MarkWebhookDeliveryDelivered(ctx, sqlc.MarkWebhookDeliveryDeliveredParams{
+				ID:             delivery.ID,
+				ResponseStatus: responseStatus,
+				ResponseBody:   responseBody,
+			}); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			delivered++
+			continue
+		}
+
+		lastError := pgtype.Text{String: truncateError(sendErr.Error()), Valid: true}
+		if delivery.Attempts >= delivery.MaxAttempts {
+			err = s.repo.MarkWebhookDeliveryFailed(ctx, sqlc.MarkWebhookDeliveryFailedParams{
+				ID:             delivery.ID,
+				ResponseStatus: responseStatus,
+				ResponseBody:   responseBody,
+				LastError:      lastError,
+			})
+		} else {
+			err = s.repo.MarkWebhookDeliveryRetry(ctx, sqlc.MarkWebhookDeliveryRetryParams{
+				ID:             delivery.ID,
+				NextAttemptAt:  pgtype.Timestamptz{Time: time.Now().Add(webhookRetryDelay(int(delivery.Attempts))), Valid: true},
+				ResponseStatus: responseStatus,
+				ResponseBody:   responseBody,
+				LastError:      lastError,
+			})
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return delivered, errors.Join(errs...)
+}
+
+// send posts one delivery. Any 2xx response counts as delivered; the status
+// and the start of the response body are returned for the delivery log.
+func (s *webhookService) send(ctx context.Context, delivery sqlc.ClaimDueWebhookDeliveriesRow) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SplitPlus-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, uuidToString(delivery.ID))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(delivery.Secret, time.Now().Unix(), delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyLimit))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, string(body), fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+// getWebhook loads a webhook of the group for one of its managers.
+func (s *webhookService) getWebhook(ctx context.Context, groupID, webhookID, requesterID pgtype.UUID) (sqlc.GroupWebhook, error) {
+	if err := s.requireWebhookManager(ctx, groupID, requesterID); err != nil {
+		return sqlc.GroupWebhook{}, err
+	}
+
+	webhook, err := s.repo.GetGroupWebhook(ctx, sqlc.GetGroupWebhookParams{ID: webhookID, GroupID: groupID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.GroupWebhook{}, ErrWebhookNotFound
+		}
+		return sqlc.GroupWebhook{}, err
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) requireWebhookManager(ctx context.Context, groupID, requesterID pgtype.UUID) error {
+	if _, err := s.groupRepo.GetGroupByID(ctx, groupID); err != nil {
+		return ErrGroupNotFound
+	}
+
+	member, err := s.groupRepo.GetGroupMember(ctx, sqlc.GetGroupMemberParams{
+		GroupID: groupID,
+		UserID:  requesterID,
+	})
+	if err != nil || member.Status != "active" {
+		return ErrNotGroupMember
+	}
+	if member.Role != "owner" && member.Role != "admin" {
+		return ErrInsufficientPermissions
+	}
+	return nil
+}
+
+// webhookRetryDelay doubles the wait after every failed attempt: 30s, 1m, 2m, ...
+// capped at webhookRetryMaxDelay.
+func webhookRetryDelay(attempts int) time.Duration {
+	return backoffDelay(attempts, webhookRetryBaseDelay, webhookRetryMaxDelay)
+}
+
+func normalizeWebhookURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || parsed.User != nil {
+		return "", ErrInvalidWebhookURL
+	}
+	return parsed.String(), nil
+}
+
+// normalizeWebhookEvents checks the event filter and drops duplicates.
+func normalizeWebhookEvents(requested []string) ([]string, error) {
+	events := make([]string, 0, len(requested))
+	for _, event := range requested {
+		if !slices.Contains(WebhookEvents, event) {
+			return nil, ErrInvalidWebhookEvent
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,533 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dhruvsaxena1998/splitplus/internal/db/sqlc"
+	"github.com/dhruvsaxena1998/splitplus/internal/testutil"
+)
+
+// MockWebhookRepository for testing
+type MockWebhookRepository struct {
+	CreateGroupWebhookFunc           func(ctx context.Context, params sqlc.CreateGroupWebhookParams) (sqlc.GroupWebhook, error)
+	GetGroupWebhookFunc              func(ctx context.Context, params sqlc.GetGroupWebhookParams) (sqlc.GroupWebhook, error)
+	ListGroupWebhooksFunc            func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupWebhook, error)
+	CountGroupWebhooksFunc           func(ctx context.Context, groupID pgtype.UUID) (int64, error)
+	UpdateGroupWebhookFunc           func(ctx context.Context, params sqlc.UpdateGroupWebhookParams) (sqlc.GroupWebhook, error)
+	DeleteGroupWebhookFunc           func(ctx context.Context, params sqlc.DeleteGroupWebhookParams) error
+	EnqueueWebhookDeliveriesFunc     func(ctx context.Context, params sqlc.EnqueueWebhookDeliveriesParams) error
+	CreateWebhookDeliveryFunc        func(ctx context.Context, params sqlc.CreateWebhookDeliveryParams) (sqlc.WebhookDelivery, error)
+	GetWebhookDeliveryFunc           func(ctx context.Context, params sqlc.GetWebhookDeliveryParams) (sqlc.WebhookDelivery, error)
+	ListWebhookDeliveriesFunc        func(ctx context.Context, params sqlc.ListWebhookDeliveriesParams) ([]sqlc.WebhookDelivery, error)
+	ClaimDueWebhookDeliveriesFunc    func(ctx context.Context, limit int32) ([]sqlc.ClaimDueWebhookDeliveriesRow, error)
+	MarkWebhookDeliveryDeliveredFunc func(ctx context.Context, params sqlc.MarkWebhookDeliveryDeliveredParams) error
+	MarkWebhookDeliveryRetryFunc     func(ctx context.Context, params sqlc.MarkWebhookDeliveryRetryParams) error
+	MarkWebhookDeliveryFailedFunc    func(ctx context.Context, params sqlc.MarkWebhookDeliveryFailedParams) error
+}
+
+func (m *MockWebhookRepository) CreateGroupWebhook(ctx context.Context, params sqlc.CreateGroupWebhookParams) (sqlc.GroupWebhook, error) {
+	if m.CreateGroupWebhookFunc != nil {
+		return m.CreateGroupWebhookFunc(ctx, params)
+	}
+	return sqlc.GroupWebhook{}, nil
+}
+
+func (m *MockWebhookRepository) GetGroupWebhook(ctx context.Context, params sqlc.GetGroupWebhookParams) (sqlc.GroupWebhook, error) {
+	if m.GetGroupWebhookFunc != nil {
+		return m.GetGroupWebhookFunc(ctx, params)
+	}
+	return sqlc.GroupWebhook{}, pgx.ErrNoRows
+}
+
+func (m *MockWebhookRepository) ListGroupWebhooks(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupWebhook, error) {
+	if m.ListGroupWebhooksFunc != nil {
+		return m.ListGroupWebhooksFunc(ctx, groupID)
+	}
+	return []sqlc.GroupWebhook{}, nil
+}
+
+func (m *MockWebhookRepository) CountGroupWebhooks(ctx context.Context, groupID pgtype.UUID) (int64, error) {
+	if m.CountGroupWebhooksFunc != nil {
+		return m.CountGroupWebhooksFunc(ctx, groupID)
+	}
+	return 0, nil
+}
+
+func (m *MockWebhookRepository) UpdateGroupWebhook(ctx context.Context, params sqlc.UpdateGroupWebhookParams) (sqlc.GroupWebhook, error) {
+	if m.UpdateGroupWebhookFunc != nil {
+		return m.UpdateGroupWebhookFunc(ctx, params)
+	}
+	return sqlc.GroupWebhook{}, nil
+}
+
+func (m *MockWebhookRepository) DeleteGroupWebhook(ctx context.Context, params sqlc.DeleteGroupWebhookParams) error {
+	if m.DeleteGroupWebhookFunc != nil {
+		return m.DeleteGroupWebhookFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) EnqueueWebhookDeliveries(ctx context.Context, params sqlc.EnqueueWebhookDeliveriesParams) error {
+	if m.EnqueueWebhookDeliveriesFunc != nil {
+		return m.EnqueueWebhookDeliveriesFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) CreateWebhookDelivery(ctx context.Context, params sqlc.CreateWebhookDeliveryParams) (sqlc.WebhookDelivery, error) {
+	if m.CreateWebhookDeliveryFunc != nil {
+		return m.CreateWebhookDeliveryFunc(ctx, params)
+	}
+	return sqlc.WebhookDelivery{}, nil
+}
+
+func (m *MockWebhookRepository) GetWebhookDelivery(ctx context.Context, params sqlc.GetWebhookDeliveryParams) (sqlc.WebhookDelivery, error) {
+	if m.GetWebhookDeliveryFunc != nil {
+		return m.GetWebhookDeliveryFunc(ctx, params)
+	}
+	return sqlc.WebhookDelivery{}, pgx.ErrNoRows
+}
+
+func (m *MockWebhookRepository) ListWebhookDeliveries(ctx context.Context, params sqlc.ListWebhookDeliveriesParams) ([]sqlc.WebhookDelivery, error) {
+	if m.ListWebhookDeliveriesFunc != nil {
+		return m.ListWebhookDeliveriesFunc(ctx, params)
+	}
+	return []sqlc.WebhookDelivery{}, nil
+}
+
+func (m *MockWebhookRepository) ClaimDueWebhookDeliveries(ctx context.Context, limit int32) ([]sqlc.ClaimDueWebhookDeliveriesRow, error) {
+	if m.ClaimDueWebhookDeliveriesFunc != nil {
+		return m.ClaimDueWebhookDeliveriesFunc(ctx, limit)
+	}
+	return []sqlc.ClaimDueWebhookDeliveriesRow{}, nil
+}
+
+func (m *MockWebhookRepository) MarkWebhookDeliveryDelivered(ctx context.Context, params sqlc.MarkWebhookDeliveryDeliveredParams) error {
+	if m.MarkWebhookDeliveryDeliveredFunc != nil {
+		return m.MarkWebhookDeliveryDeliveredFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) MarkWebhookDeliveryRetry(ctx context.Context, params sqlc.MarkWebhookDeliveryRetryParams) error {
+	if m.MarkWebhookDeliveryRetryFunc != nil {
+		return m.MarkWebhookDeliveryRetryFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) MarkWebhookDeliveryFailed(ctx context.Context, params sqlc.MarkWebhookDeliveryFailedParams) error {
+	if m.MarkWebhookDeliveryFailedFunc != nil {
+		return m.MarkWebhookDeliveryFailedFunc(ctx, params)
+	}
+	return nil
+}
+
+// groupWithRole is a group repository where the requester has role.
+func groupWithRole(role string) *testutil.MockGroupRepository {
+	return &testutil.MockGroupRepository{
+		GetGroupMemberFunc: func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error) {
+			return sqlc.GroupMember{GroupID: params.GroupID, UserID: params.UserID, Role: role, Status: "active"}, nil
+		},
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// echo -n '1700000000.{"id":"1"}' | openssl dgst -sha256 -hmac whsec_test
+	got := SignWebhookPayload("whsec_test", 1700000000, []byte(`{"id":"1"}`))
+	if want := "t=1700000000,v1=11bf4466ea17c3df3fd743af0b435368e16b7a05eb8eced85e8c4670767bdec5"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got == SignWebhookPayload("whsec_other", 1700000000, []byte(`{"id":"1"}`)) {
+		t.Error("expected the secret to change the signature")
+	}
+	if got == SignWebhookPayload("whsec_test", 1700000001, []byte(`{"id":"1"}`)) {
+		t.Error("expected the timestamp to change the signature")
+	}
+}
+
+func TestWebhookService_ActivityLogged(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	activity := sqlc.GroupActivity{
+		ID:         testutil.CreateTestUUID(2),
+		GroupID:    groupID,
+		UserID:     testutil.CreateTestUUID(3),
+		EntityType: "settlement",
+		EntityID:   testutil.CreateTestUUID(4),
+		Metadata:   []byte(`{"status":"completed"}`),
+		CreatedAt:  pgtype.Timestamptz{Time: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	tests := []struct {
+		action    string
+		wantEvent string
+	}{
+		{action: "expense_created", wantEvent: WebhookEventExpenseCreated},
+		{action: "settlement_completed", wantEvent: WebhookEventSettlementStatusChanged},
+		{action: "settlement_disputed", wantEvent: WebhookEventSettlementStatusChanged},
+		{action: "member_joined", wantEvent: WebhookEventMemberJoined},
+		{action: "comment_added", wantEvent: WebhookEventCommentAdded},
+		{action: "group_updated", wantEvent: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			var queued *sqlc.EnqueueWebhookDeliveriesParams
+			repo := &MockWebhookRepository{
+				EnqueueWebhookDeliveriesFunc: func(ctx context.Context, params sqlc.EnqueueWebhookDeliveriesParams) error {
+					queued = &params
+					return nil
+				},
+			}
+			svc := NewWebhookService(repo, &testutil.MockGroupRepository{}, http.DefaultClient)
+
+			a := activity
+			a.Action = tt.action
+			if err := svc.ActivityLogged(context.Background(), a); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantEvent == "" {
+				if queued != nil {
+					t.Fatalf("expected %s not to be sent to webhooks", tt.action)
+				}
+				return
+			}
+			if queued == nil {
+				t.Fatal("expected deliveries to be queued")
+			}
+			if queued.EventType != tt.wantEvent || queued.GroupID != groupID || queued.EventID != activity.ID {
+				t.Errorf("unexpected params %+v", queued)
+			}
+
+			var event WebhookEvent
+			if err := json.Unmarshal(queued.Payload, &event); err != nil {
+				t.Fatalf("invalid payload: %v", err)
+			}
+			if event.ID != uuidToString(activity.ID) || event.Type != tt.wantEvent || event.Data.Action != tt.action {
+				t.Errorf("unexpected event %+v", event)
+			}
+			if string(event.Data.Metadata) != `{"status":"completed"}` {
+				t.Errorf("expected metadata to be passed through, got %s", event.Data.Metadata)
+			}
+		})
+	}
+}
+
+func TestWebhookService_DeliverPending(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		attempts      int32
+		wantState     string
+		wantDelay     time.Duration
+		wantDelivered int
+	}{
+		{name: "2xx is delivered", status: http.StatusAccepted, attempts: 1, wantState: "delivered", wantDelivered: 1},
+		{name: "first failure retries after base delay", status: http.StatusInternalServerError, attempts: 1, wantState: "retry", wantDelay: 30 * time.Second},
+		{name: "later failure backs off", status: http.StatusBadGateway, attempts: 3, wantState: "retry", wantDelay: 2 * time.Minute},
+		{name: "redirects are not followed", status: http.StatusFound, attempts: 1, wantState: "retry", wantDelay: 30 * time.Second},
+		{name: "last attempt fails permanently", status: http.StatusInternalServerError, attempts: 8, wantState: "failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := []byte(`{"id":"evt","type":"expense.created"}`)
+			var received *http.Request
+			var receivedBody []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				receivedBody, _ = io.ReadAll(r.Body)
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte("receiver says hi"))
+			}))
+			defer server.Close()
+
+			delivery := sqlc.ClaimDueWebhookDeliveriesRow{
+				ID:          testutil.CreateTestUUID(1),
+				WebhookID:   testutil.CreateTestUUID(2),
+				EventType:   WebhookEventExpenseCreated,
+				Payload:     payload,
+				Attempts:    tt.attempts,
+				MaxAttempts: DefaultWebhookMaxAttempts,
+				Url:         server.URL + "/hooks",
+				Secret:      "whsec_test_secret",
+			}
+
+			state := ""
+			var retryAt time.Time
+			var recordedStatus pgtype.Int4
+			repo := &MockWebhookRepository{
+				ClaimDueWebhookDeliveriesFunc: func(ctx context.Context, limit int32) ([]sqlc.ClaimDueWebhookDeliveriesRow, error) {
+					return []sqlc.ClaimDueWebhookDeliveriesRow{delivery}, nil
+				},
+				MarkWebhookDeliveryDeliveredFunc: func(ctx context.Context, params sqlc.MarkWebhookDeliveryDeliveredParams) error {
+					state = "delivered"
+					recordedStatus = params.ResponseStatus
+					if params.ResponseBody.String != "receiver says hi" {
+						t.Errorf("expected response body to be recorded, got %q", params.ResponseBody.String)
+					}
+					return nil
+				},
+				MarkWebhookDeliveryRetryFunc: func(ctx context.Context, params sqlc.MarkWebhookDeliveryRetryParams) error {
+					state = "retry"
+					retryAt = params.NextAttemptAt.Time
+					recordedStatus = params.ResponseStatus
+					if params.LastError.String == "" {
+						t.Error("expected last error to be recorded")
+					}
+					return nil
+				},
+				MarkWebhookDeliveryFailedFunc: func(ctx context.Context, params sqlc.MarkWebhookDeliveryFailedParams) error {
+					state = "failed"
+					recordedStatus = params.ResponseStatus
+					return nil
+				},
+			}
+			// httptest listens on loopback, which the production client refuses
+			svc := NewWebhookService(repo, &testutil.MockGroupRepository{}, NewWebhookHTTPClient(true))
+
+			before := time.Now()
+			delivered, err := svc.DeliverPending(context.Background(), 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if delivered != tt.wantDelivered {
+				t.Errorf("expected %d delivered, got %d", tt.wantDelivered, delivered)
+			}
+			if state != tt.wantState {
+				t.Fatalf("expected state %q, got %q", tt.wantState, state)
+			}
+			if recordedStatus.Int32 != int32(tt.status) {
+				t.Errorf("expected status %d recorded, got %d", tt.status, recordedStatus.Int32)
+			}
+			if tt.wantDelay > 0 {
+				if delay := retryAt.Sub(before); delay < tt.wantDelay || delay > tt.wantDelay+5*time.Second {
+					t.Errorf("expected retry in ~%s, got %s", tt.wantDelay, delay)
+				}
+			}
+
+			// The receiver can verify the request with the webhook secret
+			if received == nil {
+				t.Fatal("expected the receiver to be called")
+			}
+			if received.Method != http.MethodPost || string(receivedBody) != string(payload) {
+				t.Errorf("unexpected request %s %s", received.Method, receivedBody)
+			}
+			if received.Header.Get(WebhookEventHeader) != WebhookEventExpenseCreated {
+				t.Errorf("unexpected event header %q", received.Header.Get(WebhookEventHeader))
+			}
+			if received.Header.Get(WebhookDeliveryHeader) != uuidToString(delivery.ID) {
+				t.Errorf("unexpected delivery header %q", received.Header.Get(WebhookDeliveryHeader))
+			}
+			signature := received.Header.Get(WebhookSignatureHeader)
+			timestamp, err := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+			if err != nil {
+				t.Fatalf("unexpected signature header %q", signature)
+			}
+			if signature != SignWebhookPayload(delivery.Secret, timestamp, receivedBody) {
+				t.Errorf("signature %q doesn't verify", signature)
+			}
+		})
+	}
+}
+
+func TestWebhookService_DeliverPending_UnreachableReceiver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	retried := false
+	repo := &MockWebhookRepository{
+		ClaimDueWebhookDeliveriesFunc: func(ctx context.Context, limit int32) ([]sqlc.ClaimDueWebhookDeliveriesRow, error) {
+			return []sqlc.ClaimDueWebhookDeliveriesRow{{ID: testutil.CreateTestUUID(1), Attempts: 1, MaxAttempts: 8, Url: server.URL, Payload: []byte(`{}`)}}, nil
+		},
+		MarkWebhookDeliveryRetryFunc: func(ctx context.Context, params sqlc.MarkWebhookDeliveryRetryParams) error {
+			retried = true
+			if params.ResponseStatus.Valid {
+				t.Error("expected no response status without a response")
+			}
+			return nil
+		},
+	}
+	svc := NewWebhookService(repo, &testutil.MockGroupRepository{}, NewWebhookHTTPClient(true))
+
+	if _, err := svc.DeliverPending(context.Background(), 10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !retried {
+		t.Error("expected the delivery to be retried")
+	}
+}
+
+func TestWebhookHTTPClient_RefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected the request to be refused")
+	}))
+	defer server.Close()
+
+	_, err := NewWebhookHTTPClient(false).Get(server.URL)
+	if !errors.Is(err, errWebhookPrivateAddress) {
+		t.Errorf("expected errWebhookPrivateAddress, got %v", err)
+	}
+}
+
+func TestWebhookAddressBlocked(t *testing.T) {
+	tests := []struct {
+		addr    string
+		blocked bool
+	}{
+		{addr: "127.0.0.1", blocked: true},
+		{addr: "::1", blocked: true},
+		{addr: "10.1.2.3", blocked: true},
+		{addr: "172.16.0.1", blocked: true},
+		{addr: "192.168.1.1", blocked: true},
+		{addr: "::ffff:192.168.1.1", blocked: true},
+		{addr: "0.0.0.0", blocked: true},
+		{addr: "0.1.2.3", blocked: true},
+		{addr: "::", blocked: true},
+		{addr: "169.254.169.254", blocked: true},
+		{addr: "fe80::1", blocked: true},
+		{addr: "100.64.0.1", blocked: true},
+		{addr: "100.127.255.254", blocked: true},
+		{addr: "198.18.0.1", blocked: true},
+		{addr: "198.19.255.254", blocked: true},
+		{addr: "224.0.0.1", blocked: true},
+		{addr: "239.255.255.250", blocked: true},
+		{addr: "ff02::1", blocked: true},
+		{addr: "fc00::1", blocked: true},
+		{addr: "fd12:3456::1", blocked: true},
+		{addr: "93.184.216.34", blocked: false},
+		{addr: "100.128.0.1", blocked: false},
+		{addr: "198.20.0.1", blocked: false},
+		{addr: "2606:4700::1111", blocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := webhookAddressBlocked(netip.MustParseAddr(tt.addr)); got != tt.blocked {
+				t.Errorf("expected blocked=%v, got %v", tt.blocked, got)
+			}
+		})
+	}
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	userID := testutil.CreateTestUUID(2)
+
+	tests := []struct {
+		name       string
+		role       string
+		input      CreateWebhookInput
+		count      int64
+		wantEvents []string
+		wantErr    error
+	}{
+		{name: "owner creates with generated secret", role: "owner", input: CreateWebhookInput{URL: "https://example.com/hook"}, wantEvents: []string{}},
+		{name: "admin creates with event filter", role: "admin", input: CreateWebhookInput{URL: "https://example.com/hook", Events: []string{WebhookEventExpenseCreated, WebhookEventExpenseCreated}}, wantEvents: []string{WebhookEventExpenseCreated}},
+		{name: "members can't manage webhooks", role: "member", input: CreateWebhookInput{URL: "https://example.com/hook"}, wantErr: ErrInsufficientPermissions},
+		{name: "unknown event", role: "owner", input: CreateWebhookInput{URL: "https://example.com/hook", Events: []string{"expense.exploded"}}, wantErr: ErrInvalidWebhookEvent},
+		{name: "non http url", role: "owner", input: CreateWebhookInput{URL: "ftp://example.com/hook"}, wantErr: ErrInvalidWebhookURL},
+		{name: "short secret", role: "owner", input: CreateWebhookInput{URL: "https://example.com/hook", Secret: "short"}, wantErr: ErrInvalidWebhookSecret},
+		{name: "limit reached", role: "owner", input: CreateWebhookInput{URL: "https://example.com/hook"}, count: 10, wantErr: ErrTooManyWebhooks},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created sqlc.CreateGroupWebhookParams
+			repo := &MockWebhookRepository{
+				CountGroupWebhooksFunc: func(ctx context.Context, groupID pgtype.UUID) (int64, error) {
+					return tt.count, nil
+				},
+				CreateGroupWebhookFunc: func(ctx context.Context, params sqlc.CreateGroupWebhookParams) (sqlc.GroupWebhook, error) {
+					created = params
+					return sqlc.GroupWebhook{Secret: params.Secret, Events: params.Events}, nil
+				},
+			}
+			svc := NewWebhookService(repo, groupWithRole(tt.role), http.DefaultClient)
+
+			tt.input.GroupID = groupID
+			tt.input.RequesterID = userID
+			webhook, err := svc.CreateWebhook(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.HasPrefix(webhook.Secret, webhookSecretPrefix) {
+				t.Errorf("expected a generated secret, got %q", webhook.Secret)
+			}
+			if !slices.Equal(created.Events, tt.wantEvents) {
+				t.Errorf("expected events %v, got %v", tt.wantEvents, created.Events)
+			}
+			if created.CreatedBy != userID || created.GroupID != groupID {
+				t.Errorf("unexpected params %+v", created)
+			}
+		})
+	}
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	groupID := testutil.CreateTestUUID(1)
+	webhookID := testutil.CreateTestUUID(2)
+	deliveryID := testutil.CreateTestUUID(3)
+	eventID := testutil.CreateTestUUID(4)
+
+	var redelivered sqlc.CreateWebhookDeliveryParams
+	repo := &MockWebhookRepository{
+		GetGroupWebhookFunc: func(ctx context.Context, params sqlc.GetGroupWebhookParams) (sqlc.GroupWebhook, error) {
+			if params.ID != webhookID || params.GroupID != groupID {
+				return sqlc.GroupWebhook{}, pgx.ErrNoRows
+			}
+			return sqlc.GroupWebhook{ID: webhookID, GroupID: groupID}, nil
+		},
+		GetWebhookDeliveryFunc: func(ctx context.Context, params sqlc.GetWebhookDeliveryParams) (sqlc.WebhookDelivery, error) {
+			if params.ID != deliveryID || params.WebhookID != webhookID {
+				return sqlc.WebhookDelivery{}, pgx.ErrNoRows
+			}
+			return sqlc.WebhookDelivery{ID: deliveryID, WebhookID: webhookID, EventID: eventID, EventType: WebhookEventCommentAdded, Payload: []byte(`{}`), Status: "failed", Attempts: 8}, nil
+		},
+		CreateWebhookDeliveryFunc: func(ctx context.Context, params sqlc.CreateWebhookDeliveryParams) (sqlc.WebhookDelivery, error) {
+			redelivered = params
+			return sqlc.WebhookDelivery{WebhookID: params.WebhookID, EventID: params.EventID, Status: "pending"}, nil
+		},
+	}
+	svc := NewWebhookService(repo, groupWithRole("owner"), http.DefaultClient)
+	userID := testutil.CreateTestUUID(9)
+
+	if _, err := svc.Redeliver(context.Background(), groupID, webhookID, deliveryID, userID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if redelivered.EventID != eventID || redelivered.EventType != WebhookEventCommentAdded || redelivered.MaxAttempts != DefaultWebhookMaxAttempts {
+		t.Errorf("expected the same event to be queued again, got %+v", redelivered)
+	}
+
+	if _, err := svc.Redeliver(context.Background(), groupID, webhookID, testutil.CreateTestUUID(5), userID); !errors.Is(err, ErrWebhookDeliveryNotFound) {
+		t.Errorf("expected ErrWebhookDeliveryNotFound, got %v", err)
+	}
+	if _, err := svc.Redeliver(context.Background(), testutil.CreateTestUUID(6), webhookID, deliveryID, userID); !errors.Is(err, ErrWebhookNotFound) {
+		t.Errorf("expected webhooks of other groups to be hidden, got %v", err)
+	}
+}
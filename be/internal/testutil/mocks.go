@@ -17,9 +17,15 @@ import (
 // ============================================================================
 
 type MockUserRepository struct {
-	CreateUserFunc     func(ctx context.Context, params sqlc.CreateUserParams) (sqlc.User, error)
-	GetUserByEmailFunc func(ctx context.Context, email string) (sqlc.User, error)
-	GetUserByIDFunc    func(ctx context.Context, id pgtype.UUID) (sqlc.User, error)
+	CreateUserFunc            func(ctx context.Context, params sqlc.CreateUserParams) (sqlc.User, error)
+	GetUserByEmailFunc        func(ctx context.Context, email string) (sqlc.User, error)
+	GetUserByIDFunc           func(ctx context.Context, id pgtype.UUID) (sqlc.User, error)
+	MarkUserEmailVerifiedFunc func(ctx context.Context, id pgtype.UUID) error
+	UpdateUserPasswordFunc    func(ctx context.Context, params sqlc.UpdateUserPasswordParams) error
+	GetUserLoginAttemptsFunc  func(ctx context.Context, userID pgtype.UUID) (sqlc.UserLoginAttempt, error)
+	RecordFailedLoginFunc     func(ctx context.Context, params sqlc.RecordFailedLoginParams) (sqlc.UserLoginAttempt, error)
+	LockUserLoginFunc         func(ctx context.Context, params sqlc.LockUserLoginParams) error
+	ResetFailedLoginsFunc     func(ctx context.Context, userID pgtype.UUID) error
 }
 
 func (m *MockUserRepository) CreateUser(ctx context.Context, params sqlc.CreateUserParams) (sqlc.User, error) {
@@ -43,6 +49,48 @@ func (m *MockUserRepository) GetUserByID(ctx context.Context, id pgtype.UUID) (s
 	return sqlc.User{}, nil
 }
 
+func (m *MockUserRepository) MarkUserEmailVerified(ctx context.Context, id pgtype.UUID) error {
+	if m.MarkUserEmailVerifiedFunc != nil {
+		return m.MarkUserEmailVerifiedFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) UpdateUserPassword(ctx context.Context, params sqlc.UpdateUserPasswordParams) error {
+	if m.UpdateUserPasswordFunc != nil {
+		return m.UpdateUserPasswordFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) GetUserLoginAttempts(ctx context.Context, userID pgtype.UUID) (sqlc.UserLoginAttempt, error) {
+	if m.GetUserLoginAttemptsFunc != nil {
+		return m.GetUserLoginAttemptsFunc(ctx, userID)
+	}
+	return sqlc.UserLoginAttempt{}, pgx.ErrNoRows
+}
+
+func (m *MockUserRepository) RecordFailedLogin(ctx context.Context, params sqlc.RecordFailedLoginParams) (sqlc.UserLoginAttempt, error) {
+	if m.RecordFailedLoginFunc != nil {
+		return m.RecordFailedLoginFunc(ctx, params)
+	}
+	return sqlc.UserLoginAttempt{UserID: params.UserID, FailedAttempts: 1}, nil
+}
+
+func (m *MockUserRepository) LockUserLogin(ctx context.Context, params sqlc.LockUserLoginParams) error {
+	if m.LockUserLoginFunc != nil {
+		return m.LockUserLoginFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) ResetFailedLogins(ctx context.Context, userID pgtype.UUID) error {
+	if m.ResetFailedLoginsFunc != nil {
+		return m.ResetFailedLoginsFunc(ctx, userID)
+	}
+	return nil
+}
+
 var _ repository.UserRepository = (*MockUserRepository)(nil)
 
 // ============================================================================
@@ -50,9 +98,10 @@ var _ repository.UserRepository = (*MockUserRepository)(nil)
 // ============================================================================
 
 type MockUserService struct {
-	CreateUserFunc       func(ctx context.Context, name, email, password string) (sqlc.User, error)
-	AuthenticateUserFunc func(ctx context.Context, email, password string) (sqlc.User, error)
-	GetUserFunc          func(ctx context.Context, id pgtype.UUID) (sqlc.User, error)
+	CreateUserFunc                func(ctx context.Context, name, email, password string) (sqlc.User, error)
+	CreateUserWithoutPasswordFunc func(ctx context.Context, name, email string) (sqlc.User, error)
+	AuthenticateUserFunc          func(ctx context.Context, email, password string) (sqlc.User, error)
+	GetUserFunc                   func(ctx context.Context, id pgtype.UUID) (sqlc.User, error)
 }
 
 func (m *MockUserService) CreateUser(ctx context.Context, name, email, password string) (sqlc.User, error) {
@@ -62,6 +111,13 @@ func (m *MockUserService) CreateUser(ctx context.Context, name, email, password
 	return sqlc.User{}, nil
 }
 
+func (m *MockUserService) CreateUserWithoutPassword(ctx context.Context, name, email string) (sqlc.User, error) {
+	if m.CreateUserWithoutPasswordFunc != nil {
+		return m.CreateUserWithoutPasswordFunc(ctx, name, email)
+	}
+	return sqlc.User{}, nil
+}
+
 func (m *MockUserService) GetUser(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
 	if m.GetUserFunc != nil {
 		return m.GetUserFunc(ctx, id)
@@ -81,16 +137,24 @@ func (m *MockUserService) AuthenticateUser(ctx context.Context, email, password
 // ============================================================================
 
 type MockGroupRepository struct {
-	BeginTxFunc                 func(ctx context.Context) (pgx.Tx, error)
-	WithTxFunc                  func(tx pgx.Tx) repository.GroupRepository
-	CreateGroupFunc             func(ctx context.Context, params sqlc.CreateGroupParams) (sqlc.Group, error)
-	GetGroupByIDFunc            func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error)
-	CreateGroupMemberFunc       func(ctx context.Context, params sqlc.CreateGroupMemberParams) (sqlc.GroupMember, error)
-	GetGroupMemberFunc          func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error)
-	UpdateGroupMemberStatusFunc func(ctx context.Context, params sqlc.UpdateGroupMemberStatusParams) (sqlc.GroupMember, error)
-	ListGroupMembersFunc        func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListGroupMembersRow, error)
-	GetGroupsByUserIDFunc       func(ctx context.Context, userID pgtype.UUID) ([]sqlc.GetGroupsByUserIDRow, error)
-	GetUserByIDFunc             func(ctx context.Context, id pgtype.UUID) (sqlc.User, error)
+	BeginTxFunc                     func(ctx context.Context) (pgx.Tx, error)
+	WithTxFunc                      func(tx pgx.Tx) repository.GroupRepository
+	CreateGroupFunc                 func(ctx context.Context, params sqlc.CreateGroupParams) (sqlc.Group, error)
+	GetGroupByIDFunc                func(ctx context.Context, id pgtype.UUID) (sqlc.Group, error)
+	UpdateGroupFunc                 func(ctx context.Context, params sqlc.UpdateGroupParams) (sqlc.Group, error)
+	ArchiveGroupFunc                func(ctx context.Context, params sqlc.ArchiveGroupParams) (sqlc.Group, error)
+	RestoreGroupFunc                func(ctx context.Context, params sqlc.RestoreGroupParams) (sqlc.Group, error)
+	DeleteGroupFunc                 func(ctx context.Context, params sqlc.DeleteGroupParams) error
+	CountGroupExpensesFunc          func(ctx context.Context, groupID pgtype.UUID) (int64, error)
+	GetGroupBalancesWithPendingFunc func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GetGroupBalancesWithPendingRow, error)
+	CreateGroupMemberFunc           func(ctx context.Context, params sqlc.CreateGroupMemberParams) (sqlc.GroupMember, error)
+	GetGroupMemberFunc              func(ctx context.Context, params sqlc.GetGroupMemberParams) (sqlc.GroupMember, error)
+	UpdateGroupMemberStatusFunc     func(ctx context.Context, params sqlc.UpdateGroupMemberStatusParams) (sqlc.GroupMember, error)
+	UpdateGroupMemberRoleFunc       func(ctx context.Context, params sqlc.UpdateGroupMemberRoleParams) (sqlc.GroupMember, error)
+	GetUserBalanceInGroupFunc       func(ctx context.Context, params sqlc.GetUserBalanceInGroupParams) (sqlc.GetUserBalanceInGroupRow, error)
+	ListGroupMembersFunc            func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListGroupMembersRow, error)
+	GetGroupsByUserIDFunc           func(ctx context.Context, userID pgtype.UUID) ([]sqlc.GetGroupsByUserIDRow, error)
+	GetUserByIDFunc                 func(ctx context.Context, id pgtype.UUID) (sqlc.User, error)
 }
 
 func (m *MockGroupRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
@@ -121,6 +185,48 @@ func (m *MockGroupRepository) GetGroupByID(ctx context.Context, id pgtype.UUID)
 	return sqlc.Group{}, nil
 }
 
+func (m *MockGroupRepository) UpdateGroup(ctx context.Context, params sqlc.UpdateGroupParams) (sqlc.Group, error) {
+	if m.UpdateGroupFunc != nil {
+		return m.UpdateGroupFunc(ctx, params)
+	}
+	return sqlc.Group{}, nil
+}
+
+func (m *MockGroupRepository) ArchiveGroup(ctx context.Context, params sqlc.ArchiveGroupParams) (sqlc.Group, error) {
+	if m.ArchiveGroupFunc != nil {
+		return m.ArchiveGroupFunc(ctx, params)
+	}
+	return sqlc.Group{}, nil
+}
+
+func (m *MockGroupRepository) RestoreGroup(ctx context.Context, params sqlc.RestoreGroupParams) (sqlc.Group, error) {
+	if m.RestoreGroupFunc != nil {
+		return m.RestoreGroupFunc(ctx, params)
+	}
+	return sqlc.Group{}, nil
+}
+
+func (m *MockGroupRepository) DeleteGroup(ctx context.Context, params sqlc.DeleteGroupParams) error {
+	if m.DeleteGroupFunc != nil {
+		return m.DeleteGroupFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *MockGroupRepository) CountGroupExpenses(ctx context.Context, groupID pgtype.UUID) (int64, error) {
+	if m.CountGroupExpensesFunc != nil {
+		return m.CountGroupExpensesFunc(ctx, groupID)
+	}
+	return 0, nil
+}
+
+func (m *MockGroupRepository) GetGroupBalancesWithPending(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GetGroupBalancesWithPendingRow, error) {
+	if m.GetGroupBalancesWithPendingFunc != nil {
+		return m.GetGroupBalancesWithPendingFunc(ctx, groupID)
+	}
+	return []sqlc.GetGroupBalancesWithPendingRow{}, nil
+}
+
 func (m *MockGroupRepository) CreateGroupMember(ctx context.Context, params sqlc.CreateGroupMemberParams) (sqlc.GroupMember, error) {
 	if m.CreateGroupMemberFunc != nil {
 		return m.CreateGroupMemberFunc(ctx, params)
@@ -142,6 +248,20 @@ func (m *MockGroupRepository) UpdateGroupMemberStatus(ctx context.Context, param
 	return sqlc.GroupMember{}, nil
 }
 
+func (m *MockGroupRepository) UpdateGroupMemberRole(ctx context.Context, params sqlc.UpdateGroupMemberRoleParams) (sqlc.GroupMember, error) {
+	if m.UpdateGroupMemberRoleFunc != nil {
+		return m.UpdateGroupMemberRoleFunc(ctx, params)
+	}
+	return sqlc.GroupMember{}, nil
+}
+
+func (m *MockGroupRepository) GetUserBalanceInGroup(ctx context.Context, params sqlc.GetUserBalanceInGroupParams) (sqlc.GetUserBalanceInGroupRow, error) {
+	if m.GetUserBalanceInGroupFunc != nil {
+		return m.GetUserBalanceInGroupFunc(ctx, params)
+	}
+	return sqlc.GetUserBalanceInGroupRow{Balance: "0"}, nil
+}
+
 func (m *MockGroupRepository) ListGroupMembers(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListGroupMembersRow, error) {
 	if m.ListGroupMembersFunc != nil {
 		return m.ListGroupMembersFunc(ctx, groupID)
@@ -237,6 +357,13 @@ type MockGroupInvitationRepository struct {
 	UpdateInvitationStatusFunc       func(ctx context.Context, params sqlc.UpdateInvitationStatusParams) (sqlc.GroupInvitation, error)
 	ListInvitationsByGroupFunc       func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.ListInvitationsByGroupRow, error)
 	GetPendingInvitationsByEmailFunc func(ctx context.Context, email string) ([]sqlc.GetPendingInvitationsByEmailRow, error)
+	RenewInvitationFunc              func(ctx context.Context, params sqlc.RenewInvitationParams) (sqlc.GroupInvitation, error)
+	CreateJoinLinkFunc               func(ctx context.Context, params sqlc.CreateJoinLinkParams) (sqlc.GroupJoinLink, error)
+	GetJoinLinkByIDFunc              func(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error)
+	GetJoinLinkByTokenFunc           func(ctx context.Context, token string) (sqlc.GetJoinLinkByTokenRow, error)
+	ListJoinLinksByGroupFunc         func(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupJoinLink, error)
+	ConsumeJoinLinkFunc              func(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error)
+	RevokeJoinLinkFunc               func(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error)
 }
 
 func (m *MockGroupInvitationRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
@@ -295,6 +422,56 @@ func (m *MockGroupInvitationRepository) GetPendingInvitationsByEmail(ctx context
 	return []sqlc.GetPendingInvitationsByEmailRow{}, nil
 }
 
+func (m *MockGroupInvitationRepository) RenewInvitation(ctx context.Context, params sqlc.RenewInvitationParams) (sqlc.GroupInvitation, error) {
+	if m.RenewInvitationFunc != nil {
+		return m.RenewInvitationFunc(ctx, params)
+	}
+	return sqlc.GroupInvitation{}, nil
+}
+
+func (m *MockGroupInvitationRepository) CreateJoinLink(ctx context.Context, params sqlc.CreateJoinLinkParams) (sqlc.GroupJoinLink, error) {
+	if m.CreateJoinLinkFunc != nil {
+		return m.CreateJoinLinkFunc(ctx, params)
+	}
+	return sqlc.GroupJoinLink{}, nil
+}
+
+func (m *MockGroupInvitationRepository) GetJoinLinkByID(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error) {
+	if m.GetJoinLinkByIDFunc != nil {
+		return m.GetJoinLinkByIDFunc(ctx, id)
+	}
+	return sqlc.GroupJoinLink{}, pgx.ErrNoRows
+}
+
+// GetJoinLinkByToken defaults to not found, so tokens only resolve to invitations unless a test opts in.
+func (m *MockGroupInvitationRepository) GetJoinLinkByToken(ctx context.Context, token string) (sqlc.GetJoinLinkByTokenRow, error) {
+	if m.GetJoinLinkByTokenFunc != nil {
+		return m.GetJoinLinkByTokenFunc(ctx, token)
+	}
+	return sqlc.GetJoinLinkByTokenRow{}, pgx.ErrNoRows
+}
+
+func (m *MockGroupInvitationRepository) ListJoinLinksByGroup(ctx context.Context, groupID pgtype.UUID) ([]sqlc.GroupJoinLink, error) {
+	if m.ListJoinLinksByGroupFunc != nil {
+		return m.ListJoinLinksByGroupFunc(ctx, groupID)
+	}
+	return []sqlc.GroupJoinLink{}, nil
+}
+
+func (m *MockGroupInvitationRepository) ConsumeJoinLink(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error) {
+	if m.ConsumeJoinLinkFunc != nil {
+		return m.ConsumeJoinLinkFunc(ctx, id)
+	}
+	return sqlc.GroupJoinLink{ID: id}, nil
+}
+
+func (m *MockGroupInvitationRepository) RevokeJoinLink(ctx context.Context, id pgtype.UUID) (sqlc.GroupJoinLink, error) {
+	if m.RevokeJoinLinkFunc != nil {
+		return m.RevokeJoinLinkFunc(ctx, id)
+	}
+	return sqlc.GroupJoinLink{ID: id}, nil
+}
+
 var _ repository.GroupInvitationRepository = (*MockGroupInvitationRepository)(nil)
 
 // ============================================================================
@@ -306,6 +483,7 @@ type MockPendingUserRepository struct {
 	WithTxFunc                             func(tx pgx.Tx) repository.PendingUserRepository
 	CreatePendingUserFunc                  func(ctx context.Context, params sqlc.CreatePendingUserParams) (sqlc.PendingUser, error)
 	GetPendingUserByEmailFunc              func(ctx context.Context, email string) (sqlc.PendingUser, error)
+	GetGroupPendingUserByEmailFunc         func(ctx context.Context, params sqlc.GetGroupPendingUserByEmailParams) (sqlc.PendingUser, error)
 	GetPendingUserByIDFunc                 func(ctx context.Context, id pgtype.UUID) (sqlc.PendingUser, error)
 	UpdatePendingPaymentUserIDFunc         func(ctx context.Context, params sqlc.UpdatePendingPaymentUserIDParams) error
 	UpdatePendingSplitUserIDFunc           func(ctx context.Context, params sqlc.UpdatePendingSplitUserIDParams) error
@@ -342,6 +520,13 @@ func (m *MockPendingUserRepository) GetPendingUserByEmail(ctx context.Context, e
 	return sqlc.PendingUser{}, nil
 }
 
+func (m *MockPendingUserRepository) GetGroupPendingUserByEmail(ctx context.Context, params sqlc.GetGroupPendingUserByEmailParams) (sqlc.PendingUser, error) {
+	if m.GetGroupPendingUserByEmailFunc != nil {
+		return m.GetGroupPendingUserByEmailFunc(ctx, params)
+	}
+	return sqlc.PendingUser{}, nil
+}
+
 func (m *MockPendingUserRepository) GetPendingUserByID(ctx context.Context, id pgtype.UUID) (sqlc.PendingUser, error) {
 	if m.GetPendingUserByIDFunc != nil {
 		return m.GetPendingUserByIDFunc(ctx, id)
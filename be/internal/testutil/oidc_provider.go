@@ -0,0 +1,197 @@
+package testutil
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ============================================================================
+// MockOIDCProvider
+// ============================================================================
+
+// MockOIDCUser is the account a user signs in with at the MockOIDCProvider.
+type MockOIDCUser struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type mockOIDCGrant struct {
+	user          MockOIDCUser
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// MockOIDCProvider is a local OpenID Connect provider for tests. It serves
+// discovery, a key set and a token endpoint that checks PKCE, and signs ID
+// tokens with a throwaway RSA key.
+type MockOIDCProvider struct {
+	Server   *httptest.Server
+	ClientID string
+
+	key    *rsa.PrivateKey
+	mu     sync.Mutex
+	grants map[string]mockOIDCGrant
+}
+
+const mockOIDCKeyID = "test-key"
+
+func NewMockOIDCProvider(clientID string) *MockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	p := &MockOIDCProvider{
+		ClientID: clientID,
+		key:      key,
+		grants:   map[string]mockOIDCGrant{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("/jwks", p.handleJWKS)
+	mux.HandleFunc("/token", p.handleToken)
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *MockOIDCProvider) Issuer() string {
+	return p.Server.URL
+}
+
+func (p *MockOIDCProvider) Close() {
+	p.Server.Close()
+}
+
+// Authorize plays the user signing in at the provider: it checks the
+// authorization URL the app built and returns the code and state the provider
+// would redirect back with.
+func (p *MockOIDCProvider) Authorize(authorizationURL string, user MockOIDCUser) (code, state string, err error) {
+	u, err := url.Parse(authorizationURL)
+	if err != nil {
+		return "", "", err
+	}
+	query := u.Query()
+
+	switch {
+	case query.Get("response_type") != "code":
+		return "", "", errors.New("response_type must be code")
+	case query.Get("client_id") != p.ClientID:
+		return "", "", errors.New("unknown client_id")
+	case query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256":
+		return "", "", errors.New("PKCE with S256 is required")
+	case query.Get("state") == "" || query.Get("nonce") == "":
+		return "", "", errors.New("state and nonce are required")
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	code = hex.EncodeToString(b)
+
+	p.mu.Lock()
+	p.grants[code] = mockOIDCGrant{
+		user:          user,
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	p.mu.Unlock()
+
+	return code, query.Get("state"), nil
+}
+
+func (p *MockOIDCProvider) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeMockOIDCJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 p.Issuer(),
+		"authorization_endpoint": p.Issuer() + "/authorize",
+		"token_endpoint":         p.Issuer() + "/token",
+		"jwks_uri":               p.Issuer() + "/jwks",
+	})
+}
+
+func (p *MockOIDCProvider) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	writeMockOIDCJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": mockOIDCKeyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *MockOIDCProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeMockOIDCJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	// Codes are single use
+	p.mu.Lock()
+	grant, ok := p.grants[r.PostForm.Get("code")]
+	delete(p.grants, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case !ok,
+		r.PostForm.Get("client_id") != p.ClientID,
+		r.PostForm.Get("redirect_uri") != grant.redirectURI,
+		base64.RawURLEncoding.EncodeToString(sum[:]) != grant.codeChallenge:
+		writeMockOIDCJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            p.Issuer(),
+		"aud":            p.ClientID,
+		"sub":            grant.user.Subject,
+		"email":          grant.user.Email,
+		"email_verified": grant.user.EmailVerified,
+		"name":           grant.user.Name,
+		"nonce":          grant.nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+	})
+	token.Header["kid"] = mockOIDCKeyID
+
+	idToken, err := token.SignedString(p.key)
+	if err != nil {
+		writeMockOIDCJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeMockOIDCJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "mock-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func writeMockOIDCJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -11,6 +11,16 @@ type LoginResponse = {
   expires_in: number
 }
 
+type TwoFactorChallengeResponse = {
+  two_factor_required: true
+  challenge_token: string
+  expires_in: number
+}
+
+export type LoginResult =
+  | { status: 'signed_in' }
+  | { status: 'two_factor_required'; challengeToken: string }
+
 type CreateUserResponse = {
   id: string
   name: string
@@ -18,13 +28,40 @@ type CreateUserResponse = {
   created_at: string
 }
 
-export async function login(email: string, password: string) {
-  const response = await apiRequest<LoginResponse>('/auth/login', {
+export async function login(
+  email: string,
+  password: string,
+): Promise<LoginResult> {
+  const response = await apiRequest<
+    LoginResponse | TwoFactorChallengeResponse
+  >('/auth/login', {
     method: 'POST',
     body: JSON.stringify({ email, password }),
     retryOnAuthFail: false,
   })
 
+  // Accounts with two-factor authentication get tokens from verifyTwoFactorLogin
+  if ('two_factor_required' in response) {
+    return {
+      status: 'two_factor_required',
+      challengeToken: response.challenge_token,
+    }
+  }
+
+  setSessionTokens(response.access_token, response.refresh_token)
+  return { status: 'signed_in' }
+}
+
+export async function verifyTwoFactorLogin(
+  challengeToken: string,
+  code: string,
+) {
+  const response = await apiRequest<LoginResponse>('/auth/two-factor/verify', {
+    method: 'POST',
+    body: JSON.stringify({ challenge_token: challengeToken, code }),
+    retryOnAuthFail: false,
+  })
+
   setSessionTokens(response.access_token, response.refresh_token)
   return response
 }
@@ -89,14 +89,26 @@ async function tryRefreshAccessToken() {
         }),
       })
 
-      const envelope = await parseEnvelope<{ access_token: string }>(response)
+      const envelope = await parseEnvelope<{
+        access_token: string
+        refresh_token?: string
+      }>(response)
 
       if (!response.ok || !envelope?.status || !envelope.data?.access_token) {
+        // Another tab may have rotated the refresh token while this request was in flight
+        const latestRefreshToken = getRefreshToken()
+        if (latestRefreshToken && latestRefreshToken !== refreshToken) {
+          return true
+        }
         clearSessionTokens()
         return false
       }
 
-      setSessionTokens(envelope.data.access_token, refreshToken)
+      // Refresh tokens are single use; the response carries the replacement
+      setSessionTokens(
+        envelope.data.access_token,
+        envelope.data.refresh_token ?? refreshToken,
+      )
       return true
     } catch {
       clearSessionTokens()
@@ -1,10 +1,83 @@
 const errorMessagesByCode: Record<string, string> = {
   'auth.credentials.invalid': 'Email or password is incorrect.',
+  'auth.account.locked':
+    'Too many failed sign-in attempts. Please wait a few minutes and try again.',
+  'rate_limit.exceeded': 'Too many attempts. Please wait a moment and try again.',
   'auth.refresh_token.invalid': 'Your session expired. Please sign in again.',
+  'auth.refresh_token.reused':
+    'You were signed out for your security. Please sign in again.',
   'auth.token.expired': 'Your session expired. Please sign in again.',
   'auth.token.invalid': 'Please sign in again to continue.',
   'auth.token.revoked':
     'Your session is no longer valid. Please sign in again.',
+  'auth.oidc.state_invalid': 'This sign-in link expired. Please try again.',
+  'auth.oidc.rejected':
+    'The sign-in provider could not confirm your identity. Please try again.',
+  'auth.oidc.email_unverified':
+    'Your email address is not verified with this provider.',
+  'conflict.oidc.account_unverified':
+    'An account with this email already exists. Sign in with your password and verify your email first.',
+  'resource.oidc_provider.not_found': 'This sign-in option is not available.',
+  'system.oidc.provider_unavailable':
+    'The sign-in provider is unavailable right now. Please try again later.',
+  'auth.two_factor.code_invalid': 'That code is not valid. Please try again.',
+  'auth.two_factor.challenge_invalid':
+    'Your sign-in expired. Please sign in again.',
+  'auth.two_factor.locked':
+    'Too many invalid codes. Please try again later.',
+  'validation.two_factor.code_invalid':
+    'That code is not valid. Please try again.',
+  'conflict.two_factor.already_enabled':
+    'Two-factor authentication is already turned on.',
+  'conflict.two_factor.not_enrolled': 'Start two-factor setup first.',
+  'conflict.two_factor.not_enabled':
+    'Two-factor authentication is not turned on.',
+  'auth.token.session_required':
+    'Access tokens cannot be used here. Please sign in instead.',
+  'auth.token.scope_insufficient':
+    'This access token does not allow that action.',
+  'validation.token.name.required': 'Please name the access token.',
+  'validation.token.scopes.required': 'Choose at least one permission.',
+  'validation.token.scopes_invalid': 'Choose at least one valid permission.',
+  'validation.token.expiry_invalid': 'Access tokens can last at most 365 days.',
+  'conflict.token.limit_reached':
+    'You have too many access tokens. Revoke one before creating another.',
+  'resource.token.not_found': 'This access token no longer exists.',
+  'validation.webhook.url.required': 'Please enter the webhook URL.',
+  'validation.webhook.url.url': 'Please enter a valid URL.',
+  'validation.webhook.url_invalid': 'Webhook URLs must start with http or https.',
+  'validation.webhook.events_invalid': 'Choose valid webhook events.',
+  'validation.webhook.secret.min':
+    'Webhook secrets must be at least 16 characters.',
+  'validation.webhook.secret_invalid':
+    'Webhook secrets must be at least 16 characters.',
+  'conflict.webhook.limit_reached':
+    'This group has too many webhooks. Delete one before adding another.',
+  'resource.webhook.not_found': 'This webhook no longer exists.',
+  'resource.webhook.delivery_not_found': 'This delivery no longer exists.',
+  'validation.budget.name.required': 'Please enter a budget name.',
+  'validation.budget.name_invalid': 'Please enter a budget name.',
+  'validation.budget.amount.required': 'Please enter the budget amount.',
+  'validation.budget.amount_invalid':
+    'Budget amounts must be positive, with at most 2 decimals.',
+  'validation.budget.period.oneof': 'Choose weekly, monthly or custom.',
+  'validation.budget.period_invalid': 'Choose weekly, monthly or custom.',
+  'validation.budget.dates_invalid':
+    'Custom budgets need a start date on or before their end date.',
+  'validation.budget.thresholds_invalid':
+    'Use up to 5 alert thresholds between 1% and 1000%.',
+  'validation.budget.category_invalid': 'Choose a category from this group.',
+  'conflict.budget.limit_reached':
+    'This group has too many budgets. Delete one before adding another.',
+  'resource.budget.not_found': 'This budget no longer exists.',
+  'permission.group.role_required':
+    'Only group owners and admins can do that.',
+  'validation.stream.last_event_id_invalid':
+    'Live updates could not resume. Please reload the page.',
+  'validation.notification.category.oneof': 'Choose a valid notification category.',
+  'validation.notification.category_invalid':
+    'Choose a valid notification category.',
+  'resource.notification.not_found': 'This notification no longer exists.',
   'auth.authorization.missing_header': 'Please sign in to continue.',
   'auth.authorization.invalid_format': 'Please sign in to continue.',
   'auth.authorization.unauthorized': 'Please sign in to continue.',
@@ -21,6 +94,7 @@ const errorMessagesByCode: Record<string, string> = {
   'validation.group.name.required': 'Please enter a group name.',
   'validation.group.name.invalid': 'Please enter a valid group name.',
   'validation.group.group_id.invalid': 'The group link is invalid.',
+  'validation.group.settings.reminders_invalid': 'Reminder settings are invalid. Use a non-negative amount and 1-365 days between reminders.',
   'validation.invitation.email.required': 'Please enter an email to invite.',
   'validation.invitation.email.email': 'Please enter a valid email to invite.',
   'validation.invitation.group_id.invalid': 'The group link is invalid.',
@@ -43,8 +43,14 @@ type RawCreateGroupResponse = {
 }
 
 type RawCreateInvitationResponse = {
-  message: string
-  token: string
+  id: string
+  group_id: string
+  email: string
+  role: string
+  status: string
+  invited_by: string
+  expires_at: string
+  created_at: string
 }
 
 export type UserGroup = {
@@ -85,8 +91,11 @@ export type CreatedGroup = {
 }
 
 export type CreateInvitationResult = {
-  message: string
-  token: string
+  id: string
+  email: string
+  role: string
+  status: string
+  expiresAt: string
 }
 
 const USER_GROUPS_CACHE_TTL_MS = 20_000
@@ -271,7 +280,7 @@ export async function createGroupInvitation(
   groupId: string,
   input: { email: string; name?: string; role?: 'member' | 'admin' },
 ) {
-  const result = (await apiRequest<RawCreateInvitationResponse>(
+  const raw = await apiRequest<RawCreateInvitationResponse>(
     `/groups/${groupId}/invitations`,
     {
       method: 'POST',
@@ -282,8 +291,15 @@ export async function createGroupInvitation(
         role: input.role || 'member',
       }),
     },
-  )) as CreateInvitationResult
+  )
 
+  const result: CreateInvitationResult = {
+    id: raw.id,
+    email: raw.email,
+    role: raw.role,
+    status: raw.status,
+    expiresAt: raw.expires_at,
+  }
   invalidateGroupMembersCache(groupId)
   return result
 }
@@ -16,6 +16,7 @@ type RawInvitation = {
   invited_by: string | RawUUID
   inviter_name?: string
   inviter_email?: string
+  type?: 'invitation' | 'join_link'
 }
 
 export type Invitation = {
@@ -29,6 +30,7 @@ export type Invitation = {
   invitedBy: string
   inviterName?: string
   inviterEmail?: string
+  isJoinLink: boolean
 }
 
 function formatUUIDFromBytes(bytes: Array<number>) {
@@ -63,6 +65,7 @@ function mapInvitation(raw: RawInvitation): Invitation {
     invitedBy: parseUUID(raw.invited_by),
     inviterName: raw.inviter_name || undefined,
     inviterEmail: raw.inviter_email || undefined,
+    isJoinLink: raw.type === 'join_link',
   }
 }
 
@@ -83,12 +86,13 @@ export async function acceptInvitation(token: string) {
 
 export async function joinGroupViaInvitation(
   token: string,
-  input: { password: string; name?: string },
+  input: { password: string; name?: string; email?: string },
 ) {
   return apiRequest<{ message: string }>(`/invitations/${token}/join`, {
     method: 'POST',
     retryOnAuthFail: false,
     body: JSON.stringify({
+      email: input.email || '',
       password: input.password,
       name: input.name || '',
     }),
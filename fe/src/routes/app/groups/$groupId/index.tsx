@@ -503,16 +503,26 @@ function GroupDetailPage() {
         role: inviteRole,
       })
 
-      setInviteLink(buildInvitationLink(result.token))
       setInviteEmail('')
       setInviteName('')
       setInviteRole('member')
 
+      // The invitation is emailed; the link stays available as a fallback to share by hand
       const refreshedMembers = await listGroupMembers(groupId, { force: true })
       setMembers(refreshedMembers)
+      const invited = refreshedMembers.find(
+        (member) =>
+          member.status === 'pending' &&
+          member.user.email.toLowerCase() === result.email.toLowerCase(),
+      )
+      setInviteLink(
+        invited?.invitationToken
+          ? buildInvitationLink(invited.invitationToken)
+          : null,
+      )
     } catch (err) {
       setInviteError(
-        err instanceof Error ? err.message : 'Unable to create invite link.',
+        err instanceof Error ? err.message : 'Unable to send invite.',
       )
     } finally {
       setIsInviting(false)
@@ -992,7 +1002,7 @@ function GroupDetailPage() {
                     ) : null}
 
                     <Button type="submit" disabled={isInviting} className="w-full">
-                      {isInviting ? 'Creating invite...' : 'Create invite link'}
+                      {isInviting ? 'Sending invite...' : 'Send invite'}
                     </Button>
                   </form>
 
@@ -1311,6 +1321,28 @@ function summarizeActivity(activity: GroupActivity, fallbackCurrencyCode: string
     }
   }
 
+  if (activity.action === 'settlement_disputed') {
+    return {
+      title: 'Settlement disputed',
+      detail: asText(metadata.reason) || `${activity.entityType} updated`,
+    }
+  }
+
+  if (activity.action === 'budget_threshold_crossed' && asText(metadata.spent)) {
+    const threshold =
+      typeof metadata.threshold === 'number' ? metadata.threshold : null
+    return {
+      title: `Budget ${threshold !== null ? `${threshold}% ` : ''}reached: ${asText(metadata.name) || 'Budget'}`,
+      detail: `${formatExpenseAmount(
+        asText(metadata.spent) || '0',
+        fallbackCurrencyCode,
+      )} of ${formatExpenseAmount(
+        asText(metadata.amount) || '0',
+        fallbackCurrencyCode,
+      )}`,
+    }
+  }
+
   return {
     title: label,
     detail: `${activity.entityType} updated`,
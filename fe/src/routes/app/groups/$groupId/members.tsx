@@ -123,7 +123,8 @@ function GroupMembersPage() {
         name: inviteName,
         role: inviteRole,
       })
-      setInviteLink(buildInvitationLink(result.token))
+      setInviteLink(null)
+      showNotice('success', `Invitation emailed to ${result.email}`)
       setInviteEmail('')
       setInviteName('')
       setInviteRole('member')
@@ -132,7 +133,7 @@ function GroupMembersPage() {
           id: '',
           groupId,
           userId: '',
-          invitationToken: result.token,
+          invitationToken: '',
           role: optimisticRole,
           status: 'pending',
           invitedAt: new Date().toISOString(),
@@ -155,11 +156,20 @@ function GroupMembersPage() {
       void Promise.resolve(listGroupMembers(groupId, { force: true })).then(
         (updatedMembers) => {
           setMembers(updatedMembers)
+          // Offer the link as a fallback to share by hand
+          const invited = updatedMembers.find(
+            (member) =>
+              member.status === 'pending' &&
+              member.user.email.toLowerCase() === result.email.toLowerCase(),
+          )
+          if (invited?.invitationToken) {
+            setInviteLink(buildInvitationLink(invited.invitationToken))
+          }
         },
       )
     } catch (err) {
       setInviteError(
-        err instanceof Error ? err.message : 'Unable to create invite link.',
+        err instanceof Error ? err.message : 'Unable to send invite.',
       )
     } finally {
       setIsInviting(false)
@@ -337,7 +347,7 @@ function GroupMembersPage() {
             ) : null}
 
             <Button type="submit" disabled={isInviting} className="w-full">
-              {isInviting ? 'Creating invite...' : 'Create invite link'}
+              {isInviting ? 'Sending invite...' : 'Send invite'}
             </Button>
           </form>
 
@@ -32,6 +32,7 @@ function InviteTokenPage() {
   const [error, setError] = useState<string | null>(null)
   const [isSubmitting, setIsSubmitting] = useState(false)
   const [name, setName] = useState('')
+  const [email, setEmail] = useState('')
   const [password, setPassword] = useState('')
   const [successMessage, setSuccessMessage] = useState<string | null>(null)
 
@@ -96,8 +97,19 @@ function InviteTokenPage() {
     setSuccessMessage(null)
     setIsSubmitting(true)
     try {
-      await joinGroupViaInvitation(token, { name, password })
-      await login(invitation.email, password)
+      // Join links aren't tied to an email, so the visitor provides one
+      const accountEmail = invitation.isJoinLink ? email : invitation.email
+      await joinGroupViaInvitation(token, {
+        name,
+        password,
+        email: accountEmail,
+      })
+      const result = await login(accountEmail, password)
+      if (result.status === 'two_factor_required') {
+        // The second factor is asked for on the sign-in page
+        navigate({ to: '/login' })
+        return
+      }
       setSuccessMessage('Joined successfully. Redirecting to your group...')
       navigate({
         to: '/app/groups/$groupId',
@@ -159,6 +171,15 @@ function InviteTokenPage() {
                 </Button>
               ) : (
                 <form className="space-y-3" onSubmit={handleJoin}>
+                  {invitation.isJoinLink ? (
+                    <Input
+                      type="email"
+                      required
+                      placeholder="Email"
+                      value={email}
+                      onChange={(e) => setEmail(e.target.value)}
+                    />
+                  ) : null}
                   <Input
                     placeholder="Name (optional)"
                     value={name}
@@ -172,7 +193,9 @@ function InviteTokenPage() {
                     onChange={(e) => setPassword(e.target.value)}
                   />
                   <p className="text-xs text-muted-foreground">
-                    Set a password to accept this invitation.
+                    {invitation.isJoinLink
+                      ? 'Sign in with your password, or set one to create an account.'
+                      : 'Set a password to accept this invitation.'}
                   </p>
                   <Button
                     type="submit"
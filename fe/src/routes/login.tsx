@@ -16,7 +16,7 @@ import {
   CardTitle,
 } from '@/components/ui/card'
 import { Input } from '@/components/ui/input'
-import { login } from '@/lib/api/auth'
+import { login, verifyTwoFactorLogin } from '@/lib/api/auth'
 import { hasSession } from '@/lib/session'
 
 export const Route = createFileRoute('/login')({
@@ -33,6 +33,7 @@ function LoginPage() {
   const navigate = useNavigate()
   const [isSubmitting, setIsSubmitting] = useState(false)
   const [error, setError] = useState<string | null>(null)
+  const [challengeToken, setChallengeToken] = useState<string | null>(null)
 
   async function handleSubmit(e: FormEvent<HTMLFormElement>) {
     e.preventDefault()
@@ -45,7 +46,11 @@ function LoginPage() {
     setIsSubmitting(true)
 
     try {
-      await login(email, password)
+      const result = await login(email, password)
+      if (result.status === 'two_factor_required') {
+        setChallengeToken(result.challengeToken)
+        return
+      }
       navigate({ to: '/app' })
     } catch (err) {
       setError(err instanceof Error ? err.message : 'Unable to sign in')
@@ -54,6 +59,71 @@ function LoginPage() {
     }
   }
 
+  async function handleTwoFactorSubmit(e: FormEvent<HTMLFormElement>) {
+    e.preventDefault()
+    if (!challengeToken) return
+
+    const formData = new FormData(e.currentTarget)
+    const code = String(formData.get('code') || '')
+
+    setError(null)
+    setIsSubmitting(true)
+
+    try {
+      await verifyTwoFactorLogin(challengeToken, code)
+      navigate({ to: '/app' })
+    } catch (err) {
+      setError(err instanceof Error ? err.message : 'Unable to sign in')
+    } finally {
+      setIsSubmitting(false)
+    }
+  }
+
+  if (challengeToken) {
+    return (
+      <main className="mx-auto flex min-h-screen w-full max-w-md items-center px-6 py-10">
+        <Card className="w-full border-border/70 bg-card">
+          <CardHeader>
+            <CardTitle className="text-lg">Two-factor authentication</CardTitle>
+            <CardDescription>
+              Enter the code from your authenticator app, or one of your
+              recovery codes.
+            </CardDescription>
+          </CardHeader>
+          <CardContent>
+            <form className="space-y-3" onSubmit={handleTwoFactorSubmit}>
+              <Input
+                name="code"
+                required
+                autoFocus
+                autoComplete="one-time-code"
+                placeholder="123456"
+              />
+              {error ? (
+                <p className="text-xs text-destructive">{error}</p>
+              ) : null}
+              <Button type="submit" disabled={isSubmitting} className="w-full">
+                Verify
+              </Button>
+            </form>
+            <p className="mt-4 text-center text-xs text-muted-foreground">
+              <button
+                type="button"
+                className="text-foreground underline-offset-4 hover:underline"
+                onClick={() => {
+                  setChallengeToken(null)
+                  setError(null)
+                }}
+              >
+                Back to sign in
+              </button>
+            </p>
+          </CardContent>
+        </Card>
+      </main>
+    )
+  }
+
   return (
     <main className="mx-auto flex min-h-screen w-full max-w-md items-center px-6 py-10">
       <Card className="w-full border-border/70 bg-card">